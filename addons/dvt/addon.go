@@ -0,0 +1,83 @@
+package dvt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+type DistributedValidators struct {
+	cfg *DvtConfig `yaml:"config,omitempty"`
+}
+
+func NewDistributedValidators() addons.SmartnodeAddon {
+	return &DistributedValidators{
+		cfg: NewConfig(),
+	}
+}
+
+func (dv *DistributedValidators) GetName() string {
+	return "Distributed Validators"
+}
+
+func (dv *DistributedValidators) GetDescription() string {
+	return "This addon splits the validator key of each new minipool into a distributed validator cluster, using either Obol (Charon) or SSV, so the validator is run by several operators instead of only your own Validator Client.\n\nThe split keys are kept in the `dvt` folder of your data directory. Once a minipool has been staked, its key is moved out of your Validator Client so it's only ever run by the cluster; you're responsible for getting the split keys to the cluster's operators before then."
+}
+
+func (dv *DistributedValidators) GetConfig() cfgtypes.Config {
+	return dv.cfg
+}
+
+// The clusters run on other machines, so the addon doesn't run a container of its own
+func (dv *DistributedValidators) GetContainerName() string {
+	return ""
+}
+
+func (dv *DistributedValidators) GetContainerTag() string {
+	return ""
+}
+
+func (dv *DistributedValidators) GetEnabledParameter() *cfgtypes.Parameter {
+	return &dv.cfg.Enabled
+}
+
+func (dv *DistributedValidators) UpdateEnvVars(envVars map[string]string) error {
+	return nil
+}
+
+// Get the selected provider
+func (cfg *DvtConfig) GetProvider() Provider {
+	return cfg.Provider.Value.(Provider)
+}
+
+// Get the health check URLs of the cluster's nodes
+func (cfg *DvtConfig) GetHealthUrls() []string {
+	return splitList(cfg.HealthUrls.Value.(string))
+}
+
+// Get the IDs and public keys of the SSV operators, checking that there's a key for each ID
+func (cfg *DvtConfig) GetSsvOperators() ([]string, []string, error) {
+	ids := splitList(cfg.SsvOperatorIds.Value.(string))
+	keys := splitList(cfg.SsvOperatorKeys.Value.(string))
+	if len(ids) < 4 {
+		return nil, nil, fmt.Errorf("SSV needs at least 4 operators but %d operator IDs are configured", len(ids))
+	}
+	if len(ids) != len(keys) {
+		return nil, nil, fmt.Errorf("%d SSV operator IDs are configured but there are %d operator keys", len(ids), len(keys))
+	}
+	return ids, keys, nil
+}
+
+// Split a comma-separated setting into its trimmed, non-empty entries
+func splitList(value string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,169 @@
+package dvt
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The distributed validator technologies a minipool's key can be split for
+type Provider string
+
+const (
+	Provider_Obol Provider = "obol"
+	Provider_Ssv  Provider = "ssv"
+)
+
+// Configuration for distributed validators
+type DvtConfig struct {
+	Title string `yaml:"-"`
+
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	Provider config.Parameter `yaml:"provider,omitempty"`
+
+	// The number of Charon nodes in each Obol cluster
+	ClusterSize config.Parameter `yaml:"clusterSize,omitempty"`
+
+	// The SSV operators each validator's shares are encrypted for
+	SsvOperatorIds config.Parameter `yaml:"ssvOperatorIds,omitempty"`
+
+	SsvOperatorKeys config.Parameter `yaml:"ssvOperatorKeys,omitempty"`
+
+	// The paths of the key splitting tools
+	CharonPath config.Parameter `yaml:"charonPath,omitempty"`
+
+	SsvKeysPath config.Parameter `yaml:"ssvKeysPath,omitempty"`
+
+	// The health endpoints of the cluster's nodes
+	HealthUrls config.Parameter `yaml:"healthUrls,omitempty"`
+}
+
+// Creates a new configuration instance
+func NewConfig() *DvtConfig {
+	return &DvtConfig{
+		Title: "Distributed Validator Settings",
+
+		Enabled: config.Parameter{
+			ID:                   "enabled",
+			Name:                 "Enabled",
+			Description:          "Split the validator key of each new minipool into a distributed validator cluster, and stop your own Validator Client from using it once the minipool has been staked.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Provider: config.Parameter{
+			ID:                   "provider",
+			Name:                 "Provider",
+			Description:          "The distributed validator technology the keys are split for.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: Provider_Obol},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Obol",
+				Description: "Split the key into an Obol cluster with `charon create cluster --split-existing-keys`. The cluster definition and each node's keys are written to a folder per validator for you to hand to the cluster's operators.",
+				Value:       Provider_Obol,
+			}, {
+				Name:        "SSV",
+				Description: "Split the key into SSV key shares for the configured operators with `ssv-keys`. The key shares file has to be registered with the SSV Network contract before the operators will run the validator.",
+				Value:       Provider_Ssv,
+			}},
+		},
+
+		ClusterSize: config.Parameter{
+			ID:                   "clusterSize",
+			Name:                 "Cluster Size",
+			Description:          "The number of Charon nodes in each Obol cluster. Charon uses a threshold of two thirds of them, rounded up.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(4)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvOperatorIds: config.Parameter{
+			ID:                   "ssvOperatorIds",
+			Name:                 "SSV Operator IDs",
+			Description:          "A comma-separated list of the IDs of the SSV operators to split the keys between, such as `1,2,3,4`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvOperatorKeys: config.Parameter{
+			ID:                   "ssvOperatorKeys",
+			Name:                 "SSV Operator Keys",
+			Description:          "A comma-separated list of the public keys of the SSV operators, in the same order as their IDs.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CharonPath: config.Parameter{
+			ID:                   "charonPath",
+			Name:                 "Charon Path",
+			Description:          "The path of the `charon` binary used to split keys for Obol clusters. It must be available to the node process.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "charon"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvKeysPath: config.Parameter{
+			ID:                   "ssvKeysPath",
+			Name:                 "ssv-keys Path",
+			Description:          "The path of the `ssv-keys` binary used to split keys into SSV key shares. It must be available to the node process. The keystore password is given to it on stdin rather than with `--password`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "ssv-keys"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HealthUrls: config.Parameter{
+			ID:                   "healthUrls",
+			Name:                 "Health URLs",
+			Description:          "A comma-separated list of the health check URLs of the cluster's nodes, such as Charon's `http://<host>:3620/readyz` or an SSV node's `http://<host>:16000/v1/node/health`. Each one is reported as healthy when it responds with a 200 status.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *DvtConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Provider,
+		&cfg.ClusterSize,
+		&cfg.SsvOperatorIds,
+		&cfg.SsvOperatorKeys,
+		&cfg.CharonPath,
+		&cfg.SsvKeysPath,
+		&cfg.HealthUrls,
+	}
+}
+
+// The the title for the config
+func (cfg *DvtConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -0,0 +1,92 @@
+package rescue_node
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+type RescueNode struct {
+	cfg *RescueNodeConfig `yaml:"config,omitempty"`
+}
+
+func NewRescueNode() addons.SmartnodeAddon {
+	return &RescueNode{
+		cfg: NewConfig(),
+	}
+}
+
+func (rn *RescueNode) GetName() string {
+	return "Rescue Node"
+}
+
+func (rn *RescueNode) GetDescription() string {
+	return "This addon temporarily points your Validator Client at the Rescue Node (https://rescuenode.com), a community-run set of Beacon Nodes you can use while your own is down or resyncing, so you keep attesting in the meantime.\n\nUse `rocketpool node attach-rescue` to get a credential and connect to it; your Validator Client goes back to your own Beacon Node when the credential expires."
+}
+
+func (rn *RescueNode) GetConfig() cfgtypes.Config {
+	return rn.cfg
+}
+
+// The Rescue Node doesn't run a container of its own, it only changes where the Validator Client connects to
+func (rn *RescueNode) GetContainerName() string {
+	return ""
+}
+
+func (rn *RescueNode) GetContainerTag() string {
+	return ""
+}
+
+func (rn *RescueNode) GetEnabledParameter() *cfgtypes.Parameter {
+	return &rn.cfg.Enabled
+}
+
+func (rn *RescueNode) UpdateEnvVars(envVars map[string]string) error {
+	if !rn.cfg.IsActive(time.Now()) {
+		return nil
+	}
+
+	client := cfgtypes.ConsensusClient(envVars["CC_CLIENT"])
+	endpoint, err := rn.cfg.GetApiEndpoint(client)
+	if err != nil {
+		return err
+	}
+	envVars["CC_API_ENDPOINT"] = endpoint
+	cfgtypes.AddParametersToEnvVars([]*cfgtypes.Parameter{&rn.cfg.Enabled}, envVars)
+	return nil
+}
+
+// Check if the Validator Client should be using the Rescue Node at the given time
+func (cfg *RescueNodeConfig) IsActive(now time.Time) bool {
+	if cfg.Enabled.Value != true {
+		return false
+	}
+	return now.Before(cfg.GetExpiration())
+}
+
+// Get the time the rescue window closes
+func (cfg *RescueNodeConfig) GetExpiration() time.Time {
+	return time.Unix(int64(cfg.Expiration.Value.(uint64)), 0)
+}
+
+// Get the Rescue Node's Beacon API endpoint for the given Consensus Client, including the credential
+func (cfg *RescueNodeConfig) GetApiEndpoint(client cfgtypes.ConsensusClient) (string, error) {
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse,
+		cfgtypes.ConsensusClient_Lodestar,
+		cfgtypes.ConsensusClient_Nimbus,
+		cfgtypes.ConsensusClient_Teku:
+	default:
+		return "", fmt.Errorf("the Rescue Node addon doesn't support the %s Validator Client", client)
+	}
+
+	endpoint := url.URL{
+		Scheme: "https",
+		User:   url.UserPassword(cfg.Username.Value.(string), cfg.Password.Value.(string)),
+		Host:   fmt.Sprintf("%s.%s", client, cfg.Domain.Value.(string)),
+	}
+	return endpoint.String(), nil
+}
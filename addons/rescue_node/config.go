@@ -0,0 +1,108 @@
+package rescue_node
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	defaultDomain string = "rescuenode.com"
+)
+
+// Configuration for the Rescue Node
+type RescueNodeConfig struct {
+	Title string `yaml:"-"`
+
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	Username config.Parameter `yaml:"username,omitempty"`
+
+	Password config.Parameter `yaml:"password,omitempty"`
+
+	Domain config.Parameter `yaml:"domain,omitempty"`
+
+	Expiration config.Parameter `yaml:"expiration,omitempty"`
+}
+
+// Creates a new configuration instance
+func NewConfig() *RescueNodeConfig {
+	return &RescueNodeConfig{
+		Title: "Rescue Node Settings",
+
+		Enabled: config.Parameter{
+			ID:                   "enabled",
+			Name:                 "Enabled",
+			Description:          "Connect your Validator Client to the Rescue Node instead of your own Beacon Node while the rescue window is open",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{"ADDON_RESCUE_NODE_ENABLED"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Username: config.Parameter{
+			ID:                   "username",
+			Name:                 "Username",
+			Description:          "The username of the credential issued by the Rescue Node",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Password: config.Parameter{
+			ID:                   "password",
+			Name:                 "Password",
+			Description:          "The password of the credential issued by the Rescue Node",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Domain: config.Parameter{
+			ID:                   "domain",
+			Name:                 "Domain",
+			Description:          "The domain of the Rescue Node. Each Consensus Client has its own host under it, such as `lighthouse.rescuenode.com`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultDomain},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Expiration: config.Parameter{
+			ID:                   "expiration",
+			Name:                 "Expiration",
+			Description:          "The time the rescue window closes, as a Unix timestamp. Once it's passed, the Validator Client goes back to your own Beacon Node.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *RescueNodeConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Username,
+		&cfg.Password,
+		&cfg.Domain,
+		&cfg.Expiration,
+	}
+}
+
+// The the title for the config
+func (cfg *RescueNodeConfig) GetConfigTitle() string {
+	return cfg.Title
+}
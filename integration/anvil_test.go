@@ -0,0 +1,246 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The mainnet RocketStorage address, which every other Rocket Pool contract is looked up from
+const mainnetStorageAddress string = "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"
+
+// How long to wait for anvil to start answering requests
+const anvilStartTimeout time.Duration = 60 * time.Second
+
+// A local anvil node forking a live chain that has the Rocket Pool contracts deployed
+type AnvilFork struct {
+	cmd     *exec.Cmd
+	url     string
+	rpc     *rpc.Client
+	ec      *ethclient.Client
+	rp      *rocketpool.RocketPool
+	logFile *os.File
+}
+
+// Start anvil forking the provided RPC URL, logging its output to the provided file
+func StartAnvilFork(anvilPath string, forkUrl string, forkBlock uint64, logPath string) (*AnvilFork, error) {
+
+	// Pick a free port
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error finding a free port for anvil: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	if err := listener.Close(); err != nil {
+		return nil, fmt.Errorf("error releasing the port for anvil: %w", err)
+	}
+
+	// Start anvil
+	args := []string{
+		"--fork-url", forkUrl,
+		"--port", fmt.Sprint(port),
+		"--chain-id", "1",
+		"--silent",
+	}
+	if forkBlock != 0 {
+		args = append(args, "--fork-block-number", fmt.Sprint(forkBlock))
+	}
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating anvil log file: %w", err)
+	}
+	cmd := exec.Command(anvilPath, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return nil, fmt.Errorf("error starting anvil: %w", err)
+	}
+
+	fork := &AnvilFork{
+		cmd:     cmd,
+		url:     fmt.Sprintf("http://127.0.0.1:%d", port),
+		logFile: logFile,
+	}
+
+	// Wait for it to answer requests
+	if err := fork.waitReady(); err != nil {
+		fork.Close()
+		return nil, err
+	}
+	fork.ec = ethclient.NewClient(fork.rpc)
+	fork.rp, err = rocketpool.NewRocketPool(fork.ec, common.HexToAddress(mainnetStorageAddress))
+	if err != nil {
+		fork.Close()
+		return nil, fmt.Errorf("error creating Rocket Pool binding for the fork: %w", err)
+	}
+	return fork, nil
+
+}
+
+// Get the URL of the fork's RPC endpoint
+func (f *AnvilFork) GetUrl() string {
+	return f.url
+}
+
+// Get an Execution client connected to the fork
+func (f *AnvilFork) GetExecutionClient() *ethclient.Client {
+	return f.ec
+}
+
+// Get a Rocket Pool binding connected to the fork
+func (f *AnvilFork) GetRocketPool() *rocketpool.RocketPool {
+	return f.rp
+}
+
+// Stop anvil
+func (f *AnvilFork) Close() {
+	if f.rpc != nil {
+		f.rpc.Close()
+	}
+	if f.cmd.Process != nil {
+		_ = f.cmd.Process.Kill()
+		_ = f.cmd.Wait()
+	}
+	f.logFile.Close()
+}
+
+// Set the ETH balance of an account
+func (f *AnvilFork) SetBalance(address common.Address, balance *big.Int) error {
+	if err := f.rpc.Call(nil, "anvil_setBalance", address, (*hexutil.Big)(balance)); err != nil {
+		return fmt.Errorf("error setting balance of %s: %w", address.Hex(), err)
+	}
+	return nil
+}
+
+// Move the chain's clock forward and mine a block at the new time
+func (f *AnvilFork) IncreaseTime(duration time.Duration) error {
+	if err := f.rpc.Call(nil, "evm_increaseTime", hexutil.Uint64(duration.Seconds())); err != nil {
+		return fmt.Errorf("error increasing chain time: %w", err)
+	}
+	return f.Mine()
+}
+
+// Mine a block
+func (f *AnvilFork) Mine() error {
+	if err := f.rpc.Call(nil, "evm_mine"); err != nil {
+		return fmt.Errorf("error mining a block: %w", err)
+	}
+	return nil
+}
+
+// Send a transaction from any account, including contracts, without its key
+func (f *AnvilFork) SendAs(from common.Address, to common.Address, value *big.Int, data []byte) (common.Hash, error) {
+	if err := f.rpc.Call(nil, "anvil_impersonateAccount", from); err != nil {
+		return common.Hash{}, fmt.Errorf("error impersonating %s: %w", from.Hex(), err)
+	}
+	defer func() {
+		_ = f.rpc.Call(nil, "anvil_stopImpersonatingAccount", from)
+	}()
+
+	// Make sure the account can pay for gas
+	balance, err := f.ec.BalanceAt(context.Background(), from, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting balance of %s: %w", from.Hex(), err)
+	}
+	minBalance := new(big.Int).Add(value, big.NewInt(1e18))
+	if balance.Cmp(minBalance) < 0 {
+		if err := f.SetBalance(from, minBalance); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	tx := map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"value": (*hexutil.Big)(value),
+		"data":  hexutil.Bytes(data),
+	}
+	var txHash common.Hash
+	if err := f.rpc.Call(&txHash, "eth_sendTransaction", tx); err != nil {
+		return common.Hash{}, fmt.Errorf("error sending transaction as %s: %w", from.Hex(), err)
+	}
+	if err := f.waitForReceipt(txHash); err != nil {
+		return common.Hash{}, err
+	}
+	return txHash, nil
+}
+
+// Call a function of a Rocket Pool contract as the provided account
+func (f *AnvilFork) CallContractAs(from common.Address, contractName string, value *big.Int, method string, params ...interface{}) (common.Hash, error) {
+	contract, err := f.rp.GetContract(contractName, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting %s contract: %w", contractName, err)
+	}
+	data, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error packing %s.%s call: %w", contractName, method, err)
+	}
+	return f.SendAs(from, *contract.Address, value, data)
+}
+
+// Give an account RPL out of the Rocket Pool vault's holdings
+func (f *AnvilFork) FundRpl(address common.Address, amount *big.Int) error {
+	vaultAddress, err := f.rp.GetAddress("rocketVault", nil)
+	if err != nil {
+		return fmt.Errorf("error getting vault address: %w", err)
+	}
+	if _, err := f.CallContractAs(*vaultAddress, "rocketTokenRPL", big.NewInt(0), "transfer", address, amount); err != nil {
+		return fmt.Errorf("error transferring RPL from the vault: %w", err)
+	}
+	return nil
+}
+
+// Deposit ETH into the deposit pool as a staker so new minipools get assigned
+func (f *AnvilFork) DepositToPool(from common.Address, amount *big.Int) error {
+	if _, err := f.CallContractAs(from, "rocketDepositPool", amount, "deposit"); err != nil {
+		return fmt.Errorf("error depositing to the deposit pool: %w", err)
+	}
+	return nil
+}
+
+// Wait for anvil to answer requests
+func (f *AnvilFork) waitReady() error {
+	deadline := time.Now().Add(anvilStartTimeout)
+	for {
+		client, err := rpc.Dial(f.url)
+		if err == nil {
+			var chainId hexutil.Big
+			err = client.Call(&chainId, "eth_chainId")
+			if err == nil {
+				f.rpc = client
+				return nil
+			}
+			client.Close()
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("anvil didn't start within %s: %w", anvilStartTimeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// Wait for a transaction to be mined and make sure it succeeded
+func (f *AnvilFork) waitForReceipt(txHash common.Hash) error {
+	for i := 0; i < 100; i++ {
+		receipt, err := f.ec.TransactionReceipt(context.Background(), txHash)
+		if err == nil {
+			if receipt.Status == 0 {
+				return fmt.Errorf("transaction %s reverted", txHash.Hex())
+			}
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("transaction %s was never mined", txHash.Hex())
+}
@@ -0,0 +1,363 @@
+package integration
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Mainnet Beacon chain parameters, since the harness forks mainnet
+const (
+	mainnetGenesisTime           uint64 = 1606824023
+	mainnetGenesisValidatorsRoot string = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	mainnetGenesisForkVersion    string = "0x00000000"
+	mainnetCurrentForkVersion    string = "0x03000000"
+	mainnetDepositContract       string = "0x00000000219ab540356cBB839Cbe05303d7705Fa"
+	secondsPerSlot               uint64 = 12
+	slotsPerEpoch                uint64 = 32
+	farFutureEpoch               uint64 = 18446744073709551615
+)
+
+// A validator known to the mock Beacon node
+type MockValidator struct {
+	Index                 uint64
+	Pubkey                rptypes.ValidatorPubkey
+	WithdrawalCredentials common.Hash
+	Status                string
+	Balance               uint64
+	ActivationEpoch       uint64
+	ExitEpoch             uint64
+}
+
+// A minimal Beacon node API that serves the endpoints the daemon and CLI use, following the forked chain's clock.
+// Validators only exist once they've been added, and their statuses only change when the harness changes them.
+type MockBeaconNode struct {
+	server     *http.Server
+	listener   net.Listener
+	ec         *ethclient.Client
+	validators map[rptypes.ValidatorPubkey]*MockValidator
+	nextIndex  uint64
+	lock       sync.Mutex
+}
+
+// Create a mock Beacon node that reads the chain time and block number from the provided Execution client
+func NewMockBeaconNode(ec *ethclient.Client) (*MockBeaconNode, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error listening for mock Beacon node requests: %w", err)
+	}
+
+	bn := &MockBeaconNode{
+		listener:   listener,
+		ec:         ec,
+		validators: map[rptypes.ValidatorPubkey]*MockValidator{},
+		// Start well above the real validator count so indices never collide with it
+		nextIndex: 10000000,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/node/syncing", bn.handleSyncing)
+	mux.HandleFunc("/eth/v1/node/peer_count", bn.handlePeerCount)
+	mux.HandleFunc("/eth/v1/node/version", bn.handleVersion)
+	mux.HandleFunc("/eth/v1/config/spec", bn.handleSpec)
+	mux.HandleFunc("/eth/v1/config/deposit_contract", bn.handleDepositContract)
+	mux.HandleFunc("/eth/v1/beacon/genesis", bn.handleGenesis)
+	mux.HandleFunc("/eth/v1/beacon/states/", bn.handleState)
+	mux.HandleFunc("/eth/v2/beacon/blocks/", bn.handleBlock)
+	bn.server = &http.Server{Handler: mux}
+
+	go func() {
+		_ = bn.server.Serve(listener)
+	}()
+	return bn, nil
+}
+
+// Get the URL of the mock Beacon node's API
+func (bn *MockBeaconNode) GetUrl() string {
+	return fmt.Sprintf("http://%s", bn.listener.Addr().String())
+}
+
+// Stop serving requests
+func (bn *MockBeaconNode) Close() error {
+	return bn.server.Close()
+}
+
+// Add a validator that has been deposited to, as if the Beacon chain had just seen its deposit
+func (bn *MockBeaconNode) AddValidator(pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, balanceEth float64) *MockValidator {
+	bn.lock.Lock()
+	defer bn.lock.Unlock()
+
+	validator := &MockValidator{
+		Index:                 bn.nextIndex,
+		Pubkey:                pubkey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Status:                "pending_initialized",
+		Balance:               ethToGwei(balanceEth),
+		ActivationEpoch:       farFutureEpoch,
+		ExitEpoch:             farFutureEpoch,
+	}
+	bn.nextIndex++
+	bn.validators[pubkey] = validator
+	return validator
+}
+
+// Update a validator's status and balance, activating it if it's now active
+func (bn *MockBeaconNode) SetValidatorStatus(pubkey rptypes.ValidatorPubkey, status string, balanceEth float64) error {
+	bn.lock.Lock()
+	defer bn.lock.Unlock()
+
+	validator, exists := bn.validators[pubkey]
+	if !exists {
+		return fmt.Errorf("validator %s hasn't been added to the mock Beacon node", pubkey.Hex())
+	}
+	validator.Status = status
+	validator.Balance = ethToGwei(balanceEth)
+	if strings.HasPrefix(status, "active") && validator.ActivationEpoch == farFutureEpoch {
+		epoch, err := bn.getHeadEpoch()
+		if err != nil {
+			return err
+		}
+		validator.ActivationEpoch = epoch
+	}
+	return nil
+}
+
+// Get the head slot from the forked chain's clock, which moves with the harness's time travel
+func (bn *MockBeaconNode) getHeadSlot() (uint64, error) {
+	header, err := bn.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the latest block header: %w", err)
+	}
+	return (header.Time - mainnetGenesisTime) / secondsPerSlot, nil
+}
+
+// Get the head epoch from the forked chain's clock
+func (bn *MockBeaconNode) getHeadEpoch() (uint64, error) {
+	slot, err := bn.getHeadSlot()
+	if err != nil {
+		return 0, err
+	}
+	return slot / slotsPerEpoch, nil
+}
+
+func (bn *MockBeaconNode) handleSyncing(w http.ResponseWriter, r *http.Request) {
+	slot, err := bn.getHeadSlot()
+	if err != nil {
+		writeMockError(w, err)
+		return
+	}
+	writeMockData(w, map[string]interface{}{
+		"is_syncing":    false,
+		"head_slot":     fmt.Sprint(slot),
+		"sync_distance": "0",
+	})
+}
+
+func (bn *MockBeaconNode) handlePeerCount(w http.ResponseWriter, r *http.Request) {
+	writeMockData(w, map[string]interface{}{
+		"disconnected":  "0",
+		"connecting":    "0",
+		"connected":     "0",
+		"disconnecting": "0",
+	})
+}
+
+func (bn *MockBeaconNode) handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeMockData(w, map[string]interface{}{
+		"version": "Mock/v0.0.0",
+	})
+}
+
+func (bn *MockBeaconNode) handleSpec(w http.ResponseWriter, r *http.Request) {
+	writeMockData(w, map[string]interface{}{
+		"SECONDS_PER_SLOT":                 fmt.Sprint(secondsPerSlot),
+		"SLOTS_PER_EPOCH":                  fmt.Sprint(slotsPerEpoch),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": "256",
+	})
+}
+
+func (bn *MockBeaconNode) handleDepositContract(w http.ResponseWriter, r *http.Request) {
+	writeMockData(w, map[string]interface{}{
+		"chain_id": "1",
+		"address":  mainnetDepositContract,
+	})
+}
+
+func (bn *MockBeaconNode) handleGenesis(w http.ResponseWriter, r *http.Request) {
+	writeMockData(w, map[string]interface{}{
+		"genesis_time":            fmt.Sprint(mainnetGenesisTime),
+		"genesis_fork_version":    mainnetGenesisForkVersion,
+		"genesis_validators_root": mainnetGenesisValidatorsRoot,
+	})
+}
+
+// Serves the state endpoints; every state ID is treated as the head
+func (bn *MockBeaconNode) handleState(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/states/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	epoch, err := bn.getHeadEpoch()
+	if err != nil {
+		writeMockError(w, err)
+		return
+	}
+	switch parts[1] {
+	case "finality_checkpoints":
+		finalized := uint64(0)
+		if epoch > 2 {
+			finalized = epoch - 2
+		}
+		writeMockData(w, map[string]interface{}{
+			"previous_justified": map[string]string{"epoch": fmt.Sprint(finalized)},
+			"current_justified":  map[string]string{"epoch": fmt.Sprint(finalized + 1)},
+			"finalized":          map[string]string{"epoch": fmt.Sprint(finalized)},
+		})
+	case "fork":
+		writeMockData(w, map[string]interface{}{
+			"previous_version": mainnetCurrentForkVersion,
+			"current_version":  mainnetCurrentForkVersion,
+			"epoch":            "0",
+		})
+	case "validators":
+		writeMockData(w, bn.getValidators(r.URL.Query().Get("id")))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Get the validators matching a comma-separated list of pubkeys or indices
+func (bn *MockBeaconNode) getValidators(ids string) []interface{} {
+	bn.lock.Lock()
+	defer bn.lock.Unlock()
+
+	results := []interface{}{}
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		for _, validator := range bn.validators {
+			if strings.EqualFold(id, validator.Pubkey.Hex()) || strings.EqualFold(id, "0x"+validator.Pubkey.Hex()) || id == strconv.FormatUint(validator.Index, 10) {
+				results = append(results, serializeMockValidator(validator))
+				break
+			}
+		}
+	}
+	return results
+}
+
+// Serves the head block, pointing at the forked chain's latest block
+func (bn *MockBeaconNode) handleBlock(w http.ResponseWriter, r *http.Request) {
+	slot, err := bn.getHeadSlot()
+	if err != nil {
+		writeMockError(w, err)
+		return
+	}
+	blockNumber, err := bn.ec.BlockNumber(context.Background())
+	if err != nil {
+		writeMockError(w, fmt.Errorf("error getting the latest block number: %w", err))
+		return
+	}
+
+	blockId := strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/")
+	if blockId != "head" && blockId != "finalized" && blockId != fmt.Sprint(slot) {
+		// Only the head block exists; the daemon treats every other slot as missed
+		http.NotFound(w, r)
+		return
+	}
+	writeMockData(w, map[string]interface{}{
+		"message": map[string]interface{}{
+			"slot":           fmt.Sprint(slot),
+			"proposer_index": "0",
+			"body": map[string]interface{}{
+				"eth1_data": map[string]interface{}{
+					"deposit_root":  "0x" + strings.Repeat("00", 32),
+					"deposit_count": "0",
+					"block_hash":    "0x" + strings.Repeat("00", 32),
+				},
+				"graffiti":     "0x" + strings.Repeat("00", 32),
+				"attestations": []interface{}{},
+				"execution_payload": map[string]interface{}{
+					"fee_recipient": "0x" + strings.Repeat("00", 20),
+					"block_number":  fmt.Sprint(blockNumber),
+				},
+			},
+		},
+	})
+}
+
+// Serialize a validator the way the Beacon API does
+func serializeMockValidator(validator *MockValidator) map[string]interface{} {
+	effectiveBalance := validator.Balance
+	if effectiveBalance > ethToGwei(32) {
+		effectiveBalance = ethToGwei(32)
+	}
+	return map[string]interface{}{
+		"index":   fmt.Sprint(validator.Index),
+		"balance": fmt.Sprint(validator.Balance),
+		"status":  validator.Status,
+		"validator": map[string]interface{}{
+			"pubkey":                       "0x" + validator.Pubkey.Hex(),
+			"withdrawal_credentials":       "0x" + hex.EncodeToString(validator.WithdrawalCredentials.Bytes()),
+			"effective_balance":            fmt.Sprint(effectiveBalance),
+			"slashed":                      false,
+			"activation_eligibility_epoch": "0",
+			"activation_epoch":             fmt.Sprint(validator.ActivationEpoch),
+			"exit_epoch":                   fmt.Sprint(validator.ExitEpoch),
+			"withdrawable_epoch":           fmt.Sprint(validator.ExitEpoch),
+		},
+	}
+}
+
+// Convert an ETH amount to the gwei the Beacon chain tracks balances in
+func ethToGwei(eth float64) uint64 {
+	return uint64(eth * 1e9)
+}
+
+// Write a successful Beacon API response
+func writeMockData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": data,
+	})
+}
+
+// Write a failed Beacon API response
+func writeMockError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    http.StatusInternalServerError,
+		"message": err.Error(),
+	})
+}
+
+// Wait for the mock Beacon node to answer requests
+func (bn *MockBeaconNode) waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		response, err := http.Get(bn.GetUrl() + "/eth/v1/node/version")
+		if err == nil {
+			_ = response.Body.Close()
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the mock Beacon node didn't start: %w", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
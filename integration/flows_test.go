@@ -0,0 +1,270 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings used by the flows
+const (
+	harnessPassword     string        = "integration-test-password"
+	harnessTimezone     string        = "Etc/UTC"
+	harnessNodeEth      float64       = 100
+	harnessRplStake     float64       = 5000
+	harnessBondEth      float64       = 8
+	harnessPoolDeposit  float64       = 32
+	harnessSkimmedEth   float64       = 0.5
+	harnessStakeTimeout time.Duration = 5 * time.Minute
+	harnessPollInterval time.Duration = 5 * time.Second
+)
+
+// The state the flows hand to each other
+type flowState struct {
+	NodeAddress     common.Address
+	MinipoolAddress common.Address
+	ValidatorPubkey rptypes.ValidatorPubkey
+	ScrubPeriod     time.Duration
+}
+
+// A step of the node operator lifecycle that the harness drives and checks
+type flow struct {
+	name string
+	run  func(h *Harness, state *flowState) error
+}
+
+// The flows in the order they must run, since each one builds on the previous one's state
+var flows = []flow{
+	{
+		// Create a wallet, register the node, stake RPL, and make an 8 ETH minipool deposit
+		name: "deposit",
+		run:  runDepositFlow,
+	}, {
+		// Pass the scrub check and let the node daemon stake the minipool
+		name: "stake",
+		run:  runStakeFlow,
+	}, {
+		// Skim rewards to the minipool and distribute its balance
+		name: "rewards",
+		run:  runRewardsFlow,
+	},
+}
+
+// Run the node operator lifecycle against a mainnet fork and a mock Beacon node.
+// It's skipped unless RP_E2E_FORK_URL is set; see getHarnessConfig for the other settings.
+func TestNodeOperatorFlows(t *testing.T) {
+	h := newTestHarness(t)
+	state := &flowState{}
+	for _, f := range flows {
+		f := f
+		passed := t.Run(f.name, func(t *testing.T) {
+			if err := f.run(h, state); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		// Every flow depends on the state of the ones before it
+		if !passed {
+			t.Fatalf("flow %s failed; skipping the flows after it", f.name)
+		}
+	}
+}
+
+// Create a wallet, register the node, stake RPL, and make a minipool deposit
+func runDepositFlow(h *Harness, state *flowState) error {
+
+	// Set up the wallet
+	if _, err := h.Client.SetPassword(harnessPassword); err != nil {
+		return fmt.Errorf("error setting password: %w", err)
+	}
+	wallet, err := h.Client.InitWallet("", 0)
+	if err != nil {
+		return fmt.Errorf("error initializing wallet: %w", err)
+	}
+	state.NodeAddress = wallet.AccountAddress
+	if err := h.Fork.SetBalance(state.NodeAddress, eth.EthToWei(harnessNodeEth)); err != nil {
+		return err
+	}
+
+	// Register the node
+	register, err := h.Client.RegisterNode(harnessTimezone)
+	if err != nil {
+		return fmt.Errorf("error registering node: %w", err)
+	}
+	if err := waitForTransaction(h, register.TxHash); err != nil {
+		return err
+	}
+
+	// Stake RPL
+	rplAmount := eth.EthToWei(harnessRplStake)
+	if err := h.Fork.FundRpl(state.NodeAddress, rplAmount); err != nil {
+		return err
+	}
+	approve, err := h.Client.NodeStakeRplApprove(rplAmount)
+	if err != nil {
+		return fmt.Errorf("error approving RPL: %w", err)
+	}
+	if err := waitForTransaction(h, approve.ApproveTxHash); err != nil {
+		return err
+	}
+	stake, err := h.Client.NodeStakeRpl(rplAmount)
+	if err != nil {
+		return fmt.Errorf("error staking RPL: %w", err)
+	}
+	if err := waitForTransaction(h, stake.StakeTxHash); err != nil {
+		return err
+	}
+
+	// Make the deposit
+	deposit, err := h.Client.NodeDeposit(eth.EthToWei(harnessBondEth), 0, big.NewInt(0), false, true)
+	if err != nil {
+		return fmt.Errorf("error making minipool deposit: %w", err)
+	}
+	if err := waitForTransaction(h, deposit.TxHash); err != nil {
+		return err
+	}
+	state.MinipoolAddress = deposit.MinipoolAddress
+	state.ValidatorPubkey = deposit.ValidatorPubkey
+	state.ScrubPeriod = deposit.ScrubPeriod
+
+	// Give the deposit pool enough ETH to assign the new minipool, in case the queue is empty
+	if err := h.Fork.DepositToPool(common.HexToAddress("0x000000000000000000000000000000000000dEaD"), eth.EthToWei(harnessPoolDeposit)); err != nil {
+		return err
+	}
+
+	// The Beacon chain sees the 1 ETH prestake
+	withdrawalCredentials := common.Hash{}
+	withdrawalCredentials[0] = 0x01
+	copy(withdrawalCredentials[12:], state.MinipoolAddress.Bytes())
+	h.Beacon.AddValidator(state.ValidatorPubkey, withdrawalCredentials, 1)
+
+	// Make sure the minipool was created
+	details, err := getMinipool(h, state.MinipoolAddress)
+	if err != nil {
+		return err
+	}
+	if details.Status.Status != rptypes.Initialized && details.Status.Status != rptypes.Prelaunch {
+		return fmt.Errorf("minipool %s has status %s after the deposit", state.MinipoolAddress.Hex(), details.Status.Status.String())
+	}
+	return nil
+
+}
+
+// Pass the scrub check and let the node daemon stake the minipool
+func runStakeFlow(h *Harness, state *flowState) error {
+
+	// Pass the scrub period
+	if err := h.Fork.IncreaseTime(state.ScrubPeriod + time.Minute); err != nil {
+		return err
+	}
+	if err := h.Beacon.SetValidatorStatus(state.ValidatorPubkey, "pending_queued", 1); err != nil {
+		return err
+	}
+
+	// Let the daemon's prelaunch task stake it
+	if err := h.StartDaemon(); err != nil {
+		return err
+	}
+	defer h.StopDaemon()
+
+	deadline := time.Now().Add(harnessStakeTimeout)
+	for {
+		details, err := getMinipool(h, state.MinipoolAddress)
+		if err != nil {
+			return err
+		}
+		if details.Status.Status == rptypes.Staking {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the node daemon didn't stake minipool %s within %s (status %s); see %s/node.log", state.MinipoolAddress.Hex(), harnessStakeTimeout, details.Status.Status.String(), h.GetWorkDir())
+		}
+
+		// Keep the chain moving so the daemon's transactions get mined
+		if err := h.Fork.Mine(); err != nil {
+			return err
+		}
+		time.Sleep(harnessPollInterval)
+	}
+
+	// The Beacon chain sees the full deposit
+	return h.Beacon.SetValidatorStatus(state.ValidatorPubkey, "active_ongoing", 32)
+
+}
+
+// Skim rewards to the minipool and distribute its balance
+func runRewardsFlow(h *Harness, state *flowState) error {
+
+	// Simulate a withdrawal of skimmed rewards
+	if err := h.Fork.SetBalance(state.MinipoolAddress, eth.EthToWei(harnessSkimmedEth)); err != nil {
+		return err
+	}
+	if err := h.Beacon.SetValidatorStatus(state.ValidatorPubkey, "active_ongoing", 32); err != nil {
+		return err
+	}
+
+	// Distribute it
+	ec := h.Fork.GetExecutionClient()
+	balanceBefore, err := ec.BalanceAt(context.Background(), state.NodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node balance: %w", err)
+	}
+	distribute, err := h.Client.DistributeBalance(state.MinipoolAddress)
+	if err != nil {
+		return fmt.Errorf("error distributing minipool balance: %w", err)
+	}
+	if err := waitForTransaction(h, distribute.TxHash); err != nil {
+		return err
+	}
+
+	// The node's share has to cover the gas it spent
+	balanceAfter, err := ec.BalanceAt(context.Background(), state.NodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node balance: %w", err)
+	}
+	if balanceAfter.Cmp(balanceBefore) <= 0 {
+		return fmt.Errorf("node balance didn't increase after distributing (before %.6f ETH, after %.6f ETH)", eth.WeiToEth(balanceBefore), eth.WeiToEth(balanceAfter))
+	}
+	minipoolBalance, err := ec.BalanceAt(context.Background(), state.MinipoolAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting minipool balance: %w", err)
+	}
+	if minipoolBalance.Sign() != 0 {
+		return fmt.Errorf("minipool still has %.6f ETH after distributing", eth.WeiToEth(minipoolBalance))
+	}
+	return nil
+
+}
+
+// Wait for a transaction submitted through the client
+func waitForTransaction(h *Harness, txHash common.Hash) error {
+	if err := h.Fork.Mine(); err != nil {
+		return err
+	}
+	if _, err := h.Client.WaitForTransaction(txHash); err != nil {
+		return fmt.Errorf("error waiting for transaction %s: %w", txHash.Hex(), err)
+	}
+	return nil
+}
+
+// Get a node minipool's details through the client
+func getMinipool(h *Harness, address common.Address) (api.MinipoolDetails, error) {
+	status, err := h.Client.MinipoolStatus()
+	if err != nil {
+		return api.MinipoolDetails{}, fmt.Errorf("error getting minipool status: %w", err)
+	}
+	for _, details := range status.Minipools {
+		if details.Address == address {
+			return details, nil
+		}
+	}
+	return api.MinipoolDetails{}, fmt.Errorf("minipool %s wasn't found", address.Hex())
+}
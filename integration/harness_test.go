@@ -0,0 +1,252 @@
+package integration
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Gas settings for the harness's transactions; the fork has no gas oracle to ask
+const (
+	harnessMaxFee     float64 = 200
+	harnessMaxPrioFee float64 = 1
+)
+
+// Settings for a harness run
+type HarnessConfig struct {
+	// The RPC URL of a live chain to fork
+	ForkUrl string
+
+	// The block to fork at, or 0 for the latest one
+	ForkBlock uint64
+
+	// The path to the anvil binary
+	AnvilPath string
+
+	// The path to a built daemon binary
+	DaemonPath string
+
+	// The directory to keep the settings, wallet and logs in; a temporary one is used if this is empty
+	WorkDir string
+
+	// Keep the work directory after the run instead of removing it
+	KeepWorkDir bool
+}
+
+// An isolated Rocket Pool network made of an anvil fork, a mock Beacon node, and a Native mode node that uses them
+type Harness struct {
+	Fork   *AnvilFork
+	Beacon *MockBeaconNode
+	Client *rocketpool.Client
+
+	cfg        HarnessConfig
+	workDir    string
+	daemonPath string
+	daemon     *exec.Cmd
+	daemonLog  *os.File
+}
+
+// Read the harness settings from the environment. The tests are skipped unless RP_E2E_FORK_URL is set, so they only run
+// where an archive RPC endpoint and anvil are available:
+//
+//	RP_E2E_FORK_URL     the RPC URL of a mainnet Execution client to fork
+//	RP_E2E_FORK_BLOCK   the block to fork at (default: the latest)
+//	RP_E2E_ANVIL_PATH   the path to the anvil binary (default: anvil)
+//	RP_E2E_DAEMON_PATH  the path to a built rocketpool daemon (default: build one into the work directory)
+//	RP_E2E_WORK_DIR     the directory to keep the settings, wallet and logs in (default: a temporary one)
+//	RP_E2E_KEEP         keep the temporary work directory after the run if this is set
+func getHarnessConfig(t *testing.T) HarnessConfig {
+	forkUrl := os.Getenv("RP_E2E_FORK_URL")
+	if forkUrl == "" {
+		t.Skip("RP_E2E_FORK_URL isn't set")
+	}
+
+	harnessCfg := HarnessConfig{
+		ForkUrl:     forkUrl,
+		AnvilPath:   os.Getenv("RP_E2E_ANVIL_PATH"),
+		DaemonPath:  os.Getenv("RP_E2E_DAEMON_PATH"),
+		WorkDir:     os.Getenv("RP_E2E_WORK_DIR"),
+		KeepWorkDir: os.Getenv("RP_E2E_KEEP") != "",
+	}
+	if harnessCfg.AnvilPath == "" {
+		harnessCfg.AnvilPath = "anvil"
+	}
+	if forkBlock := os.Getenv("RP_E2E_FORK_BLOCK"); forkBlock != "" {
+		var err error
+		harnessCfg.ForkBlock, err = strconv.ParseUint(forkBlock, 10, 64)
+		if err != nil {
+			t.Fatalf("invalid RP_E2E_FORK_BLOCK [%s]: %s", forkBlock, err.Error())
+		}
+	}
+	return harnessCfg
+}
+
+// Start a harness for a test, building the daemon first if a built one wasn't provided, and stop it when the test ends
+func newTestHarness(t *testing.T) *Harness {
+	harnessCfg := getHarnessConfig(t)
+	if harnessCfg.DaemonPath == "" {
+		harnessCfg.DaemonPath = filepath.Join(t.TempDir(), "rocketpool")
+		build := exec.Command("go", "build", "-o", harnessCfg.DaemonPath, "../rocketpool")
+		if output, err := build.CombinedOutput(); err != nil {
+			t.Fatalf("error building the daemon: %s\n%s", err.Error(), string(output))
+		}
+	}
+
+	h, err := NewHarness(harnessCfg)
+	if err != nil {
+		t.Fatalf("error starting the harness: %s", err.Error())
+	}
+	t.Cleanup(h.Close)
+	t.Logf("Harness running in %s", h.GetWorkDir())
+	return h
+}
+
+// Start the network and write a Native mode config that points at it
+func NewHarness(harnessCfg HarnessConfig) (*Harness, error) {
+
+	// Set up the work directory
+	workDir := harnessCfg.WorkDir
+	if workDir == "" {
+		var err error
+		workDir, err = os.MkdirTemp("", "rocketpool-integration-")
+		if err != nil {
+			return nil, fmt.Errorf("error creating work directory: %w", err)
+		}
+	} else if err := os.MkdirAll(workDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating work directory %s: %w", workDir, err)
+	}
+	daemonPath, err := filepath.Abs(harnessCfg.DaemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the daemon path: %w", err)
+	}
+	h := &Harness{
+		cfg:        harnessCfg,
+		workDir:    workDir,
+		daemonPath: daemonPath,
+	}
+
+	// Start the fork and the mock Beacon node
+	h.Fork, err = StartAnvilFork(harnessCfg.AnvilPath, harnessCfg.ForkUrl, harnessCfg.ForkBlock, filepath.Join(workDir, "anvil.log"))
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	h.Beacon, err = NewMockBeaconNode(h.Fork.GetExecutionClient())
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	if err := h.Beacon.waitReady(10 * time.Second); err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	// Write the config
+	if err := h.writeConfig(); err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	// Create the client, which runs the daemon's API commands against the config directly
+	h.Client, err = rocketpool.NewClient(workDir, daemonPath, harnessMaxFee, harnessMaxPrioFee, 0, "", false)
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("error creating Rocket Pool client: %w", err)
+	}
+	return h, nil
+
+}
+
+// Get the directory the harness keeps its settings, wallet and logs in
+func (h *Harness) GetWorkDir() string {
+	return h.workDir
+}
+
+// Start the node daemon so its automatic tasks run against the network
+func (h *Harness) StartDaemon() error {
+	if h.daemon != nil {
+		return nil
+	}
+
+	logFile, err := os.Create(filepath.Join(h.workDir, "node.log"))
+	if err != nil {
+		return fmt.Errorf("error creating node daemon log file: %w", err)
+	}
+	cmd := exec.Command(h.daemonPath, "--settings", h.getSettingsPath(), "node")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return fmt.Errorf("error starting node daemon: %w", err)
+	}
+	h.daemon = cmd
+	h.daemonLog = logFile
+	return nil
+}
+
+// Stop the node daemon if it's running
+func (h *Harness) StopDaemon() {
+	if h.daemon == nil {
+		return
+	}
+	if h.daemon.Process != nil {
+		_ = h.daemon.Process.Kill()
+		_ = h.daemon.Wait()
+	}
+	h.daemonLog.Close()
+	h.daemon = nil
+	h.daemonLog = nil
+}
+
+// Stop everything and clean up the work directory unless it should be kept
+func (h *Harness) Close() {
+	h.StopDaemon()
+	if h.Client != nil {
+		h.Client.Close()
+	}
+	if h.Beacon != nil {
+		_ = h.Beacon.Close()
+	}
+	if h.Fork != nil {
+		h.Fork.Close()
+	}
+	if !h.cfg.KeepWorkDir && h.cfg.WorkDir == "" {
+		_ = os.RemoveAll(h.workDir)
+	}
+}
+
+// Get the path of the settings file
+func (h *Harness) getSettingsPath() string {
+	return filepath.Join(h.workDir, rocketpool.SettingsFile)
+}
+
+// Write a Native mode config pointing at the fork and the mock Beacon node
+func (h *Harness) writeConfig() error {
+	cfg := config.NewRocketPoolConfig(h.workDir, true)
+	cfg.Smartnode.Network.Value = cfgtypes.Network_Mainnet
+	cfg.Smartnode.DataPath.Value = filepath.Join(h.workDir, "data")
+	cfg.Native.EcHttpUrl.Value = h.Fork.GetUrl()
+	cfg.Native.CcHttpUrl.Value = h.Beacon.GetUrl()
+	cfg.Native.ConsensusClient.Value = cfgtypes.ConsensusClient_Lighthouse
+
+	// There's no validator client, so restarting and stopping it are no-ops
+	cfg.Native.ValidatorRestartCommand.Value = "true"
+	cfg.Native.ValidatorStopCommand.Value = "true"
+
+	if err := os.MkdirAll(filepath.Join(h.workDir, "data", "validators"), 0755); err != nil {
+		return fmt.Errorf("error creating data directory: %w", err)
+	}
+	if err := rputils.SaveConfig(cfg, h.getSettingsPath()); err != nil {
+		return fmt.Errorf("error saving harness config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Print a shell completion script for the rocketpool command (" + getShellNames() + ")",
+		UsageText: "rocketpool completion shell\n\n" +
+			"   To enable completion for the current session:\n" +
+			"     bash: source <(rocketpool completion bash)\n" +
+			"     zsh:  source <(rocketpool completion zsh)\n" +
+			"     fish: rocketpool completion fish | source",
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+			shell := c.Args().Get(0)
+			generator, exists := generators[shell]
+			if !exists {
+				return fmt.Errorf("Unsupported shell '%s'; expected one of: %s", shell, getShellNames())
+			}
+
+			// Run
+			fmt.Print(generator(c.App))
+			return nil
+
+		},
+	})
+}
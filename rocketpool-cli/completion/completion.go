@@ -0,0 +1,246 @@
+package completion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// The name of the command the scripts complete
+const rootCommand string = "rocketpool"
+
+// The completion script generators for each supported shell
+var generators = map[string]func(app *cli.App) string{
+	"bash": generateBash,
+	"zsh":  generateZsh,
+	"fish": generateFish,
+}
+
+// A command in the CLI's command tree, flattened so the scripts can look it up by its path
+type commandNode struct {
+	path        string
+	names       []string
+	usage       string
+	subcommands []*commandNode
+	flags       []flagInfo
+}
+
+// A flag's names and usage
+type flagInfo struct {
+	long  []string
+	short []string
+	usage string
+}
+
+// Get the names of the supported shells
+func getShellNames() string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Build the command tree from the commands registered with the app, so the scripts always cover every command
+func buildCommandTree(app *cli.App) *commandNode {
+	root := &commandNode{
+		path:  rootCommand,
+		names: []string{rootCommand},
+		usage: app.Usage,
+		flags: getFlagInfo(app.Flags),
+	}
+	root.subcommands = getCommandNodes(rootCommand, app.Commands)
+	return root
+}
+
+// Get the nodes of a list of commands and their subcommands
+func getCommandNodes(parentPath string, commands []cli.Command) []*commandNode {
+	nodes := []*commandNode{}
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		node := &commandNode{
+			path:  parentPath + " " + command.Name,
+			names: command.Names(),
+			usage: command.Usage,
+			flags: getFlagInfo(command.Flags),
+		}
+		node.subcommands = getCommandNodes(node.path, command.Subcommands)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// Split the flags into their long and short names
+func getFlagInfo(flags []cli.Flag) []flagInfo {
+	infos := []flagInfo{}
+	for _, flag := range flags {
+		info := flagInfo{}
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if len(name) == 1 {
+				info.short = append(info.short, name)
+			} else {
+				info.long = append(info.long, name)
+			}
+		}
+		if usageFlag, ok := flag.(interface{ GetUsage() string }); ok {
+			info.usage = usageFlag.GetUsage()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Walk the command tree, calling the visitor for every command
+func (node *commandNode) walk(visitor func(node *commandNode)) {
+	visitor(node)
+	for _, subcommand := range node.subcommands {
+		subcommand.walk(visitor)
+	}
+}
+
+// Get the words that can follow a command: its subcommands, their aliases and its flags
+func (node *commandNode) getWords() []string {
+	words := []string{}
+	for _, subcommand := range node.subcommands {
+		words = append(words, subcommand.names...)
+	}
+	for _, flag := range node.flags {
+		for _, name := range flag.long {
+			words = append(words, "--"+name)
+		}
+		for _, name := range flag.short {
+			words = append(words, "-"+name)
+		}
+	}
+	return words
+}
+
+// Generate the bash completion script
+func generateBash(app *cli.App) string {
+	root := buildCommandTree(app)
+	var builder strings.Builder
+
+	builder.WriteString("# bash completion for rocketpool; generated by `rocketpool completion bash`\n\n")
+
+	// Map each subcommand name or alias under a command to the subcommand's path
+	builder.WriteString("_rocketpool_child() {\n\tcase \"$1\" in\n")
+	root.walk(func(node *commandNode) {
+		for _, subcommand := range node.subcommands {
+			patterns := []string{}
+			for _, name := range subcommand.names {
+				patterns = append(patterns, fmt.Sprintf("\"%s %s\"", node.path, name))
+			}
+			fmt.Fprintf(&builder, "\t\t%s) echo \"%s\" ;;\n", strings.Join(patterns, "|"), subcommand.path)
+		}
+	})
+	builder.WriteString("\tesac\n}\n\n")
+
+	// List the words that can follow each command
+	builder.WriteString("_rocketpool_words() {\n\tcase \"$1\" in\n")
+	root.walk(func(node *commandNode) {
+		fmt.Fprintf(&builder, "\t\t\"%s\") echo \"%s\" ;;\n", node.path, strings.Join(node.getWords(), " "))
+	})
+	builder.WriteString("\tesac\n}\n\n")
+
+	builder.WriteString(`_rocketpool() {
+	local cur path child i
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	path="rocketpool"
+	for ((i = 1; i < COMP_CWORD; i++)); do
+		child="$(_rocketpool_child "$path ${COMP_WORDS[i]}")"
+		if [[ -n "$child" ]]; then
+			path="$child"
+		fi
+	done
+	COMPREPLY=($(compgen -W "$(_rocketpool_words "$path")" -- "$cur"))
+}
+
+complete -o default -F _rocketpool rocketpool
+`)
+	return builder.String()
+}
+
+// Generate the zsh completion script, which reuses the bash one through zsh's bash completion support
+func generateZsh(app *cli.App) string {
+	return "#compdef rocketpool\n" +
+		"# zsh completion for rocketpool; generated by `rocketpool completion zsh`\n\n" +
+		"autoload -U +X compinit && compinit\n" +
+		"autoload -U +X bashcompinit && bashcompinit\n\n" +
+		generateBash(app)
+}
+
+// Generate the fish completion script
+func generateFish(app *cli.App) string {
+	root := buildCommandTree(app)
+	var builder strings.Builder
+
+	builder.WriteString("# fish completion for rocketpool; generated by `rocketpool completion fish`\n\n")
+
+	// Map each subcommand name or alias under a command to the subcommand's path
+	builder.WriteString("function __fish_rocketpool_child\n\tswitch $argv[1]\n")
+	root.walk(func(node *commandNode) {
+		for _, subcommand := range node.subcommands {
+			patterns := []string{}
+			for _, name := range subcommand.names {
+				patterns = append(patterns, fishQuote(node.path+" "+name))
+			}
+			fmt.Fprintf(&builder, "\t\tcase %s\n\t\t\techo %s\n", strings.Join(patterns, " "), fishQuote(subcommand.path))
+		}
+	})
+	builder.WriteString("\tend\nend\n\n")
+
+	// Get the path of the command being completed
+	builder.WriteString(`function __fish_rocketpool_path
+	set -l path rocketpool
+	for word in (commandline -opc)[2..-1]
+		set -l child (__fish_rocketpool_child "$path $word")
+		if test -n "$child"
+			set path $child
+		end
+	end
+	echo $path
+end
+
+function __fish_rocketpool_at
+	test (__fish_rocketpool_path) = $argv[1]
+end
+
+complete -c rocketpool -f
+`)
+
+	// Complete the subcommands and flags of each command
+	root.walk(func(node *commandNode) {
+		condition := fishQuote(fmt.Sprintf("__fish_rocketpool_at \"%s\"", node.path))
+		for _, subcommand := range node.subcommands {
+			fmt.Fprintf(&builder, "complete -c rocketpool -n %s -a %s -d %s\n", condition, fishQuote(strings.Join(subcommand.names, " ")), fishQuote(subcommand.usage))
+		}
+		for _, flag := range node.flags {
+			line := fmt.Sprintf("complete -c rocketpool -n %s", condition)
+			for _, name := range flag.long {
+				line += " -l " + name
+			}
+			for _, name := range flag.short {
+				line += " -s " + name
+			}
+			fmt.Fprintf(&builder, "%s -d %s\n", line, fishQuote(flag.usage))
+		}
+	})
+	return builder.String()
+}
+
+// Quote a string for fish, which only treats backslashes and single quotes specially inside single quotes
+func fishQuote(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "'", "\\'")
+	value = strings.ReplaceAll(value, "\n", " ")
+	return "'" + value + "'"
+}
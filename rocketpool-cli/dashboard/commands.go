@@ -0,0 +1,35 @@
+package dashboard
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Show a live dashboard of your node's wallet, minipools, sync status, rewards, and gas prices",
+		UsageText: "rocketpool dashboard [options]",
+		Flags: []cli.Flag{
+			cli.Uint64Flag{
+				Name:  "refresh, r",
+				Usage: "The number of seconds to wait between dashboard refreshes",
+				Value: defaultRefreshInterval,
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return runDashboard(c)
+
+		},
+	})
+}
@@ -0,0 +1,375 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	defaultRefreshInterval uint64 = 30
+	timeFormat             string = "2006-01-02 15:04:05"
+)
+
+// The live dashboard TUI
+type dashboard struct {
+	app           *tview.Application
+	rp            *rocketpool.Client
+	nodePanel     *tview.TextView
+	syncPanel     *tview.TextView
+	rewardsPanel  *tview.TextView
+	gasPanel      *tview.TextView
+	minipoolPanel *tview.TextView
+	footer        *tview.TextView
+	interval      time.Duration
+	refreshNow    chan struct{}
+}
+
+// Run the dashboard until the user quits
+func runDashboard(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the refresh interval
+	refreshSeconds := c.Uint64("refresh")
+	if refreshSeconds == 0 {
+		return fmt.Errorf("The refresh interval must be at least 1 second.")
+	}
+
+	// Build and run the dashboard
+	app := tview.NewApplication()
+	d := newDashboard(app, rp, time.Duration(refreshSeconds)*time.Second)
+	go d.refreshLoop()
+	return app.Run()
+
+}
+
+// Create the dashboard layout
+func newDashboard(app *tview.Application, rp *rocketpool.Client, interval time.Duration) *dashboard {
+
+	d := &dashboard{
+		app:           app,
+		rp:            rp,
+		nodePanel:     newPanel("Node"),
+		syncPanel:     newPanel("Sync Status"),
+		rewardsPanel:  newPanel("Rewards"),
+		gasPanel:      newPanel("Gas Prices"),
+		minipoolPanel: newPanel("Minipools"),
+		footer:        tview.NewTextView().SetDynamicColors(true),
+		interval:      interval,
+		refreshNow:    make(chan struct{}, 1),
+	}
+
+	// Lay out the panels: node and sync on top, rewards and gas in the middle, minipools across the bottom
+	grid := tview.NewGrid().
+		SetRows(0, 0, 0, 1).
+		SetColumns(0, 0).
+		AddItem(d.nodePanel, 0, 0, 1, 1, 0, 0, false).
+		AddItem(d.syncPanel, 0, 1, 1, 1, 0, 0, false).
+		AddItem(d.rewardsPanel, 1, 0, 1, 1, 0, 0, false).
+		AddItem(d.gasPanel, 1, 1, 1, 1, 0, 0, false).
+		AddItem(d.minipoolPanel, 2, 0, 1, 2, 0, 0, true).
+		AddItem(d.footer, 3, 0, 1, 2, 0, 0, false)
+	grid.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Rocket Pool Smartnode %s Dashboard ", shared.RocketPoolVersion)).
+		SetBorderColor(tcell.ColorOrange).
+		SetTitleColor(tcell.ColorOrange)
+
+	// Handle the quit and refresh keys
+	grid.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyCtrlC {
+			app.Stop()
+			return nil
+		}
+		switch event.Rune() {
+		case 'q':
+			app.Stop()
+			return nil
+		case 'r':
+			select {
+			case d.refreshNow <- struct{}{}:
+			default:
+			}
+			return nil
+		}
+		return event
+	})
+
+	for _, panel := range []*tview.TextView{d.nodePanel, d.syncPanel, d.rewardsPanel, d.gasPanel, d.minipoolPanel} {
+		panel.SetText("[gray]Loading...[-]")
+	}
+	d.footer.SetText(d.getFooterText("Loading..."))
+
+	app.SetRoot(grid, true).SetFocus(d.minipoolPanel)
+	return d
+
+}
+
+// Create a bordered text panel
+func newPanel(title string) *tview.TextView {
+	panel := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	panel.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s ", title)).
+		SetTitleAlign(tview.AlignLeft)
+	return panel
+}
+
+// Refresh the dashboard on every tick, or when the user asks for it
+func (d *dashboard) refreshLoop() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		d.refresh()
+		select {
+		case <-ticker.C:
+		case <-d.refreshNow:
+		}
+	}
+}
+
+// Query the latest data and redraw the panels
+func (d *dashboard) refresh() {
+
+	d.setFooter("Refreshing...")
+
+	nodeText := d.getNodeText()
+	syncText := d.getSyncText()
+	rewardsText := d.getRewardsText()
+	gasText := getGasText()
+	minipoolText := d.getMinipoolText()
+
+	d.app.QueueUpdateDraw(func() {
+		d.nodePanel.SetText(nodeText)
+		d.syncPanel.SetText(syncText)
+		d.rewardsPanel.SetText(rewardsText)
+		d.gasPanel.SetText(gasText)
+		d.minipoolPanel.SetText(minipoolText)
+	})
+	d.setFooter(fmt.Sprintf("Last updated %s", time.Now().Format(timeFormat)))
+
+}
+
+// Update the footer's status message
+func (d *dashboard) setFooter(status string) {
+	text := d.getFooterText(status)
+	d.app.QueueUpdateDraw(func() {
+		d.footer.SetText(text)
+	})
+}
+
+// Get the footer text for a status message
+func (d *dashboard) getFooterText(status string) string {
+	return fmt.Sprintf("[gray]%s - refreshing every %s. Press 'r' to refresh now, 'q' or Esc to quit.[-]", status, d.interval)
+}
+
+// Get the wallet and node summary
+func (d *dashboard) getNodeText() string {
+
+	walletStatus, err := d.rp.WalletStatus()
+	if err != nil {
+		return formatError(err)
+	}
+	if !walletStatus.WalletInitialized && !walletStatus.IsMasquerading {
+		return "[yellow]The node wallet has not been initialized.[-]\nRun `rocketpool wallet init` or `rocketpool wallet recover` to set it up."
+	}
+
+	status, err := d.rp.NodeStatus()
+	if err != nil {
+		return formatError(err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Account:    %s\n", status.AccountAddress.Hex())
+	fmt.Fprintf(&sb, "Withdrawal: %s\n", status.WithdrawalAddress.Hex())
+	if !status.Registered {
+		sb.WriteString("[yellow]The node is not registered with Rocket Pool.[-]\n")
+		return sb.String()
+	}
+	if status.Trusted {
+		sb.WriteString("[green]The node is a member of the Oracle DAO.[-]\n")
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "ETH balance: %.6f ETH\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6))
+	fmt.Fprintf(&sb, "RPL balance: %.6f RPL\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6))
+	fmt.Fprintf(&sb, "RPL staked:  %.6f RPL (%.2f%% of borrowed ETH)\n", math.RoundDown(eth.WeiToEth(status.RplStake), 6), status.BorrowedCollateralRatio*100)
+	if status.EffectiveRplStake.Cmp(status.RplStake) < 0 {
+		fmt.Fprintf(&sb, "[yellow]Effective:   %.6f RPL[-]\n", math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6))
+	}
+	return sb.String()
+
+}
+
+// Get the sync status of the clients
+func (d *dashboard) getSyncText() string {
+
+	status, err := d.rp.NodeSync()
+	if err != nil {
+		return formatError(err)
+	}
+
+	var sb strings.Builder
+	writeClientManagerStatus(&sb, "Execution", &status.EcStatus)
+	sb.WriteString("\n")
+	writeClientManagerStatus(&sb, "Consensus", &status.BcStatus)
+	return sb.String()
+
+}
+
+// Write the status of a client and its fallback
+func writeClientManagerStatus(sb *strings.Builder, name string, status *api.ClientManagerStatus) {
+	fmt.Fprintf(sb, "%s client:   %s\n", name, formatClientStatus(&status.PrimaryClientStatus))
+	if status.FallbackEnabled {
+		fmt.Fprintf(sb, "%s fallback: %s\n", name, formatClientStatus(&status.FallbackClientStatus))
+	}
+}
+
+// Get a short description of a client's status
+func formatClientStatus(status *api.ClientStatus) string {
+	if status.Error != "" {
+		return fmt.Sprintf("[red]unavailable (%s)[-]", tview.Escape(status.Error))
+	}
+	if status.IsSynced {
+		return "[green]synced[-]"
+	}
+	return fmt.Sprintf("[yellow]syncing (%.2f%%)[-]", status.SyncProgress*100)
+}
+
+// Get the rewards estimates
+func (d *dashboard) getRewardsText() string {
+
+	rewards, err := d.rp.NodeRewards()
+	if err != nil {
+		return formatError(err)
+	}
+	if !rewards.Registered {
+		return "[gray]The node is not registered with Rocket Pool.[-]"
+	}
+
+	nextCheckpoint := rewards.LastCheckpoint.Add(rewards.RewardsInterval)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Next interval:   %s (in %s)\n", nextCheckpoint.Format(timeFormat), time.Until(nextCheckpoint).Round(time.Minute))
+	fmt.Fprintf(&sb, "Estimated RPL:   %.6f RPL\n", rewards.EstimatedRewards)
+	if rewards.Trusted {
+		fmt.Fprintf(&sb, "Estimated oDAO:  %.6f RPL\n", rewards.EstimatedTrustedRplRewards)
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Unclaimed RPL:   %.6f RPL\n", rewards.UnclaimedRplRewards+rewards.UnclaimedTrustedRplRewards)
+	fmt.Fprintf(&sb, "Unclaimed ETH:   %.6f ETH\n", rewards.UnclaimedEthRewards)
+	fmt.Fprintf(&sb, "Beacon rewards:  %.6f ETH\n", rewards.BeaconRewards)
+	return sb.String()
+
+}
+
+// Get the current gas price suggestions
+func getGasText() string {
+
+	etherchainData, err := etherchain.GetGasPrices()
+	if err == nil {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Rapid:    %.2f gwei\n", eth.WeiToGwei(etherchainData.RapidWei))
+		fmt.Fprintf(&sb, "Fast:     %.2f gwei\n", eth.WeiToGwei(etherchainData.FastWei))
+		fmt.Fprintf(&sb, "Standard: %.2f gwei\n", eth.WeiToGwei(etherchainData.StandardWei))
+		fmt.Fprintf(&sb, "Slow:     %.2f gwei\n", eth.WeiToGwei(etherchainData.SlowWei))
+		if etherchainData.EthUsd > 0 {
+			fmt.Fprintf(&sb, "\nETH price: $%.2f\n", etherchainData.EthUsd)
+		}
+		return sb.String()
+	}
+
+	// Fall back to Etherscan
+	etherscanData, err := etherscan.GetGasPrices()
+	if err != nil {
+		return formatError(fmt.Errorf("Error getting gas price suggestions: %w", err))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Fast:     %.2f gwei\n", etherscanData.FastGwei)
+	fmt.Fprintf(&sb, "Standard: %.2f gwei\n", etherscanData.StandardGwei)
+	fmt.Fprintf(&sb, "Slow:     %.2f gwei\n", etherscanData.SlowGwei)
+	return sb.String()
+
+}
+
+// Get the list of minipools
+func (d *dashboard) getMinipoolText() string {
+
+	status, err := d.rp.MinipoolStatus()
+	if err != nil {
+		return formatError(err)
+	}
+	if len(status.Minipools) == 0 {
+		return "[gray]The node does not have any minipools yet.[-]"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-42s  %-12s  %-10s  %-11s  %s\n", "Address", "Status", "Validator", "Commission", "Balance (Beacon)")
+	for _, mp := range status.Minipools {
+		if mp.Finalised {
+			continue
+		}
+
+		validator := "-"
+		balance := "-"
+		if mp.Validator.Exists {
+			validator = fmt.Sprint(mp.Validator.Index)
+			balance = fmt.Sprintf("%.6f ETH", math.RoundDown(eth.WeiToEth(mp.Validator.Balance), 6))
+		}
+
+		statusColor := "white"
+		switch mp.Status.Status {
+		case types.Staking:
+			statusColor = "green"
+		case types.Initialized, types.Prelaunch:
+			statusColor = "yellow"
+		case types.Dissolved:
+			statusColor = "red"
+		}
+		if mp.Status.IsVacant {
+			statusColor = "yellow"
+		}
+
+		fmt.Fprintf(&sb, "%-42s  [%s]%-12s[-]  %-10s  %-11s  %s\n",
+			mp.Address.Hex(),
+			statusColor,
+			mp.Status.Status.String(),
+			validator,
+			fmt.Sprintf("%.2f%%", mp.Node.Fee*100),
+			balance,
+		)
+	}
+	return sb.String()
+
+}
+
+// Format an error for display in a panel
+func formatError(err error) string {
+	return fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error()))
+}
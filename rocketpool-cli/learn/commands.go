@@ -0,0 +1,38 @@
+package learn
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Take a guided, read-only tour of your node's status that explains what each field means, what to expect, and common pitfalls",
+		UsageText: "rocketpool learn [options]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "section, s",
+				Usage: "Only show a single section of the tour (" + getSectionNames() + ")",
+			},
+			cli.BoolFlag{
+				Name:  "no-pause, n",
+				Usage: "Don't wait for you to press Enter between sections",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return runTutorial(c)
+
+		},
+	})
+}
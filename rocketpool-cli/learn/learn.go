@@ -0,0 +1,507 @@
+package learn
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+	colorBlue   string = "\033[36m"
+
+	lowGasBalanceEth  float64 = 0.05
+	validatorBalance  float64 = 32
+	balanceTolerance  float64 = 0.1
+	docsLink          string  = "https://docs.rocketpool.net/guides/node/responsibilities.html"
+	smoothingPoolLink string  = "https://docs.rocketpool.net/guides/redstone/whats-new.html#smoothing-pool"
+)
+
+// A single section of the tutorial
+type section struct {
+	name  string
+	title string
+	show  func(t *tutorial) error
+}
+
+// The sections of the tutorial, in the order they're shown
+var sections = []section{
+	{name: "sync", title: "Clients and Sync Status", show: showSync},
+	{name: "wallet", title: "Node Wallet", show: showWallet},
+	{name: "node", title: "Node Registration, Balances, and RPL Stake", show: showNode},
+	{name: "minipools", title: "Minipools", show: showMinipools},
+	{name: "fees", title: "Fee Recipient and the Smoothing Pool", show: showFees},
+	{name: "rewards", title: "Rewards", show: showRewards},
+}
+
+// The state of a running tutorial, with lazily-loaded node data
+type tutorial struct {
+	rp             *rocketpool.Client
+	reader         *bufio.Reader
+	pause          bool
+	walletStatus   *api.WalletStatusResponse
+	nodeStatus     *api.NodeStatusResponse
+	minipoolStatus *api.MinipoolStatusResponse
+}
+
+// Get the names of all sections, for the help text
+func getSectionNames() string {
+	names := make([]string, len(sections))
+	for i, s := range sections {
+		names[i] = s.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Walk the user through their node's status
+func runTutorial(c *cli.Context) error {
+
+	// Get the sections to show
+	selected := sections
+	if name := strings.ToLower(c.String("section")); name != "" {
+		selected = nil
+		for _, s := range sections {
+			if s.name == name {
+				selected = []section{s}
+				break
+			}
+		}
+		if selected == nil {
+			return fmt.Errorf("Unknown section '%s'; please use one of: %s.", name, getSectionNames())
+		}
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	t := &tutorial{
+		rp:     rp,
+		reader: bufio.NewReader(os.Stdin),
+		pause:  !c.Bool("no-pause"),
+	}
+
+	fmt.Printf("%sWelcome to the Rocket Pool Smartnode tour!%s\n", colorGreen, colorReset)
+	fmt.Println("This walks through the information your node reports, using your node's live data. Nothing here will send a transaction or change any settings.")
+	fmt.Printf("For the full set of node operator responsibilities, see %s.\n\n", docsLink)
+
+	for i, s := range selected {
+		fmt.Printf("%s=== %d/%d: %s ===%s\n", colorGreen, i+1, len(selected), s.title, colorReset)
+		err := s.show(t)
+		if err != nil {
+			return err
+		}
+		if i < len(selected)-1 {
+			t.waitForNext()
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("That's the end of the tour. You can run `rocketpool learn` again at any time, or `rocketpool learn --section <name>` to revisit a single section.")
+	return nil
+
+}
+
+// Wait for the user to press Enter before moving on
+func (t *tutorial) waitForNext() {
+	fmt.Println()
+	if !t.pause {
+		return
+	}
+	fmt.Print("Press Enter to continue...")
+	_, _ = t.reader.ReadString('\n')
+	fmt.Println()
+}
+
+// Get the wallet status, loading it if it hasn't been loaded yet
+func (t *tutorial) getWalletStatus() (*api.WalletStatusResponse, error) {
+	if t.walletStatus == nil {
+		status, err := t.rp.WalletStatus()
+		if err != nil {
+			return nil, err
+		}
+		t.walletStatus = &status
+	}
+	return t.walletStatus, nil
+}
+
+// Get the node status, loading it if it hasn't been loaded yet; returns nil if the node isn't ready to query
+func (t *tutorial) getNodeStatus() (*api.NodeStatusResponse, error) {
+	if t.nodeStatus == nil {
+		walletStatus, err := t.getWalletStatus()
+		if err != nil {
+			return nil, err
+		}
+		if !walletStatus.WalletInitialized {
+			return nil, nil
+		}
+		err = cliutils.CheckClientStatus(t.rp)
+		if err != nil {
+			return nil, err
+		}
+		status, err := t.rp.NodeStatus()
+		if err != nil {
+			return nil, err
+		}
+		t.nodeStatus = &status
+	}
+	return t.nodeStatus, nil
+}
+
+// Get the node's minipools, loading them if they haven't been loaded yet
+func (t *tutorial) getMinipoolStatus() (*api.MinipoolStatusResponse, error) {
+	if t.minipoolStatus == nil {
+		status, err := t.rp.MinipoolStatus()
+		if err != nil {
+			return nil, err
+		}
+		t.minipoolStatus = &status
+	}
+	return t.minipoolStatus, nil
+}
+
+// Get the node status for a section that requires a registered node, explaining what to do if it isn't
+func (t *tutorial) requireRegisteredNode() (*api.NodeStatusResponse, error) {
+	status, err := t.getNodeStatus()
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		printWarning("Your node wallet hasn't been set up yet, so there's nothing to show here. Run `rocketpool wallet init` first.")
+		return nil, nil
+	}
+	if !status.Registered {
+		printWarning("Your node isn't registered with Rocket Pool yet, so there's nothing to show here. Run `rocketpool node register` once your wallet has ETH for gas.")
+		return nil, nil
+	}
+	return status, nil
+}
+
+func showSync(t *tutorial) error {
+
+	err := cliutils.PrintNetwork(t.rp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Your node runs two clients: an Execution client (ETH1) that tracks transactions and Rocket Pool's contracts, and a Consensus client (ETH2) that runs the Beacon Chain your validators attest to.")
+	fmt.Println("Both must be fully synced before your node can do anything useful.")
+	fmt.Println()
+
+	status, err := t.rp.NodeSync()
+	if err != nil {
+		return err
+	}
+	printClientStatus("Execution client", status.EcStatus)
+	printClientStatus("Consensus client", status.BcStatus)
+
+	printPitfalls(
+		"Syncing from scratch can take hours or days; `rocketpool node sync` shows the progress.",
+		"A client that was synced can fall behind again if the machine runs out of disk space, memory, or peers. Check `rocketpool service logs eth1` and `rocketpool service logs eth2` if it does.",
+		"Fallback clients are used automatically when the primary clients go down, but they must be on the same network as your primary clients.",
+	)
+	return nil
+
+}
+
+func showWallet(t *tutorial) error {
+
+	fmt.Println("The node wallet holds the account that pays for your node's transactions and owns your minipools. Its mnemonic is the only way to recover it and your validator keys.")
+	fmt.Println()
+
+	status, err := t.getWalletStatus()
+	if err != nil {
+		return err
+	}
+
+	printField("Password set", fmt.Sprint(status.PasswordSet))
+	printField("Wallet initialized", fmt.Sprint(status.WalletInitialized))
+	if status.WalletInitialized {
+		printField("Node account", status.AccountAddress.Hex())
+		printOk("Your wallet is ready.")
+	} else if status.PasswordSet {
+		printWarning("Your password is set but the wallet hasn't been created. Run `rocketpool wallet init` or `rocketpool wallet recover`.")
+	} else {
+		printWarning("You don't have a wallet yet. Run `rocketpool wallet init` to create one, or `rocketpool wallet recover` to restore an existing one.")
+	}
+
+	printPitfalls(
+		"Anyone with your mnemonic controls your node. Never type it into a website or share it with anyone, including Rocket Pool support.",
+		"Keep an offline copy of your mnemonic; you'll need it to recover your node if the machine fails.",
+		"Running the same validator keys on two machines at once will get them slashed. Never restore your wallet on a second machine while the first one is still running.",
+	)
+	return nil
+
+}
+
+func showNode(t *tutorial) error {
+
+	fmt.Println("Your node account pays gas for everything the node daemon does, and stakes RPL as insurance for the ETH you borrow from the staking pool.")
+	fmt.Println()
+
+	status, err := t.getNodeStatus()
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		printWarning("Your node wallet hasn't been set up yet, so there's nothing to show here. Run `rocketpool wallet init` first.")
+		return nil
+	}
+
+	// Registration
+	printField("Registered", fmt.Sprint(status.Registered))
+	if !status.Registered {
+		printWarning("Your node needs to be registered before it can create minipools. Run `rocketpool node register`.")
+	}
+	printField("Withdrawal address", status.WithdrawalAddress.Hex())
+	if status.WithdrawalAddress == status.AccountAddress {
+		printWarning("Your withdrawal address is your node account. Consider changing it to a cold wallet you control with `rocketpool node set-withdrawal-address`, so your rewards aren't kept on a hot wallet.")
+	} else {
+		printOk("Your rewards and staked RPL will be sent to a separate withdrawal address.")
+	}
+
+	// Gas balance
+	ethBalance := eth.WeiToEth(status.AccountBalances.ETH)
+	printField("ETH balance", fmt.Sprintf("%.6f ETH", math.RoundDown(ethBalance, 6)))
+	if ethBalance < lowGasBalanceEth {
+		printWarning(fmt.Sprintf("Your node has less than %.2f ETH for gas. Automatic tasks like staking prelaunch minipools will fail if it runs out.", lowGasBalanceEth))
+	} else {
+		printOk("Your node has enough ETH to pay for gas.")
+	}
+
+	// RPL stake
+	if status.Registered {
+		rplStake := eth.WeiToEth(status.RplStake)
+		minStake := eth.WeiToEth(status.MinimumRplStake)
+		maxStake := eth.WeiToEth(status.MaximumRplStake)
+		printField("Staked RPL", fmt.Sprintf("%.6f RPL", math.RoundDown(rplStake, 6)))
+		printField("Effective RPL", fmt.Sprintf("%.6f RPL", math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6)))
+		printField("Minimum / maximum", fmt.Sprintf("%.6f / %.6f RPL", math.RoundDown(minStake, 6), math.RoundDown(maxStake, 6)))
+		printField("Collateral ratio", fmt.Sprintf("%.2f%% of borrowed ETH", status.BorrowedCollateralRatio*100))
+		fmt.Println("The minimum is 10% of the ETH your minipools borrow, and the maximum is 150% of the ETH you bonded. Only RPL between those limits earns RPL rewards.")
+		if status.MinipoolCounts.Total == 0 {
+			printOk("You don't have any minipools yet, so there's no minimum to meet.")
+		} else if status.RplStake.Cmp(status.MinimumRplStake) < 0 {
+			printWarning("You're below the minimum RPL stake, so you won't earn RPL rewards and can't create new minipools. Top up with `rocketpool node stake-rpl`.")
+		} else if status.RplStake.Cmp(status.MaximumRplStake) > 0 {
+			printWarning("You're above the maximum RPL stake. The excess doesn't earn rewards, but it can be withdrawn with `rocketpool node withdraw-rpl`.")
+		} else {
+			printOk("Your RPL stake is within the range that earns rewards.")
+		}
+	}
+
+	printPitfalls(
+		"The collateral ratio moves with the RPL price, so a node that was above the minimum can fall below it without doing anything.",
+		"RPL can only be withdrawn while you stay above 150% of your bonded ETH, and not until a while after you last staked.",
+		"Gas fees spike during busy periods; the node daemon waits for gas to drop below your configured maximum, which can delay automatic tasks.",
+	)
+	return nil
+
+}
+
+func showMinipools(t *tutorial) error {
+
+	fmt.Println("Each minipool is one validator, funded by your bond plus ETH borrowed from the staking pool. A minipool moves from Initialized (waiting in the queue) to Prelaunch (deposited, waiting to be staked), to Staking, and finally to Withdrawable once the validator has exited.")
+	fmt.Println()
+
+	nodeStatus, err := t.requireRegisteredNode()
+	if err != nil || nodeStatus == nil {
+		return err
+	}
+	status, err := t.getMinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	counts := nodeStatus.MinipoolCounts
+	printField("Total", fmt.Sprint(counts.Total))
+	printField("Initialized", fmt.Sprint(counts.Initialized))
+	printField("Prelaunch", fmt.Sprint(counts.Prelaunch))
+	printField("Staking", fmt.Sprint(counts.Staking))
+	printField("Withdrawable", fmt.Sprint(counts.Withdrawable))
+	printField("Dissolved", fmt.Sprint(counts.Dissolved))
+	if counts.Total == 0 {
+		printOk("You don't have any minipools yet. Run `rocketpool node deposit` when you're ready to create one.")
+	}
+	if counts.Dissolved > 0 {
+		printWarning("Dissolved minipools weren't staked in time and won't earn rewards. Close them with `rocketpool minipool close` to recover your ETH.")
+	}
+	if counts.RefundAvailable > 0 {
+		printWarning(fmt.Sprintf("%d minipool(s) have a refund waiting. Claim it with `rocketpool minipool refund`.", counts.RefundAvailable))
+	}
+
+	// Check each staking validator's balance
+	for _, mp := range status.Minipools {
+		if mp.Status.Status != types.Staking || mp.Finalised {
+			continue
+		}
+		if !mp.Validator.Exists {
+			printWarning(fmt.Sprintf("Minipool %s is staking, but its validator isn't on the Beacon Chain yet. This is normal for a few hours after staking.", mp.Address.Hex()))
+			continue
+		}
+		balance := eth.WeiToEth(mp.Validator.Balance)
+		if balance < validatorBalance-balanceTolerance {
+			printWarning(fmt.Sprintf("Minipool %s's validator has %.6f ETH, which is below the %.0f ETH it started with. It may be offline and missing attestations.", mp.Address.Hex(), balance, validatorBalance))
+		}
+	}
+	if counts.Staking > 0 {
+		fmt.Printf("A healthy validator's balance slowly rises above %.0f ETH as it earns rewards, and the commission (shown by `rocketpool minipool status`) is the share of the borrowed ETH's rewards that you keep.\n", validatorBalance)
+	}
+
+	printPitfalls(
+		"A Prelaunch minipool must be staked within the scrub period; the node daemon does this automatically as long as it's running and has gas.",
+		"Validators lose a little ETH for every missed attestation. Short outages are cheap, but keep your clients updated and your machine online.",
+		"Exited validators need `rocketpool minipool distribute-balance` or `rocketpool minipool close` to pay out; exiting alone doesn't send you the ETH.",
+	)
+	return nil
+
+}
+
+func showFees(t *tutorial) error {
+
+	fmt.Println("Priority fees and MEV from the blocks your validators propose go to your fee recipient instead of the Beacon Chain. Rocket Pool sets this for you, either to your node's fee distributor or to the Smoothing Pool.")
+	fmt.Println()
+
+	status, err := t.requireRegisteredNode()
+	if err != nil || status == nil {
+		return err
+	}
+
+	info := status.FeeRecipientInfo
+	printField("In the Smoothing Pool", fmt.Sprint(info.IsInSmoothingPool))
+	if info.IsInSmoothingPool {
+		printField("Fee recipient", fmt.Sprintf("%s (Smoothing Pool)", info.SmoothingPoolAddress.Hex()))
+		printOk("Your fees are shared with every other Smoothing Pool member and paid out with each rewards interval.")
+	} else {
+		printField("Fee recipient", fmt.Sprintf("%s (fee distributor)", info.FeeDistributorAddress.Hex()))
+		if info.IsInOptOutCooldown {
+			printWarning(fmt.Sprintf("You recently left the Smoothing Pool. Your fee recipient stays set to the Smoothing Pool until epoch %d.", info.OptOutEpoch))
+		}
+		if !status.IsFeeDistributorInitialized {
+			printWarning("Your fee distributor hasn't been initialized yet. Run `rocketpool node initialize-fee-distributor` before creating minipools.")
+		} else if status.FeeDistributorBalance != nil && status.FeeDistributorBalance.Cmp(big.NewInt(0)) > 0 {
+			printField("Distributor balance", fmt.Sprintf("%.6f ETH", math.RoundDown(eth.WeiToEth(status.FeeDistributorBalance), 6)))
+			fmt.Println("Your share of this balance is paid out when you run `rocketpool node distribute-fees`.")
+		}
+		fmt.Printf("You can learn about the Smoothing Pool at %s.\n", smoothingPoolLink)
+	}
+
+	if len(status.PenalizedMinipools) > 0 {
+		printWarning(fmt.Sprintf("%d of your minipools have been penalized for using the wrong fee recipient. Make sure your validator client isn't overriding the fee recipient Rocket Pool sets.", len(status.PenalizedMinipools)))
+	} else {
+		printOk("None of your minipools have been penalized for using the wrong fee recipient.")
+	}
+
+	printPitfalls(
+		"Changing the fee recipient in your validator client by hand is treated as theft, and the Oracle DAO will penalize your minipools for it.",
+		"If you use MEV-Boost, the relays you pick must honor the fee recipient; use the relays offered by `rocketpool service config`.",
+	)
+	return nil
+
+}
+
+func showRewards(t *tutorial) error {
+
+	fmt.Println("Rocket Pool pays RPL rewards (and Smoothing Pool ETH, if you've joined it) at the end of every rewards interval. They accumulate until you claim them.")
+	fmt.Println()
+
+	status, err := t.requireRegisteredNode()
+	if err != nil || status == nil {
+		return err
+	}
+	rewards, err := t.rp.NodeRewards()
+	if err != nil {
+		return err
+	}
+
+	nextCheckpoint := rewards.LastCheckpoint.Add(rewards.RewardsInterval)
+	printField("Rewards interval", rewards.RewardsInterval.String())
+	printField("Next interval ends", fmt.Sprintf("%s (in %s)", nextCheckpoint.Format(time.RFC1123), time.Until(nextCheckpoint).Round(time.Minute)))
+	printField("Estimated RPL", fmt.Sprintf("%.6f RPL this interval", rewards.EstimatedRewards))
+	printField("Unclaimed RPL", fmt.Sprintf("%.6f RPL", rewards.UnclaimedRplRewards))
+	printField("Unclaimed ETH", fmt.Sprintf("%.6f ETH", rewards.UnclaimedEthRewards))
+	printField("Lifetime RPL claimed", fmt.Sprintf("%.6f RPL", rewards.CumulativeRplRewards))
+	if rewards.EstimatedRewards == 0 && status.MinipoolCounts.Staking > 0 {
+		printWarning("You aren't expected to earn RPL rewards this interval, usually because your RPL stake is below the minimum.")
+	}
+	if rewards.UnclaimedRplRewards > 0 || rewards.UnclaimedEthRewards > 0 {
+		printOk("You have rewards waiting. Claim them with `rocketpool node claim-rewards`; you can restake some or all of the RPL at the same time.")
+	}
+
+	printPitfalls(
+		"Rewards don't expire, so there's no need to pay gas to claim every interval.",
+		"The estimate changes as your collateral ratio and the RPL price move during the interval.",
+		"Validator rewards from the Beacon Chain aren't claimed here; they build up in each minipool and are paid out by distributing its balance.",
+	)
+	return nil
+
+}
+
+// Print the status of a client and its fallback
+func printClientStatus(name string, status api.ClientManagerStatus) {
+	printField(name, getClientStatusString(status.PrimaryClientStatus))
+	if status.FallbackEnabled {
+		printField(name+" fallback", getClientStatusString(status.FallbackClientStatus))
+	}
+	if status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced {
+		printOk(fmt.Sprintf("Your %s is ready.", strings.ToLower(name)))
+	} else if status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced {
+		printWarning(fmt.Sprintf("Your %s isn't ready, so your node is using its fallback for now.", strings.ToLower(name)))
+	} else {
+		printWarning(fmt.Sprintf("Your %s isn't ready yet. Most commands won't work until it finishes syncing.", strings.ToLower(name)))
+	}
+}
+
+// Get a short description of a client's status
+func getClientStatusString(status api.ClientStatus) string {
+	if !status.IsWorking {
+		return fmt.Sprintf("unavailable (%s)", status.Error)
+	}
+	if status.IsSynced {
+		return "synced and ready"
+	}
+	return fmt.Sprintf("syncing (%.2f%%)", status.SyncProgress*100)
+}
+
+// Print a labelled live value
+func printField(label string, value string) {
+	fmt.Printf("  %-22s %s%s%s\n", label+":", colorBlue, value, colorReset)
+}
+
+// Print an assessment that doesn't need any action
+func printOk(message string) {
+	fmt.Printf("  %s[OK]%s %s\n", colorGreen, colorReset, message)
+}
+
+// Print an assessment that needs the user's attention
+func printWarning(message string) {
+	fmt.Printf("  %s[!]%s %s\n", colorYellow, colorReset, message)
+}
+
+// Print the common pitfalls for a section
+func printPitfalls(pitfalls ...string) {
+	fmt.Println()
+	fmt.Printf("%sCommon pitfalls:%s\n", colorRed, colorReset)
+	for _, pitfall := range pitfalls {
+		fmt.Printf("  - %s\n", pitfall)
+	}
+}
@@ -0,0 +1,347 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const colorGreen string = "\033[32m"
+
+func manageBondReductions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the bond reduction details
+	response, err := rp.GetBondReductions()
+	if err != nil {
+		return err
+	}
+	if !response.IsAtlasDeployed {
+		fmt.Println("You cannot reduce a minipool's bond until Atlas has been deployed.")
+		return nil
+	}
+	if len(response.Minipools) == 0 {
+		fmt.Println("The node does not have any 16 ETH minipools.")
+		return nil
+	}
+
+	// Sort the minipools by phase
+	phases := map[api.BondReductionPhase][]api.MinipoolBondReductionDetails{}
+	for _, minipool := range response.Minipools {
+		phases[minipool.Phase] = append(phases[minipool.Phase], minipool)
+	}
+	ready := phases[api.BondReductionPhase_Ready]
+	waiting := phases[api.BondReductionPhase_Waiting]
+	eligible := phases[api.BondReductionPhase_Eligible]
+
+	// Print the minipools in each phase
+	if len(ready) > 0 {
+		fmt.Printf("%s%d minipool(s) ready to complete their bond reduction:%s\n", colorGreen, len(ready), colorReset)
+		for _, minipool := range ready {
+			fmt.Printf("\t%s (must be completed by %s)\n", minipool.Address.Hex(), minipool.WindowEnd.Format(TimeFormat))
+		}
+		fmt.Println()
+	}
+	if len(waiting) > 0 {
+		fmt.Printf("%d minipool(s) waiting for their bond reduction window to open:\n", len(waiting))
+		for _, minipool := range waiting {
+			fmt.Printf("\t%s (can be completed from %s to %s)\n", minipool.Address.Hex(), minipool.WindowStart.Format(TimeFormat), minipool.WindowEnd.Format(TimeFormat))
+		}
+		fmt.Println()
+	}
+	if len(eligible) > 0 {
+		fmt.Printf("%d minipool(s) eligible to begin a bond reduction:\n", len(eligible))
+		for i, minipool := range eligible {
+			fmt.Printf("\t%d: %s (commission: %.2f%% -> %.2f%%)\n", i+1, minipool.Address.Hex(), minipool.NodeFee*100, minipool.NewNodeFee*100)
+		}
+		fmt.Println()
+	}
+	if ineligible := phases[api.BondReductionPhase_Ineligible]; len(ineligible) > 0 {
+		fmt.Printf("%s%d minipool(s) not currently eligible for a bond reduction:\n", colorYellow, len(ineligible))
+		for _, minipool := range ineligible {
+			fmt.Printf("\t%s: %s\n", minipool.Address.Hex(), minipool.IneligibleReason)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+	if scrubbed := phases[api.BondReductionPhase_Scrubbed]; len(scrubbed) > 0 {
+		fmt.Printf("%s%d minipool(s) had a bond reduction scrubbed by the Oracle DAO and can no longer be reduced:\n", colorRed, len(scrubbed))
+		for _, minipool := range scrubbed {
+			fmt.Printf("\t%s\n", minipool.Address.Hex())
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+
+	// Print the collateral impact
+	fmt.Printf("Your node has %.6f RPL staked, and needs %.6f RPL to cover its current minipools and any bond reductions already in progress.\n", math.RoundDown(eth.WeiToEth(response.RplStake), 6), math.RoundUp(eth.WeiToEth(response.MinimumRplStake), 6))
+	fmt.Printf("Each bond reduction borrows another 8 ETH, which raises the minimum stake by %.6f RPL.\n", math.RoundUp(eth.WeiToEth(response.RplPerReduction), 6))
+	supportedReductions := getSupportedBondReductions(response)
+	fmt.Printf("Your current stake supports %d more bond reduction(s).\n\n", supportedReductions)
+
+	// Complete the reductions that are ready
+	if len(ready) > 0 {
+		if response.AutoCompleteEnabled {
+			fmt.Println("Your node daemon will complete the ready bond reductions automatically once the network's gas price is below its gas threshold for bond reductions (the Automatic TX Gas Threshold, unless you've set a gas ceiling for this task).")
+		} else if c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Automatic bond reductions are disabled, so your node daemon will not complete bond reductions for you. Would you like to complete the %d ready bond reduction(s) now?", len(ready))) {
+			err = completeBondReductions(c, rp, ready)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Println()
+	}
+
+	// Begin new reductions
+	if len(eligible) == 0 {
+		return nil
+	}
+	if !response.BondReductionEnabled {
+		fmt.Println("Bond reductions are currently disabled by the Protocol DAO, so new ones cannot be started.")
+		return nil
+	}
+	if !response.IsFeeDistributorInitialized {
+		fmt.Println("Minipools cannot have their bonds reduced until your fee distributor has been initialized.\nPlease run `rocketpool node initialize-fee-distributor` first, then return here to reduce your bonds.")
+		return nil
+	}
+	selectedMinipools, err := getSelectedBondReductions(c, eligible)
+	if err != nil {
+		return err
+	}
+	if len(selectedMinipools) == 0 {
+		return nil
+	}
+
+	// Make sure the node has enough RPL for the selected reductions
+	if uint64(len(selectedMinipools)) > supportedReductions {
+		requiredStake := new(big.Int).Mul(response.RplPerReduction, big.NewInt(int64(len(selectedMinipools))))
+		requiredStake.Add(requiredStake, response.MinimumRplStake)
+		shortfall := requiredStake.Sub(requiredStake, response.RplStake)
+		fmt.Printf("You do not have enough RPL staked to reduce the bonds of %d minipools; you can reduce %d with your current stake.\nYou must stake %.6f more RPL first.\n", len(selectedMinipools), supportedReductions, math.RoundUp(eth.WeiToEth(shortfall), 6))
+		return nil
+	}
+
+	return beginBondReductions(c, rp, response, selectedMinipools)
+
+}
+
+// Get the number of additional bond reductions the node's RPL stake supports
+func getSupportedBondReductions(response api.GetBondReductionsResponse) uint64 {
+	if response.RplPerReduction.Sign() == 0 || response.RplStake.Cmp(response.MinimumRplStake) <= 0 {
+		return 0
+	}
+	excess := new(big.Int).Sub(response.RplStake, response.MinimumRplStake)
+	return excess.Div(excess, response.RplPerReduction).Uint64()
+}
+
+// Get the eligible minipools to begin bond reductions for, from the flag or by prompting for them
+func getSelectedBondReductions(c *cli.Context, eligible []api.MinipoolBondReductionDetails) ([]api.MinipoolBondReductionDetails, error) {
+
+	// Get matching minipools
+	if c.String("minipool") != "" {
+		if c.String("minipool") == "all" {
+			return eligible, nil
+		}
+		selectedMinipools := []api.MinipoolBondReductionDetails{}
+		for _, element := range strings.Split(c.String("minipool"), ",") {
+			selectedAddress := common.HexToAddress(strings.TrimSpace(element))
+			found := false
+			for _, minipool := range eligible {
+				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+					selectedMinipools = append(selectedMinipools, minipool)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("The minipool %s is not eligible to begin a bond reduction.", selectedAddress.Hex())
+			}
+		}
+		return selectedMinipools, nil
+	}
+	if c.Bool("yes") {
+		// Beginning a reduction borrows more ETH and locks up RPL, so --yes alone never picks the minipools
+		fmt.Println("No minipools were selected with --minipool, so no new bond reductions will be started. Use `--minipool` with their addresses or 'all' to begin them without being prompted.")
+		return nil, nil
+	}
+
+	// Prompt for the minipools by their index
+	for {
+		indexSelection := cliutils.Prompt("Which minipools would you like to begin a bond reduction for? Use a comma separated list (such as '1,2,3'), 'all' for every eligible minipool, or leave it blank to skip.", "^$|^all$|^\\d+(,\\d+)*$", "Invalid minipool selection")
+		if indexSelection == "" {
+			return nil, nil
+		}
+		if indexSelection == "all" {
+			return eligible, nil
+		}
+
+		selectedMinipools := []api.MinipoolBondReductionDetails{}
+		seenIndices := map[uint64]bool{}
+		allValid := true
+		for _, element := range strings.Split(indexSelection, ",") {
+			index, err := strconv.ParseUint(element, 10, 64)
+			if err != nil || index == 0 || index > uint64(len(eligible)) {
+				fmt.Printf("'%s' is an invalid index; valid indices are 1 to %d.\n", element, len(eligible))
+				allValid = false
+				break
+			}
+
+			// Ignore duplicates
+			if !seenIndices[index] {
+				selectedMinipools = append(selectedMinipools, eligible[index-1])
+				seenIndices[index] = true
+			}
+		}
+		if allValid {
+			return selectedMinipools, nil
+		}
+	}
+
+}
+
+// Begin the bond reductions for the selected minipools
+func beginBondReductions(c *cli.Context, rp *rocketpool.Client, response api.GetBondReductionsResponse, selectedMinipools []api.MinipoolBondReductionDetails) error {
+
+	// Get the total gas limit estimate
+	newBondAmount := eth.EthToWei(8)
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, minipool := range selectedMinipools {
+		canResponse, err := rp.CanBeginReduceBondAmount(minipool.Address, newBondAmount)
+		if err != nil {
+			return fmt.Errorf("couldn't check if minipool %s could have its bond reduced: %w", minipool.Address.Hex(), err)
+		}
+		if !canResponse.CanReduce {
+			return fmt.Errorf("Minipool %s can no longer begin a bond reduction; please run this command again to refresh its status.", minipool.Address.Hex())
+		}
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err := gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to begin bond reduction for %d minipools from 16 ETH to 8 ETH? Each one must then be completed within %.0f hours after its %.0f-hour wait period ends, or it will time out.", len(selectedMinipools), response.WindowLength.Hours(), response.WindowStart.Hours()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Begin bond reduction
+	started := 0
+	for _, minipool := range selectedMinipools {
+		txResponse, err := rp.BeginReduceBondAmount(minipool.Address, newBondAmount)
+		if err != nil {
+			fmt.Printf("Could not begin bond reduction for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
+			continue
+		}
+
+		fmt.Printf("Beginning bond reduction for minipool %s...\n", minipool.Address.Hex())
+		cliutils.PrintTransactionHash(rp, txResponse.TxHash)
+		if _, err = rp.WaitForTransaction(txResponse.TxHash); err != nil {
+			fmt.Printf("Could not begin bond reduction for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
+		} else {
+			fmt.Printf("Successfully started bond reduction for minipool %s.\n", minipool.Address.Hex())
+			started++
+		}
+	}
+
+	// Explain the next step
+	if started > 0 {
+		fmt.Println()
+		fmt.Printf("The bond reductions can be completed in %.0f hours, and must be completed within %.0f hours after that.\n", response.WindowStart.Hours(), response.WindowLength.Hours())
+		if response.AutoCompleteEnabled {
+			fmt.Println("Your node daemon will complete them automatically; you can follow their progress by running this command again.")
+		} else {
+			fmt.Printf("%sAutomatic transactions are disabled, so you must complete them yourself by running this command again or `rocketpool minipool reduce-bond` during that window.%s\n", colorYellow, colorReset)
+		}
+	}
+	return nil
+
+}
+
+// Complete the bond reductions that are ready
+func completeBondReductions(c *cli.Context, rp *rocketpool.Client, ready []api.MinipoolBondReductionDetails) error {
+
+	// Workaround for the fee distribution issue
+	err := forceFeeDistribution(c, rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, minipool := range ready {
+		canResponse, err := rp.CanReduceBondAmount(minipool.Address)
+		if err != nil {
+			return fmt.Errorf("error checking if minipool %s can have its bond reduced: %w", minipool.Address.Hex(), err)
+		}
+		if !canResponse.CanReduce {
+			fmt.Printf("Minipool %s cannot have its bond reduced:\n", minipool.Address.Hex())
+			fmt.Println("The minipool version is too low. Please run `rocketpool minipool delegate-upgrade` to update it.")
+			return nil
+		}
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Complete bond reduction
+	for _, minipool := range ready {
+		response, err := rp.ReduceBondAmount(minipool.Address)
+		if err != nil {
+			fmt.Printf("Could not reduce bond for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
+			continue
+		}
+
+		fmt.Printf("Reducing bond for minipool %s...\n", minipool.Address.Hex())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			fmt.Printf("Could not reduce bond for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
+		} else {
+			fmt.Printf("Successfully reduced bond for minipool %s.\n", minipool.Address.Hex())
+		}
+	}
+	return nil
+
+}
@@ -60,6 +60,7 @@ func closeMinipools(c *cli.Context) error {
 	versionTooLowMinipools := []api.MinipoolCloseDetails{}
 	balanceLessThanRefundMinipools := []api.MinipoolCloseDetails{}
 	unwithdrawnMinipools := []api.MinipoolCloseDetails{}
+	unfinalizedMinipools := []api.MinipoolCloseDetails{}
 
 	for _, mp := range details.Details {
 		if mp.IsFinalized {
@@ -75,9 +76,12 @@ func closeMinipools(c *cli.Context) error {
 			if mp.Balance.Cmp(mp.Refund) == -1 {
 				balanceLessThanRefundMinipools = append(balanceLessThanRefundMinipools, mp)
 			}
-			if mp.MinipoolStatus != types.Dissolved &&
-				mp.BeaconState != beacon.ValidatorState_WithdrawalDone {
-				unwithdrawnMinipools = append(unwithdrawnMinipools, mp)
+			if mp.MinipoolStatus != types.Dissolved {
+				if mp.BeaconState != beacon.ValidatorState_WithdrawalDone {
+					unwithdrawnMinipools = append(unwithdrawnMinipools, mp)
+				} else if !mp.ExitFinalized {
+					unfinalizedMinipools = append(unfinalizedMinipools, mp)
+				}
 			}
 		}
 	}
@@ -90,6 +94,13 @@ func closeMinipools(c *cli.Context) error {
 		}
 		fmt.Printf("\nTo close them, first run `rocketpool minipool exit` on them and wait until their balances have been withdrawn.%s\n\n", colorReset)
 	}
+	if len(unfinalizedMinipools) > 0 {
+		fmt.Printf("%sNOTE: The following minipools have been withdrawn from the Beacon Chain, but their withdrawals haven't been finalized yet:\n", colorBlue)
+		for _, mp := range unfinalizedMinipools {
+			fmt.Printf("\t%s\n", mp.Address)
+		}
+		fmt.Printf("\nThey can be closed once the Beacon Chain finalizes their withdrawals, which usually takes about 15 minutes.%s\n\n", colorReset)
+	}
 	if len(versionTooLowMinipools) > 0 {
 		fmt.Printf("%sWARNING: The following minipools are using an old delegate and cannot be safely closed:\n", colorYellow)
 		for _, mp := range versionTooLowMinipools {
@@ -113,7 +124,21 @@ func closeMinipools(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolCloseDetails
-	if c.String("minipool") == "" {
+	if c.Bool("all-eligible") {
+
+		// Take every closable minipool that won't lose any ETH; the ones that need a confirmation have to be closed on their own
+		selectedMinipools = getEligibleMinipools(closableMinipools)
+		if len(selectedMinipools) == 0 {
+			fmt.Println("No minipools can be closed without a loss of ETH. Please close them individually with `rocketpool minipool close --minipool`.")
+			return nil
+		}
+		fmt.Printf("%d minipool(s) are eligible to be closed:\n", len(selectedMinipools))
+		for _, minipool := range selectedMinipools {
+			fmt.Printf("\t%s (%.6f ETH will be returned)\n", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(getReturnedEth(minipool)), 6))
+		}
+		fmt.Println()
+
+	} else if c.String("minipool") == "" {
 
 		// Prompt for minipool selection
 		options := make([]string, len(closableMinipools)+1)
@@ -212,6 +237,8 @@ func closeMinipools(c *cli.Context) error {
 	}
 
 	// Close minipools
+	closedCount := 0
+	returnedEth := big.NewInt(0)
 	for _, minipool := range selectedMinipools {
 
 		response, err := rp.CloseMinipool(minipool.Address)
@@ -226,10 +253,44 @@ func closeMinipools(c *cli.Context) error {
 			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
 		} else {
 			fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
+			closedCount++
+			returnedEth.Add(returnedEth, getReturnedEth(minipool))
 		}
 	}
 
+	// Report the total
+	if len(selectedMinipools) > 1 {
+		fmt.Printf("\nClosed %d of %d minipools, returning a total of %.6f ETH to your node.\n", closedCount, len(selectedMinipools), math.RoundDown(eth.WeiToEth(returnedEth), 6))
+	}
+
 	// Return
 	return nil
 
 }
+
+// Get the closable minipools that can be closed without a loss of ETH, which don't need any confirmation
+func getEligibleMinipools(closableMinipools []api.MinipoolCloseDetails) []api.MinipoolCloseDetails {
+	thirtyTwo := eth.EthToWei(32)
+	eligibleMinipools := []api.MinipoolCloseDetails{}
+	for _, minipool := range closableMinipools {
+		if minipool.MinipoolStatus == types.Dissolved {
+			eligibleMinipools = append(eligibleMinipools, minipool)
+			continue
+		}
+		distributableBalance := big.NewInt(0).Sub(minipool.Balance, minipool.Refund)
+		if distributableBalance.Cmp(thirtyTwo) < 0 {
+			fmt.Printf("%sSkipping minipool %s: it has a distributable balance of %.6f ETH, so closing it would lose ETH. Please close it individually with `rocketpool minipool close --minipool`.%s\n", colorYellow, minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(distributableBalance), 6), colorReset)
+			continue
+		}
+		eligibleMinipools = append(eligibleMinipools, minipool)
+	}
+	return eligibleMinipools
+}
+
+// Get the amount of ETH closing a minipool will send to the node
+func getReturnedEth(minipool api.MinipoolCloseDetails) *big.Int {
+	if minipool.MinipoolStatus == types.Dissolved {
+		return minipool.Balance
+	}
+	return big.NewInt(0).Add(minipool.NodeShare, minipool.Refund)
+}
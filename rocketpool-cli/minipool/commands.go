@@ -1,9 +1,13 @@
 package minipool
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 // Register commands
@@ -24,6 +28,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "include-finalized, f",
 						Usage: "Include finalized minipools in the list (default is to hide them).",
 					},
+					cli.BoolFlag{
+						Name:  "detailed, d",
+						Usage: "Include each staking minipool's projected annual income, based on the recent staking APR.",
+					},
+					cli.StringFlag{
+						Name:  "tag, t",
+						Usage: "Only show the minipools with this tag.",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -41,13 +53,21 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
-				Usage:     "Stake a minipool after the scrub check, moving it from prelaunch to staking.",
+				Usage:     "Stake minipools after the scrub check, moving them from prelaunch to staking.",
 				UsageText: "rocketpool minipool stake [options]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to stake (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Stake all of the minipools that have passed the scrub check",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm each stake transaction",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -57,6 +77,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if c.Bool("all") && c.String("minipool") != "" {
+						return fmt.Errorf("The --all and --minipool flags can't be used together.")
+					}
 					if c.String("minipool") != "" && c.String("minipool") != "all" {
 						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
 							return err
@@ -69,6 +92,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-deposit-data",
+				Aliases:   []string{"dd"},
+				Usage:     "Export the deposit data for prelaunch minipools' stake deposits in the launchpad format, so it can be checked with external tools before staking",
+				UsageText: "rocketpool minipool export-deposit-data [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to export the deposit data for (address or 'all', defaults to all)",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the deposit data to (prints it to the terminal if blank)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return exportDepositData(c)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-creds",
 				Aliases:   []string{"swc"},
@@ -255,6 +313,130 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "bond-reductions",
+				Aliases:   []string{"brs"},
+				Usage:     "Lists the minipools that can have their bond reduced from 16 ETH to 8 ETH along with the RPL collateral impact, and begins or completes the reductions for the ones you select.",
+				UsageText: "rocketpool minipool bond-reductions [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to begin a bond reduction for (comma-separated addresses or 'all')",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the bond reductions; new ones are only begun for the minipools given with --minipool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						for _, address := range strings.Split(c.String("minipool"), ",") {
+							if _, err := cliutils.ValidateAddress("minipool address", strings.TrimSpace(address)); err != nil {
+								return err
+							}
+						}
+					}
+
+					// Run
+					return manageBondReductions(c)
+
+				},
+			},
+
+			{
+				Name:      "migrate-plan",
+				Aliases:   []string{"mp"},
+				Usage:     "Plan the migration of your 16 ETH minipools to 8 ETH bonds, including the order of the bond reductions, the RPL they need, and the deposit credit they free up",
+				UsageText: "rocketpool minipool migrate-plan [--execute] [--yes]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "execute, e",
+						Usage: "Carry out the plan step by step after printing it",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm each step of the plan",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return migratePlan(c)
+
+				},
+			},
+
+			{
+				Name:      "exit-advice",
+				Aliases:   []string{"ea"},
+				Usage:     "Rank your minipools by how much more they would earn if you exited them and redeployed the ETH into new 8 ETH minipools",
+				UsageText: "rocketpool minipool exit-advice [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "apr, a",
+						Usage: "The staking APR to use for the estimates, as a percentage (e.g. 4.5); by default they are shown per 1% of APR",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.Float64("apr") < 0 {
+						return fmt.Errorf("Invalid APR '%f' - must be a positive percentage", c.Float64("apr"))
+					}
+
+					// Run
+					return getExitAdvice(c)
+
+				},
+			},
+
+			{
+				Name:      "performance",
+				Aliases:   []string{"pf"},
+				Usage:     "Score your minipools' attestations and proposals over a window of epochs against the network average",
+				UsageText: "rocketpool minipool performance [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: "The number of epochs to score the minipools over, ending two epochs ago so all of their attestations have been included",
+						Value: rputils.DefaultPerformanceEpochs,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.Uint64("interval") == 0 {
+						return fmt.Errorf("Invalid interval - must be at least 1 epoch")
+					}
+
+					// Run
+					return getPerformance(c)
+
+				},
+			},
+
 			{
 				Name:      "distribute-balance",
 				Aliases:   []string{"d"},
@@ -364,6 +546,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to close (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "all-eligible",
+						Usage: "Close every dissolved or fully withdrawn minipool whose withdrawal has been finalized and that won't lose any ETH",
+					},
 					cli.BoolFlag{
 						Name:  "confirm-slashing",
 						Usage: "Reserved for acknowledging situations where you've been slashed by the Beacon Chain, and closing a minipool will result in the complete loss of the ETH bond and your RPL collateral. DO NOT use this flag unless you have been explicitly instructed to do so.",
@@ -377,6 +563,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if c.String("minipool") != "" && c.Bool("all-eligible") {
+						return fmt.Errorf("--minipool and --all-eligible can't be used together")
+					}
 					if c.String("minipool") != "" && c.String("minipool") != "all" {
 						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
 							return err
@@ -488,8 +677,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 			{
 				Name:      "find-vanity-address",
-				Aliases:   []string{"v"},
-				Usage:     "Search for a custom vanity minipool address",
+				Aliases:   []string{"find-vanity-salt", "v"},
+				Usage:     "Search for a custom vanity minipool address using all of your CPU cores or an OpenCL GPU; the search can be interrupted and resumed later",
 				UsageText: "rocketpool minipool find-vanity-address [options]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
@@ -504,6 +693,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "threads, t",
 						Usage: "The number of threads to use for searching (defaults to your CPU thread count)",
 					},
+					cli.BoolFlag{
+						Name:  "gpu, g",
+						Usage: "Search on the first OpenCL GPU instead of the CPU (requires a CLI built with `-tags opencl`)",
+					},
 					cli.StringFlag{
 						Name:  "node-address, n",
 						Usage: "The node address to search for (leave blank to use the local node)",
@@ -512,6 +705,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "amount, a",
 						Usage: "The bond amount to be used for the minipool, in ETH (impacts vanity address generation)",
 					},
+					cli.BoolFlag{
+						Name:  "resume, r",
+						Usage: "Resume the last search for the same prefix, node address, and bond amount from where it was stopped",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -527,6 +724,80 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "labels",
+				Usage:     "List the labels you've given your minipools",
+				UsageText: "rocketpool minipool labels",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getLabels(c)
+
+				},
+			},
+
+			{
+				Name:      "set-label",
+				Usage:     "Give one of your minipools a label, which is shown alongside its address; leave the label out to remove it",
+				UsageText: "rocketpool minipool set-label minipool-address [label]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if len(c.Args()) < 1 || len(c.Args()) > 2 {
+						return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+					}
+
+					// Run
+					return setLabel(c)
+
+				},
+			},
+
+			{
+				Name:      "tags",
+				Usage:     "List the tags you've given your minipools, or the minipools with a specific tag",
+				UsageText: "rocketpool minipool tags [tag]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if len(c.Args()) > 1 {
+						return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+					}
+
+					// Run
+					return getTags(c)
+
+				},
+			},
+
+			{
+				Name:      "tag",
+				Usage:     "Tag one of your minipools, for example to mark it for an exit, a migration, or testing; tags are shown in `rocketpool minipool status`",
+				UsageText: "rocketpool minipool tag minipool-address tag",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "remove, r",
+						Usage: "Remove the tag from the minipool instead of adding it",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					return tagMinipool(c)
+
+				},
+			},
 		},
 	})
 }
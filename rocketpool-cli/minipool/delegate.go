@@ -63,7 +63,7 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 			options := make([]string, len(minipools)+1)
 			options[0] = "All available minipools"
 			for mi, minipool := range minipools {
-				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
+				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", getMinipoolName(minipool), minipool.Delegate.Hex())
 			}
 			selected, _ := cliutils.Select("Please select a minipool to upgrade:", options)
 
@@ -176,7 +176,7 @@ func delegateRollbackMinipools(c *cli.Context) error {
 			options := make([]string, len(minipools)+1)
 			options[0] = "All available minipools"
 			for mi, minipool := range minipools {
-				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
+				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", getMinipoolName(minipool), minipool.Delegate.Hex())
 			}
 			selected, _ := cliutils.Select("Please select a minipool to rollback the delegate for:", options)
 
@@ -293,7 +293,7 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 		options := make([]string, len(minipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range minipools {
-			options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
+			options[mi+1] = fmt.Sprintf("%s (using delegate %s)", getMinipoolName(minipool), minipool.Delegate.Hex())
 		}
 		var action string
 		if setting {
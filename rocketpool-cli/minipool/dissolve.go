@@ -60,7 +60,7 @@ func dissolveMinipools(c *cli.Context) error {
 		options := make([]string, len(initializedMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range initializedMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%.6f ETH deposited)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
+			options[mi+1] = fmt.Sprintf("%s (%.6f ETH deposited)", getMinipoolName(minipool), math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
 		}
 		selected, _ := cliutils.Select("Please select a minipool to dissolve:", options)
 
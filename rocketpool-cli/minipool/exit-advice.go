@@ -0,0 +1,92 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getExitAdvice(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the advice
+	response, err := rp.GetMinipoolExitAdvice()
+	if err != nil {
+		return err
+	}
+	report := response.Report
+	if len(report.Minipools) == 0 {
+		fmt.Println("Your node does not have any active staking minipools.")
+		return nil
+	}
+
+	// Apply the APR, if one was given
+	apr := c.Float64("apr")
+	unit := "ETH per year per 1% APR"
+	scale := 1.0
+	if apr > 0 {
+		unit = fmt.Sprintf("ETH per year at %.2f%% APR", apr)
+		scale = apr
+	}
+
+	fmt.Printf("New minipools currently earn a commission of %.2f%%.\n", report.NetworkCommission*100)
+	fmt.Printf("Your node has %.6f RPL staked (the minimum for its current minipools is %.6f RPL).\n", math.RoundDown(eth.WeiToEth(report.RplStake), 6), math.RoundUp(eth.WeiToEth(report.MinimumRplStake), 6))
+	fmt.Println("Each minipool is compared on its own to exiting it and using the ETH it returns for new 8 ETH minipools.")
+	fmt.Println("The estimates don't include RPL rewards, gas costs, or the time spent in the exit and deposit queues.")
+	fmt.Println()
+
+	// Print the ranked list
+	for i, advice := range report.Minipools {
+		color := colorYellow
+		switch advice.Recommendation {
+		case rputils.ExitRecommendation_Keep:
+			color = colorReset
+		case rputils.ExitRecommendation_ReduceBond:
+			color = colorGreen
+		}
+		fmt.Printf("%s%d. Minipool %s (validator %d)%s\n", color, i+1, advice.MinipoolAddress.Hex(), advice.ValidatorIndex, colorReset)
+		fmt.Printf("\tBond:             %.0f ETH at %.2f%% commission\n", advice.BondEth, advice.Commission*100)
+		fmt.Printf("\tReturned on exit: %.6f ETH (validator balance %.6f ETH)\n", math.RoundDown(advice.RecoverableEth, 6), math.RoundDown(advice.ValidatorBalance, 6))
+		fmt.Printf("\tKeep:             %.6f %s\n", advice.KeepReturn*scale, unit)
+		fmt.Printf("\tRedeploy:         %.6f %s (%d new minipool(s) at %.2f%%", advice.RedeployReturn*scale, unit, advice.RedeployMinipools, advice.RedeployCommission*100)
+		if advice.UnusedEth > 0 {
+			fmt.Printf(", %.6f ETH left over", math.RoundDown(advice.UnusedEth, 6))
+		}
+		fmt.Println(")")
+		fmt.Printf("\tDifference:       %+.6f %s\n", advice.MarginalReturn*scale, unit)
+		if advice.AdditionalRpl.Sign() > 0 {
+			fmt.Printf("\t%sRedeploying borrows %.0f more ETH; you must stake %.6f more RPL to meet the minimum collateral for it.%s\n", colorYellow, advice.AdditionalBorrowed, math.RoundUp(eth.WeiToEth(advice.AdditionalRpl), 6), colorReset)
+		}
+
+		switch advice.Recommendation {
+		case rputils.ExitRecommendation_Keep:
+			fmt.Println("\tRecommendation:   keep this minipool running.")
+		case rputils.ExitRecommendation_ReduceBond:
+			fmt.Println("\tRecommendation:   reduce its bond to 8 ETH with 'rocketpool minipool begin-bond-reduction' instead of exiting; this borrows the extra ETH without leaving the validator set.")
+		case rputils.ExitRecommendation_Redeploy:
+			fmt.Println("\tRecommendation:   exiting and redeploying would earn more. Use 'rocketpool minipool exit', then 'rocketpool minipool close' and 'rocketpool node deposit' once the ETH is withdrawn.")
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
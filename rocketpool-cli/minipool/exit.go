@@ -56,7 +56,7 @@ func exitMinipools(c *cli.Context) error {
 		options := make([]string, len(activeMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range activeMinipools {
-			options[mi+1] = fmt.Sprintf("%s (staking since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
+			options[mi+1] = fmt.Sprintf("%s (staking since %s)", getMinipoolName(minipool), minipool.Status.StatusTime.Format(TimeFormat))
 		}
 		selected, _ := cliutils.Select("Please select a minipool to exit:", options)
 
@@ -0,0 +1,102 @@
+package minipool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func exportDepositData(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get prelaunch minipools
+	prelaunchMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status == types.Prelaunch && !minipool.Status.IsVacant {
+			prelaunchMinipools = append(prelaunchMinipools, minipool)
+		}
+	}
+
+	// Check for prelaunch minipools
+	if len(prelaunchMinipools) == 0 {
+		fmt.Println("No minipools are waiting to be staked.")
+		return nil
+	}
+
+	// Get selected minipools
+	var selectedMinipools []api.MinipoolDetails
+	if c.String("minipool") == "" || c.String("minipool") == "all" {
+		selectedMinipools = prelaunchMinipools
+	} else {
+		selectedAddress := common.HexToAddress(c.String("minipool"))
+		for _, minipool := range prelaunchMinipools {
+			if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+				selectedMinipools = []api.MinipoolDetails{minipool}
+				break
+			}
+		}
+		if selectedMinipools == nil {
+			return fmt.Errorf("The minipool %s is not waiting to be staked.", selectedAddress.Hex())
+		}
+	}
+
+	// Get the deposit data for each minipool
+	depositData := make([]api.LaunchpadDepositData, 0, len(selectedMinipools))
+	for _, minipool := range selectedMinipools {
+		response, err := rp.GetMinipoolDepositData(minipool.Address)
+		if err != nil {
+			return fmt.Errorf("Could not get the deposit data for minipool %s: %w", minipool.Address.Hex(), err)
+		}
+		depositData = append(depositData, response.DepositData)
+	}
+
+	// Serialize it
+	data, err := json.MarshalIndent(depositData, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not serialize the deposit data: %w", err)
+	}
+
+	// Print it or write it to the output file
+	output := c.String("output")
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	err = os.WriteFile(output, data, 0644)
+	if err != nil {
+		return fmt.Errorf("Could not write the deposit data to %s: %w", output, err)
+	}
+
+	fmt.Printf("Wrote the deposit data for %d minipool(s) to %s.\n", len(depositData), output)
+	fmt.Println("Each entry is for the minipool's remaining stake deposit, and uses the minipool's address as its withdrawal credentials.")
+	fmt.Printf("You can check it with external tools before staking, for example: `ethdo deposit verify --data=%s --withdrawaladdress=<minipool address> --depositvalue=\"31 Ether\"`.\n", output)
+	return nil
+
+}
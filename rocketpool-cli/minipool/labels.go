@@ -0,0 +1,72 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getLabels(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the labels
+	response, err := rp.GetMinipoolLabels()
+	if err != nil {
+		return err
+	}
+	if len(response.Labels) == 0 {
+		fmt.Println("None of your minipools have a label. You can give them one with `rocketpool minipool set-label`.")
+		return nil
+	}
+
+	for _, label := range response.Labels {
+		fmt.Printf("%s  %s\n", label.Address.Hex(), label.Label)
+	}
+	return nil
+
+}
+
+func setLabel(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the arguments
+	minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	label := c.Args().Get(1)
+
+	// Set the label
+	if _, err := rp.SetMinipoolLabel(minipoolAddress, label); err != nil {
+		return err
+	}
+
+	if label == "" {
+		fmt.Printf("Removed the label from minipool %s.\n", minipoolAddress.Hex())
+	} else {
+		fmt.Printf("Minipool %s is now labelled '%s'.\n", minipoolAddress.Hex(), label)
+	}
+	return nil
+
+}
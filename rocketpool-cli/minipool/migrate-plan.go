@@ -0,0 +1,233 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func migratePlan(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the plan
+	plan, err := rp.GetMigratePlan()
+	if err != nil {
+		return err
+	}
+	reductions := plan.BondReductions
+	if !reductions.IsAtlasDeployed {
+		fmt.Println("You cannot reduce a minipool's bond until Atlas has been deployed.")
+		return nil
+	}
+	if len(reductions.Minipools) == 0 {
+		fmt.Println("The node does not have any 16 ETH minipools.")
+		return nil
+	}
+	if len(plan.Steps) == 0 {
+		fmt.Println("None of the node's 16 ETH minipools can be migrated right now; run `rocketpool minipool bond-reductions` to see why.")
+		return nil
+	}
+
+	// Print the plan
+	fmt.Printf("%sMigration plan for %d 16 ETH minipool(s):%s\n", colorGreen, len(reductions.Minipools), colorReset)
+	for i, step := range plan.Steps {
+		switch step.Action {
+		case api.MigratePlanAction_CompleteReduction:
+			fmt.Printf("%d. Complete the bond reduction for %s before %s (commission: %.2f%% -> %.2f%%)\n", i+1, step.Minipool.Hex(), step.WindowEnd.Format(TimeFormat), step.NodeFee*100, step.NewNodeFee*100)
+		case api.MigratePlanAction_WaitForReduction:
+			fmt.Printf("%d. Wait for the bond reduction of %s to become ready, then complete it before %s\n", i+1, step.Minipool.Hex(), step.WindowEnd.Format(TimeFormat))
+		case api.MigratePlanAction_StakeRpl:
+			fmt.Printf("%d. Stake %.6f RPL from the node wallet\n", i+1, math.RoundUp(eth.WeiToEth(step.RplAmount), 6))
+		case api.MigratePlanAction_BeginReduction:
+			fmt.Printf("%d. Begin a bond reduction for %s (commission: %.2f%% -> %.2f%%)\n", i+1, step.Minipool.Hex(), step.NodeFee*100, step.NewNodeFee*100)
+		}
+	}
+	fmt.Println()
+	if len(plan.BlockedMinipools) > 0 {
+		fmt.Printf("%s%d eligible minipool(s) were left out because the node doesn't have enough RPL to cover them.\nStaking another %.6f RPL on top of the node wallet's balance would cover them all.%s\n\n", colorYellow, len(plan.BlockedMinipools), math.RoundUp(eth.WeiToEth(plan.RplShortfall), 6), colorReset)
+	}
+
+	// Print the projected position
+	fmt.Println("Once every reduction in the plan is complete:")
+	fmt.Printf("\tYour deposit credit will be %.6f ETH (currently %.6f ETH), which can be used to create new minipools with `rocketpool node deposit`.\n", math.RoundDown(eth.WeiToEth(plan.ProjectedCreditBalance), 6), math.RoundDown(eth.WeiToEth(plan.CreditBalance), 6))
+	fmt.Printf("\tThe most RPL your node can have staked before it can withdraw any will be %.6f RPL (currently %.6f RPL).\n", math.RoundDown(eth.WeiToEth(plan.ProjectedMaximumRplStake), 6), math.RoundDown(eth.WeiToEth(plan.MaximumRplStake), 6))
+	if plan.ProjectedWithdrawableRpl.Sign() > 0 {
+		fmt.Printf("\tYou will be able to unstake up to %.6f RPL with `rocketpool node withdraw-rpl`.\n", math.RoundDown(eth.WeiToEth(plan.ProjectedWithdrawableRpl), 6))
+	} else {
+		fmt.Println("\tYou will not be able to unstake any RPL.")
+	}
+	fmt.Println()
+
+	// Stop here unless the plan should be executed
+	if !c.Bool("execute") {
+		fmt.Println("Run this command again with `--execute` to carry out the plan step by step.")
+		return nil
+	}
+	return executeMigratePlan(c, rp, plan)
+
+}
+
+// Carry out the steps of a migration plan, confirming each one
+func executeMigratePlan(c *cli.Context, rp *rocketpool.Client, plan api.MinipoolMigratePlanResponse) error {
+
+	// Sort the steps by action
+	reductions := plan.BondReductions
+	readyAddresses := map[string]bool{}
+	beginAddresses := map[string]bool{}
+	rplToStake := big.NewInt(0)
+	for _, step := range plan.Steps {
+		switch step.Action {
+		case api.MigratePlanAction_CompleteReduction:
+			readyAddresses[step.Minipool.Hex()] = true
+		case api.MigratePlanAction_BeginReduction:
+			beginAddresses[step.Minipool.Hex()] = true
+		case api.MigratePlanAction_StakeRpl:
+			rplToStake = step.RplAmount
+		}
+	}
+	var ready, begin []api.MinipoolBondReductionDetails
+	for _, minipool := range reductions.Minipools {
+		if readyAddresses[minipool.Address.Hex()] {
+			ready = append(ready, minipool)
+		} else if beginAddresses[minipool.Address.Hex()] {
+			begin = append(begin, minipool)
+		}
+	}
+
+	// Complete the ready reductions
+	if len(ready) > 0 {
+		if c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to complete the %d ready bond reduction(s) now?", len(ready))) {
+			err := completeBondReductions(c, rp, ready)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Println()
+	}
+
+	// Begin new reductions if they're allowed
+	if len(begin) == 0 {
+		return nil
+	}
+	if !reductions.BondReductionEnabled {
+		fmt.Println("Bond reductions are currently disabled by the Protocol DAO, so new ones cannot be started.")
+		return nil
+	}
+	if !reductions.IsFeeDistributorInitialized {
+		fmt.Println("Minipools cannot have their bonds reduced until your fee distributor has been initialized.\nPlease run `rocketpool node initialize-fee-distributor` first, then return here to continue the plan.")
+		return nil
+	}
+
+	// Stake the RPL they need
+	if rplToStake.Sign() > 0 {
+		staked, err := stakeRplForMigration(c, rp, rplToStake)
+		if err != nil {
+			return err
+		}
+		if !staked {
+			fmt.Println("The new bond reductions need the extra RPL, so they won't be started.")
+			return nil
+		}
+		fmt.Println()
+
+		// If a custom nonce is set, increment it for the next transaction
+		if c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+	}
+
+	return beginBondReductions(c, rp, reductions, begin)
+
+}
+
+// Stake the RPL a migration plan needs, approving it for staking first if required
+func stakeRplForMigration(c *cli.Context, rp *rocketpool.Client, amountWei *big.Int) (bool, error) {
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to stake %.6f RPL to cover the new bond reductions? You will not be able to unstake this RPL until you exit your validators and close your minipools, or reach over 150%% collateral!", math.RoundUp(eth.WeiToEth(amountWei), 6)))) {
+		return false, nil
+	}
+
+	// Approve the staking contract if needed
+	allowance, err := rp.GetNodeStakeRplAllowance()
+	if err != nil {
+		return false, err
+	}
+	if allowance.Allowance.Cmp(amountWei) < 0 {
+		fmt.Println("Before staking RPL, you must first give the staking contract approval to interact with your RPL.")
+		maxApproval := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil), big.NewInt(1))
+		approvalGas, err := rp.NodeStakeRplApprovalGas(maxApproval)
+		if err != nil {
+			return false, err
+		}
+		err = gas.AssignMaxFeeAndLimit(approvalGas.GasInfo, rp, c.Bool("yes"))
+		if err != nil {
+			return false, err
+		}
+		response, err := rp.NodeStakeRplApprove(maxApproval)
+		if err != nil {
+			return false, err
+		}
+		fmt.Printf("Approving RPL for staking...\n")
+		cliutils.PrintTransactionHash(rp, response.ApproveTxHash)
+		if _, err = rp.WaitForTransaction(response.ApproveTxHash); err != nil {
+			return false, err
+		}
+
+		// If a custom nonce is set, increment it for the next transaction
+		if c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+	}
+
+	// Check RPL can be staked
+	canStake, err := rp.CanNodeStakeRpl(amountWei)
+	if err != nil {
+		return false, err
+	}
+	if !canStake.CanStake {
+		fmt.Println("Cannot stake RPL:")
+		if canStake.InsufficientBalance {
+			fmt.Println("The node's RPL balance is insufficient.")
+		}
+		return false, nil
+	}
+	err = gas.AssignMaxFeeAndLimit(canStake.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Stake RPL
+	stakeResponse, err := rp.NodeStakeRpl(amountWei)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("Staking RPL...\n")
+	cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
+	if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
+		return false, err
+	}
+	fmt.Printf("Successfully staked %.6f RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+	return true, nil
+
+}
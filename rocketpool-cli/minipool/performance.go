@@ -0,0 +1,84 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Minipools scoring below this are attesting noticeably worse than the network
+const lowPerformanceScore float64 = 95
+
+func getPerformance(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the scores
+	epochs := c.Uint64("interval")
+	fmt.Printf("Checking every block and committee in the last %d epochs; this can take a few minutes...\n\n", epochs)
+	start := time.Now()
+	response, err := rp.GetMinipoolPerformance(epochs)
+	if err != nil {
+		return err
+	}
+	report := response.Report
+	if len(report.Minipools) == 0 {
+		fmt.Printf("None of your minipools had any attestation duties between epochs %d and %d.\n", report.StartEpoch, report.EndEpoch)
+		return nil
+	}
+
+	// Print the network averages
+	fmt.Printf("Performance for epochs %d to %d (checked in %s):\n", report.StartEpoch, report.EndEpoch, time.Since(start).Round(time.Second))
+	fmt.Printf("Network attestation rate: %.2f%% (%d of %d attestations included)\n", report.NetworkAttestationRate*100, report.NetworkAttestations, report.NetworkAttestationDuties)
+	fmt.Printf("Network proposal rate:    %.2f%% (%d of %d slots had a block)\n", report.NetworkProposalRate*100, report.NetworkProposals, report.NetworkSlots)
+	fmt.Println("Each minipool's score is its attestation rate compared to the network's, so 100 is the network average.")
+	fmt.Println()
+
+	// Print each minipool, weakest first
+	lowScores := 0
+	missedProposals := uint64(0)
+	for _, minipool := range report.Minipools {
+		color := colorReset
+		if minipool.Score < lowPerformanceScore {
+			color = colorYellow
+			lowScores++
+		}
+		fmt.Printf("%sMinipool %s (validator %d)%s\n", color, minipool.MinipoolAddress.Hex(), minipool.ValidatorIndex, colorReset)
+		fmt.Printf("\tScore:        %s%.1f%s\n", color, minipool.Score, colorReset)
+		fmt.Printf("\tAttestations: %d of %d included (%.2f%%)\n", minipool.Attestations, minipool.AttestationDuties, minipool.AttestationRate*100)
+		if minipool.ProposalDuties > 0 {
+			proposalColor := colorReset
+			if minipool.Proposals < minipool.ProposalDuties {
+				proposalColor = colorRed
+				missedProposals += minipool.ProposalDuties - minipool.Proposals
+			}
+			fmt.Printf("\tProposals:    %s%d of %d proposed%s\n", proposalColor, minipool.Proposals, minipool.ProposalDuties, colorReset)
+		}
+		fmt.Println()
+	}
+
+	// Summarize anything worth looking into
+	if lowScores > 0 {
+		fmt.Printf("%s%d minipool(s) scored below %.0f. Check your Validator Client's logs, your peer count, and whether your machine is keeping up with the chain.%s\n", colorYellow, lowScores, lowPerformanceScore, colorReset)
+	}
+	if missedProposals > 0 {
+		fmt.Printf("%sYour minipools missed %d block proposal(s) in this window.%s\n", colorRed, missedProposals, colorReset)
+	}
+	return nil
+
+}
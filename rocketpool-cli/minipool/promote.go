@@ -57,7 +57,7 @@ func promoteMinipools(c *cli.Context) error {
 		options := make([]string, len(promotableMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range promotableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
+			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", getMinipoolName(minipool), minipool.TimeUntilDissolve)
 		}
 		selected, _ := cliutils.Select("Please select a minipool to promote:", options)
 
@@ -117,7 +117,7 @@ func beginReduceBondAmount(c *cli.Context) error {
 		options := make([]string, len(reduceableMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range reduceableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH, commission: %.2f%%)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)), minipool.Node.Fee*100)
+			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH, commission: %.2f%%)", getMinipoolName(minipool), int(eth.WeiToEth(minipool.Node.DepositBalance)), minipool.Node.Fee*100)
 		}
 		selected, _ := cliutils.Select("Please select a minipool to begin the ETH bond reduction for:", options)
 
@@ -261,7 +261,7 @@ func reduceBondAmount(c *cli.Context) error {
 		return err
 	}
 
-	fmt.Println("NOTE: this function is used to complete the bond reduction process for a minipool. If you haven't started the process already, please run `rocketpool minipool begin-bond-reduction` first.\n")
+	fmt.Print("NOTE: this function is used to complete the bond reduction process for a minipool. If you haven't started the process already, please run `rocketpool minipool begin-bond-reduction` first.\n\n")
 
 	// Get reduceable minipools
 	reduceableMinipools := []api.MinipoolDetails{}
@@ -292,7 +292,7 @@ func reduceBondAmount(c *cli.Context) error {
 		options := make([]string, len(reduceableMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range reduceableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)))
+			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH)", getMinipoolName(minipool), int(eth.WeiToEth(minipool.Node.DepositBalance)))
 		}
 		selected, _ := cliutils.Select("Please select a minipool to reduce the ETH bond for:", options)
 
@@ -387,10 +387,10 @@ func forceFeeDistribution(c *cli.Context, rp *rocketpool.Client) error {
 
 	balance := eth.WeiToEth(canDistributeResponse.Balance)
 	if balance == 0 {
-		fmt.Println("Your fee distributor does not have any ETH and does not need to be distributed.\n")
+		fmt.Print("Your fee distributor does not have any ETH and does not need to be distributed.\n\n")
 		return nil
 	}
-	fmt.Println("NOTE: prior to bond reduction, you must distribute the funds in your fee distributor.\n")
+	fmt.Print("NOTE: prior to bond reduction, you must distribute the funds in your fee distributor.\n\n")
 
 	// Print info
 	nodeShare := (1 + canDistributeResponse.AverageNodeFee) * balance / 2
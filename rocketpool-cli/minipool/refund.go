@@ -59,7 +59,7 @@ func refundMinipools(c *cli.Context) error {
 		options := make([]string, len(refundableMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range refundableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%.6f ETH to claim)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
+			options[mi+1] = fmt.Sprintf("%s (%.6f ETH to claim)", getMinipoolName(minipool), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
 		}
 		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options)
 
@@ -2,6 +2,7 @@ package minipool
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -51,13 +52,18 @@ func stakeMinipools(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
+	if c.Bool("all") {
+
+		// Use all of them
+		selectedMinipools = stakeableMinipools
+
+	} else if c.String("minipool") == "" {
 
 		// Prompt for minipool selection
 		options := make([]string, len(stakeableMinipools)+1)
 		options[0] = "All available minipools"
 		for mi, minipool := range stakeableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
+			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", getMinipoolName(minipool), minipool.TimeUntilDissolve)
 		}
 		selected, _ := cliutils.Select("Please select a minipool to stake:", options)
 
@@ -88,20 +94,37 @@ func stakeMinipools(c *cli.Context) error {
 
 	}
 
-	// Get the total gas limit estimate
+	// Validate the scrub status and deposit data of each minipool, and get the total gas limit estimate
+	if len(selectedMinipools) > 1 {
+		fmt.Printf("Checking %d minipools before staking...\n", len(selectedMinipools))
+	}
+	validMinipools := []api.MinipoolDetails{}
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
 	for _, minipool := range selectedMinipools {
 		canResponse, err := rp.CanStakeMinipool(minipool.Address)
 		if err != nil {
-			fmt.Printf("WARNING: Couldn't get gas price for stake transaction (%s)", err)
-			break
-		} else {
-			gasInfo = canResponse.GasInfo
-			totalGas += canResponse.GasInfo.EstGasLimit
-			totalSafeGas += canResponse.GasInfo.SafeGasLimit
+			fmt.Printf("%sSkipping minipool %s: couldn't check if it can be staked (%s).%s\n", colorYellow, minipool.Address.Hex(), err, colorReset)
+			continue
+		}
+		if !canResponse.CanStake {
+			fmt.Printf("%sSkipping minipool %s: it hasn't passed the scrub check yet.%s\n", colorYellow, minipool.Address.Hex(), colorReset)
+			continue
 		}
+		err = validateStakeDepositData(rp, minipool.Address)
+		if err != nil {
+			fmt.Printf("%sSkipping minipool %s: %s%s\n", colorRed, minipool.Address.Hex(), err, colorReset)
+			continue
+		}
+		validMinipools = append(validMinipools, minipool)
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+	}
+	if len(validMinipools) == 0 {
+		fmt.Println("None of the selected minipools can be staked.")
+		return nil
 	}
 	gasInfo.EstGasLimit = totalGas
 	gasInfo.SafeGasLimit = totalSafeGas
@@ -112,16 +135,21 @@ func stakeMinipools(c *cli.Context) error {
 		return err
 	}
 
-	fmt.Println("\nNOTE: Your validator container will be restarted after this process so it loads the new validator key.\n")
+	fmt.Print("\nNOTE: Your validator container will be restarted after this process so it loads the new validator key.\n\n")
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake %d minipools?", len(selectedMinipools)))) {
-		fmt.Println("Cancelled.")
-		return nil
+	if len(validMinipools) > 1 && !c.Bool("yes") {
+		fmt.Printf("%d minipools are ready to be staked. You'll be asked to confirm each stake transaction; use the `--yes` flag to stake them all without confirmation.\n\n", len(validMinipools))
 	}
 
-	// Stake minipools
-	for _, minipool := range selectedMinipools {
+	// Stake minipools one at a time
+	stakedCount := 0
+	for _, minipool := range validMinipools {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake minipool %s?", minipool.Address.Hex()))) {
+			fmt.Printf("Skipping minipool %s.\n", minipool.Address.Hex())
+			continue
+		}
+
 		response, err := rp.StakeMinipool(minipool.Address)
 		if err != nil {
 			fmt.Printf("Could not stake minipool %s: %s.\n", minipool.Address.Hex(), err)
@@ -134,10 +162,31 @@ func stakeMinipools(c *cli.Context) error {
 			fmt.Printf("Could not stake minipool %s: %s.\n", minipool.Address.Hex(), err)
 		} else {
 			fmt.Printf("Successfully staked minipool %s.\n", minipool.Address.Hex())
+			stakedCount++
 		}
 	}
+	if len(validMinipools) > 1 {
+		fmt.Printf("\nStaked %d of %d minipools.\n", stakedCount, len(validMinipools))
+	}
 
 	// Return
 	return nil
 
 }
+
+// Make sure a minipool's stake deposit data is sent to the minipool itself before submitting it
+func validateStakeDepositData(rp *rocketpool.Client, minipoolAddress common.Address) error {
+	response, err := rp.GetMinipoolDepositData(minipoolAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't check its deposit data (%w).", err)
+	}
+
+	// Withdrawal credentials for a minipool are 0x01, 11 zero bytes, and the minipool address
+	expectedCredentials := common.Hash{}
+	expectedCredentials[0] = 0x01
+	copy(expectedCredentials[12:], minipoolAddress.Bytes())
+	if response.DepositData.WithdrawalCredentials != hex.EncodeToString(expectedCredentials[:]) {
+		return fmt.Errorf("its deposit data has withdrawal credentials 0x%s instead of %s.", response.DepositData.WithdrawalCredentials, expectedCredentials.Hex())
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package minipool
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -41,6 +42,24 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Only show the minipools with the given tag
+	if tag := strings.ToLower(strings.TrimSpace(c.String("tag"))); tag != "" {
+		taggedMinipools := []api.MinipoolDetails{}
+		for _, minipool := range status.Minipools {
+			for _, minipoolTag := range minipool.Tags {
+				if minipoolTag == tag {
+					taggedMinipools = append(taggedMinipools, minipool)
+					break
+				}
+			}
+		}
+		if len(taggedMinipools) == 0 {
+			fmt.Printf("None of the node's minipools have the tag '%s'.\n", tag)
+			return nil
+		}
+		status.Minipools = taggedMinipools
+	}
+
 	// Get minipools by status
 	statusMinipools := map[string][]api.MinipoolDetails{}
 	refundableMinipools := []api.MinipoolDetails{}
@@ -85,6 +104,20 @@ func getStatus(c *cli.Context) error {
 		return nil
 	}
 
+	// Get the projected income of each staking minipool
+	projectedIncome := map[common.Address]float64{}
+	if c.Bool("detailed") {
+		incomeResponse, err := rp.GetMinipoolProjectedIncome()
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't get the projected income of your minipools: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		} else {
+			fmt.Printf("Recent staking APR: %.2f%% (estimated from the rETH exchange rate over the last week)\n\n", incomeResponse.StakingApr*100)
+			for _, minipool := range incomeResponse.Minipools {
+				projectedIncome[minipool.Address] = minipool.ProjectedAnnualIncome
+			}
+		}
+	}
+
 	// Print minipool details by status
 	for _, statusName := range types.MinipoolStatuses {
 		minipools, ok := statusMinipools[statusName]
@@ -101,7 +134,7 @@ func getStatus(c *cli.Context) error {
 		// Minipools
 		for _, minipool := range minipools {
 			if !minipool.Finalised || c.Bool("include-finalized") {
-				printMinipoolDetails(minipool, status.LatestDelegate)
+				printMinipoolDetails(minipool, status.LatestDelegate, projectedIncome)
 			}
 		}
 
@@ -115,7 +148,7 @@ func getStatus(c *cli.Context) error {
 
 		// Minipools
 		for _, minipool := range finalisedMinipools {
-			printMinipoolDetails(minipool, status.LatestDelegate)
+			printMinipoolDetails(minipool, status.LatestDelegate, projectedIncome)
 		}
 	} else {
 		fmt.Printf("%d finalized minipool(s) (hidden)\n", len(finalisedMinipools))
@@ -128,14 +161,14 @@ func getStatus(c *cli.Context) error {
 	if len(refundableMinipools) > 0 {
 		fmt.Printf("%d minipool(s) have refunds available:\n", len(refundableMinipools))
 		for _, minipool := range refundableMinipools {
-			fmt.Printf("- %s (%.6f ETH to claim)\n", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
+			fmt.Printf("- %s (%.6f ETH to claim)\n", getMinipoolName(minipool), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
 		}
 		fmt.Println("")
 	}
 	if len(closeableMinipools) > 0 {
 		fmt.Printf("%d dissolved minipool(s) can be closed once Beacon Chain withdrawals are enabled:\n", len(closeableMinipools))
 		for _, minipool := range closeableMinipools {
-			fmt.Printf("- %s (%.6f ETH to claim)\n", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balances.ETH), 6))
+			fmt.Printf("- %s (%.6f ETH to claim)\n", getMinipoolName(minipool), math.RoundDown(eth.WeiToEth(minipool.Balances.ETH), 6))
 		}
 		fmt.Println("")
 	}
@@ -145,13 +178,19 @@ func getStatus(c *cli.Context) error {
 
 }
 
-func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address) {
+func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address, projectedIncome map[common.Address]float64) {
 
 	fmt.Printf("--------------------\n")
 	fmt.Printf("\n")
 
 	// Main details
 	fmt.Printf("Address:               %s\n", minipool.Address.Hex())
+	if minipool.Label != "" {
+		fmt.Printf("Label:                 %s\n", minipool.Label)
+	}
+	if len(minipool.Tags) > 0 {
+		fmt.Printf("Tags:                  %s\n", strings.Join(minipool.Tags, ", "))
+	}
 	if minipool.Penalties == 0 {
 		fmt.Println("Penalties:             0")
 	} else if minipool.Penalties < 3 {
@@ -162,6 +201,9 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 	fmt.Printf("Status updated:        %s\n", minipool.Status.StatusTime.Format(TimeFormat))
 	fmt.Printf("Node fee:              %f%%\n", minipool.Node.Fee*100)
 	fmt.Printf("Node deposit:          %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
+	if income, exists := projectedIncome[minipool.Address]; exists {
+		fmt.Printf("Projected income:      %.6f ETH per year\n", math.RoundDown(income, 6))
+	}
 
 	// Queue position
 	if minipool.Queue.Position != 0 {
@@ -0,0 +1,96 @@
+package minipool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getTags(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the tags
+	tag := c.Args().Get(0)
+	response, err := rp.GetMinipoolTags(tag)
+	if err != nil {
+		return err
+	}
+	if len(response.Tags) == 0 {
+		if tag != "" {
+			fmt.Printf("None of your minipools have the tag '%s'.\n", tag)
+		} else {
+			fmt.Println("None of your minipools have a tag. You can give them one with `rocketpool minipool tag`.")
+		}
+		return nil
+	}
+
+	for _, minipool := range response.Tags {
+		name := minipool.Address.Hex()
+		if minipool.Label != "" {
+			name = fmt.Sprintf("%s (%s)", minipool.Label, minipool.Address.Hex())
+		}
+		fmt.Printf("%s  %s\n", name, strings.Join(minipool.Tags, ", "))
+	}
+	return nil
+
+}
+
+func tagMinipool(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the arguments
+	minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	tag := strings.ToLower(strings.TrimSpace(c.Args().Get(1)))
+
+	// Remove the tag
+	if c.Bool("remove") {
+		response, err := rp.UntagMinipool(minipoolAddress, tag)
+		if err != nil {
+			return err
+		}
+		if response.Changed {
+			fmt.Printf("Removed the tag '%s' from minipool %s.\n", tag, minipoolAddress.Hex())
+		} else {
+			fmt.Printf("Minipool %s doesn't have the tag '%s'.\n", minipoolAddress.Hex(), tag)
+		}
+		return nil
+	}
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Add the tag
+	response, err := rp.TagMinipool(minipoolAddress, tag)
+	if err != nil {
+		return err
+	}
+	if response.Changed {
+		fmt.Printf("Minipool %s is now tagged '%s'.\n", minipoolAddress.Hex(), tag)
+	} else {
+		fmt.Printf("Minipool %s already has the tag '%s'.\n", minipoolAddress.Hex(), tag)
+	}
+	return nil
+
+}
@@ -1,4 +1,18 @@
 package minipool
 
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
 // Config
 const TimeFormat = "2006-01-02, 15:04 -0700 MST"
+
+// Get the name of a minipool to show in lists, including its label if it has one
+func getMinipoolName(minipool api.MinipoolDetails) string {
+	if minipool.Label == "" {
+		return minipool.Address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", minipool.Label, minipool.Address.Hex())
+}
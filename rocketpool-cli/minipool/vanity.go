@@ -1,16 +1,24 @@
 package minipool
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
@@ -18,6 +26,32 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// The file in the Rocket Pool directory that an interrupted vanity search is saved to
+const vanitySearchStateFile string = "vanity-search.json"
+
+// How often to report the search progress and save it
+const vanityReportInterval time.Duration = 5 * time.Second
+
+// How many salts a worker checks between progress updates
+const vanityProgressBatchSize uint64 = 4096
+
+// The progress of a vanity address search, saved so it can be resumed later
+type vanitySearchState struct {
+	Prefix                 string         `json:"prefix"`
+	NodeAddress            common.Address `json:"nodeAddress"`
+	MinipoolFactoryAddress common.Address `json:"minipoolFactoryAddress"`
+	InitHash               common.Hash    `json:"initHash"`
+	NextSalt               string         `json:"nextSalt"`
+	SaltsChecked           uint64         `json:"saltsChecked"`
+}
+
+// A salt that produces a matching minipool address
+type vanityResult struct {
+	thread  int
+	salt    *big.Int
+	address common.Address
+}
+
 func findVanitySalt(c *cli.Context) error {
 
 	// Get RP client
@@ -52,9 +86,12 @@ func findVanitySalt(c *cli.Context) error {
 	if saltString == "" {
 		salt = big.NewInt(0)
 	} else {
+		if c.Bool("resume") {
+			return fmt.Errorf("You can't specify a starting salt when resuming a search.")
+		}
 		salt, success = big.NewInt(0).SetString(saltString, 0)
 		if !success {
-			return fmt.Errorf("Invalid starting salt: %s", salt)
+			return fmt.Errorf("Invalid starting salt: %s", saltString)
 		}
 	}
 
@@ -144,14 +181,68 @@ func findVanitySalt(c *cli.Context) error {
 	minipoolFactoryAddress := vanityArtifacts.MinipoolFactoryAddress
 	initHash := vanityArtifacts.InitHash.Bytes()
 	shiftAmount := uint(42 - len(prefix))
+	state := vanitySearchState{
+		Prefix:                 strings.ToLower(prefix),
+		NodeAddress:            vanityArtifacts.NodeAddress,
+		MinipoolFactoryAddress: minipoolFactoryAddress,
+		InitHash:               vanityArtifacts.InitHash,
+	}
+
+	// Resume the previous search if requested
+	statePath, err := getVanitySearchStatePath(c)
+	if err != nil {
+		return err
+	}
+	savedState, err := loadVanitySearchState(statePath)
+	if err != nil {
+		return err
+	}
+	isSameSearch := savedState != nil &&
+		savedState.Prefix == state.Prefix &&
+		savedState.NodeAddress == state.NodeAddress &&
+		savedState.MinipoolFactoryAddress == state.MinipoolFactoryAddress &&
+		savedState.InitHash == state.InitHash
+	if c.Bool("resume") {
+		if !isSameSearch {
+			return fmt.Errorf("There is no saved search for prefix %s with this node address and deposit amount to resume.", prefix)
+		}
+		salt, success = big.NewInt(0).SetString(savedState.NextSalt, 0)
+		if !success {
+			return fmt.Errorf("The saved search has an invalid salt (%s); please start a new search.", savedState.NextSalt)
+		}
+		state.SaltsChecked = savedState.SaltsChecked
+		fmt.Printf("Resuming the search at salt 0x%x (%s salts already checked).\n", salt, humanize.Comma(int64(savedState.SaltsChecked)))
+	} else if isSameSearch && saltString == "" {
+		fmt.Printf("%sNOTE: A previous search for this prefix stopped at salt %s. Use the --resume flag to continue it instead of starting over.%s\n", colorYellow, savedState.NextSalt, colorReset)
+	}
+
+	// Each hex digit of the prefix makes a match 16 times less likely
+	expectedSalts := math.Pow(16, float64(len(prefix)-2))
+
+	// Set up the GPU if requested; it checks consecutive salts on its own, so it runs as a single worker
+	var gpu *openclSearcher
+	if c.Bool("gpu") {
+		gpu, err = newOpenclSearcher(nodeAddress, minipoolFactoryAddress, initHash, prefix)
+		if err != nil {
+			return err
+		}
+		defer gpu.close()
+		threads = 1
+	}
 
 	// Run the search
-	fmt.Printf("Running with %d threads.\n", threads)
+	if gpu != nil {
+		fmt.Printf("Running on %s.\n", gpu.getDeviceName())
+	} else {
+		fmt.Printf("Running with %d threads.\n", threads)
+	}
 
 	wg := new(sync.WaitGroup)
 	wg.Add(threads)
-	stop := false
-	stopPtr := &stop
+	stop := new(atomic.Bool)
+	results := make(chan vanityResult, threads)
+	searchErrors := make(chan error, threads)
+	progress := make([]atomic.Uint64, threads)
 
 	// Spawn worker threads
 	start := time.Now()
@@ -160,19 +251,107 @@ func findVanitySalt(c *cli.Context) error {
 		workerSalt := big.NewInt(0).Add(salt, saltOffset)
 
 		go func(i int) {
-			foundSalt, foundAddress := runWorker(i == 0, stopPtr, targetPrefix, nodeAddress, minipoolFactoryAddress, initHash, workerSalt, int64(threads), shiftAmount)
+			defer wg.Done()
+			var foundSalt *big.Int
+			var foundAddress common.Address
+			if gpu != nil {
+				var err error
+				foundSalt, err = gpu.search(stop, &progress[i], workerSalt)
+				if err != nil {
+					searchErrors <- err
+					return
+				}
+				if foundSalt != nil {
+					foundAddress = getVanityAddress(nodeAddress, minipoolFactoryAddress, initHash, foundSalt)
+				}
+			} else {
+				foundSalt, foundAddress = runWorker(stop, &progress[i], targetPrefix, nodeAddress, minipoolFactoryAddress, initHash, workerSalt, int64(threads), shiftAmount)
+			}
 			if foundSalt != nil {
-				fmt.Printf("Found on thread %d: salt 0x%x = %s\n", i, foundSalt, foundAddress.Hex())
-				*stopPtr = true
+				results <- vanityResult{
+					thread:  i,
+					salt:    foundSalt,
+					address: foundAddress,
+				}
+				stop.Store(true)
 			}
-			wg.Done()
 		}(i)
 	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Stop the search cleanly if it's interrupted so its progress can be saved
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	// Report the progress and save it periodically until the workers finish
+	ticker := time.NewTicker(vanityReportInterval)
+	defer ticker.Stop()
+	interrupted := false
+	lastChecked := uint64(0)
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+
+		case <-interrupt:
+			fmt.Println("Stopping the search...")
+			interrupted = true
+			stop.Store(true)
+
+		case <-ticker.C:
+			checked := getSaltsChecked(progress)
+			rate := float64(checked-lastChecked) / vanityReportInterval.Seconds()
+			lastChecked = checked
+			rateFloat, suffix := humanize.ComputeSI(rate)
+			rateString := humanize.FtoaWithDigits(rateFloat, 2) + suffix
+			totalChecked := state.SaltsChecked + checked
+			estimate := "unknown"
+			if rate > 0 && float64(totalChecked) < expectedSalts {
+				remaining := time.Duration((expectedSalts - float64(totalChecked)) / rate * float64(time.Second))
+				estimate = remaining.Round(time.Second).String()
+			} else if rate > 0 {
+				estimate = "any time now (past the expected number of attempts)"
+			}
+			nextSalt := getNextSalt(salt, progress)
+			fmt.Printf("At salt 0x%x... %s (%s salts/sec, %.1f%% of the expected %s attempts, about %s remaining)\n", nextSalt, time.Since(start).Round(time.Second), rateString, float64(totalChecked)/expectedSalts*100, humanize.SIWithDigits(expectedSalts, 2, ""), estimate)
+
+			if err := saveVanitySearchState(statePath, state, nextSalt, totalChecked); err != nil {
+				fmt.Printf("%sWARNING: couldn't save the search progress: %s%s\n", colorYellow, err.Error(), colorReset)
+			}
+		}
+	}
+	elapsed := time.Since(start)
 
-	// Wait for the workers to finish and print the elapsed time
-	wg.Wait()
-	end := time.Now()
-	elapsed := end.Sub(start)
+	// Print the result
+	select {
+	case err := <-searchErrors:
+		nextSalt := getNextSalt(salt, progress)
+		if saveErr := saveVanitySearchState(statePath, state, nextSalt, state.SaltsChecked+getSaltsChecked(progress)); saveErr != nil {
+			fmt.Printf("%sWARNING: couldn't save the search progress: %s%s\n", colorYellow, saveErr.Error(), colorReset)
+		}
+		return fmt.Errorf("error running the GPU search: %w", err)
+	case result := <-results:
+		if !strings.HasPrefix(strings.ToLower(result.address.Hex()), state.Prefix) {
+			return fmt.Errorf("salt 0x%x gives address %s, which doesn't match the prefix; the GPU search is producing incorrect results", result.salt, result.address.Hex())
+		}
+		fmt.Printf("Found on thread %d: salt 0x%x = %s\n", result.thread, result.salt, result.address.Hex())
+		if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("%sWARNING: couldn't remove the saved search progress at %s: %s%s\n", colorYellow, statePath, err.Error(), colorReset)
+		}
+	default:
+		if interrupted {
+			nextSalt := getNextSalt(salt, progress)
+			if err := saveVanitySearchState(statePath, state, nextSalt, state.SaltsChecked+getSaltsChecked(progress)); err != nil {
+				return fmt.Errorf("error saving the search progress: %w", err)
+			}
+			fmt.Printf("Search stopped at salt 0x%x. Run this command again with the --resume flag to continue it.\n", nextSalt)
+		}
+	}
 	fmt.Printf("Finished in %s\n", elapsed)
 
 	// Return
@@ -180,7 +359,7 @@ func findVanitySalt(c *cli.Context) error {
 
 }
 
-func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64, shiftAmount uint) (*big.Int, common.Address) {
+func runWorker(stop *atomic.Bool, progress *atomic.Uint64, targetPrefix *big.Int, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64, shiftAmount uint) (*big.Int, common.Address) {
 	saltBytes := [32]byte{}
 	hashInt := big.NewInt(0)
 	incrementInt := big.NewInt(increment)
@@ -188,36 +367,16 @@ func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byt
 	nodeSalt := common.Hash{}
 	addressResult := common.Hash{}
 
-	// Set up the reporting ticker if requested
-	var ticker *time.Ticker
-	var tickerChan chan struct{}
-	lastSalt := big.NewInt(0).Set(salt)
-	if report {
-		start := time.Now()
-		reportInterval := 5 * time.Second
-		ticker = time.NewTicker(reportInterval)
-		tickerChan = make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-					delta := big.NewInt(0).Sub(salt, lastSalt)
-					deltaFloat, suffix := humanize.ComputeSI(float64(delta.Uint64()) / 5.0)
-					deltaString := humanize.FtoaWithDigits(deltaFloat, 2) + suffix
-					fmt.Printf("At salt 0x%x... %s (%s salts/sec)\n", salt, time.Since(start), deltaString)
-					lastSalt.Set(salt)
-				case <-tickerChan:
-					ticker.Stop()
-					return
-				}
-			}
-		}()
-	}
-
 	// Run the main salt finder loop
+	batchCount := uint64(0)
 	for {
-		if *stop {
-			return nil, common.Address{}
+		// Publish the progress in batches so the workers don't contend over it on every salt
+		if batchCount == vanityProgressBatchSize {
+			progress.Add(batchCount)
+			batchCount = 0
+			if stop.Load() {
+				return nil, common.Address{}
+			}
 		}
 
 		// Some speed optimizations -
@@ -244,12 +403,85 @@ func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byt
 		hashInt.SetBytes(addressResult[12:])
 		hashInt.Rsh(hashInt, shiftAmount*4)
 		if hashInt.Cmp(targetPrefix) == 0 {
-			if report {
-				close(tickerChan)
-			}
+			progress.Add(batchCount)
 			address := common.BytesToAddress(addressResult[12:])
 			return salt, address
 		}
 		salt.Add(salt, incrementInt)
+		batchCount++
+	}
+}
+
+// Get the minipool address a salt gives, the slow way
+func getVanityAddress(nodeAddress []byte, minipoolFactoryAddress common.Address, initHash []byte, salt *big.Int) common.Address {
+	saltBytes := [32]byte{}
+	salt.FillBytes(saltBytes[:])
+	nodeSalt := crypto.Keccak256Hash(nodeAddress, saltBytes[:])
+	return crypto.CreateAddress2(minipoolFactoryAddress, nodeSalt, initHash)
+}
+
+// Get the total number of salts the workers have checked
+func getSaltsChecked(progress []atomic.Uint64) uint64 {
+	total := uint64(0)
+	for i := range progress {
+		total += progress[i].Load()
+	}
+	return total
+}
+
+// Get the lowest salt that hasn't been checked yet.
+// The workers interleave their salts, so every salt below the slowest worker's position has been checked.
+func getNextSalt(startSalt *big.Int, progress []atomic.Uint64) *big.Int {
+	minChecked := uint64(math.MaxUint64)
+	for i := range progress {
+		checked := progress[i].Load()
+		if checked < minChecked {
+			minChecked = checked
+		}
 	}
+	offset := big.NewInt(0).Mul(big.NewInt(0).SetUint64(minChecked), big.NewInt(int64(len(progress))))
+	return offset.Add(offset, startSalt)
+}
+
+// Get the path of the saved vanity search
+func getVanitySearchStatePath(c *cli.Context) (string, error) {
+	configPath, err := rocketpool.GetConfigPathFromCtx(c)
+	if err != nil {
+		return "", err
+	}
+	path, err := homedir.Expand(configPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path [%s]: %w", configPath, err)
+	}
+	return filepath.Join(path, vanitySearchStateFile), nil
+}
+
+// Load the saved vanity search, or nil if there isn't one
+func loadVanitySearchState(path string) (*vanitySearchState, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading saved vanity search [%s]: %w", path, err)
+	}
+	var state vanitySearchState
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return nil, fmt.Errorf("error deserializing saved vanity search [%s]: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save the progress of a vanity search
+func saveVanitySearchState(path string, state vanitySearchState, nextSalt *big.Int, saltsChecked uint64) error {
+	state.NextSalt = fmt.Sprintf("0x%x", nextSalt)
+	state.SaltsChecked = saltsChecked
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing vanity search: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing vanity search [%s]: %w", path, err)
+	}
+	return nil
 }
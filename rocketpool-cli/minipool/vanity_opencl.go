@@ -0,0 +1,385 @@
+//go:build opencl
+// +build opencl
+
+package minipool
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=120
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo !darwin LDFLAGS: -lOpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// How many salts each kernel run checks
+const openclBatchSize uint64 = 1 << 22
+
+// The OpenCL kernel that checks a batch of salts. Each work item checks the salt at its offset from the batch's base salt
+// the same way the CPU workers do: it hashes the node address with the salt, derives the CREATE2 address from the result,
+// and compares the address's leading nibbles with the prefix.
+//
+// The params buffer holds the node address (20 bytes), the minipool factory address (20 bytes), the init hash (32 bytes)
+// and the prefix left-aligned in an address (20 bytes).
+const openclVanityKernel string = `
+#define ROTL64(x, n) (((x) << (n)) | ((x) >> (64 - (n))))
+
+__constant ulong keccakRoundConstants[24] = {
+	0x0000000000000001UL, 0x0000000000008082UL, 0x800000000000808aUL, 0x8000000080008000UL,
+	0x000000000000808bUL, 0x0000000080000001UL, 0x8000000080008081UL, 0x8000000000008009UL,
+	0x000000000000008aUL, 0x0000000000000088UL, 0x0000000080008009UL, 0x000000008000000aUL,
+	0x000000008000808bUL, 0x800000000000008bUL, 0x8000000000008089UL, 0x8000000000008003UL,
+	0x8000000000008002UL, 0x8000000000000080UL, 0x000000000000800aUL, 0x800000008000000aUL,
+	0x8000000080008081UL, 0x8000000000008080UL, 0x0000000080000001UL, 0x8000000080008008UL
+};
+__constant int keccakRotations[24] = {
+	1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44
+};
+__constant int keccakLanes[24] = {
+	10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1
+};
+
+void keccakf(ulong* state) {
+	ulong columns[5];
+	for (int round = 0; round < 24; round++) {
+		for (int i = 0; i < 5; i++) {
+			columns[i] = state[i] ^ state[i + 5] ^ state[i + 10] ^ state[i + 15] ^ state[i + 20];
+		}
+		for (int i = 0; i < 5; i++) {
+			ulong t = columns[(i + 4) % 5] ^ ROTL64(columns[(i + 1) % 5], 1);
+			for (int j = 0; j < 25; j += 5) {
+				state[j + i] ^= t;
+			}
+		}
+
+		ulong t = state[1];
+		for (int i = 0; i < 24; i++) {
+			int j = keccakLanes[i];
+			columns[0] = state[j];
+			state[j] = ROTL64(t, keccakRotations[i]);
+			t = columns[0];
+		}
+
+		for (int j = 0; j < 25; j += 5) {
+			for (int i = 0; i < 5; i++) {
+				columns[i] = state[j + i];
+			}
+			for (int i = 0; i < 5; i++) {
+				state[j + i] ^= (~columns[(i + 1) % 5]) & columns[(i + 2) % 5];
+			}
+		}
+
+		state[0] ^= keccakRoundConstants[round];
+	}
+}
+
+// Keccak-256 of an input that fits in a single block
+void keccak256(const uchar* input, uint length, uchar* output) {
+	ulong state[25];
+	for (int i = 0; i < 25; i++) {
+		state[i] = 0;
+	}
+	for (uint i = 0; i < length; i++) {
+		state[i / 8] ^= ((ulong)input[i]) << (8 * (i % 8));
+	}
+	state[length / 8] ^= ((ulong)0x01) << (8 * (length % 8));
+	state[16] ^= ((ulong)0x80) << 56;
+	keccakf(state);
+	for (int i = 0; i < 32; i++) {
+		output[i] = (uchar)(state[i / 8] >> (8 * (i % 8)));
+	}
+}
+
+__kernel void searchVanitySalt(__constant uchar* params, __constant uchar* baseSalt, uint prefixNibbles, __global uint* found, __global ulong* foundOffset) {
+	ulong offset = get_global_id(0);
+	uchar data[85];
+	uchar nodeSalt[32];
+	uchar hash[32];
+
+	// nodeSalt = keccak256(nodeAddress ++ (baseSalt + offset))
+	for (int i = 0; i < 20; i++) {
+		data[i] = params[i];
+	}
+	ulong carry = offset;
+	for (int i = 31; i >= 0; i--) {
+		ulong sum = (ulong)baseSalt[i] + (carry & 0xff);
+		data[20 + i] = (uchar)sum;
+		carry = (carry >> 8) + (sum >> 8);
+	}
+	keccak256(data, 52, nodeSalt);
+
+	// address = keccak256(0xff ++ factoryAddress ++ nodeSalt ++ initHash)[12:]
+	data[0] = 0xff;
+	for (int i = 0; i < 20; i++) {
+		data[1 + i] = params[20 + i];
+	}
+	for (int i = 0; i < 32; i++) {
+		data[21 + i] = nodeSalt[i];
+		data[53 + i] = params[40 + i];
+	}
+	keccak256(data, 85, hash);
+
+	for (uint i = 0; i < prefixNibbles; i++) {
+		uchar addressByte = hash[12 + i / 2];
+		uchar prefixByte = params[72 + i / 2];
+		if (i % 2 == 0) {
+			addressByte >>= 4;
+			prefixByte >>= 4;
+		} else {
+			addressByte &= 0x0f;
+			prefixByte &= 0x0f;
+		}
+		if (addressByte != prefixByte) {
+			return;
+		}
+	}
+	if (atomic_cmpxchg(found, 0, 1) == 0) {
+		*foundOffset = offset;
+	}
+}
+`
+
+// Searches for vanity salts on an OpenCL GPU
+type openclSearcher struct {
+	deviceName  string
+	context     C.cl_context
+	queue       C.cl_command_queue
+	program     C.cl_program
+	kernel      C.cl_kernel
+	params      C.cl_mem
+	baseSalt    C.cl_mem
+	found       C.cl_mem
+	foundOffset C.cl_mem
+}
+
+// Set up the search on the first OpenCL GPU
+func newOpenclSearcher(nodeAddress []byte, minipoolFactoryAddress common.Address, initHash []byte, prefix string) (*openclSearcher, error) {
+	prefixBytes, prefixNibbles, err := getVanityPrefixBytes(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find a GPU
+	var platformCount C.cl_uint
+	if status := C.clGetPlatformIDs(0, nil, &platformCount); status != C.CL_SUCCESS || platformCount == 0 {
+		return nil, fmt.Errorf("no OpenCL platforms were found (error %d); make sure your GPU drivers include OpenCL support", status)
+	}
+	platforms := make([]C.cl_platform_id, platformCount)
+	if status := C.clGetPlatformIDs(platformCount, &platforms[0], nil); status != C.CL_SUCCESS {
+		return nil, fmt.Errorf("error getting the OpenCL platforms: error %d", status)
+	}
+	var device C.cl_device_id
+	var deviceCount C.cl_uint
+	for _, platform := range platforms {
+		if C.clGetDeviceIDs(platform, C.CL_DEVICE_TYPE_GPU, 1, &device, &deviceCount) == C.CL_SUCCESS && deviceCount > 0 {
+			break
+		}
+	}
+	if deviceCount == 0 {
+		return nil, fmt.Errorf("no OpenCL GPUs were found")
+	}
+
+	s := &openclSearcher{
+		deviceName: getOpenclDeviceName(device),
+	}
+	var status C.cl_int
+	s.context = C.clCreateContext(nil, 1, &device, nil, nil, &status)
+	if status != C.CL_SUCCESS {
+		return nil, fmt.Errorf("error creating the OpenCL context: error %d", status)
+	}
+	s.queue = C.clCreateCommandQueue(s.context, device, 0, &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL command queue: error %d", status)
+	}
+
+	// Build the kernel
+	source := C.CString(openclVanityKernel)
+	defer C.free(unsafe.Pointer(source))
+	s.program = C.clCreateProgramWithSource(s.context, 1, &source, nil, &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL program: error %d", status)
+	}
+	if status := C.clBuildProgram(s.program, 1, &device, nil, nil, nil); status != C.CL_SUCCESS {
+		buildLog := getOpenclBuildLog(s.program, device)
+		s.close()
+		return nil, fmt.Errorf("error building the OpenCL kernel (error %d):\n%s", status, buildLog)
+	}
+	kernelName := C.CString("searchVanitySalt")
+	defer C.free(unsafe.Pointer(kernelName))
+	s.kernel = C.clCreateKernel(s.program, kernelName, &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL kernel: error %d", status)
+	}
+
+	// Create the buffers
+	params := make([]byte, 0, 92)
+	params = append(params, nodeAddress...)
+	params = append(params, minipoolFactoryAddress.Bytes()...)
+	params = append(params, initHash...)
+	params = append(params, prefixBytes[:]...)
+	s.params = C.clCreateBuffer(s.context, C.CL_MEM_READ_ONLY|C.CL_MEM_COPY_HOST_PTR, C.size_t(len(params)), unsafe.Pointer(&params[0]), &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL parameter buffer: error %d", status)
+	}
+	s.baseSalt = C.clCreateBuffer(s.context, C.CL_MEM_READ_ONLY, 32, nil, &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL salt buffer: error %d", status)
+	}
+	found := C.cl_uint(0)
+	s.found = C.clCreateBuffer(s.context, C.CL_MEM_READ_WRITE|C.CL_MEM_COPY_HOST_PTR, C.size_t(unsafe.Sizeof(found)), unsafe.Pointer(&found), &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL result buffer: error %d", status)
+	}
+	s.foundOffset = C.clCreateBuffer(s.context, C.CL_MEM_READ_WRITE, C.size_t(unsafe.Sizeof(C.cl_ulong(0))), nil, &status)
+	if status != C.CL_SUCCESS {
+		s.close()
+		return nil, fmt.Errorf("error creating the OpenCL result buffer: error %d", status)
+	}
+
+	// Set the kernel arguments
+	nibbles := C.cl_uint(prefixNibbles)
+	args := []struct {
+		size  uintptr
+		value unsafe.Pointer
+	}{
+		{unsafe.Sizeof(s.params), unsafe.Pointer(&s.params)},
+		{unsafe.Sizeof(s.baseSalt), unsafe.Pointer(&s.baseSalt)},
+		{unsafe.Sizeof(nibbles), unsafe.Pointer(&nibbles)},
+		{unsafe.Sizeof(s.found), unsafe.Pointer(&s.found)},
+		{unsafe.Sizeof(s.foundOffset), unsafe.Pointer(&s.foundOffset)},
+	}
+	for i, arg := range args {
+		if status := C.clSetKernelArg(s.kernel, C.cl_uint(i), C.size_t(arg.size), arg.value); status != C.CL_SUCCESS {
+			s.close()
+			return nil, fmt.Errorf("error setting OpenCL kernel argument %d: error %d", i, status)
+		}
+	}
+
+	return s, nil
+}
+
+// Check consecutive salts from the starting salt in batches until one matches or the search is stopped.
+// Returns nil if the search was stopped before a match was found.
+func (s *openclSearcher) search(stop *atomic.Bool, progress *atomic.Uint64, startSalt *big.Int) (*big.Int, error) {
+	salt := big.NewInt(0).Set(startSalt)
+	batchSize := big.NewInt(0).SetUint64(openclBatchSize)
+	globalSize := C.size_t(openclBatchSize)
+	saltBytes := [32]byte{}
+	for !stop.Load() {
+		salt.FillBytes(saltBytes[:])
+		if status := C.clEnqueueWriteBuffer(s.queue, s.baseSalt, C.CL_TRUE, 0, C.size_t(len(saltBytes)), unsafe.Pointer(&saltBytes[0]), 0, nil, nil); status != C.CL_SUCCESS {
+			return nil, fmt.Errorf("error writing the salt to the GPU: error %d", status)
+		}
+		if status := C.clEnqueueNDRangeKernel(s.queue, s.kernel, 1, nil, &globalSize, nil, 0, nil, nil); status != C.CL_SUCCESS {
+			return nil, fmt.Errorf("error running the OpenCL kernel: error %d", status)
+		}
+
+		// The queue runs in order, so the blocking read waits for the batch to finish
+		var found C.cl_uint
+		if status := C.clEnqueueReadBuffer(s.queue, s.found, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(found)), unsafe.Pointer(&found), 0, nil, nil); status != C.CL_SUCCESS {
+			return nil, fmt.Errorf("error reading the result from the GPU: error %d", status)
+		}
+		progress.Add(openclBatchSize)
+		if found != 0 {
+			var offset C.cl_ulong
+			if status := C.clEnqueueReadBuffer(s.queue, s.foundOffset, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(offset)), unsafe.Pointer(&offset), 0, nil, nil); status != C.CL_SUCCESS {
+				return nil, fmt.Errorf("error reading the result from the GPU: error %d", status)
+			}
+			return salt.Add(salt, big.NewInt(0).SetUint64(uint64(offset))), nil
+		}
+		salt.Add(salt, batchSize)
+	}
+	return nil, nil
+}
+
+// Get the name of the GPU the search runs on
+func (s *openclSearcher) getDeviceName() string {
+	return s.deviceName
+}
+
+// Release the OpenCL resources
+func (s *openclSearcher) close() {
+	for _, buffer := range []C.cl_mem{s.params, s.baseSalt, s.found, s.foundOffset} {
+		if buffer != nil {
+			C.clReleaseMemObject(buffer)
+		}
+	}
+	if s.kernel != nil {
+		C.clReleaseKernel(s.kernel)
+	}
+	if s.program != nil {
+		C.clReleaseProgram(s.program)
+	}
+	if s.queue != nil {
+		C.clReleaseCommandQueue(s.queue)
+	}
+	if s.context != nil {
+		C.clReleaseContext(s.context)
+	}
+}
+
+// Get the prefix's hex digits left-aligned in an address, along with the number of digits
+func getVanityPrefixBytes(prefix string) ([common.AddressLength]byte, int, error) {
+	prefixBytes := [common.AddressLength]byte{}
+	digits := strings.TrimPrefix(prefix, "0x")
+	if len(digits) > common.AddressLength*2 {
+		return prefixBytes, 0, fmt.Errorf("the prefix is longer than an address")
+	}
+	nibbles := len(digits)
+	if nibbles%2 == 1 {
+		digits += "0"
+	}
+	decoded, err := hex.DecodeString(digits)
+	if err != nil {
+		return prefixBytes, 0, fmt.Errorf("invalid prefix %s: %w", prefix, err)
+	}
+	copy(prefixBytes[:], decoded)
+	return prefixBytes, nibbles, nil
+}
+
+// Get the name of an OpenCL device
+func getOpenclDeviceName(device C.cl_device_id) string {
+	var size C.size_t
+	if C.clGetDeviceInfo(device, C.CL_DEVICE_NAME, 0, nil, &size) != C.CL_SUCCESS || size == 0 {
+		return "unknown GPU"
+	}
+	name := make([]byte, size)
+	if C.clGetDeviceInfo(device, C.CL_DEVICE_NAME, size, unsafe.Pointer(&name[0]), nil) != C.CL_SUCCESS {
+		return "unknown GPU"
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&name[0])))
+}
+
+// Get the compiler output for an OpenCL program that failed to build
+func getOpenclBuildLog(program C.cl_program, device C.cl_device_id) string {
+	var size C.size_t
+	if C.clGetProgramBuildInfo(program, device, C.CL_PROGRAM_BUILD_LOG, 0, nil, &size) != C.CL_SUCCESS || size == 0 {
+		return ""
+	}
+	buildLog := make([]byte, size)
+	if C.clGetProgramBuildInfo(program, device, C.CL_PROGRAM_BUILD_LOG, size, unsafe.Pointer(&buildLog[0]), nil) != C.CL_SUCCESS {
+		return ""
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&buildLog[0])))
+}
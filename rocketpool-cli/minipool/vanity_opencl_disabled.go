@@ -0,0 +1,34 @@
+//go:build !opencl
+// +build !opencl
+
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GPU searches need OpenCL, which this binary was built without
+type openclSearcher struct{}
+
+// Returns an error since this binary was built without OpenCL support
+func newOpenclSearcher(nodeAddress []byte, minipoolFactoryAddress common.Address, initHash []byte, prefix string) (*openclSearcher, error) {
+	return nil, fmt.Errorf("This build of the Smartnode CLI doesn't support GPU searches. Build it with `-tags opencl` on a system with the OpenCL headers and drivers installed to use one.")
+}
+
+// GPU searches are compiled out, so there's never anything to search with
+func (s *openclSearcher) search(stop *atomic.Bool, progress *atomic.Uint64, startSalt *big.Int) (*big.Int, error) {
+	return nil, fmt.Errorf("GPU searches are not supported by this build")
+}
+
+// GPU searches are compiled out, so there's no device
+func (s *openclSearcher) getDeviceName() string {
+	return ""
+}
+
+// GPU searches are compiled out, so there's nothing to release
+func (s *openclSearcher) close() {
+}
@@ -118,6 +118,50 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "download-rewards-file",
+				Aliases:   []string{"drf"},
+				Usage:     "Download the rewards tree file for the provided interval from IPFS or one of your configured mirrors, and verify it against the Merkle root submitted on-chain",
+				UsageText: "rocketpool network download-rewards-file interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return downloadRewardsFile(c, interval)
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-file",
+				Aliases:   []string{"vrf"},
+				Usage:     "Independently verify the rewards tree file for the provided interval: rebuild its Merkle tree, and compare it to the Merkle root submitted on-chain and the file published under the submitted CID",
+				UsageText: "rocketpool network verify-rewards-file interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsFile(c, interval)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
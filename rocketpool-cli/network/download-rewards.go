@@ -0,0 +1,38 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func downloadRewardsFile(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Download the file
+	fmt.Printf("Downloading the rewards tree file for interval %d...\n", interval)
+	_, err = rp.DownloadRewardsFile(interval)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The rewards tree file for interval %d was downloaded and its Merkle root matches the one submitted by the Oracle DAO.\n", interval)
+	return nil
+
+}
@@ -44,6 +44,8 @@ func getStats(c *cli.Context) error {
 	fmt.Printf("Total Value Locked:      %f ETH\n", response.TotalValueLocked)
 	fmt.Printf("Staking Pool Balance:    %f ETH\n", response.DepositPoolBalance)
 	fmt.Printf("Minipool Queue Demand:   %f ETH\n", response.MinipoolCapacity)
+	fmt.Printf("Node Demand:             %f ETH\n", response.NodeDemand)
+	fmt.Printf("Deposit Pool Excess:     %f ETH\n", response.DepositPoolExcess)
 	fmt.Printf("Staking Pool ETH Used:   %f%%\n\n", response.StakerUtilization*100)
 
 	fmt.Printf("%s============== Nodes ==============%s\n", colorGreen, colorReset)
@@ -64,6 +66,11 @@ func getStats(c *cli.Context) error {
 
 	fmt.Printf("%s============== Tokens =============%s\n", colorGreen, colorReset)
 	fmt.Printf("rETH Price (ETH / rETH): %f ETH\n", response.RethPrice)
+	if response.RethAprError == "" {
+		fmt.Printf("rETH APR (last 7 days):  %.2f%%\n", response.RethApr*100)
+	} else {
+		fmt.Printf("rETH APR (last 7 days):  unavailable (%s)\n", response.RethAprError)
+	}
 	fmt.Printf("RPL Price (ETH / RPL):   %f ETH\n", response.RplPrice)
 	fmt.Printf("Total RPL staked:        %f RPL\n", response.TotalRplStaked)
 	fmt.Printf("Effective RPL staked:    %f RPL\n", response.EffectiveRplStaked)
@@ -0,0 +1,80 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func verifyRewardsFile(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Verify the file
+	fmt.Printf("Verifying the rewards tree file for interval %d...\n\n", interval)
+	response, err := rp.VerifyRewardsFile(interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Submitted Merkle root: %s\n", response.CanonicalMerkleRoot.Hex())
+	fmt.Printf("Submitted CID:         %s\n\n", response.CID)
+	if !response.TreeFileExists {
+		fmt.Printf("%sYou don't have the rewards tree file for interval %d (%s).%s\n", colorYellow, interval, response.TreeFilePath, colorReset)
+		fmt.Printf("You can download it with `rocketpool network download-rewards-file %d`, or generate it yourself with `rocketpool network generate-rewards-tree --index %d`.\n", interval, interval)
+		return nil
+	}
+
+	// Local file
+	fmt.Printf("Local file: %s\n", response.TreeFilePath)
+	printCheck(response.FileIndex == interval, fmt.Sprintf("The file is for interval %d", interval), fmt.Sprintf("The file is for interval %d, not %d", response.FileIndex, interval))
+	printCheck(response.FileNetwork == response.ExpectedNetwork, fmt.Sprintf("The file is for the %s network", response.ExpectedNetwork), fmt.Sprintf("The file is for the %s network, not %s", response.FileNetwork, response.ExpectedNetwork))
+	printCheck(response.FileMerkleRoot == response.CanonicalMerkleRoot, "The Merkle root in the file matches the submitted root", fmt.Sprintf("The Merkle root in the file (%s) doesn't match the submitted root", response.FileMerkleRoot.Hex()))
+	printCheck(response.ComputedMerkleRoot == response.CanonicalMerkleRoot, "The Merkle root rebuilt from the file's rewards matches the submitted root", fmt.Sprintf("The Merkle root rebuilt from the file's rewards (%s) doesn't match the submitted root", response.ComputedMerkleRoot.Hex()))
+	printCheck(len(response.InvalidProofs) == 0, "Every node's Merkle proof is valid", fmt.Sprintf("%d node(s) have Merkle proofs that don't lead to the file's root", len(response.InvalidProofs)))
+	for _, address := range response.InvalidProofs {
+		fmt.Printf("    - %s\n", address.Hex())
+	}
+	fmt.Println()
+
+	// Published file
+	fmt.Println("Published file:")
+	if response.PublishedFileError != "" {
+		printCheck(false, "", fmt.Sprintf("The file published under the CID couldn't be checked:\n%s", response.PublishedFileError))
+	} else {
+		printCheck(response.PublishedMerkleRoot == response.CanonicalMerkleRoot, "The Merkle root in the published file matches the submitted root", fmt.Sprintf("The Merkle root in the published file (%s) doesn't match the submitted root", response.PublishedMerkleRoot.Hex()))
+		printCheck(!response.PublishedFileMismatch, "The rewards in the published file match your local file", fmt.Sprintf("The rewards in the published file (Merkle root %s) don't match your local file", response.PublishedComputedRoot.Hex()))
+	}
+	fmt.Println()
+
+	if response.Verified {
+		fmt.Printf("%sThe rewards tree file for interval %d is verified.%s\n", colorGreen, interval, colorReset)
+	} else {
+		fmt.Printf("%sThe rewards tree file for interval %d could not be verified; see the problems above.%s\n", colorRed, interval, colorReset)
+	}
+	return nil
+
+}
+
+// Print the result of a verification check
+func printCheck(passed bool, passedMessage string, failedMessage string) {
+	if passed {
+		fmt.Printf("  %s✓%s %s\n", colorGreen, colorReset, passedMessage)
+	} else {
+		fmt.Printf("  %s✗%s %s\n", colorRed, colorReset, failedMessage)
+	}
+}
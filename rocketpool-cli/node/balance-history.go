@@ -0,0 +1,68 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getBalanceHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the history
+	response, err := rp.NodeBalanceHistory(c.Uint64("days"))
+	if err != nil {
+		return err
+	}
+	if len(response.Snapshots) == 0 {
+		fmt.Println("There aren't any balance snapshots in that range yet. The node daemon takes one each day, so check back tomorrow if it was only just started.")
+		return nil
+	}
+
+	// Print the snapshots
+	fmt.Printf("Balance history for node %s:\n\n", response.Node.Hex())
+	fmt.Printf("%-10s  %12s  %12s  %12s  %12s  %12s  %9s  %10s\n", "Date", "ETH", "RPL", "rETH", "Staked RPL", "Beacon ETH", "Minipools", "Collateral")
+	for _, snapshot := range response.Snapshots {
+		fmt.Printf("%-10s  %12.6f  %12.6f  %12.6f  %12.6f  %12.6f  %9d  %9.2f%%\n",
+			snapshot.Date,
+			snapshot.EthBalance,
+			snapshot.RplBalance,
+			snapshot.RethBalance,
+			snapshot.StakedRpl,
+			snapshot.BeaconBalance,
+			snapshot.ActiveMinipools,
+			snapshot.CollateralRatio*100,
+		)
+	}
+	fmt.Println()
+
+	// Print the changes
+	if len(response.Deltas) == 0 {
+		fmt.Println("There isn't enough history to show how your balances have changed yet.")
+		return nil
+	}
+	fmt.Printf("%sChanges up to %s:%s\n", colorGreen, response.Deltas[0].To, colorReset)
+	fmt.Printf("%-10s  %13s  %13s  %13s  %13s  %13s  %10s\n", "Period", "ETH", "RPL", "rETH", "Staked RPL", "Beacon ETH", "Collateral")
+	for _, delta := range response.Deltas {
+		fmt.Printf("%-10s  %+13.6f  %+13.6f  %+13.6f  %+13.6f  %+13.6f  %+9.2f%%\n",
+			fmt.Sprintf("%d day(s)", delta.Days),
+			delta.EthBalance,
+			delta.RplBalance,
+			delta.RethBalance,
+			delta.StakedRpl,
+			delta.BeaconBalance,
+			delta.CollateralRatio*100,
+		)
+	}
+
+	return nil
+
+}
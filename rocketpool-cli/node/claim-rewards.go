@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
@@ -81,7 +82,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		// Download the files
 		for _, missingInterval := range missingIntervals {
 			fmt.Printf("Downloading interval %d file... ", missingInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, missingInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -90,7 +91,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		}
 		for _, invalidInterval := range invalidIntervals {
 			fmt.Printf("Downloading interval %d file... ", invalidInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, invalidInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -207,57 +208,79 @@ func nodeClaimRewards(c *cli.Context) error {
 		return err
 	}
 
-	// Check claim ability
-	if restakeAmountWei == nil {
-		canClaim, err := rp.CanNodeClaimRewards(indices)
+	// Work out the cheapest way to claim the selected intervals if there's more than one
+	claimTxs := []api.ClaimTransaction{{
+		Indices:     indices,
+		StakeAmount: restakeAmountWei,
+	}}
+	var gasInfo rocketpoolapi.GasInfo
+	if len(indices) > 1 {
+		claimTxs, err = getOptimizedClaims(c, rp, indices, restakeAmountWei)
 		if err != nil {
 			return err
 		}
-
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+		for _, tx := range claimTxs {
+			gasInfo.EstGasLimit += tx.GasInfo.EstGasLimit
+			gasInfo.SafeGasLimit += tx.GasInfo.SafeGasLimit
+		}
+	} else if restakeAmountWei == nil {
+		canClaim, err := rp.CanNodeClaimRewards(indices)
 		if err != nil {
 			return err
 		}
+		gasInfo = canClaim.GasInfo
 	} else {
 		canClaim, err := rp.CanNodeClaimAndStakeRewards(indices, restakeAmountWei)
 		if err != nil {
 			return err
 		}
+		gasInfo = canClaim.GasInfo
+	}
 
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
-		if err != nil {
-			return err
-		}
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to claim your rewards?")) {
+	confirmation := "Are you sure you want to claim your rewards?"
+	if len(claimTxs) > 1 {
+		confirmation = fmt.Sprintf("Are you sure you want to claim your rewards in %d transactions?", len(claimTxs))
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(confirmation)) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
-	// Claim rewards
-	var txHash common.Hash
-	if restakeAmountWei == nil {
-		response, err := rp.NodeClaimRewards(indices)
-		if err != nil {
-			return err
+	// Claim rewards; the gas settings are cleared after each call, so they're reapplied for each transaction
+	maxFee, maxPrioFee, gasLimit := rp.GetGasSettings()
+	for _, tx := range claimTxs {
+		rp.AssignGasSettings(maxFee, maxPrioFee, gasLimit)
+		var txHash common.Hash
+		if tx.StakeAmount == nil || tx.StakeAmount.Sign() == 0 {
+			response, err := rp.NodeClaimRewards(tx.Indices)
+			if err != nil {
+				return err
+			}
+			txHash = response.TxHash
+		} else {
+			response, err := rp.NodeClaimAndStakeRewards(tx.Indices, tx.StakeAmount)
+			if err != nil {
+				return err
+			}
+			txHash = response.TxHash
 		}
-		txHash = response.TxHash
-	} else {
-		response, err := rp.NodeClaimAndStakeRewards(indices, restakeAmountWei)
-		if err != nil {
+
+		if len(claimTxs) > 1 {
+			fmt.Printf("Claiming rewards for interval(s) %s...\n", formatIntervalIndices(tx.Indices))
+		} else {
+			fmt.Printf("Claiming Rewards...\n")
+		}
+		cliutils.PrintTransactionHash(rp, txHash)
+		if _, err = rp.WaitForTransaction(txHash); err != nil {
 			return err
 		}
-		txHash = response.TxHash
-	}
-
-	fmt.Printf("Claiming Rewards...\n")
-	cliutils.PrintTransactionHash(rp, txHash)
-	if _, err = rp.WaitForTransaction(txHash); err != nil {
-		return err
 	}
 
 	// Log & return
@@ -458,3 +481,54 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 	return restakeAmountWei, nil
 
 }
+
+// Compare the ways the selected intervals can be claimed and get the transactions of the chosen one
+func getOptimizedClaims(c *cli.Context, rp *rocketpool.Client, indices []uint64, restakeAmountWei *big.Int) ([]api.ClaimTransaction, error) {
+	stakeAmountWei := big.NewInt(0)
+	if restakeAmountWei != nil {
+		stakeAmountWei = restakeAmountWei
+	}
+	response, err := rp.NodeOptimizeClaims(indices, stakeAmountWei)
+	if err != nil {
+		return nil, err
+	}
+	if response.RecommendedPlan < 0 || response.RecommendedPlan >= len(response.Plans) {
+		return nil, fmt.Errorf("no way of claiming the selected intervals was found")
+	}
+
+	// Print the comparison
+	recommended := response.Plans[response.RecommendedPlan]
+	fmt.Println("Ways to claim the selected intervals:")
+	for i, plan := range response.Plans {
+		marker := " "
+		if i == response.RecommendedPlan {
+			marker = "*"
+		}
+		fmt.Printf("%s %-48s %d tx, %d gas (%d bytes of calldata costing %d gas)\n", marker, plan.Description+":", len(plan.Transactions), plan.TotalGas, plan.TotalCalldataBytes, plan.TotalCalldataGas)
+	}
+	for _, plan := range response.Plans {
+		if plan.Restake != recommended.Restake && plan.Description == strings.TrimSuffix(recommended.Description, ", restaking RPL") {
+			fmt.Printf("Restaking RPL in the claim costs an extra %d gas.\n", int64(recommended.TotalGas)-int64(plan.TotalGas))
+		}
+	}
+	fmt.Printf("%s* Recommended: %s.%s\n\n", colorBlue, recommended.Description, colorReset)
+
+	// Let the user fall back to a single transaction if the recommendation needs more than one
+	if len(recommended.Transactions) > 1 && !c.Bool("yes") && !cliutils.Confirm("Would you like to use the recommended way?") {
+		for _, plan := range response.Plans {
+			if plan.Restake == recommended.Restake && len(plan.Transactions) == 1 {
+				return plan.Transactions, nil
+			}
+		}
+	}
+	return recommended.Transactions, nil
+}
+
+// Format interval indices as a comma-separated list
+func formatIntervalIndices(indices []uint64) string {
+	elements := make([]string, 0, len(indices))
+	for _, index := range indices {
+		elements = append(elements, fmt.Sprint(index))
+	}
+	return strings.Join(elements, ",")
+}
@@ -1,8 +1,11 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/prices"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -97,6 +100,146 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return getRewards(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"x"},
+						Usage:     "Export the rewards your node earned in every interval, along with their claim transactions and fiat value, for tax reporting",
+						UsageText: "rocketpool node rewards export [--format csv|json] [--output path] [--currency usd] [--price-source coingecko|none|url]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "format, f",
+								Usage: "The format to export to: 'csv' or 'json'",
+								Value: exportFormatCsv,
+							},
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "The file to write the export to (prints it to the terminal if blank)",
+							},
+							cli.StringFlag{
+								Name:  "currency, c",
+								Usage: "The fiat currency to value the rewards in at the end of each interval",
+								Value: defaultRewardsCurrency,
+							},
+							cli.StringFlag{
+								Name:  "price-source",
+								Usage: "Where to get historical prices from: 'coingecko', 'none' to leave out fiat values, or a URL returning JSON with a 'price' field that can use the {asset}, {currency}, and {date} placeholders",
+								Value: prices.Source_CoinGecko,
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return exportRewards(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "withdrawal-ledger",
+				Aliases:   []string{"wl"},
+				Usage:     "Show a dated ledger of everything Rocket Pool has sent to the node's withdrawal address",
+				UsageText: "rocketpool node withdrawal-ledger",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getWithdrawalLedger(c)
+
+				},
+			},
+
+			{
+				Name:      "export-calendar",
+				Aliases:   []string{"cal"},
+				Usage:     "Export the node's upcoming obligations, such as rewards checkpoints, sync committee duties, and planned maintenance, as an iCal file",
+				UsageText: "rocketpool node export-calendar [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the calendar to",
+						Value: "rocketpool-duties.ics",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportCalendar(c)
+
+				},
+			},
+
+			{
+				Name:      "history",
+				Usage:     "Show the node's lifecycle events from its event journal, such as minipool deposits, reward claims, and daemon errors",
+				UsageText: "rocketpool node history [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "type, t",
+						Usage: "The event types to show: 'all' or a comma-separated list of wallet_saved, minipool_created, minipool_staked, minipool_exited, rewards_claimed, bond_reduction_started, bond_reduced, and daemon_error",
+						Value: "all",
+					},
+					cli.StringFlag{
+						Name:  "since, s",
+						Usage: "Only show events after this date (YYYY-MM-DD) or within this duration of now (e.g. '72h')",
+					},
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of most recent events to show (0 for all of them)",
+						Value: 50,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getNodeHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "balance-history",
+				Usage:     "Show the node's daily balance snapshots and how its balances, RPL stake, and collateral have changed over time",
+				UsageText: "rocketpool node balance-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of snapshots to show (0 for all of them)",
+						Value: 30,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getBalanceHistory(c)
+
+				},
 			},
 
 			{
@@ -152,6 +295,91 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rpl-withdrawal-address",
+				Usage:     "Show the node's RPL withdrawal address and how to confirm a pending change to it",
+				UsageText: "rocketpool node rpl-withdrawal-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRplWithdrawalAddress(c)
+
+				},
+			},
+
+			{
+				Name:      "set-rpl-withdrawal-address",
+				Usage:     "Set the address the node's RPL rewards and staked RPL are withdrawn to, separately from its withdrawal address",
+				UsageText: "rocketpool node set-rpl-withdrawal-address [options] address",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm setting the RPL withdrawal address",
+					},
+					cli.BoolFlag{
+						Name:  "force",
+						Usage: "Force update the RPL withdrawal address, bypassing the 'pending' state that requires a confirmation transaction from the new address",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					rplWithdrawalAddress := c.Args().Get(0)
+
+					// Run
+					return setRplWithdrawalAddress(c, rplWithdrawalAddress)
+
+				},
+			},
+
+			{
+				Name:      "confirm-rpl-withdrawal-address",
+				Usage:     "Confirm the node's pending RPL withdrawal address, or show the transaction the pending address needs to send to confirm itself",
+				UsageText: "rocketpool node confirm-rpl-withdrawal-address [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the RPL withdrawal address",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return confirmRplWithdrawalAddress(c)
+
+				},
+			},
+
+			{
+				Name:      "withdrawal-safety",
+				Usage:     "Check the node's withdrawal addresses against the ones it's configured to expect, and show the message to sign to prove you control the withdrawal address",
+				UsageText: "rocketpool node withdrawal-safety",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getWithdrawalSafety(c)
+
+				},
+			},
+
 			{
 				Name:      "set-timezone",
 				Aliases:   []string{"t"},
@@ -286,6 +514,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "run-plan",
+				Usage:     "Run a prepared list of operations (rewards claims, balance distributions, and RPL staking) in order",
+				UsageText: "rocketpool node run-plan [options] plan-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Run every step without asking for confirmation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return runPlan(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "withdraw-rpl",
 				Aliases:   []string{"i"},
@@ -296,6 +547,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "amount, a",
 						Usage: "The amount of RPL to withdraw (or 'max')",
 					},
+					cli.BoolFlag{
+						Name:  "max-safe",
+						Usage: "Withdraw as much RPL as possible while keeping your stake above the target collateral, including pending bond reductions",
+					},
+					cli.Float64Flag{
+						Name:  "target-collateral",
+						Usage: "The collateral to keep with --max-safe, as a percentage of the ETH your node has borrowed",
+						Value: 10,
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm RPL withdrawal",
@@ -309,6 +569,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if c.Bool("max-safe") && c.String("amount") != "" {
+						return fmt.Errorf("--max-safe and --amount cannot be used together")
+					}
+					if c.Float64("target-collateral") < 0 {
+						return fmt.Errorf("Invalid target collateral '%f' - must be 0 or more", c.Float64("target-collateral"))
+					}
 					if c.String("amount") != "" && c.String("amount") != "max" {
 						if _, err := cliutils.ValidatePositiveEthAmount("withdrawal amount", c.String("amount")); err != nil {
 							return err
@@ -343,6 +609,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "salt, l",
 						Usage: "An optional seed to use when generating the new minipool's address. Use this if you want it to have a custom vanity address.",
 					},
+					cli.Uint64Flag{
+						Name:  "count, n",
+						Usage: "The number of minipools to create in this session; they all use the same bond and commission rate",
+						Value: 1,
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -352,6 +623,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if c.Uint64("count") == 0 {
+						return fmt.Errorf("The minipool count must be at least 1.")
+					}
+					if c.Uint64("count") > 1 && c.String("salt") != "" {
+						return fmt.Errorf("A custom salt can't be used when creating more than one minipool.")
+					}
 					if c.String("amount") != "" {
 						if _, err := cliutils.ValidatePositiveEthAmount("deposit amount", c.String("amount")); err != nil {
 							return err
@@ -491,7 +768,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					delegate := c.Args().Get(0)
 
 					// Run
-					return nodeSetVotingDelegate(c, delegate)
+					return SetVotingDelegate(c, delegate)
 
 				},
 			},
@@ -514,7 +791,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return nodeClearVotingDelegate(c)
+					return ClearVotingDelegate(c)
 
 				},
 			},
@@ -618,19 +895,161 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "sign-message",
 				Aliases:   []string{"sm"},
-				Usage:     "Sign an arbitrary message with the node's private key",
-				UsageText: "rocketpool node sign-message [-m message]",
+				Usage:     "Sign an arbitrary message (EIP-191) or typed data (EIP-712) with the node's private key",
+				UsageText: "rocketpool node sign-message [-m message | -t typed-data-file [-y]]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "message, m",
 						Usage: "The 'quoted message' to be signed",
 					},
+					cli.StringFlag{
+						Name:  "typed-data, t",
+						Usage: "The path to a JSON file with EIP-712 typed data to sign instead of a message",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing typed data",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					// Run
 					return signMessage(c)
 				},
 			},
+
+			{
+				Name:      "export",
+				Aliases:   []string{"x"},
+				Usage:     "Export your node's minipools, rewards intervals, balances, and transactions to a spreadsheet",
+				UsageText: "rocketpool node export [--format xlsx|csv] [--output path]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format to export to: 'xlsx' for a single workbook, or 'csv' for a directory with one file per sheet",
+						Value: exportFormatXlsx,
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path of the workbook file or CSV directory to write",
+						Value: defaultExportOutput,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportNodeData(c)
+
+				},
+			},
+
+			{
+				Name:      "commission-upgrades",
+				Aliases:   []string{"cu"},
+				Usage:     "Find bond reductions and new deposits that would raise your node's average commission",
+				UsageText: "rocketpool node commission-upgrades",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getCommissionUpgrades(c)
+
+				},
+			},
+
+			{
+				Name:      "graffiti",
+				Usage:     "Show the graffiti each of your validators will use",
+				UsageText: "rocketpool node graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getGraffiti(c)
+
+				},
+			},
+
+			{
+				Name:  "set-graffiti",
+				Usage: "Set the graffiti template of one of your validators",
+				UsageText: "rocketpool node set-graffiti [options] pubkey\n\n" +
+					"   The template can use the variables {graffiti}, {prefix}, {custom}, {ec}, {cc}, {ecVersion}, {ccVersion}, {nickname}, {index} and {message}.",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "template, t",
+						Usage: "The graffiti template for the validator; leave it out to go back to the node's graffiti template",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return setGraffiti(c, c.String("template"))
+
+				},
+			},
+
+			{
+				Name:      "attach-rescue",
+				Usage:     "Temporarily connect your validator client to the Rescue Node while your own Beacon Node is unavailable",
+				UsageText: "rocketpool node attach-rescue [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "duration, d",
+						Usage: "How long to use the Rescue Node for, such as '240h'; this should match the lifetime of the credential it issues you",
+						Value: "240h",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch to the Rescue Node",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return attachRescue(c)
+
+				},
+			},
+
+			{
+				Name:      "detach-rescue",
+				Usage:     "Connect your validator client back to your own Beacon Node before the Rescue Node window closes",
+				UsageText: "rocketpool node detach-rescue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return detachRescue(c)
+
+				},
+			},
 		},
 	})
 }
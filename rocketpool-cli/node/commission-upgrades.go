@@ -0,0 +1,91 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getCommissionUpgrades(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the upgrades
+	response, err := rp.GetCommissionUpgrades()
+	if err != nil {
+		return err
+	}
+	if !response.IsAtlasDeployed {
+		fmt.Println("Commission upgrades are not available until Atlas has been deployed.")
+		return nil
+	}
+	report := response.Report
+
+	// Print the current commission
+	if report.BorrowedEth > 0 {
+		fmt.Printf("Your node's average commission is %.2f%% across %.0f ETH borrowed from the staking pool.\n", report.AverageCommission*100, report.BorrowedEth)
+	} else {
+		fmt.Println("Your node does not have any active minipools.")
+	}
+	fmt.Printf("New minipools currently earn a commission of %.2f%%.\n", report.NetworkCommission*100)
+	if report.DepositCreditBalance.Sign() > 0 {
+		fmt.Printf("You have %.6f ETH in your deposit credit balance.\n", math.RoundDown(eth.WeiToEth(report.DepositCreditBalance), 6))
+	}
+	fmt.Println()
+
+	if len(report.Upgrades) == 0 {
+		fmt.Println("There are no actions that would raise your node's commission right now.")
+		return nil
+	}
+
+	// Print each upgrade
+	colorReset := "\033[0m"
+	colorGreen := "\033[32m"
+	colorYellow := "\033[33m"
+	fmt.Println("Each action below is measured on its own against your current minipools.")
+	fmt.Println("\"Extra rewards\" is the additional ETH you would earn each year for every 1% of staking APR.")
+	fmt.Println()
+	for _, upgrade := range report.Upgrades {
+		switch upgrade.Type {
+		case rputils.CommissionUpgradeType_ReduceBond:
+			fmt.Printf("%sReduce the bond of minipool %s from 16 to 8 ETH%s\n", colorGreen, upgrade.MinipoolAddress.Hex(), colorReset)
+			fmt.Printf("\tCommission:       %.2f%% -> %.2f%%\n", upgrade.CurrentCommission*100, upgrade.NewCommission*100)
+			fmt.Println("\tStart with 'rocketpool minipool begin-bond-reduction'. The 8 ETH released is added to your deposit credit.")
+		case rputils.CommissionUpgradeType_DepositWithCredit:
+			fmt.Printf("%sCreate an 8 ETH minipool using your deposit credit (you can create %d)%s\n", colorGreen, upgrade.Count, colorReset)
+			fmt.Printf("\tCommission:       %.2f%%\n", upgrade.NewCommission*100)
+			fmt.Println("\tUse 'rocketpool node deposit --amount 8'; no ETH is needed from your node wallet.")
+		case rputils.CommissionUpgradeType_Deposit:
+			fmt.Printf("%sCreate an 8 ETH minipool%s\n", colorGreen, colorReset)
+			fmt.Printf("\tCommission:       %.2f%%\n", upgrade.NewCommission*100)
+			fmt.Printf("\tUse 'rocketpool node deposit --amount 8'; this requires %.0f ETH from your node wallet.\n", upgrade.EthRequired)
+		}
+		fmt.Printf("\tBorrowed ETH:     +%.0f ETH\n", upgrade.AdditionalBorrowedEth)
+		fmt.Printf("\tAvg. commission:  %.2f%% -> %.2f%%\n", report.AverageCommission*100, upgrade.NewAverageCommission*100)
+		fmt.Printf("\tExtra rewards:    %.6f ETH per year per 1%% APR\n", upgrade.CommissionWeightedEthGain*0.01)
+		if upgrade.AdditionalRplRequired.Sign() > 0 {
+			fmt.Printf("\t%sYou must stake %.6f more RPL to meet the minimum collateral for this.%s\n", colorYellow, math.RoundUp(eth.WeiToEth(upgrade.AdditionalRplRequired), 6), colorReset)
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
@@ -47,7 +47,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		return nil
 	}
 
-	fmt.Println("Your eth2 client is on the correct network.\n")
+	fmt.Print("Your eth2 client is on the correct network.\n\n")
 
 	// Check for Atlas
 	atlasResponse, err := rp.IsAtlasDeployed()
@@ -256,7 +256,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 	if c.IsSet("mnemonic") {
 		mnemonic = c.String("mnemonic")
 	} else if !c.Bool("yes") {
-		fmt.Println("You have the option of importing your validator's private key into the Smartnode's Validator Client instead of running your own Validator Client separately. In doing so, the Smartnode will also automatically migrate your validator's withdrawal credentials from your BLS private key to the minipool you just created.\n")
+		fmt.Print("You have the option of importing your validator's private key into the Smartnode's Validator Client instead of running your own Validator Client separately. In doing so, the Smartnode will also automatically migrate your validator's withdrawal credentials from your BLS private key to the minipool you just created.\n\n")
 		if cliutils.Confirm("Would you like to import your key and automatically migrate your withdrawal credentials?") {
 			mnemonic = wallet.PromptMnemonic()
 		}
@@ -266,7 +266,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		handleImport(c, rp, response.MinipoolAddress, mnemonic)
 	} else {
 		// Ignore importing / it errored out
-		fmt.Println("Since you're not importing your validator key, you will still be responsible for running and maintaining your own Validator Client with the validator's private key loaded, just as you are today.\n\n")
+		fmt.Print("Since you're not importing your validator key, you will still be responsible for running and maintaining your own Validator Client with the validator's private key loaded, just as you are today.\n\n\n")
 		fmt.Printf("You must now upgrade your validator's withdrawal credentials manually, using as tool such as `ethdo` (https://github.com/wealdtech/ethdo), to the following minipool address:\n\n\t%s\n\n", response.MinipoolAddress)
 	}
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -48,7 +49,7 @@ func nodeDeposit(c *cli.Context) error {
 		return nil
 	}
 
-	fmt.Println("Your eth2 client is on the correct network.\n")
+	fmt.Print("Your eth2 client is on the correct network.\n\n")
 
 	// Check if the fee distributor has been initialized
 	isInitializedResponse, err := rp.IsFeeDistributorInitialized()
@@ -72,6 +73,23 @@ func nodeDeposit(c *cli.Context) error {
 		return fmt.Errorf("error checking if Atlas has been deployed: %w", err)
 	}
 
+	// Show how many minipools the node can afford
+	count := c.Uint64("count")
+	if count == 0 {
+		count = 1
+	}
+	var plan api.NodeDepositPlanResponse
+	if atlasResponse.IsAtlasDeployed {
+		plan, err = rp.GetNodeDepositPlan()
+		if err != nil {
+			return err
+		}
+		printDepositPlan(plan)
+	} else if count > 1 {
+		fmt.Println("Multiple minipools can't be created in one session until the Atlas upgrade has been activated.")
+		return nil
+	}
+
 	// Get deposit amount
 
 	var amount float64
@@ -105,6 +123,30 @@ func nodeDeposit(c *cli.Context) error {
 
 	amountWei := eth.EthToWei(amount)
 
+	// Make sure every requested minipool can be created before starting on the first one
+	if count > 1 {
+		var option *api.NodeDepositPlanOption
+		for i := range plan.Options {
+			if plan.Options[i].BondAmount.Cmp(amountWei) == 0 {
+				option = &plan.Options[i]
+				break
+			}
+		}
+		if option == nil {
+			return fmt.Errorf("Multiple minipools can only be created with a bond of 8 or 16 ETH.")
+		}
+		if option.MaxCount < count {
+			fmt.Printf("Cannot create %d minipools with a %.0f ETH bond; the node can only create %d right now.\n", count, amount, option.MaxCount)
+			if option.MaxByEth < count {
+				fmt.Printf("Your wallet balance and usable credit (%.6f ETH) only cover %d of them.\n", math.RoundDown(eth.WeiToEth(plan.AvailableEth), 6), option.MaxByEth)
+			}
+			if option.MaxByRpl < count {
+				fmt.Printf("Your RPL stake can only collateralize %d of them; you can borrow %.6f more ETH but each one borrows %.0f ETH.\n", option.MaxByRpl, math.RoundDown(eth.WeiToEth(plan.AvailableToMatch), 6), eth.WeiToEth(option.MatchAmount))
+			}
+			return nil
+		}
+	}
+
 	// Get network node fees
 	nodeFees, err := rp.NodeFee()
 	if err != nil {
@@ -157,12 +199,10 @@ func nodeDeposit(c *cli.Context) error {
 			return fmt.Errorf("Invalid minipool salt: %s", c.String("salt"))
 		}
 	} else {
-		buffer := make([]byte, 32)
-		_, err = rand.Read(buffer)
+		salt, err = getRandomSalt()
 		if err != nil {
-			return fmt.Errorf("Error generating random salt: %w", err)
+			return err
 		}
-		salt = big.NewInt(0).SetBytes(buffer)
 	}
 
 	// Check deposit can be made
@@ -250,14 +290,29 @@ func nodeDeposit(c *cli.Context) error {
 		}
 	}
 
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canDeposit.GasInfo, rp, c.Bool("yes"))
+	// Assign max fees, covering every deposit in the session
+	gasInfo := canDeposit.GasInfo
+	gasInfo.EstGasLimit *= count
+	gasInfo.SafeGasLimit *= count
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
 	if err != nil {
 		return err
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+	if count > 1 {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+			"You are about to deposit %.6f ETH each to create %d minipools with a minimum possible commission rate of %f%%.\n"+
+				"%sARE YOU SURE YOU WANT TO DO THIS? Exiting these minipools and retrieving your capital cannot be done until they have been *active* on the Beacon Chain for 256 epochs (approx. 27 hours).%s\n",
+			math.RoundDown(eth.WeiToEth(amountWei), 6),
+			count,
+			minNodeFee*100,
+			colorYellow,
+			colorReset))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
 		"You are about to deposit %.6f ETH to create a minipool with a minimum possible commission rate of %f%%.\n"+
 			"%sARE YOU SURE YOU WANT TO DO THIS? Exiting this minipool and retrieving your capital cannot be done until your minipool has been *active* on the Beacon Chain for 256 epochs (approx. 27 hours).%s\n",
 		math.RoundDown(eth.WeiToEth(amountWei), 6),
@@ -268,25 +323,57 @@ func nodeDeposit(c *cli.Context) error {
 		return nil
 	}
 
-	// Make deposit
-	response, err := rp.NodeDeposit(amountWei, minNodeFee, salt, useCreditBalance, true)
-	if err != nil {
-		return err
-	}
+	// Make the deposits; the gas settings are cleared after each API call, so they're reapplied for each transaction
+	maxFee, maxPrioFee, gasLimit := rp.GetGasSettings()
+	var response api.NodeDepositResponse
+	for i := uint64(0); i < count; i++ {
 
-	// Log and wait for the minipool address
-	fmt.Printf("Creating minipool...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	_, err = rp.WaitForTransaction(response.TxHash)
-	if err != nil {
-		return err
-	}
+		// Every deposit after the first needs its own salt, and the credit it can use depends on the ones before it
+		if i > 0 {
+			salt, err = getRandomSalt()
+			if err != nil {
+				return err
+			}
+			canDeposit, err = rp.CanNodeDeposit(amountWei, minNodeFee, salt)
+			if err != nil {
+				return err
+			}
+			if !canDeposit.CanDeposit {
+				fmt.Printf("%sCannot create any more minipools; %d of %d were created. Please run `rocketpool node deposit` again for details.%s\n", colorYellow, i, count, colorReset)
+				return nil
+			}
+			useCreditBalance = canDeposit.CanUseCredit && canDeposit.CreditBalance.Cmp(big.NewInt(0)) > 0
+		}
+
+		// Make deposit
+		rp.AssignGasSettings(maxFee, maxPrioFee, gasLimit)
+		response, err = rp.NodeDeposit(amountWei, minNodeFee, salt, useCreditBalance, true)
+		if err != nil {
+			return err
+		}
+
+		// Log and wait for the minipool address
+		if count > 1 {
+			fmt.Printf("Creating minipool %d of %d...\n", i+1, count)
+		} else {
+			fmt.Printf("Creating minipool...\n")
+		}
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		_, err = rp.WaitForTransaction(response.TxHash)
+		if err != nil {
+			return err
+		}
+
+		// Log
+		fmt.Printf("The node deposit of %.6f ETH was made successfully!\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+		fmt.Printf("Your new minipool's address is: %s\n", response.MinipoolAddress)
+		fmt.Printf("The validator pubkey is: %s\n\n", response.ValidatorPubkey.Hex())
 
-	// Log & return
-	fmt.Printf("The node deposit of %.6f ETH was made successfully!\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
-	fmt.Printf("Your new minipool's address is: %s\n", response.MinipoolAddress)
-	fmt.Printf("The validator pubkey is: %s\n\n", response.ValidatorPubkey.Hex())
+	}
 
+	if count > 1 {
+		fmt.Printf("All %d minipools were created successfully.\n", count)
+	}
 	fmt.Println("Your minipool is now in Initialized status.")
 	fmt.Println("Once the remaining ETH has been assigned to your minipool from the staking pool, it will move to Prelaunch status.")
 	fmt.Printf("After that, it will move to Staking status once %s have passed.\n", response.ScrubPeriod)
@@ -295,3 +382,36 @@ func nodeDeposit(c *cli.Context) error {
 	return nil
 
 }
+
+// Generate a random minipool salt
+func getRandomSalt() (*big.Int, error) {
+	buffer := make([]byte, 32)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("Error generating random salt: %w", err)
+	}
+	return big.NewInt(0).SetBytes(buffer), nil
+}
+
+// Print how many minipools of each bond size the node can create, and what's holding it back
+func printDepositPlan(plan api.NodeDepositPlanResponse) {
+	fmt.Printf("Your node has %.6f ETH in its wallet and %.6f ETH of deposit credit.\n", math.RoundDown(eth.WeiToEth(plan.NodeBalance), 6), math.RoundDown(eth.WeiToEth(plan.CreditBalance), 6))
+	if plan.CreditBalance.Sign() > 0 && !plan.CanUseCredit {
+		fmt.Printf("%sYour credit can't be used right now because the staking pool only has %.2f ETH in it (it needs at least 1 ETH).%s\n", colorYellow, eth.WeiToEth(plan.DepositPoolBalance), colorReset)
+	}
+	fmt.Printf("Your staked RPL lets you borrow %.6f more ETH from the staking pool.\n", math.RoundDown(eth.WeiToEth(plan.AvailableToMatch), 6))
+	if plan.DepositDisabled {
+		fmt.Printf("%sNode deposits are currently disabled.%s\n\n", colorYellow, colorReset)
+		return
+	}
+	for _, option := range plan.Options {
+		limit := ""
+		if option.MaxByEth < option.MaxByRpl {
+			limit = " (limited by ETH)"
+		} else if option.MaxByRpl < option.MaxByEth {
+			limit = " (limited by RPL stake)"
+		}
+		fmt.Printf("\tYou can create %d minipool(s) with a %.0f ETH bond%s.\n", option.MaxCount, eth.WeiToEth(option.BondAmount), limit)
+	}
+	fmt.Println()
+}
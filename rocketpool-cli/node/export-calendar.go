@@ -0,0 +1,65 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/ical"
+)
+
+// The name of the calendar in calendar apps
+const dutiesCalendarName string = "Rocket Pool Node Duties"
+
+func exportCalendar(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the events
+	response, err := rp.NodeDutiesCalendar()
+	if err != nil {
+		return err
+	}
+
+	// Write the calendar
+	output := c.String("output")
+	if err := os.WriteFile(output, []byte(ical.Write(dutiesCalendarName, response.Events)), 0644); err != nil {
+		return fmt.Errorf("error writing calendar to %s: %w", output, err)
+	}
+
+	// Print the events
+	fmt.Printf("Exported %d event(s) to %s:\n", len(response.Events), output)
+	for _, event := range response.Events {
+		fmt.Printf("%s  %-24s  %s\n", event.Start.Local().Format("2006-01-02 15:04"), event.Category, event.Summary)
+	}
+	fmt.Println()
+
+	// Point out the live feed
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Smartnode.EnableNodeApi.Value == true && cfg.Smartnode.NodeApiCalendarToken.Value.(string) != "" {
+		fmt.Printf("You can also subscribe to the calendar so it stays up to date: http://<your node's address>:%d/api/v1/node/calendar.ics?token=<your node API calendar token>\n", cfg.Smartnode.NodeApiPort.Value)
+	} else if cfg.Smartnode.EnableNodeApi.Value == true {
+		fmt.Println("To subscribe to the calendar so it stays up to date instead, set a Node HTTP API Calendar Token in the Smartnode section of `rocketpool service config` and subscribe to its /api/v1/node/calendar.ics?token=<calendar token> route.")
+	} else {
+		fmt.Println("To subscribe to the calendar so it stays up to date instead, enable the node HTTP API in the Smartnode section of `rocketpool service config` and subscribe to its /api/v1/node/calendar.ics route.")
+	}
+	return nil
+
+}
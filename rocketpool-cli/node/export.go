@@ -0,0 +1,327 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/xlsx"
+)
+
+// Settings
+const (
+	exportFormatXlsx    string = "xlsx"
+	exportFormatCsv     string = "csv"
+	defaultExportOutput string = "rocketpool-node-export"
+)
+
+func exportNodeData(c *cli.Context) error {
+
+	// Get the format
+	format := strings.ToLower(c.String("format"))
+	if format != exportFormatXlsx && format != exportFormatCsv {
+		return fmt.Errorf("Invalid format '%s'; please use '%s' or '%s'.", format, exportFormatXlsx, exportFormatCsv)
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the node data
+	fmt.Println("Gathering your node's data, this may take a while...")
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Registered {
+		return fmt.Errorf("The node is not registered with Rocket Pool.")
+	}
+	minipoolStatus, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+	rewardsInfo, err := rp.GetRewardsInfo()
+	if err != nil {
+		return err
+	}
+	activity, err := rp.GetNodeActivity()
+	if err != nil {
+		return err
+	}
+
+	// Build the sheets
+	wb := xlsx.NewWorkbook()
+	err = wb.AddSheet("Minipools", []string{
+		"Address", "Validator Pubkey", "Validator Index", "Status", "Status Time", "Deposit Type", "Commission (%)",
+		"Node Deposit (ETH)", "User Deposit (ETH)", "Beacon Balance (ETH)", "Minipool Balance (ETH)", "Your Share (ETH)", "Refund (ETH)", "Finalised",
+	}, getMinipoolRows(minipoolStatus.Minipools))
+	if err != nil {
+		return err
+	}
+	err = wb.AddSheet("Rewards Intervals", []string{
+		"Interval", "Status", "Start Time", "End Time", "Collateral RPL", "Oracle DAO RPL", "Smoothing Pool ETH",
+	}, getRewardsIntervalRows(rewardsInfo, activity.Activity))
+	if err != nil {
+		return err
+	}
+	err = wb.AddSheet("Balances", []string{
+		"Account", "Item", "Amount", "Token",
+	}, getBalanceRows(status))
+	if err != nil {
+		return err
+	}
+	err = wb.AddSheet("Transactions", []string{
+		"Time", "Block", "Type", "Interval", "Minipool", "RPL", "ETH", "Transaction Hash",
+	}, getTransactionRows(activity.Activity))
+	if err != nil {
+		return err
+	}
+
+	// Write the output
+	output := c.String("output")
+	if format == exportFormatXlsx {
+		if filepath.Ext(output) != ".xlsx" {
+			output += ".xlsx"
+		}
+		err = writeXlsxExport(wb, output)
+	} else {
+		err = writeCsvExport(wb, output)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d minipools, %d rewards intervals, and %d transactions to %s.\n", len(minipoolStatus.Minipools), len(wb.Sheets()[1].Rows), len(activity.Activity), output)
+	return nil
+
+}
+
+// Get a row for each of the node's minipools
+func getMinipoolRows(minipools []api.MinipoolDetails) [][]interface{} {
+	rows := [][]interface{}{}
+	for _, mp := range minipools {
+		var validatorIndex interface{}
+		var beaconBalance interface{}
+		if mp.Validator.Exists {
+			validatorIndex = mp.Validator.Index
+			beaconBalance = weiToEth(mp.Validator.Balance)
+		}
+		rows = append(rows, []interface{}{
+			mp.Address.Hex(),
+			mp.ValidatorPubkey.Hex(),
+			validatorIndex,
+			mp.Status.Status.String(),
+			mp.Status.StatusTime,
+			mp.DepositType.String(),
+			mp.Node.Fee * 100,
+			weiToEth(mp.Node.DepositBalance),
+			weiToEth(mp.User.DepositBalance),
+			beaconBalance,
+			weiToEth(mp.Balances.ETH),
+			weiToEth(mp.NodeShareOfETHBalance),
+			weiToEth(mp.Node.RefundBalance),
+			mp.Finalised,
+		})
+	}
+	return rows
+}
+
+// Get a row for each rewards interval the node has been part of
+func getRewardsIntervalRows(rewardsInfo api.NodeGetRewardsInfoResponse, activity []api.NodeActivity) [][]interface{} {
+
+	// Get the claimed amounts from the claim transactions
+	claims := map[uint64]api.NodeActivity{}
+	for _, item := range activity {
+		if item.Interval != nil {
+			claims[*item.Interval] = item
+		}
+	}
+
+	intervalRows := map[uint64][]interface{}{}
+	for _, interval := range rewardsInfo.ClaimedIntervals {
+		row := []interface{}{interval, "Claimed", nil, nil, nil, nil, nil}
+		if claim, exists := claims[interval]; exists {
+			row[4] = weiToEth(claim.RplAmount)
+			row[6] = weiToEth(claim.EthAmount)
+		}
+		intervalRows[interval] = row
+	}
+	addIntervalInfoRows(intervalRows, rewardsInfo.UnclaimedIntervals, "Unclaimed")
+	addIntervalInfoRows(intervalRows, rewardsInfo.InvalidIntervals, "Invalid Tree File")
+
+	// Sort them by interval
+	intervals := make([]uint64, 0, len(intervalRows))
+	for interval := range intervalRows {
+		intervals = append(intervals, interval)
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	rows := make([][]interface{}, 0, len(intervals))
+	for _, interval := range intervals {
+		rows = append(rows, intervalRows[interval])
+	}
+	return rows
+
+}
+
+// Add rows for intervals that have their details available
+func addIntervalInfoRows(intervalRows map[uint64][]interface{}, intervals []rewards.IntervalInfo, status string) {
+	for _, info := range intervals {
+		intervalRows[info.Index] = []interface{}{
+			info.Index,
+			status,
+			info.StartTime,
+			info.EndTime,
+			quotedWeiToEth(info.CollateralRplAmount),
+			quotedWeiToEth(info.ODaoRplAmount),
+			quotedWeiToEth(info.SmoothingPoolEthAmount),
+		}
+	}
+}
+
+// Get a row for each of the node's and withdrawal address's balances
+func getBalanceRows(status api.NodeStatusResponse) [][]interface{} {
+	node := fmt.Sprintf("Node (%s)", status.AccountAddress.Hex())
+	withdrawal := fmt.Sprintf("Withdrawal Address (%s)", status.WithdrawalAddress.Hex())
+	rows := [][]interface{}{
+		{node, "Balance", weiToEth(status.AccountBalances.ETH), "ETH"},
+		{node, "Balance", weiToEth(status.AccountBalances.RPL), "RPL"},
+		{node, "Balance", weiToEth(status.AccountBalances.FixedSupplyRPL), "Legacy RPL"},
+		{node, "Balance", weiToEth(status.AccountBalances.RETH), "rETH"},
+		{node, "Staked", weiToEth(status.RplStake), "RPL"},
+		{node, "Effective Stake", weiToEth(status.EffectiveRplStake), "RPL"},
+		{node, "Minimum Stake", weiToEth(status.MinimumRplStake), "RPL"},
+		{node, "Maximum Stake", weiToEth(status.MaximumRplStake), "RPL"},
+		{node, "Borrowed Collateral Ratio (%)", status.BorrowedCollateralRatio * 100, ""},
+		{node, "Bonded Collateral Ratio (%)", status.BondedCollateralRatio * 100, ""},
+	}
+	if status.WithdrawalAddress != status.AccountAddress {
+		rows = append(rows,
+			[]interface{}{withdrawal, "Balance", weiToEth(status.WithdrawalBalances.ETH), "ETH"},
+			[]interface{}{withdrawal, "Balance", weiToEth(status.WithdrawalBalances.RPL), "RPL"},
+			[]interface{}{withdrawal, "Balance", weiToEth(status.WithdrawalBalances.FixedSupplyRPL), "Legacy RPL"},
+			[]interface{}{withdrawal, "Balance", weiToEth(status.WithdrawalBalances.RETH), "rETH"},
+		)
+	}
+	rows = append(rows, []interface{}{"", "Exported At", time.Now(), ""})
+	return rows
+}
+
+// Get a row for each of the node's transactions
+func getTransactionRows(activity []api.NodeActivity) [][]interface{} {
+	rows := make([][]interface{}, 0, len(activity))
+	for _, item := range activity {
+		var interval interface{}
+		if item.Interval != nil {
+			interval = *item.Interval
+		}
+		var minipool interface{}
+		if item.Minipool != (common.Address{}) {
+			minipool = item.Minipool.Hex()
+		}
+		rows = append(rows, []interface{}{
+			item.Time,
+			item.BlockNumber,
+			item.Type,
+			interval,
+			minipool,
+			weiToEth(item.RplAmount),
+			weiToEth(item.EthAmount),
+			item.TxHash.Hex(),
+		})
+	}
+	return rows
+}
+
+// Write the workbook to an .xlsx file
+func writeXlsxExport(wb *xlsx.Workbook, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("Error creating %s: %w", path, err)
+	}
+	defer file.Close()
+
+	err = wb.Write(file)
+	if err != nil {
+		return fmt.Errorf("Error writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// Write each sheet of the workbook to its own CSV file in the output directory
+func writeCsvExport(wb *xlsx.Workbook, dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("Error creating directory %s: %w", dir, err)
+	}
+
+	for _, sheet := range wb.Sheets() {
+		filename := strings.ToLower(strings.ReplaceAll(sheet.Name, " ", "-")) + ".csv"
+		path := filepath.Join(dir, filename)
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", path, err)
+		}
+
+		writer := csv.NewWriter(file)
+		err = writer.Write(sheet.Header)
+		for _, row := range sheet.Rows {
+			if err != nil {
+				break
+			}
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = xlsx.FormatValue(value)
+			}
+			err = writer.Write(record)
+		}
+		writer.Flush()
+		if err == nil {
+			err = writer.Error()
+		}
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("Error writing %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Convert a wei amount to ETH, treating missing amounts as zero
+func weiToEth(amount *big.Int) float64 {
+	if amount == nil {
+		return 0
+	}
+	return eth.WeiToEth(amount)
+}
+
+// Convert a quoted wei amount to ETH, treating missing amounts as zero
+func quotedWeiToEth(amount *rewards.QuotedBigInt) float64 {
+	if amount == nil {
+		return 0
+	}
+	return eth.WeiToEth(&amount.Int)
+}
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getGraffiti(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the graffiti
+	response, err := rp.NodeGraffiti()
+	if err != nil {
+		return err
+	}
+
+	colorReset := "\033[0m"
+	colorYellow := "\033[33m"
+	if !response.Enabled {
+		fmt.Printf("%sGraffiti management is disabled, so your Validator Client is using its own graffiti. You can enable it in the Smartnode section of the `rocketpool service config` TUI.%s\n\n", colorYellow, colorReset)
+	}
+	fmt.Printf("Node graffiti template: %s\n\n", response.Template)
+
+	if len(response.Validators) == 0 {
+		fmt.Println("The node does not have any validators.")
+		return nil
+	}
+
+	// Print each validator's graffiti
+	for _, validator := range response.Validators {
+		fmt.Printf("Validator 0x%s", validator.Pubkey.Hex())
+		if validator.Index != "" {
+			fmt.Printf(" (index %s)", validator.Index)
+		}
+		fmt.Println(":")
+		if validator.Custom {
+			fmt.Printf("\tTemplate: %s (set for this validator)\n", validator.Template)
+		} else {
+			fmt.Printf("\tTemplate: %s\n", validator.Template)
+		}
+		fmt.Printf("\tGraffiti: %s\n\n", validator.Graffiti)
+	}
+	return nil
+
+}
+
+func setGraffiti(c *cli.Context, template string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the pubkey
+	pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	// Set the template
+	if _, err := rp.NodeSetGraffiti(pubkey, template); err != nil {
+		return err
+	}
+
+	if template == "" {
+		fmt.Printf("Validator 0x%s will now use the node's graffiti template.\n", pubkey.Hex())
+	} else {
+		fmt.Printf("Validator 0x%s will now use the graffiti template '%s'.\n", pubkey.Hex(), template)
+	}
+	fmt.Println("The new graffiti will be applied the next time the node daemon checks your validators, if graffiti management is enabled.")
+	return nil
+
+}
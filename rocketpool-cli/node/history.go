@@ -0,0 +1,67 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getNodeHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the start of the range
+	var since uint64
+	if sinceString := c.String("since"); sinceString != "" {
+		sinceTime, err := parseHistorySince(sinceString)
+		if err != nil {
+			return err
+		}
+		since = uint64(sinceTime.Unix())
+	}
+
+	// Get the events
+	response, err := rp.NodeHistory(c.String("type"), since, c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Events) == 0 {
+		fmt.Println("The event journal doesn't have any matching events.")
+		return nil
+	}
+
+	// Print the timeline
+	for _, event := range response.Events {
+		source := "manual"
+		if event.Automatic {
+			source = "daemon"
+		}
+		fmt.Printf("%s  %-22s  %-6s  %s\n", event.Time.Local().Format("2006-01-02 15:04:05"), event.Type, source, event.Message)
+		if event.TxHash != nil {
+			fmt.Printf("%s  Transaction: %s%s\n", colorBlue, event.TxHash.Hex(), colorReset)
+		}
+	}
+
+	return nil
+
+}
+
+// Parse the start of the history range, which is either a date or a duration before now
+func parseHistorySince(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start '%s': it must be a date (YYYY-MM-DD) or a duration such as '72h'", value)
+	}
+	return time.Now().Add(-duration), nil
+}
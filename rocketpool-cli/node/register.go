@@ -30,7 +30,7 @@ func registerNode(c *cli.Context) error {
 	if c.String("timezone") != "" {
 		timezoneLocation = c.String("timezone")
 	} else {
-		timezoneLocation = promptTimezone()
+		timezoneLocation = promptTimezone(rp)
 	}
 
 	// Check node can be registered
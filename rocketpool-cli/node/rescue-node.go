@@ -0,0 +1,165 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The message the Rescue Node expects the node wallet to sign when requesting a credential
+const rescueNodeMessagePrefix string = "Rescue Node"
+
+// The site operators submit their signed message to in exchange for a credential
+const rescueNodeUrl string = "https://rescuenode.com"
+
+// How the rescue window is shown
+const rescueNodeTimeFormat string = "2006-01-02, 15:04 -0700 MST"
+
+// A signed message in the format the Rescue Node accepts
+type rescueNodeSignedMessage struct {
+	Address string `json:"address"`
+	Message string `json:"msg"`
+	Sig     string `json:"sig"`
+	Version string `json:"version"`
+}
+
+func attachRescue(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("The Rescue Node can't be attached automatically in Native mode; please point your validator client at it manually.")
+	}
+
+	// Make sure the validator client can use the Rescue Node
+	client, _ := cfg.GetSelectedConsensusClient()
+	rescueCfg := cfg.RescueNode.GetConfig().(*rescue_node.RescueNodeConfig)
+	if _, err := rescueCfg.GetApiEndpoint(client); err != nil {
+		return err
+	}
+	if rescueCfg.IsActive(time.Now()) {
+		fmt.Printf("Your validator client is already using the Rescue Node until %s.\nRun `rocketpool node detach-rescue` first if you want to attach with a new credential.\n", rescueCfg.GetExpiration().Format(rescueNodeTimeFormat))
+		return nil
+	}
+
+	// Get the window
+	duration, err := time.ParseDuration(c.String("duration"))
+	if err != nil {
+		return fmt.Errorf("Invalid duration '%s': %w", c.String("duration"), err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("The duration must be greater than zero.")
+	}
+
+	// Sign the credential request with the node wallet
+	message := fmt.Sprintf("%s %d", rescueNodeMessagePrefix, time.Now().Unix())
+	signResponse, err := rp.SignMessage(message)
+	if err != nil {
+		return fmt.Errorf("Error signing the Rescue Node credential request: %w", err)
+	}
+	signedMessage, err := json.Marshal(rescueNodeSignedMessage{
+		Address: signResponse.Address.Hex(),
+		Message: message,
+		Sig:     signResponse.SignedData,
+		Version: "1",
+	})
+	if err != nil {
+		return fmt.Errorf("Error serializing the Rescue Node credential request: %w", err)
+	}
+	fmt.Printf("Submit the following signed message at %s to get your Rescue Node credential:\n\n", rescueNodeUrl)
+	fmt.Printf("%s\n\n", string(signedMessage))
+
+	// Get the credential
+	username := strings.TrimSpace(cliutils.Prompt("Please enter the username of your Rescue Node credential:", "^\\S+$", "Please enter a username without spaces."))
+	password := strings.TrimSpace(cliutils.Prompt("Please enter the password of your Rescue Node credential:", "^\\S+$", "Please enter a password without spaces."))
+
+	// Prompt for confirmation
+	expiration := time.Now().Add(duration)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Your validator client will be restarted and connected to the Rescue Node (%s) until %s, and then go back to your own Beacon Node. Are you sure you want to continue?", client, expiration.Format(rescueNodeTimeFormat)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save the settings
+	rescueCfg.Enabled.Value = true
+	rescueCfg.Username.Value = username
+	rescueCfg.Password.Value = password
+	rescueCfg.Expiration.Value = uint64(expiration.Unix())
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the Rescue Node settings: %w", err)
+	}
+
+	// Recreate the validator client so it picks up the new endpoint
+	composeFiles := c.Parent().StringSlice("compose-file")
+	if err := rp.StartServiceContainers(composeFiles, string(cfgtypes.ContainerID_Validator)); err != nil {
+		return fmt.Errorf("The Rescue Node settings were saved, but your validator client couldn't be restarted: %w\nPlease run `rocketpool service start` to finish attaching.", err)
+	}
+
+	fmt.Printf("Your validator client is now connected to the Rescue Node until %s.\n", expiration.Format(rescueNodeTimeFormat))
+	fmt.Println("The node daemon will switch it back to your own Beacon Node when the window closes; you can also do it sooner with `rocketpool node detach-rescue`.")
+	return nil
+
+}
+
+func detachRescue(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	rescueCfg := cfg.RescueNode.GetConfig().(*rescue_node.RescueNodeConfig)
+	if rescueCfg.Enabled.Value != true {
+		fmt.Println("Your validator client isn't using the Rescue Node.")
+		return nil
+	}
+
+	// Save the settings
+	rescueCfg.Enabled.Value = false
+	rescueCfg.Username.Value = ""
+	rescueCfg.Password.Value = ""
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the Rescue Node settings: %w", err)
+	}
+
+	// Recreate the validator client so it goes back to the node's own Beacon Node
+	composeFiles := c.Parent().StringSlice("compose-file")
+	if err := rp.StartServiceContainers(composeFiles, string(cfgtypes.ContainerID_Validator)); err != nil {
+		return fmt.Errorf("The Rescue Node has been disabled, but your validator client couldn't be restarted: %w\nPlease run `rocketpool service start` to finish detaching.", err)
+	}
+
+	fmt.Println("Your validator client is connected to your own Beacon Node again.")
+	return nil
+
+}
@@ -0,0 +1,257 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/xlsx"
+)
+
+// Settings
+const (
+	rewardsExportFormatJson string = "json"
+	defaultRewardsCurrency  string = "usd"
+
+	// What CSV cells hold when the amount isn't known because the interval's tree file is missing
+	rewardsExportMissing string = "missing"
+)
+
+// A single interval in the rewards export.
+// Amounts that aren't known because the interval's tree file is missing are left nil, rather than reported as 0.
+type rewardsExportRecord struct {
+	Interval         uint64     `json:"interval"`
+	Status           string     `json:"status"`
+	TreeFileMissing  bool       `json:"treeFileMissing,omitempty"`
+	StartTime        time.Time  `json:"startTime"`
+	EndTime          time.Time  `json:"endTime"`
+	CollateralRpl    *float64   `json:"collateralRpl"`
+	ODaoRpl          *float64   `json:"oDaoRpl"`
+	TotalRpl         *float64   `json:"totalRpl"`
+	SmoothingPoolEth *float64   `json:"smoothingPoolEth"`
+	ClaimTime        *time.Time `json:"claimTime,omitempty"`
+	ClaimTxHash      string     `json:"claimTxHash,omitempty"`
+	Currency         string     `json:"currency,omitempty"`
+	RplPrice         *float64   `json:"rplPrice,omitempty"`
+	EthPrice         *float64   `json:"ethPrice,omitempty"`
+	RplValue         *float64   `json:"rplValue,omitempty"`
+	EthValue         *float64   `json:"ethValue,omitempty"`
+	TotalValue       *float64   `json:"totalValue,omitempty"`
+}
+
+func exportRewards(c *cli.Context) error {
+
+	// Get the format and price source
+	format := strings.ToLower(c.String("format"))
+	if format != exportFormatCsv && format != rewardsExportFormatJson {
+		return fmt.Errorf("Invalid format '%s'; please use '%s' or '%s'.", format, exportFormatCsv, rewardsExportFormatJson)
+	}
+	priceSource, err := prices.NewHistoricalPriceSource(c.String("price-source"), c.String("currency"))
+	if err != nil {
+		return fmt.Errorf("Invalid price source: %w", err)
+	}
+	output := c.String("output")
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the rewards history
+	if output != "" {
+		fmt.Println("Gathering your node's rewards history, this may take a while...")
+	}
+	history, err := rp.GetRewardsHistory()
+	if err != nil {
+		return err
+	}
+
+	// Build the records
+	records := make([]rewardsExportRecord, 0, len(history.Intervals))
+	missingTreeFiles := []uint64{}
+	for _, interval := range history.Intervals {
+		record, err := getRewardsExportRecord(interval, priceSource)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+		if !interval.TreeFileExists {
+			missingTreeFiles = append(missingTreeFiles, interval.Index)
+		}
+	}
+
+	// Write the export
+	var writer io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", output, err)
+		}
+		defer file.Close()
+		writer = file
+	}
+	if format == rewardsExportFormatJson {
+		err = writeRewardsJson(writer, records)
+	} else {
+		err = writeRewardsCsv(writer, records, priceSource)
+	}
+	if err != nil {
+		return fmt.Errorf("Error writing rewards export: %w", err)
+	}
+
+	if output != "" {
+		fmt.Printf("Exported %d rewards intervals to %s.\n", len(records), output)
+	}
+
+	// Warn on stderr so the warning isn't lost or mixed into the export when it's written to stdout
+	if len(missingTreeFiles) > 0 {
+		fmt.Fprintf(os.Stderr, "%sThe rewards tree files for intervals %s are missing, so they're marked as missing in the export. Claimed intervals have their RPL reported from the claim transaction as collateral RPL; unclaimed ones have no amounts at all. Run `rocketpool node claim-rewards` to download the tree files.%s\n", colorYellow, joinIntervals(missingTreeFiles), colorReset)
+	}
+	return nil
+
+}
+
+// Create the export record for an interval, adding its fiat value at the end of the interval if prices are enabled
+func getRewardsExportRecord(interval api.NodeRewardsInterval, priceSource prices.HistoricalPriceSource) (rewardsExportRecord, error) {
+	record := rewardsExportRecord{
+		Interval:        interval.Index,
+		Status:          "Unclaimed",
+		TreeFileMissing: !interval.TreeFileExists,
+		StartTime:       interval.StartTime,
+		EndTime:         interval.EndTime,
+		ClaimTime:       interval.ClaimTime,
+	}
+	if interval.Claimed {
+		record.Status = "Claimed"
+	}
+	if interval.ClaimTxHash != nil {
+		record.ClaimTxHash = interval.ClaimTxHash.Hex()
+	}
+
+	// Without the tree file, the amounts are only known from the claim, which doesn't split out the Oracle DAO RPL
+	collateralRpl := weiToEth(interval.CollateralRpl)
+	oDaoRpl := weiToEth(interval.ODaoRpl)
+	totalRpl := collateralRpl + oDaoRpl
+	smoothingPoolEth := weiToEth(interval.SmoothingPoolEth)
+	if interval.TreeFileExists {
+		record.CollateralRpl = &collateralRpl
+		record.ODaoRpl = &oDaoRpl
+	}
+	if interval.TreeFileExists || interval.ClaimTxHash != nil {
+		record.TotalRpl = &totalRpl
+		record.SmoothingPoolEth = &smoothingPoolEth
+	}
+	if priceSource == nil || record.TotalRpl == nil {
+		return record, nil
+	}
+
+	rplPrice, err := priceSource.GetPrice(prices.Asset_RPL, interval.EndTime)
+	if err != nil {
+		return rewardsExportRecord{}, fmt.Errorf("Error getting the RPL price for interval %d: %w", interval.Index, err)
+	}
+	ethPrice, err := priceSource.GetPrice(prices.Asset_ETH, interval.EndTime)
+	if err != nil {
+		return rewardsExportRecord{}, fmt.Errorf("Error getting the ETH price for interval %d: %w", interval.Index, err)
+	}
+	rplValue := *record.TotalRpl * rplPrice
+	ethValue := *record.SmoothingPoolEth * ethPrice
+	totalValue := rplValue + ethValue
+	record.Currency = strings.ToUpper(priceSource.GetCurrency())
+	record.RplPrice = &rplPrice
+	record.EthPrice = &ethPrice
+	record.RplValue = &rplValue
+	record.EthValue = &ethValue
+	record.TotalValue = &totalValue
+	return record, nil
+}
+
+// Write the records as a JSON array
+func writeRewardsJson(writer io.Writer, records []rewardsExportRecord) error {
+	bytes, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer, string(bytes))
+	return err
+}
+
+// Write the records as CSV, with the fiat columns included if prices are enabled
+func writeRewardsCsv(writer io.Writer, records []rewardsExportRecord, priceSource prices.HistoricalPriceSource) error {
+	header := []string{
+		"Interval", "Status", "Tree File Missing", "Start Time", "End Time", "Collateral RPL", "Oracle DAO RPL", "Total RPL", "Smoothing Pool ETH", "Claim Time", "Claim Transaction Hash",
+	}
+	if priceSource != nil {
+		currency := strings.ToUpper(priceSource.GetCurrency())
+		header = append(header,
+			fmt.Sprintf("RPL Price (%s)", currency),
+			fmt.Sprintf("ETH Price (%s)", currency),
+			fmt.Sprintf("RPL Value (%s)", currency),
+			fmt.Sprintf("ETH Value (%s)", currency),
+			fmt.Sprintf("Total Value (%s)", currency),
+		)
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(header)
+	for _, record := range records {
+		if err != nil {
+			break
+		}
+		var claimTime interface{}
+		if record.ClaimTime != nil {
+			claimTime = *record.ClaimTime
+		}
+		row := []interface{}{
+			record.Interval, record.Status, record.TreeFileMissing, record.StartTime, record.EndTime, getRewardsExportAmount(record.CollateralRpl), getRewardsExportAmount(record.ODaoRpl), getRewardsExportAmount(record.TotalRpl), getRewardsExportAmount(record.SmoothingPoolEth), claimTime, record.ClaimTxHash,
+		}
+		if priceSource != nil {
+			row = append(row, getRewardsExportAmount(record.RplPrice), getRewardsExportAmount(record.EthPrice), getRewardsExportAmount(record.RplValue), getRewardsExportAmount(record.EthValue), getRewardsExportAmount(record.TotalValue))
+		}
+
+		values := make([]string, len(row))
+		for i, value := range row {
+			values[i] = xlsx.FormatValue(value)
+		}
+		err = csvWriter.Write(values)
+	}
+	csvWriter.Flush()
+	if err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+// Get the CSV value of an amount, marking it as missing if it isn't known
+func getRewardsExportAmount(amount *float64) interface{} {
+	if amount == nil {
+		return rewardsExportMissing
+	}
+	return *amount
+}
+
+// Join interval indices into a readable list
+func joinIntervals(intervals []uint64) string {
+	elements := make([]string, len(intervals))
+	for i, interval := range intervals {
+		elements[i] = fmt.Sprint(interval)
+	}
+	return strings.Join(elements, ", ")
+}
@@ -0,0 +1,248 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How many characters at the end of the new RPL withdrawal address have to be typed back to confirm it
+const rplWithdrawalAddressChallengeLength int = 6
+
+func getRplWithdrawalAddress(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the addresses
+	response, err := rp.GetNodeRplWithdrawalAddress()
+	if err != nil {
+		return err
+	}
+	if !response.IsSupported {
+		fmt.Println("The Rocket Pool contracts on this network don't support a separate RPL withdrawal address yet.")
+		fmt.Printf("All of the node's RPL is withdrawn to its withdrawal address, %s.\n", response.WithdrawalAddress.Hex())
+		return nil
+	}
+
+	// Print them
+	fmt.Printf("Withdrawal address:          %s\n", response.WithdrawalAddress.Hex())
+	if response.HasRplWithdrawalAddress {
+		fmt.Printf("RPL withdrawal address:      %s\n", response.RplWithdrawalAddress.Hex())
+	} else {
+		fmt.Println("RPL withdrawal address:      none (RPL goes to the withdrawal address)")
+	}
+	if response.PendingRplWithdrawalAddress != (common.Address{}) {
+		fmt.Printf("Pending RPL withdrawal address: %s\n", response.PendingRplWithdrawalAddress.Hex())
+	}
+	fmt.Println()
+
+	if response.HasRplWithdrawalAddress && response.RplWithdrawalAddress != response.WithdrawalAddress {
+		fmt.Printf("%sThe node's RPL is withdrawn to a different address than its ETH. Only the RPL withdrawal address can change or remove it from now on; the withdrawal address no longer can.%s\n\n", colorYellow, colorReset)
+	}
+	if response.PendingRplWithdrawalAddress != (common.Address{}) {
+		fmt.Println("The pending RPL withdrawal address won't be used until it confirms the change itself.")
+		printRplWithdrawalAddressTransaction(response.PendingRplWithdrawalAddress, response.NodeManagerAddress, response.ConfirmCalldata)
+	}
+	return nil
+
+}
+
+func setRplWithdrawalAddress(c *cli.Context, rplWithdrawalAddressOrENS string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	var rplWithdrawalAddress common.Address
+	var rplWithdrawalAddressString string
+	if strings.Contains(rplWithdrawalAddressOrENS, ".") {
+		response, err := rp.ResolveEnsName(rplWithdrawalAddressOrENS)
+		if err != nil {
+			return err
+		}
+		rplWithdrawalAddress = response.Address
+		rplWithdrawalAddressString = fmt.Sprintf("%s (%s)", rplWithdrawalAddressOrENS, rplWithdrawalAddress.Hex())
+	} else {
+		rplWithdrawalAddress, err = cliutils.ValidateAddress("RPL withdrawal address", rplWithdrawalAddressOrENS)
+		if err != nil {
+			return err
+		}
+		rplWithdrawalAddressString = rplWithdrawalAddress.Hex()
+	}
+
+	// Print the warnings
+	confirm := c.Bool("force")
+	fmt.Println("You are about to change your RPL withdrawal address. All future RPL rewards and staked RPL you withdraw will be sent there instead of to your withdrawal address.")
+	fmt.Printf("%sOnce it is set, only the RPL withdrawal address itself can change or remove it - your withdrawal address will no longer be able to.%s\n", colorYellow, colorReset)
+	if !confirm {
+		fmt.Println("By default, this will put your new RPL withdrawal address into a \"pending\" state.")
+		fmt.Println("Rocket Pool will continue to send RPL to your current address until the new address confirms the change by sending a transaction itself.")
+		fmt.Printf("%sIf you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sYou have specified the \"--force\" option, so your new RPL withdrawal address will take effect immediately.\n", colorRed)
+		fmt.Printf("Please ensure that you have the correct address - if you do not control the new address, you will lose access to your RPL and will not be able to change this once set!%s\n\n", colorReset)
+	}
+
+	// Check if the RPL withdrawal address can be set
+	canResponse, err := rp.CanSetNodeRplWithdrawalAddress(rplWithdrawalAddress, confirm)
+	if err != nil {
+		return err
+	}
+	if canResponse.IsUnsupported {
+		fmt.Println("The Rocket Pool contracts on this network don't support a separate RPL withdrawal address yet.")
+		return nil
+	}
+	if !canResponse.CanSet {
+		fmt.Printf("Only %s can change this node's RPL withdrawal address, so it can't be changed from the node wallet.\n", canResponse.Authority.Hex())
+		fmt.Println("To make the change, send the following transaction from that address:")
+		printRplWithdrawalAddressTransaction(canResponse.Authority, canResponse.NodeManagerAddress, canResponse.Calldata)
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Make the user type the end of the new address back, so a mistyped or swapped address is caught before it's too late
+	if !c.Bool("yes") {
+		challenge := rplWithdrawalAddress.Hex()[len(rplWithdrawalAddress.Hex())-rplWithdrawalAddressChallengeLength:]
+		response := cliutils.Prompt(
+			fmt.Sprintf("To confirm that %s is the correct address, please type its last %d characters:", rplWithdrawalAddressString, rplWithdrawalAddressChallengeLength),
+			fmt.Sprintf("(?i)^[0-9a-f]{%d}$", rplWithdrawalAddressChallengeLength),
+			fmt.Sprintf("Please enter the last %d characters of the address", rplWithdrawalAddressChallengeLength))
+		if !strings.EqualFold(strings.TrimSpace(response), challenge) {
+			fmt.Println("That doesn't match the new RPL withdrawal address. Cancelled.")
+			return nil
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to set your node's RPL withdrawal address to %s?", rplWithdrawalAddressString))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Set the node's RPL withdrawal address
+	response, err := rp.SetNodeRplWithdrawalAddress(rplWithdrawalAddress, confirm)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Setting RPL withdrawal address...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	if confirm {
+		fmt.Printf("The node's RPL withdrawal address was successfully set to %s.\n", rplWithdrawalAddressString)
+		return nil
+	}
+	fmt.Printf("The node's RPL withdrawal address update to %s is now pending.\n", rplWithdrawalAddressString)
+	fmt.Println("To confirm it, run `rocketpool node rpl-withdrawal-address` to see the transaction the new address needs to send.")
+	return nil
+
+}
+
+func confirmRplWithdrawalAddress(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check if the RPL withdrawal address can be confirmed
+	canResponse, err := rp.CanConfirmNodeRplWithdrawalAddress()
+	if err != nil {
+		return err
+	}
+	if canResponse.IsUnsupported {
+		fmt.Println("The Rocket Pool contracts on this network don't support a separate RPL withdrawal address yet.")
+		return nil
+	}
+	if canResponse.PendingRplWithdrawalAddress == (common.Address{}) {
+		fmt.Println("The node doesn't have a pending RPL withdrawal address to confirm.")
+		return nil
+	}
+	if !canResponse.CanConfirm {
+		fmt.Printf("The pending RPL withdrawal address is %s, so it must be confirmed from that address rather than the node wallet.\n", canResponse.PendingRplWithdrawalAddress.Hex())
+		fmt.Println("To confirm it, send the following transaction from that address:")
+		printRplWithdrawalAddressTransaction(canResponse.PendingRplWithdrawalAddress, canResponse.NodeManagerAddress, canResponse.Calldata)
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to confirm your node's address as the new RPL withdrawal address?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Confirm the node's RPL withdrawal address
+	response, err := rp.ConfirmNodeRplWithdrawalAddress()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Confirming new RPL withdrawal address...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The node's RPL withdrawal address was successfully set to the node address.\n")
+	return nil
+
+}
+
+// Print the details of a transaction that has to be sent from a wallet other than the node's
+func printRplWithdrawalAddressTransaction(from common.Address, to common.Address, calldata string) {
+	fmt.Printf("\tFrom:   %s\n", from.Hex())
+	fmt.Printf("\tTo:     %s (the Rocket Pool node manager contract)\n", to.Hex())
+	fmt.Println("\tAmount: 0 ETH")
+	fmt.Printf("\tData:   %s\n", calldata)
+	fmt.Printf("%sMost wallets can send this with their custom data or \"hex data\" option. Make sure the From address is the one shown above, or the transaction will fail.%s\n", colorYellow, colorReset)
+}
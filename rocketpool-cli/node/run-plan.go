@@ -0,0 +1,444 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Supported plan actions
+const (
+	planActionClaimRewards = "claim-rewards"
+	planActionDistribute   = "distribute-balance"
+	planActionStakeRpl     = "stake-rpl"
+)
+
+// A maintenance plan made up of operations that are run in order
+type nodePlan struct {
+	Steps []nodePlanStep `json:"steps"`
+}
+
+// A single operation in a plan
+type nodePlanStep struct {
+	// The operation to run
+	Action string `json:"action"`
+
+	// The rewards intervals to claim, for claim-rewards
+	Intervals []uint64 `json:"intervals,omitempty"`
+
+	// The amount of RPL to restake while claiming, for claim-rewards (optional)
+	RestakeAmount float64 `json:"restakeAmount,omitempty"`
+
+	// The minipools to distribute, for distribute-balance
+	Minipools []common.Address `json:"minipools,omitempty"`
+
+	// The amount of RPL to stake, for stake-rpl
+	Amount float64 `json:"amount,omitempty"`
+}
+
+func runPlan(c *cli.Context, planFile string) error {
+
+	// Load the plan
+	plan, err := loadNodePlan(planFile)
+	if err != nil {
+		return err
+	}
+	if len(plan.Steps) == 0 {
+		fmt.Println("The plan doesn't contain any steps.")
+		return nil
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Print the plan for review
+	fmt.Printf("%sThis plan contains %d step(s):%s\n", colorGreen, len(plan.Steps), colorReset)
+	for i, step := range plan.Steps {
+		fmt.Printf("%d. %s\n", i+1, step.describe())
+	}
+	fmt.Println()
+
+	unattended := c.Bool("yes")
+	if !(unattended || cliutils.Confirm("Do you want to run this plan? You will be asked to confirm each step individually.")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run each step in order, stopping at the first failure
+	for i, step := range plan.Steps {
+		fmt.Printf("\n%s=== Step %d of %d: %s ===%s\n", colorGreen, i+1, len(plan.Steps), step.describe(), colorReset)
+		var ran bool
+		switch step.Action {
+		case planActionClaimRewards:
+			ran, err = runPlanClaimRewards(c, rp, step)
+		case planActionDistribute:
+			ran, err = runPlanDistribute(c, rp, step)
+		case planActionStakeRpl:
+			ran, err = runPlanStakeRpl(c, rp, step)
+		}
+		if err != nil {
+			return fmt.Errorf("step %d (%s) failed: %w\nThe remaining steps have not been run.", i+1, step.Action, err)
+		}
+		if !ran {
+			fmt.Printf("Skipped step %d.\n", i+1)
+		}
+
+		// If a custom nonce is set, increment it for the next transaction
+		if ran && c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+	}
+
+	fmt.Printf("\nFinished running the plan.\n")
+	return nil
+
+}
+
+// Claim rewards for the intervals in the step, optionally restaking some of the RPL
+func runPlanClaimRewards(c *cli.Context, rp *rocketpool.Client, step nodePlanStep) (bool, error) {
+
+	// Check claim ability
+	restakeAmountWei := big.NewInt(0)
+	if step.RestakeAmount > 0 {
+		restakeAmountWei = eth.EthToWei(step.RestakeAmount)
+		canClaim, err := rp.CanNodeClaimAndStakeRewards(step.Intervals, restakeAmountWei)
+		if err != nil {
+			return false, err
+		}
+		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+		if err != nil {
+			return false, err
+		}
+	} else {
+		canClaim, err := rp.CanNodeClaimRewards(step.Intervals)
+		if err != nil {
+			return false, err
+		}
+		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Do you want to run this step?")) {
+		return false, nil
+	}
+
+	// Claim rewards
+	var txHash common.Hash
+	if step.RestakeAmount > 0 {
+		response, err := rp.NodeClaimAndStakeRewards(step.Intervals, restakeAmountWei)
+		if err != nil {
+			return false, err
+		}
+		txHash = response.TxHash
+	} else {
+		response, err := rp.NodeClaimRewards(step.Intervals)
+		if err != nil {
+			return false, err
+		}
+		txHash = response.TxHash
+	}
+
+	fmt.Printf("Claiming rewards...\n")
+	cliutils.PrintTransactionHash(rp, txHash)
+	if _, err := rp.WaitForTransaction(txHash); err != nil {
+		return false, err
+	}
+	fmt.Println("Successfully claimed rewards.")
+	return true, nil
+
+}
+
+// Distribute the balances of the minipools in the step
+func runPlanDistribute(c *cli.Context, rp *rocketpool.Client, step nodePlanStep) (bool, error) {
+
+	// Get the distribution details for the node's minipools
+	details, err := rp.GetDistributeBalanceDetails()
+	if err != nil {
+		return false, err
+	}
+	if !details.IsAtlasDeployed {
+		return false, fmt.Errorf("balance distribution is not available until the Atlas upgrade has been deployed")
+	}
+
+	// Make sure every requested minipool can be distributed and tally the gas
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	for _, address := range step.Minipools {
+		found := false
+		for _, minipool := range details.Details {
+			if minipool.Address != address {
+				continue
+			}
+			if !minipool.CanDistribute {
+				return false, fmt.Errorf("minipool %s is not eligible for balance distribution", address.Hex())
+			}
+			totalGas += minipool.GasInfo.EstGasLimit
+			totalSafeGas += minipool.GasInfo.SafeGasLimit
+			found = true
+			break
+		}
+		if !found {
+			return false, fmt.Errorf("minipool %s does not belong to this node", address.Hex())
+		}
+	}
+	gasInfo := details.Details[0].GasInfo
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Do you want to run this step?")) {
+		return false, nil
+	}
+
+	// Distribute the balances
+	for i, address := range step.Minipools {
+		response, err := rp.DistributeBalance(address)
+		if err != nil {
+			return false, fmt.Errorf("could not distribute the ETH balance of minipool %s: %w", address.Hex(), err)
+		}
+
+		fmt.Printf("Distributing balance of minipool %s...\n", address.Hex())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			return false, fmt.Errorf("could not distribute the ETH balance of minipool %s: %w", address.Hex(), err)
+		}
+		fmt.Printf("Successfully distributed the ETH balance of minipool %s.\n", address.Hex())
+
+		// If a custom nonce is set, increment it for the next transaction
+		if i < len(step.Minipools)-1 && c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+	}
+	return true, nil
+
+}
+
+// Stake the amount of RPL in the step
+func runPlanStakeRpl(c *cli.Context, rp *rocketpool.Client, step nodePlanStep) (bool, error) {
+
+	amountWei := eth.EthToWei(step.Amount)
+
+	// Make sure the staking contract is allowed to use the RPL
+	allowance, err := rp.GetNodeStakeRplAllowance()
+	if err != nil {
+		return false, err
+	}
+	if allowance.Allowance.Cmp(amountWei) < 0 {
+		return false, fmt.Errorf("the staking contract has not been approved to use your RPL yet; please run `rocketpool node stake-rpl` once to approve it before using it in a plan")
+	}
+
+	// Check RPL can be staked
+	canStake, err := rp.CanNodeStakeRpl(amountWei)
+	if err != nil {
+		return false, err
+	}
+	if !canStake.CanStake {
+		if canStake.InsufficientBalance {
+			return false, fmt.Errorf("the node's RPL balance is insufficient")
+		}
+		if !canStake.IsAtlasDeployed && !canStake.InConsensus {
+			return false, fmt.Errorf("the RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO")
+		}
+		return false, fmt.Errorf("cannot stake RPL")
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canStake.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Do you want to run this step?")) {
+		return false, nil
+	}
+
+	// Stake RPL
+	response, err := rp.NodeStakeRpl(amountWei)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("Staking RPL...\n")
+	cliutils.PrintTransactionHash(rp, response.StakeTxHash)
+	if _, err = rp.WaitForTransaction(response.StakeTxHash); err != nil {
+		return false, err
+	}
+	fmt.Printf("Successfully staked %.6f RPL.\n", step.Amount)
+	return true, nil
+
+}
+
+// Get a human-readable description of a step
+func (step nodePlanStep) describe() string {
+	switch step.Action {
+	case planActionClaimRewards:
+		intervals := make([]string, len(step.Intervals))
+		for i, interval := range step.Intervals {
+			intervals[i] = fmt.Sprint(interval)
+		}
+		description := fmt.Sprintf("claim rewards for interval(s) %s", strings.Join(intervals, ", "))
+		if step.RestakeAmount > 0 {
+			description += fmt.Sprintf(" and restake %.6f RPL", step.RestakeAmount)
+		}
+		return description
+	case planActionDistribute:
+		minipools := make([]string, len(step.Minipools))
+		for i, minipool := range step.Minipools {
+			minipools[i] = minipool.Hex()
+		}
+		return fmt.Sprintf("distribute the balance of minipool(s) %s", strings.Join(minipools, ", "))
+	case planActionStakeRpl:
+		return fmt.Sprintf("stake %.6f RPL", step.Amount)
+	default:
+		return step.Action
+	}
+}
+
+// Make sure a step has everything it needs to run
+func (step nodePlanStep) validate() error {
+	switch step.Action {
+	case planActionClaimRewards:
+		if len(step.Intervals) == 0 {
+			return fmt.Errorf("no intervals were provided")
+		}
+		if step.RestakeAmount < 0 {
+			return fmt.Errorf("the restake amount cannot be negative")
+		}
+	case planActionDistribute:
+		if len(step.Minipools) == 0 {
+			return fmt.Errorf("no minipools were provided")
+		}
+	case planActionStakeRpl:
+		if step.Amount <= 0 {
+			return fmt.Errorf("the amount must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("unknown action '%s' (supported actions: %s, %s, %s)", step.Action, planActionClaimRewards, planActionDistribute, planActionStakeRpl)
+	}
+	return nil
+}
+
+// Load a plan from a JSON or CSV file
+func loadNodePlan(planFile string) (*nodePlan, error) {
+
+	bytes, err := os.ReadFile(planFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan file: %w", err)
+	}
+
+	var plan *nodePlan
+	switch strings.ToLower(filepath.Ext(planFile)) {
+	case ".json":
+		plan = &nodePlan{}
+		if err := json.Unmarshal(bytes, plan); err != nil {
+			return nil, fmt.Errorf("error parsing plan file: %w", err)
+		}
+	case ".csv":
+		plan, err = parseCsvNodePlan(string(bytes))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing plan file: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported plan file type '%s'; plans must be .json or .csv files", filepath.Ext(planFile))
+	}
+
+	for i, step := range plan.Steps {
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("step %d is invalid: %w", i+1, err)
+		}
+	}
+	return plan, nil
+
+}
+
+// Parse a CSV plan, where each row is an action followed by its arguments:
+//
+//	claim-rewards,<interval;interval;...>[,<restake amount>]
+//	distribute-balance,<minipool;minipool;...>
+//	stake-rpl,<amount>
+func parseCsvNodePlan(contents string) (*nodePlan, error) {
+
+	reader := csv.NewReader(strings.NewReader(contents))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &nodePlan{}
+	for i, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("row %d must contain an action and its arguments", i+1)
+		}
+		step := nodePlanStep{
+			Action: strings.TrimSpace(record[0]),
+		}
+		args := strings.Split(record[1], ";")
+		switch step.Action {
+		case planActionClaimRewards:
+			for _, arg := range args {
+				interval, err := strconv.ParseUint(strings.TrimSpace(arg), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("row %d has an invalid interval '%s'", i+1, arg)
+				}
+				step.Intervals = append(step.Intervals, interval)
+			}
+			if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+				step.RestakeAmount, err = strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+				if err != nil {
+					return nil, fmt.Errorf("row %d has an invalid restake amount '%s'", i+1, record[2])
+				}
+			}
+		case planActionDistribute:
+			for _, arg := range args {
+				address, err := cliutils.ValidateAddress("minipool address", strings.TrimSpace(arg))
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", i+1, err)
+				}
+				step.Minipools = append(step.Minipools, address)
+			}
+		case planActionStakeRpl:
+			step.Amount, err = strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d has an invalid amount '%s'", i+1, record[1])
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, nil
+
+}
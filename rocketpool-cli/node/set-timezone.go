@@ -30,7 +30,7 @@ func setTimezoneLocation(c *cli.Context) error {
 	if c.String("timezone") != "" {
 		timezoneLocation = c.String("timezone")
 	} else {
-		timezoneLocation = promptTimezone()
+		timezoneLocation = promptTimezone(rp)
 	}
 
 	// Get the gas estimate
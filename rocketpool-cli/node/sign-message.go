@@ -2,10 +2,13 @@ package node
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"encoding/json"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
@@ -14,6 +17,9 @@ import (
 
 const signatureVersion = 1
 
+// Primary types that grant someone else control of the node's tokens; signing one of these can drain the wallet
+var permitLikeTypes = []string{"permit", "approval", "approve"}
+
 type PersonalSignature struct {
 	Address   common.Address `json:"address"`
 	Message   string         `json:"msg"`
@@ -21,8 +27,20 @@ type PersonalSignature struct {
 	Version   string         `json:"version"` // beaconcha.in expects a string
 }
 
+type TypedDataSignature struct {
+	Address     common.Address  `json:"address"`
+	TypedData   json.RawMessage `json:"typedData"`
+	Signature   string          `json:"sig"`
+	MessageHash common.Hash     `json:"hash"`
+}
+
 func signMessage(c *cli.Context) error {
 
+	// Validate flags
+	if c.String("message") != "" && c.String("typed-data") != "" {
+		return fmt.Errorf("Only one of --message and --typed-data can be used at a time.")
+	}
+
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c)
 	if err != nil {
@@ -41,6 +59,11 @@ func signMessage(c *cli.Context) error {
 		return nil
 	}
 
+	// Sign typed data if it was provided
+	if c.String("typed-data") != "" {
+		return signTypedData(c, rp)
+	}
+
 	message := c.String("message")
 	for message == "" {
 		message = cliutils.Prompt("Please enter the message you want to sign: (EIP-191 personal_sign)", "^.+$", "Please enter the message you want to sign: (EIP-191 personal_sign)")
@@ -63,7 +86,90 @@ func signMessage(c *cli.Context) error {
 		return err
 	}
 
-	fmt.Printf("Signed Message:\n\n%s\n", string(bytes))
+	fmt.Printf("Signed Message:\n\n%s\n\n", string(bytes))
+
+	// Print the verification details
+	fmt.Println("Verification details:")
+	fmt.Println("Standard:      EIP-191 (personal_sign)")
+	fmt.Printf("Signer:        %s\n", response.Address.Hex())
+	fmt.Printf("Message hash:  %s\n", response.MessageHash.Hex())
+	fmt.Println("The signature can be checked with any tool that supports personal_sign, such as Etherscan's \"Verify Signature\" page.")
+
+	return nil
+
+}
+
+// Sign the EIP-712 typed data in the provided file
+func signTypedData(c *cli.Context, rp *rocketpool.Client) error {
+
+	// Read the typed data
+	path := c.String("typed-data")
+	typedDataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading typed data file [%s]: %w", path, err)
+	}
+	if !json.Valid(typedDataBytes) {
+		return fmt.Errorf("The typed data file [%s] does not contain valid JSON.", path)
+	}
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(typedDataBytes, &typedData); err != nil {
+		return fmt.Errorf("The typed data file [%s] does not contain valid EIP-712 typed data: %w", path, err)
+	}
+
+	// Show what's being signed
+	domain, err := json.MarshalIndent(typedData.Domain, "", "    ")
+	if err != nil {
+		return err
+	}
+	message, err := json.MarshalIndent(typedData.Message, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Domain:\n%s\n\n", string(domain))
+	fmt.Printf("Primary type: %s\n\n", typedData.PrimaryType)
+	fmt.Printf("Message:\n%s\n\n", string(message))
+
+	// Warn about signatures that hand over token spending rights
+	primaryType := strings.ToLower(typedData.PrimaryType)
+	for _, permitType := range permitLikeTypes {
+		if strings.Contains(primaryType, permitType) {
+			fmt.Printf("%sWARNING: this looks like a token permit or approval (%s). Signing it lets the contract or spender named in the message move tokens out of your node wallet without any further transaction from you. Only sign it if you know exactly who asked for it and why.%s\n\n", colorRed, typedData.PrimaryType, colorReset)
+			break
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to sign this typed data with your node wallet?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	response, err := rp.SignTypedData(string(typedDataBytes))
+	if err != nil {
+		return err
+	}
+
+	// Print the signature
+	formattedSignature := TypedDataSignature{
+		Address:     response.Address,
+		TypedData:   json.RawMessage(typedDataBytes),
+		Signature:   response.SignedData,
+		MessageHash: response.MessageHash,
+	}
+	bytes, err := json.MarshalIndent(formattedSignature, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed Typed Data:\n\n%s\n\n", string(bytes))
+
+	// Print the verification details
+	fmt.Println("Verification details:")
+	fmt.Println("Standard:          EIP-712 (eth_signTypedData_v4)")
+	fmt.Printf("Signer:            %s\n", response.Address.Hex())
+	fmt.Printf("Primary type:      %s\n", response.PrimaryType)
+	fmt.Printf("Domain separator:  %s\n", response.DomainSeparator.Hex())
+	fmt.Printf("Message hash:      %s\n", response.MessageHash.Hex())
 
 	return nil
 
@@ -42,7 +42,7 @@ func joinSmoothingPool(c *cli.Context) error {
 	}
 
 	// Print some info
-	fmt.Println("You are about to opt into the Smoothing Pool.\nYour fee recipient will be changed to the Smoothing Pool contract.\nAll priority fees and MEV you earn via proposals will be shared equally with other members of the Smoothing Pool.\n\nIf you desire, you can opt back out after one full rewards interval has passed.\n")
+	fmt.Print("You are about to opt into the Smoothing Pool.\nYour fee recipient will be changed to the Smoothing Pool contract.\nAll priority fees and MEV you earn via proposals will be shared equally with other members of the Smoothing Pool.\n\nIf you desire, you can opt back out after one full rewards interval has passed.\n\n")
 
 	// Get the gas estimate
 	canResponse, err := rp.CanNodeSetSmoothingPoolStatus(true)
@@ -114,7 +114,7 @@ func leaveSmoothingPool(c *cli.Context) error {
 	}
 
 	// Print some info
-	fmt.Println("You are about to opt out of the Smoothing Pool.\nYour fee recipient will be changed back to your node's distributor contract once the next Epoch has been finalized.\nAll priority fees and MEV you earn via proposals will go directly to your distributor and will not be shared by the Smoothing Pool members.\n\nIf you desire, you can opt back in after one full rewards interval has passed.\n")
+	fmt.Print("You are about to opt out of the Smoothing Pool.\nYour fee recipient will be changed back to your node's distributor contract once the next Epoch has been finalized.\nAll priority fees and MEV you earn via proposals will go directly to your distributor and will not be shared by the Smoothing Pool members.\n\nIf you desire, you can opt back in after one full rewards interval has passed.\n\n")
 
 	// Get the gas estimate
 	canResponse, err := rp.CanNodeSetSmoothingPoolStatus(false)
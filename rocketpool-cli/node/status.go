@@ -56,6 +56,20 @@ func getStatus(c *cli.Context) error {
 		return fmt.Errorf("Error loading configuration: %w", err)
 	}
 
+	// Warn about validators that are attesting from another machine
+	if status.Registered {
+		doppelgangers, err := rp.CheckDoppelgangers()
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't check the Beacon Chain for copies of your validators running on another machine: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		} else if len(doppelgangers.LiveValidators) > 0 {
+			fmt.Printf("%sWARNING: The following validators were attesting during epoch(s) %v while your validator client was not, so they appear to be running on another machine:\n", colorRed, doppelgangers.Epochs)
+			for _, pubkey := range doppelgangers.LiveValidators {
+				fmt.Printf("\t%s\n", pubkey.Hex())
+			}
+			fmt.Printf("Do NOT start your validator client until you have stopped the other one, or both may be slashed.%s\n\n", colorReset)
+		}
+	}
+
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
 	fmt.Printf(
@@ -230,7 +244,7 @@ func getStatus(c *cli.Context) error {
 				fmt.Println("")
 			}
 		} else {
-			fmt.Println("NOTE: The following figures take *any pending bond reductions* into account.\n")
+			fmt.Print("NOTE: The following figures take *any pending bond reductions* into account.\n\n")
 			fmt.Printf(
 				"The node has a total stake of %.6f RPL and an effective stake of %.6f RPL.\n",
 				math.RoundDown(eth.WeiToEth(status.RplStake), 6),
@@ -89,7 +89,7 @@ func getSyncProgress(c *cli.Context) error {
 			depositContractInfo.BeaconDepositContract)
 		return nil
 	} else {
-		fmt.Println("Your consensus client is on the correct network.\n")
+		fmt.Print("Your consensus client is on the correct network.\n\n")
 	}
 
 	// Get node status
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -20,6 +19,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 	"gopkg.in/yaml.v2"
 )
 
@@ -32,15 +32,18 @@ type ipInfoResponse struct {
 }
 
 // Prompt user for a time zone string
-func promptTimezone() string {
+func promptTimezone(rp *rocketpool.Client) string {
 
 	// Time zone value
 	var timezone string
 
 	// Prompt for auto-detect
 	if cliutils.Confirm("Would you like to detect your timezone automatically?") {
-		// Detect using the IPInfo API
-		resp, err := http.Get(IPInfoURL)
+		// Detect using the IPInfo API, through the configured proxy
+		if err := rp.ConfigureHttpClients(); err != nil {
+			fmt.Printf("WARNING: couldn't apply your HTTP proxy settings (%s).\n", err.Error())
+		}
+		resp, err := net.GetHttpClient(net.HttpTarget_Lookups).Get(IPInfoURL)
 		if err == nil {
 			defer func() {
 				_ = resp.Body.Close()
@@ -157,7 +160,7 @@ func promptTimezone() string {
 		}
 	}
 
-	fmt.Println("You will now be prompted to enter a timezone.\nFor a complete list of valid entries, please use one of the \"TZ database name\" entries listed here:\nhttps://en.wikipedia.org/wiki/List_of_tz_database_time_zones\n")
+	fmt.Print("You will now be prompted to enter a timezone.\nFor a complete list of valid entries, please use one of the \"TZ database name\" entries listed here:\nhttps://en.wikipedia.org/wiki/List_of_tz_database_time_zones\n\n")
 
 	// Handle situations where we couldn't parse any timezone info from the OS
 	if len(countryNames) == 0 {
@@ -368,7 +371,7 @@ func promptForSoloKeyPassword(rp *rocketpool.Client, cfg *config.RocketPoolConfi
 	}
 
 	if len(pubkeyPasswords) == 0 {
-		return "", fmt.Errorf("couldn't find the keystore for validator %s in the custom-keys directory; if you want to import this key into the Smartnode stack, you will need to put its keystore file into custom-keys first")
+		return "", fmt.Errorf("couldn't find the keystore for validator %s in the custom-keys directory; if you want to import this key into the Smartnode stack, you will need to put its keystore file into custom-keys first", pubkey.Hex())
 	}
 
 	// Store it in the file
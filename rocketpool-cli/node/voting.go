@@ -12,7 +12,7 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
-func nodeSetVotingDelegate(c *cli.Context, nameOrAddress string) error {
+func SetVotingDelegate(c *cli.Context, nameOrAddress string) error {
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c)
 	if err != nil {
@@ -78,7 +78,7 @@ func nodeSetVotingDelegate(c *cli.Context, nameOrAddress string) error {
 
 }
 
-func nodeClearVotingDelegate(c *cli.Context) error {
+func ClearVotingDelegate(c *cli.Context) error {
 
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c)
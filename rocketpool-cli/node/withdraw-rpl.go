@@ -10,6 +10,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -31,7 +32,18 @@ func nodeWithdrawRpl(c *cli.Context) error {
 
 	// Get withdrawal mount
 	var amountWei *big.Int
-	if c.String("amount") == "max" {
+	if c.Bool("max-safe") {
+
+		// Get the most that can be withdrawn safely
+		amountWei, err = getMaxSafeRplWithdrawal(c, rp)
+		if err != nil {
+			return err
+		}
+		if amountWei == nil {
+			return nil
+		}
+
+	} else if c.String("amount") == "max" {
 
 		// Get node status
 		status, err := rp.NodeStatus()
@@ -141,3 +153,57 @@ func nodeWithdrawRpl(c *cli.Context) error {
 	return nil
 
 }
+
+// Get the most staked RPL that can be withdrawn while staying above the target collateral ratio, and print the plan.
+// Returns nil if nothing can be withdrawn.
+func getMaxSafeRplWithdrawal(c *cli.Context, rp *rocketpool.Client) (*big.Int, error) {
+
+	// Get the plan
+	targetCollateral := c.Float64("target-collateral")
+	plan, err := rp.GetNodeRplWithdrawalPlan(eth.EthToWei(targetCollateral / 100))
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsAtlasDeployed {
+		fmt.Println("The safe withdrawal planner is not available until Atlas has been deployed. Please use `--amount` instead.")
+		return nil, nil
+	}
+
+	// Print it
+	fmt.Printf("Your node has %.6f RPL staked", math.RoundDown(eth.WeiToEth(plan.RplStake), 6))
+	if plan.CurrentCollateralRatio >= 0 {
+		fmt.Printf(", which is %.2f%% of the ETH it has borrowed", plan.CurrentCollateralRatio*100)
+		if plan.PendingMatchAmount.Sign() > 0 {
+			fmt.Printf(" (including %.6f ETH from pending bond reductions)", math.RoundDown(eth.WeiToEth(plan.PendingMatchAmount), 6))
+		}
+	}
+	fmt.Println(".")
+	fmt.Println("Your stake must stay above:")
+	fmt.Printf("\t%.6f RPL, the protocol's withdrawal limit (150%% of your bonded ETH)\n", math.RoundUp(eth.WeiToEth(plan.MaximumRplStake), 6))
+	fmt.Printf("\t%.6f RPL, the minimum collateral for your borrowed ETH\n", math.RoundUp(eth.WeiToEth(plan.MinimumRplStake), 6))
+	fmt.Printf("\t%.6f RPL, your target of %.2f%% of your borrowed ETH\n", math.RoundUp(eth.WeiToEth(plan.TargetRplStake), 6), targetCollateral)
+	fmt.Println()
+
+	if plan.MaxSafeWithdrawal.Sign() == 0 {
+		switch plan.LimitedBy {
+		case api.RplWithdrawalLimit_Protocol:
+			fmt.Println("You cannot withdraw any RPL without going below the protocol's withdrawal limit.")
+		case api.RplWithdrawalLimit_Minimum:
+			fmt.Println("You cannot withdraw any RPL without leaving your minipools and pending bond reductions undercollateralized.")
+		case api.RplWithdrawalLimit_Target:
+			fmt.Printf("You cannot withdraw any RPL without going below your target collateral of %.2f%%.\n", targetCollateral)
+		}
+		return nil, nil
+	}
+	fmt.Printf("You can safely withdraw up to %s%.6f RPL%s.\n", colorGreen, math.RoundDown(eth.WeiToEth(plan.MaxSafeWithdrawal), 6), colorReset)
+	if plan.ResultingCollateralRatio >= 0 {
+		fmt.Printf("Afterwards, your RPL stake will be %.2f%% of your borrowed ETH.\n", plan.ResultingCollateralRatio*100)
+	}
+	if plan.WithdrawalDelayActive {
+		fmt.Printf("%sYou staked RPL recently, so you cannot withdraw any until %s.%s\n", colorYellow, plan.WithdrawalDelayEnd.Local().Format("2006-01-02 15:04 MST"), colorReset)
+		return nil, nil
+	}
+	fmt.Println()
+	return plan.MaxSafeWithdrawal, nil
+
+}
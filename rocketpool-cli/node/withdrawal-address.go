@@ -199,3 +199,54 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	return nil
 
 }
+
+func getWithdrawalSafety(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the addresses
+	response, err := rp.GetWithdrawalSafety()
+	if err != nil {
+		return err
+	}
+
+	// Print the addresses
+	fmt.Printf("Withdrawal address:          %s\n", response.WithdrawalAddress.Hex())
+	if response.PendingWithdrawalAddress != (common.Address{}) {
+		fmt.Printf("Pending withdrawal address:  %s\n", response.PendingWithdrawalAddress.Hex())
+	}
+	if response.HasRplWithdrawalAddress {
+		fmt.Printf("RPL withdrawal address:      %s\n", response.RplWithdrawalAddress.Hex())
+	} else {
+		fmt.Println("RPL withdrawal address:      none (RPL goes to the withdrawal address)")
+	}
+	fmt.Println()
+	if response.ExpectedWithdrawalAddress == "" && response.ExpectedRplWithdrawalAddress == "" && !response.HasProof {
+		fmt.Printf("%sNo expected withdrawal addresses or withdrawal address proof have been set, so only your minipools' withdrawal credentials are checked before exiting, closing, or distributing them. You can set them in the Smartnode section of `rocketpool service config`.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Print the proof message
+	fmt.Println("To prove you control your withdrawal address, sign this message with it (for example, on Etherscan's Verified Signatures page) and put the signature in the Withdrawal Address Proof setting:")
+	fmt.Printf("\t%s\n", response.ProofMessage)
+	if response.HasProof && len(response.Problems) == 0 {
+		fmt.Printf("Your proof was signed by %s.\n", response.ProofSigner.Hex())
+	}
+	fmt.Println()
+
+	// Print the results
+	if len(response.Problems) == 0 {
+		fmt.Printf("%sAll of the withdrawal address checks passed.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	fmt.Printf("%sThe following problems were found; minipools can't be exited, closed, or distributed until they are fixed:%s\n", colorRed, colorReset)
+	for _, problem := range response.Problems {
+		fmt.Printf("\t- %s\n", problem)
+	}
+	return nil
+
+}
@@ -0,0 +1,91 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getWithdrawalLedger(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the ledger
+	fmt.Println("Searching the chain for payouts to your withdrawal address, this may take a while...")
+	response, err := rp.GetWithdrawalLedger()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Node address:       %s\n", response.NodeAddress.Hex())
+	fmt.Printf("Withdrawal address: %s\n", response.WithdrawalAddress.Hex())
+	if response.WithdrawalAddress != response.NodeAddress {
+		fmt.Printf("%sNOTE: each payout went to whichever withdrawal address the node had at the time, which may not be the current one.%s\n", colorBlue, colorReset)
+	}
+	fmt.Println()
+
+	if len(response.Entries) == 0 {
+		fmt.Println("Rocket Pool hasn't sent anything to your withdrawal address yet.")
+		return nil
+	}
+
+	// Print the ledger with running totals
+	runningEth := big.NewInt(0)
+	runningRpl := big.NewInt(0)
+	totalsByType := map[string][2]*big.Int{}
+	types := []string{}
+	fmt.Printf("%-10s  %-21s  %8s  %14s  %14s  %14s  %14s  %s\n", "Date", "Type", "Interval", "ETH", "RPL", "Total ETH", "Total RPL", "Transaction")
+	for _, entry := range response.Entries {
+		runningEth.Add(runningEth, entry.EthAmount)
+		runningRpl.Add(runningRpl, entry.RplAmount)
+		interval := ""
+		if entry.Interval != nil {
+			interval = fmt.Sprint(*entry.Interval)
+		}
+		fmt.Printf("%-10s  %-21s  %8s  %14.6f  %14.6f  %14.6f  %14.6f  %s\n",
+			entry.Time.Format("2006-01-02"),
+			entry.Type,
+			interval,
+			eth.WeiToEth(entry.EthAmount),
+			eth.WeiToEth(entry.RplAmount),
+			eth.WeiToEth(runningEth),
+			eth.WeiToEth(runningRpl),
+			entry.TxHash.Hex(),
+		)
+
+		totals, exists := totalsByType[entry.Type]
+		if !exists {
+			totals = [2]*big.Int{big.NewInt(0), big.NewInt(0)}
+			totalsByType[entry.Type] = totals
+			types = append(types, entry.Type)
+		}
+		totals[0].Add(totals[0], entry.EthAmount)
+		totals[1].Add(totals[1], entry.RplAmount)
+	}
+
+	// Print the totals
+	fmt.Printf("\n%sTotals by type:%s\n", colorGreen, colorReset)
+	for _, entryType := range types {
+		totals := totalsByType[entryType]
+		fmt.Printf("%-21s  %.6f ETH  %.6f RPL\n", entryType, eth.WeiToEth(totals[0]), eth.WeiToEth(totals[1]))
+	}
+	fmt.Printf("\nIn total, Rocket Pool has sent %.6f ETH and %.6f RPL to your withdrawal address.\n", eth.WeiToEth(response.TotalEth), eth.WeiToEth(response.TotalRpl))
+	return nil
+
+}
@@ -0,0 +1,211 @@
+package odao
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func challengeMember(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get member to challenge
+	selectedMember, err := getSelectedMember(c, rp, "Please select a member to challenge:")
+	if err != nil {
+		return err
+	}
+
+	// Check if the member can be challenged
+	canChallenge, err := rp.CanChallengeTNDAOMember(selectedMember.Address)
+	if err != nil {
+		return err
+	}
+	if !canChallenge.CanChallenge {
+		fmt.Println("Cannot challenge the oracle DAO member:")
+		if canChallenge.MemberDoesNotExist {
+			fmt.Printf("The node %s is not a member of the oracle DAO.\n", selectedMember.Address.Hex())
+		}
+		if canChallenge.ChallengeSelf {
+			fmt.Println("You cannot challenge your own node.")
+		}
+		if canChallenge.AlreadyChallenged {
+			fmt.Println("The member already has an active challenge against it.")
+		}
+		if canChallenge.ChallengeCooldown {
+			fmt.Println("You must wait for the challenge cooldown to pass before making another challenge.")
+		}
+		if canChallenge.InsufficientBalance {
+			fmt.Printf("Your node does not have the %.6f ETH required to challenge a member.\n", math.RoundDown(eth.WeiToEth(canChallenge.ChallengeCost), 6))
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canChallenge.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	var costMessage string
+	if !canChallenge.IsMember {
+		costMessage = fmt.Sprintf(" This will cost %.6f ETH, which is not refunded.", math.RoundDown(eth.WeiToEth(canChallenge.ChallengeCost), 6))
+	}
+	challengeWindow := time.Duration(canChallenge.ChallengeWindow) * time.Second
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to challenge %s (%s)? It will be removed from the oracle DAO if it does not respond within %s.%s", selectedMember.ID, selectedMember.Address.Hex(), challengeWindow, costMessage))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Challenge the member
+	response, err := rp.ChallengeTNDAOMember(selectedMember.Address)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Challenging oracle DAO member...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully challenged %s. Once the challenge window has passed without a response, it can be decided with 'rocketpool odao decide-challenge'.\n", selectedMember.Address.Hex())
+	return nil
+
+}
+
+func decideChallenge(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the challenged member; default to responding to a challenge against this node
+	var memberAddress common.Address
+	if c.String("member") != "" {
+		memberAddress = common.HexToAddress(c.String("member"))
+	} else {
+		wallet, err := rp.WalletStatus()
+		if err != nil {
+			return err
+		}
+		memberAddress = wallet.AccountAddress
+	}
+
+	// Check if the challenge can be decided
+	canDecide, err := rp.CanDecideTNDAOChallenge(memberAddress)
+	if err != nil {
+		return err
+	}
+	if !canDecide.CanDecide {
+		fmt.Println("Cannot decide the challenge:")
+		if canDecide.NotChallenged {
+			fmt.Printf("The node %s does not have an active challenge against it.\n", memberAddress.Hex())
+		}
+		if canDecide.ChallengeWindowOpen {
+			fmt.Printf("The member can still respond to the challenge until %s.\n", cliutils.GetDateTimeString(canDecide.ChallengeWindowEnd))
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canDecide.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	var prompt string
+	if canDecide.IsOwnChallenge {
+		prompt = "Are you sure you want to respond to the challenge against your node?"
+	} else {
+		prompt = fmt.Sprintf("The member %s did not respond to its challenge in time. Are you sure you want to remove it from the oracle DAO?", memberAddress.Hex())
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(prompt)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Decide the challenge
+	response, err := rp.DecideTNDAOChallenge(memberAddress)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deciding challenge...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	if canDecide.IsOwnChallenge {
+		fmt.Println("Successfully responded to the challenge against your node.")
+	} else {
+		fmt.Printf("Successfully decided the challenge; %s has been removed from the oracle DAO.\n", memberAddress.Hex())
+	}
+	return nil
+
+}
+
+// Get the oracle DAO member selected by the member flag, prompting for one if it wasn't provided
+func getSelectedMember(c *cli.Context, rp *rocketpool.Client, prompt string) (trustednode.MemberDetails, error) {
+
+	// Get DAO members
+	members, err := rp.TNDAOMembers()
+	if err != nil {
+		return trustednode.MemberDetails{}, err
+	}
+
+	// Get matching member
+	if c.String("member") != "" {
+		selectedAddress := common.HexToAddress(c.String("member"))
+		for _, member := range members.Members {
+			if bytes.Equal(member.Address.Bytes(), selectedAddress.Bytes()) {
+				return member, nil
+			}
+		}
+		return trustednode.MemberDetails{}, fmt.Errorf("The oracle DAO member %s does not exist.", selectedAddress.Hex())
+	}
+
+	// Prompt for member selection
+	options := make([]string, len(members.Members))
+	for mi, member := range members.Members {
+		options[mi] = fmt.Sprintf("%s (URL: %s, node: %s)", member.ID, member.Url, member.Address)
+	}
+	selected, _ := cliutils.Select(prompt, options)
+	return members.Members[selected], nil
+
+}
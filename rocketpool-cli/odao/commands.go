@@ -659,6 +659,94 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "membership",
+				Aliases:   []string{"ms"},
+				Usage:     "Show the node's RPL bond, challenge status and pending leave or replacement",
+				UsageText: "rocketpool odao membership",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getMembership(c)
+
+				},
+			},
+
+			{
+				Name:      "challenge",
+				Aliases:   []string{"c"},
+				Usage:     "Challenge an oracle DAO member to prove it is still online",
+				UsageText: "rocketpool odao challenge [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "member, m",
+						Usage: "The address of the member to challenge",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the challenge",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("member") != "" {
+						if _, err := cliutils.ValidateAddress("member address", c.String("member")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return challengeMember(c)
+
+				},
+			},
+
+			{
+				Name:      "decide-challenge",
+				Aliases:   []string{"dc"},
+				Usage:     "Respond to a challenge against this node, or remove a member that did not respond to its challenge in time",
+				UsageText: "rocketpool odao decide-challenge [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "member, m",
+						Usage: "The address of the challenged member (defaults to this node)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the decision",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("member") != "" {
+						if _, err := cliutils.ValidateAddress("member address", c.String("member")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return decideChallenge(c)
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,109 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+func getMembership(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get membership status
+	membership, err := rp.TNDAOMembership()
+	if err != nil {
+		return err
+	}
+	now := uint64(time.Now().Unix())
+
+	// Non-members can only be waiting to join or to take over another member's position
+	if !membership.IsMember {
+		fmt.Println("The node is not a member of the oracle DAO.")
+		fmt.Printf("Joining requires an executed invite proposal and a bond of %.6f RPL.\n", math.RoundDown(eth.WeiToEth(membership.RequiredRPLBond), 6))
+		if membership.ReplacingMember != (common.Address{}) {
+			fmt.Printf("This node has been named as the replacement for member %s; that member must complete the replacement for this node to take over its position.\n", membership.ReplacingMember.Hex())
+		}
+		return nil
+	}
+
+	// Bond
+	fmt.Printf("%s=== RPL Bond ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Bonded RPL:           %.6f\n", math.RoundDown(eth.WeiToEth(membership.RPLBondAmount), 6))
+	fmt.Printf("Current bond setting: %.6f\n", math.RoundDown(eth.WeiToEth(membership.RequiredRPLBond), 6))
+	fmt.Printf("Unbonded minipools:   %d\n", membership.UnbondedValidatorCount)
+	if membership.RPLBondAmount.Cmp(membership.RequiredRPLBond) < 0 {
+		fmt.Println("Your bond is below the current bond setting, which only applies to new members; your existing bond remains valid.")
+	}
+	fmt.Println("The bond is locked for as long as the node is a member. It cannot be topped up or partially withdrawn; the full bond is refunded when the node leaves with 'rocketpool odao leave'.")
+	fmt.Println()
+
+	// Challenge
+	fmt.Printf("%s=== Challenges ===%s\n", colorGreen, colorReset)
+	if membership.IsChallenged {
+		windowEnd := membership.ChallengedTime + membership.ChallengeWindow
+		fmt.Printf("%sThe node was challenged by %s at %s.%s\n", colorYellow, membership.ChallengedBy.Hex(), cliutils.GetDateTimeString(membership.ChallengedTime), colorReset)
+		if now <= windowEnd {
+			fmt.Printf("It must respond before %s or it can be removed from the oracle DAO. The watchtower responds automatically while it is running, or you can respond now with 'rocketpool odao decide-challenge'.\n", cliutils.GetDateTimeString(windowEnd))
+		} else {
+			fmt.Printf("%sThe challenge window ended at %s; respond immediately with 'rocketpool odao decide-challenge' before anyone else decides it and removes the node.%s\n", colorRed, cliutils.GetDateTimeString(windowEnd), colorReset)
+		}
+	} else {
+		fmt.Println("The node does not have an active challenge against it.")
+	}
+	fmt.Println()
+
+	// Leave & replacement proposals
+	fmt.Printf("%s=== Leaving & Replacement ===%s\n", colorGreen, colorReset)
+	printExecutedProposal("leave", membership.LeaveProposalExecutedTime, membership.ProposalActionTime, now)
+	printExecutedProposal("replace", membership.ReplaceProposalExecutedTime, membership.ProposalActionTime, now)
+	if membership.ReplacementAddress != (common.Address{}) {
+		fmt.Printf("Replacement node:     %s\n", membership.ReplacementAddress.Hex())
+		if membership.ReplaceProposalExecutedTime+membership.ProposalActionTime > now {
+			fmt.Println("The replacement node should be running a synced Smartnode with the watchtower enabled before the replacement takes effect, so no oracle duties are missed during the handover.")
+		}
+	}
+	if membership.LeaveProposalExecutedTime+membership.ProposalActionTime > now {
+		fmt.Println("You can leave the oracle DAO now with 'rocketpool odao leave'.")
+	}
+	return nil
+
+}
+
+// Print the status of one of the node's executed membership proposals
+func printExecutedProposal(proposalType string, executedTime uint64, actionTime uint64, now uint64) {
+	label := fmt.Sprintf("%s proposal:", proposalType)
+	switch {
+	case executedTime == 0:
+		fmt.Printf("%-22s none\n", label)
+	case now < executedTime+actionTime:
+		fmt.Printf("%-22s executed at %s, actionable until %s\n", label, cliutils.GetDateTimeString(executedTime), cliutils.GetDateTimeString(executedTime+actionTime))
+	default:
+		fmt.Printf("%-22s executed at %s, expired at %s\n", label, cliutils.GetDateTimeString(executedTime), cliutils.GetDateTimeString(executedTime+actionTime))
+	}
+}
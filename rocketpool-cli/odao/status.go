@@ -40,7 +40,7 @@ func getStatus(c *cli.Context) error {
 			fmt.Println("The node has an executed proposal to leave - you can leave the oracle DAO with 'rocketpool odao leave'")
 		}
 		if status.CanReplace {
-			fmt.Println("The node has an executed proposal to replace itself - you can replace your position in the oracle DAO with 'rocketpool odao replace'")
+			fmt.Println("The node has an executed proposal to replace itself - see 'rocketpool odao membership' for the replacement node and its deadline")
 		}
 	} else {
 		fmt.Println("The node is not a member of the oracle DAO.")
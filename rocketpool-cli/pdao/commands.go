@@ -0,0 +1,136 @@
+package pdao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Take part in the Rocket Pool protocol DAO",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "proposals",
+				Aliases:   []string{"p"},
+				Usage:     "List the protocol DAO proposals that are being voted on",
+				UsageText: "rocketpool pdao proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProposals(c)
+
+				},
+			},
+
+			{
+				Name:      "voting-power",
+				Aliases:   []string{"vp"},
+				Usage:     "Show the node's voting power and its voting delegate",
+				UsageText: "rocketpool pdao voting-power",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getVotingPower(c)
+
+				},
+			},
+
+			{
+				Name:      "vote",
+				Aliases:   []string{"v"},
+				Usage:     "Vote on a protocol DAO proposal with the node's voting power, including any power delegated to it",
+				UsageText: "rocketpool pdao vote [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "proposal, p",
+						Usage: "The ID of the proposal to vote on",
+					},
+					cli.StringFlag{
+						Name:  "choice, c",
+						Usage: "The choice to vote for, either by its number or its name",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the vote",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return voteOnProposal(c)
+
+				},
+			},
+
+			{
+				Name:      "set-delegate",
+				Aliases:   []string{"sd"},
+				Usage:     "Set an on-chain delegate to vote on protocol DAO proposals with your node's voting power",
+				UsageText: "rocketpool pdao set-delegate address",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm delegate setting",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					delegate := c.Args().Get(0)
+
+					// Run
+					return node.SetVotingDelegate(c, delegate)
+
+				},
+			},
+
+			{
+				Name:      "clear-delegate",
+				Aliases:   []string{"cd"},
+				Usage:     "Remove your node's on-chain voting delegate",
+				UsageText: "rocketpool pdao clear-delegate",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm delegate clearing",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return node.ClearVotingDelegate(c)
+
+				},
+			},
+		},
+	})
+}
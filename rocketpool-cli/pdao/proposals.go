@@ -0,0 +1,103 @@
+package pdao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	colorReset  string = "\033[0m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+	colorBlue   string = "\033[36m"
+)
+
+func getProposals(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get active proposals
+	response, err := rp.GetActiveDAOProposals()
+	if err != nil {
+		return err
+	}
+	if len(response.ActiveSnapshotProposals) == 0 {
+		fmt.Println("Rocket Pool has no protocol DAO proposals being voted on.")
+		return nil
+	}
+
+	// Print the proposals
+	fmt.Printf("%s=== Active Proposals ===%s\n", colorGreen, colorReset)
+	for _, proposal := range response.ActiveSnapshotProposals {
+		fmt.Printf("\n%s%s%s\n", colorBlue, proposal.Title, colorReset)
+		fmt.Printf("ID:      %s\n", proposal.Id)
+		if proposal.Link != "" {
+			fmt.Printf("Link:    %s\n", proposal.Link)
+		}
+		if time.Now().Unix() < proposal.Start {
+			fmt.Printf("Starts:  %s (in %s)\n", cliutils.GetDateTimeString(uint64(proposal.Start)), time.Until(time.Unix(proposal.Start, 0)).Round(time.Second))
+		} else {
+			fmt.Printf("Ends:    %s (in %s)\n", cliutils.GetDateTimeString(uint64(proposal.End)), time.Until(time.Unix(proposal.End, 0)).Round(time.Second))
+		}
+		fmt.Println("Choices:")
+		for i, choice := range proposal.Choices {
+			score := float64(0)
+			if i < len(proposal.Scores) {
+				score = proposal.Scores[i]
+			}
+			fmt.Printf("\t%d: %s (%.2f)\n", i+1, choice, score)
+		}
+		fmt.Printf("Quorum:  %.2f of %.2f\n", proposal.ScoresTotal, proposal.Quorum)
+		printVote(proposal, response)
+	}
+	fmt.Println()
+	return nil
+
+}
+
+// Print the node's or its delegate's vote on a proposal
+func printVote(proposal api.SnapshotProposal, response api.NetworkDAOProposalsResponse) {
+	for _, vote := range response.ProposalVotes {
+		if vote.Proposal.Id != proposal.Id {
+			continue
+		}
+		voter := "Your delegate"
+		if vote.Voter == response.AccountAddress {
+			voter = "You"
+		}
+		fmt.Printf("%s%s voted for [%s]%s\n", colorGreen, voter, getChoiceString(proposal, vote.Choice), colorReset)
+		return
+	}
+	fmt.Printf("%sYou have NOT voted on this proposal yet.%s\n", colorYellow, colorReset)
+}
+
+// Get the name of the choice a vote was cast for
+func getChoiceString(proposal api.SnapshotProposal, choice interface{}) string {
+	choiceFloat, ok := choice.(float64)
+	if !ok {
+		return fmt.Sprintf("%v", choice)
+	}
+	index := int(choiceFloat) - 1
+	if index < 0 || index >= len(proposal.Choices) {
+		return fmt.Sprintf("Unknown (%d is out of bounds)", index+1)
+	}
+	return proposal.Choices[index]
+}
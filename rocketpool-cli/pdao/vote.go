@@ -0,0 +1,132 @@
+package pdao
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func voteOnProposal(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get active proposals
+	proposals, err := rp.GetActiveDAOProposals()
+	if err != nil {
+		return err
+	}
+	if len(proposals.ActiveSnapshotProposals) == 0 {
+		fmt.Println("There are no proposals that can be voted on.")
+		return nil
+	}
+
+	// Get the selected proposal
+	var selectedProposal api.SnapshotProposal
+	if c.String("proposal") != "" {
+		found := false
+		for _, proposal := range proposals.ActiveSnapshotProposals {
+			if strings.EqualFold(proposal.Id, c.String("proposal")) {
+				selectedProposal = proposal
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Proposal %s is not being voted on.", c.String("proposal"))
+		}
+	} else {
+		options := make([]string, len(proposals.ActiveSnapshotProposals))
+		for i, proposal := range proposals.ActiveSnapshotProposals {
+			options[i] = fmt.Sprintf("%s (ends %s)", proposal.Title, cliutils.GetDateTimeString(uint64(proposal.End)))
+		}
+		selected, _ := cliutils.Select("Please select a proposal to vote on:", options)
+		selectedProposal = proposals.ActiveSnapshotProposals[selected]
+	}
+
+	// Get the selected choice
+	var choice uint64
+	if c.String("choice") != "" {
+		choice, err = parseChoice(selectedProposal, c.String("choice"))
+		if err != nil {
+			return err
+		}
+	} else {
+		selected, _ := cliutils.Select("Please select your vote:", selectedProposal.Choices)
+		choice = uint64(selected + 1)
+	}
+
+	// Check the vote can be cast
+	canVote, err := rp.PdaoCanVote(selectedProposal.Id, choice)
+	if err != nil {
+		return err
+	}
+	if !canVote.CanVote {
+		fmt.Println("Cannot vote on this proposal:")
+		if canVote.ProposalNotFound {
+			fmt.Println("The proposal does not exist.")
+		}
+		if canVote.ProposalNotActive {
+			fmt.Println("The proposal is not being voted on.")
+		}
+		if canVote.UnsupportedType {
+			fmt.Printf("The proposal uses '%s' voting, which can only be voted on through the Snapshot website.\n", canVote.Proposal.Type)
+		}
+		if canVote.InvalidChoice {
+			fmt.Printf("The proposal doesn't have a choice %d.\n", choice)
+		}
+		if canVote.NoVotingPower {
+			fmt.Println("The node has no voting power for this proposal.")
+		}
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to vote for [%s] on '%s' with %.2f voting power?", selectedProposal.Choices[choice-1], selectedProposal.Title, canVote.VotingPower))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Vote
+	response, err := rp.PdaoVote(selectedProposal.Id, choice)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully voted for [%s] on '%s'. Your vote's ID is %s.\n", selectedProposal.Choices[choice-1], selectedProposal.Title, response.VoteId)
+	return nil
+
+}
+
+// Get the number of a choice (starting at 1) from either its number or its name
+func parseChoice(proposal api.SnapshotProposal, value string) (uint64, error) {
+	if number, err := strconv.ParseUint(value, 10, 64); err == nil {
+		if number < 1 || number > uint64(len(proposal.Choices)) {
+			return 0, fmt.Errorf("Invalid choice %d - it must be between 1 and %d.", number, len(proposal.Choices))
+		}
+		return number, nil
+	}
+	for i, choice := range proposal.Choices {
+		if strings.EqualFold(choice, value) {
+			return uint64(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid choice '%s' - it must be one of: %s", value, strings.Join(proposal.Choices, ", "))
+}
@@ -0,0 +1,55 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getVotingPower(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the voting power
+	response, err := rp.PdaoVotingPower()
+	if err != nil {
+		return err
+	}
+
+	// Print the voting power
+	fmt.Printf("%s=== Voting Power ===%s\n", colorGreen, colorReset)
+	fmt.Printf("The node %s%s%s has %.2f voting power, including any power that other nodes have delegated to it.\n", colorBlue, response.AccountAddress.Hex(), colorReset, response.VotingPower)
+	if len(response.VotingPowerByStrategy) > 1 {
+		for i, power := range response.VotingPowerByStrategy {
+			fmt.Printf("\tStrategy %d: %.2f\n", i+1, power)
+		}
+	}
+	fmt.Println()
+
+	// Print the delegate
+	fmt.Printf("%s=== Delegate ===%s\n", colorGreen, colorReset)
+	if response.VotingDelegate == (common.Address{}) {
+		fmt.Println("The node does not have an on-chain voting delegate, so its power is only used when it votes itself.")
+	} else if response.VotingDelegate == response.AccountAddress {
+		fmt.Println("The node is its own voting delegate.")
+	} else {
+		fmt.Printf("The node has delegated its voting power to %s%s%s. Its delegate votes on its behalf unless the node votes on a proposal itself, which overrides the delegate's vote.\n", colorBlue, response.VotingDelegate.Hex(), colorReset)
+	}
+	return nil
+
+}
@@ -3,16 +3,21 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/completion"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/dashboard"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/faucet"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/learn"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/pdao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
@@ -22,6 +27,11 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+const (
+	colorReset  string = "\033[0m"
+	colorYellow string = "\033[33m"
+)
+
 // Run
 func main() {
 
@@ -74,6 +84,11 @@ ______           _        _    ______           _
 			Usage: "Rocket Pool config asset `path`",
 			Value: "~/.rocketpool",
 		},
+		cli.StringFlag{
+			Name:   "network, n",
+			Usage:  "The `network` to use when several are set up on this machine (mainnet, prater, or devnet); each one gets its own config folder, wallet, and containers",
+			EnvVar: "ROCKETPOOL_NETWORK",
+		},
 		cli.StringFlag{
 			Name:  "daemon-path, d",
 			Usage: "Interact with a Rocket Pool service daemon at a `path` on the host OS, running outside of docker",
@@ -90,10 +105,18 @@ ______           _        _    ______           _
 			Name:  "gasLimit, l",
 			Usage: "[DEPRECATED] Desired gas limit",
 		},
+		cli.StringFlag{
+			Name:  "preset, p",
+			Usage: "The name of a transaction preset from the Smartnode settings to use for the max fee, priority fee, submission route, and deadline",
+		},
 		cli.StringFlag{
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "simulate",
+			Usage: "Run any transactions through eth_call and eth_estimateGas instead of signing and broadcasting them, and print the decoded call, expected gas, and result of each",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
@@ -107,6 +130,8 @@ ______           _        _    ______           _
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
+	completion.RegisterCommands(app, "completion", []string{})
+	dashboard.RegisterCommands(app, "dashboard", []string{"d"})
 
 	// Get the config path from the arguments (or use the default)
 	configPath := "~/.rocketpool"
@@ -120,6 +145,14 @@ ______           _        _    ______           _
 		}
 	}
 
+	// Switch to the selected network's config folder
+	selectedNetwork := getGlobalNetworkArg(os.Args)
+	configPath, err := rocketpool.GetNetworkConfigPath(configPath, selectedNetwork)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
+
 	// Get and parse the config file
 	configFile := fmt.Sprintf("%s/%s", configPath, rocketpool.SettingsFile)
 	expandedPath, err := homedir.Expand(configFile)
@@ -128,6 +161,7 @@ ______           _        _    ______           _
 		os.Exit(1)
 	}
 	// Stop if the config file doesn't exist yet
+	hasContractOverrides := false
 	_, err = os.Stat(expandedPath)
 	if !os.IsNotExist(err) {
 		cfg, err := rp.LoadConfigFromFile(expandedPath)
@@ -135,6 +169,7 @@ ______           _        _    ______           _
 			fmt.Fprintf(os.Stderr, "Failed to load the global config file: %s\n", err.Error())
 			os.Exit(1)
 		}
+		hasContractOverrides = cfg.Smartnode.HasContractAddressOverrides()
 
 		// Add the faucet if we're on a testnet and it has a contract address
 		if cfg.Smartnode.GetRplFaucetAddress() != "" {
@@ -142,10 +177,12 @@ ______           _        _    ______           _
 		}
 	}
 
+	learn.RegisterCommands(app, "learn", []string{"l"})
 	minipool.RegisterCommands(app, "minipool", []string{"m"})
 	network.RegisterCommands(app, "network", []string{"e"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	odao.RegisterCommands(app, "odao", []string{"o"})
+	pdao.RegisterCommands(app, "pdao", []string{"p"})
 	queue.RegisterCommands(app, "queue", []string{"q"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
@@ -158,6 +195,21 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		// Make it clear that nothing will actually be submitted
+		if c.GlobalBool("simulate") {
+			fmt.Fprintf(os.Stderr, "%sNOTE: simulation mode is active. Transactions will be simulated against the current chain state, but nothing will be signed or broadcast.%s\n\n", colorYellow, colorReset)
+		}
+
+		// Make it clear which network's node the commands apply to
+		if selectedNetwork != "" {
+			fmt.Fprintf(os.Stderr, "%sNOTE: using the %s node in %s.%s\n\n", colorYellow, selectedNetwork, configPath, colorReset)
+		}
+
+		// Make it clear that the node isn't using the built-in contract addresses
+		if hasContractOverrides {
+			fmt.Fprintf(os.Stderr, "%sNOTE: contract address overrides are active in your Smartnode configuration. Some commands will interact with custom contracts instead of the official Rocket Pool deployment.%s\n\n", colorYellow, colorReset)
+		}
+
 		return nil
 	}
 
@@ -169,3 +221,35 @@ ______           _        _    ______           _
 	fmt.Println("")
 
 }
+
+// Get the network selected with the global --network flag, or from the environment if it isn't given.
+// Only the arguments before the command are checked, since some commands have a --network flag of their own.
+func getGlobalNetworkArg(args []string) string {
+	selectedNetwork := os.Getenv("ROCKETPOOL_NETWORK")
+	for index := 1; index < len(args); index++ {
+		arg := args[index]
+		if !strings.HasPrefix(arg, "-") {
+			break
+		}
+		if strings.HasPrefix(arg, "--network=") {
+			selectedNetwork = strings.TrimPrefix(arg, "--network=")
+			continue
+		}
+		if arg == "-n" || arg == "--network" {
+			if index+1 < len(args) {
+				selectedNetwork = args[index+1]
+			}
+			index++
+			continue
+		}
+
+		// Skip the values of the other global flags that take one
+		switch strings.TrimLeft(strings.SplitN(arg, "=", 2)[0], "-") {
+		case "c", "config-path", "d", "daemon-path", "f", "maxFee", "i", "maxPrioFee", "l", "gasLimit", "p", "preset", "nonce":
+			if !strings.Contains(arg, "=") {
+				index++
+			}
+		}
+	}
+	return selectedNetwork
+}
@@ -188,6 +188,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "networks",
+				Usage:     "List the networks set up on this machine; select one for any command with `rocketpool --network <network>`",
+				UsageText: "rocketpool service networks",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return listNetworks(c)
+
+				},
+			},
+
 			{
 				Name:      "start",
 				Aliases:   []string{"s"},
@@ -202,6 +219,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Ignore service config prompt after upgrading",
 					},
+					cli.BoolFlag{
+						Name:  "rolling",
+						Usage: "Restart the clients one at a time, waiting for each to be healthy (EC synced, BC synced, VC attesting) before moving on and rolling back any that fail",
+					},
+					cli.StringFlag{
+						Name:  "gate-timeout",
+						Usage: "How long to wait for each client to become healthy during a rolling upgrade before rolling it back (e.g. 30m, 1h)",
+						Value: defaultGateTimeout,
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -318,6 +344,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rollback-config",
+				Usage:     "Restore your settings from before the last Smartnode upgrade",
+				UsageText: "rocketpool service rollback-config [--yes]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the rollback",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return rollbackConfig(c)
+
+				},
+			},
+
 			{
 				Name:      "version",
 				Aliases:   []string{"v"},
@@ -405,6 +454,38 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-debug",
+				Usage:     "Collect your redacted configuration, recent node and watchtower logs, client sync status, hardware info and metrics collector errors into a single archive for support requests. Your node wallet and validator keys are never included.",
+				UsageText: "rocketpool service export-debug [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path of the archive to create (defaults to rocketpool-debug-<timestamp>.tar.gz in the current folder)",
+					},
+					cli.StringFlag{
+						Name:  "tail, t",
+						Usage: "The number of lines to include from the end of the logs (number or \"all\")",
+						Value: "1000",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm creating the archive",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return exportDebug(c)
+
+				},
+			},
+
 			{
 				Name:      "get-config-yaml",
 				Usage:     "Generate YAML that shows the current configuration schema, including all of the parameters and their descriptions",
@@ -473,9 +554,16 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			},
 
 			{
-				Name:      "resync-eth1",
-				Usage:     fmt.Sprintf("%sDeletes the main ETH1 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
-				UsageText: "rocketpool service resync-eth1",
+				Name:      "resync-ec",
+				Aliases:   []string{"resync-eth1"},
+				Usage:     fmt.Sprintf("%sDeletes the main Execution client's chain data and resyncs it from its peers, using snap sync for Geth and Nethermind and the client's default sync mode for the others. Only use this as a last resort!%s", colorRed, colorReset),
+				UsageText: "rocketpool service resync-ec [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the chain data",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -484,15 +572,61 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return resyncEth1(c)
+					return resyncEc(c)
 
 				},
 			},
 
 			{
-				Name:      "resync-eth2",
-				Usage:     fmt.Sprintf("%sDeletes the ETH2 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
-				UsageText: "rocketpool service resync-eth2",
+				Name:      "resync-cc",
+				Aliases:   []string{"resync-eth2"},
+				Usage:     fmt.Sprintf("%sDeletes the Consensus client's chain data and resyncs it with checkpoint sync. Only use this as a last resort!%s", colorRed, colorReset),
+				UsageText: "rocketpool service resync-cc [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the chain data",
+					},
+					cli.StringFlag{
+						Name:  "checkpoint-sync-url, u",
+						Usage: "The checkpoint sync provider to resync from; it's checked before anything is deleted and saved to your configuration (defaults to the configured provider)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return resyncCc(c)
+
+				},
+			},
+
+			{
+				Name:      "switch-clients",
+				Usage:     "Shows the current client diversity and switches to a minority Consensus client, deleting the old client's chain data and rebuilding your validator keys",
+				UsageText: "rocketpool service switch-clients [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch, using the recommended Consensus client unless one is specified",
+					},
+					cli.StringFlag{
+						Name:  "consensus-client, c",
+						Usage: "The Consensus client to switch to (defaults to prompting with the least used client recommended)",
+					},
+					cli.StringFlag{
+						Name:  "execution-client, e",
+						Usage: "An Execution client to switch to as well; it will resync from scratch",
+					},
+					cli.StringFlag{
+						Name:  "checkpoint-sync-url, u",
+						Usage: "The checkpoint sync provider the new Consensus client syncs from; it's checked before anything is deleted and saved to your configuration (defaults to the configured provider)",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -501,7 +635,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return resyncEth2(c)
+					return switchClients(c)
 
 				},
 			},
@@ -17,6 +17,7 @@ type AddonsPage struct {
 	masterConfig  *config.RocketPoolConfig
 	gwwPage       *AddonGwwPage
 	gwwButton     *parameterizedFormItem
+	dvtPage       *AddonDvtPage
 	categoryList  *tview.List
 	addonSubpages []settingsPage
 	content       tview.Primitive
@@ -39,8 +40,10 @@ func NewAddonsPage(home *settingsHome) *AddonsPage {
 
 	// Create the addon subpages
 	addonsPage.gwwPage = NewAddonGwwPage(addonsPage, home.md.Config.GraffitiWallWriter)
+	addonsPage.dvtPage = NewAddonDvtPage(addonsPage, home.md.Config.Dvt)
 	addonSubpages := []settingsPage{
 		addonsPage.gwwPage,
+		addonsPage.dvtPage,
 	}
 	addonsPage.addonSubpages = addonSubpages
 
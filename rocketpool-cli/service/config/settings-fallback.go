@@ -15,6 +15,8 @@ type FallbackConfigPage struct {
 	masterConfig        *config.RocketPoolConfig
 	useFallbackBox      *parameterizedFormItem
 	reconnectDelay      *parameterizedFormItem
+	latencyRouting      *parameterizedFormItem
+	heavyRouting        *parameterizedFormItem
 	fallbackNormalItems []*parameterizedFormItem
 	fallbackPrysmItems  []*parameterizedFormItem
 }
@@ -74,11 +76,13 @@ func (configPage *FallbackConfigPage) createContent() {
 	// Set up the form items
 	configPage.useFallbackBox = createParameterizedCheckbox(&configPage.masterConfig.UseFallbackClients)
 	configPage.reconnectDelay = createParameterizedStringField(&configPage.masterConfig.ReconnectDelay)
+	configPage.latencyRouting = createParameterizedDropDown(&configPage.masterConfig.LatencySensitiveBcRouting, configPage.layout.descriptionBox)
+	configPage.heavyRouting = createParameterizedDropDown(&configPage.masterConfig.HeavyBcRouting, configPage.layout.descriptionBox)
 	configPage.fallbackNormalItems = createParameterizedFormItems(configPage.masterConfig.FallbackNormal.GetParameters(), configPage.layout.descriptionBox)
 	configPage.fallbackPrysmItems = createParameterizedFormItems(configPage.masterConfig.FallbackPrysm.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay)
+	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay, configPage.latencyRouting, configPage.heavyRouting)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackNormalItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackPrysmItems...)
 
@@ -105,6 +109,8 @@ func (configPage *FallbackConfigPage) handleUseFallbackChanged() {
 		return
 	}
 	configPage.layout.form.AddFormItem(configPage.reconnectDelay.item)
+	configPage.layout.form.AddFormItem(configPage.latencyRouting.item)
+	configPage.layout.form.AddFormItem(configPage.heavyRouting.item)
 
 	cc, _ := configPage.masterConfig.GetSelectedConsensusClient()
 	switch cc {
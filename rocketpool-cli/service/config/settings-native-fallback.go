@@ -14,6 +14,8 @@ type NativeFallbackConfigPage struct {
 	masterConfig   *config.RocketPoolConfig
 	useFallbackBox *parameterizedFormItem
 	reconnectDelay *parameterizedFormItem
+	latencyRouting *parameterizedFormItem
+	heavyRouting   *parameterizedFormItem
 	fallbackItems  []*parameterizedFormItem
 }
 
@@ -72,10 +74,12 @@ func (configPage *NativeFallbackConfigPage) createContent() {
 	// Set up the form items
 	configPage.useFallbackBox = createParameterizedCheckbox(&configPage.masterConfig.UseFallbackClients)
 	configPage.reconnectDelay = createParameterizedStringField(&configPage.masterConfig.ReconnectDelay)
+	configPage.latencyRouting = createParameterizedDropDown(&configPage.masterConfig.LatencySensitiveBcRouting, configPage.layout.descriptionBox)
+	configPage.heavyRouting = createParameterizedDropDown(&configPage.masterConfig.HeavyBcRouting, configPage.layout.descriptionBox)
 	configPage.fallbackItems = createParameterizedFormItems(configPage.masterConfig.FallbackNormal.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay)
+	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay, configPage.latencyRouting, configPage.heavyRouting)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackItems...)
 
 	// Set up the setting callbacks
@@ -101,6 +105,8 @@ func (configPage *NativeFallbackConfigPage) handleUseFallbackChanged() {
 		return
 	}
 	configPage.layout.form.AddFormItem(configPage.reconnectDelay.item)
+	configPage.layout.form.AddFormItem(configPage.latencyRouting.item)
+	configPage.layout.form.AddFormItem(configPage.heavyRouting.item)
 	configPage.layout.addFormItems(configPage.fallbackItems)
 
 	configPage.layout.refresh()
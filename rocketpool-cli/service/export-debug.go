@@ -0,0 +1,296 @@
+package service
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Debug bundle settings
+const (
+	debugBundleFileMode     os.FileMode = 0600
+	debugBundleNameFormat   string      = "rocketpool-debug-%s.tar.gz"
+	debugLogServices        string      = "node watchtower"
+	debugRedacted           string      = "REDACTED"
+	debugMinSecretPathChars int         = 20
+)
+
+// Settings whose values are always secret, matched against their IDs
+var debugSecretParamRegex = regexp.MustCompile(`(?i)(token|secret|password|apikey|api_key|credential)`)
+
+// URLs in settings and logs, which can carry API keys in their credentials, query or path
+var debugUrlRegex = regexp.MustCompile(`(?i)\b(https?|wss?)://[^\s"'<>]+`)
+
+// The lines of the node's log written by the metrics collectors
+var debugCollectorLogRegex = regexp.MustCompile(`\[[^\]]*Collector\]`)
+
+// Collect the node's configuration, logs and status into a redacted archive for support requests
+func exportDebug(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the output path
+	now := time.Now()
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf(debugBundleNameFormat, now.Format("20060102-150405"))
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists; please choose a different output path with --output", outputPath)
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will collect the following into a single archive you can attach to a support request:")
+	fmt.Println("\t- Your Smartnode configuration, with API tokens, passwords and the credentials in URLs removed")
+	fmt.Printf("\t- The last %s lines of the node and watchtower logs\n", c.String("tail"))
+	fmt.Println("\t- The sync status of your clients")
+	fmt.Println("\t- Your machine's operating system, CPU, memory and disk space")
+	fmt.Println("\t- The latest errors from the metrics collectors")
+	fmt.Printf("%sYour node wallet, its password and your validator keys are never included.%s\n\n", colorGreen, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to create the debug bundle?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Collect the bundle's files; anything that can't be collected is noted in the file instead
+	files := map[string][]byte{}
+	files["config.yaml"] = getDebugConfig(cfg)
+	nodeLogs := getDebugLogs(c, rp, cfg)
+	files["logs.txt"] = nodeLogs
+	files["collector-errors.txt"] = getDebugCollectorErrors(nodeLogs)
+	files["sync-status.json"] = getDebugSyncStatus(rp)
+	files["hardware.txt"] = getDebugHardware(cfg)
+	files["manifest.txt"] = getDebugManifest(now, files)
+
+	// Write the archive
+	if err := writeDebugBundle(outputPath, files); err != nil {
+		return err
+	}
+	fmt.Printf("Saved the debug bundle to %s%s%s.\n", colorGreen, outputPath, colorReset)
+	fmt.Println("Please look through it before sharing it; logs can still mention your node and minipool addresses.")
+	return nil
+
+}
+
+// Serialize the config with its secrets removed
+func getDebugConfig(cfg *config.RocketPoolConfig) []byte {
+	serialized := cfg.Serialize()
+	for _, section := range serialized {
+		for id, value := range section {
+			if value != "" && debugSecretParamRegex.MatchString(id) {
+				section[id] = debugRedacted
+			} else {
+				section[id] = redactDebugText(value)
+			}
+		}
+	}
+	bytes, err := yaml.Marshal(serialized)
+	if err != nil {
+		return []byte(fmt.Sprintf("Error serializing config: %s\n", err.Error()))
+	}
+	return bytes
+}
+
+// Get the recent logs of the node and watchtower
+func getDebugLogs(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) []byte {
+	if cfg.IsNativeMode {
+		return []byte("The Smartnode is running in Native mode, so its logs can't be collected automatically. Please attach the output of your service manager for the node and watchtower instead.\n")
+	}
+	logs, err := rp.GetServiceLogs(getComposeFiles(c), c.String("tail"), strings.Fields(debugLogServices)...)
+	if err != nil {
+		return []byte(fmt.Sprintf("Error getting logs: %s\n", err.Error()))
+	}
+	return []byte(redactDebugText(string(logs)))
+}
+
+// Get the lines of the logs written by the metrics collectors
+func getDebugCollectorErrors(logs []byte) []byte {
+	var buffer bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if debugCollectorLogRegex.MatchString(scanner.Text()) {
+			buffer.WriteString(scanner.Text())
+			buffer.WriteString("\n")
+		}
+	}
+	if buffer.Len() == 0 {
+		return []byte("The metrics collectors haven't logged any errors recently.\n")
+	}
+	return buffer.Bytes()
+}
+
+// Get the sync status of the clients
+func getDebugSyncStatus(rp *rocketpool.Client) []byte {
+	status, err := rp.NodeSync()
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", redactDebugText(err.Error())))
+	}
+	bytes, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", err.Error()))
+	}
+	return []byte(redactDebugText(string(bytes)))
+}
+
+// Get a summary of the machine's hardware
+func getDebugHardware(cfg *config.RocketPoolConfig) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Architecture: %s\n", runtime.GOARCH)
+	if info, err := host.Info(); err == nil {
+		fmt.Fprintf(&buffer, "OS: %s %s (%s)\n", info.Platform, info.PlatformVersion, info.KernelVersion)
+		fmt.Fprintf(&buffer, "Virtualization: %s %s\n", info.VirtualizationSystem, info.VirtualizationRole)
+		fmt.Fprintf(&buffer, "Uptime: %s\n", (time.Duration(info.Uptime) * time.Second).String())
+	} else {
+		fmt.Fprintf(&buffer, "OS: error: %s\n", err.Error())
+	}
+	if cores, err := cpu.Counts(true); err == nil {
+		fmt.Fprintf(&buffer, "CPU threads: %d\n", cores)
+	}
+	if infos, err := cpu.Info(); err == nil && len(infos) > 0 {
+		fmt.Fprintf(&buffer, "CPU model: %s\n", infos[0].ModelName)
+	}
+	if memory, err := mem.VirtualMemory(); err == nil {
+		fmt.Fprintf(&buffer, "Memory: %d MiB total, %d MiB available\n", memory.Total/1024/1024, memory.Available/1024/1024)
+	} else {
+		fmt.Fprintf(&buffer, "Memory: error: %s\n", err.Error())
+	}
+	if swap, err := mem.SwapMemory(); err == nil {
+		fmt.Fprintf(&buffer, "Swap: %d MiB total, %d MiB used\n", swap.Total/1024/1024, swap.Used/1024/1024)
+	}
+	if usage, err := disk.Usage(cfg.RocketPoolDirectory); err == nil {
+		fmt.Fprintf(&buffer, "Disk (Smartnode folder): %d GiB total, %d GiB free (%s)\n", usage.Total/1024/1024/1024, usage.Free/1024/1024/1024, usage.Fstype)
+	}
+	if partitions, err := disk.Partitions(false); err == nil {
+		for _, partition := range partitions {
+			usage, err := disk.Usage(partition.Mountpoint)
+			if err != nil || usage.Total == 0 {
+				continue
+			}
+			fmt.Fprintf(&buffer, "Disk %s: %d GiB total, %d GiB free (%s)\n", partition.Mountpoint, usage.Total/1024/1024/1024, usage.Free/1024/1024/1024, usage.Fstype)
+		}
+	}
+	return buffer.Bytes()
+}
+
+// Describe the contents of the bundle
+func getDebugManifest(created time.Time, files map[string][]byte) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Rocket Pool Smartnode debug bundle\n")
+	fmt.Fprintf(&buffer, "Smartnode version: v%s\n", shared.RocketPoolVersion)
+	fmt.Fprintf(&buffer, "Created: %s\n\n", created.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&buffer, "Contents:\n")
+	for _, name := range getSortedDebugFileNames(files) {
+		fmt.Fprintf(&buffer, "\t%s (%d bytes)\n", name, len(files[name]))
+	}
+	fmt.Fprintf(&buffer, "\nExcluded: the node wallet, its password, validator keys and secrets, and the transactor key.\n")
+	fmt.Fprintf(&buffer, "Redacted: API tokens, passwords, and the credentials, query strings and API key paths of URLs.\n")
+	return buffer.Bytes()
+}
+
+// Write the bundle's files to a tar.gz archive
+func writeDebugBundle(path string, files map[string][]byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, debugBundleFileMode)
+	if err != nil {
+		return fmt.Errorf("error creating debug bundle: %w", err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+	for _, name := range getSortedDebugFileNames(files) {
+		contents := files[name]
+		header := &tar.Header{
+			Name:    "rocketpool-debug/" + name,
+			Mode:    int64(debugBundleFileMode),
+			Size:    int64(len(contents)),
+			ModTime: now,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing %s to debug bundle: %w", name, err)
+		}
+		if _, err := tarWriter.Write(contents); err != nil {
+			return fmt.Errorf("error writing %s to debug bundle: %w", name, err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error finishing debug bundle: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error finishing debug bundle: %w", err)
+	}
+	return nil
+}
+
+// Get the names of the bundle's files in order
+func getSortedDebugFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove the credentials from the URLs in some text
+func redactDebugText(text string) string {
+	return debugUrlRegex.ReplaceAllStringFunc(text, func(rawUrl string) string {
+		parsedUrl, err := url.Parse(rawUrl)
+		if err != nil {
+			return debugRedacted
+		}
+		if parsedUrl.User != nil {
+			parsedUrl.User = url.User(debugRedacted)
+		}
+		if parsedUrl.RawQuery != "" {
+			parsedUrl.RawQuery = debugRedacted
+		}
+
+		// Providers like Infura and Alchemy put the API key in the path
+		for _, segment := range strings.Split(parsedUrl.Path, "/") {
+			if len(segment) >= debugMinSecretPathChars {
+				parsedUrl.Path = "/" + debugRedacted
+				parsedUrl.RawPath = ""
+				break
+			}
+		}
+		return parsedUrl.String()
+	})
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Make sure the service commands are allowed to manage the Native mode systemd units
+func checkSystemdManagement(cfg *config.RocketPoolConfig) error {
+	if cfg.Native.ManageSystemdUnits.Value != true {
+		return fmt.Errorf("In Native mode, you manage the Smartnode's services yourself.\nIf you'd like `rocketpool service` to manage them with systemd instead, enable the '%s' option in the Native Mode Settings of `rocketpool service config`.", cfg.Native.ManageSystemdUnits.Name)
+	}
+	return nil
+}
+
+// Generate the Native mode systemd units from the config, then enable and start them
+func startNativeService(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	if err := checkSystemdManagement(cfg); err != nil {
+		return err
+	}
+
+	// Validate the config
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sYour configuration encountered errors. You must correct the following in order to start Rocket Pool:\n\n", colorRed)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Println(colorReset)
+		return nil
+	}
+
+	// Install the units
+	units, err := rp.InstallSystemdUnits(cfg)
+	if err != nil {
+		return err
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.Name
+		fmt.Printf("Updated %s\n", unit.Name)
+	}
+	if cfg.Native.VcCommand.Value.(string) == "" {
+		fmt.Printf("%sNOTE: no Validator client command is set, so your Validator client is not managed by the Smartnode and must be started separately.%s\n", colorYellow, colorReset)
+	}
+
+	// Start them
+	err = rp.StartSystemdUnits(unitNames)
+	if err != nil {
+		return fmt.Errorf("error starting the systemd units: %w", err)
+	}
+	fmt.Printf("%sStarted %d services.%s\n", colorGreen, len(unitNames), colorReset)
+
+	// Remove the upgrade flag if it's there
+	return rp.RemoveUpgradeFlagFile()
+}
+
+// Stop the Native mode systemd units
+func pauseNativeService(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	if err := checkSystemdManagement(cfg); err != nil {
+		return err
+	}
+	return rp.StopSystemdUnits(cfg.GetSystemdUnitNames())
+}
+
+// Print the status of the Native mode systemd units
+func nativeServiceStatus(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	if err := checkSystemdManagement(cfg); err != nil {
+		return err
+	}
+	return rp.PrintSystemdUnitStatus(cfg.GetSystemdUnitNames())
+}
+
+// Print the logs of the Native mode systemd units, using the same service names as Docker mode
+func nativeServiceLogs(rp *rocketpool.Client, cfg *config.RocketPoolConfig, tail string, serviceNames ...string) error {
+	if err := checkSystemdManagement(cfg); err != nil {
+		return err
+	}
+	unitNames := cfg.GetSystemdUnitNames()
+	if len(serviceNames) > 0 {
+		unitNames = make([]string, len(serviceNames))
+		for i, serviceName := range serviceNames {
+			unitNames[i] = config.GetSystemdUnitName(cfgtypes.ContainerID(serviceName))
+		}
+	}
+	return rp.PrintSystemdUnitLogs(unitNames, tail)
+}
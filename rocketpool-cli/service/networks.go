@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// List the networks that have been set up on this machine
+func listNetworks(c *cli.Context) error {
+
+	// Get the config folder of each network
+	paths, err := rocketpool.GetNetworkConfigPaths(c.GlobalString("config-path"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Println("No networks have been set up yet. Run `rocketpool service install` to set up your first one.")
+		return nil
+	}
+	selectedPath, err := rocketpool.GetConfigPathFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Print them in a stable order
+	networks := []cfgtypes.Network{}
+	for network := range paths {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i] < networks[j]
+	})
+	fmt.Println("The following networks are set up on this machine:")
+	for _, network := range networks {
+		path := paths[network]
+		cfg, err := loadNetworkConfig(path)
+		if err != nil {
+			return err
+		}
+		marker := ""
+		if path == selectedPath {
+			marker = fmt.Sprintf(" %s<- selected%s", colorGreen, colorReset)
+		}
+		fmt.Printf("\t%-8s %s (containers: %s)%s\n", network, path, cfg.Smartnode.ProjectName.Value, marker)
+	}
+	fmt.Println()
+	fmt.Println("Use `rocketpool --network <network> ...` to run a command against one of them, or set the ROCKETPOOL_NETWORK environment variable to switch your shell over.")
+	fmt.Println("To set up another network, run `rocketpool --network <network> service install` followed by `rocketpool --network <network> service config`.")
+	return nil
+
+}
+
+// Make sure the node being started won't collide with the other networks set up on this machine.
+// Sharing a Docker project would make them replace each other's containers, so that's an error; sharing a host port only stops one of them from binding it, so that's a warning.
+func checkForNetworkConflicts(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	paths, err := rocketpool.GetNetworkConfigPaths(c.GlobalString("config-path"))
+	if err != nil {
+		return err
+	}
+	ports := getHostPorts(cfg)
+	for network, path := range paths {
+		if path == rp.GetConfigPath() {
+			continue
+		}
+		otherCfg, err := loadNetworkConfig(path)
+		if err != nil {
+			return err
+		}
+		if otherCfg.Smartnode.ProjectName.Value == cfg.Smartnode.ProjectName.Value {
+			return fmt.Errorf("your %s node in %s uses the same Docker project name (%s) as this one, so their containers would replace each other; please give one of them a different Project Name with `rocketpool service config`", network, path, cfg.Smartnode.ProjectName.Value)
+		}
+		for port, name := range getHostPorts(otherCfg) {
+			if ownName, exists := ports[port]; exists {
+				fmt.Printf("%sWARNING: your %s uses port %d, which your %s node in %s also uses for its %s. Only one of them can run at a time unless you change one of the ports with `rocketpool service config`.%s\n\n", colorYellow, ownName, port, network, path, name, colorReset)
+			}
+		}
+	}
+	return nil
+}
+
+// Get the ports a node binds on the host, and what uses them
+func getHostPorts(cfg *config.RocketPoolConfig) map[uint16]string {
+	ports := map[uint16]string{}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		ports[cfg.ExecutionCommon.P2pPort.Value.(uint16)] = "Execution client P2P traffic"
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		ports[cfg.ConsensusCommon.P2pPort.Value.(uint16)] = "Consensus client P2P traffic"
+	}
+	if cfg.EnableMetrics.Value == true {
+		ports[cfg.Grafana.Port.Value.(uint16)] = "Grafana dashboard"
+	}
+	return ports
+}
+
+// Load the config of a network that has been set up
+func loadNetworkConfig(path string) (*config.RocketPoolConfig, error) {
+	settingsFilePath, err := homedir.Expand(fmt.Sprintf("%s/%s", path, rocketpool.SettingsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	cfg, err := rputils.LoadConfigFromFile(settingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the settings in %s: %w", path, err)
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("the settings in %s are missing", path)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// How long to wait for the checkpoint sync provider to respond before giving up on it
+const checkpointSyncCheckTimeout time.Duration = 15 * time.Second
+
+// The response from a Beacon node's deposit contract route, used to check the network a checkpoint sync provider is on
+type checkpointSyncDepositContractResponse struct {
+	Data struct {
+		ChainID string `json:"chain_id"`
+	} `json:"data"`
+}
+
+// Destroy and resync the Execution client from scratch
+func resyncEc(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		fmt.Println("You use an externally-managed Execution client. Rocket Pool cannot resync it for you.")
+		return nil
+	}
+
+	fmt.Println("This will delete the chain data of your primary Execution client and resync it from scratch.")
+	fmt.Printf("%sYou should only do this if your Execution client has failed and can no longer start or sync properly.\nThis is meant to be a last resort.%s\n\n", colorYellow, colorReset)
+
+	// Explain how it will sync
+	fmt.Printf("%s This usually takes several hours, depending on your hardware.\n", getEcSyncDescription(cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient)))
+	printResyncFallbackWarning(cfg, "Execution")
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main Execution client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Delete the client and its data, then rebuild it
+	executionContainerName := prefix + ExecutionContainerSuffix
+	if err := deleteClientData(rp, executionContainerName, "Execution"); err != nil {
+		return err
+	}
+	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", executionContainerName)
+	err = startService(c, true)
+	if err != nil {
+		return fmt.Errorf("Error starting Rocket Pool: %s", err)
+	}
+
+	fmt.Printf("\nDone! Your main Execution client is now resyncing. You can follow its progress with `rocketpool service logs eth1` or `rocketpool node sync`.\n")
+	return nil
+
+}
+
+// Destroy and resync the Consensus client from scratch, using checkpoint sync if it's available
+func resyncCc(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the merged config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if err := rp.ConfigureHttpClients(); err != nil {
+		return err
+	}
+
+	fmt.Println("This will delete the chain data of your Consensus client and resync it from scratch.")
+	fmt.Printf("%sYou should only do this if your Consensus client has failed and can no longer start or sync properly.\nThis is meant to be a last resort.%s\n\n", colorYellow, colorReset)
+
+	// Get the parameters that the selected client doesn't support
+	var unsupportedParams []string
+	var clientName string
+	eth2ClientMode := cfg.ConsensusClientMode.Value.(cfgtypes.Mode)
+	switch eth2ClientMode {
+	case cfgtypes.Mode_Local:
+		selectedClientConfig, err := cfg.GetSelectedConsensusClientConfig()
+		if err != nil {
+			return fmt.Errorf("error getting selected consensus client config: %w", err)
+		}
+		unsupportedParams = selectedClientConfig.(cfgtypes.LocalConsensusConfig).GetUnsupportedCommonParams()
+		clientName = selectedClientConfig.GetName()
+
+	case cfgtypes.Mode_External:
+		fmt.Println("You use an externally-managed Consensus client. Rocket Pool cannot resync it for you.")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown consensus client mode [%v]", eth2ClientMode)
+	}
+
+	// Get the checkpoint sync provider to resync from
+	checkpointSyncUrl, err := getCheckpointSyncUrl(c, cfg, clientName, unsupportedParams)
+	if err != nil {
+		return err
+	}
+	printResyncFallbackWarning(cfg, "Consensus")
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main Consensus client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save the new checkpoint sync provider so the rebuilt client uses it
+	if checkpointSyncUrl != "" && checkpointSyncUrl != cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string) {
+		cfg.ConsensusCommon.CheckpointSyncProvider.Value = checkpointSyncUrl
+		if err := rp.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("Error saving the checkpoint sync provider: %w", err)
+		}
+		fmt.Println("Saved the checkpoint sync provider to your configuration.")
+	}
+
+	// Delete the client and its data, then rebuild it
+	beaconContainerName := prefix + BeaconContainerSuffix
+	if err := deleteClientData(rp, beaconContainerName, "Consensus"); err != nil {
+		return err
+	}
+	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", beaconContainerName)
+	err = startService(c, true)
+	if err != nil {
+		return fmt.Errorf("Error starting Rocket Pool: %s", err)
+	}
+
+	fmt.Printf("\nDone! Your Consensus client is now resyncing. You can follow its progress with `rocketpool service logs eth2` or `rocketpool node sync`.\n")
+	return nil
+
+}
+
+// Get the checkpoint sync provider a rebuilt Consensus client will sync from, checking that it works before any chain data is deleted.
+// This is blank if the client doesn't support checkpoint sync or there's no provider.
+func getCheckpointSyncUrl(c *cli.Context, cfg *config.RocketPoolConfig, clientName string, unsupportedParams []string) (string, error) {
+
+	// Check if the selected client supports checkpoint sync
+	supportsCheckpointSync := true
+	for _, param := range unsupportedParams {
+		if param == config.CheckpointSyncUrlID {
+			supportsCheckpointSync = false
+		}
+	}
+	checkpointSyncUrl := ""
+	if !supportsCheckpointSync {
+		if c.String("checkpoint-sync-url") != "" {
+			return "", fmt.Errorf("your Consensus client (%s) does not support checkpoint sync", clientName)
+		}
+		fmt.Printf("%sYour Consensus client (%s) does not support checkpoint sync.\nIf you have active validators, they %swill be considered offline and will leak ETH%s%s while the client is syncing.%s\n\n", colorRed, clientName, colorBold, colorReset, colorRed, colorReset)
+	} else {
+		// Use the provider from the command line if there is one, falling back to the configured one
+		checkpointSyncUrl = strings.TrimSuffix(strings.TrimSpace(c.String("checkpoint-sync-url")), "/")
+		if checkpointSyncUrl == "" {
+			checkpointSyncUrl = cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
+		}
+		if checkpointSyncUrl == "" {
+			fmt.Printf("%sYou do not have a checkpoint sync provider configured.\nIf you have active validators, they %swill be considered offline and will lose ETH%s%s until your Consensus client finishes syncing.\nWe strongly recommend you provide one with the --checkpoint-sync-url flag so it syncs instantly.%s\n\n", colorRed, colorBold, colorReset, colorRed, colorReset)
+		} else {
+			// Make sure the provider works before the existing chain data is deleted
+			fmt.Printf("Checking the checkpoint sync provider (%s)...\n", checkpointSyncUrl)
+			if err := checkCheckpointSyncProvider(checkpointSyncUrl, cfg.Smartnode.GetChainID()); err != nil {
+				return "", fmt.Errorf("the checkpoint sync provider can't be used, so your chain data was left alone: %w", err)
+			}
+			fmt.Printf("Your Consensus client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n")
+		}
+	}
+	return checkpointSyncUrl, nil
+
+}
+
+// Stop a client's container, then delete it and its chain data volume
+func deleteClientData(rp *rocketpool.Client, containerName string, clientType string) error {
+
+	// Get the volume name first, since it can't be found once the container is gone
+	volume, err := rp.GetClientVolumeName(containerName, clientDataVolumeName)
+	if err != nil {
+		return fmt.Errorf("Error getting %s client volume name: %w", clientType, err)
+	}
+
+	// Stop the client so its database is closed cleanly; the data can't be deleted while it's still running
+	fmt.Printf("Stopping %s...\n", containerName)
+	result, err := rp.StopContainer(containerName)
+	if err != nil {
+		return fmt.Errorf("Error stopping the %s client container, nothing was deleted: %w", clientType, err)
+	}
+	if result != containerName {
+		return fmt.Errorf("Unexpected output while stopping the %s client container, nothing was deleted: %s", clientType, result)
+	}
+
+	// Remove the container
+	fmt.Printf("Deleting %s...\n", containerName)
+	result, err = rp.RemoveContainer(containerName)
+	if err != nil {
+		return fmt.Errorf("Error deleting the %s client container: %w", clientType, err)
+	}
+	if result != containerName {
+		return fmt.Errorf("Unexpected output while deleting the %s client container: %s", clientType, result)
+	}
+
+	// Delete the volume
+	fmt.Printf("Deleting volume %s...\n", volume)
+	result, err = rp.DeleteVolume(volume)
+	if err != nil {
+		return fmt.Errorf("Error deleting volume: %w", err)
+	}
+	if result != volume {
+		return fmt.Errorf("Unexpected output while deleting volume: %s", result)
+	}
+	return nil
+
+}
+
+// Warn the user about their validators going offline during the resync if they don't have fallback clients
+func printResyncFallbackWarning(cfg *config.RocketPoolConfig, clientType string) {
+	if cfg.UseFallbackClients.Value == true {
+		fmt.Printf("Your fallback clients will be used while the %s client resyncs.\n\n", clientType)
+		return
+	}
+	fmt.Printf("%sYou don't have fallback clients configured, so your validators won't be able to attest until the %s client finishes resyncing.%s\n\n", colorYellow, clientType, colorReset)
+}
+
+// Make sure a checkpoint sync provider is reachable, has a finalized state to sync from, and is on the expected network
+func checkCheckpointSyncProvider(url string, expectedChainID uint) error {
+	client := *net.GetHttpClient(net.HttpTarget_Lookups)
+	client.Timeout = checkpointSyncCheckTimeout
+
+	// Check the network
+	response, err := client.Get(url + "/eth/v1/config/deposit_contract")
+	if err != nil {
+		return fmt.Errorf("error contacting the provider: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("the provider responded with %s", response.Status)
+	}
+	var depositContract checkpointSyncDepositContractResponse
+	if err := json.NewDecoder(response.Body).Decode(&depositContract); err != nil {
+		return fmt.Errorf("error decoding the provider's response: %w", err)
+	}
+	chainID, err := strconv.ParseUint(depositContract.Data.ChainID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("the provider returned an invalid chain ID '%s'", depositContract.Data.ChainID)
+	}
+	if uint(chainID) != expectedChainID {
+		return fmt.Errorf("the provider is on chain %d, but your node is on chain %d", chainID, expectedChainID)
+	}
+
+	// Check that it has a finalized state
+	finalityResponse, err := client.Get(url + "/eth/v1/beacon/states/finalized/finality_checkpoints")
+	if err != nil {
+		return fmt.Errorf("error getting the provider's finalized state: %w", err)
+	}
+	defer finalityResponse.Body.Close()
+	if finalityResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("the provider responded to the finalized state request with %s", finalityResponse.Status)
+	}
+	return nil
+}
+
+// Describe how an Execution client syncs from scratch with the settings the Smartnode runs it with
+func getEcSyncDescription(client cfgtypes.ExecutionClient) string {
+	switch client {
+	case cfgtypes.ExecutionClient_Geth:
+		return "Geth will use snap sync to download the latest state from its peers instead of replaying the whole chain."
+	case cfgtypes.ExecutionClient_Nethermind:
+		return "Nethermind will use snap sync to download the latest state from its peers, then download the older blocks and receipts in the background."
+	case cfgtypes.ExecutionClient_Besu:
+		return "Besu will download the latest state from its peers with its default sync mode instead of replaying the whole chain."
+	default:
+		return fmt.Sprintf("Your Execution client (%s) will sync using its default sync mode.", client)
+	}
+}
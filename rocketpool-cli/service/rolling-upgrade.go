@@ -0,0 +1,255 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	defaultGateTimeout     string        = "30m"
+	gatePollInterval       time.Duration = 15 * time.Second
+	attestationGateRefresh time.Duration = time.Minute
+)
+
+// A component that's restarted on its own during a rolling upgrade, followed by a check that it's healthy
+type rollingUpgradeStep struct {
+	service   cfgtypes.ContainerID
+	suffix    string
+	gateName  string
+	checkGate func(rp *rocketpool.Client, container string, timeout time.Duration) error
+}
+
+// The image a container ran before the upgrade; the ID is what it's rolled back to, since pulling moves the tag to the new image
+type previousImage struct {
+	tag string
+	id  string
+}
+
+// Start the service by upgrading one component at a time, waiting for each to be healthy before moving on to the next.
+// Components that fail their health gate are rolled back to the image they used before the upgrade.
+func startServiceRolling(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	// Get the settings
+	gateTimeout, err := time.ParseDuration(c.String("gate-timeout"))
+	if err != nil {
+		return fmt.Errorf("Invalid gate timeout '%s': %w", c.String("gate-timeout"), err)
+	}
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return err
+	}
+	composeFiles := getComposeFiles(c)
+
+	// Build the steps; the API has to come first since the other gates depend on it
+	steps := []rollingUpgradeStep{
+		{service: cfgtypes.ContainerID_Api, suffix: ApiContainerSuffix, gateName: "API responding", checkGate: waitForApi},
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		steps = append(steps, rollingUpgradeStep{service: cfgtypes.ContainerID_Eth1, suffix: ExecutionContainerSuffix, gateName: "Execution client synced", checkGate: waitForEcSync})
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		steps = append(steps, rollingUpgradeStep{service: cfgtypes.ContainerID_Eth2, suffix: BeaconContainerSuffix, gateName: "Beacon node synced", checkGate: waitForBcSync})
+	}
+	steps = append(steps, rollingUpgradeStep{service: cfgtypes.ContainerID_Validator, suffix: ValidatorContainerSuffix, gateName: "Validator client attesting", checkGate: waitForAttestations})
+
+	// Record the images the containers are running before pulling, so they can be rolled back to them
+	previousImages := make([]previousImage, len(steps))
+	for i, step := range steps {
+		container := prefix + step.suffix
+		previousImages[i].tag, _ = rp.GetDockerImage(container)
+		previousImages[i].id, _ = rp.GetDockerImageID(container)
+	}
+
+	// Download the new images first so each component is only down for as long as it takes to restart
+	fmt.Println("Pulling the latest images...")
+	err = rp.PullServiceImages(composeFiles)
+	if err != nil {
+		return fmt.Errorf("Error pulling images: %w", err)
+	}
+
+	for i, step := range steps {
+		container := prefix + step.suffix
+		fmt.Printf("%s[%d/%d] Upgrading %s...%s\n", colorLightBlue, i+1, len(steps)+1, step.service, colorReset)
+
+		// Get the container's current state so it can be rolled back
+		previousImage := previousImages[i]
+		previousID, _ := rp.GetDockerContainerID(container)
+
+		// Restart it with the new settings
+		err = rp.StartServiceContainers(composeFiles, string(step.service))
+		if err != nil {
+			return rollBackStep(rp, composeFiles, step, previousImage, fmt.Errorf("error starting %s: %w", step.service, err))
+		}
+		newID, err := rp.GetDockerContainerID(container)
+		if err == nil && previousID != "" && newID == previousID {
+			fmt.Printf("%s is already up to date.\n\n", step.service)
+			continue
+		}
+
+		// Wait for the health gate
+		fmt.Printf("Waiting for gate: %s (timeout %s)...\n", step.gateName, gateTimeout)
+		err = step.checkGate(rp, container, gateTimeout)
+		if err != nil {
+			return rollBackStep(rp, composeFiles, step, previousImage, fmt.Errorf("%s failed its health gate (%s): %w", step.service, step.gateName, err))
+		}
+		fmt.Printf("%sGate passed: %s.%s\n\n", colorGreen, step.gateName, colorReset)
+	}
+
+	// Start everything else
+	fmt.Printf("%s[%d/%d] Upgrading the remaining containers...%s\n", colorLightBlue, len(steps)+1, len(steps)+1, colorReset)
+	err = rp.StartService(composeFiles)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sRolling upgrade complete.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Roll a failed step back to its previous image, and stop the upgrade
+func rollBackStep(rp *rocketpool.Client, composeFiles []string, step rollingUpgradeStep, previous previousImage, cause error) error {
+	fmt.Printf("%s%s%s\n", colorRed, cause.Error(), colorReset)
+	if previous.id == "" {
+		return fmt.Errorf("%w\nThere was no previous %s container to roll back to, so the upgrade has been stopped.", cause, step.service)
+	}
+
+	description := fmt.Sprintf("%s (%s)", previous.tag, previous.id)
+	fmt.Printf("Rolling %s back to %s...\n", step.service, description)
+	err := rp.StartServiceContainerWithImage(composeFiles, string(step.service), previous.id)
+	if err != nil {
+		return fmt.Errorf("%w\nRolling %s back to %s also failed: %s\nPlease check its logs with `rocketpool service logs %s`.", cause, step.service, description, err.Error(), step.service)
+	}
+	return fmt.Errorf("%w\n%s was rolled back to %s and the rest of the upgrade has been stopped; containers that weren't upgraded yet are still on their old versions.\nCheck its logs with `rocketpool service logs %s`. Running `rocketpool service start` again will retry the upgrade.", cause, step.service, description, step.service)
+}
+
+// Wait for the API container to respond to commands
+func waitForApi(rp *rocketpool.Client, container string, timeout time.Duration) error {
+	return waitForGate(rp, container, timeout, func() (bool, string) {
+		_, err := rp.GetServiceVersion()
+		if err != nil {
+			return false, fmt.Sprintf("not responding yet (%s)", err.Error())
+		}
+		return true, ""
+	})
+}
+
+// Wait for the primary Execution client to be synced
+func waitForEcSync(rp *rocketpool.Client, container string, timeout time.Duration) error {
+	return waitForGate(rp, container, timeout, func() (bool, string) {
+		response, err := rp.GetClientStatus()
+		if err != nil {
+			return false, fmt.Sprintf("couldn't get status (%s)", err.Error())
+		}
+		status := response.EcManagerStatus.PrimaryClientStatus
+		if !status.IsSynced {
+			return false, getGateClientStatus(status.IsWorking, status.SyncProgress, status.Error)
+		}
+		return true, ""
+	})
+}
+
+// Wait for the primary Beacon node to be synced
+func waitForBcSync(rp *rocketpool.Client, container string, timeout time.Duration) error {
+	return waitForGate(rp, container, timeout, func() (bool, string) {
+		response, err := rp.GetClientStatus()
+		if err != nil {
+			return false, fmt.Sprintf("couldn't get status (%s)", err.Error())
+		}
+		status := response.BcManagerStatus.PrimaryClientStatus
+		if !status.IsSynced {
+			return false, getGateClientStatus(status.IsWorking, status.SyncProgress, status.Error)
+		}
+		return true, ""
+	})
+}
+
+// Wait for the node's active validators to earn attestation rewards again.
+// Balances change once per epoch, and the first change after a restart can still include the attestations that were
+// missed while the validator client was down, so the gate passes on the first increase after that.
+func waitForAttestations(rp *rocketpool.Client, container string, timeout time.Duration) error {
+	var lastBalance *big.Int
+	balanceChanges := 0
+	lastCheck := time.Time{}
+	return waitForGate(rp, container, timeout, func() (bool, string) {
+		if lastBalance != nil && time.Since(lastCheck) < attestationGateRefresh {
+			return false, fmt.Sprintf("waiting for the next epoch (%d balance change(s) seen)", balanceChanges)
+		}
+		lastCheck = time.Now()
+
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return false, fmt.Sprintf("couldn't get minipool status (%s)", err.Error())
+		}
+		balance := big.NewInt(0)
+		activeValidators := 0
+		for _, minipool := range status.Minipools {
+			if minipool.Validator.Exists && minipool.Validator.Active && minipool.Validator.Balance != nil {
+				balance.Add(balance, minipool.Validator.Balance)
+				activeValidators++
+			}
+		}
+		if activeValidators == 0 {
+			fmt.Println("The node doesn't have any active validators, so there are no attestations to check.")
+			return true, ""
+		}
+
+		if lastBalance == nil {
+			lastBalance = balance
+			return false, "waiting for the next epoch"
+		}
+		switch balance.Cmp(lastBalance) {
+		case 0:
+			return false, fmt.Sprintf("waiting for the next epoch (%d balance change(s) seen)", balanceChanges)
+		case 1:
+			balanceChanges++
+			if balanceChanges > 1 {
+				return true, ""
+			}
+		default:
+			balanceChanges++
+		}
+		lastBalance = balance
+		return false, fmt.Sprintf("waiting for attestation rewards (%d balance change(s) seen)", balanceChanges)
+	})
+}
+
+// Poll a health check until it passes, the container stops running, or the timeout expires
+func waitForGate(rp *rocketpool.Client, container string, timeout time.Duration, check func() (bool, string)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := rp.GetDockerStatus(container)
+		if err != nil {
+			return fmt.Errorf("error getting the status of %s: %w", container, err)
+		}
+		if status != "running" {
+			return fmt.Errorf("%s is %s instead of running", container, status)
+		}
+
+		passed, progress := check()
+		if passed {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s: %s", timeout, progress)
+		}
+		fmt.Printf("%s\r\t%s", clearLine, progress)
+		time.Sleep(gatePollInterval)
+		fmt.Printf("%s\r", clearLine)
+	}
+}
+
+// Describe a client's progress towards a sync gate
+func getGateClientStatus(isWorking bool, syncProgress float64, errorMessage string) string {
+	if isWorking {
+		return fmt.Sprintf("syncing (%.2f%%)", syncProgress*100)
+	}
+	return fmt.Sprintf("unavailable (%s)", errorMessage)
+}
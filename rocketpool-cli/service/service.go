@@ -88,8 +88,17 @@ func installService(c *cli.Context) error {
 		}
 	}
 
+	// Install into the selected network's own folder if it isn't using the default one
+	installPath := c.String("path")
+	if installPath == "" && rp.GetConfigPath() != c.GlobalString("config-path") {
+		installPath, err = homedir.Expand(rp.GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("error expanding install path: %w", err)
+		}
+	}
+
 	// Install service
-	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), c.String("path"), dataPath)
+	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), installPath, dataPath)
 	if err != nil {
 		return err
 	}
@@ -150,13 +159,13 @@ ______           _        _    ______           _
 	fmt.Printf("Changes you should be aware of before starting:\n\n")
 
 	fmt.Printf("%s=== Atlas and Shapella ===%s\n", colorGreen, colorReset)
-	fmt.Println("This version has support for both the Atlas and Shapella network upgrades, bringing Beacon reward withdrawals, 8-ETH minipools, solo staker migration, and more! For more info, please read the Atlas guide:\nhttps://docs.rocketpool.net/guides/atlas/whats-new.html\n")
+	fmt.Print("This version has support for both the Atlas and Shapella network upgrades, bringing Beacon reward withdrawals, 8-ETH minipools, solo staker migration, and more! For more info, please read the Atlas guide:\nhttps://docs.rocketpool.net/guides/atlas/whats-new.html\n\n")
 
 	fmt.Printf("%s=== Nimbus Changes ===%s\n", colorGreen, colorReset)
-	fmt.Println("Nimbus now supports running a separate Validator Client, which means it now supports fallback clients! If you're using Nimbus and would like to set up a fallback client pair for your node, simply go to the Consensus Client section of the `service config` TUI - you can now add one just like with the other clients!\nNote that if you want to check on your validator performance, you will need to look at the validator container instead of the eth2 container like you used to do.\n")
+	fmt.Print("Nimbus now supports running a separate Validator Client, which means it now supports fallback clients! If you're using Nimbus and would like to set up a fallback client pair for your node, simply go to the Consensus Client section of the `service config` TUI - you can now add one just like with the other clients!\nNote that if you want to check on your validator performance, you will need to look at the validator container instead of the eth2 container like you used to do.\n\n")
 
 	fmt.Printf("%s=== Lodestar ===%s\n", colorGreen, colorReset)
-	fmt.Println("The Smartnode now supports Lodestar - the Ethereum Consensus Client written in Typescript! If you'd like to switch to Lodestar, simply follow the instructions for changing consensus clients: https://docs.rocketpool.net/guides/node/change-clients.html#changing-consensus-clients\n")
+	fmt.Print("The Smartnode now supports Lodestar - the Ethereum Consensus Client written in Typescript! If you'd like to switch to Lodestar, simply follow the instructions for changing consensus clients: https://docs.rocketpool.net/guides/node/change-clients.html#changing-consensus-clients\n\n")
 
 	fmt.Printf("%s=== Much, Much More ===%s\n", colorGreen, colorReset)
 	fmt.Println("There's just too much to fit into this little highlight section! Please see the official release on GitHub for the full rundown of changes.")
@@ -215,6 +224,13 @@ func serviceStatus(c *cli.Context) error {
 	}
 
 	// Print service status
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.IsNativeMode {
+		return nativeServiceStatus(rp, cfg)
+	}
 	return rp.PrintServiceStatus(getComposeFiles(c))
 
 }
@@ -223,7 +239,10 @@ func serviceStatus(c *cli.Context) error {
 func configureService(c *cli.Context) error {
 
 	// Make sure the config directory exists first
-	configPath := c.GlobalString("config-path")
+	configPath, err := rocketpool.GetConfigPathFromCtx(c)
+	if err != nil {
+		return err
+	}
 	path, err := homedir.Expand(configPath)
 	if err != nil {
 		return fmt.Errorf("error expanding config path [%s]: %w", configPath, err)
@@ -268,6 +287,11 @@ func configureService(c *cli.Context) error {
 		return fmt.Errorf("error checking for first-run status: %w", err)
 	}
 
+	// Show what the upgrade changed, and what it couldn't carry over, before the settings are saved again
+	if !isNew {
+		printConfigMigrationNotes(cfg)
+	}
+
 	// For migrations and upgrades, move the config to the old one and create a new upgraded copy
 	if isMigration || isUpdate {
 		oldCfg = cfg
@@ -338,6 +362,7 @@ func configureService(c *cli.Context) error {
 
 		// Query for service start if this is a new installation
 		if isNew {
+			fmt.Println("New to running a node? Once your services are up, run `rocketpool learn` for a guided tour of your node's status.")
 			if !cliutils.Confirm("Would you like to start the Smartnode services automatically now?") {
 				fmt.Println("Please run `rocketpool service start` when you are ready to launch.")
 				return nil
@@ -575,6 +600,7 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return fmt.Errorf("error checking for first-run status: %w", err)
 	}
 	if isUpdate && !ignoreConfigSuggestion {
+		printConfigMigrationNotes(cfg)
 		if c.Bool("yes") || cliutils.Confirm("Smartnode upgrade detected - starting will overwrite certain settings with the latest defaults (such as container versions).\nYou may want to run `service config` first to see what's changed.\n\nWould you like to continue starting the service?") {
 			err = cfg.UpdateDefaults()
 			if err != nil {
@@ -588,6 +614,11 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		}
 	}
 
+	// Native mode uses systemd units instead of containers
+	if cfg.IsNativeMode {
+		return startNativeService(rp, cfg)
+	}
+
 	// Update the Prometheus template with the assigned ports
 	metricsEnabled := cfg.EnableMetrics.Value.(bool)
 	if metricsEnabled {
@@ -608,6 +639,12 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return nil
 	}
 
+	// Make sure it won't collide with the nodes for other networks on this machine
+	err = checkForNetworkConflicts(c, rp, cfg)
+	if err != nil {
+		return err
+	}
+
 	if !c.Bool("ignore-slash-timer") {
 		// Do the client swap check
 		err := checkForValidatorChange(rp, cfg)
@@ -615,8 +652,8 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 			fmt.Printf("%sWARNING: couldn't verify that the validator container can be safely restarted:\n\t%s\n", colorYellow, err.Error())
 			fmt.Println("If you are changing to a different ETH2 client, it may resubmit an attestation you have already submitted.")
 			fmt.Println("This will slash your validator!")
-			fmt.Println("To prevent slashing, you must wait 15 minutes from the time you stopped the clients before starting them again.\n")
-			fmt.Println("**If you did NOT change clients, you can safely ignore this warning.**\n")
+			fmt.Print("To prevent slashing, you must wait 15 minutes from the time you stopped the clients before starting them again.\n\n")
+			fmt.Print("**If you did NOT change clients, you can safely ignore this warning.**\n\n")
 			if !cliutils.Confirm(fmt.Sprintf("Press y when you understand the above warning, have waited, and are ready to start Rocket Pool:%s", colorReset)) {
 				fmt.Println("Cancelled.")
 				return nil
@@ -674,7 +711,11 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 	}
 
 	// Start service
-	err = rp.StartService(getComposeFiles(c))
+	if c.Bool("rolling") {
+		err = startServiceRolling(c, rp, cfg)
+	} else {
+		err = rp.StartService(getComposeFiles(c))
+	}
 	if err != nil {
 		return err
 	}
@@ -1069,7 +1110,7 @@ func pruneExecutionClient(c *cli.Context) error {
 	}
 
 	fmt.Println("This will shut down your main execution client and prune its database, freeing up disk space.")
-	fmt.Println("Once pruning is complete, your execution client will restart automatically.\n")
+	fmt.Print("Once pruning is complete, your execution client will restart automatically.\n\n")
 
 	if selectedEc == cfgtypes.ExecutionClient_Geth {
 		if cfg.UseFallbackClients.Value == false {
@@ -1189,6 +1230,15 @@ func pauseService(c *cli.Context) error {
 		return err
 	}
 
+	// Native mode uses systemd units instead of containers
+	if cfg.IsNativeMode {
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to stop the Rocket Pool services? Any staking minipools will be penalized!")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return pauseNativeService(rp, cfg)
+	}
+
 	// Write a note on doppelganger protection
 	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
 	if err != nil {
@@ -1225,7 +1275,7 @@ func terminateService(c *cli.Context) error {
 	defer rp.Close()
 
 	// Stop service
-	return rp.TerminateService(getComposeFiles(c), c.GlobalString("config-path"))
+	return rp.TerminateService(getComposeFiles(c), rp.GetConfigPath())
 
 }
 
@@ -1240,6 +1290,13 @@ func serviceLogs(c *cli.Context, serviceNames ...string) error {
 	defer rp.Close()
 
 	// Print service logs
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.IsNativeMode {
+		return nativeServiceLogs(rp, cfg, c.String("tail"), serviceNames...)
+	}
 	return rp.PrintServiceLogs(getComposeFiles(c), c.String("tail"), serviceNames...)
 
 }
@@ -1396,214 +1453,6 @@ func getComposeFiles(c *cli.Context) []string {
 	return c.Parent().StringSlice("compose-file")
 }
 
-// Destroy and resync the eth1 client from scratch
-func resyncEth1(c *cli.Context) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Get the config
-	_, isNew, err := rp.LoadConfig()
-	if err != nil {
-		return err
-	}
-	if isNew {
-		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
-	}
-
-	fmt.Println("This will delete the chain data of your primary ETH1 client and resync it from scratch.")
-	fmt.Printf("%sYou should only do this if your ETH1 client has failed and can no longer start or sync properly.\nThis is meant to be a last resort.%s\n", colorYellow, colorReset)
-
-	// Get the container prefix
-	prefix, err := getContainerPrefix(rp)
-	if err != nil {
-		return fmt.Errorf("Error getting container prefix: %w", err)
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main ETH1 client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Stop ETH1
-	executionContainerName := prefix + ExecutionContainerSuffix
-	fmt.Printf("Stopping %s...\n", executionContainerName)
-	result, err := rp.StopContainer(executionContainerName)
-	if err != nil {
-		fmt.Printf("%sWARNING: Stopping main ETH1 container failed: %s%s\n", colorYellow, err.Error(), colorReset)
-	}
-	if result != executionContainerName {
-		fmt.Printf("%sWARNING: Unexpected output while stopping main ETH1 container: %s%s\n", colorYellow, result, colorReset)
-	}
-
-	// Get ETH1 volume name
-	volume, err := rp.GetClientVolumeName(executionContainerName, clientDataVolumeName)
-	if err != nil {
-		return fmt.Errorf("Error getting ETH1 volume name: %w", err)
-	}
-
-	// Remove ETH1
-	fmt.Printf("Deleting %s...\n", executionContainerName)
-	result, err = rp.RemoveContainer(executionContainerName)
-	if err != nil {
-		return fmt.Errorf("Error deleting main ETH1 container: %w", err)
-	}
-	if result != executionContainerName {
-		return fmt.Errorf("Unexpected output while deleting main ETH1 container: %s", result)
-	}
-
-	// Delete the ETH1 volume
-	fmt.Printf("Deleting volume %s...\n", volume)
-	result, err = rp.DeleteVolume(volume)
-	if err != nil {
-		return fmt.Errorf("Error deleting volume: %w", err)
-	}
-	if result != volume {
-		return fmt.Errorf("Unexpected output while deleting volume: %s", result)
-	}
-
-	// Restart Rocket Pool
-	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", executionContainerName)
-	err = startService(c, true)
-	if err != nil {
-		return fmt.Errorf("Error starting Rocket Pool: %s", err)
-	}
-
-	fmt.Printf("\nDone! Your main ETH1 client is now resyncing. You can follow its progress with `rocketpool service logs eth1`.\n")
-
-	return nil
-
-}
-
-// Destroy and resync the eth2 client from scratch
-func resyncEth2(c *cli.Context) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Get the merged config
-	cfg, isNew, err := rp.LoadConfig()
-	if err != nil {
-		return err
-	}
-	if isNew {
-		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
-	}
-
-	fmt.Println("This will delete the chain data of your ETH2 client and resync it from scratch.")
-	fmt.Printf("%sYou should only do this if your ETH2 client has failed and can no longer start or sync properly.\nThis is meant to be a last resort.%s\n\n", colorYellow, colorReset)
-
-	// Get the parameters that the selected client doesn't support
-	var unsupportedParams []string
-	var clientName string
-	eth2ClientMode := cfg.ConsensusClientMode.Value.(cfgtypes.Mode)
-	switch eth2ClientMode {
-	case cfgtypes.Mode_Local:
-		selectedClientConfig, err := cfg.GetSelectedConsensusClientConfig()
-		if err != nil {
-			return fmt.Errorf("error getting selected consensus client config: %w", err)
-		}
-		unsupportedParams = selectedClientConfig.(cfgtypes.LocalConsensusConfig).GetUnsupportedCommonParams()
-		clientName = selectedClientConfig.GetName()
-
-	case cfgtypes.Mode_External:
-		fmt.Println("You use an externally-managed Consensus client. Rocket Pool cannot resync it for you.")
-		return nil
-
-	default:
-		return fmt.Errorf("unknown consensus client mode [%v]", eth2ClientMode)
-	}
-
-	// Check if the selected client supports checkpoint sync
-	supportsCheckpointSync := true
-	for _, param := range unsupportedParams {
-		if param == config.CheckpointSyncUrlID {
-			supportsCheckpointSync = false
-		}
-	}
-	if !supportsCheckpointSync {
-		fmt.Printf("%sYour ETH2 client (%s) does not support checkpoint sync.\nIf you have active validators, they %swill be considered offline and will leak ETH%s%s while the client is syncing.%s\n\n", colorRed, clientName, colorBold, colorReset, colorRed, colorReset)
-	} else {
-		// Get the current checkpoint sync URL
-		checkpointSyncUrl := cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
-		if checkpointSyncUrl == "" {
-			fmt.Printf("%sYou do not have a checkpoint sync provider configured.\nIf you have active validators, they %swill be considered offline and will lose ETH%s%s until your ETH2 client finishes syncing.\nWe strongly recommend you configure a checkpoint sync provider with `rocketpool service config` so it syncs instantly before running this.%s\n\n", colorRed, colorBold, colorReset, colorRed, colorReset)
-		} else {
-			fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour ETH2 client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n", checkpointSyncUrl)
-		}
-	}
-
-	// Get the container prefix
-	prefix, err := getContainerPrefix(rp)
-	if err != nil {
-		return fmt.Errorf("Error getting container prefix: %w", err)
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main ETH2 client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Stop ETH2
-	beaconContainerName := prefix + BeaconContainerSuffix
-	fmt.Printf("Stopping %s...\n", beaconContainerName)
-	result, err := rp.StopContainer(beaconContainerName)
-	if err != nil {
-		fmt.Printf("%sWARNING: Stopping ETH2 container failed: %s%s\n", colorYellow, err.Error(), colorReset)
-	}
-	if result != beaconContainerName {
-		fmt.Printf("%sWARNING: Unexpected output while stopping ETH2 container: %s%s\n", colorYellow, result, colorReset)
-	}
-
-	// Get ETH2 volume name
-	volume, err := rp.GetClientVolumeName(beaconContainerName, clientDataVolumeName)
-	if err != nil {
-		return fmt.Errorf("Error getting ETH2 volume name: %w", err)
-	}
-
-	// Remove ETH2
-	fmt.Printf("Deleting %s...\n", beaconContainerName)
-	result, err = rp.RemoveContainer(beaconContainerName)
-	if err != nil {
-		return fmt.Errorf("Error deleting ETH2 container: %w", err)
-	}
-	if result != beaconContainerName {
-		return fmt.Errorf("Unexpected output while deleting ETH2 container: %s", result)
-	}
-
-	// Delete the ETH2 volume
-	fmt.Printf("Deleting volume %s...\n", volume)
-	result, err = rp.DeleteVolume(volume)
-	if err != nil {
-		return fmt.Errorf("Error deleting volume: %w", err)
-	}
-	if result != volume {
-		return fmt.Errorf("Unexpected output while deleting volume: %s", result)
-	}
-
-	// Restart Rocket Pool
-	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", beaconContainerName)
-	err = startService(c, true)
-	if err != nil {
-		return fmt.Errorf("Error starting Rocket Pool: %s", err)
-	}
-
-	fmt.Printf("\nDone! Your ETH2 client is now resyncing. You can follow its progress with `rocketpool service logs eth2`.\n")
-
-	return nil
-
-}
-
 // Generate a YAML file that shows the current configuration schema, including all of the parameters and their descriptions
 func getConfigYaml(c *cli.Context) error {
 	cfg := config.NewRocketPoolConfig("", false)
@@ -1654,7 +1503,7 @@ func exportEcData(c *cli.Context, targetDir string) error {
 
 	fmt.Println("This will export your execution client's chain data to an external directory, such as a portable hard drive.")
 	fmt.Println("If your execution client is running, it will be shut down.")
-	fmt.Println("Once the export is complete, your execution client will restart automatically.\n")
+	fmt.Print("Once the export is complete, your execution client will restart automatically.\n\n")
 
 	// Get the container prefix
 	prefix, err := getContainerPrefix(rp)
@@ -1780,7 +1629,7 @@ func importEcData(c *cli.Context, sourceDir string) error {
 
 	fmt.Println("This will import execution layer chain data that you previously exported into your execution client.")
 	fmt.Println("If your execution client is running, it will be shut down.")
-	fmt.Println("Once the import is complete, your execution client will restart automatically.\n")
+	fmt.Print("Once the import is complete, your execution client will restart automatically.\n\n")
 
 	// Get the volume to import into
 	executionContainerName := prefix + ExecutionContainerSuffix
@@ -1905,3 +1754,50 @@ func checkCpuFeatures() error {
 	fmt.Println("Your CPU supports all required features for 'modern' images.")
 	return nil
 }
+
+// Print the config migrations that were applied when the settings were loaded, and any settings they didn't recognize
+func printConfigMigrationNotes(cfg *config.RocketPoolConfig) {
+	if len(cfg.AppliedMigrations) > 0 {
+		fmt.Printf("Your settings were upgraded to schema version %d:\n", cfg.SchemaVersion)
+		for _, migration := range cfg.AppliedMigrations {
+			fmt.Printf("\t- %s\n", migration)
+		}
+		fmt.Println("You can go back to your previous settings with `rocketpool service rollback-config` if you need to.")
+		fmt.Println()
+	}
+	if len(cfg.UnrecognizedSettings) > 0 {
+		fmt.Printf("%sThe following settings in your settings file aren't used by this version of the Smartnode, and won't be kept when it's saved:\n", colorYellow)
+		for _, setting := range cfg.UnrecognizedSettings {
+			fmt.Printf("\t- %s\n", setting)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+}
+
+// Restore the settings from before the last upgrade
+func rollbackConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("This will replace your current settings with the ones from before your last Smartnode upgrade. Your current settings will be kept as the backup, so you can switch back by running this command again. Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Roll back
+	previousVersion, err := rp.RollbackConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored the settings written by Smartnode %s.\n", previousVersion)
+	fmt.Printf("%sIf you're downgrading, reinstall that version of the Smartnode before running `rocketpool service start`; otherwise your settings will be upgraded again the next time they're loaded.%s\n", colorYellow, colorReset)
+	return nil
+
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+const (
+	// Sigma Prime's blockprint API, which classifies the Consensus client that proposed each block on mainnet
+	clientDiversityApiUrl string = "https://api.blockprint.sigp.io"
+
+	// How many epochs of blocks the client shares are taken from (about a day)
+	clientDiversityEpochs uint64 = 225
+
+	// How long to wait for the client diversity API before giving up on it
+	clientDiversityTimeout time.Duration = 15 * time.Second
+
+	// The share at which a client's bug could stop the chain from finalizing
+	clientDiversityMajorityShare float64 = 1.0 / 3.0
+)
+
+// The response from blockprint's sync status route
+type blockprintSyncStatus struct {
+	GreatestBlockSlot uint64 `json:"greatest_block_slot"`
+	Synced            bool   `json:"synced"`
+}
+
+// A Consensus client's share of the blocks proposed on mainnet
+type clientShare struct {
+	Client cfgtypes.ConsensusClient
+	Name   string
+	Share  float64
+}
+
+// Show the Consensus client diversity, then switch to a minority Consensus client (and optionally a different Execution client)
+func switchClients(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if err := rp.ConfigureHttpClients(); err != nil {
+		return err
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("The Smartnode doesn't manage your clients in Native mode, so it can't switch them for you.")
+		return nil
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		fmt.Println("You use an externally-managed Consensus client. Rocket Pool cannot switch it for you.")
+		return nil
+	}
+	currentCc := cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	currentEc := cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient)
+
+	// Get the new Execution client if one was requested
+	newEc := currentEc
+	if c.String("execution-client") != "" {
+		if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+			return fmt.Errorf("you use an externally-managed Execution client, so Rocket Pool cannot switch it for you")
+		}
+		newEc, err = getSwitchableExecutionClient(cfg, c.String("execution-client"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Show the client diversity
+	shares, err := getConsensusClientShares(cfg)
+	if err != nil {
+		fmt.Printf("%sCouldn't get the current client diversity from %s: %s\nThe clients won't be ranked by how widely they're used.%s\n\n", colorYellow, clientDiversityApiUrl, err.Error(), colorReset)
+	} else {
+		printConsensusClientShares(shares, currentCc)
+	}
+
+	// Get the new Consensus client
+	var newCc cfgtypes.ConsensusClient
+	if c.String("consensus-client") != "" {
+		newCc, err = getSwitchableConsensusClient(cfg, c.String("consensus-client"))
+		if err != nil {
+			return err
+		}
+	} else {
+		newCc, err = selectConsensusClient(c, cfg, shares, currentCc)
+		if err != nil {
+			return err
+		}
+	}
+	if newCc == currentCc && newEc == currentEc {
+		fmt.Println("You're already using the selected clients, so there's nothing to switch.")
+		return nil
+	}
+
+	// Apply the new clients to the config so the new Consensus client's settings can be checked, but don't save them yet
+	cfg.ConsensusClient.Value = newCc
+	cfg.ExecutionClient.Value = newEc
+
+	// Get the checkpoint sync provider the new Consensus client will sync from
+	checkpointSyncUrl := ""
+	if newCc != currentCc {
+		selectedClientConfig, err := cfg.GetSelectedConsensusClientConfig()
+		if err != nil {
+			return fmt.Errorf("error getting selected consensus client config: %w", err)
+		}
+		unsupportedParams := selectedClientConfig.(cfgtypes.LocalConsensusConfig).GetUnsupportedCommonParams()
+		checkpointSyncUrl, err = getCheckpointSyncUrl(c, cfg, selectedClientConfig.GetName(), unsupportedParams)
+		if err != nil {
+			return err
+		}
+		if checkpointSyncUrl != "" {
+			cfg.ConsensusCommon.CheckpointSyncProvider.Value = checkpointSyncUrl
+		}
+	}
+
+	// Make sure the new config is valid before anything is deleted
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sThe new clients can't be used with your current configuration, so nothing was changed. Please correct the following with `rocketpool service config` first:\n\n", colorRed)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Println(colorReset)
+		return nil
+	}
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+
+	// Explain what's going to happen
+	fmt.Println("The Smartnode will switch your clients with the following steps:")
+	step := 1
+	if newCc != currentCc {
+		fmt.Printf("\t%d. Stop your Validator client, starting the 15 minute anti-slashing delay\n", step)
+		step++
+		fmt.Printf("\t%d. Delete %s and its chain data\n", step, currentCc)
+		step++
+	}
+	if newEc != currentEc {
+		fmt.Printf("\t%d. Delete %s and its chain data\n", step, currentEc)
+		step++
+	}
+	fmt.Printf("\t%d. Save the new clients to your configuration\n", step)
+	step++
+	if newCc != currentCc {
+		fmt.Printf("\t%d. Wait out the anti-slashing delay, then start Rocket Pool with the new clients\n", step)
+		step++
+		fmt.Printf("\t%d. Rebuild your validator keys for the new Validator client\n\n", step)
+	} else {
+		fmt.Printf("\t%d. Start Rocket Pool with the new client\n\n", step)
+	}
+
+	if newCc != currentCc {
+		fmt.Printf("Your Consensus client will change from %s to %s.\n", currentCc, newCc)
+		fmt.Printf("%sYour validators won't be able to attest for at least 15 minutes while the Validator client is switched.%s\n", colorYellow, colorReset)
+	}
+	if newEc != currentEc {
+		fmt.Printf("Your Execution client will change from %s to %s. It will have to sync from scratch, which usually takes several hours.\n", currentEc, newEc)
+	}
+	printResyncFallbackWarning(cfg, "new")
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to switch clients? Your old clients' chain data will be deleted and this cannot be undone!%s", colorRed, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the Validator client first so the anti-slashing delay runs while the old Consensus client is removed
+	if newCc != currentCc {
+		validatorContainerName := prefix + ValidatorContainerSuffix
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		result, err := rp.StopContainer(validatorContainerName)
+		if err != nil {
+			return fmt.Errorf("Error stopping the Validator client container, nothing was changed: %w", err)
+		}
+		if result != validatorContainerName {
+			return fmt.Errorf("Unexpected output while stopping the Validator client container, nothing was changed: %s", result)
+		}
+	}
+
+	// Delete the old clients and their data
+	if newCc != currentCc {
+		if err := deleteClientData(rp, prefix+BeaconContainerSuffix, "Consensus"); err != nil {
+			return err
+		}
+	}
+	if newEc != currentEc {
+		if err := deleteClientData(rp, prefix+ExecutionContainerSuffix, "Execution"); err != nil {
+			return err
+		}
+	}
+
+	// Save the new clients
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the new clients to your configuration: %w", err)
+	}
+	fmt.Println("Saved the new clients to your configuration.")
+
+	// Start Rocket Pool, which waits out the anti-slashing delay
+	fmt.Println("Starting Rocket Pool with the new clients...")
+	err = startService(c, true)
+	if err != nil {
+		return fmt.Errorf("Error starting Rocket Pool: %s", err)
+	}
+
+	if newCc == currentCc {
+		fmt.Printf("\nDone! Your new Execution client is now syncing. You can follow its progress with `rocketpool node sync`.\n")
+		return nil
+	}
+
+	// Rebuild the validator keys for the new Validator client
+	fmt.Println("Rebuilding your validator keys for the new Validator client...")
+	rebuildResponse, err := rp.RebuildWallet()
+	if err != nil {
+		fmt.Printf("%sCouldn't rebuild your validator keys: %s\nPlease run `rocketpool wallet rebuild` once the Smartnode is running so the new Validator client can attest.%s\n", colorRed, err.Error(), colorReset)
+	} else if len(rebuildResponse.ValidatorKeys) == 0 {
+		fmt.Println("You don't have any validator keys to rebuild.")
+	} else {
+		fmt.Printf("Rebuilt %d validator keys for the new Validator client.\n", len(rebuildResponse.ValidatorKeys))
+	}
+
+	fmt.Printf("\nDone! Your new clients are now syncing. You can follow their progress with `rocketpool node sync`.\n")
+	return nil
+
+}
+
+// Get the share of the blocks proposed on mainnet by each Consensus client the Smartnode supports, from the largest to the smallest
+func getConsensusClientShares(cfg *config.RocketPoolConfig) ([]clientShare, error) {
+	client := *net.GetHttpClient(net.HttpTarget_Lookups)
+	client.Timeout = clientDiversityTimeout
+
+	// Get the latest slot blockprint has classified
+	response, err := client.Get(clientDiversityApiUrl + "/sync/status")
+	if err != nil {
+		return nil, fmt.Errorf("error getting the sync status: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the sync status request failed with %s", response.Status)
+	}
+	var status blockprintSyncStatus
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("error decoding the sync status: %w", err)
+	}
+	endEpoch := status.GreatestBlockSlot / 32
+	if endEpoch < clientDiversityEpochs {
+		return nil, fmt.Errorf("the latest slot (%d) is too early to get the client shares", status.GreatestBlockSlot)
+	}
+	startEpoch := endEpoch - clientDiversityEpochs
+
+	// Get the blocks proposed by each client
+	blocksResponse, err := client.Get(fmt.Sprintf("%s/blocks_per_client/%d/%d", clientDiversityApiUrl, startEpoch, endEpoch))
+	if err != nil {
+		return nil, fmt.Errorf("error getting the blocks per client: %w", err)
+	}
+	defer blocksResponse.Body.Close()
+	if blocksResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the blocks per client request failed with %s", blocksResponse.Status)
+	}
+	blocksPerClient := map[string]uint64{}
+	if err := json.NewDecoder(blocksResponse.Body).Decode(&blocksPerClient); err != nil {
+		return nil, fmt.Errorf("error decoding the blocks per client: %w", err)
+	}
+	totalBlocks := uint64(0)
+	for _, blocks := range blocksPerClient {
+		totalBlocks += blocks
+	}
+	if totalBlocks == 0 {
+		return nil, fmt.Errorf("no blocks were classified between epochs %d and %d", startEpoch, endEpoch)
+	}
+
+	// Match them to the supported clients; the ones blockprint couldn't classify are left out
+	shares := []clientShare{}
+	for _, option := range cfg.ConsensusClient.Options {
+		consensusClient := option.Value.(cfgtypes.ConsensusClient)
+		share := clientShare{
+			Client: consensusClient,
+			Name:   option.Name,
+		}
+		for name, blocks := range blocksPerClient {
+			if strings.EqualFold(name, string(consensusClient)) {
+				share.Share = float64(blocks) / float64(totalBlocks)
+			}
+		}
+		shares = append(shares, share)
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Share > shares[j].Share
+	})
+	return shares, nil
+}
+
+// Print the share of each Consensus client, marking the one the node uses
+func printConsensusClientShares(shares []clientShare, currentCc cfgtypes.ConsensusClient) {
+	fmt.Printf("Consensus client share of the blocks proposed on mainnet over the last %d epochs (from blockprint by Sigma Prime):\n", clientDiversityEpochs)
+	for _, share := range shares {
+		color := colorGreen
+		if share.Share >= clientDiversityMajorityShare {
+			color = colorRed
+		}
+		marker := ""
+		if share.Client == currentCc {
+			marker = " <- your client"
+		}
+		fmt.Printf("\t%-12s %s%5.1f%%%s%s\n", share.Name, color, share.Share*100, colorReset, marker)
+	}
+	fmt.Println()
+
+	for _, share := range shares {
+		if share.Client != currentCc {
+			continue
+		}
+		if share.Share >= clientDiversityMajorityShare {
+			fmt.Printf("%sYour Consensus client is used by over a third of the network. A bug in it could stop the chain from finalizing, and your validators would be penalized along with everyone else using it.\nSwitching to a minority client protects both you and Ethereum.%s\n\n", colorYellow, colorReset)
+		} else {
+			fmt.Printf("%sYour Consensus client is already a minority client - thanks for helping keep Ethereum healthy!%s\n\n", colorGreen, colorReset)
+		}
+	}
+}
+
+// Have the user pick the Consensus client to switch to, recommending the least used one
+func selectConsensusClient(c *cli.Context, cfg *config.RocketPoolConfig, shares []clientShare, currentCc cfgtypes.ConsensusClient) (cfgtypes.ConsensusClient, error) {
+
+	// Rank the other clients from the least used to the most used, or in the config's order if there are no shares
+	candidates := []clientShare{}
+	if shares == nil {
+		for _, option := range cfg.ConsensusClient.Options {
+			candidates = append(candidates, clientShare{
+				Client: option.Value.(cfgtypes.ConsensusClient),
+				Name:   option.Name,
+			})
+		}
+	} else {
+		for i := len(shares) - 1; i >= 0; i-- {
+			candidates = append(candidates, shares[i])
+		}
+	}
+	options := []string{}
+	clients := []cfgtypes.ConsensusClient{}
+	for _, candidate := range candidates {
+		if candidate.Client == currentCc {
+			continue
+		}
+		label := candidate.Name
+		if shares != nil {
+			label = fmt.Sprintf("%s (%.1f%%)", candidate.Name, candidate.Share*100)
+		}
+		if len(options) == 0 && shares != nil {
+			label += " - recommended"
+		}
+		options = append(options, label)
+		clients = append(clients, candidate.Client)
+	}
+	if len(clients) == 0 {
+		return "", fmt.Errorf("there are no other Consensus clients to switch to")
+	}
+
+	if c.Bool("yes") {
+		if shares == nil {
+			return "", fmt.Errorf("the client diversity couldn't be retrieved, so please choose a Consensus client with the --consensus-client flag")
+		}
+		fmt.Printf("Switching to the recommended Consensus client, %s.\n\n", clients[0])
+		return clients[0], nil
+	}
+	index, _ := cliutils.Select("Which Consensus client would you like to switch to?", options)
+	return clients[index], nil
+
+}
+
+// Get a Consensus client the Smartnode can run from its name
+func getSwitchableConsensusClient(cfg *config.RocketPoolConfig, name string) (cfgtypes.ConsensusClient, error) {
+	names := []string{}
+	for _, option := range cfg.ConsensusClient.Options {
+		client := option.Value.(cfgtypes.ConsensusClient)
+		if strings.EqualFold(name, string(client)) {
+			return client, nil
+		}
+		names = append(names, string(client))
+	}
+	return "", fmt.Errorf("unknown Consensus client '%s' (supported clients: %s)", name, strings.Join(names, ", "))
+}
+
+// Get an Execution client the Smartnode can run from its name
+func getSwitchableExecutionClient(cfg *config.RocketPoolConfig, name string) (cfgtypes.ExecutionClient, error) {
+	names := []string{}
+	for _, option := range cfg.ExecutionClient.Options {
+		client := option.Value.(cfgtypes.ExecutionClient)
+		if client == cfgtypes.ExecutionClient_Obs_Infura || client == cfgtypes.ExecutionClient_Obs_Pocket {
+			continue
+		}
+		if strings.EqualFold(name, string(client)) {
+			return client, nil
+		}
+		names = append(names, string(client))
+	}
+	return "", fmt.Errorf("unknown Execution client '%s' (supported clients: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,188 @@
+package wallet
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The permission bits that shouldn't be set on files that hold secrets
+const (
+	// The wallet, its password and the other secrets only the daemon reads must be private to their owner
+	privateFileDisallowedBits fs.FileMode = 0077
+
+	// Some Validator Clients run as a different user in the owner's group, so keystores may be group-readable but nothing more
+	keystoreDisallowedBits fs.FileMode = 0027
+
+	// Anyone who can write to a folder can swap the secrets in it for their own
+	folderDisallowedBits fs.FileMode = 0002
+)
+
+// A file or folder holding secrets with permissions that are too open
+type permissionIssue struct {
+	path    string
+	mode    fs.FileMode
+	problem string
+	fix     string
+}
+
+// Checks the permissions of the files and folders holding the node's secrets
+type permissionAuditor struct {
+	checked   int
+	issues    []permissionIssue
+	unchecked []string
+}
+
+// Check the permissions of the node wallet, its password, and the validator keystores
+func auditWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	datapath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+	walletPath, err := homedir.Expand(cfg.Smartnode.GetWalletPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding wallet path: %w", err)
+	}
+	passwordPath, err := homedir.Expand(cfg.Smartnode.GetPasswordPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding password path: %w", err)
+	}
+	keychainPath, err := homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding validator keychain path: %w", err)
+	}
+
+	// Check everything that holds a secret
+	fmt.Printf("Checking the permissions of your node's secrets in %s...\n\n", datapath)
+	auditor := &permissionAuditor{}
+	auditor.checkPath(datapath, privateFileDisallowedBits)
+	for _, path := range []string{
+		walletPath,
+		passwordPath,
+		filepath.Join(datapath, config.TransactorKeyFile),
+		filepath.Join(datapath, "custom-key-passwords"),
+	} {
+		auditor.checkPath(path, privateFileDisallowedBits)
+	}
+	for _, path := range []string{
+		keychainPath,
+		filepath.Join(datapath, "custom-keys"),
+	} {
+		auditor.checkTree(path, keystoreDisallowedBits)
+	}
+
+	// Print the results
+	if len(auditor.issues) == 0 && len(auditor.unchecked) == 0 {
+		fmt.Printf("%sAll %d files and folders holding your node's secrets have safe permissions.%s\n", colorGreen, auditor.checked, colorReset)
+		return nil
+	}
+	if len(auditor.issues) > 0 {
+		fmt.Printf("%sFound %d of %d files and folders with permissions that are too open:%s\n", colorRed, len(auditor.issues), auditor.checked, colorReset)
+		for _, issue := range auditor.issues {
+			fmt.Printf("\t%s (%s) %s\n", issue.path, issue.mode.Perm(), issue.problem)
+		}
+		fmt.Println()
+		fmt.Println("You can fix them with the following commands (use sudo if the files are owned by root):")
+		for _, issue := range auditor.issues {
+			fmt.Printf("\t%s\n", issue.fix)
+		}
+		fmt.Println()
+	} else {
+		fmt.Printf("%sAll %d files and folders that could be checked have safe permissions.%s\n\n", colorGreen, auditor.checked, colorReset)
+	}
+	if len(auditor.unchecked) > 0 {
+		fmt.Printf("%sThe following couldn't be checked, most likely because they belong to another user such as root:%s\n", colorYellow, colorReset)
+		for _, unchecked := range auditor.unchecked {
+			fmt.Printf("\t%s\n", unchecked)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Check a single file or folder, skipping it if it doesn't exist
+func (a *permissionAuditor) checkPath(path string, disallowedBits fs.FileMode) {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		a.unchecked = append(a.unchecked, err.Error())
+		return
+	}
+	a.checkInfo(path, info, disallowedBits)
+}
+
+// Check a folder and everything in it, skipping it if it doesn't exist
+func (a *permissionAuditor) checkTree(root string, disallowedBits fs.FileMode) {
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if !(path == root && os.IsNotExist(err)) {
+				a.unchecked = append(a.unchecked, err.Error())
+			}
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			a.unchecked = append(a.unchecked, err.Error())
+			return nil
+		}
+		a.checkInfo(path, info, disallowedBits)
+		return nil
+	})
+}
+
+// Record an issue if a file's permissions are too open; folders only need to be safe from being written to.
+// Symlinks are skipped since their own permissions are meaningless.
+func (a *permissionAuditor) checkInfo(path string, info fs.FileInfo, fileDisallowedBits fs.FileMode) {
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		a.checked++
+		if mode.Perm()&folderDisallowedBits != 0 {
+			a.issues = append(a.issues, permissionIssue{
+				path:    path,
+				mode:    mode,
+				problem: "can be written to by any user, so the secrets in it could be replaced",
+				fix:     fmt.Sprintf("chmod %o %s", mode.Perm()&^folderDisallowedBits, path),
+			})
+		}
+
+	case mode.IsRegular():
+		a.checked++
+		if mode.Perm()&fileDisallowedBits != 0 {
+			a.issues = append(a.issues, permissionIssue{
+				path:    path,
+				mode:    mode,
+				problem: "can be read or changed by other users",
+				fix:     fmt.Sprintf("chmod %o %s", mode.Perm()&^fileDisallowedBits, path),
+			})
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The version of the wallet backup payload
+const walletBackupVersion uint = 1
+
+// The contents of an encrypted wallet backup
+type walletBackup struct {
+	Version     uint           `json:"version"`
+	Created     time.Time      `json:"created"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	Wallet      string         `json:"wallet"`
+	Password    string         `json:"password"`
+
+	// Set for bundles created with `rocketpool wallet offline-init`
+	Offline          bool                    `json:"offline,omitempty"`
+	ValidatorPubkeys []types.ValidatorPubkey `json:"validatorPubkeys,omitempty"`
+}
+
+func backupWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the destination
+	destination, err := getBackupDestination(c, rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the passphrase
+	passphrase := c.String("passphrase")
+	if passphrase == "" {
+		passphrase = promptBackupPassphrase()
+	}
+	if len(passphrase) < backup.MinPassphraseLength {
+		return fmt.Errorf("the backup passphrase must be at least %d characters long", backup.MinPassphraseLength)
+	}
+
+	// Export the wallet
+	export, err := rp.ExportWallet()
+	if err != nil {
+		return err
+	}
+	created := time.Now().UTC()
+	payload, err := json.Marshal(walletBackup{
+		Version:     walletBackupVersion,
+		Created:     created,
+		NodeAddress: status.AccountAddress,
+		Wallet:      export.Wallet,
+		Password:    export.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing wallet backup: %w", err)
+	}
+
+	// Encrypt and upload it
+	fmt.Println("Encrypting the wallet backup...")
+	encrypted, err := backup.Encrypt(payload, passphrase)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("rp-wallet-%s-%s.json.enc", status.AccountAddress.Hex(), created.Format("20060102-150405"))
+	fmt.Printf("Uploading the backup to %s...\n", destination.String())
+	if err := destination.Upload(name, encrypted); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The node wallet was successfully backed up to %s as %s.\n", destination.String(), name)
+	fmt.Printf("%sKeep your passphrase somewhere safe - the backup can't be restored without it.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+func restoreWalletBackup(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Println("The node wallet is already initialized.")
+		return nil
+	}
+
+	// Download the backup
+	destination, err := getBackupDestination(c, rp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Downloading %s from %s...\n", name, destination.String())
+	encrypted, err := destination.Download(name)
+	if err != nil {
+		return err
+	}
+
+	// Decrypt it
+	passphrase := c.String("passphrase")
+	if passphrase == "" {
+		passphrase = cliutils.PromptPassword("Please enter the backup passphrase:", "^.*$", "")
+	}
+	payload, err := backup.Decrypt(encrypted, passphrase)
+	if err != nil {
+		return err
+	}
+	var contents walletBackup
+	if err := json.Unmarshal(payload, &contents); err != nil {
+		return fmt.Errorf("error decoding wallet backup: %w", err)
+	}
+	if contents.Version != walletBackupVersion {
+		return fmt.Errorf("unsupported wallet backup version %d", contents.Version)
+	}
+	if contents.Offline {
+		fmt.Printf("Found a bundle for node wallet %s, created on an offline machine on %s.\n\n", contents.NodeAddress.Hex(), contents.Created.Format(time.RFC1123))
+	} else {
+		fmt.Printf("Found a backup of node wallet %s, created on %s.\n\n", contents.NodeAddress.Hex(), contents.Created.Format(time.RFC1123))
+	}
+
+	// Set the password from the backup, since it's what the wallet file is encrypted with
+	if !status.PasswordSet {
+		if _, err := rp.SetPassword(contents.Password); err != nil {
+			return err
+		}
+	}
+
+	// Handle validator key recovery skipping
+	skipValidatorKeyRecovery := c.Bool("skip-validator-key-recovery")
+	if skipValidatorKeyRecovery {
+		fmt.Println("Restoring node wallet only (ignoring validator keys)...")
+	} else {
+		// Check and assign the EC status
+		err = cliutils.CheckClientStatus(rp)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Restoring node wallet and validator keys...")
+	}
+
+	// Restore wallet
+	response, err := rp.RestoreWalletBackup(contents.Wallet, skipValidatorKeyRecovery)
+	if err != nil {
+		if status.PasswordSet {
+			fmt.Printf("%sA node password was already set; if it doesn't match the one in the backup, delete it with `rocketpool wallet purge` and try again.%s\n", colorYellow, colorReset)
+		}
+		return err
+	}
+
+	// Make sure the wallet matches the one the bundle was made for
+	if response.AccountAddress != contents.NodeAddress {
+		return fmt.Errorf("expected node account %s, but the restored wallet is %s", contents.NodeAddress.Hex(), response.AccountAddress.Hex())
+	}
+
+	// Log & return
+	fmt.Println("The node wallet was successfully restored.")
+	fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+	if len(contents.ValidatorPubkeys) > 0 {
+		fmt.Println("The offline machine derived these keys for your first validators; check them against the ones it printed:")
+		for _, pubkey := range contents.ValidatorPubkeys {
+			fmt.Println(pubkey.Hex())
+		}
+	}
+	if !skipValidatorKeyRecovery {
+		if len(response.ValidatorKeys) > 0 {
+			fmt.Println("Validator keys:")
+			for _, key := range response.ValidatorKeys {
+				fmt.Println(key.Hex())
+			}
+		} else {
+			fmt.Println("No validator keys were found.")
+		}
+	}
+	return nil
+
+}
+
+// Get the backup destination from the command line, falling back to the one in the config
+func getBackupDestination(c *cli.Context, rp *rocketpool.Client) (backup.Destination, error) {
+	location := c.String("destination")
+	if location == "" {
+		cfg, _, err := rp.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		location, _ = cfg.Smartnode.WalletBackupDestination.Value.(string)
+	}
+	if location == "" {
+		return nil, fmt.Errorf("no backup destination was provided; use the --destination flag or set the Wallet Backup Destination in the Smartnode section of `rocketpool service config`")
+	}
+	if err := rp.ConfigureHttpClients(); err != nil {
+		return nil, err
+	}
+	return backup.NewDestination(os.ExpandEnv(location))
+}
+
+// Prompt for a backup passphrase
+func promptBackupPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", backup.MinPassphraseLength),
+			fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", backup.MinPassphraseLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
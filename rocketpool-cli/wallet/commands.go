@@ -52,6 +52,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path when initializing your wallet",
+						Value: 0,
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -73,6 +78,63 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "offline-init",
+				Usage:     "Create a node wallet on an offline machine and save it to an encrypted bundle for your node to import with `rocketpool wallet restore-backup`",
+				UsageText: "rocketpool wallet offline-init [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The folder to save the encrypted wallet bundle to, such as a mounted USB drive",
+						Value: ".",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to secure the wallet with on your node",
+					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The passphrase to encrypt the wallet bundle with",
+					},
+					cli.BoolFlag{
+						Name:  "confirm-mnemonic, c",
+						Usage: "Automatically confirm the mnemonic phrase",
+					},
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
+					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path when initializing your wallet",
+						Value: 0,
+					},
+					cli.UintFlag{
+						Name:  "validator-keys, k",
+						Usage: "The number of validator keys to derive and record in the bundle, so you can check them on your node",
+						Value: 1,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return offlineInitWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "recover",
 				Aliases:   []string{"r"},
@@ -130,6 +192,46 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "search",
+				Aliases:   []string{"f"},
+				Usage:     "Search the common derivation paths and indices of a mnemonic phrase for accounts that have been used on-chain, to find the settings to recover it with",
+				UsageText: "rocketpool wallet search [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic, m",
+						Usage: "The mnemonic phrase to search",
+					},
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "An additional custom derivation path to search along with the default, Ledger Live, and MyEtherWallet paths. Use %d in place of the index.",
+					},
+					cli.UintFlag{
+						Name:  "max-index, i",
+						Usage: "The number of indices to search on each derivation path",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("mnemonic") != "" {
+						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return searchWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
@@ -213,6 +315,62 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "backup",
+				Usage:     "Encrypt the node wallet with a passphrase and upload it to a local directory, an S3-compatible bucket, or an SFTP server",
+				UsageText: "rocketpool wallet backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "destination, d",
+						Usage: "Where to upload the backup: a local path, s3://endpoint/bucket/path, or sftp://user@host:port/path (defaults to the Wallet Backup Destination setting)",
+					},
+					cli.StringFlag{
+						Name:  "passphrase, p",
+						Usage: "The passphrase to encrypt the backup with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return backupWallet(c)
+
+				},
+			},
+			{
+				Name:      "restore-backup",
+				Usage:     "Download and decrypt a wallet backup created with `rocketpool wallet backup`, and restore the node wallet from it",
+				UsageText: "rocketpool wallet restore-backup [options] backup-name",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "destination, d",
+						Usage: "Where to download the backup from: a local path, s3://endpoint/bucket/path, or sftp://user@host:port/path (defaults to the Wallet Backup Destination setting)",
+					},
+					cli.StringFlag{
+						Name:  "passphrase, p",
+						Usage: "The passphrase the backup was encrypted with",
+					},
+					cli.BoolFlag{
+						Name:  "skip-validator-key-recovery, k",
+						Usage: "Restore the node wallet, but do not regenerate its validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return restoreWalletBackup(c, c.Args().Get(0))
+
+				},
+			},
 			{
 				Name:      "set-ens-name",
 				Aliases:   []string{"ens"},
@@ -231,6 +389,203 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "test-transaction",
+				Aliases:   []string{"test-sign"},
+				Usage:     "Sign a zero-value transaction from the node wallet to itself without broadcasting it, to verify the wallet can sign",
+				UsageText: "rocketpool wallet test-transaction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return testTransaction(c)
+
+				},
+			},
+
+			{
+				Name:      "masquerade",
+				Usage:     "Put the CLI and the node daemon into a read-only mode that tracks another node's address, for monitoring or supporting that node. With a transactor key, the daemon still sends the routine transactions it can use that key for, so you can also use this to run your own node with its mnemonic kept offline",
+				UsageText: "rocketpool wallet masquerade address [--yes]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm masquerading and restart the daemons",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return masquerade(c)
+
+				},
+			},
+
+			{
+				Name:      "end-masquerade",
+				Usage:     "Stop masquerading as another node and go back to the node wallet",
+				UsageText: "rocketpool wallet end-masquerade [--yes]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically restart the daemons",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return endMasquerade(c)
+
+				},
+			},
+
+			{
+				Name:      "transactor",
+				Usage:     "Manage the transactor key, a separate hot key the node daemon uses for routine transactions that don't need to come from the node account",
+				UsageText: "rocketpool wallet transactor [command]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "status",
+						Usage:     "Show the transactor account and its balance",
+						UsageText: "rocketpool wallet transactor status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return transactorStatus(c)
+
+						},
+					},
+					{
+						Name:      "create",
+						Usage:     "Create a transactor key and restart the daemons so they use it",
+						UsageText: "rocketpool wallet transactor create [--yes]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm creating the key and restart the daemons",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return createTransactor(c, false)
+
+						},
+					},
+					{
+						Name:      "fund",
+						Usage:     "Send ETH from the node account to the transactor account to pay for gas",
+						UsageText: "rocketpool wallet transactor fund amount [--yes]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm funding the transactor account",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							amount, err := cliutils.ValidatePositiveEthAmount("fund amount", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return fundTransactor(c, amount)
+
+						},
+					},
+					{
+						Name:      "rotate",
+						Usage:     "Replace the transactor key with a new one and move its balance over",
+						UsageText: "rocketpool wallet transactor rotate [--yes]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm rotating the key and restart the daemons",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return createTransactor(c, true)
+
+						},
+					},
+					{
+						Name:      "remove",
+						Usage:     "Retire the transactor key and send its balance back to the node account",
+						UsageText: "rocketpool wallet transactor remove [--yes]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm removing the key and restart the daemons",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return removeTransactor(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "audit",
+				Usage:     "Check that the node wallet, its password, and your validator keystores can't be read or changed by other users",
+				UsageText: "rocketpool wallet audit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return auditWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
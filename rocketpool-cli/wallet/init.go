@@ -54,8 +54,14 @@ func initWallet(c *cli.Context) error {
 		fmt.Printf("Using a custom derivation path (%s).\n\n", derivationPath)
 	}
 
+	// Get the wallet index
+	walletIndex := c.Uint("wallet-index")
+	if walletIndex != 0 {
+		fmt.Printf("Using a custom wallet index (%d).\n\n", walletIndex)
+	}
+
 	// Initialize wallet
-	response, err := rp.InitWallet(derivationPath)
+	response, err := rp.InitWallet(derivationPath, walletIndex)
 	if err != nil {
 		return err
 	}
@@ -76,7 +82,7 @@ func initWallet(c *cli.Context) error {
 	}
 
 	// Do a recover to save the wallet
-	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0)
+	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, walletIndex)
 	if err != nil {
 		return fmt.Errorf("error saving wallet: %w", err)
 	}
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func masquerade(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the address
+	address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("%sWhile masquerading, the CLI and the node daemon will report node %s as your node, but nothing can be signed or submitted.\nYour node daemon will stop its automatic tasks (such as claiming rewards or staking minipools) until you run `rocketpool wallet end-masquerade`.%s\n\n", colorYellow, address.Hex(), colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to masquerade as %s?", address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Masquerade
+	if _, err := rp.Masquerade(address); err != nil {
+		return err
+	}
+	fmt.Printf("The CLI is now masquerading as node %s.\n", address.Hex())
+
+	// Restart the daemons so their metrics and tasks follow the new address
+	return restartDaemonsForWalletChange(c, rp)
+
+}
+
+func endMasquerade(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// End the masquerade
+	response, err := rp.EndMasquerade()
+	if err != nil {
+		return err
+	}
+	if !response.WasMasquerading {
+		fmt.Println("The wallet wasn't masquerading as another node.")
+		return nil
+	}
+	fmt.Println("The CLI is using your node wallet again.")
+
+	// Restart the daemons so they go back to the node wallet
+	return restartDaemonsForWalletChange(c, rp)
+
+}
+
+// The daemons only load the masquerade address and transactor key on startup, so offer to restart them
+func restartDaemonsForWalletChange(c *cli.Context, rp *rocketpool.Client) error {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew || cfg.IsNativeMode {
+		fmt.Println("Please restart your node and watchtower daemons for the change to apply to them.")
+		return nil
+	}
+
+	if !(c.Bool("yes") || cliutils.Confirm("Your node and watchtower containers need to be restarted for the change to apply to them. Would you like to restart them now?")) {
+		fmt.Println("Please restart them when you're ready.")
+		return nil
+	}
+	for _, suffix := range []string{"node", "watchtower"} {
+		container := fmt.Sprintf("%s_%s", cfg.Smartnode.ProjectName.Value.(string), suffix)
+		response, err := rp.RestartContainer(container)
+		if err != nil {
+			return fmt.Errorf("Error restarting %s: %w", suffix, err)
+		}
+		if response != container {
+			return fmt.Errorf("Unexpected output while restarting %s: %s", suffix, response)
+		}
+	}
+	fmt.Println("Done!")
+	return nil
+}
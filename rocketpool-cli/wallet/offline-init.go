@@ -0,0 +1,173 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/term"
+)
+
+// Create the node wallet on an offline machine without using the Smartnode daemon, and write it to an encrypted bundle
+// that the online node can import with `rocketpool wallet restore-backup`
+func offlineInitWallet(c *cli.Context) error {
+
+	fmt.Printf("%sThis command is meant to be run on an offline machine. The mnemonic it prints should never be entered on a machine that's connected to the internet.%s\n\n", colorYellow, colorReset)
+
+	// Prompt for user confirmation before printing sensitive information
+	if !(c.GlobalBool("secure-session") ||
+		cliutils.ConfirmSecureSession("Creating a wallet will print sensitive information to your screen.")) {
+		return nil
+	}
+
+	// Check the output folder before doing anything else
+	outputDir := c.String("output")
+	if info, err := os.Stat(outputDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("the output folder [%s] doesn't exist", outputDir)
+	}
+
+	// Get the node password, which the wallet file is encrypted with
+	password := c.String("password")
+	if password == "" {
+		password = promptPassword()
+	}
+
+	// Get the bundle passphrase
+	passphrase := c.String("passphrase")
+	if passphrase == "" {
+		fmt.Println("The wallet bundle will be encrypted with a passphrase, which you'll need to import it on your node.")
+		passphrase = promptBackupPassphrase()
+	}
+	if len(passphrase) < backup.MinPassphraseLength {
+		return fmt.Errorf("the bundle passphrase must be at least %d characters long", backup.MinPassphraseLength)
+	}
+
+	// Get the derivation path
+	derivationPath := c.String("derivation-path")
+	switch derivationPath {
+	case "":
+		derivationPath = wallet.DefaultNodeKeyPath
+	case "ledgerLive":
+		derivationPath = wallet.LedgerLiveNodeKeyPath
+	case "mew":
+		derivationPath = wallet.MyEtherWalletNodeKeyPath
+	default:
+		fmt.Printf("Using a custom derivation path (%s).\n\n", derivationPath)
+	}
+
+	// Get the wallet index
+	walletIndex := c.Uint("wallet-index")
+	if walletIndex != 0 {
+		fmt.Printf("Using a custom wallet index (%d).\n\n", walletIndex)
+	}
+
+	// The wallet needs a password file to encrypt its seed with, so keep one in a scratch folder that's removed afterwards
+	scratchDir, err := os.MkdirTemp("", "rp-offline-wallet-")
+	if err != nil {
+		return fmt.Errorf("error creating scratch folder: %w", err)
+	}
+	defer os.RemoveAll(scratchDir)
+	pm := passwords.NewPasswordManager(filepath.Join(scratchDir, "password"))
+	if err := pm.SetPassword(password); err != nil {
+		return err
+	}
+
+	// Create the wallet; the chain ID and gas settings are only used for transactions, which are never sent from here
+	w, err := wallet.NewWallet(filepath.Join(scratchDir, "wallet"), 0, big.NewInt(0), big.NewInt(0), 0, pm)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := w.Initialize(derivationPath, walletIndex)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Derive the first validator keys so they can be recorded alongside the node address
+	validatorKeyCount := c.Uint("validator-keys")
+	validatorKeys, err := w.GetValidatorKeys(0, validatorKeyCount)
+	if err != nil {
+		return err
+	}
+	validatorPubkeys := make([]types.ValidatorPubkey, 0, len(validatorKeys))
+	for _, key := range validatorKeys {
+		validatorPubkeys = append(validatorPubkeys, key.PublicKey)
+	}
+
+	// Print mnemonic
+	fmt.Println("Your mnemonic phrase to recover your wallet is printed below. It can be used to recover your node account and validator keys if they are lost.")
+	fmt.Println("Record this phrase somewhere secure and private. Do not share it with anyone as it will give them control of your node account and validators.")
+	fmt.Println("==============================================================================================================================================")
+	fmt.Println("")
+	fmt.Println(mnemonic)
+	fmt.Println("")
+	fmt.Println("==============================================================================================================================================")
+	fmt.Println("")
+
+	// Confirm mnemonic
+	if !c.Bool("confirm-mnemonic") {
+		confirmMnemonic(mnemonic)
+	}
+
+	// Build the bundle; it's the same format as a wallet backup so the node can import it the same way
+	walletJson, err := w.String()
+	if err != nil {
+		return err
+	}
+	created := time.Now().UTC()
+	payload, err := json.Marshal(walletBackup{
+		Version:          walletBackupVersion,
+		Created:          created,
+		NodeAddress:      nodeAccount.Address,
+		Wallet:           walletJson,
+		Password:         password,
+		Offline:          true,
+		ValidatorPubkeys: validatorPubkeys,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing wallet bundle: %w", err)
+	}
+
+	// Encrypt and save it
+	encrypted, err := backup.Encrypt(payload, passphrase)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("rp-wallet-%s-%s.json.enc", nodeAccount.Address.Hex(), created.Format("20060102-150405"))
+	if err := os.WriteFile(filepath.Join(outputDir, name), encrypted, 0600); err != nil {
+		return fmt.Errorf("error saving wallet bundle: %w", err)
+	}
+
+	// Clear terminal output
+	_ = term.Clear()
+
+	// Log & return
+	fmt.Println("The node wallet was successfully created.")
+	fmt.Printf("Node account: %s\n", nodeAccount.Address.Hex())
+	if len(validatorPubkeys) > 0 {
+		fmt.Println("First validator keys:")
+		for _, pubkey := range validatorPubkeys {
+			fmt.Println(pubkey.Hex())
+		}
+	}
+	fmt.Println()
+	fmt.Printf("The encrypted wallet bundle was saved to %s.\n", filepath.Join(outputDir, name))
+	fmt.Println("Copy it to your node with removable media, then import it there with:")
+	fmt.Printf("\trocketpool wallet restore-backup --destination <folder containing the bundle> %s\n", name)
+	fmt.Printf("%sThe bundle doesn't contain your mnemonic, so keep your written copy somewhere safe.%s\n", colorYellow, colorReset)
+	return nil
+
+}
@@ -72,6 +72,9 @@ func rebuildWallet(c *cli.Context) error {
 
 	// Log & return
 	fmt.Println("The node wallet was successfully rebuilt.")
+	if response.DoppelgangerCheckSkipped {
+		fmt.Printf("%sNOTE: your validator client is running, so the Beacon Chain couldn't be checked for copies of these validators running on another machine. Make sure they aren't running anywhere else.%s\n", colorYellow, colorReset)
+	}
 	if len(response.ValidatorKeys) > 0 {
 		fmt.Println("Validator keys:")
 		for _, key := range response.ValidatorKeys {
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func searchWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for mnemonic
+	var mnemonic string
+	if c.String("mnemonic") != "" {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = PromptMnemonic()
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	// Search the wallet
+	maxIndex := c.Uint("max-index")
+	fmt.Printf("Searching the first %d indices of each derivation path for accounts with on-chain history...\n\n", maxIndex)
+	response, err := rp.SearchWallet(mnemonic, c.String("derivation-path"), maxIndex)
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	if len(response.Accounts) == 0 {
+		fmt.Println("No accounts with any transactions or ETH balance were found.")
+		fmt.Println("If your wallet was created with a different tool, try a larger --max-index or provide its derivation path with --derivation-path.")
+		return nil
+	}
+	fmt.Printf("Found %d account(s) with on-chain history:\n\n", len(response.Accounts))
+	for _, account := range response.Accounts {
+		fmt.Printf("Address:         %s\n", account.Address.Hex())
+		fmt.Printf("Derivation path: %s\n", account.DerivationPath)
+		fmt.Printf("Wallet index:    %d\n", account.Index)
+		fmt.Printf("Transactions:    %d\n", account.Nonce)
+		fmt.Printf("Balance:         %.6f ETH\n", math.RoundDown(eth.WeiToEth(account.Balance), 6))
+		fmt.Printf("Recover with:    rocketpool wallet recover%s\n\n", getRecoverFlags(account.DerivationPath, account.Index))
+	}
+
+	return nil
+
+}
+
+// Get the `wallet recover` flags that select a derivation path and index
+func getRecoverFlags(derivationPath string, index uint) string {
+	var flags string
+	switch derivationPath {
+	case wallet.DefaultNodeKeyPath:
+	case wallet.LedgerLiveNodeKeyPath:
+		flags += " --derivation-path ledgerLive"
+	case wallet.MyEtherWalletNodeKeyPath:
+		flags += " --derivation-path mew"
+	default:
+		flags += fmt.Sprintf(" --derivation-path \"%s\"", derivationPath)
+	}
+	if index != 0 {
+		flags += fmt.Sprintf(" --wallet-index %d", index)
+	}
+	return flags
+}
@@ -31,9 +31,16 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Print status & return
+	if status.IsMasquerading {
+		fmt.Printf("%sThe wallet is masquerading as node %s in read-only mode. Run `rocketpool wallet end-masquerade` to use your node wallet again.%s\n", colorYellow, status.AccountAddress.Hex(), colorReset)
+		return nil
+	}
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		if status.HasTransactor {
+			fmt.Printf("Transactor account: %s\n", status.TransactorAddress.Hex())
+		}
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
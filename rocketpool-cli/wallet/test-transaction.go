@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func testTransaction(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Sign the test transaction
+	fmt.Println("Signing a zero-value transaction from the node wallet to itself. It will not be broadcast to the network.")
+	fmt.Println()
+	response, err := rp.TestTransaction()
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	fmt.Printf("Node account:      %s\n", response.AccountAddress.Hex())
+	fmt.Printf("Derivation path:   %s\n", response.DerivationPath)
+	fmt.Printf("Recovered signer:  %s\n", response.RecoveredAddress.Hex())
+	fmt.Printf("Transaction hash:  %s\n", response.TxHash.Hex())
+	fmt.Printf("Signed payload:    %s\n\n", response.SignedTx)
+
+	if !response.SignatureValid {
+		fmt.Printf("%sThe signature does not match the node account! Do not use this wallet for any transactions until you've resolved this.%s\n", colorRed, colorReset)
+		return nil
+	}
+	fmt.Printf("%sThe node wallet signed the transaction successfully; your wallet, password, and derivation path are working.%s\n", colorGreen, colorReset)
+	return nil
+
+}
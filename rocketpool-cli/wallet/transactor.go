@@ -0,0 +1,216 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func transactorStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the transactor status
+	status, err := rp.TransactorStatus()
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	if !status.HasTransactor {
+		fmt.Printf("The wallet doesn't have a transactor key, so the node daemon sends every transaction from the node account %s.\n", status.NodeAddress.Hex())
+		fmt.Println("Run `rocketpool wallet transactor create` to create one.")
+		return nil
+	}
+	fmt.Printf("The node daemon sends routine transactions from the transactor account %s.\n", status.Address.Hex())
+	fmt.Printf("It holds %.6f ETH for gas.\n", math.RoundDown(eth.WeiToEth(status.Balance), 6))
+	fmt.Printf("Transactions that must come from your node (such as staking or claiming rewards) are still sent from the node account %s.\n", status.NodeAddress.Hex())
+	return nil
+
+}
+
+func createTransactor(c *cli.Context, rotate bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the current key
+	status, err := rp.TransactorStatus()
+	if err != nil {
+		return err
+	}
+	if status.HasTransactor && !rotate {
+		fmt.Printf("The wallet already has a transactor key (%s). Run `rocketpool wallet transactor rotate` to replace it.\n", status.Address.Hex())
+		return nil
+	}
+	if !status.HasTransactor && rotate {
+		fmt.Println("The wallet doesn't have a transactor key yet. Run `rocketpool wallet transactor create` to create one.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if rotate {
+		fmt.Printf("This will replace the transactor key %s with a new one and send its %.6f ETH (minus gas) to the new key.\nThe old key will be kept on disk next to the new one.\n\n", status.Address.Hex(), math.RoundDown(eth.WeiToEth(status.Balance), 6))
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to rotate the transactor key?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else {
+		fmt.Printf("%sThe transactor key is a separate hot key that the node daemon uses for routine transactions that don't have to come from your node account.\nIt has no special permissions in Rocket Pool, so only fund it with enough ETH to cover gas.%s\n\n", colorYellow, colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to create a transactor key?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Create the key
+	response, err := rp.CreateTransactor()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The new transactor account is %s.\n", response.Address.Hex())
+	if response.SweepTxHash != (common.Hash{}) {
+		fmt.Printf("Moving %.6f ETH from the old transactor account %s...\n", math.RoundDown(eth.WeiToEth(response.SweptAmount), 6), response.RetiredAddress.Hex())
+		cliutils.PrintTransactionHash(rp, response.SweepTxHash)
+		if _, err = rp.WaitForTransaction(response.SweepTxHash); err != nil {
+			return err
+		}
+	} else if response.Rotated {
+		fmt.Printf("The old transactor account %s didn't have enough ETH to be worth moving.\n", response.RetiredAddress.Hex())
+	} else {
+		fmt.Println("Run `rocketpool wallet transactor fund` to send it some ETH for gas.")
+	}
+
+	// Restart the daemons so they pick up the new key
+	return restartDaemonsForWalletChange(c, rp)
+
+}
+
+func fundTransactor(c *cli.Context, amount float64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the transactor account
+	status, err := rp.TransactorStatus()
+	if err != nil {
+		return err
+	}
+	if !status.HasTransactor {
+		fmt.Println("The wallet doesn't have a transactor key yet. Run `rocketpool wallet transactor create` to create one.")
+		return nil
+	}
+
+	// Check the ETH can be sent
+	amountWei := eth.EthToWei(amount)
+	canSend, err := rp.CanNodeSend(amountWei, "eth")
+	if err != nil {
+		return err
+	}
+	if !canSend.CanSend {
+		fmt.Println("Cannot fund the transactor account:")
+		if canSend.InsufficientBalance {
+			fmt.Println("The node's ETH balance is insufficient.")
+		}
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %.6f ETH from the node account to the transactor account %s?", math.RoundDown(amount, 6), status.Address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canSend.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Send the ETH
+	response, err := rp.NodeSend(amountWei, "eth", status.Address)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Funding the transactor account %s...\n", status.Address.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully sent %.6f ETH to the transactor account.\n", math.RoundDown(amount, 6))
+	return nil
+
+}
+
+func removeTransactor(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the current key
+	status, err := rp.TransactorStatus()
+	if err != nil {
+		return err
+	}
+	if !status.HasTransactor {
+		fmt.Println("The wallet doesn't have a transactor key.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("This will retire the transactor key %s and send its %.6f ETH (minus gas) back to the node account %s.\nThe node daemon will send every transaction from the node account again.\n\n", status.Address.Hex(), math.RoundDown(eth.WeiToEth(status.Balance), 6), status.NodeAddress.Hex())
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to remove the transactor key?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the key
+	response, err := rp.RemoveTransactor()
+	if err != nil {
+		return err
+	}
+	if response.SweepTxHash != (common.Hash{}) {
+		fmt.Printf("Sending %.6f ETH back to the node account...\n", math.RoundDown(eth.WeiToEth(response.SweptAmount), 6))
+		cliutils.PrintTransactionHash(rp, response.SweepTxHash)
+		if _, err = rp.WaitForTransaction(response.SweepTxHash); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("The transactor key %s has been retired.\n", response.RetiredAddress.Hex())
+
+	// Restart the daemons so they stop using the key
+	return restartDaemonsForWalletChange(c, rp)
+
+}
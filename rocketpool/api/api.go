@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/api/network"
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/rocketpool/api/odao"
+	"github.com/rocket-pool/smartnode/rocketpool/api/pdao"
 	"github.com/rocket-pool/smartnode/rocketpool/api/queue"
 	apiservice "github.com/rocket-pool/smartnode/rocketpool/api/service"
 	"github.com/rocket-pool/smartnode/rocketpool/api/wallet"
@@ -64,6 +65,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 	network.RegisterSubcommands(&command, "network", []string{"e"})
 	node.RegisterSubcommands(&command, "node", []string{"n"})
 	odao.RegisterSubcommands(&command, "odao", []string{"o"})
+	pdao.RegisterSubcommands(&command, "pdao", []string{"p"})
 	queue.RegisterSubcommands(&command, "queue", []string{"q"})
 	wallet.RegisterSubcommands(&command, "wallet", []string{"w"})
 	apiservice.RegisterSubcommands(&command, "service", []string{"s"})
@@ -94,7 +94,7 @@ func ExportValidators(c *cli.Context) error {
 		return err
 	}
 
-	fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+	fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 		"Minipool Address",
 		"Validator Pub Key",
 		"Activation Epoch",
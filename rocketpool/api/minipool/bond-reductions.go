@@ -0,0 +1,185 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The only bond reduction currently supported is from 16 ETH down to 8 ETH
+const (
+	bondReductionCurrentBond float64 = 16
+	bondReductionNewBond     float64 = 8
+)
+
+func getBondReductions(c *cli.Context) (*api.GetBondReductionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetBondReductionsResponse{
+		AutoCompleteEnabled: cfg.Smartnode.GetTaskGasThreshold(config.Task_ReduceBonds) != 0,
+		RplStake:            big.NewInt(0),
+		MinimumRplStake:     big.NewInt(0),
+		RplPerReduction:     big.NewInt(0),
+		Minipools:           []api.MinipoolBondReductionDetails{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's state at the head of the chain
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address, false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	response.IsAtlasDeployed = networkState.IsAtlasDeployed
+	if !response.IsAtlasDeployed {
+		return &response, nil
+	}
+	nodeDetails, exists := networkState.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return nil, fmt.Errorf("node %s was not found in the network state", nodeAccount.Address.Hex())
+	}
+
+	// Get the bond reduction settings
+	response.BondReductionEnabled, err = protocol.GetBondReductionEnabled(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if bond reduction is enabled: %w", err)
+	}
+	response.IsFeeDistributorInitialized = nodeDetails.FeeDistributorInitialised
+	response.WindowStart = networkState.NetworkDetails.BondReductionWindowStart
+	response.WindowLength = networkState.NetworkDetails.BondReductionWindowLength
+	networkFee := networkState.NetworkDetails.NodeFee
+
+	// Sort the node's minipools into the phases of the bond reduction process
+	now := time.Now()
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Finalised || eth.WeiToEth(mpd.NodeDepositBalance) != bondReductionCurrentBond {
+			continue
+		}
+		details := api.MinipoolBondReductionDetails{
+			Address:    mpd.MinipoolAddress,
+			NodeFee:    eth.WeiToEth(mpd.NodeFee),
+			NewNodeFee: eth.WeiToEth(mpd.NodeFee),
+		}
+		if networkFee > details.NewNodeFee {
+			details.NewNodeFee = networkFee
+		}
+		validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		if exists {
+			details.Balance = validator.Balance
+			details.BeaconState = validator.Status
+		}
+
+		// Check for a reduction that's already underway
+		if mpd.ReduceBondTime != nil && mpd.ReduceBondTime.Sign() > 0 {
+			details.ReduceBondTime = time.Unix(mpd.ReduceBondTime.Int64(), 0)
+			details.WindowStart = details.ReduceBondTime.Add(response.WindowStart)
+			details.WindowEnd = details.WindowStart.Add(response.WindowLength)
+		}
+		switch {
+		case mpd.ReduceBondCancelled:
+			details.Phase = api.BondReductionPhase_Scrubbed
+		case !details.ReduceBondTime.IsZero() && now.Before(details.WindowStart):
+			details.Phase = api.BondReductionPhase_Waiting
+			response.PendingReductions++
+		case !details.ReduceBondTime.IsZero() && now.Before(details.WindowEnd):
+			details.Phase = api.BondReductionPhase_Ready
+			response.PendingReductions++
+		default:
+			// Either no reduction was started, or the last one timed out
+			details.IneligibleReason = getBondReductionIneligibility(mpd.Status, mpd.Version, details.Balance, details.BeaconState, exists)
+			if details.IneligibleReason == "" {
+				details.Phase = api.BondReductionPhase_Eligible
+			} else {
+				details.Phase = api.BondReductionPhase_Ineligible
+			}
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+
+	// Get the collateral impact; the minimum stake includes reductions that have already been started
+	rplPrice := networkState.NetworkDetails.RplPrice
+	if nodeDetails.RplStake != nil {
+		response.RplStake = nodeDetails.RplStake
+	}
+	if rplPrice != nil && rplPrice.Sign() > 0 && nodeDetails.EthMatched != nil {
+		reductionEth := eth.EthToWei(bondReductionCurrentBond - bondReductionNewBond)
+		minCollateral := networkState.NetworkDetails.MinCollateralFraction
+
+		response.RplPerReduction = new(big.Int).Mul(reductionEth, minCollateral)
+		response.RplPerReduction.Div(response.RplPerReduction, rplPrice)
+
+		matched := new(big.Int).Mul(reductionEth, new(big.Int).SetUint64(response.PendingReductions))
+		matched.Add(matched, nodeDetails.EthMatched)
+		response.MinimumRplStake = matched.Mul(matched, minCollateral)
+		response.MinimumRplStake.Div(response.MinimumRplStake, rplPrice)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the reason a minipool can't begin a bond reduction, or an empty string if it can
+func getBondReductionIneligibility(status types.MinipoolStatus, version uint8, balance uint64, beaconState beacon.ValidatorState, onBeacon bool) string {
+	if status != types.Staking {
+		return fmt.Sprintf("the minipool is in the %s state instead of staking", status.String())
+	}
+	if version < 3 {
+		return "the minipool delegate is too old; upgrade it with `rocketpool minipool delegate-upgrade`"
+	}
+	if !onBeacon {
+		return "the validator was not found on the Beacon Chain"
+	}
+	if !(beaconState == beacon.ValidatorState_PendingInitialized ||
+		beaconState == beacon.ValidatorState_PendingQueued ||
+		beaconState == beacon.ValidatorState_ActiveOngoing) {
+		return fmt.Sprintf("the validator is %s on the Beacon Chain; it must be pending or active", beaconState)
+	}
+	if balance < 32000000000 {
+		return fmt.Sprintf("the validator balance is %.6f ETH; it must be at least 32 ETH", float64(balance)/1e9)
+	}
+	return ""
+}
@@ -130,15 +130,26 @@ func getMinipoolCloseDetailsForNode(c *cli.Context) (*api.GetMinipoolCloseDetail
 		return nil, fmt.Errorf("error getting beacon status of minipools: %w", err)
 	}
 
+	// The withdrawal has to be done in the finalized state too, so a reorg can't take it back
+	finalizedStatusMap, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+		StateId: "finalized",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting finalized beacon status of minipools: %w", err)
+	}
+
 	// Review closeability based on validator status
 	for i, mp := range details {
 		pubkey := pubkeyMap[mp.Address]
 		validator := statusMap[pubkey]
-		if mp.MinipoolStatus != types.Dissolved {
-			details[i].BeaconState = validator.Status
-			if validator.Status != beacon.ValidatorState_WithdrawalDone {
-				details[i].CanClose = false
-			}
+		if mp.MinipoolStatus == types.Dissolved {
+			details[i].ExitFinalized = true
+			continue
+		}
+		details[i].BeaconState = validator.Status
+		details[i].ExitFinalized = finalizedStatusMap[pubkey].Status == beacon.ValidatorState_WithdrawalDone
+		if !details[i].ExitFinalized {
+			details[i].CanClose = false
 		}
 	}
 
@@ -318,6 +329,9 @@ func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMi
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	if err := services.RequireWithdrawalAddressSafety(c, minipoolAddress); err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -55,6 +55,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-deposit-data",
+				Usage:     "Get the deposit data for a prelaunch minipool's stake deposit, in the launchpad format",
+				UsageText: "rocketpool api minipool get-deposit-data minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolDepositData(c, minipoolAddress))
+					return nil
+
+				},
+			},
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -635,6 +656,41 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-bond-reductions",
+				Usage:     "Get the bond reduction eligibility and progress of all of the node's 16 ETH minipools",
+				UsageText: "rocketpool api minipool get-bond-reductions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBondReductions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "migrate-plan",
+				Usage:     "Plan the bond reductions, RPL stake and deposit credit for migrating the node's 16 ETH minipools to 8 ETH bonds",
+				UsageText: "rocketpool api minipool migrate-plan",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMigratePlan(c))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "get-distribute-balance-details",
@@ -689,7 +745,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(1))
+					mnemonic, err := api.GetSecretArg(c, 1)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -715,7 +775,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(1))
+					mnemonic, err := api.GetSecretArg(c, 1)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -740,7 +804,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(1))
+					mnemonic, err := api.GetSecretArg(c, 1)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -751,6 +819,158 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "exit-advice",
+				Usage:     "Estimate the return of keeping each of the node's minipools versus exiting it and redeploying its ETH",
+				UsageText: "rocketpool api minipool exit-advice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getExitAdvice(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "projected-income",
+				Usage:     "Estimate the ETH each of the node's staking minipools will earn over a year at the recent staking APR",
+				UsageText: "rocketpool api minipool projected-income",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getProjectedIncome(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "performance",
+				Usage:     "Score the attestations and proposals of the node's minipools over a number of epochs against the network average",
+				UsageText: "rocketpool api minipool performance epochs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					epochs, err := cliutils.ValidatePositiveUint("epochs", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPerformance(c, epochs))
+					return nil
+
+				},
+			},
+			{
+				Name:      "labels",
+				Usage:     "Get the labels given to the node's minipools",
+				UsageText: "rocketpool api minipool labels",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getLabels(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-label",
+				Usage:     "Set the label of one of the node's minipools; an empty label removes it",
+				UsageText: "rocketpool api minipool set-label minipool-address label",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setLabel(c, minipoolAddress, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "tags",
+				Usage:     "Get the tags given to the node's minipools; a non-empty tag only includes the minipools with that tag",
+				UsageText: "rocketpool api minipool tags tag",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTags(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "tag",
+				Usage:     "Add a tag to one of the node's minipools",
+				UsageText: "rocketpool api minipool tag minipool-address tag",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(tagMinipool(c, minipoolAddress, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "untag",
+				Usage:     "Remove a tag from one of the node's minipools",
+				UsageText: "rocketpool api minipool untag minipool-address tag",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(untagMinipool(c, minipoolAddress, c.Args().Get(1)))
+					return nil
+
+				},
+			},
 		},
 	})
 }
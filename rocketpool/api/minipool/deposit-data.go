@@ -0,0 +1,162 @@
+package minipool
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// The deposit CLI version to report in exported deposit data; the launchpad and most tools reject files from versions before 1.0.0
+const launchpadDepositCliVersion string = "2.3.0"
+
+func getMinipoolDepositData(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolDepositDataResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Only prelaunch minipools still have a stake deposit to make
+	status, err := mp.GetStatusDetails(nil)
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != rptypes.Prelaunch {
+		return nil, fmt.Errorf("minipool %s is not in prelaunch (current status: %s)", minipoolAddress.Hex(), status.Status.String())
+	}
+	if status.IsVacant {
+		return nil, fmt.Errorf("minipool %s is a vacant minipool for a migrated solo validator, so it does not have a stake deposit", minipoolAddress.Hex())
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get minipool withdrawal credentials
+	withdrawalCredentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, mp.GetAddress(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the validator key for the minipool
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
+	if err != nil {
+		return nil, err
+	}
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the stake amount for the minipool type
+	var depositType rptypes.MinipoolDeposit
+	isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
+	}
+	if !isAtlasDeployed {
+		depositType, err = mp.GetDepositType(nil)
+	} else {
+		depositType, err = minipool.GetMinipoolDepositType(rp, mp.GetAddress(), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposit type for minipool %s: %w", mp.GetAddress().Hex(), err)
+	}
+	var depositAmount uint64
+	switch depositType {
+	case rptypes.Full, rptypes.Half, rptypes.Empty:
+		depositAmount = uint64(16e9) // 16 ETH in gwei
+	case rptypes.Variable:
+		depositAmount = uint64(31e9) // 31 ETH in gwei
+	default:
+		return nil, fmt.Errorf("error getting deposit data for minipool %s: unknown deposit type %d", mp.GetAddress().Hex(), depositType)
+	}
+
+	// Get validator deposit data
+	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	if err != nil {
+		return nil, err
+	}
+	depositMessage := eth2.DepositDataNoSignature{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposit message root for minipool %s: %w", mp.GetAddress().Hex(), err)
+	}
+
+	// Get the network name the launchpad uses
+	var networkName string
+	switch cfg.Smartnode.Network.Value.(cfgtypes.Network) {
+	case cfgtypes.Network_Mainnet:
+		networkName = "mainnet"
+	default:
+		// The devnet runs on the Prater beacon chain
+		networkName = "prater"
+	}
+
+	response.DepositData = api.LaunchpadDepositData{
+		Pubkey:                hex.EncodeToString(depositData.PublicKey),
+		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
+		Amount:                depositData.Amount,
+		Signature:             hex.EncodeToString(depositData.Signature),
+		DepositMessageRoot:    hex.EncodeToString(depositMessageRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(depositDataRoot[:]),
+		ForkVersion:           hex.EncodeToString(eth2Config.GenesisForkVersion),
+		NetworkName:           networkName,
+		DepositCliVersion:     launchpadDepositCliVersion,
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -205,6 +205,9 @@ func distributeBalance(c *cli.Context, minipoolAddress common.Address) (*api.Clo
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	if err := services.RequireWithdrawalAddressSafety(c, minipoolAddress); err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -8,6 +10,7 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -67,6 +70,9 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
+	if err := services.RequireWithdrawalAddressSafety(c, minipoolAddress); err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -124,6 +130,13 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 		return nil, err
 	}
 
+	// Record the exit
+	services.RecordEvent(c, journal.Event{
+		Type:     journal.EventType_MinipoolExited,
+		Minipool: &minipoolAddress,
+		Message:  fmt.Sprintf("Broadcast a voluntary exit for minipool %s (validator %d) at epoch %d.", minipoolAddress.Hex(), validatorIndex, head.Epoch),
+	})
+
 	// Return response
 	return &response, nil
 
@@ -0,0 +1,100 @@
+package minipool
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getLabels(c *cli.Context) (*api.MinipoolLabelsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolLabelsResponse{}
+
+	// Get the labels, sorted by address so the order is stable
+	book, err := addressbook.Load(cfg.Smartnode.GetMinipoolLabelsPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Labels = []api.MinipoolLabel{}
+	for address, label := range book.GetLabels() {
+		response.Labels = append(response.Labels, api.MinipoolLabel{
+			Address: address,
+			Label:   label,
+		})
+	}
+	sort.Slice(response.Labels, func(i, j int) bool {
+		return bytes.Compare(response.Labels[i].Address.Bytes(), response.Labels[j].Address.Bytes()) < 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+func setLabel(c *cli.Context, minipoolAddress common.Address, label string) (*api.SetMinipoolLabelResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetMinipoolLabelResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Update the label
+	book, err := addressbook.Load(cfg.Smartnode.GetMinipoolLabelsPath())
+	if err != nil {
+		return nil, err
+	}
+	if err := book.SetLabel(minipoolAddress, label); err != nil {
+		return nil, err
+	}
+	if err := book.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,203 @@
+package minipool
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMigratePlan(c *cli.Context) (*api.MinipoolMigratePlanResponse, error) {
+
+	// Get the bond reductions; this checks the node is registered and the clients are synced
+	reductions, err := getBondReductions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolMigratePlanResponse{
+		BondReductions:           *reductions,
+		RplBalance:               big.NewInt(0),
+		CreditBalance:            big.NewInt(0),
+		MaximumRplStake:          big.NewInt(0),
+		Steps:                    []api.MigratePlanStep{},
+		RplToStake:               big.NewInt(0),
+		RplShortfall:             big.NewInt(0),
+		BlockedMinipools:         []api.MinipoolBondReductionDetails{},
+		ProjectedCreditBalance:   big.NewInt(0),
+		ProjectedMaximumRplStake: big.NewInt(0),
+		ProjectedWithdrawableRpl: big.NewInt(0),
+	}
+	if !reductions.IsAtlasDeployed {
+		return &response, nil
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	var rplPrice *big.Int
+	var maxCollateral *big.Int
+	wg.Go(func() error {
+		var err error
+		response.RplBalance, err = tokens.GetRPLBalance(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.CreditBalance, err = node.GetNodeDepositCredit(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaximumRplStake, err = node.GetNodeMaximumRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplPrice, err = network.GetRPLPrice(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maxCollateral, err = protocol.GetMaximumPerMinipoolStakeRaw(rp, nil)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Sort the minipools into the phases of the bond reduction process
+	var ready, waiting, eligible []api.MinipoolBondReductionDetails
+	for _, minipool := range reductions.Minipools {
+		switch minipool.Phase {
+		case api.BondReductionPhase_Ready:
+			ready = append(ready, minipool)
+		case api.BondReductionPhase_Waiting:
+			waiting = append(waiting, minipool)
+		case api.BondReductionPhase_Eligible:
+			eligible = append(eligible, minipool)
+		}
+	}
+
+	// Complete the reductions that are ready first, since their windows are closing
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].WindowEnd.Before(ready[j].WindowEnd)
+	})
+	for _, minipool := range ready {
+		response.Steps = append(response.Steps, api.MigratePlanStep{
+			Action:     api.MigratePlanAction_CompleteReduction,
+			Minipool:   minipool.Address,
+			NodeFee:    minipool.NodeFee,
+			NewNodeFee: minipool.NewNodeFee,
+			WindowEnd:  minipool.WindowEnd,
+		})
+	}
+	for _, minipool := range waiting {
+		response.Steps = append(response.Steps, api.MigratePlanStep{
+			Action:     api.MigratePlanAction_WaitForReduction,
+			Minipool:   minipool.Address,
+			NodeFee:    minipool.NodeFee,
+			NewNodeFee: minipool.NewNodeFee,
+			WindowEnd:  minipool.WindowEnd,
+		})
+	}
+
+	// Start the new reductions with the biggest commission gain first, so the RPL goes where it earns the most
+	sort.SliceStable(eligible, func(i, j int) bool {
+		gainI := eligible[i].NewNodeFee - eligible[i].NodeFee
+		gainJ := eligible[j].NewNodeFee - eligible[j].NodeFee
+		if gainI != gainJ {
+			return gainI > gainJ
+		}
+		return eligible[i].Balance > eligible[j].Balance
+	})
+
+	// Work out how many of them the node's stake and wallet RPL can cover
+	planned := eligible
+	if len(eligible) > 0 && reductions.RplPerReduction.Sign() > 0 {
+		available := new(big.Int).Add(reductions.RplStake, response.RplBalance)
+		coverable := uint64(0)
+		if available.Cmp(reductions.MinimumRplStake) > 0 {
+			excess := new(big.Int).Sub(available, reductions.MinimumRplStake)
+			coverable = excess.Div(excess, reductions.RplPerReduction).Uint64()
+		}
+		if coverable < uint64(len(eligible)) {
+			planned = eligible[:coverable]
+			response.BlockedMinipools = eligible[coverable:]
+
+			// Get how much more RPL it would take to cover all of them
+			required := new(big.Int).Mul(reductions.RplPerReduction, big.NewInt(int64(len(eligible))))
+			required.Add(required, reductions.MinimumRplStake)
+			response.RplShortfall.Sub(required, available)
+		}
+
+		// Stake just enough RPL to cover the planned reductions
+		required := new(big.Int).Mul(reductions.RplPerReduction, big.NewInt(int64(len(planned))))
+		required.Add(required, reductions.MinimumRplStake)
+		if required.Cmp(reductions.RplStake) > 0 {
+			response.RplToStake.Sub(required, reductions.RplStake)
+			response.Steps = append(response.Steps, api.MigratePlanStep{
+				Action:    api.MigratePlanAction_StakeRpl,
+				RplAmount: new(big.Int).Set(response.RplToStake),
+			})
+		}
+	}
+	for _, minipool := range planned {
+		response.Steps = append(response.Steps, api.MigratePlanStep{
+			Action:     api.MigratePlanAction_BeginReduction,
+			Minipool:   minipool.Address,
+			RplAmount:  new(big.Int).Set(reductions.RplPerReduction),
+			NodeFee:    minipool.NodeFee,
+			NewNodeFee: minipool.NewNodeFee,
+		})
+	}
+
+	// Project the node's position once every planned reduction is complete.
+	// Each one gives the node 8 ETH of deposit credit and lowers the RPL it can have staked before it can withdraw any.
+	reductionCount := int64(len(ready) + len(waiting) + len(planned))
+	reductionEth := eth.EthToWei(bondReductionCurrentBond - bondReductionNewBond)
+	creditGain := new(big.Int).Mul(reductionEth, big.NewInt(reductionCount))
+	response.ProjectedCreditBalance.Add(response.CreditBalance, creditGain)
+	response.ProjectedMaximumRplStake.Set(response.MaximumRplStake)
+	if rplPrice != nil && rplPrice.Sign() > 0 && maxCollateral != nil {
+		maxDrop := new(big.Int).Mul(creditGain, maxCollateral)
+		maxDrop.Div(maxDrop, rplPrice)
+		response.ProjectedMaximumRplStake.Sub(response.ProjectedMaximumRplStake, maxDrop)
+		if response.ProjectedMaximumRplStake.Sign() < 0 {
+			response.ProjectedMaximumRplStake.SetUint64(0)
+		}
+	}
+	projectedStake := new(big.Int).Add(reductions.RplStake, response.RplToStake)
+	if projectedStake.Cmp(response.ProjectedMaximumRplStake) > 0 {
+		response.ProjectedWithdrawableRpl.Sub(projectedStake, response.ProjectedMaximumRplStake)
+	}
+
+	// Return response
+	return &response, nil
+
+}
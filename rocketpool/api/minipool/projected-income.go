@@ -0,0 +1,86 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getProjectedIncome(c *cli.Context) (*api.MinipoolProjectedIncomeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolProjectedIncomeResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's state at the head of the chain
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address, false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Estimate the recent staking APR
+	response.StakingApr, err = rputils.GetRecentStakingApr(rp, networkState.ElBlockNumber, networkState.NetworkDetails.NodeFee)
+	if err != nil {
+		return nil, fmt.Errorf("error estimating the staking APR: %w", err)
+	}
+
+	// Project the income of each staking minipool
+	response.Minipools = []api.MinipoolProjectedIncome{}
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Finalised || mpd.Status != types.Staking {
+			continue
+		}
+		nodeFee := eth.WeiToEth(mpd.NodeFee)
+		depositSize := eth.WeiToEth(mpd.NodeDepositBalance)
+		response.Minipools = append(response.Minipools, api.MinipoolProjectedIncome{
+			Address:               mpd.MinipoolAddress,
+			NodeFee:               nodeFee,
+			DepositSize:           depositSize,
+			ProjectedAnnualIncome: rputils.GetProjectedAnnualIncome(depositSize, nodeFee, response.StakingApr),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/urfave/cli"
@@ -158,6 +160,14 @@ func beginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, newBo
 	}
 	response.TxHash = hash
 
+	// Record the request
+	services.RecordEvent(c, journal.Event{
+		Type:     journal.EventType_BondReductionStarted,
+		Minipool: &minipoolAddress,
+		TxHash:   &hash,
+		Message:  fmt.Sprintf("Started reducing the bond of minipool %s to %.6f ETH.", minipoolAddress.Hex(), eth.WeiToEth(newBondAmountWei)),
+	})
+
 	// Return response
 	return &response, nil
 }
@@ -250,6 +260,14 @@ func reduceBondAmount(c *cli.Context, minipoolAddress common.Address) (*api.Redu
 	}
 	response.TxHash = hash
 
+	// Record the reduction
+	services.RecordEvent(c, journal.Event{
+		Type:     journal.EventType_BondReduced,
+		Minipool: &minipoolAddress,
+		TxHash:   &hash,
+		Message:  fmt.Sprintf("Reduced the bond of minipool %s.", minipoolAddress.Hex()),
+	})
+
 	// Return response
 	return &response, nil
 }
@@ -12,6 +12,7 @@ import (
 
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -260,6 +261,14 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	}
 	response.TxHash = hash
 
+	// Record the stake
+	services.RecordEvent(c, journal.Event{
+		Type:     journal.EventType_MinipoolStaked,
+		Minipool: &minipoolAddress,
+		TxHash:   &hash,
+		Message:  fmt.Sprintf("Staked minipool %s.", minipoolAddress.Hex()),
+	})
+
 	// Return response
 	return &response, nil
 
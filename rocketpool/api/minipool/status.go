@@ -6,10 +6,16 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// Get the status of the node's minipools; used by the node daemon's HTTP API
+func GetStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
+	return getStatus(c)
+}
+
 func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 
 	// Get services
@@ -59,6 +65,20 @@ func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 	}
 	response.Minipools = details
 
+	// Add the minipool labels and tags
+	book, err := addressbook.Load(cfg.Smartnode.GetMinipoolLabelsPath())
+	if err != nil {
+		return nil, err
+	}
+	tagBook, err := addressbook.LoadTags(cfg.Smartnode.GetMinipoolTagsPath())
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Minipools {
+		response.Minipools[i].Label = book.GetLabel(response.Minipools[i].Address)
+		response.Minipools[i].Tags = tagBook.GetTags(response.Minipools[i].Address)
+	}
+
 	delegate, err := rp.GetContract("rocketMinipoolDelegate", nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting latest minipool delegate contract: %w", err)
@@ -0,0 +1,139 @@
+package minipool
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTags(c *cli.Context, tag string) (*api.MinipoolTagsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolTagsResponse{}
+
+	// Get the tags, sorted by address so the order is stable; if a tag was given, only minipools with it are included
+	tagBook, err := addressbook.LoadTags(cfg.Smartnode.GetMinipoolTagsPath())
+	if err != nil {
+		return nil, err
+	}
+	book, err := addressbook.Load(cfg.Smartnode.GetMinipoolLabelsPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Tags = []api.MinipoolTags{}
+	for address, tags := range tagBook.GetAllTags() {
+		if tag != "" && !tagBook.HasTag(address, tag) {
+			continue
+		}
+		response.Tags = append(response.Tags, api.MinipoolTags{
+			Address: address,
+			Label:   book.GetLabel(address),
+			Tags:    tags,
+		})
+	}
+	sort.Slice(response.Tags, func(i, j int) bool {
+		return bytes.Compare(response.Tags[i].Address.Bytes(), response.Tags[j].Address.Bytes()) < 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+func tagMinipool(c *cli.Context, minipoolAddress common.Address, tag string) (*api.TagMinipoolResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TagMinipoolResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Add the tag
+	tagBook, err := addressbook.LoadTags(cfg.Smartnode.GetMinipoolTagsPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Changed, err = tagBook.AddTag(minipoolAddress, tag)
+	if err != nil {
+		return nil, err
+	}
+	if response.Changed {
+		if err := tagBook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func untagMinipool(c *cli.Context, minipoolAddress common.Address, tag string) (*api.TagMinipoolResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TagMinipoolResponse{}
+
+	// Remove the tag; this doesn't need the minipool to exist, so tags on closed minipools can be cleaned up
+	tagBook, err := addressbook.LoadTags(cfg.Smartnode.GetMinipoolTagsPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Changed = tagBook.RemoveTag(minipoolAddress, tag)
+	if response.Changed {
+		if err := tagBook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
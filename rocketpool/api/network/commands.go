@@ -53,6 +53,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "fee-suggestion",
+				Usage:     "Get EIP-1559 fee suggestions from the Execution client's recent fee history",
+				UsageText: "rocketpool api network fee-suggestion",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getFeeSuggestion(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"s"},
@@ -159,7 +177,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "download-rewards-file",
 				Aliases:   []string{"drf"},
-				Usage:     "Download a rewards info file from IPFS for the given interval",
+				Usage:     "Download a rewards info file from IPFS or a mirror for the given interval",
 				UsageText: "rocketpool api service download-rewards-file interval",
 				Action: func(c *cli.Context) error {
 
@@ -168,7 +186,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
-					interval, err := cliutils.ValidatePositiveUint("interval", c.Args().Get(0))
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
 					if err != nil {
 						return err
 					}
@@ -180,6 +198,30 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-file",
+				Aliases:   []string{"vrf"},
+				Usage:     "Verify the local rewards tree file for the given interval against the submitted Merkle root and the file published under the submitted CID",
+				UsageText: "rocketpool api network verify-rewards-file interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyRewardsFile(c, interval))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "is-atlas-deployed",
 				Aliases:   []string{"iad"},
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getFeeSuggestion(c *cli.Context) (*api.NetworkFeeSuggestionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkFeeSuggestionResponse{}
+
+	// Get the suggestions
+	response.Suggestion, err = feehistory.GetGasPrices(ec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
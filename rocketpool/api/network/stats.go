@@ -19,6 +19,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
@@ -51,6 +52,15 @@ func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
 		return err
 	})
 
+	// Get the deposit pool excess
+	wg.Go(func() error {
+		excess, err := deposit.GetExcessBalance(rp, nil)
+		if err == nil {
+			response.DepositPoolExcess = eth.WeiToEth(excess)
+		}
+		return err
+	})
+
 	// Get the total minipool capacity
 	wg.Go(func() error {
 		minipoolQueueCapacity, err := minipool.GetQueueCapacity(rp, nil)
@@ -165,6 +175,21 @@ func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
 		return err
 	})
 
+	// Get the rETH APR; the rest of the stats are still reported without it
+	wg.Go(func() error {
+		blockNumber, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("error getting latest block number: %w", err)
+		}
+		rethApr, err := rputils.GetRecentRethApr(rp, blockNumber)
+		if err != nil {
+			response.RethAprError = err.Error()
+		} else {
+			response.RethApr = rethApr
+		}
+		return nil
+	})
+
 	// Get smoothing pool status
 	wg.Go(func() error {
 		smoothingPoolNodes, err := node.GetSmoothingPoolRegisteredNodeCount(rp, nil)
@@ -197,6 +222,9 @@ func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
 		return nil, err
 	}
 
+	// Get the node demand
+	response.NodeDemand = response.DepositPoolBalance - response.MinipoolCapacity
+
 	// Get the TVL
 	activeMinipools := response.InitializedMinipoolCount +
 		response.PrelaunchMinipoolCount +
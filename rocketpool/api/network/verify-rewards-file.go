@@ -0,0 +1,103 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Check the local rewards tree file for an interval against the Merkle root the Oracle DAO submitted and the file
+// published under the submitted CID. This doesn't need a node wallet, so any node can audit the rewards.
+func verifyRewardsFile(c *cli.Context, interval uint64) (*api.VerifyRewardsFileResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyRewardsFileResponse{
+		Interval:        interval,
+		ExpectedNetwork: fmt.Sprint(cfg.Smartnode.Network.Value),
+		InvalidProofs:   []common.Address{},
+	}
+
+	// Make sure the interval has been submitted
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the current rewards interval: %w", err)
+	}
+	if interval >= currentIndex.Uint64() {
+		return nil, fmt.Errorf("interval %d hasn't been submitted yet (the current interval is %d)", interval, currentIndex.Uint64())
+	}
+
+	// Get the canonical Merkle root and CID
+	event, err := rprewards.GetRewardSnapshotEvent(rp, cfg, interval)
+	if err != nil {
+		return nil, fmt.Errorf("error getting interval %d info: %w", interval, err)
+	}
+	response.CID = event.MerkleTreeCID
+	response.CanonicalMerkleRoot = event.MerkleRoot
+
+	// Load the local file
+	response.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(interval, true)
+	_, err = os.Stat(response.TreeFilePath)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	response.TreeFileExists = true
+	rewardsFile, err := rprewards.LoadRewardsFile(response.TreeFilePath)
+	if err != nil {
+		return nil, err
+	}
+	response.FileIndex = rewardsFile.Index
+	response.FileNetwork = rewardsFile.Network
+	response.FileMerkleRoot = common.HexToHash(rewardsFile.MerkleRoot)
+
+	// Rebuild its tree
+	verification, err := rprewards.VerifyRewardsTree(rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error rebuilding the Merkle tree from %s: %w", response.TreeFilePath, err)
+	}
+	response.ComputedMerkleRoot = verification.ComputedMerkleRoot
+	response.InvalidProofs = verification.InvalidProofs
+
+	// Compare it to the file published under the CID
+	publishedFile, err := rprewards.DownloadPublishedRewardsFile(cfg, interval, response.CID)
+	if err != nil {
+		response.PublishedFileError = err.Error()
+	} else {
+		response.PublishedFileChecked = true
+		response.PublishedMerkleRoot = common.HexToHash(publishedFile.MerkleRoot)
+		publishedVerification, err := rprewards.VerifyRewardsTree(publishedFile)
+		if err != nil {
+			response.PublishedFileError = fmt.Sprintf("error rebuilding the Merkle tree from the published file: %s", err.Error())
+		} else {
+			response.PublishedComputedRoot = publishedVerification.ComputedMerkleRoot
+			response.PublishedFileMismatch = (response.PublishedComputedRoot != response.ComputedMerkleRoot)
+		}
+	}
+
+	response.Verified = response.FileIndex == interval &&
+		response.FileNetwork == response.ExpectedNetwork &&
+		response.FileMerkleRoot == response.CanonicalMerkleRoot &&
+		response.ComputedMerkleRoot == response.CanonicalMerkleRoot &&
+		len(response.InvalidProofs) == 0 &&
+		response.PublishedFileChecked && response.PublishedFileError == "" && !response.PublishedFileMismatch
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,221 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+)
+
+// A contract event that's part of the node's on-chain activity
+type nodeActivityEvent struct {
+	contractName   string
+	eventName      string
+	activityType   string
+	nodeTopicIndex int
+}
+
+// The events to build the node's activity history from
+var nodeActivityEvents = []nodeActivityEvent{
+	{contractName: "rocketNodeStaking", eventName: "RPLStaked", activityType: "RPL Staked", nodeTopicIndex: 1},
+	{contractName: "rocketNodeStaking", eventName: "RPLWithdrawn", activityType: "RPL Withdrawn", nodeTopicIndex: 1},
+	{contractName: "rocketNodeStaking", eventName: "RPLSlashed", activityType: "RPL Slashed", nodeTopicIndex: 1},
+	{contractName: "rocketMinipoolManager", eventName: "MinipoolCreated", activityType: "Minipool Created", nodeTopicIndex: 2},
+	{contractName: "rocketMerkleDistributorMainnet", eventName: "RewardsClaimed", activityType: "Rewards Claimed", nodeTopicIndex: 1},
+}
+
+func getNodeActivity(c *cli.Context) (*api.NodeActivityResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeActivityResponse{
+		Activity: []api.NodeActivity{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Get the activity
+	response.Activity, err = getActivityFromEvents(rp, nodeAccount.Address, intervalSize, nodeActivityEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's activity from the logs of the provided events, sorted chronologically
+func getActivityFromEvents(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int, events []nodeActivityEvent) ([]api.NodeActivity, error) {
+
+	activities := []api.NodeActivity{}
+	blockTimes := map[uint64]time.Time{}
+	contractAddresses := map[string][]common.Address{}
+	for _, event := range events {
+		contract, err := rp.GetContract(event.contractName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting contract %s: %w", event.contractName, err)
+		}
+		abiEvent, exists := contract.ABI.Events[event.eventName]
+		if !exists {
+			// Older contract versions may not have this event
+			continue
+		}
+
+		// Get every address the contract has been deployed at; only logs matching the current event signature are returned
+		addresses, exists := contractAddresses[event.contractName]
+		if !exists {
+			addresses, err = getContractAddresses(rp, event.contractName, intervalSize)
+			if err != nil {
+				return nil, err
+			}
+			contractAddresses[event.contractName] = addresses
+		}
+
+		// Get the logs for the node
+		topicFilter := make([][]common.Hash, event.nodeTopicIndex+1)
+		topicFilter[0] = []common.Hash{abiEvent.ID}
+		topicFilter[event.nodeTopicIndex] = []common.Hash{nodeAddress.Hash()}
+		logs, err := logscan.GetLogs(rp, addresses, topicFilter, intervalSize.Uint64(), nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s events: %w", event.eventName, err)
+		}
+
+		for _, log := range logs {
+			values := map[string]interface{}{}
+			err = abiEvent.Inputs.UnpackIntoMap(values, log.Data)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding %s event in transaction %s: %w", event.eventName, log.TxHash.Hex(), err)
+			}
+
+			// Get the block time
+			blockTime, err := getBlockTime(rp, blockTimes, log.BlockNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			activity := api.NodeActivity{
+				BlockNumber: log.BlockNumber,
+				Time:        blockTime,
+				TxHash:      log.TxHash,
+				Type:        event.activityType,
+				RplAmount:   big.NewInt(0),
+				EthAmount:   big.NewInt(0),
+			}
+			switch event.eventName {
+			case "RPLStaked", "RPLWithdrawn":
+				activity.RplAmount = values["amount"].(*big.Int)
+			case "RPLSlashed":
+				activity.RplAmount = values["amount"].(*big.Int)
+				activity.EthAmount = values["ethValue"].(*big.Int)
+			case "MinipoolCreated":
+				activity.Minipool = common.BytesToAddress(log.Topics[1].Bytes())
+			case "RewardsClaimed":
+				// Break the claim down by interval
+				indices := values["rewardIndex"].([]*big.Int)
+				rplAmounts := values["amountRPL"].([]*big.Int)
+				ethAmounts := values["amountETH"].([]*big.Int)
+				for i, index := range indices {
+					interval := index.Uint64()
+					claim := activity
+					claim.Interval = &interval
+					claim.RplAmount = rplAmounts[i]
+					claim.EthAmount = ethAmounts[i]
+					activities = append(activities, claim)
+				}
+				continue
+			}
+			activities = append(activities, activity)
+		}
+	}
+
+	// Sort the activity chronologically
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].BlockNumber < activities[j].BlockNumber
+	})
+	return activities, nil
+
+}
+
+// Get the current and all previous addresses of a contract from the trusted node upgrade history
+func getContractAddresses(rp *rocketpool.RocketPool, contractName string, intervalSize *big.Int) ([]common.Address, error) {
+	upgradeContract, err := rp.GetContract("rocketDAONodeTrustedUpgrade", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract rocketDAONodeTrustedUpgrade: %w", err)
+	}
+	currentAddress, err := rp.GetAddress(contractName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting address of %s: %w", contractName, err)
+	}
+
+	// The old address is the second indexed argument of ContractUpgraded
+	topicFilter := [][]common.Hash{{upgradeContract.ABI.Events["ContractUpgraded"].ID}, {crypto.Keccak256Hash([]byte(contractName))}}
+	logs, err := logscan.GetLogs(rp, []common.Address{*upgradeContract.Address}, topicFilter, intervalSize.Uint64(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting upgrade history of %s: %w", contractName, err)
+	}
+	addresses := []common.Address{*currentAddress}
+	seen := map[common.Address]bool{*currentAddress: true}
+	for _, log := range logs {
+		if len(log.Topics) < 3 {
+			continue
+		}
+		address := common.BytesToAddress(log.Topics[2].Bytes())
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+// Get the time of a block, caching it for other events in the same block
+func getBlockTime(rp *rocketpool.RocketPool, blockTimes map[uint64]time.Time, blockNumber uint64) (time.Time, error) {
+	blockTime, exists := blockTimes[blockNumber]
+	if !exists {
+		header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error getting header for block %d: %w", blockNumber, err)
+		}
+		blockTime = time.Unix(int64(header.Time), 0)
+		blockTimes[blockNumber] = blockTime
+	}
+	return blockTime, nil
+}
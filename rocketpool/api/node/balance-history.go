@@ -0,0 +1,59 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getBalanceHistory(c *cli.Context, days uint64) (*api.NodeBalanceHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	store, err := services.GetBalanceHistory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the whole history so the changes can be calculated over periods longer than the requested range
+	snapshots, err := store.Read(nodeAccount.Address, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	response := api.NodeBalanceHistoryResponse{
+		Node:      nodeAccount.Address,
+		Snapshots: []balancehistory.Snapshot{},
+		Deltas:    balancehistory.GetDeltas(snapshots, balancehistory.DeltaPeriods),
+	}
+
+	// Only return the snapshots in the requested range
+	cutoff := ""
+	if days > 0 {
+		cutoff = balancehistory.GetDate(time.Now().AddDate(0, 0, -int(days)))
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Date > cutoff {
+			response.Snapshots = append(response.Snapshots, snapshot)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
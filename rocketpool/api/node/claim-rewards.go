@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -304,6 +305,13 @@ func claimRewards(c *cli.Context, indicesString string) (*api.NodeClaimRewardsRe
 	}
 	response.TxHash = hash
 
+	// Record the claim
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_RewardsClaimed,
+		TxHash:  &hash,
+		Message: fmt.Sprintf("Claimed rewards for interval(s) %s.", indicesString),
+	})
+
 	// Return response
 	return &response, nil
 
@@ -410,6 +418,13 @@ func claimAndStakeRewards(c *cli.Context, indicesString string, stakeAmount *big
 	}
 	response.TxHash = hash
 
+	// Record the claim
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_RewardsClaimed,
+		TxHash:  &hash,
+		Message: fmt.Sprintf("Claimed rewards for interval(s) %s and restaked %.6f RPL.", indicesString, eth.WeiToEth(stakeAmount)),
+	})
+
 	// Return response
 	return &response, nil
 
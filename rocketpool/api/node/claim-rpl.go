@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/legacy/v1.0.0/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -120,6 +121,13 @@ func nodeClaimRpl(c *cli.Context) (*api.NodeClaimRplResponse, error) {
 	}
 	response.TxHash = hash
 
+	// Record the claim
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_RewardsClaimed,
+		TxHash:  &hash,
+		Message: "Claimed legacy RPL rewards.",
+	})
+
 	// Return response
 	return &response, nil
 
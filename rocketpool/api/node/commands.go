@@ -185,6 +185,126 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-rpl-withdrawal-address",
+				Usage:     "Get the node's RPL withdrawal address and any pending change to it",
+				UsageText: "rocketpool api node get-rpl-withdrawal-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRplWithdrawalAddress(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-set-rpl-withdrawal-address",
+				Usage:     "Checks if the node can set its RPL withdrawal address",
+				UsageText: "rocketpool api node can-set-rpl-withdrawal-address address confirm",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					rplWithdrawalAddress, err := cliutils.ValidateAddress("RPL withdrawal address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					confirm, err := cliutils.ValidateBool("confirm", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetRplWithdrawalAddress(c, rplWithdrawalAddress, confirm))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-rpl-withdrawal-address",
+				Usage:     "Set the node's RPL withdrawal address",
+				UsageText: "rocketpool api node set-rpl-withdrawal-address address confirm",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					rplWithdrawalAddress, err := cliutils.ValidateAddress("RPL withdrawal address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					confirm, err := cliutils.ValidateBool("confirm", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setRplWithdrawalAddress(c, rplWithdrawalAddress, confirm))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-confirm-rpl-withdrawal-address",
+				Usage:     "Checks if the node can confirm its pending RPL withdrawal address",
+				UsageText: "rocketpool api node can-confirm-rpl-withdrawal-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canConfirmRplWithdrawalAddress(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "confirm-rpl-withdrawal-address",
+				Usage:     "Confirms the node's RPL withdrawal address if it was set to the node address",
+				UsageText: "rocketpool api node confirm-rpl-withdrawal-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(confirmRplWithdrawalAddress(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-withdrawal-safety",
+				Usage:     "Check the node's withdrawal addresses and its minipools' withdrawal credentials against the expected ones",
+				UsageText: "rocketpool api node get-withdrawal-safety",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getWithdrawalSafety(c))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-set-timezone",
@@ -511,6 +631,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-rpl-withdrawal-plan",
+				Usage:     "Get the most staked RPL the node can withdraw while staying above a target collateral ratio, including pending bond reductions",
+				UsageText: "rocketpool api node get-rpl-withdrawal-plan target-collateral",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					targetCollateral, err := cliutils.ValidatePositiveOrZeroWeiAmount("target collateral", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRplWithdrawalPlan(c, targetCollateral))
+					return nil
+
+				},
+			},
 			{
 				Name:      "withdraw-rpl",
 				Aliases:   []string{"i"},
@@ -824,6 +965,26 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-typed-data",
+				Usage:     "Signs EIP-712 typed data with the node's private key.",
+				UsageText: "rocketpool api node sign-typed-data typed-data-json",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					typedData := c.Args().Get(0)
+
+					// Run
+					api.PrintResponse(signTypedData(c, typedData))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "estimate-set-snapshot-delegate-gas",
 				Usage:     "Estimate the gas required to set a voting snapshot delegate",
@@ -1128,6 +1289,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "optimize-claims",
+				Usage:     "Compare the gas and calldata costs of the ways the rewards for the given intervals can be claimed",
+				UsageText: "rocketpool api node optimize-claims 0,1,2,5,6 amount-to-restake",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					indicesString := c.Args().Get(0)
+
+					stakeAmount, err := cliutils.ValidateBigInt("stakeAmount", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(optimizeClaims(c, indicesString, stakeAmount))
+					return nil
+
+				},
+			},
 			{
 				Name:      "claim-and-stake-rewards",
 				Usage:     "Claim rewards for the given reward intervals and restake RPL automatically",
@@ -1335,6 +1519,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-deposit-plan",
+				Usage:     "Get how many minipools the node can create from its credit, its ETH balance and its RPL stake",
+				UsageText: "rocketpool api node get-deposit-plan",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDepositPlan(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "get-eth-balance",
 				Usage:     "Get the ETH balance of the node address",
@@ -1352,6 +1554,183 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "get-activity",
+				Usage:     "Get the history of the node's on-chain activity, such as RPL staking, minipool creation, and rewards claims",
+				UsageText: "rocketpool api node get-activity",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeActivity(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-rewards-history",
+				Usage:     "Get the node's rewards for every interval it took part in, along with the transactions that claimed them",
+				UsageText: "rocketpool api node get-rewards-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "balance-history",
+				Usage:     "Get the node's daily balance snapshots from the last number of days (0 for all of them) and the changes between them",
+				UsageText: "rocketpool api node balance-history days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBalanceHistory(c, days))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-withdrawal-ledger",
+				Usage:     "Get everything Rocket Pool has sent to the node's withdrawal address",
+				UsageText: "rocketpool api node get-withdrawal-ledger",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getWithdrawalLedger(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "commission-upgrades",
+				Usage:     "Find the actions that would raise the node's weighted average commission",
+				UsageText: "rocketpool api node commission-upgrades",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getCommissionUpgrades(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "duties-calendar",
+				Usage:     "Get the node's upcoming obligations, such as rewards checkpoints and sync committee duties, as calendar events",
+				UsageText: "rocketpool api node duties-calendar",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDutiesCalendar(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "history",
+				Usage:     "Get the events recorded in the node's event journal",
+				UsageText: "rocketpool api node history types since limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					since, err := cliutils.ValidateUint("since", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeHistory(c, c.Args().Get(0), since, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "graffiti",
+				Usage:     "Get the graffiti of each of the node's validators",
+				UsageText: "rocketpool api node graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGraffiti(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-graffiti",
+				Usage:     "Set the graffiti template of one of the node's validators; an empty template uses the node's template",
+				UsageText: "rocketpool api node set-graffiti pubkey template",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setGraffiti(c, pubkey, c.Args().Get(1)))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getCommissionUpgrades(c *cli.Context) (*api.NodeCommissionUpgradesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeCommissionUpgradesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's state at the head of the chain
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address, false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	response.IsAtlasDeployed = networkState.IsAtlasDeployed
+
+	// Find the upgrades
+	response.Report = rputils.GetCommissionUpgrades(networkState, nodeAccount.Address)
+
+	// Return response
+	return &response, nil
+
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -271,6 +272,15 @@ func createVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64
 	response.TxHash = tx.Hash()
 	response.MinipoolAddress = minipoolAddress
 
+	// Record the new minipool
+	txHash := tx.Hash()
+	services.RecordEvent(c, journal.Event{
+		Type:     journal.EventType_MinipoolCreated,
+		Minipool: &minipoolAddress,
+		TxHash:   &txHash,
+		Message:  fmt.Sprintf("Created vacant minipool %s for existing validator %s with a %.6f ETH bond.", minipoolAddress.Hex(), pubkey.Hex(), eth.WeiToEth(amountWei)),
+	})
+
 	// Return response
 	return &response, nil
 
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The bond sizes new minipools can be created with
+var depositPlanBondAmounts = []float64{8, 16}
+
+func getDepositPlan(c *cli.Context) (*api.NodeDepositPlanResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDepositPlanResponse{
+		Options: []api.NodeDepositPlanOption{},
+	}
+
+	// The plan only covers Atlas deposits
+	response.IsAtlasDeployed, err = state.IsAtlasDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+	}
+	if !response.IsAtlasDeployed {
+		return &response, nil
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		response.CreditBalance, err = node.GetNodeDepositCredit(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.NodeBalance, err = ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		depositEnabled, err := protocol.GetNodeDepositEnabled(rp, nil)
+		if err == nil {
+			response.DepositDisabled = !depositEnabled
+		}
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.EthMatched, response.EthMatchedLimit, response.PendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return fmt.Errorf("error checking collateral for node %s: %w", nodeAccount.Address.Hex(), err)
+		}
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Credit can only be spent while the deposit pool can cover the initial deposit on the node's behalf
+	response.CanUseCredit = (response.DepositPoolBalance.Cmp(eth.EthToWei(1)) >= 0)
+	availableEth := big.NewInt(0).Set(response.NodeBalance)
+	if response.CanUseCredit {
+		availableEth.Add(availableEth, response.CreditBalance)
+	}
+	response.AvailableEth = availableEth
+
+	// The RPL headroom is how much more ETH the node's stake lets it borrow
+	availableToMatch := big.NewInt(0).Sub(response.EthMatchedLimit, response.EthMatched)
+	availableToMatch.Sub(availableToMatch, response.PendingMatchAmount)
+	if availableToMatch.Sign() < 0 {
+		availableToMatch.SetUint64(0)
+	}
+	response.AvailableToMatch = availableToMatch
+
+	// Work out how many minipools of each bond size the ETH and the RPL headroom can each cover
+	validatorEthWei := eth.EthToWei(ValidatorEth)
+	for _, bond := range depositPlanBondAmounts {
+		option := api.NodeDepositPlanOption{
+			BondAmount: eth.EthToWei(bond),
+		}
+		option.MatchAmount = big.NewInt(0).Sub(validatorEthWei, option.BondAmount)
+		option.MaxByEth = big.NewInt(0).Div(availableEth, option.BondAmount).Uint64()
+		option.MaxByRpl = big.NewInt(0).Div(availableToMatch, option.MatchAmount).Uint64()
+		option.MaxCount = option.MaxByEth
+		if option.MaxByRpl < option.MaxCount {
+			option.MaxCount = option.MaxByRpl
+		}
+		if response.DepositDisabled {
+			option.MaxCount = 0
+		}
+		response.Options = append(response.Options, option)
+	}
+
+	// Return response
+	return &response, nil
+
+}
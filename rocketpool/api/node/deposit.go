@@ -29,6 +29,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -578,8 +579,13 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		opts.Value = amountWei
 	}
 
-	// Create and save a new validator key
-	validatorKey, err := w.CreateValidatorKey()
+	// Create and save a new validator key; simulations only preview the next one so nothing is written to the keystores
+	var validatorKey *eth2types.BLSPrivateKey
+	if w.IsSimulating() {
+		validatorKey, err = w.GetNextValidatorKey()
+	} else {
+		validatorKey, err = w.CreateValidatorKey()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -662,8 +668,10 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	}
 
 	// Save wallet
-	if err := w.Save(); err != nil {
-		return nil, err
+	if !w.IsSimulating() {
+		if err := w.Save(); err != nil {
+			return nil, err
+		}
 	}
 
 	// Print transaction if requested
@@ -679,6 +687,17 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	response.MinipoolAddress = minipoolAddress
 	response.ValidatorPubkey = pubKey
 
+	// Record the new minipool
+	if submit {
+		txHash := tx.Hash()
+		services.RecordEvent(c, journal.Event{
+			Type:     journal.EventType_MinipoolCreated,
+			Minipool: &minipoolAddress,
+			TxHash:   &txHash,
+			Message:  fmt.Sprintf("Created minipool %s for validator %s with a %.6f ETH bond.", minipoolAddress.Hex(), pubKey.Hex(), eth.WeiToEth(amountWei)),
+		})
+	}
+
 	// Return response
 	return &response, nil
 
@@ -748,8 +767,13 @@ func legacyNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, s
 		return nil, err
 	}
 
-	// Create and save a new validator key
-	validatorKey, err := w.CreateValidatorKey()
+	// Create and save a new validator key; simulations only preview the next one so nothing is written to the keystores
+	var validatorKey *eth2types.BLSPrivateKey
+	if w.IsSimulating() {
+		validatorKey, err = w.GetNextValidatorKey()
+	} else {
+		validatorKey, err = w.CreateValidatorKey()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -827,8 +851,10 @@ func legacyNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, s
 	}
 
 	// Save wallet
-	if err := w.Save(); err != nil {
-		return nil, err
+	if !w.IsSimulating() {
+		if err := w.Save(); err != nil {
+			return nil, err
+		}
 	}
 
 	// Print transaction if requested
@@ -844,6 +870,17 @@ func legacyNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, s
 	response.MinipoolAddress = minipoolAddress
 	response.ValidatorPubkey = pubKey
 
+	// Record the new minipool
+	if submit {
+		txHash := tx.Hash()
+		services.RecordEvent(c, journal.Event{
+			Type:     journal.EventType_MinipoolCreated,
+			Minipool: &minipoolAddress,
+			TxHash:   &txHash,
+			Message:  fmt.Sprintf("Created minipool %s for validator %s with a %.6f ETH bond.", minipoolAddress.Hex(), pubKey.Hex(), eth.WeiToEth(amountWei)),
+		})
+	}
+
 	// Return response
 	return &response, nil
 
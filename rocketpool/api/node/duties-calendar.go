@@ -0,0 +1,250 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/ical"
+)
+
+// Settings
+const (
+	// The number of upcoming rewards checkpoints to include
+	calendarRewardsCheckpoints int = 3
+
+	// Suffix for event UIDs, so they don't clash with other calendars
+	calendarUidDomain string = "smartnode.rocketpool.net"
+)
+
+// The categories of events in the duties calendar
+const (
+	CalendarCategory_RewardsCheckpoint     string = "Rewards Checkpoint"
+	CalendarCategory_SyncCommittee         string = "Sync Committee"
+	CalendarCategory_Maintenance           string = "Maintenance"
+	CalendarCategory_SmoothingPoolCooldown string = "Smoothing Pool Cooldown"
+)
+
+func GetDutiesCalendar(c *cli.Context) (*api.NodeDutiesCalendarResponse, error) {
+	return getDutiesCalendar(c)
+}
+
+func getDutiesCalendar(c *cli.Context) (*api.NodeDutiesCalendarResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDutiesCalendarResponse{
+		Events: []ical.Event{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	// Get the upcoming rewards checkpoints
+	intervalIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current rewards interval: %w", err)
+	}
+	intervalStart, err := rewards.GetClaimIntervalTimeStart(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current rewards interval start: %w", err)
+	}
+	intervalTime, err := rewards.GetClaimIntervalTime(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards interval time: %w", err)
+	}
+	for i := 0; i < calendarRewardsCheckpoints; i++ {
+		interval := intervalIndex.Uint64() + uint64(i)
+		checkpoint := intervalStart.Add(intervalTime * time.Duration(i+1))
+		response.Events = append(response.Events, ical.Event{
+			UID:         getCalendarUid(nodeAccount.Address, "rewards-checkpoint", interval),
+			Start:       checkpoint,
+			End:         checkpoint.Add(time.Hour),
+			Category:    CalendarCategory_RewardsCheckpoint,
+			Summary:     fmt.Sprintf("Rocket Pool rewards interval %d ends", interval),
+			Description: fmt.Sprintf("The Oracle DAO will submit the rewards tree for interval %d shortly after this checkpoint. Make sure your validators are online so the interval's attestations and Smoothing Pool rewards are counted.", interval),
+		})
+	}
+
+	// Get the end of the Smoothing Pool cooldown if the node changed its status recently
+	isRegistered, err := node.GetSmoothingPoolRegistrationState(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Smoothing Pool status: %w", err)
+	}
+	regChangeTime, err := node.GetSmoothingPoolRegistrationChanged(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Smoothing Pool status change time: %w", err)
+	}
+	if changeAvailableTime := regChangeTime.Add(intervalTime); changeAvailableTime.After(now) {
+		action := "join"
+		if isRegistered {
+			action = "leave"
+		}
+		response.Events = append(response.Events, ical.Event{
+			UID:         getCalendarUid(nodeAccount.Address, "smoothing-pool-cooldown", uint64(regChangeTime.Unix())),
+			Start:       changeAvailableTime,
+			End:         changeAvailableTime.Add(time.Hour),
+			Category:    CalendarCategory_SmoothingPoolCooldown,
+			Summary:     "Rocket Pool Smoothing Pool cooldown ends",
+			Description: fmt.Sprintf("Your node can %s the Smoothing Pool again after this time.", action),
+		})
+	}
+
+	// Get the sync committee periods the node's validators are part of
+	syncCommitteeEvents, err := getSyncCommitteeEvents(rp, bc, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.Events = append(response.Events, syncCommitteeEvents...)
+
+	// Get the maintenance windows that haven't ended yet
+	windows, err := cfg.Smartnode.GetMaintenanceWindows()
+	if err != nil {
+		return nil, fmt.Errorf("error getting maintenance windows: %w", err)
+	}
+	for _, window := range windows {
+		end := window.Start.Add(window.Duration)
+		if end.Before(now) {
+			continue
+		}
+		response.Events = append(response.Events, ical.Event{
+			UID:         getCalendarUid(nodeAccount.Address, "maintenance", uint64(window.Start.Unix())),
+			Start:       window.Start,
+			End:         end,
+			Category:    CalendarCategory_Maintenance,
+			Summary:     "Rocket Pool node maintenance",
+			Description: fmt.Sprintf("Planned maintenance for node %s. Your validators will miss their duties while the node is offline.", nodeAccount.Address.Hex()),
+		})
+	}
+
+	// Sort the events by start time
+	sort.SliceStable(response.Events, func(i, j int) bool {
+		return response.Events[i].Start.Before(response.Events[j].Start)
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get an event for the current and next sync committee periods if any of the node's validators are part of them
+func getSyncCommitteeEvents(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) ([]ical.Event, error) {
+
+	// Get the node's validator indices
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	if len(pubkeys) == 0 {
+		return []ical.Event{}, nil
+	}
+	statuses, err := eth2.GetValidatorStatusBatcher(bc).GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []uint64{}
+	for _, status := range statuses {
+		if status.Exists {
+			indices = append(indices, status.Index)
+		}
+	}
+	if len(indices) == 0 {
+		return []ical.Event{}, nil
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	// Get the Beacon chain timing
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	genesis := time.Unix(int64(eth2Config.GenesisTime), 0)
+	getEpochTime := func(epoch uint64) time.Time {
+		return genesis.Add(time.Duration(epoch*eth2Config.SlotsPerEpoch*eth2Config.SecondsPerSlot) * time.Second)
+	}
+
+	// Check the current period and the next one, which are the only ones the Beacon node knows about
+	events := []ical.Event{}
+	currentPeriod := head.Epoch / eth2Config.EpochsPerSyncCommitteePeriod
+	for period := currentPeriod; period <= currentPeriod+1; period++ {
+		startEpoch := period * eth2Config.EpochsPerSyncCommitteePeriod
+		endEpoch := startEpoch + eth2Config.EpochsPerSyncCommitteePeriod
+		queryEpoch := startEpoch
+		if period == currentPeriod {
+			queryEpoch = head.Epoch
+		}
+		duties, err := bc.GetValidatorSyncDuties(indices, queryEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("error getting sync duties for epoch %d: %w", queryEpoch, err)
+		}
+		members := []string{}
+		for _, index := range indices {
+			if duties[index] {
+				members = append(members, fmt.Sprint(index))
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		events = append(events, ical.Event{
+			UID:         getCalendarUid(nodeAddress, "sync-committee", period),
+			Start:       getEpochTime(startEpoch),
+			End:         getEpochTime(endEpoch),
+			Category:    CalendarCategory_SyncCommittee,
+			Summary:     fmt.Sprintf("Rocket Pool sync committee duty (%d validator(s))", len(members)),
+			Description: fmt.Sprintf("Validator(s) %s are in sync committee period %d (epochs %d to %d). Avoid taking the node offline during this period, since sync committee rewards are much larger than attestation rewards.", strings.Join(members, ", "), period, startEpoch, endEpoch-1),
+		})
+	}
+	return events, nil
+
+}
+
+// Get a stable UID for a calendar event, so calendar apps update it instead of adding a duplicate
+func getCalendarUid(nodeAddress common.Address, kind string, id uint64) string {
+	return fmt.Sprintf("%s-%d-%s@%s", kind, id, strings.ToLower(nodeAddress.Hex()), calendarUidDomain)
+}
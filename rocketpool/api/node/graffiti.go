@@ -0,0 +1,157 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+)
+
+func getGraffiti(c *cli.Context) (*api.NodeGraffitiResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeGraffitiResponse{
+		Enabled: cfg.Smartnode.EnableGraffitiManagement.Value == true,
+	}
+
+	// Get the graffiti settings
+	settings, err := graffiti.LoadSettings(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error loading graffiti settings: %w", err)
+	}
+	response.Template = settings.Template
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's validators and their indices
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	statuses := map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+	if len(pubkeys) > 0 {
+		statuses, err = eth2.GetValidatorStatusBatcher(bc).GetValidatorStatuses(pubkeys, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator statuses: %w", err)
+		}
+	}
+
+	// Work out each validator's graffiti
+	now := time.Now()
+	response.Validators = make([]api.ValidatorGraffiti, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		index := ""
+		if status, exists := statuses[pubkey]; exists && status.Exists {
+			index = fmt.Sprint(status.Index)
+		}
+		template, custom := settings.GetTemplate(pubkey)
+		response.Validators = append(response.Validators, api.ValidatorGraffiti{
+			Pubkey:   pubkey,
+			Index:    index,
+			Template: template,
+			Custom:   custom,
+			Graffiti: settings.GetGraffiti(pubkey, index, now),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func setGraffiti(c *cli.Context, pubkey types.ValidatorPubkey, template string) (*api.NodeSetGraffitiResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSetGraffitiResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the validator belongs to the node
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	found := false
+	for _, nodePubkey := range pubkeys {
+		if nodePubkey == pubkey {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("validator %s does not belong to this node", pubkey.Hex())
+	}
+
+	// Update the validator's template; an empty one means it goes back to the node's template
+	path := cfg.Smartnode.GetValidatorGraffitiPath()
+	templates, err := graffiti.LoadValidatorGraffiti(path)
+	if err != nil {
+		return nil, err
+	}
+	if template == "" {
+		delete(templates, pubkey)
+	} else {
+		templates[pubkey] = template
+	}
+	if err := graffiti.SaveValidatorGraffiti(path, templates); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
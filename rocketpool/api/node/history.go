@@ -0,0 +1,61 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getNodeHistory(c *cli.Context, typeFilter string, since uint64, limit uint64) (*api.NodeHistoryResponse, error) {
+
+	// Get services
+	j, err := services.GetEventJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the event types
+	filter := journal.Filter{
+		Limit: int(limit),
+	}
+	if typeFilter != "all" {
+		for _, element := range strings.Split(typeFilter, ",") {
+			eventType := journal.EventType(strings.TrimSpace(element))
+			if !isKnownEventType(eventType) {
+				return nil, fmt.Errorf("unknown event type '%s'", eventType)
+			}
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+	if since > 0 {
+		filter.Since = time.Unix(int64(since), 0)
+	}
+
+	// Read the journal
+	events, err := j.Read(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &api.NodeHistoryResponse{
+		Events: events,
+	}, nil
+
+}
+
+// Check if an event type is one the journal records
+func isKnownEventType(eventType journal.EventType) bool {
+	for _, knownType := range journal.EventTypes {
+		if eventType == knownType {
+			return true
+		}
+	}
+	return false
+}
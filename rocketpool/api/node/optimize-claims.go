@@ -0,0 +1,220 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The gas charged for each byte of transaction calldata (EIP-2028)
+const (
+	calldataZeroByteGas    uint64 = 4
+	calldataNonZeroByteGas uint64 = 16
+)
+
+// The rewards for a set of intervals, in the form the distributor contract takes them
+type intervalClaims struct {
+	indices      []*big.Int
+	amountRPL    []*big.Int
+	amountETH    []*big.Int
+	merkleProofs [][]common.Hash
+}
+
+func optimizeClaims(c *cli.Context, indicesString string, stakeAmount *big.Int) (*api.NodeOptimizeClaimsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeOptimizeClaimsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the rewards
+	indices, amountRPL, amountETH, merkleProofs, err := getRewardsForIntervals(rp, cfg, nodeAccount.Address, indicesString)
+	if err != nil {
+		return nil, err
+	}
+	if len(amountRPL) != len(indices) {
+		return nil, fmt.Errorf("the node doesn't have any rewards in some of the selected intervals")
+	}
+	all := intervalClaims{
+		indices:      indices,
+		amountRPL:    amountRPL,
+		amountETH:    amountETH,
+		merkleProofs: merkleProofs,
+	}
+
+	// Get the ways the intervals can be split into transactions
+	together := []intervalClaims{all}
+	individually := []intervalClaims{}
+	for i := range indices {
+		individually = append(individually, intervalClaims{
+			indices:      all.indices[i : i+1],
+			amountRPL:    all.amountRPL[i : i+1],
+			amountETH:    all.amountETH[i : i+1],
+			merkleProofs: all.merkleProofs[i : i+1],
+		})
+	}
+
+	// Get the distributor contract, which is needed to work out the calldata of each transaction
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the rewards distributor contract: %w", err)
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cost out every combination; plans without restaking are included when restaking so the cost of it can be seen
+	restake := stakeAmount != nil && stakeAmount.Sign() > 0
+	stakeAmounts := []*big.Int{nil}
+	if restake {
+		stakeAmounts = append(stakeAmounts, stakeAmount)
+	}
+	for _, planStakeAmount := range stakeAmounts {
+		suffix := ""
+		if planStakeAmount != nil {
+			suffix = ", restaking RPL"
+		}
+		plan, err := getClaimPlan(rp, distributor, nodeAccount.Address, "All intervals in one transaction"+suffix, together, planStakeAmount, opts)
+		if err != nil {
+			return nil, err
+		}
+		response.Plans = append(response.Plans, plan)
+		plan, err = getClaimPlan(rp, distributor, nodeAccount.Address, "One transaction per interval"+suffix, individually, planStakeAmount, opts)
+		if err != nil {
+			return nil, err
+		}
+		response.Plans = append(response.Plans, plan)
+	}
+
+	// Recommend the cheapest plan that does what was asked for
+	response.RecommendedPlan = -1
+	for i, plan := range response.Plans {
+		if plan.Restake != restake {
+			continue
+		}
+		if response.RecommendedPlan == -1 || plan.TotalGas < response.Plans[response.RecommendedPlan].TotalGas {
+			response.RecommendedPlan = i
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the gas and calldata costs of claiming the given groups of intervals, one transaction per group.
+// The restake amount is taken out of the RPL claimed by the earliest transactions, since each one can only stake what it claims.
+func getClaimPlan(rp *rocketpool.RocketPool, distributor *rocketpool.Contract, nodeAddress common.Address, description string, groups []intervalClaims, stakeAmount *big.Int, opts *bind.TransactOpts) (api.ClaimPlan, error) {
+	plan := api.ClaimPlan{
+		Description: description,
+		Restake:     stakeAmount != nil,
+	}
+	remainingStake := big.NewInt(0)
+	if stakeAmount != nil {
+		remainingStake.Set(stakeAmount)
+	}
+
+	for _, group := range groups {
+		tx := api.ClaimTransaction{}
+		for _, index := range group.indices {
+			tx.Indices = append(tx.Indices, index.Uint64())
+		}
+
+		// Get the amount to restake in this transaction
+		groupRpl := big.NewInt(0)
+		for _, amount := range group.amountRPL {
+			groupRpl.Add(groupRpl, amount)
+		}
+		txStake := big.NewInt(0).Set(remainingStake)
+		if txStake.Cmp(groupRpl) > 0 {
+			txStake.Set(groupRpl)
+		}
+		remainingStake.Sub(remainingStake, txStake)
+
+		// Get the gas estimate and calldata
+		var calldata []byte
+		var err error
+		if txStake.Sign() > 0 {
+			tx.StakeAmount = txStake
+			tx.GasInfo, err = rewards.EstimateClaimAndStakeGas(rp, nodeAddress, group.indices, group.amountRPL, group.amountETH, group.merkleProofs, txStake, opts)
+			if err != nil {
+				return api.ClaimPlan{}, fmt.Errorf("error estimating the gas to claim interval(s) %s: %w", formatIndices(tx.Indices), err)
+			}
+			calldata, err = distributor.ABI.Pack("claimAndStake", nodeAddress, group.indices, group.amountRPL, group.amountETH, group.merkleProofs, txStake)
+		} else {
+			tx.GasInfo, err = rewards.EstimateClaimGas(rp, nodeAddress, group.indices, group.amountRPL, group.amountETH, group.merkleProofs, opts)
+			if err != nil {
+				return api.ClaimPlan{}, fmt.Errorf("error estimating the gas to claim interval(s) %s: %w", formatIndices(tx.Indices), err)
+			}
+			calldata, err = distributor.ABI.Pack("claim", nodeAddress, group.indices, group.amountRPL, group.amountETH, group.merkleProofs)
+		}
+		if err != nil {
+			return api.ClaimPlan{}, fmt.Errorf("error building the calldata to claim interval(s) %s: %w", formatIndices(tx.Indices), err)
+		}
+		tx.CalldataBytes = uint64(len(calldata))
+		tx.CalldataGas = getCalldataGas(calldata)
+
+		plan.Transactions = append(plan.Transactions, tx)
+		plan.TotalGas += tx.GasInfo.EstGasLimit
+		plan.TotalCalldataBytes += tx.CalldataBytes
+		plan.TotalCalldataGas += tx.CalldataGas
+	}
+
+	if remainingStake.Sign() > 0 {
+		return api.ClaimPlan{}, fmt.Errorf("the restake amount is more than the RPL being claimed")
+	}
+	return plan, nil
+}
+
+// Get the gas charged for a transaction's calldata
+func getCalldataGas(calldata []byte) uint64 {
+	gas := uint64(0)
+	for _, b := range calldata {
+		if b == 0 {
+			gas += calldataZeroByteGas
+		} else {
+			gas += calldataNonZeroByteGas
+		}
+	}
+	return gas
+}
+
+// Format interval indices as a comma-separated list
+func formatIndices(indices []uint64) string {
+	elements := make([]string, 0, len(indices))
+	for _, index := range indices {
+		elements = append(elements, fmt.Sprint(index))
+	}
+	return strings.Join(elements, ",")
+}
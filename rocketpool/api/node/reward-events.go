@@ -0,0 +1,102 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Reward event types
+const (
+	rewardEventCollateralRpl    string = "collateral_rpl"
+	rewardEventODaoRpl          string = "odao_rpl"
+	rewardEventSmoothingPoolEth string = "smoothing_pool_eth"
+)
+
+// Get the rewards the node received between two times, valued on the day they were received; used by the node daemon's HTTP API.
+// Rewards are received at the end of their interval, when the tree is published, regardless of when they're claimed.
+func GetRewardEvents(c *cli.Context, from time.Time, to time.Time, priceSource prices.HistoricalPriceSource) (*api.NodeRewardEventsResponse, error) {
+	if priceSource == nil {
+		return nil, fmt.Errorf("a price source is required to value rewards")
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("the end of the range (%s) is before the start (%s)", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	// Get the per-interval rewards from the tree files
+	history, err := getRewardsHistory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardEventsResponse{
+		From:     from,
+		To:       to,
+		Currency: strings.ToUpper(priceSource.GetCurrency()),
+		Events:   []api.NodeRewardEvent{},
+		TotalRpl: big.NewInt(0),
+		TotalEth: big.NewInt(0),
+	}
+
+	// Build the events in interval order, keeping the running totals
+	for _, interval := range history.Intervals {
+		if interval.EndTime.Before(from) || interval.EndTime.After(to) {
+			continue
+		}
+
+		amounts := []struct {
+			eventType string
+			asset     prices.Asset
+			amount    *big.Int
+		}{
+			{rewardEventCollateralRpl, prices.Asset_RPL, interval.CollateralRpl},
+			{rewardEventODaoRpl, prices.Asset_RPL, interval.ODaoRpl},
+			{rewardEventSmoothingPoolEth, prices.Asset_ETH, interval.SmoothingPoolEth},
+		}
+		for _, reward := range amounts {
+			if reward.amount == nil || reward.amount.Sign() == 0 {
+				continue
+			}
+			price, err := priceSource.GetPrice(reward.asset, interval.EndTime)
+			if err != nil {
+				return nil, fmt.Errorf("error valuing interval %d rewards: %w", interval.Index, err)
+			}
+			value := eth.WeiToEth(reward.amount) * price
+
+			if reward.asset == prices.Asset_RPL {
+				response.TotalRpl.Add(response.TotalRpl, reward.amount)
+			} else {
+				response.TotalEth.Add(response.TotalEth, reward.amount)
+			}
+			response.TotalValue += value
+
+			response.Events = append(response.Events, api.NodeRewardEvent{
+				Interval:       interval.Index,
+				Time:           interval.EndTime,
+				Type:           reward.eventType,
+				Asset:          string(reward.asset),
+				Amount:         reward.amount,
+				Price:          price,
+				Value:          value,
+				TreeFileExists: interval.TreeFileExists,
+				Claimed:        interval.Claimed,
+				ClaimTxHash:    interval.ClaimTxHash,
+				RunningRpl:     new(big.Int).Set(response.TotalRpl),
+				RunningEth:     new(big.Int).Set(response.TotalEth),
+				RunningValue:   response.TotalValue,
+			})
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,125 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRewardsHistory(c *cli.Context) (*api.NodeRewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsHistoryResponse{
+		Intervals: []api.NodeRewardsInterval{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the claimed and unclaimed intervals
+	unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	isClaimed := map[uint64]bool{}
+	for _, interval := range claimed {
+		isClaimed[interval] = true
+	}
+	intervals := append(append([]uint64{}, claimed...), unclaimed...)
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+
+	// Get the claim transactions
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	claimEvents := []nodeActivityEvent{}
+	for _, event := range nodeActivityEvents {
+		if event.eventName == "RewardsClaimed" {
+			claimEvents = append(claimEvents, event)
+		}
+	}
+	claimActivity, err := getActivityFromEvents(rp, nodeAccount.Address, big.NewInt(int64(eventLogInterval)), claimEvents)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claims: %w", err)
+	}
+	claims := map[uint64]api.NodeActivity{}
+	for _, claim := range claimActivity {
+		if claim.Interval != nil {
+			claims[*claim.Interval] = claim
+		}
+	}
+
+	// Get the details of each interval from its tree file, falling back to the claim for the amounts if it's missing
+	for _, index := range intervals {
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, index)
+		if err != nil {
+			return nil, err
+		}
+		treeFileValid := intervalInfo.TreeFileExists && intervalInfo.MerkleRootValid
+		claim, hasClaim := claims[index]
+		if treeFileValid && !intervalInfo.NodeExists && !hasClaim {
+			// The node didn't earn anything this interval
+			continue
+		}
+
+		interval := api.NodeRewardsInterval{
+			Index:            index,
+			Claimed:          isClaimed[index],
+			TreeFileExists:   treeFileValid,
+			StartTime:        intervalInfo.StartTime,
+			EndTime:          intervalInfo.EndTime,
+			CollateralRpl:    big.NewInt(0),
+			ODaoRpl:          big.NewInt(0),
+			SmoothingPoolEth: big.NewInt(0),
+		}
+		if treeFileValid && intervalInfo.NodeExists {
+			interval.CollateralRpl = &intervalInfo.CollateralRplAmount.Int
+			interval.ODaoRpl = &intervalInfo.ODaoRplAmount.Int
+			interval.SmoothingPoolEth = &intervalInfo.SmoothingPoolEthAmount.Int
+		} else if hasClaim {
+			// Claims don't separate collateral and Oracle DAO RPL, so it's all reported as collateral
+			interval.CollateralRpl = claim.RplAmount
+			interval.SmoothingPoolEth = claim.EthAmount
+		}
+		if hasClaim {
+			txHash := claim.TxHash
+			claimTime := claim.Time
+			interval.ClaimTxHash = &txHash
+			interval.ClaimTime = &claimTime
+		}
+		response.Intervals = append(response.Intervals, interval)
+	}
+
+	// Return response
+	return &response, nil
+
+}
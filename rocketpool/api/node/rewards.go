@@ -26,6 +26,11 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 )
 
+// Get the node's rewards info; used by the node daemon's HTTP API
+func GetRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
+	return getRewards(c)
+}
+
 func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get services
@@ -85,6 +90,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 	var totalNodeShare float64
 	var addresses []common.Address
 	var beaconHead beacon.BeaconHead
+	var nodeRewards *rprewards.NodeRewards
 
 	// Sync
 	var wg errgroup.Group
@@ -121,54 +127,14 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get claimed and pending rewards
 	wg.Go(func() error {
-		// Legacy rewards
-		unclaimedRplRewardsWei := big.NewInt(0)
-		rplRewards := big.NewInt(0)
-		// TEMP removal of the legacy rewards crawler for now, TODO performance improvements here
-		/*
-			rplRewards, err := legacyrewards.CalculateLifetimeNodeRewards(rp, nodeAccount.Address, big.NewInt(int64(eventLogInterval)), nil, &legacyRocketRewardsAddress, &legacyClaimNodeAddress)*/
-		unclaimedEthRewardsWei := big.NewInt(0)
-		ethRewards := big.NewInt(0)
-
-		// Get the claimed and unclaimed intervals
-		unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
-		if err != nil {
-			return err
-		}
-
-		// Get the info for each claimed interval
-		for _, claimedInterval := range claimed {
-			intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, claimedInterval)
-			if err != nil {
-				return err
-			}
-			if !intervalInfo.TreeFileExists {
-				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
-			}
-			rplRewards.Add(rplRewards, &intervalInfo.CollateralRplAmount.Int)
-			ethRewards.Add(ethRewards, &intervalInfo.SmoothingPoolEthAmount.Int)
-		}
-
-		// Get the unclaimed rewards
-		for _, unclaimedInterval := range unclaimed {
-			intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, unclaimedInterval)
-			if err != nil {
-				return err
-			}
-			if !intervalInfo.TreeFileExists {
-				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
-			}
-			if intervalInfo.NodeExists {
-				unclaimedRplRewardsWei.Add(unclaimedRplRewardsWei, &intervalInfo.CollateralRplAmount.Int)
-				unclaimedEthRewardsWei.Add(unclaimedEthRewardsWei, &intervalInfo.SmoothingPoolEthAmount.Int)
-			}
-		}
-
+		var err error
+		nodeRewards, err = rprewards.NewRewardsInfo(rp, cfg).Update(nodeAccount.Address)
 		if err == nil {
-			response.CumulativeRplRewards = eth.WeiToEth(rplRewards)
-			response.UnclaimedRplRewards = eth.WeiToEth(unclaimedRplRewardsWei)
-			response.CumulativeEthRewards = eth.WeiToEth(ethRewards)
-			response.UnclaimedEthRewards = eth.WeiToEth(unclaimedEthRewardsWei)
+			response.CumulativeRplRewards = eth.WeiToEth(nodeRewards.CumulativeRpl)
+			response.UnclaimedRplRewards = eth.WeiToEth(nodeRewards.UnclaimedRpl)
+			response.CumulativeEthRewards = eth.WeiToEth(nodeRewards.CumulativeEth)
+			response.UnclaimedEthRewards = eth.WeiToEth(nodeRewards.UnclaimedEth)
+			response.MissingRewardsIntervals = nodeRewards.MissingIntervals
 		}
 		return err
 	})
@@ -320,51 +286,8 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 		var wg2 errgroup.Group
 
 		// Get cumulative ODAO rewards
-		wg2.Go(func() error {
-			// Legacy rewards
-			unclaimedRplRewardsWei := big.NewInt(0)
-			rplRewards := big.NewInt(0)
-			// TODO: PERFORMANCE IMPROVEMENTS
-			//rplRewards, err := legacyrewards.CalculateLifetimeTrustedNodeRewards(rp, nodeAccount.Address, big.NewInt(int64(eventLogInterval)), nil, &legacyRocketRewardsAddress, &legacyClaimTrustedNodeAddress)
-
-			// Get the claimed and unclaimed intervals
-			unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
-			if err != nil {
-				return err
-			}
-
-			// Get the info for each claimed interval
-			for _, claimedInterval := range claimed {
-				intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, claimedInterval)
-				if err != nil {
-					return err
-				}
-				if !intervalInfo.TreeFileExists {
-					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
-				}
-				rplRewards.Add(rplRewards, &intervalInfo.ODaoRplAmount.Int)
-			}
-
-			// Get the unclaimed rewards
-			for _, unclaimedInterval := range unclaimed {
-				intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, unclaimedInterval)
-				if err != nil {
-					return err
-				}
-				if !intervalInfo.TreeFileExists {
-					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
-				}
-				if intervalInfo.NodeExists {
-					unclaimedRplRewardsWei.Add(unclaimedRplRewardsWei, &intervalInfo.ODaoRplAmount.Int)
-				}
-			}
-
-			if err == nil {
-				response.CumulativeTrustedRplRewards = eth.WeiToEth(rplRewards)
-				response.UnclaimedTrustedRplRewards = eth.WeiToEth(unclaimedRplRewardsWei)
-			}
-			return err
-		})
+		response.CumulativeTrustedRplRewards = eth.WeiToEth(nodeRewards.CumulativeOdaoRpl)
+		response.UnclaimedTrustedRplRewards = eth.WeiToEth(nodeRewards.UnclaimedOdaoRpl)
 
 		// Get the ODAO member count
 		wg2.Go(func() error {
@@ -0,0 +1,334 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getRplWithdrawalAddress(c *cli.Context) (*api.NodeRplWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRplWithdrawalAddressResponse{}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the addresses
+	response.WithdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsSupported, err = rputils.IsRplWithdrawalAddressSupported(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsSupported {
+		return &response, nil
+	}
+	response.RplWithdrawalAddress, response.HasRplWithdrawalAddress, err = rputils.GetNodeRplWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.PendingRplWithdrawalAddress, err = rputils.GetNodePendingRplWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the transaction the pending address needs to send to confirm itself
+	if response.PendingRplWithdrawalAddress != (common.Address{}) {
+		nodeManagerAddress, calldata, err := rputils.GetConfirmRplWithdrawalAddressCalldata(rp, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+		response.NodeManagerAddress = nodeManagerAddress
+		response.ConfirmCalldata = hexutil.Encode(calldata)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canSetRplWithdrawalAddress(c *cli.Context, rplWithdrawalAddress common.Address, confirm bool) (*api.CanSetNodeRplWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSetNodeRplWithdrawalAddressResponse{}
+
+	// Make sure the contracts support it
+	isSupported, err := rputils.IsRplWithdrawalAddressSupported(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !isSupported {
+		response.IsUnsupported = true
+		return &response, nil
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the address that's allowed to make the change, and the transaction it would send
+	response.Authority, err = rputils.GetRplWithdrawalAddressAuthority(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeManagerAddress, calldata, err := rputils.GetSetRplWithdrawalAddressCalldata(rp, nodeAccount.Address, rplWithdrawalAddress, confirm)
+	if err != nil {
+		return nil, err
+	}
+	response.NodeManagerAddress = nodeManagerAddress
+	response.Calldata = hexutil.Encode(calldata)
+
+	// The node wallet can only send it if it's the one allowed to
+	if response.Authority != nodeAccount.Address {
+		return &response, nil
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the gas info
+	gasInfo, err := rputils.EstimateSetRplWithdrawalAddressGas(rp, nodeAccount.Address, rplWithdrawalAddress, confirm, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	response.CanSet = true
+	return &response, nil
+
+}
+
+func setRplWithdrawalAddress(c *cli.Context, rplWithdrawalAddress common.Address, confirm bool) (*api.SetNodeRplWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetNodeRplWithdrawalAddressResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the node is allowed to make the change
+	authority, err := rputils.GetRplWithdrawalAddressAuthority(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authority != nodeAccount.Address {
+		return nil, fmt.Errorf("Only %s can change this node's RPL withdrawal address, "+
+			"so you cannot call set-rpl-withdrawal-address from the node.", authority.Hex())
+	}
+
+	// Set the RPL withdrawal address
+	hash, err := rputils.SetRplWithdrawalAddress(rp, nodeAccount.Address, rplWithdrawalAddress, confirm, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func canConfirmRplWithdrawalAddress(c *cli.Context) (*api.CanConfirmNodeRplWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanConfirmNodeRplWithdrawalAddressResponse{}
+
+	// Make sure the contracts support it
+	isSupported, err := rputils.IsRplWithdrawalAddressSupported(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !isSupported {
+		response.IsUnsupported = true
+		return &response, nil
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pending address and the transaction it needs to send
+	response.PendingRplWithdrawalAddress, err = rputils.GetNodePendingRplWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.PendingRplWithdrawalAddress == (common.Address{}) {
+		return &response, nil
+	}
+	nodeManagerAddress, calldata, err := rputils.GetConfirmRplWithdrawalAddressCalldata(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.NodeManagerAddress = nodeManagerAddress
+	response.Calldata = hexutil.Encode(calldata)
+
+	// The node wallet can only confirm it if the pending address is the node address
+	if response.PendingRplWithdrawalAddress != nodeAccount.Address {
+		return &response, nil
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the gas info
+	gasInfo, err := rputils.EstimateConfirmRplWithdrawalAddressGas(rp, nodeAccount.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	response.CanConfirm = true
+	return &response, nil
+
+}
+
+func confirmRplWithdrawalAddress(c *cli.Context) (*api.ConfirmNodeRplWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ConfirmNodeRplWithdrawalAddressResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the pending address is the node address
+	pendingAddress, err := rputils.GetNodePendingRplWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pendingAddress != nodeAccount.Address {
+		return nil, fmt.Errorf("This node's pending RPL withdrawal address is %s, "+
+			"which is not the node address; it must be confirmed from that address.", pendingAddress.Hex())
+	}
+
+	// Confirm the RPL withdrawal address
+	hash, err := rputils.ConfirmRplWithdrawalAddress(rp, nodeAccount.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,160 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Get the most staked RPL the node can withdraw while keeping its stake above the protocol's withdrawal limit,
+// the minimum stake for its borrowed ETH, and the target collateral ratio.
+// Pending bond reductions are counted as borrowed ETH, since they need the collateral to complete.
+func getRplWithdrawalPlan(c *cli.Context, targetCollateral *big.Int) (*api.NodeRplWithdrawalPlanResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRplWithdrawalPlanResponse{
+		MinimumRplStake:   big.NewInt(0),
+		TargetRplStake:    big.NewInt(0),
+		RequiredRplStake:  big.NewInt(0),
+		MaxSafeWithdrawal: big.NewInt(0),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+	}
+	response.IsAtlasDeployed = isAtlasDeployed
+	if !isAtlasDeployed {
+		return &response, nil
+	}
+
+	// Data
+	var wg errgroup.Group
+	var minCollateral *big.Int
+	var currentTime uint64
+	var rplStakedTime uint64
+	var withdrawalDelay uint64
+	wg.Go(func() error {
+		var err error
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaximumRplStake, err = node.GetNodeMaximumRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.RplPrice, err = network.GetRPLPrice(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minCollateral, err = protocol.GetMinimumPerMinipoolStakeRaw(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.EthMatched, _, response.PendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		header, err := ec.HeaderByNumber(context.Background(), nil)
+		if err == nil {
+			currentTime = header.Time
+		}
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplStakedTime, err = node.GetNodeRPLStakedTime(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		withdrawalDelay, err = protocol.GetRewardsClaimIntervalTime(rp, nil)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the stake each constraint requires
+	borrowedEth := big.NewInt(0).Add(response.EthMatched, response.PendingMatchAmount)
+	if response.RplPrice.Sign() > 0 {
+		response.MinimumRplStake.Mul(borrowedEth, minCollateral)
+		response.MinimumRplStake.Div(response.MinimumRplStake, response.RplPrice)
+		response.TargetRplStake.Mul(borrowedEth, targetCollateral)
+		response.TargetRplStake.Div(response.TargetRplStake, response.RplPrice)
+	}
+
+	// The strictest one sets the limit
+	response.RequiredRplStake.Set(response.MaximumRplStake)
+	response.LimitedBy = api.RplWithdrawalLimit_Protocol
+	if response.MinimumRplStake.Cmp(response.RequiredRplStake) > 0 {
+		response.RequiredRplStake.Set(response.MinimumRplStake)
+		response.LimitedBy = api.RplWithdrawalLimit_Minimum
+	}
+	if response.TargetRplStake.Cmp(response.RequiredRplStake) > 0 {
+		response.RequiredRplStake.Set(response.TargetRplStake)
+		response.LimitedBy = api.RplWithdrawalLimit_Target
+	}
+	if response.RplStake.Cmp(response.RequiredRplStake) > 0 {
+		response.MaxSafeWithdrawal.Sub(response.RplStake, response.RequiredRplStake)
+	}
+
+	// Get the collateral ratio before and after the withdrawal
+	if borrowedEth.Sign() > 0 {
+		remainingStake := big.NewInt(0).Sub(response.RplStake, response.MaxSafeWithdrawal)
+		response.CurrentCollateralRatio = eth.WeiToEth(response.RplPrice) * eth.WeiToEth(response.RplStake) / eth.WeiToEth(borrowedEth)
+		response.ResultingCollateralRatio = eth.WeiToEth(response.RplPrice) * eth.WeiToEth(remainingStake) / eth.WeiToEth(borrowedEth)
+	} else {
+		response.CurrentCollateralRatio = -1
+		response.ResultingCollateralRatio = -1
+	}
+
+	// Check the withdrawal delay since the node last staked RPL
+	response.WithdrawalDelayEnd = time.Unix(int64(rplStakedTime+withdrawalDelay), 0)
+	response.WithdrawalDelayActive = ((currentTime - rplStakedTime) < withdrawalDelay)
+
+	// Return response
+	return &response, nil
+
+}
@@ -1,10 +1,16 @@
 package node
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	_ "time/tzdata"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -17,16 +23,85 @@ func signMessage(c *cli.Context, message string) (*api.NodeSignResponse, error)
 	if err != nil {
 		return nil, err
 	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
-	response := api.NodeSignResponse{}
+	response := api.NodeSignResponse{
+		Address:     nodeAccount.Address,
+		MessageHash: common.BytesToHash(accounts.TextHash([]byte(message))),
+	}
 	signedBytes, err := w.SignMessage(message)
 	if err != nil {
 		return nil, fmt.Errorf("Error signing message [%s]: %w", message, err)
 	}
+	if err := verifySignature(response.MessageHash, signedBytes, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+	response.SignedData = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+
+	// Return response
+	return &response, nil
+
+}
+
+func signTypedData(c *cli.Context, typedDataJson string) (*api.NodeSignTypedDataResponse, error) {
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the typed data
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal([]byte(typedDataJson), &typedData); err != nil {
+		return nil, fmt.Errorf("Error parsing EIP-712 typed data: %w", err)
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing EIP-712 domain: %w", err)
+	}
+
+	// Response
+	response := api.NodeSignTypedDataResponse{
+		Address:         nodeAccount.Address,
+		PrimaryType:     typedData.PrimaryType,
+		DomainSeparator: common.BytesToHash(domainSeparator),
+	}
+	signedBytes, dataHash, err := w.SignTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	response.MessageHash = common.BytesToHash(dataHash)
+	if err := verifySignature(response.MessageHash, signedBytes, nodeAccount.Address); err != nil {
+		return nil, err
+	}
 	response.SignedData = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
 
 	// Return response
 	return &response, nil
 
 }
+
+// Recover the signer of a hash to make sure the signature can be verified against the node address
+func verifySignature(hash common.Hash, signature []byte, expectedSigner common.Address) error {
+	// Undo the 'v' adjustment made during signing, since the recovery ID is expected to be 0 or 1
+	rawSignature := make([]byte, len(signature))
+	copy(rawSignature, signature)
+	rawSignature[crypto.RecoveryIDOffset] -= 27
+
+	pubkey, err := crypto.SigToPub(hash.Bytes(), rawSignature)
+	if err != nil {
+		return fmt.Errorf("Error recovering the signer of the signature: %w", err)
+	}
+	signer := crypto.PubkeyToAddress(*pubkey)
+	if !bytes.Equal(signer.Bytes(), expectedSigner.Bytes()) {
+		return fmt.Errorf("The signature was recovered to %s instead of the node address %s", signer.Hex(), expectedSigner.Hex())
+	}
+	return nil
+}
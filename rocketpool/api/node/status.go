@@ -24,6 +24,11 @@ import (
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+// Get the node's status; used by the node daemon's HTTP API
+func GetStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
+	return getStatus(c)
+}
+
 func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
 	// Get services
@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 func estimateSetSnapshotDelegateGas(c *cli.Context, address common.Address) (*api.EstimateSetSnapshotDelegateGasResponse, error) {
@@ -251,9 +252,9 @@ func clearSnapshotDelegate(c *cli.Context) (*api.ClearSnapshotDelegateResponse,
 }
 
 func getHttpClientWithTimeout() *http.Client {
-	return &http.Client{
-		Timeout: time.Second * 5,
-	}
+	client := *net.GetHttpClient(net.HttpTarget_Snapshot)
+	client.Timeout = time.Second * 5
+	return &client
 }
 
 func GetSnapshotVotingPower(apiDomain string, space string, nodeAddress common.Address) (*api.SnapshotVotingPower, error) {
@@ -264,6 +265,7 @@ func GetSnapshotVotingPower(apiDomain string, space string, nodeAddress common.A
 			voter: "%s",
 		) {
 			vp
+			vp_by_strategy
 		}
 	}
 	`, space, nodeAddress)
@@ -348,6 +350,7 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 	    end
 	    snapshot
 	    state
+	    type
 	    author
 		scores
 		scores_total
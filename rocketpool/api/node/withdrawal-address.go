@@ -4,12 +4,14 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 func canSetWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, confirm bool) (*api.CanSetNodeWithdrawalAddressResponse, error) {
@@ -289,3 +291,58 @@ func getNodePendingWithdrawalAddress(c *cli.Context) (*api.GetNodePendingWithdra
 	return &response, nil
 
 }
+
+func getWithdrawalSafety(c *cli.Context) (*api.NodeWithdrawalSafetyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every minipool's withdrawal credentials along with the addresses
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool addresses: %w", err)
+	}
+	safety, err := rputils.CheckWithdrawalSafety(rp, bc, cfg, nodeAccount.Address, minipoolAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &api.NodeWithdrawalSafetyResponse{
+		WithdrawalAddress:            safety.WithdrawalAddress,
+		PendingWithdrawalAddress:     safety.PendingWithdrawalAddress,
+		RplWithdrawalAddress:         safety.RplWithdrawalAddress,
+		HasRplWithdrawalAddress:      safety.HasRplWithdrawalAddress,
+		ExpectedWithdrawalAddress:    cfg.Smartnode.ExpectedWithdrawalAddress.Value.(string),
+		ExpectedRplWithdrawalAddress: cfg.Smartnode.ExpectedRplWithdrawalAddress.Value.(string),
+		ProofMessage:                 safety.ProofMessage,
+		HasProof:                     safety.HasProof,
+		ProofSigner:                  safety.ProofSigner,
+		Problems:                     safety.Problems,
+	}, nil
+
+}
@@ -0,0 +1,318 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+)
+
+// Ledger entry types
+const (
+	withdrawalLedgerRewardsClaim         string = "Rewards Claim"
+	withdrawalLedgerRplWithdrawal        string = "RPL Withdrawal"
+	withdrawalLedgerMinipoolDistribution string = "Minipool Distribution"
+	withdrawalLedgerMinipoolRefund       string = "Minipool Refund"
+	withdrawalLedgerFeeDistribution      string = "Fee Distribution"
+)
+
+func getWithdrawalLedger(c *cli.Context) (*api.NodeWithdrawalLedgerResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeWithdrawalLedgerResponse{
+		Entries:  []api.WithdrawalLedgerEntry{},
+		TotalEth: big.NewInt(0),
+		TotalRpl: big.NewInt(0),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+	response.WithdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting withdrawal address: %w", err)
+	}
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	blockTimes := map[uint64]time.Time{}
+
+	// Get the RPL withdrawals and rewards claims
+	nodeEntries, err := getNodeLedgerEntries(rp, nodeAccount.Address, intervalSize)
+	if err != nil {
+		return nil, err
+	}
+	response.Entries = append(response.Entries, nodeEntries...)
+
+	// Get the minipool distributions and refunds
+	minipoolAddresses, err := getAllNodeMinipoolAddresses(rp, nodeAccount.Address, intervalSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(minipoolAddresses) > 0 {
+		minipoolEntries, err := getMinipoolLedgerEntries(rp, minipoolAddresses, intervalSize, blockTimes)
+		if err != nil {
+			return nil, err
+		}
+		response.Entries = append(response.Entries, minipoolEntries...)
+	}
+
+	// Get the fee distributor payouts
+	distributorEntries, err := getDistributorLedgerEntries(rp, nodeAccount.Address, intervalSize, blockTimes)
+	if err != nil {
+		return nil, err
+	}
+	response.Entries = append(response.Entries, distributorEntries...)
+
+	// Sort the ledger chronologically and total it up
+	sort.SliceStable(response.Entries, func(i, j int) bool {
+		return response.Entries[i].BlockNumber < response.Entries[j].BlockNumber
+	})
+	for _, entry := range response.Entries {
+		response.TotalEth.Add(response.TotalEth, entry.EthAmount)
+		response.TotalRpl.Add(response.TotalRpl, entry.RplAmount)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the RPL withdrawals and rewards claims, which are sent to the withdrawal address by the node contracts
+func getNodeLedgerEntries(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int) ([]api.WithdrawalLedgerEntry, error) {
+	events := []nodeActivityEvent{}
+	sources := map[string]common.Address{}
+	for _, event := range nodeActivityEvents {
+		switch event.eventName {
+		case "RPLWithdrawn", "RPLStaked", "RewardsClaimed":
+			events = append(events, event)
+			address, err := rp.GetAddress(event.contractName, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting address of %s: %w", event.contractName, err)
+			}
+			sources[event.eventName] = *address
+		}
+	}
+	activity, err := getActivityFromEvents(rp, nodeAddress, intervalSize, events)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claims can restake some of the RPL, which shows up as a stake in the same transaction and never reaches the withdrawal address
+	restaked := map[common.Hash]*big.Int{}
+	for _, item := range activity {
+		if item.Type == "RPL Staked" {
+			if _, exists := restaked[item.TxHash]; !exists {
+				restaked[item.TxHash] = big.NewInt(0)
+			}
+			restaked[item.TxHash].Add(restaked[item.TxHash], item.RplAmount)
+		}
+	}
+
+	entries := []api.WithdrawalLedgerEntry{}
+	for _, item := range activity {
+		entry := api.WithdrawalLedgerEntry{
+			BlockNumber: item.BlockNumber,
+			Time:        item.Time,
+			TxHash:      item.TxHash,
+			Interval:    item.Interval,
+			EthAmount:   big.NewInt(0),
+			RplAmount:   new(big.Int).Set(item.RplAmount),
+		}
+		switch item.Type {
+		case "RPL Withdrawn":
+			entry.Type = withdrawalLedgerRplWithdrawal
+			entry.Source = sources["RPLWithdrawn"]
+		case "Rewards Claimed":
+			entry.Type = withdrawalLedgerRewardsClaim
+			entry.Source = sources["RewardsClaimed"]
+			entry.EthAmount = item.EthAmount
+			if stake, exists := restaked[item.TxHash]; exists && stake.Sign() > 0 {
+				deducted := stake
+				if deducted.Cmp(entry.RplAmount) > 0 {
+					deducted = entry.RplAmount
+				}
+				stake.Sub(stake, deducted)
+				entry.RplAmount.Sub(entry.RplAmount, deducted)
+			}
+		default:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Get the node's current minipools plus any closed or destroyed ones, which only show up in its MinipoolCreated events
+func getAllNodeMinipoolAddresses(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int) ([]common.Address, error) {
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool addresses: %w", err)
+	}
+	known := map[common.Address]bool{}
+	for _, address := range minipoolAddresses {
+		known[address] = true
+	}
+
+	events := []nodeActivityEvent{}
+	for _, event := range nodeActivityEvents {
+		if event.eventName == "MinipoolCreated" {
+			events = append(events, event)
+		}
+	}
+	activity, err := getActivityFromEvents(rp, nodeAddress, intervalSize, events)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range activity {
+		if !known[item.Minipool] {
+			known[item.Minipool] = true
+			minipoolAddresses = append(minipoolAddresses, item.Minipool)
+		}
+	}
+	return minipoolAddresses, nil
+}
+
+// Get the node's share of minipool balance distributions, and any refunds sent separately
+func getMinipoolLedgerEntries(rp *rocketpool.RocketPool, minipoolAddresses []common.Address, intervalSize *big.Int, blockTimes map[uint64]time.Time) ([]api.WithdrawalLedgerEntry, error) {
+	delegateAbi, err := rp.GetABI("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool delegate ABI: %w", err)
+	}
+
+	// Distributions include the refund, so only refunds in other transactions are counted on their own
+	type minipoolTx struct {
+		txHash   common.Hash
+		minipool common.Address
+	}
+	distributions := map[minipoolTx]bool{}
+	entries := []api.WithdrawalLedgerEntry{}
+	for _, eventName := range []string{"EtherWithdrawalProcessed", "EtherWithdrawn"} {
+		abiEvent, exists := delegateAbi.Events[eventName]
+		if !exists {
+			continue
+		}
+		logs, err := logscan.GetLogs(rp, minipoolAddresses, [][]common.Hash{{abiEvent.ID}}, intervalSize.Uint64(), nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting minipool %s events: %w", eventName, err)
+		}
+
+		for _, log := range logs {
+			key := minipoolTx{txHash: log.TxHash, minipool: log.Address}
+			if eventName == "EtherWithdrawn" && distributions[key] {
+				continue
+			}
+			entry, err := newLedgerEntry(rp, abiEvent, log, blockTimes)
+			if err != nil {
+				return nil, err
+			}
+			if eventName == "EtherWithdrawalProcessed" {
+				entry.Type = withdrawalLedgerMinipoolDistribution
+				distributions[key] = true
+			} else {
+				entry.Type = withdrawalLedgerMinipoolRefund
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Get the node's share of the fee distributor's balance each time it was distributed
+func getDistributorLedgerEntries(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int, blockTimes map[uint64]time.Time) ([]api.WithdrawalLedgerEntry, error) {
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor address: %w", err)
+	}
+	distributorAbi, err := rp.GetABI("rocketNodeDistributorDelegate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor ABI: %w", err)
+	}
+	abiEvent, exists := distributorAbi.Events["FeesDistributed"]
+	if !exists {
+		return []api.WithdrawalLedgerEntry{}, nil
+	}
+
+	logs, err := logscan.GetLogs(rp, []common.Address{distributorAddress}, [][]common.Hash{{abiEvent.ID}}, intervalSize.Uint64(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor events: %w", err)
+	}
+	entries := make([]api.WithdrawalLedgerEntry, 0, len(logs))
+	for _, log := range logs {
+		entry, err := newLedgerEntry(rp, abiEvent, log, blockTimes)
+		if err != nil {
+			return nil, err
+		}
+		entry.Type = withdrawalLedgerFeeDistribution
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Create a ledger entry for the node's ETH share of a distribution event
+func newLedgerEntry(rp *rocketpool.RocketPool, abiEvent abi.Event, log types.Log, blockTimes map[uint64]time.Time) (api.WithdrawalLedgerEntry, error) {
+	values := map[string]interface{}{}
+	err := abiEvent.Inputs.UnpackIntoMap(values, log.Data)
+	if err != nil {
+		return api.WithdrawalLedgerEntry{}, fmt.Errorf("error decoding %s event in transaction %s: %w", abiEvent.Name, log.TxHash.Hex(), err)
+	}
+	blockTime, err := getBlockTime(rp, blockTimes, log.BlockNumber)
+	if err != nil {
+		return api.WithdrawalLedgerEntry{}, err
+	}
+
+	// The contracts don't all use the same argument names
+	amount := big.NewInt(0)
+	for _, name := range []string{"nodeAmount", "_nodeAmount", "amount"} {
+		if value, ok := values[name].(*big.Int); ok {
+			amount = value
+			break
+		}
+	}
+
+	return api.WithdrawalLedgerEntry{
+		BlockNumber: log.BlockNumber,
+		Time:        blockTime,
+		TxHash:      log.TxHash,
+		Source:      log.Address,
+		EthAmount:   amount,
+		RplAmount:   big.NewInt(0),
+	}, nil
+}
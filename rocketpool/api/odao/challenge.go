@@ -0,0 +1,313 @@
+package odao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canChallengeMember(c *cli.Context, memberAddress common.Address) (*api.CanChallengeTNDAOMemberResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanChallengeTNDAOMemberResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.ChallengeSelf = (nodeAccount.Address == memberAddress)
+
+	// Sync
+	var wg errgroup.Group
+	var balance *big.Int
+
+	// Check if the node is a member
+	wg.Go(func() error {
+		isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+		if err == nil {
+			response.IsMember = isMember
+		}
+		return err
+	})
+
+	// Check if the challenged member exists
+	wg.Go(func() error {
+		memberExists, err := trustednode.GetMemberExists(rp, memberAddress, nil)
+		if err == nil {
+			response.MemberDoesNotExist = !memberExists
+		}
+		return err
+	})
+
+	// Check if the member is already challenged
+	wg.Go(func() error {
+		isChallenged, err := trustednode.GetMemberIsChallenged(rp, memberAddress, nil)
+		if err == nil {
+			response.AlreadyChallenged = isChallenged
+		}
+		return err
+	})
+
+	// Get the challenge settings
+	wg.Go(func() error {
+		challengeCost, err := tnsettings.GetChallengeCost(rp, nil)
+		if err == nil {
+			response.ChallengeCost = challengeCost
+		}
+		return err
+	})
+	wg.Go(func() error {
+		challengeWindow, err := tnsettings.GetChallengeWindow(rp, nil)
+		if err == nil {
+			response.ChallengeWindow = challengeWindow
+		}
+		return err
+	})
+
+	// Get the node's ETH balance
+	wg.Go(func() error {
+		var err error
+		balance, err = ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Members are limited by a cooldown between challenges; everyone else pays the challenge cost instead
+	if response.IsMember {
+		cooldownActive, err := getChallengeCooldownActive(rp, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+		response.ChallengeCooldown = cooldownActive
+	} else {
+		response.InsufficientBalance = (balance.Cmp(response.ChallengeCost) < 0)
+	}
+
+	// Update & return response
+	response.CanChallenge = !(response.MemberDoesNotExist || response.ChallengeSelf || response.AlreadyChallenged || response.ChallengeCooldown || response.InsufficientBalance)
+	if !response.CanChallenge {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsMember {
+		opts.Value = response.ChallengeCost
+	}
+	gasInfo, err := trustednode.EstimateMakeChallengeGas(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+	return &response, nil
+
+}
+
+func challengeMember(c *cli.Context, memberAddress common.Address) (*api.ChallengeTNDAOMemberResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ChallengeTNDAOMemberResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Non-members must send the challenge cost with the challenge
+	isMember, err := trustednode.GetMemberExists(rp, opts.From, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		challengeCost, err := tnsettings.GetChallengeCost(rp, nil)
+		if err != nil {
+			return nil, err
+		}
+		opts.Value = challengeCost
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Make the challenge
+	hash, err := trustednode.MakeChallenge(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func canDecideChallenge(c *cli.Context, memberAddress common.Address) (*api.CanDecideTNDAOChallengeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanDecideTNDAOChallengeResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.IsOwnChallenge = (nodeAccount.Address == memberAddress)
+
+	// Sync
+	var wg errgroup.Group
+	var challengedTime uint64
+	var challengeWindow uint64
+
+	// Get the time the challenge was made
+	wg.Go(func() error {
+		var err error
+		challengedTime, err = getMemberChallengedTime(rp, memberAddress)
+		return err
+	})
+
+	// Get the challenge window
+	wg.Go(func() error {
+		var err error
+		challengeWindow, err = tnsettings.GetChallengeWindow(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// A challenged member can respond at any time, but anyone else has to wait for the window to close
+	response.NotChallenged = (challengedTime == 0)
+	if !response.NotChallenged {
+		response.ChallengeWindowEnd = challengedTime + challengeWindow
+		response.ChallengeWindowOpen = !response.IsOwnChallenge && (uint64(time.Now().Unix()) <= response.ChallengeWindowEnd)
+	}
+
+	// Update & return response
+	response.CanDecide = !(response.NotChallenged || response.ChallengeWindowOpen)
+	if !response.CanDecide {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+	return &response, nil
+
+}
+
+func decideChallenge(c *cli.Context, memberAddress common.Address) (*api.DecideTNDAOChallengeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DecideTNDAOChallengeResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Decide the challenge
+	hash, err := trustednode.DecideChallenge(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -470,6 +470,110 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "membership",
+				Usage:     "Get the node's oracle DAO bond, challenge and replacement status",
+				UsageText: "rocketpool api odao membership",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMembership(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-challenge-member",
+				Usage:     "Check whether the node can challenge an oracle DAO member",
+				UsageText: "rocketpool api odao can-challenge-member member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canChallengeMember(c, memberAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "challenge-member",
+				Usage:     "Challenge an oracle DAO member to prove they are still online",
+				UsageText: "rocketpool api odao challenge-member member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(challengeMember(c, memberAddress))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-decide-challenge",
+				Usage:     "Check whether the node can decide a challenge against an oracle DAO member",
+				UsageText: "rocketpool api odao can-decide-challenge member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canDecideChallenge(c, memberAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "decide-challenge",
+				Usage:     "Decide a challenge against an oracle DAO member (responds to it if the member is this node)",
+				UsageText: "rocketpool api odao decide-challenge member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(decideChallenge(c, memberAddress))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-propose-members-quorum",
 				Usage:     "Check whether the node can propose the members.quorum setting",
@@ -0,0 +1,160 @@
+package odao
+
+import (
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMembership(c *cli.Context) (*api.TNDAOMembershipResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOMembershipResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get membership status
+	isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsMember = isMember
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the current bond requirement and proposal action window
+	wg.Go(func() error {
+		requiredBond, err := tnsettings.GetRPLBond(rp, nil)
+		if err == nil {
+			response.RequiredRPLBond = requiredBond
+		}
+		return err
+	})
+	wg.Go(func() error {
+		actionTime, err := tnsettings.GetProposalActionTime(rp, nil)
+		if err == nil {
+			response.ProposalActionTime = actionTime
+		}
+		return err
+	})
+
+	if isMember {
+
+		// Get the node's bond
+		wg.Go(func() error {
+			bondAmount, err := trustednode.GetMemberRPLBondAmount(rp, nodeAccount.Address, nil)
+			if err == nil {
+				response.RPLBondAmount = bondAmount
+			}
+			return err
+		})
+		wg.Go(func() error {
+			unbondedValidatorCount, err := trustednode.GetMemberUnbondedValidatorCount(rp, nodeAccount.Address, nil)
+			if err == nil {
+				response.UnbondedValidatorCount = unbondedValidatorCount
+			}
+			return err
+		})
+
+		// Get the node's challenge status
+		wg.Go(func() error {
+			challengedTime, err := getMemberChallengedTime(rp, nodeAccount.Address)
+			if err != nil {
+				return err
+			}
+			response.ChallengedTime = challengedTime
+			response.IsChallenged = (challengedTime > 0)
+			if !response.IsChallenged {
+				return nil
+			}
+			response.ChallengedBy, err = getMemberChallengedBy(rp, nodeAccount.Address)
+			return err
+		})
+		wg.Go(func() error {
+			challengeWindow, err := tnsettings.GetChallengeWindow(rp, nil)
+			if err == nil {
+				response.ChallengeWindow = challengeWindow
+			}
+			return err
+		})
+
+		// Get the node's leave and replacement proposals
+		wg.Go(func() error {
+			executedTime, err := trustednode.GetMemberLeaveProposalExecutedTime(rp, nodeAccount.Address, nil)
+			if err == nil {
+				response.LeaveProposalExecutedTime = executedTime
+			}
+			return err
+		})
+		wg.Go(func() error {
+			executedTime, err := trustednode.GetMemberReplaceProposalExecutedTime(rp, nodeAccount.Address, nil)
+			if err == nil {
+				response.ReplaceProposalExecutedTime = executedTime
+			}
+			return err
+		})
+		wg.Go(func() error {
+			replacementAddress, err := trustednode.GetMemberReplacementAddress(rp, nodeAccount.Address, nil)
+			if err == nil {
+				response.ReplacementAddress = replacementAddress
+			}
+			return err
+		})
+
+	} else {
+
+		// Check whether the node has been named as the replacement for an existing member
+		wg.Go(func() error {
+			memberAddresses, err := trustednode.GetMemberAddresses(rp, nil)
+			if err != nil {
+				return err
+			}
+			for _, memberAddress := range memberAddresses {
+				replacementAddress, err := trustednode.GetMemberReplacementAddress(rp, memberAddress, nil)
+				if err != nil {
+					return err
+				}
+				if replacementAddress == nodeAccount.Address {
+					response.ReplacingMember = memberAddress
+					break
+				}
+			}
+			return nil
+		})
+
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao"
 	tndao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -15,6 +16,9 @@ import (
 // Settings
 const ProposalStatesBatchSize = 50
 
+// The RocketStorage namespace the oracle DAO keeps its member state under
+const memberStorageNamespace = "dao.trustednodes."
+
 // Check if the proposal cooldown for an oracle node is active
 func getProposalCooldownActive(rp *rocketpool.RocketPool, nodeAddress common.Address) (bool, error) {
 
@@ -153,3 +157,51 @@ func getProposalStates(rp *rocketpool.RocketPool) ([]rptypes.ProposalState, erro
 	return states, nil
 
 }
+
+// Get the time a challenge was made against a member, or 0 if they are not challenged
+func getMemberChallengedTime(rp *rocketpool.RocketPool, memberAddress common.Address) (uint64, error) {
+	challengedTime, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte(memberStorageNamespace+"member.challenged.time"), memberAddress.Bytes()))
+	if err != nil {
+		return 0, err
+	}
+	return challengedTime.Uint64(), nil
+}
+
+// Get the address that made the current challenge against a member
+func getMemberChallengedBy(rp *rocketpool.RocketPool, memberAddress common.Address) (common.Address, error) {
+	return rp.RocketStorage.GetAddress(nil, crypto.Keccak256Hash([]byte(memberStorageNamespace+"member.challenged.by"), memberAddress.Bytes()))
+}
+
+// Check if the challenge cooldown for an oracle node is active
+func getChallengeCooldownActive(rp *rocketpool.RocketPool, nodeAddress common.Address) (bool, error) {
+
+	// Data
+	var wg errgroup.Group
+	var lastChallengeTime uint64
+	var challengeCooldown uint64
+
+	// Get last challenge time
+	wg.Go(func() error {
+		createdTime, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte(memberStorageNamespace+"member.challenge.created"), nodeAddress.Bytes()))
+		if err == nil {
+			lastChallengeTime = createdTime.Uint64()
+		}
+		return err
+	})
+
+	// Get challenge cooldown
+	wg.Go(func() error {
+		var err error
+		challengeCooldown, err = tnsettings.GetChallengeCooldown(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return false, err
+	}
+
+	// Return
+	return ((uint64(time.Now().Unix()) - lastChallengeTime) <= challengeCooldown), nil
+
+}
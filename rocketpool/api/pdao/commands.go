@@ -0,0 +1,84 @@
+package pdao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage the node's participation in the Rocket Pool protocol DAO",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "voting-power",
+				Aliases:   []string{"p"},
+				Usage:     "Get the node's voting power and its voting delegate",
+				UsageText: "rocketpool api pdao voting-power",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getVotingPower(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-vote",
+				Usage:     "Check whether the node can vote on a proposal",
+				UsageText: "rocketpool api pdao can-vote proposal-id choice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId := c.Args().Get(0)
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canVote(c, proposalId, choice))
+					return nil
+
+				},
+			},
+			{
+				Name:      "vote",
+				Aliases:   []string{"v"},
+				Usage:     "Vote on a proposal",
+				UsageText: "rocketpool api pdao vote proposal-id choice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId := c.Args().Get(0)
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(vote(c, proposalId, choice))
+					return nil
+
+				},
+			},
+		},
+	})
+}
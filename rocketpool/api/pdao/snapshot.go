@@ -0,0 +1,209 @@
+package pdao
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const (
+	// The EIP-712 domain Snapshot expects votes to be signed under
+	snapshotDomainName    string = "snapshot"
+	snapshotDomainVersion string = "0.1.4"
+
+	// The app name votes are tagged with on Snapshot
+	snapshotAppName string = "smartnode"
+
+	// The timeout for requests to the Snapshot API and sequencer
+	snapshotRequestTimeout time.Duration = 10 * time.Second
+)
+
+// A response from the Snapshot sequencer
+type snapshotSequencerResponse struct {
+	Id               string `json:"id"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Get an HTTP client for the Snapshot API and sequencer, routed through the configured proxy
+func getSnapshotHttpClient() *http.Client {
+	client := *net.GetHttpClient(net.HttpTarget_Snapshot)
+	client.Timeout = snapshotRequestTimeout
+	return &client
+}
+
+// Get a Snapshot proposal by its ID, or nil if it doesn't exist
+func GetSnapshotProposal(apiDomain string, id string) (*api.SnapshotProposal, error) {
+	client := getSnapshotHttpClient()
+	query := `query Proposal($id: String!) {
+	proposal(id: $id) {
+	    id
+	    title
+	    choices
+	    start
+	    end
+	    snapshot
+	    state
+	    type
+	    author
+		scores
+		scores_total
+		scores_updated
+		quorum
+		link
+	  }
+    }`
+
+	// The ID is passed as a variable rather than put into the query, so it can't change the query itself
+	variables, err := json.Marshal(map[string]string{"id": id})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing snapshot query variables: %w", err)
+	}
+	params := url.Values{}
+	params.Set("operationName", "Proposal")
+	params.Set("query", query)
+	params.Set("variables", string(variables))
+	url := fmt.Sprintf("https://%s/graphql?%s", apiDomain, params.Encode())
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Data struct {
+			Proposal *api.SnapshotProposal `json:"proposal"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot response: %w", err)
+	}
+	return response.Data.Proposal, nil
+}
+
+// Check if a proposal takes a single choice per vote, which is the only kind the Smartnode can vote on
+func IsSingleChoiceProposal(proposal api.SnapshotProposal) bool {
+	return proposal.Type == "single-choice" || proposal.Type == "basic"
+}
+
+// Sign a vote for one of a proposal's choices (starting at 1) with the node wallet and send it to the Snapshot sequencer.
+// Returns the ID of the recorded vote.
+func CastSnapshotVote(cfg *config.RocketPoolConfig, w *wallet.Wallet, proposalId string, choice uint64) (string, error) {
+	sequencerUrl := cfg.Smartnode.GetSnapshotSequencerUrl()
+	if sequencerUrl == "" {
+		return "", fmt.Errorf("voting is not enabled on network [%v]", cfg.Smartnode.Network.Value)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return "", err
+	}
+
+	// Older proposals have IPFS hashes for IDs instead of hex strings
+	proposalType := "string"
+	if strings.HasPrefix(proposalId, "0x") {
+		proposalType = "bytes32"
+	}
+
+	// Build the vote message
+	voteTypes := []apitypes.Type{
+		{Name: "from", Type: "address"},
+		{Name: "space", Type: "string"},
+		{Name: "timestamp", Type: "uint64"},
+		{Name: "proposal", Type: proposalType},
+		{Name: "choice", Type: "uint32"},
+		{Name: "reason", Type: "string"},
+		{Name: "app", Type: "string"},
+		{Name: "metadata", Type: "string"},
+	}
+	message := apitypes.TypedDataMessage{
+		"from":      nodeAccount.Address.Hex(),
+		"space":     cfg.Smartnode.GetSnapshotID(),
+		"timestamp": float64(time.Now().Unix()),
+		"proposal":  proposalId,
+		"choice":    float64(choice),
+		"reason":    "",
+		"app":       snapshotAppName,
+		"metadata":  "{}",
+	}
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"Vote": voteTypes,
+		},
+		PrimaryType: "Vote",
+		Domain: apitypes.TypedDataDomain{
+			Name:    snapshotDomainName,
+			Version: snapshotDomainVersion,
+		},
+		Message: message,
+	}
+
+	// Sign it
+	signature, _, err := w.SignTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+
+	// Send it to the sequencer
+	envelope := map[string]interface{}{
+		"address": nodeAccount.Address.Hex(),
+		"sig":     hexutil.Encode(signature),
+		"data": map[string]interface{}{
+			"domain": map[string]string{
+				"name":    snapshotDomainName,
+				"version": snapshotDomainVersion,
+			},
+			"types": map[string][]apitypes.Type{
+				"Vote": voteTypes,
+			},
+			"message": message,
+		},
+	}
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("error serializing vote: %w", err)
+	}
+	client := getSnapshotHttpClient()
+	resp, err := client.Post(sequencerUrl, "application/json", bytes.NewReader(envelopeBytes))
+	if err != nil {
+		return "", fmt.Errorf("error sending vote to Snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading Snapshot response: %w", err)
+	}
+
+	var response snapshotSequencerResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("could not decode Snapshot response (HTTP status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || response.Error != "" {
+		return "", fmt.Errorf("Snapshot rejected the vote (HTTP status %d): %s %s", resp.StatusCode, response.Error, response.ErrorDescription)
+	}
+	return response.Id, nil
+}
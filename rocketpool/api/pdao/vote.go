@@ -0,0 +1,98 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api/node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func canVote(c *cli.Context, proposalId string, choice uint64) (*api.PdaoCanVoteResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Smartnode.GetSnapshotSequencerUrl() == "" {
+		return nil, fmt.Errorf("voting is not enabled on network [%s]", cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	}
+
+	// Response
+	response := api.PdaoCanVoteResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the proposal
+	proposal, err := GetSnapshotProposal(cfg.Smartnode.GetSnapshotApiDomain(), proposalId)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		response.ProposalNotFound = true
+		return &response, nil
+	}
+	response.Proposal = *proposal
+
+	// Check the proposal
+	response.ProposalNotActive = (proposal.State != "active")
+	response.UnsupportedType = !IsSingleChoiceProposal(*proposal)
+	response.InvalidChoice = (choice < 1 || choice > uint64(len(proposal.Choices)))
+
+	// Check the voting power
+	votingPower, err := node.GetSnapshotVotingPower(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.VotingPower = votingPower.Data.Vp.Vp
+	response.NoVotingPower = (response.VotingPower == 0)
+
+	// Update & return response
+	response.CanVote = !(response.ProposalNotActive || response.UnsupportedType || response.InvalidChoice || response.NoVotingPower)
+	return &response, nil
+
+}
+
+func vote(c *cli.Context, proposalId string, choice uint64) (*api.PdaoVoteResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PdaoVoteResponse{}
+
+	// Cast the vote
+	response.VoteId, err = CastSnapshotVote(cfg, w, proposalId, choice)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
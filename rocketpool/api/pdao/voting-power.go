@@ -0,0 +1,64 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api/node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func getVotingPower(c *cli.Context) (*api.PdaoVotingPowerResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	s, err := services.GetSnapshotDelegation(c)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("voting is not enabled on network [%s]", cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	}
+
+	// Response
+	response := api.PdaoVotingPowerResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Get the delegate
+	idHash := cfg.Smartnode.GetVotingSnapshotID()
+	response.VotingDelegate, err = s.Delegation(nil, nodeAccount.Address, idHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the voting power, which includes any power delegated to the node
+	votingPower, err := node.GetSnapshotVotingPower(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.VotingPower = votingPower.Data.Vp.Vp
+	response.VotingPowerByStrategy = votingPower.Data.Vp.VpByStrategy
+
+	// Return response
+	return &response, nil
+
+}
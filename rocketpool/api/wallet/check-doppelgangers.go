@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func checkDoppelgangers(c *cli.Context) (*api.CheckDoppelgangersResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckDoppelgangersResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the node's validators
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := validator.CheckForDoppelgangers(cfg, bc, d, pubkeys)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for doppelgangers: %w", err)
+	}
+	response.Checked = result.Checked
+	response.Epochs = result.Epochs
+	response.LiveValidators = result.LiveValidators
+
+	// Return response
+	return &response, nil
+
+}
@@ -45,7 +45,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err := cliutils.ValidateArgCount(c, 1); err != nil {
 						return err
 					}
-					password, err := cliutils.ValidateNodePassword("wallet password", c.Args().Get(0))
+					password, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					password, err = cliutils.ValidateNodePassword("wallet password", password)
 					if err != nil {
 						return err
 					}
@@ -67,6 +71,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path when initializing your wallet",
+						Value: 0,
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -108,7 +117,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err := cliutils.ValidateArgCount(c, 1); err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					mnemonic, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -120,6 +133,30 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "restore-backup",
+				Usage:     "Restore a node wallet from the wallet file in a backup",
+				UsageText: "rocketpool api wallet restore-backup wallet-json",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "skip-validator-key-recovery, k",
+						Usage: "Restore the node wallet, but do not regenerate its validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(restoreWalletBackup(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "search-and-recover",
 				Aliases:   []string{"r"},
@@ -137,7 +174,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					mnemonic, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -153,6 +194,43 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "search",
+				Usage:     "Search the standard derivation paths and indices of a mnemonic phrase for accounts with on-chain history",
+				UsageText: "rocketpool api wallet search mnemonic",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "An additional custom derivation path to search, with %d in place of the index",
+					},
+					cli.UintFlag{
+						Name:  "max-index, m",
+						Usage: "The number of indices to search on each derivation path",
+						Value: defaultSearchIndices,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					mnemonic, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(searchWallet(c, mnemonic))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
@@ -172,6 +250,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "check-doppelgangers",
+				Usage:     "Check if any of the node's validators are attesting from another machine while the local validator client isn't",
+				UsageText: "rocketpool api wallet check-doppelgangers",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkDoppelgangers(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"r"},
@@ -198,7 +294,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err := cliutils.ValidateArgCount(c, 1); err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					mnemonic, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -227,7 +327,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
-					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					mnemonic, err := api.GetSecretArg(c, 0)
+					if err != nil {
+						return err
+					}
+					mnemonic, err = cliutils.ValidateWalletMnemonic("mnemonic", mnemonic)
 					if err != nil {
 						return err
 					}
@@ -262,6 +366,115 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "test-transaction",
+				Usage:     "Sign a zero-value transaction to the node account without broadcasting it",
+				UsageText: "rocketpool api wallet test-transaction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(testTransaction(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "masquerade",
+				Usage:     "Put the wallet into a read-only mode that reports another node's address as the node account",
+				UsageText: "rocketpool api wallet masquerade address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(masquerade(c, address))
+					return nil
+
+				},
+			},
+			{
+				Name:      "end-masquerade",
+				Usage:     "Stop masquerading and go back to the node wallet's own account",
+				UsageText: "rocketpool api wallet end-masquerade",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(endMasquerade(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "transactor-status",
+				Usage:     "Get the address and balance of the wallet's transactor key, if it has one",
+				UsageText: "rocketpool api wallet transactor-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactorStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "create-transactor",
+				Usage:     "Create a new transactor key for routine daemon transactions, moving the balance of the current one to it",
+				UsageText: "rocketpool api wallet create-transactor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createTransactor(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "remove-transactor",
+				Usage:     "Retire the transactor key and send its balance back to the node account",
+				UsageText: "rocketpool api wallet remove-transactor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(removeTransactor(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "estimate-gas-set-ens-name",
 				Usage:     "Estimate the gas required to set the name for the node wallet's ENS reverse record",
@@ -40,8 +40,11 @@ func initWallet(c *cli.Context) (*api.InitWalletResponse, error) {
 		path = wallet.MyEtherWalletNodeKeyPath
 	}
 
+	// Get the wallet index
+	walletIndex := c.Uint("wallet-index")
+
 	// Initialize wallet but don't save it
-	mnemonic, err := w.Initialize(path, 0)
+	mnemonic, err := w.Initialize(path, walletIndex)
 	if err != nil {
 		return nil, err
 	}
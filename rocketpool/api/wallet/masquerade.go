@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func masquerade(c *cli.Context, address common.Address) (*api.MasqueradeResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MasqueradeResponse{}
+
+	// Masquerade as the node
+	if err := w.Masquerade(address); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func endMasquerade(c *cli.Context) (*api.EndMasqueradeResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.EndMasqueradeResponse{
+		WasMasquerading: w.IsMasquerading(),
+	}
+
+	// Go back to the node wallet
+	if err := w.EndMasquerade(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -1,10 +1,16 @@
 package wallet
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
@@ -17,6 +23,10 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	if err := services.RequireRocketStorage(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -25,6 +35,14 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.RebuildWalletResponse{}
@@ -35,6 +53,46 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 		return nil, err
 	}
 
+	// Make sure Web3Signer is up before pushing the keys to it
+	if cfg.Smartnode.UseWeb3Signer.Value == true {
+		signerUrl, err := cfg.Smartnode.GetWeb3SignerUrl()
+		if err != nil {
+			return nil, err
+		}
+		if err := web3signer.NewClient(signerUrl).Upcheck(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Make sure none of the validators are attesting from another machine before loading their keys
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	doppelgangers, err := validator.CheckForDoppelgangers(cfg, bc, d, pubkeys)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for doppelgangers: %w", err)
+	}
+	if len(doppelgangers.LiveValidators) > 0 {
+		liveKeys := make([]string, len(doppelgangers.LiveValidators))
+		for i, pubkey := range doppelgangers.LiveValidators {
+			liveKeys[i] = pubkey.Hex()
+		}
+		return nil, fmt.Errorf("the following validators were attesting during epoch(s) %v while your validator client was not, so they appear to be running on another machine:\n%s\nLoading their keys here could get them slashed, so the wallet was not rebuilt. Stop the other machine's validator client and wait for at least two epochs before trying again", doppelgangers.Epochs, strings.Join(liveKeys, "\n"))
+	}
+	response.DoppelgangerCheckSkipped = !doppelgangers.Checked
+
+	// Take the keys out of the local keystores before they're moved to Web3Signer
+	if cfg.Smartnode.UseWeb3Signer.Value == true {
+		localKeystores, err := services.GetLocalKeystores(c)
+		if err != nil {
+			return nil, err
+		}
+		if err := validator.DisableLocalKeystores(cfg, localKeystores, pubkeys); err != nil {
+			return nil, err
+		}
+	}
+
 	// Recover validator keys
 	response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
 	if err != nil {
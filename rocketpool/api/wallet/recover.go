@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
@@ -85,6 +86,12 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 		return nil, err
 	}
 
+	// Record the wallet
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_WalletSaved,
+		Message: fmt.Sprintf("Recovered the node wallet for account %s (derivation path %s, index %d).", nodeAccount.Address.Hex(), path, walletIndex),
+	})
+
 	// Return response
 	return &response, nil
 
@@ -183,6 +190,12 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 		return nil, err
 	}
 
+	// Record the wallet
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_WalletSaved,
+		Message: fmt.Sprintf("Recovered the node wallet for account %s (derivation path %s, index %d).", nodeAccount.Address.Hex(), response.DerivationPath, response.Index),
+	})
+
 	// Return response
 	return &response, nil
 
@@ -0,0 +1,78 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+func restoreWalletBackup(c *cli.Context, walletJson string) (*api.RecoverWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireNodePassword(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	var rp *rocketpool.RocketPool
+	if !c.Bool("skip-validator-key-recovery") {
+		if err := services.RequireRocketStorage(c); err != nil {
+			return nil, err
+		}
+		rp, err = services.GetRocketPool(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Response
+	response := api.RecoverWalletResponse{}
+
+	// Check if wallet is already initialized
+	if w.IsInitialized() {
+		return nil, errors.New("the wallet is already initialized")
+	}
+
+	// Restore wallet
+	if err := w.Restore(walletJson); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	if !c.Bool("skip-validator-key-recovery") {
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Record the wallet
+	services.RecordEvent(c, journal.Event{
+		Type:    journal.EventType_WalletSaved,
+		Message: fmt.Sprintf("Restored the node wallet for account %s from a backup.", nodeAccount.Address.Hex()),
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+const (
+	defaultSearchIndices uint = 20
+	maxSearchIndices     uint = 1000
+)
+
+func searchWallet(c *cli.Context, mnemonic string) (*api.SearchWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SearchWalletResponse{
+		Accounts: []api.WalletSearchAccount{},
+	}
+
+	// Check the number of indices to search
+	indices := c.Uint("max-index")
+	if indices == 0 || indices > maxSearchIndices {
+		return nil, fmt.Errorf("the number of indices to search must be between 1 and %d", maxSearchIndices)
+	}
+
+	// Get the derivation paths to search
+	paths := []string{
+		wallet.DefaultNodeKeyPath,
+		wallet.LedgerLiveNodeKeyPath,
+		wallet.MyEtherWalletNodeKeyPath,
+	}
+	switch customPath := c.String("derivation-path"); customPath {
+	case "", "ledgerLive", "mew":
+		// These are already included
+	default:
+		paths = append(paths, customPath)
+	}
+
+	// Check every path and index for an account that has sent transactions or holds ETH
+	chainId := cfg.Smartnode.GetChainID()
+	for _, derivationPath := range paths {
+		for i := uint(0); i < indices; i++ {
+			candidate, err := wallet.NewWallet("", chainId, nil, nil, 0, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error generating new wallet: %w", err)
+			}
+			err = candidate.TestRecovery(derivationPath, i, mnemonic)
+			if err != nil {
+				return nil, fmt.Errorf("error recovering wallet with path [%s], index [%d]: %w", derivationPath, i, err)
+			}
+			account, err := candidate.GetNodeAccount()
+			if err != nil {
+				return nil, fmt.Errorf("error getting account for path [%s], index [%d]: %w", derivationPath, i, err)
+			}
+
+			nonce, err := ec.NonceAt(context.Background(), account.Address, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting nonce for %s: %w", account.Address.Hex(), err)
+			}
+			balance, err := ec.BalanceAt(context.Background(), account.Address, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting balance for %s: %w", account.Address.Hex(), err)
+			}
+			if nonce == 0 && balance.Sign() == 0 {
+				continue
+			}
+
+			response.Accounts = append(response.Accounts, api.WalletSearchAccount{
+				DerivationPath: derivationPath,
+				Index:          i,
+				Address:        account.Address,
+				Nonce:          nonce,
+				Balance:        balance,
+			})
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
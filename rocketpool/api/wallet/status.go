@@ -7,6 +7,11 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// Get the node wallet's status; used by the node daemon's HTTP API
+func GetStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
+	return getStatus(c)
+}
+
 func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	// Get services
@@ -25,9 +30,10 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
 	response.WalletInitialized = w.IsInitialized()
+	response.IsMasquerading = w.IsMasquerading()
 
-	// Get accounts if initialized
-	if response.WalletInitialized {
+	// Get accounts if initialized; a masquerading wallet reports the address it's masquerading as
+	if response.WalletInitialized || response.IsMasquerading {
 
 		// Get node account
 		nodeAccount, err := w.GetNodeAccount()
@@ -38,6 +44,16 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	}
 
+	// Get the transactor account if there is one
+	response.HasTransactor = w.HasTransactorKey()
+	if response.HasTransactor {
+		transactorAccount, err := w.GetTransactorAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.TransactorAddress = transactorAccount.Address
+	}
+
 	// Return response
 	return &response, nil
 
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The gas limit of a plain ETH transfer
+const transferGasLimit uint64 = 21000
+
+func testTransaction(c *cli.Context) (*api.TestTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TestTransactionResponse{}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+	response.DerivationPath = nodeAccount.URL.Path
+
+	// Build a zero-value transfer to the node itself, priced like a real transaction
+	nonce, err := ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the node account's nonce: %w", err)
+	}
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the latest block: %w", err)
+	}
+	tipCap := eth.GweiToWei(2)
+	feeCap := big.NewInt(0).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   w.GetChainID(),
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       transferGasLimit,
+		To:        &nodeAccount.Address,
+		Value:     big.NewInt(0),
+	})
+	unsignedBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing the test transaction: %w", err)
+	}
+
+	// Sign it the same way the node signs real transactions
+	signedBytes, err := w.Sign(unsignedBytes)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := types.Transaction{}
+	err = signedTx.UnmarshalBinary(signedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error deserializing the signed test transaction: %w", err)
+	}
+
+	// Make sure the signature belongs to the node account
+	sender, err := types.Sender(types.NewLondonSigner(w.GetChainID()), &signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering the signer of the test transaction: %w", err)
+	}
+	response.RecoveredAddress = sender
+	response.SignatureValid = (sender == nodeAccount.Address)
+	response.SignedTx = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+	response.TxHash = signedTx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
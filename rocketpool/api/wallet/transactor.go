@@ -0,0 +1,187 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTransactorStatus(c *cli.Context) (*api.TransactorStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TransactorStatusResponse{}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the transactor account and its balance
+	response.HasTransactor = w.HasTransactorKey()
+	if response.HasTransactor {
+		transactorAccount, err := w.GetTransactorAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.Address = transactorAccount.Address
+		response.Balance, err = ec.BalanceAt(context.Background(), transactorAccount.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting the transactor account's balance: %w", err)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func createTransactor(c *cli.Context) (*api.CreateTransactorResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateTransactorResponse{}
+
+	// Create the new key, retiring the old one
+	retiredOpts, err := w.CreateTransactorKey()
+	if err != nil {
+		return nil, err
+	}
+	transactorAccount, err := w.GetTransactorAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Address = transactorAccount.Address
+
+	// Move whatever the retired key holds to the new one
+	if retiredOpts != nil {
+		response.Rotated = true
+		response.RetiredAddress = retiredOpts.From
+		response.SweptAmount, response.SweepTxHash, err = sweepTransactor(ec, w.GetChainID(), retiredOpts, transactorAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func removeTransactor(c *cli.Context) (*api.RemoveTransactorResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RemoveTransactorResponse{}
+
+	// The sweep goes to the node account, which is someone else's address while masquerading
+	if w.IsMasquerading() {
+		return nil, wallet.ErrMasquerading
+	}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Retire the key and send whatever it holds back to the node account
+	retiredOpts, err := w.RemoveTransactorKey()
+	if err != nil {
+		return nil, err
+	}
+	response.RetiredAddress = retiredOpts.From
+	response.SweptAmount, response.SweepTxHash, err = sweepTransactor(ec, w.GetChainID(), retiredOpts, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Send the balance of a retired transactor key, minus the gas for the transfer, to another address.
+// Returns a zero amount if the balance doesn't cover the gas.
+func sweepTransactor(ec rocketpool.ExecutionClient, chainID *big.Int, opts *bind.TransactOpts, to common.Address) (*big.Int, common.Hash, error) {
+
+	// Get the balance
+	balance, err := ec.BalanceAt(context.Background(), opts.From, nil)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("Error getting the retired transactor account's balance: %w", err)
+	}
+
+	// Price the transfer like a real transaction and leave the worst-case fee behind
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("Error getting the latest block: %w", err)
+	}
+	tipCap := eth.GweiToWei(2)
+	feeCap := big.NewInt(0).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	maxCost := big.NewInt(0).Mul(feeCap, big.NewInt(int64(transferGasLimit)))
+	amount := big.NewInt(0).Sub(balance, maxCost)
+	if amount.Sign() <= 0 {
+		return big.NewInt(0), common.Hash{}, nil
+	}
+
+	// Send it
+	opts.GasFeeCap = feeCap
+	opts.GasTipCap = tipCap
+	opts.GasLimit = transferGasLimit
+	opts.Value = amount
+	hash, err := eth.SendTransaction(ec, to, chainID, opts)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("Error sending the retired transactor account's balance to %s: %w", to.Hex(), err)
+	}
+	return amount, hash, nil
+
+}
@@ -0,0 +1,95 @@
+package bnproxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	UpstreamEnvVar string = "BN_PROXY_UPSTREAM"
+	ProxyColor            = color.FgHiMagenta
+	ErrorColor            = color.FgRed
+)
+
+var statsInterval, _ = time.ParseDuration("10m")
+
+// Register the proxy command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run the caching Beacon proxy that deduplicates requests to the Consensus client",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "upstream, u",
+				Usage: fmt.Sprintf("The URL of the Consensus client's HTTP API (defaults to the %s environment variable)", UpstreamEnvVar),
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the proxy
+func run(c *cli.Context) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Initialize loggers
+	logger := log.NewModuleLogger("bnproxy", ProxyColor)
+	errorLog := log.NewErrorLogger("bnproxy", ErrorColor)
+
+	// Get the upstream Consensus client
+	upstream := c.String("upstream")
+	if upstream == "" {
+		upstream = os.Getenv(UpstreamEnvVar)
+	}
+	if upstream == "" {
+		return fmt.Errorf("no upstream Consensus client was provided; use the --upstream flag or set %s", UpstreamEnvVar)
+	}
+	upstreamUrl, err := url.Parse(upstream)
+	if err != nil {
+		return fmt.Errorf("error parsing upstream URL [%s]: %w", upstream, err)
+	}
+
+	// Create the cache
+	ttl := time.Duration(cfg.ConsensusCommon.BnProxyCacheTtl.Value.(uint64)) * time.Second
+	proxy := httputil.NewSingleHostReverseProxy(upstreamUrl)
+	cache := newResponseCache(proxy, ttl, &errorLog)
+
+	// Periodically report how much load the cache is saving
+	go func() {
+		for {
+			time.Sleep(statsInterval)
+			hits, misses := cache.getStats()
+			logger.Printlnf("Served %d requests from cache, forwarded %d to the Consensus client.", hits, misses)
+		}
+	}()
+
+	// Start the HTTP server
+	port := cfg.ConsensusCommon.BnProxyPort.Value.(uint16)
+	logger.Printlnf("Starting caching Beacon proxy on port %d, forwarding to %s with a %s cache.", port, upstreamUrl.String(), ttl)
+	err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", port), cache)
+	if err != nil {
+		return fmt.Errorf("error running HTTP server: %w", err)
+	}
+
+	return nil
+
+}
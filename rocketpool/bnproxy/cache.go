@@ -0,0 +1,197 @@
+package bnproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Routes that are safe to cache for a short time, because the VC and the daemons query them repeatedly with identical arguments
+var cacheableRoutes = []*regexp.Regexp{
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validators$`),
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validators/[^/]+$`),
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/committees$`),
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/finality_checkpoints$`),
+	regexp.MustCompile(`^/eth/v1/validator/duties/attester/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/validator/duties/proposer/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/validator/duties/sync/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/node/syncing$`),
+	regexp.MustCompile(`^/eth/v1/config/spec$`),
+	regexp.MustCompile(`^/eth/v1/beacon/genesis$`),
+}
+
+// How long a forwarded request can take; it doesn't follow any one caller's context since other callers may be waiting on it
+const upstreamTimeout time.Duration = 30 * time.Second
+
+// A cached response from the Consensus client
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expiry     time.Time
+}
+
+// An HTTP handler that caches and deduplicates identical requests before forwarding them upstream
+type responseCache struct {
+	upstream http.Handler
+	ttl      time.Duration
+	errorLog *log.ColorLogger
+
+	entries map[string]*cachedResponse
+	group   singleflight.Group
+	lock    sync.Mutex
+	hits    uint64
+	misses  uint64
+}
+
+// Create a new response cache in front of the upstream handler
+func newResponseCache(upstream http.Handler, ttl time.Duration, errorLog *log.ColorLogger) *responseCache {
+	cache := &responseCache{
+		upstream: upstream,
+		ttl:      ttl,
+		errorLog: errorLog,
+		entries:  map[string]*cachedResponse{},
+	}
+	go cache.prune()
+	return cache
+}
+
+// Serve a request, using the cache if possible
+func (cache *responseCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	// Pass anything that isn't cacheable straight through
+	if cache.ttl == 0 || !isCacheable(r) {
+		cache.upstream.ServeHTTP(w, r)
+		return
+	}
+
+	// Read the body so it can be part of the cache key
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading request body: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+		r.Body.Close()
+	}
+	key := getCacheKey(r, body)
+
+	// Check for a fresh cached copy
+	cache.lock.Lock()
+	entry, exists := cache.entries[key]
+	if exists && time.Now().Before(entry.expiry) {
+		cache.hits++
+		cache.lock.Unlock()
+		writeResponse(w, entry)
+		return
+	}
+	cache.lock.Unlock()
+
+	// Forward the request, merging it with any identical requests that are already in flight
+	result, err, shared := cache.group.Do(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), upstreamTimeout)
+		defer cancel()
+		upstreamRequest := r.Clone(ctx)
+		upstreamRequest.Body = io.NopCloser(bytes.NewReader(body))
+		recorder := httptest.NewRecorder()
+		cache.upstream.ServeHTTP(recorder, upstreamRequest)
+
+		response := &cachedResponse{
+			statusCode: recorder.Code,
+			header:     recorder.Header().Clone(),
+			body:       recorder.Body.Bytes(),
+			expiry:     time.Now().Add(cache.ttl),
+		}
+
+		// Only keep successful responses
+		cache.lock.Lock()
+		cache.misses++
+		if response.statusCode == http.StatusOK {
+			cache.entries[key] = response
+		}
+		cache.lock.Unlock()
+		return response, nil
+	})
+	if err != nil {
+		cache.errorLog.Printlnf("Error forwarding %s %s: %s", r.Method, r.URL.Path, err.Error())
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if shared {
+		cache.lock.Lock()
+		cache.hits++
+		cache.lock.Unlock()
+	}
+	writeResponse(w, result.(*cachedResponse))
+
+}
+
+// Get the number of requests served from the cache and forwarded upstream
+func (cache *responseCache) getStats() (uint64, uint64) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.hits, cache.misses
+}
+
+// Periodically remove expired entries so the cache doesn't grow forever
+func (cache *responseCache) prune() {
+	interval := cache.ttl * 10
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	for {
+		time.Sleep(interval)
+		now := time.Now()
+		cache.lock.Lock()
+		for key, entry := range cache.entries {
+			if now.After(entry.expiry) {
+				delete(cache.entries, key)
+			}
+		}
+		cache.lock.Unlock()
+	}
+}
+
+// Check if a request can be served from the cache
+func isCacheable(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		return false
+	}
+	for _, route := range cacheableRoutes {
+		if route.MatchString(r.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Build the cache key for a request from its method, URL, and body
+func getCacheKey(r *http.Request, body []byte) string {
+	hash := sha256.Sum256(body)
+	return fmt.Sprintf("%s %s %s %s", r.Method, r.URL.RequestURI(), r.Header.Get("Accept"), hex.EncodeToString(hash[:]))
+}
+
+// Write a cached response to the client
+func writeResponse(w http.ResponseWriter, response *cachedResponse) {
+	for name, values := range response.header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(response.statusCode)
+	w.Write(response.body)
+}
@@ -0,0 +1,156 @@
+package node
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	apinode "github.com/rocket-pool/smartnode/rocketpool/api/node"
+	"github.com/rocket-pool/smartnode/rocketpool/api/pdao"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the active proposals for votes to copy, since they run for days
+const autoVoteCheckInterval time.Duration = 15 * time.Minute
+
+// Copy the auto-vote delegate's protocol DAO votes task
+type autoVotePdao struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+
+	// The time the proposals were last checked
+	lastCheck time.Time
+
+	// Proposals that can't be voted on automatically, so they're only reported once
+	skippedProposals map[string]bool
+}
+
+// Create copy the auto-vote delegate's protocol DAO votes task
+func newAutoVotePdao(c *cli.Context, logger log.ColorLogger) (*autoVotePdao, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &autoVotePdao{
+		c:                c,
+		log:              logger,
+		cfg:              cfg,
+		w:                w,
+		skippedProposals: map[string]bool{},
+	}, nil
+
+}
+
+// Cast the same vote as the auto-vote delegate on each active proposal the node hasn't voted on yet
+func (t *autoVotePdao) run(state *state.NetworkState) error {
+
+	// Get the latest config, since the delegate can be changed by reloading it
+	cfg, err := services.GetConfig(t.c)
+	if err != nil {
+		return err
+	}
+	t.cfg = cfg
+
+	// Check if auto-voting is enabled
+	delegateString, ok := t.cfg.Smartnode.PdaoAutoVoteDelegate.Value.(string)
+	if !ok || delegateString == "" || t.cfg.Smartnode.GetSnapshotSequencerUrl() == "" {
+		return nil
+	}
+	if time.Since(t.lastCheck) < autoVoteCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+	delegate := common.HexToAddress(delegateString)
+	apiDomain := t.cfg.Smartnode.GetSnapshotApiDomain()
+	space := t.cfg.Smartnode.GetSnapshotID()
+
+	// Log
+	t.log.Println("Checking for protocol DAO votes to copy...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Votes from a node without any voting power are rejected, so don't bother casting them
+	votingPower, err := apinode.GetSnapshotVotingPower(apiDomain, space, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if votingPower.Data.Vp.Vp == 0 {
+		t.log.Println("The node has no voting power, so it can't vote on proposals.")
+		return nil
+	}
+
+	// Get the active proposals
+	proposals, err := apinode.GetSnapshotProposals(apiDomain, space, "active")
+	if err != nil {
+		return err
+	}
+	if len(proposals.Data.Proposals) == 0 {
+		return nil
+	}
+
+	// Get the votes by the node and the delegate
+	votes, err := apinode.GetSnapshotVotedProposals(apiDomain, space, nodeAccount.Address, delegate)
+	if err != nil {
+		return err
+	}
+	nodeVoted := map[string]bool{}
+	delegateChoices := map[string]interface{}{}
+	for _, vote := range votes.Data.Votes {
+		if vote.Voter == nodeAccount.Address {
+			nodeVoted[vote.Proposal.Id] = true
+		} else if vote.Voter == delegate {
+			delegateChoices[vote.Proposal.Id] = vote.Choice
+		}
+	}
+
+	// Copy the delegate's votes
+	for _, proposal := range proposals.Data.Proposals {
+		if nodeVoted[proposal.Id] || t.skippedProposals[proposal.Id] {
+			continue
+		}
+		choice, exists := delegateChoices[proposal.Id]
+		if !exists {
+			continue
+		}
+		choiceFloat, isSingleChoice := choice.(float64)
+		if !isSingleChoice || !pdao.IsSingleChoiceProposal(proposal) {
+			t.log.Printlnf("Proposal '%s' uses '%s' voting, which can't be voted on automatically; please vote on it manually.", proposal.Title, proposal.Type)
+			t.skippedProposals[proposal.Id] = true
+			continue
+		}
+
+		voteId, err := pdao.CastSnapshotVote(t.cfg, t.w, proposal.Id, uint64(choiceFloat))
+		if err != nil {
+			t.log.Printlnf("Could not vote on proposal '%s': %s", proposal.Title, err.Error())
+			continue
+		}
+		choiceName := "unknown"
+		if index := int(choiceFloat) - 1; index >= 0 && index < len(proposal.Choices) {
+			choiceName = proposal.Choices[index]
+		}
+		t.log.Printlnf("Voted for [%s] on proposal '%s', matching %s (vote ID %s).", choiceName, proposal.Title, delegate.Hex(), voteId)
+	}
+
+	// Return
+	return nil
+
+}
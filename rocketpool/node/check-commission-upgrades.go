@@ -0,0 +1,89 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Check commission upgrades task
+type checkCommissionUpgrades struct {
+	c   *cli.Context
+	log log.ColorLogger
+	w   *wallet.Wallet
+
+	// The reducible minipools and credit-funded deposits reported last time, so unchanged opportunities aren't logged every cycle
+	lastReducible      map[common.Address]bool
+	lastCreditDeposits uint64
+}
+
+// Create check commission upgrades task
+func newCheckCommissionUpgrades(c *cli.Context, logger log.ColorLogger) (*checkCommissionUpgrades, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkCommissionUpgrades{
+		c:             c,
+		log:           logger,
+		w:             w,
+		lastReducible: map[common.Address]bool{},
+	}, nil
+
+}
+
+// Log any new actions that don't need more ETH but would raise the node's commission
+func (t *checkCommissionUpgrades) run(state *state.NetworkState) error {
+
+	// Check if Atlas has been deployed yet
+	if !state.IsAtlasDeployed {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Find the upgrades
+	report := rputils.GetCommissionUpgrades(state, nodeAccount.Address)
+	reducible := map[common.Address]bool{}
+	var creditDeposits uint64
+	logged := false
+	for _, upgrade := range report.Upgrades {
+		switch upgrade.Type {
+		case rputils.CommissionUpgradeType_ReduceBond:
+			reducible[upgrade.MinipoolAddress] = true
+			if !t.lastReducible[upgrade.MinipoolAddress] {
+				t.log.Printlnf("Reducing the bond of minipool %s to 8 ETH would raise its commission from %.2f%% to %.2f%% and your node's average to %.2f%%.",
+					upgrade.MinipoolAddress.Hex(), upgrade.CurrentCommission*100, upgrade.NewCommission*100, upgrade.NewAverageCommission*100)
+				logged = true
+			}
+		case rputils.CommissionUpgradeType_DepositWithCredit:
+			creditDeposits = upgrade.Count
+			if creditDeposits != t.lastCreditDeposits {
+				t.log.Printlnf("Your deposit credit can fund %d new 8 ETH minipool(s) at %.2f%% commission; each would raise your node's average to %.2f%%.",
+					creditDeposits, upgrade.NewCommission*100, upgrade.NewAverageCommission*100)
+				logged = true
+			}
+		}
+	}
+	if logged {
+		t.log.Println("Run `rocketpool node commission-upgrades` for the full breakdown.")
+	}
+
+	t.lastReducible = reducible
+	t.lastCreditDeposits = creditDeposits
+	return nil
+
+}
@@ -0,0 +1,114 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Check doppelgangers task
+type checkDoppelgangers struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	bc  beacon.Client
+	d   *client.Client
+
+	// The minipools reported last time, so they aren't logged every cycle
+	lastDetected map[common.Address]bool
+}
+
+// Create check doppelgangers task
+func newCheckDoppelgangers(c *cli.Context, logger log.ColorLogger) (*checkDoppelgangers, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkDoppelgangers{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		w:            w,
+		bc:           bc,
+		d:            d,
+		lastDetected: map[common.Address]bool{},
+	}, nil
+
+}
+
+// Check if any of the node's validators are attesting from another machine while the local validator client is quiet
+func (t *checkDoppelgangers) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the validators of the staking minipools
+	pubkeys := []rptypes.ValidatorPubkey{}
+	minipoolsByPubkey := map[rptypes.ValidatorPubkey]common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Status != rptypes.Staking {
+			continue
+		}
+		if validatorDetails, exists := state.ValidatorDetails[mpd.Pubkey]; !exists || !validatorDetails.Exists {
+			continue
+		}
+		pubkeys = append(pubkeys, mpd.Pubkey)
+		minipoolsByPubkey[mpd.Pubkey] = mpd.MinipoolAddress
+	}
+
+	// Check them
+	result, err := validator.CheckForDoppelgangers(t.cfg, t.bc, t.d, pubkeys)
+	if err != nil {
+		return fmt.Errorf("error checking for doppelgangers: %w", err)
+	}
+
+	// Report the ones that are live elsewhere
+	detected := map[common.Address]rptypes.ValidatorPubkey{}
+	lastDetected := map[common.Address]bool{}
+	for _, pubkey := range result.LiveValidators {
+		minipoolAddress := minipoolsByPubkey[pubkey]
+		detected[minipoolAddress] = pubkey
+		lastDetected[minipoolAddress] = true
+		if !t.lastDetected[minipoolAddress] {
+			t.log.Warnf("the validator for minipool %s (%s) was attesting during epoch(s) %v while your validator client was not, so it appears to be running on another machine!", minipoolAddress.Hex(), pubkey.Hex(), result.Epochs)
+			t.log.Println("Do NOT start your validator client until you have stopped the other one, or both may be slashed.")
+		}
+	}
+	collectors.SetDoppelgangers(result.Checked, detected)
+	t.lastDetected = lastDetected
+
+	return nil
+
+}
@@ -0,0 +1,241 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// Registrations take one request per validator, so they're checked less often than the relay status
+	relayRegistrationCheckInterval time.Duration = time.Hour
+
+	// How many registration requests are sent to each relay at once
+	relayRegistrationConcurrency int = 8
+
+	// How long a full check of all relays may take before the remaining requests are abandoned until the next check
+	relayCheckTimeout time.Duration = 2 * time.Minute
+)
+
+// Check MEV-boost relays task
+type checkMevRelays struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+	bc  beacon.Client
+
+	// The time the registrations were last checked
+	lastRegistrationCheck time.Time
+}
+
+// Create check MEV-boost relays task
+func newCheckMevRelays(c *cli.Context, logger log.ColorLogger) (*checkMevRelays, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkMevRelays{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+		bc:  bc,
+	}, nil
+
+}
+
+// Check that each configured relay is up, and that it has the right registration for each of the node's validators
+func (t *checkMevRelays) run(state *state.NetworkState) error {
+
+	// The relays are only known when the Smartnode manages MEV-boost
+	if t.cfg.IsNativeMode || t.cfg.EnableMevBoost.Value == false || t.cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return nil
+	}
+	relays := t.cfg.MevBoost.GetEnabledMevRelays()
+	if len(relays) == 0 {
+		return nil
+	}
+	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+
+	// Get the validators that should be registered
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	checkRegistrations := time.Since(t.lastRegistrationCheck) >= relayRegistrationCheckInterval
+	var pubkeys []types.ValidatorPubkey
+	var feeRecipient common.Address
+	if checkRegistrations {
+		pubkeys = getActiveValidatorPubkeys(state, nodeAccount.Address)
+		feeRecipient, err = getCorrectFeeRecipient(t.rp, t.bc, nodeAccount.Address, state)
+		if err != nil {
+			return err
+		}
+		t.lastRegistrationCheck = time.Now()
+	}
+
+	// Get the gas limit the Validator client registers each validator with
+	var gasLimits map[types.ValidatorPubkey]uint64
+	if checkRegistrations && len(pubkeys) > 0 {
+		gasLimits = t.getExpectedGasLimits(pubkeys)
+	}
+
+	// Check the relays in parallel so a slow one doesn't hold up the others or the task loop
+	deadline := time.Now().Add(relayCheckTimeout)
+	var wg sync.WaitGroup
+	for _, relay := range relays {
+		relayName := relay.Name
+		relayUrl := relay.Urls[network]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := mevboost.NewRelayClient(relayUrl)
+			if err != nil {
+				t.log.Printlnf("Error creating client for relay %s: %s", relayName, err.Error())
+				return
+			}
+
+			// Check the status
+			latency, err := client.CheckStatus()
+			collectors.RecordMevRelayStatus(relayName, err == nil, latency)
+			if err != nil {
+				t.log.Warnf("MEV-boost relay %s is unavailable: %s", relayName, err.Error())
+				return
+			}
+
+			if checkRegistrations && len(pubkeys) > 0 {
+				t.checkRegistrations(relayName, client, pubkeys, feeRecipient, gasLimits, deadline)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+
+}
+
+// Get the gas limit the Validator client uses for each validator's relay registrations.
+// Validators it can't be read for are left out, so their gas limit isn't checked.
+func (t *checkMevRelays) getExpectedGasLimits(pubkeys []types.ValidatorPubkey) map[types.ValidatorPubkey]uint64 {
+	km := keymanager.NewClient(t.cfg.Smartnode.KeymanagerApiUrl.Value.(string), t.cfg.Smartnode.GetKeymanagerApiTokenPath())
+	gasLimits := map[types.ValidatorPubkey]uint64{}
+	for _, pubkey := range pubkeys {
+		gasLimit, err := km.GetGasLimit(pubkey)
+		if err != nil {
+			t.log.Printlnf("Couldn't get the gas limit of your validators from the Validator client, so relay gas limits won't be checked: %s", err.Error())
+			break
+		}
+		gasLimits[pubkey] = gasLimit
+	}
+	return gasLimits
+}
+
+// Check the relay's registration for each validator, and report any that are missing or wrong
+func (t *checkMevRelays) checkRegistrations(relayName string, client *mevboost.RelayClient, pubkeys []types.ValidatorPubkey, feeRecipient common.Address, gasLimits map[types.ValidatorPubkey]uint64, deadline time.Time) {
+	var lock sync.Mutex
+	registered := 0
+	mismatches := map[string]int{}
+	failed := false
+
+	// Send the requests in batches, stopping early if one fails or the check runs out of time
+	var wg errgroup.Group
+	wg.SetLimit(relayRegistrationConcurrency)
+	for _, pubkey := range pubkeys {
+		pubkey := pubkey
+		wg.Go(func() error {
+			lock.Lock()
+			stop := failed
+			lock.Unlock()
+			if stop {
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s", relayCheckTimeout)
+			}
+
+			registration, exists, err := client.GetValidatorRegistration(pubkey)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				failed = true
+				return err
+			}
+			if !exists {
+				mismatches[collectors.RelayRegistrationMismatch_Missing]++
+				return nil
+			}
+			registered++
+			if registration.FeeRecipient != feeRecipient {
+				mismatches[collectors.RelayRegistrationMismatch_FeeRecipient]++
+				t.log.Warnf("relay %s has validator %s registered with fee recipient %s instead of %s.", relayName, pubkey.Hex(), registration.FeeRecipient.Hex(), feeRecipient.Hex())
+			}
+			if gasLimit, exists := gasLimits[pubkey]; exists && registration.GasLimit != gasLimit {
+				mismatches[collectors.RelayRegistrationMismatch_GasLimit]++
+				t.log.Warnf("relay %s has validator %s registered with a gas limit of %d instead of %d.", relayName, pubkey.Hex(), registration.GasLimit, gasLimit)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		collectors.RecordMevRelayError(relayName)
+		t.log.Printlnf("Error checking registrations on relay %s, skipping it until the next check: %s", relayName, err.Error())
+		return
+	}
+
+	collectors.RecordMevRelayRegistrations(relayName, registered, mismatches)
+	if missing := mismatches[collectors.RelayRegistrationMismatch_Missing]; missing > 0 {
+		t.log.Warnf("relay %s doesn't have a registration for %d of your %d active validator(s); they won't receive MEV blocks from it. Check that your validator client has MEV-boost enabled.", relayName, missing, len(pubkeys))
+	}
+}
+
+// Get the pubkeys of the node's active validators, which are the ones that register with relays
+func getActiveValidatorPubkeys(state *state.NetworkState, nodeAddress common.Address) []types.ValidatorPubkey {
+	pubkeys := []types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		if validator.Status == beacon.ValidatorState_ActiveOngoing {
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+	return pubkeys
+}
@@ -0,0 +1,336 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How far back to search the deposit contract for a minipool's deposits; this matches the Oracle DAO's scrub check
+const scrubRiskDepositLookback uint64 = 100000
+
+// The scrub checks, used as metric labels
+const (
+	scrubCheck_BeaconCredentials = "beacon_credentials"
+	scrubCheck_PrestakeEvent     = "prestake_event"
+	scrubCheck_DepositContract   = "deposit_contract"
+	scrubCheck_StakeDepositData  = "stake_deposit_data"
+)
+
+// A scrub check a minipool failed
+type scrubRisk struct {
+	check  string
+	reason string
+}
+
+// Check scrub risk task
+type checkScrubRisk struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	w       *wallet.Wallet
+	checker *scrubRiskChecker
+
+	// The minipools reported at risk last time, so they aren't logged every cycle
+	lastAtRisk map[common.Address]bool
+}
+
+// Create check scrub risk task
+func newCheckScrubRisk(c *cli.Context, logger log.ColorLogger) (*checkScrubRisk, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	checker, err := newScrubRiskChecker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkScrubRisk{
+		c:          c,
+		log:        logger,
+		w:          w,
+		checker:    checker,
+		lastAtRisk: map[common.Address]bool{},
+	}, nil
+
+}
+
+// Check the deposit data of the node's prelaunch minipools the same way the Oracle DAO does before it scrubs them
+func (t *checkScrubRisk) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the prelaunch minipools, including the ones still in the scrub period
+	minipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Status == rptypes.Prelaunch && !mpd.IsVacant {
+			minipools = append(minipools, mpd)
+		}
+	}
+
+	// Check them
+	risks, err := t.checker.checkMinipools(state, minipools)
+	if err != nil {
+		return fmt.Errorf("error checking prelaunch minipools for scrub risks: %w", err)
+	}
+
+	// Report the ones at risk
+	atRisk := map[common.Address]bool{}
+	metricRisks := map[common.Address][]string{}
+	for minipoolAddress, minipoolRisks := range risks {
+		atRisk[minipoolAddress] = true
+		checks := make([]string, 0, len(minipoolRisks))
+		for _, risk := range minipoolRisks {
+			checks = append(checks, risk.check)
+		}
+		metricRisks[minipoolAddress] = checks
+		if !t.lastAtRisk[minipoolAddress] {
+			logScrubRisks(&t.log, minipoolAddress, minipoolRisks)
+		}
+	}
+	collectors.SetScrubRisks(metricRisks)
+	t.lastAtRisk = atRisk
+
+	return nil
+
+}
+
+// Runs the Oracle DAO's scrub checks against a node's own minipools
+type scrubRiskChecker struct {
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+}
+
+// Create a scrub risk checker
+func newScrubRiskChecker(c *cli.Context) (*scrubRiskChecker, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	return &scrubRiskChecker{
+		cfg: cfg,
+		rp:  rp,
+	}, nil
+}
+
+// Check the Beacon Chain credentials, prestake event and deposit contract deposits of some prelaunch minipools.
+// Returns the failed checks of each minipool that failed any.
+func (s *scrubRiskChecker) checkMinipools(state *state.NetworkState, minipools []*rpstate.NativeMinipoolDetails) (map[common.Address][]scrubRisk, error) {
+
+	risks := map[common.Address][]scrubRisk{}
+	if len(minipools) == 0 {
+		return risks, nil
+	}
+
+	// Get the search settings
+	depositDomain, err := getDepositDomain(state)
+	if err != nil {
+		return nil, err
+	}
+	eventLogInterval, err := s.cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, fmt.Errorf("error getting event log interval: %w", err)
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	startBlock := uint64(0)
+	if state.ElBlockNumber > scrubRiskDepositLookback {
+		startBlock = state.ElBlockNumber - scrubRiskDepositLookback
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	pubkeys := make(map[rptypes.ValidatorPubkey]bool, len(minipools))
+	for _, mpd := range minipools {
+		pubkeys[mpd.Pubkey] = true
+
+		// Step 1: the Beacon Chain credentials, if the deposit has been seen there
+		validator := state.ValidatorDetails[mpd.Pubkey]
+		if validator.Exists && validator.WithdrawalCredentials != mpd.WithdrawalCredentials {
+			risks[mpd.MinipoolAddress] = append(risks[mpd.MinipoolAddress], scrubRisk{
+				check:  scrubCheck_BeaconCredentials,
+				reason: fmt.Sprintf("the validator's withdrawal credentials on the Beacon Chain are %s instead of %s", validator.WithdrawalCredentials.Hex(), mpd.WithdrawalCredentials.Hex()),
+			})
+		}
+
+		// Step 2: the MinipoolPrestaked event
+		mp, err := minipool.NewMinipoolFromVersion(s.rp, mpd.MinipoolAddress, mpd.Version, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		}
+		prestakeData, err := mp.GetPrestakeEvent(intervalSize, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting prestake event for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		}
+		if reason := checkPrestakeData(prestakeData, mpd, depositDomain); reason != "" {
+			risks[mpd.MinipoolAddress] = append(risks[mpd.MinipoolAddress], scrubRisk{
+				check:  scrubCheck_PrestakeEvent,
+				reason: reason,
+			})
+		}
+	}
+
+	// Step 3: the first validly signed deposit for each validator in the deposit contract
+	depositMap, err := utils.GetDeposits(s.rp, pubkeys, big.NewInt(0).SetUint64(startBlock), intervalSize, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposits from the deposit contract: %w", err)
+	}
+	for _, mpd := range minipools {
+		for _, deposit := range depositMap[mpd.Pubkey] {
+			depositData := &ethpb.Deposit_Data{
+				PublicKey:             deposit.Pubkey.Bytes(),
+				WithdrawalCredentials: deposit.WithdrawalCredentials.Bytes(),
+				Amount:                deposit.Amount,
+				Signature:             deposit.Signature.Bytes(),
+			}
+			if prdeposit.VerifyDepositSignature(depositData, depositDomain) != nil {
+				// Invalid deposits are ignored by the Beacon Chain
+				continue
+			}
+			if deposit.WithdrawalCredentials != mpd.WithdrawalCredentials {
+				risks[mpd.MinipoolAddress] = append(risks[mpd.MinipoolAddress], scrubRisk{
+					check:  scrubCheck_DepositContract,
+					reason: fmt.Sprintf("the validator's first valid deposit (TX %s) has withdrawal credentials %s instead of %s", deposit.TxHash.Hex(), deposit.WithdrawalCredentials.Hex(), mpd.WithdrawalCredentials.Hex()),
+				})
+			}
+			break
+		}
+	}
+
+	return risks, nil
+
+}
+
+// Check the deposit data a minipool is about to be staked with before the transaction is sent
+func (s *scrubRiskChecker) checkStakeDepositData(state *state.NetworkState, mpd *rpstate.NativeMinipoolDetails, depositData eth2.DepositData, depositDataRoot common.Hash) ([]scrubRisk, error) {
+
+	depositDomain, err := getDepositDomain(state)
+	if err != nil {
+		return nil, err
+	}
+
+	risks := []scrubRisk{}
+	addRisk := func(reason string) {
+		risks = append(risks, scrubRisk{
+			check:  scrubCheck_StakeDepositData,
+			reason: reason,
+		})
+	}
+	if !bytes.Equal(depositData.PublicKey, mpd.Pubkey.Bytes()) {
+		addRisk(fmt.Sprintf("the deposit is for validator %s instead of %s", rptypes.BytesToValidatorPubkey(depositData.PublicKey).Hex(), mpd.Pubkey.Hex()))
+	}
+	if !bytes.Equal(depositData.WithdrawalCredentials, mpd.WithdrawalCredentials.Bytes()) {
+		addRisk(fmt.Sprintf("the deposit has withdrawal credentials %s instead of %s", common.BytesToHash(depositData.WithdrawalCredentials).Hex(), mpd.WithdrawalCredentials.Hex()))
+	}
+	err = prdeposit.VerifyDepositSignature(&ethpb.Deposit_Data{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+		Signature:             depositData.Signature,
+	}, depositDomain)
+	if err != nil {
+		addRisk(fmt.Sprintf("the deposit signature is invalid: %s", err.Error()))
+	}
+	root, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error computing deposit data root: %w", err)
+	}
+	if common.Hash(root) != depositDataRoot {
+		addRisk(fmt.Sprintf("the deposit data root is %s instead of %s", depositDataRoot.Hex(), common.Hash(root).Hex()))
+	}
+	return risks, nil
+
+}
+
+// Check a minipool's prestake event, returning why it would be scrubbed or an empty string if it's valid
+func checkPrestakeData(prestakeData minipool.PrestakeData, mpd *rpstate.NativeMinipoolDetails, depositDomain []byte) string {
+	if prestakeData.Pubkey != mpd.Pubkey {
+		return fmt.Sprintf("the prestake deposit is for validator %s instead of %s", prestakeData.Pubkey.Hex(), mpd.Pubkey.Hex())
+	}
+	if prestakeData.WithdrawalCredentials != mpd.WithdrawalCredentials {
+		return fmt.Sprintf("the prestake deposit has withdrawal credentials %s instead of %s", prestakeData.WithdrawalCredentials.Hex(), mpd.WithdrawalCredentials.Hex())
+	}
+
+	// Convert the amount to gwei
+	amount := big.NewInt(0).Div(prestakeData.Amount, big.NewInt(int64(eth.WeiPerGwei))).Uint64()
+
+	// Validate the signature
+	err := prdeposit.VerifyDepositSignature(&ethpb.Deposit_Data{
+		PublicKey:             prestakeData.Pubkey.Bytes(),
+		WithdrawalCredentials: prestakeData.WithdrawalCredentials.Bytes(),
+		Amount:                amount,
+		Signature:             prestakeData.Signature.Bytes(),
+	}, depositDomain)
+	if err != nil {
+		return fmt.Sprintf("the prestake deposit signature is invalid: %s", err.Error())
+	}
+
+	// Validate the deposit data root
+	depositData := eth2.DepositData{
+		PublicKey:             prestakeData.Pubkey.Bytes(),
+		WithdrawalCredentials: prestakeData.WithdrawalCredentials.Bytes(),
+		Amount:                amount,
+		Signature:             prestakeData.Signature.Bytes(),
+	}
+	root, err := depositData.HashTreeRoot()
+	if err != nil {
+		return fmt.Sprintf("the prestake deposit data root could not be computed: %s", err.Error())
+	}
+	if common.Hash(root) != prestakeData.DepositDataRoot {
+		return fmt.Sprintf("the prestake deposit data root is %s instead of %s", prestakeData.DepositDataRoot.Hex(), common.Hash(root).Hex())
+	}
+	return ""
+}
+
+// Get the signing domain for deposits on the current network
+func getDepositDomain(state *state.NetworkState) ([]byte, error) {
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, state.BeaconConfig.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error computing deposit domain: %w", err)
+	}
+	return depositDomain, nil
+}
+
+// Log the failed scrub checks of a minipool
+func logScrubRisks(logger *log.ColorLogger, minipoolAddress common.Address, risks []scrubRisk) {
+	logger.Println("=== SCRUB RISK DETECTED ===")
+	logger.Printlnf("\tMinipool: %s", minipoolAddress.Hex())
+	for _, risk := range risks {
+		logger.Printlnf("\t%s: %s", risk.check, risk.reason)
+	}
+	logger.Println("The Oracle DAO will scrub this minipool, and the node daemon will not stake it.")
+	logger.Println("===========================")
+}
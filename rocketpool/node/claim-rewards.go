@@ -0,0 +1,550 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const autoClaimMaxAttempts int = 5
+
+var autoClaimRetryDelay, _ = time.ParseDuration("15m")
+
+// Claim rewards task
+type claimRewards struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasThreshold   float64
+	rplThreshold   *big.Int
+	ethThreshold   *big.Int
+	restakePercent float64
+	forwardAddress *common.Address
+	forwardPercent float64
+	operatingFloat *big.Int
+	pending        *pendingForwards
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+
+	// Interval info for claimable intervals, which doesn't change once the tree file is valid
+	intervalCache map[uint64]rprewards.IntervalInfo
+
+	// Retry tracking for the current set of claimable intervals
+	latestInterval uint64
+	failedAttempts int
+	nextAttempt    time.Time
+}
+
+// Create claim rewards task
+func newClaimRewards(c *cli.Context, logger log.ColorLogger) (*claimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-claiming is disabled
+	gasThreshold, hasCeiling := cfg.Smartnode.GetTaskGasCeiling(config.Task_ClaimRewards)
+	if !hasCeiling {
+		gasThreshold = cfg.Smartnode.AutoClaimMaxFee.Value.(float64)
+		if gasThreshold == 0 {
+			gasThreshold = cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+		}
+	}
+	rplThreshold := cfg.Smartnode.AutoClaimRplThreshold.Value.(float64)
+	ethThreshold := cfg.Smartnode.AutoClaimEthThreshold.Value.(float64)
+	restakePercent := cfg.Smartnode.AutoClaimRestakePercent.Value.(float64)
+	disabled := false
+	if cfg.Smartnode.EnableAutoClaim.Value == false {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Auto-claim gas ceiling and automatic tx gas threshold are both 0, disabling auto-claim.")
+		disabled = true
+	} else if rplThreshold <= 0 && ethThreshold <= 0 {
+		logger.Println("Auto-claim RPL and ETH thresholds are both 0, disabling auto-claim.")
+		disabled = true
+	}
+
+	// Safety clamp
+	if restakePercent < 0 {
+		restakePercent = 0
+	} else if restakePercent > 100 {
+		logger.Warnf("Auto-claim restake percent is more than 100 (%.2f), reducing to 100.", restakePercent)
+		restakePercent = 100
+	}
+
+	// Get the address to forward claimed rewards to
+	var forwardAddress *common.Address
+	forwardAddressString := cfg.Smartnode.AutoClaimForwardAddress.Value.(string)
+	if forwardAddressString != "" {
+		if !common.IsHexAddress(forwardAddressString) {
+			return nil, fmt.Errorf("Auto-claim forward address [%s] is not a valid address", forwardAddressString)
+		}
+		address := common.HexToAddress(forwardAddressString)
+		forwardAddress = &address
+	}
+	forwardPercent := cfg.Smartnode.AutoClaimForwardPercent.Value.(float64)
+	if forwardPercent < 0 {
+		forwardPercent = 0
+	} else if forwardPercent > 100 {
+		logger.Warnf("Auto-claim forward percent is more than 100 (%.2f), reducing to 100.", forwardPercent)
+		forwardPercent = 100
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &claimRewards{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasThreshold:   gasThreshold,
+		rplThreshold:   eth.EthToWei(rplThreshold),
+		ethThreshold:   eth.EthToWei(ethThreshold),
+		restakePercent: restakePercent,
+		forwardAddress: forwardAddress,
+		forwardPercent: forwardPercent,
+		pending:        newPendingForwards(cfg.Smartnode.GetPendingForwardsPath()),
+		operatingFloat: eth.EthToWei(cfg.Smartnode.AutoClaimOperatingFloat.Value.(float64)),
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+		intervalCache:  map[uint64]rprewards.IntervalInfo{},
+	}, nil
+
+}
+
+// Claim rewards
+func (t *claimRewards) run(state *state.NetworkState) error {
+
+	// Check if auto-claim is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Retry forwarding the rewards from earlier claims that couldn't be forwarded at the time
+	if t.forwardAddress != nil {
+		if err := t.forwardRewards(nodeAccount.Address); err != nil {
+			t.log.Warnw("Rewards from an earlier claim still couldn't be forwarded, they will be retried on the next run.", log.Any("forwardAddress", t.forwardAddress.Hex()), log.Err(err))
+		}
+	}
+
+	// Get the claimable intervals
+	intervals, err := t.getClaimableIntervals(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	// Reset the retries once a new checkpoint has added an interval to claim
+	latestInterval := intervals[len(intervals)-1].Index
+	if latestInterval != t.latestInterval {
+		t.latestInterval = latestInterval
+		t.failedAttempts = 0
+		t.nextAttempt = time.Time{}
+	}
+	if t.failedAttempts >= autoClaimMaxAttempts {
+		return nil
+	}
+	if time.Now().Before(t.nextAttempt) {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for rewards to claim...")
+
+	// Get the totals and check them against the thresholds
+	indices := []*big.Int{}
+	amountRPL := []*big.Int{}
+	amountETH := []*big.Int{}
+	merkleProofs := [][]common.Hash{}
+	totalRpl := big.NewInt(0)
+	totalEth := big.NewInt(0)
+	for _, info := range intervals {
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &info.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &info.ODaoRplAmount.Int)
+		ethForInterval := big.NewInt(0).Set(&info.SmoothingPoolEthAmount.Int)
+
+		indices = append(indices, big.NewInt(0).SetUint64(info.Index))
+		amountRPL = append(amountRPL, rplForInterval)
+		amountETH = append(amountETH, ethForInterval)
+		merkleProofs = append(merkleProofs, info.MerkleProof)
+		totalRpl.Add(totalRpl, rplForInterval)
+		totalEth.Add(totalEth, ethForInterval)
+	}
+	rplReady := t.rplThreshold.Sign() > 0 && totalRpl.Cmp(t.rplThreshold) >= 0
+	ethReady := t.ethThreshold.Sign() > 0 && totalEth.Cmp(t.ethThreshold) >= 0
+	if !rplReady && !ethReady {
+		t.log.Printlnf("%d interval(s) have %.6f RPL and %.6f ETH to claim, which is below the auto-claim thresholds of %.6f RPL and %.6f ETH.",
+			len(intervals), eth.WeiToEth(totalRpl), eth.WeiToEth(totalEth), eth.WeiToEth(t.rplThreshold), eth.WeiToEth(t.ethThreshold))
+		return nil
+	}
+
+	// Get the amount of RPL to restake
+	restakeAmount := getPercentOf(totalRpl, t.restakePercent)
+
+	// Log
+	t.log.Printlnf("%d interval(s) have %.6f RPL and %.6f ETH to claim (restaking %.6f RPL), claiming...", len(intervals), eth.WeiToEth(totalRpl), eth.WeiToEth(totalEth), eth.WeiToEth(restakeAmount))
+
+	// Claim the rewards
+	success, err := t.claim(nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, restakeAmount)
+	if err != nil {
+		t.failedAttempts++
+		collectors.RecordAutoClaimFailure()
+		if t.failedAttempts >= autoClaimMaxAttempts {
+			t.log.Printlnf("Auto-claim failed %d times, giving up until the next rewards checkpoint. Please claim manually with `rocketpool node claim-rewards`.", t.failedAttempts)
+		} else {
+			delay := autoClaimRetryDelay * time.Duration(1<<(t.failedAttempts-1))
+			t.nextAttempt = time.Now().Add(delay)
+			t.log.Printlnf("Auto-claim failed (attempt %d of %d), retrying in %s.", t.failedAttempts, autoClaimMaxAttempts, delay)
+		}
+		return fmt.Errorf("Could not claim rewards: %w", err)
+	}
+	if !success {
+		return nil
+	}
+	collectors.RecordAutoClaim(totalRpl, totalEth, restakeAmount)
+
+	// Forward part of the rewards to the cold address
+	if t.forwardAddress != nil && t.forwardPercent > 0 {
+		unstakedRpl := big.NewInt(0).Sub(totalRpl, restakeAmount)
+		if err := t.queueForward(state, nodeAccount.Address, unstakedRpl, totalEth); err != nil {
+			return fmt.Errorf("Rewards were claimed but could not be queued to be forwarded to %s, please send them manually with `rocketpool node send`: %w", t.forwardAddress.Hex(), err)
+		}
+		if err := t.forwardRewards(nodeAccount.Address); err != nil {
+			services.RecordEvent(t.c, journal.Event{
+				Type:      journal.EventType_RewardsForwarded,
+				Automatic: true,
+				Message:   fmt.Sprintf("Couldn't forward claimed rewards to %s, will retry on the next run: %s", t.forwardAddress.Hex(), err.Error()),
+			})
+			return fmt.Errorf("Rewards were claimed but could not be forwarded to %s, they will be retried on the next run: %w", t.forwardAddress.Hex(), err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the intervals with rewards for the node that have valid tree files, in order
+func (t *claimRewards) getClaimableIntervals(nodeAddress common.Address) ([]rprewards.IntervalInfo, error) {
+
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	intervals := []rprewards.IntervalInfo{}
+	for _, index := range unclaimed {
+		info, exists := t.intervalCache[index]
+		if !exists {
+			info, err = rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, index)
+			if err != nil {
+				return nil, fmt.Errorf("error getting info for interval %d: %w", index, err)
+			}
+			if !info.TreeFileExists || !info.MerkleRootValid {
+				// The rewards tree download task will take care of this
+				continue
+			}
+			t.intervalCache[index] = info
+		}
+		if info.NodeExists {
+			intervals = append(intervals, info)
+		}
+	}
+
+	// Return
+	return intervals, nil
+
+}
+
+// Claim rewards for the provided intervals, restaking some of the RPL if requested
+func (t *claimRewards) claim(nodeAddress common.Address, indices []*big.Int, amountRPL []*big.Int, amountETH []*big.Int, merkleProofs [][]common.Hash, restakeAmount *big.Int) (bool, error) {
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	var gasInfo rocketpool.GasInfo
+	if restakeAmount.Sign() > 0 {
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, restakeAmount, opts)
+	} else {
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, opts)
+	}
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info; a gas price above the ceiling isn't a failure, the claim just waits
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Claim rewards
+	var hash common.Hash
+	if restakeAmount.Sign() > 0 {
+		hash, err = rewards.ClaimAndStake(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, restakeAmount, opts)
+	} else {
+		hash, err = rewards.Claim(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, opts)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Infow("Successfully claimed rewards.", log.Any("intervals", len(indices)), log.Any("tx", hash.Hex()))
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_RewardsClaimed,
+		Automatic: true,
+		TxHash:    &hash,
+		Message:   fmt.Sprintf("Claimed rewards for %d interval(s), restaking %.6f RPL.", len(indices), eth.WeiToEth(restakeAmount)),
+	})
+
+	// Return
+	return true, nil
+
+}
+
+// Add the configured share of freshly claimed rewards to the amounts waiting to be forwarded to the cold address
+func (t *claimRewards) queueForward(state *state.NetworkState, nodeAddress common.Address, claimedRpl *big.Int, claimedEth *big.Int) error {
+
+	// The rewards only land on the node wallet if it's also the withdrawal address
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return fmt.Errorf("node %s isn't in the network state", nodeAddress.Hex())
+	}
+	if nodeDetails.WithdrawalAddress != nodeAddress {
+		t.log.Printlnf("The claimed rewards were sent to your withdrawal address (%s), so there's nothing on the node wallet to forward.", nodeDetails.WithdrawalAddress.Hex())
+		return nil
+	}
+
+	if err := t.pending.add(pendingForward_AutoClaimRpl, getPercentOf(claimedRpl, t.forwardPercent)); err != nil {
+		return err
+	}
+	return t.pending.add(pendingForward_AutoClaimEth, getPercentOf(claimedEth, t.forwardPercent))
+
+}
+
+// Forward the claimed rewards waiting on the node wallet to the cold address.
+// Whatever can't be sent stays pending for the next run.
+func (t *claimRewards) forwardRewards(nodeAddress common.Address) error {
+
+	// Get the amounts to forward
+	rplAmount, err := t.pending.get(pendingForward_AutoClaimRpl)
+	if err != nil {
+		return err
+	}
+	ethAmount, err := t.pending.get(pendingForward_AutoClaimEth)
+	if err != nil {
+		return err
+	}
+	if rplAmount.Sign() == 0 && ethAmount.Sign() == 0 {
+		return nil
+	}
+
+	// Send the RPL
+	if rplAmount.Sign() > 0 {
+		t.log.Infow("Forwarding the claimed RPL that wasn't restaked...", log.Any("amountRpl", eth.WeiToEth(rplAmount)), log.Any("percent", t.forwardPercent), log.Any("forwardAddress", t.forwardAddress.Hex()))
+		opts, err := t.w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		gasInfo, err := tokens.EstimateTransferRPLGas(t.rp, *t.forwardAddress, rplAmount, opts)
+		if err != nil {
+			return fmt.Errorf("Could not estimate the gas required to forward RPL: %w", err)
+		}
+		if !t.applyForwardGas(opts, gasInfo) {
+			return fmt.Errorf("gas is too high to forward RPL right now")
+		}
+		hash, err := tokens.TransferRPL(t.rp, *t.forwardAddress, rplAmount, opts)
+		if err != nil {
+			return fmt.Errorf("error forwarding RPL: %w", err)
+		}
+
+		// It's no longer pending once it's been sent, so a slow transaction can't get it sent twice
+		if err := t.pending.subtract(pendingForward_AutoClaimRpl, rplAmount); err != nil {
+			return err
+		}
+		if err := api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log); err != nil {
+			return err
+		}
+		t.recordForward(hash, fmt.Sprintf("Forwarded %.6f RPL to %s.", eth.WeiToEth(rplAmount), t.forwardAddress.Hex()))
+	}
+
+	// Leave the operating float on the node wallet; the gas for both transfers comes out of it too, and whatever would
+	// dip into it is kept on the node wallet instead of being forwarded later
+	if ethAmount.Sign() == 0 {
+		return nil
+	}
+	sendAmount := ethAmount
+	balance, err := t.rp.Client.BalanceAt(context.Background(), nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node wallet balance: %w", err)
+	}
+	available := big.NewInt(0).Sub(balance, t.operatingFloat)
+	if available.Sign() <= 0 {
+		t.log.Printlnf("The node wallet has %.6f ETH, which isn't above the operating float of %.6f ETH, so no ETH will be forwarded.", eth.WeiToEth(balance), eth.WeiToEth(t.operatingFloat))
+		return t.pending.subtract(pendingForward_AutoClaimEth, ethAmount)
+	} else if sendAmount.Cmp(available) > 0 {
+		t.log.Printlnf("Only forwarding %.6f of the %.6f ETH to keep the operating float of %.6f ETH on the node wallet.", eth.WeiToEth(available), eth.WeiToEth(ethAmount), eth.WeiToEth(t.operatingFloat))
+		sendAmount = available
+	}
+
+	// Send the ETH
+	t.log.Infow("Forwarding the claimed Smoothing Pool ETH...", log.Any("amountEth", eth.WeiToEth(sendAmount)), log.Any("percent", t.forwardPercent), log.Any("forwardAddress", t.forwardAddress.Hex()))
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	opts.Value = sendAmount
+	gasInfo, err := eth.EstimateSendTransactionGas(t.rp.Client, *t.forwardAddress, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to forward ETH: %w", err)
+	}
+	if !t.applyForwardGas(opts, gasInfo) {
+		return fmt.Errorf("gas is too high to forward ETH right now")
+	}
+	hash, err := eth.SendTransaction(t.rp.Client, *t.forwardAddress, t.w.GetChainID(), opts)
+	if err != nil {
+		return fmt.Errorf("error forwarding ETH: %w", err)
+	}
+	if err := t.pending.subtract(pendingForward_AutoClaimEth, ethAmount); err != nil {
+		return err
+	}
+	if err := api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log); err != nil {
+		return err
+	}
+	t.recordForward(hash, fmt.Sprintf("Forwarded %.6f ETH to %s.", eth.WeiToEth(sendAmount), t.forwardAddress.Hex()))
+
+	// Return
+	return nil
+
+}
+
+// Set the gas for a forwarding transaction, returning false if it's above the auto-claim gas ceiling
+func (t *claimRewards) applyForwardGas(opts *bind.TransactOpts, gasInfo rocketpool.GasInfo) bool {
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		var err error
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			t.log.Printlnf("Error getting the max fee: %s", err.Error())
+			return false
+		}
+	}
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+		return false
+	}
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+	return true
+}
+
+// Log a forwarding transaction and add it to the event journal
+func (t *claimRewards) recordForward(hash common.Hash, message string) {
+	t.log.Infow(message, log.Any("tx", hash.Hex()))
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_RewardsForwarded,
+		Automatic: true,
+		TxHash:    &hash,
+		Message:   message,
+	})
+}
+
+// Get a percentage of an amount, rounded down
+func getPercentOf(amount *big.Int, percent float64) *big.Int {
+	share := big.NewInt(0).Mul(amount, big.NewInt(int64(percent*100)))
+	return share.Div(share, big.NewInt(10000))
+}
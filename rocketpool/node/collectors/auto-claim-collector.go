@@ -0,0 +1,129 @@
+package collectors
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Shared bookkeeping for the claims sent by the node daemon
+var autoClaimStats = &rewardsAutoClaimStats{}
+
+// The automatic claims the node daemon has attempted since it started
+type rewardsAutoClaimStats struct {
+	claims        float64
+	failures      float64
+	claimedRpl    float64
+	claimedEth    float64
+	restakedRpl   float64
+	lastClaimTime float64
+	lock          sync.Mutex
+}
+
+// Represents the collector for the automatic rewards claim metrics
+type AutoClaimCollector struct {
+	// The number of successful automatic claims
+	claims *prometheus.Desc
+
+	// The number of failed automatic claims
+	failures *prometheus.Desc
+
+	// The total amount of RPL claimed automatically
+	claimedRpl *prometheus.Desc
+
+	// The total amount of Smoothing Pool ETH claimed automatically
+	claimedEth *prometheus.Desc
+
+	// The total amount of claimed RPL that was restaked
+	restakedRpl *prometheus.Desc
+
+	// The time of the latest successful automatic claim
+	lastClaimTime *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new AutoClaimCollector instance
+func NewAutoClaimCollector() *AutoClaimCollector {
+	subsystem := "auto_claim"
+	return &AutoClaimCollector{
+		claims: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claims_total"),
+			"The number of times the node daemon has successfully claimed rewards automatically since it started",
+			nil, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of automatic rewards claims that failed since the node daemon started",
+			nil, nil,
+		),
+		claimedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_rpl_total"),
+			"The total amount of RPL the node daemon has claimed automatically since it started",
+			nil, nil,
+		),
+		claimedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_eth_total"),
+			"The total amount of Smoothing Pool ETH the node daemon has claimed automatically since it started",
+			nil, nil,
+		),
+		restakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "restaked_rpl_total"),
+			"The total amount of automatically claimed RPL that was restaked since the node daemon started",
+			nil, nil,
+		),
+		lastClaimTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_claim_timestamp_seconds"),
+			"The Unix time of the node daemon's latest successful automatic claim, or 0 if it hasn't made one",
+			nil, nil,
+		),
+		logPrefix: "Auto-Claim Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *AutoClaimCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.claims
+	channel <- collector.failures
+	channel <- collector.claimedRpl
+	channel <- collector.claimedEth
+	channel <- collector.restakedRpl
+	channel <- collector.lastClaimTime
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *AutoClaimCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	autoClaimStats.lock.Lock()
+	defer autoClaimStats.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.claims, prometheus.CounterValue, autoClaimStats.claims)
+	channel <- prometheus.MustNewConstMetric(
+		collector.failures, prometheus.CounterValue, autoClaimStats.failures)
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimedRpl, prometheus.CounterValue, autoClaimStats.claimedRpl)
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimedEth, prometheus.CounterValue, autoClaimStats.claimedEth)
+	channel <- prometheus.MustNewConstMetric(
+		collector.restakedRpl, prometheus.CounterValue, autoClaimStats.restakedRpl)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastClaimTime, prometheus.GaugeValue, autoClaimStats.lastClaimTime)
+}
+
+// Record that the node daemon successfully claimed rewards
+func RecordAutoClaim(rpl *big.Int, ethAmount *big.Int, restakedRpl *big.Int) {
+	autoClaimStats.lock.Lock()
+	defer autoClaimStats.lock.Unlock()
+	autoClaimStats.claims++
+	autoClaimStats.claimedRpl += eth.WeiToEth(rpl)
+	autoClaimStats.claimedEth += eth.WeiToEth(ethAmount)
+	autoClaimStats.restakedRpl += eth.WeiToEth(restakedRpl)
+	autoClaimStats.lastClaimTime = float64(time.Now().Unix())
+}
+
+// Record that an automatic claim failed
+func RecordAutoClaimFailure() {
+	autoClaimStats.lock.Lock()
+	defer autoClaimStats.lock.Unlock()
+	autoClaimStats.failures++
+}
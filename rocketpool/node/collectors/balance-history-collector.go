@@ -0,0 +1,108 @@
+package collectors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
+)
+
+// Represents the collector for the changes in the node's balances over the daily balance history
+type BalanceHistoryCollector struct {
+	// The change in each balance over each period
+	delta *prometheus.Desc
+
+	// The age of the latest snapshot
+	latestSnapshotAge *prometheus.Desc
+
+	// The number of snapshots recorded for the node
+	snapshotCount *prometheus.Desc
+
+	// The balance history store
+	store *balancehistory.Store
+
+	// The nodes to report on
+	nodeAddresses []common.Address
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new BalanceHistoryCollector instance
+func NewBalanceHistoryCollector(store *balancehistory.Store, nodeAddresses []common.Address) *BalanceHistoryCollector {
+	subsystem := "balance_history"
+	return &BalanceHistoryCollector{
+		delta: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "delta"),
+			"The change in a node balance between the latest daily snapshot and the one from the given number of days before it",
+			[]string{"balance", "days", "node"}, nil,
+		),
+		latestSnapshotAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latest_snapshot_age_seconds"),
+			"How long ago the node's latest balance snapshot was taken",
+			[]string{"node"}, nil,
+		),
+		snapshotCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "snapshots"),
+			"The number of daily balance snapshots recorded for the node",
+			[]string{"node"}, nil,
+		),
+		store:         store,
+		nodeAddresses: nodeAddresses,
+		logPrefix:     "Balance History Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *BalanceHistoryCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.delta
+	channel <- collector.latestSnapshotAge
+	channel <- collector.snapshotCount
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *BalanceHistoryCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	degraded := false
+	for _, nodeAddress := range collector.nodeAddresses {
+		snapshots, err := collector.store.Read(nodeAddress, time.Time{})
+		if err != nil {
+			collector.logError(fmt.Errorf("Error reading balance history for node %s: %w", nodeAddress.Hex(), err))
+			degraded = true
+			continue
+		}
+		nodeLabel := nodeAddress.Hex()
+		channel <- prometheus.MustNewConstMetric(
+			collector.snapshotCount, prometheus.GaugeValue, float64(len(snapshots)), nodeLabel)
+		if len(snapshots) == 0 {
+			continue
+		}
+
+		latest := snapshots[len(snapshots)-1]
+		channel <- prometheus.MustNewConstMetric(
+			collector.latestSnapshotAge, prometheus.GaugeValue, time.Since(latest.Time).Seconds(), nodeLabel)
+		for _, delta := range balancehistory.GetDeltas(snapshots, balancehistory.DeltaPeriods) {
+			days := fmt.Sprint(delta.Days)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.EthBalance, "eth", days, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.RplBalance, "rpl", days, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.RethBalance, "reth", days, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.StakedRpl, "staked_rpl", days, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.BeaconBalance, "beacon", days, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.delta, prometheus.GaugeValue, delta.CollateralRatio, "collateral_ratio", days, nodeLabel)
+		}
+	}
+	recordCollectorDegraded(collector.logPrefix, degraded)
+}
+
+// Log error messages
+func (collector *BalanceHistoryCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
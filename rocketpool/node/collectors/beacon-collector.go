@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -75,16 +76,21 @@ func (collector *BeaconCollector) Describe(channel chan<- *prometheus.Desc) {
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
 		return
 	}
 
+	// Each duty is looked up separately, so the ones that succeed are still reported if the others fail
 	var wg errgroup.Group
 	activeSyncCommittee := float64(0)
 	upcomingSyncCommittee := float64(0)
 	upcomingProposals := float64(0)
+	var activeSyncCommitteeErr, upcomingSyncCommitteeErr, upcomingProposalsErr error
 
 	var validatorIndices []uint64
 	var head beacon.BeaconHead
@@ -100,6 +106,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 	head, err := collector.bc.GetBeaconHead()
 	if err != nil {
 		collector.logError(fmt.Errorf("error getting Beacon chain head: %w", err))
+		recordCollectorDegraded(collector.logPrefix, true)
 		return
 	}
 
@@ -107,7 +114,8 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 		// Get current duties
 		duties, err := collector.bc.GetValidatorSyncDuties(validatorIndices, head.Epoch)
 		if err != nil {
-			return fmt.Errorf("Error getting sync duties: %w", err)
+			activeSyncCommitteeErr = fmt.Errorf("Error getting sync duties: %w", err)
+			return nil
 		}
 
 		for _, duty := range duties {
@@ -126,7 +134,8 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 		// Get upcoming duties
 		duties, err := collector.bc.GetValidatorSyncDuties(validatorIndices, head.Epoch+config.EpochsPerSyncCommitteePeriod)
 		if err != nil {
-			return fmt.Errorf("Error getting sync duties: %w", err)
+			upcomingSyncCommitteeErr = fmt.Errorf("Error getting upcoming sync duties: %w", err)
+			return nil
 		}
 
 		for _, duty := range duties {
@@ -142,7 +151,8 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 		// Get proposals in this epoch
 		duties, err := collector.bc.GetValidatorProposerDuties(validatorIndices, head.Epoch)
 		if err != nil {
-			return fmt.Errorf("Error getting proposer duties: %w", err)
+			upcomingProposalsErr = fmt.Errorf("Error getting proposer duties: %w", err)
+			return nil
 		}
 
 		for _, duty := range duties {
@@ -167,21 +177,36 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
-		collector.logError(err)
-		return
+	wg.Wait()
+
+	degraded := false
+	if activeSyncCommitteeErr == nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.activeSyncCommittee, prometheus.GaugeValue, activeSyncCommittee)
+	} else {
+		collector.logError(activeSyncCommitteeErr)
+		degraded = true
 	}
-
-	channel <- prometheus.MustNewConstMetric(
-		collector.activeSyncCommittee, prometheus.GaugeValue, activeSyncCommittee)
-	channel <- prometheus.MustNewConstMetric(
-		collector.upcomingSyncCommittee, prometheus.GaugeValue, upcomingSyncCommittee)
-	channel <- prometheus.MustNewConstMetric(
-		collector.upcomingProposals, prometheus.GaugeValue, upcomingProposals)
+	if upcomingSyncCommitteeErr == nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.upcomingSyncCommittee, prometheus.GaugeValue, upcomingSyncCommittee)
+	} else {
+		collector.logError(upcomingSyncCommitteeErr)
+		degraded = true
+	}
+	if upcomingProposalsErr == nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.upcomingProposals, prometheus.GaugeValue, upcomingProposals)
+	} else {
+		collector.logError(upcomingProposalsErr)
+		degraded = true
+	}
+	recordCollectorDegraded(collector.logPrefix, degraded)
 
 }
 
 // Log error messages
 func (collector *BeaconCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
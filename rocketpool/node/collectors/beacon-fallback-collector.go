@@ -0,0 +1,86 @@
+package collectors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for the Beacon client fallback metrics
+type BeaconFallbackCollector struct {
+	// Whether or not the node is currently using the fallback Beacon client
+	usingFallback *prometheus.Desc
+
+	// The Beacon client endpoint that requests are currently routed to
+	activeEndpoint *prometheus.Desc
+
+	// The number of times the node has failed over to the fallback Beacon client
+	failovers *prometheus.Desc
+
+	// The Beacon client manager
+	bc *services.BeaconClientManager
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new BeaconFallbackCollector instance
+func NewBeaconFallbackCollector(bc *services.BeaconClientManager) *BeaconFallbackCollector {
+	subsystem := "beacon_fallback"
+	return &BeaconFallbackCollector{
+		usingFallback: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Whether or not the node is currently using the fallback Beacon client (1 if so, 0 if not)",
+			nil, nil,
+		),
+		activeEndpoint: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_endpoint"),
+			"The Beacon client endpoint that requests are currently routed to",
+			[]string{"endpoint", "role"}, nil,
+		),
+		failovers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failovers_total"),
+			"The number of times the node has failed over from the primary to the fallback Beacon client",
+			nil, nil,
+		),
+		bc:        bc,
+		logPrefix: "Beacon Fallback Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *BeaconFallbackCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.usingFallback
+	channel <- collector.activeEndpoint
+	channel <- collector.failovers
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *BeaconFallbackCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	usingFallback := float64(0)
+	role := "primary"
+	if collector.bc.IsUsingFallback() {
+		usingFallback = 1
+		role = "fallback"
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.usingFallback, prometheus.GaugeValue, usingFallback)
+	channel <- prometheus.MustNewConstMetric(
+		collector.failovers, prometheus.CounterValue, float64(collector.bc.GetFailoverCount()))
+
+	activeProvider := collector.bc.GetActiveProvider()
+	if activeProvider == "" {
+		collector.logError(fmt.Errorf("no Beacon clients are currently ready"))
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.activeEndpoint, prometheus.GaugeValue, 1, activeProvider, role)
+}
+
+// Log error messages
+func (collector *BeaconFallbackCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -0,0 +1,25 @@
+package collectors_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors/harness"
+)
+
+func TestBeaconFallbackCollector(t *testing.T) {
+	_, ec := newRocketPool(t)
+	_, bcManager := newClientManagers(t, ec, newBeaconClient(t))
+
+	output, err := harness.Collect(collectors.NewBeaconFallbackCollector(bcManager))
+	if err != nil {
+		t.Fatalf("error collecting metrics: %s", err.Error())
+	}
+
+	// The mock server's port changes every run, so it's swapped for a fixed host
+	output = bytes.ReplaceAll(output, []byte(bcManager.GetActiveProvider()), []byte("http://beacon-node"))
+	if err := harness.CompareGoldenOutput(output, goldenPath("beacon-fallback"), *update); err != nil {
+		t.Error(err)
+	}
+}
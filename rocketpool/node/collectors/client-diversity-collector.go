@@ -0,0 +1,176 @@
+package collectors
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings
+const (
+	// The number of recent blocks to aggregate the graffiti tags over (about a day of slots)
+	graffitiWindowSize int = 7200
+
+	// The maximum number of blocks to scan during a single collection
+	maxGraffitiBlocksPerCollect uint64 = 32
+)
+
+// Matches the client diversity tag that the Smartnode adds to graffiti, such as "RP-GL v1.10.0"
+var clientDiversityTagRegex = regexp.MustCompile(`RP-([A-Z])([A-Z])\b`)
+
+// The clients that correspond to each tag initial
+var executionClientInitials = map[string]string{
+	"G": "geth",
+	"N": "nethermind",
+	"B": "besu",
+}
+var consensusClientInitials = map[string]string{
+	"L": "lighthouse",
+	"S": "lodestar",
+	"N": "nimbus",
+	"P": "prysm",
+	"T": "teku",
+}
+
+// A client pair observed in a block's graffiti
+type clientPair struct {
+	executionClient string
+	consensusClient string
+}
+
+// Represents the collector for the client diversity metrics, based on the graffiti tags of recent blocks
+type ClientDiversityCollector struct {
+	// The number of recent blocks tagged with each client pair
+	taggedBlocks *prometheus.Desc
+
+	// The number of recent blocks that have been scanned
+	scannedBlocks *prometheus.Desc
+
+	// The beacon client
+	bc beacon.Client
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The client pairs of the scanned blocks, oldest first; blocks without a tag are nil
+	observations []*clientPair
+
+	// The next slot to scan
+	nextSlot uint64
+
+	// Lock for the scan results
+	lock sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ClientDiversityCollector instance
+func NewClientDiversityCollector(bc beacon.Client, stateLocker *StateLocker) *ClientDiversityCollector {
+	subsystem := "client_diversity"
+	return &ClientDiversityCollector{
+		taggedBlocks: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "tagged_blocks"),
+			"The number of recent blocks with a Rocket Pool client diversity tag in their graffiti, by client pair",
+			[]string{"execution_client", "consensus_client"}, nil,
+		),
+		scannedBlocks: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scanned_blocks"),
+			"The number of recent blocks whose graffiti was scanned for client diversity tags",
+			nil, nil,
+		),
+		bc:           bc,
+		stateLocker:  stateLocker,
+		observations: []*clientPair{},
+		logPrefix:    "Client Diversity Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ClientDiversityCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.taggedBlocks
+	channel <- collector.scannedBlocks
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ClientDiversityCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Scan the blocks since the last collection, starting one epoch back on the first run
+	headSlot := state.BeaconSlotNumber
+	if collector.nextSlot == 0 {
+		if headSlot > state.BeaconConfig.SlotsPerEpoch {
+			collector.nextSlot = headSlot - state.BeaconConfig.SlotsPerEpoch
+		}
+	}
+	if headSlot >= collector.nextSlot && headSlot-collector.nextSlot >= maxGraffitiBlocksPerCollect {
+		// Skip ahead if we've fallen too far behind
+		collector.nextSlot = headSlot - maxGraffitiBlocksPerCollect + 1
+	}
+	for ; collector.nextSlot <= headSlot; collector.nextSlot++ {
+		block, exists, err := collector.bc.GetBeaconBlock(fmt.Sprint(collector.nextSlot))
+		if err != nil {
+			collector.logError(fmt.Errorf("error getting Beacon block %d: %w", collector.nextSlot, err))
+			break
+		}
+		if !exists {
+			continue
+		}
+		collector.observations = append(collector.observations, parseClientDiversityTag(block.Graffiti))
+	}
+	if len(collector.observations) > graffitiWindowSize {
+		collector.observations = collector.observations[len(collector.observations)-graffitiWindowSize:]
+	}
+
+	// Tally the client pairs
+	counts := map[clientPair]float64{}
+	for _, pair := range collector.observations {
+		if pair != nil {
+			counts[*pair]++
+		}
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.scannedBlocks, prometheus.GaugeValue, float64(len(collector.observations)))
+	for pair, count := range counts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.taggedBlocks, prometheus.GaugeValue, count, pair.executionClient, pair.consensusClient)
+	}
+}
+
+// Get the client pair from a block's graffiti, or nil if it doesn't have a client diversity tag
+func parseClientDiversityTag(graffiti string) *clientPair {
+	matches := clientDiversityTagRegex.FindStringSubmatch(graffiti)
+	if matches == nil {
+		return nil
+	}
+	executionClient, exists := executionClientInitials[matches[1]]
+	if !exists {
+		executionClient = "unknown"
+	}
+	consensusClient, exists := consensusClientInitials[matches[2]]
+	if !exists {
+		consensusClient = "unknown"
+	}
+	return &clientPair{
+		executionClient: executionClient,
+		consensusClient: consensusClient,
+	}
+}
+
+// Log error messages
+func (collector *ClientDiversityCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -0,0 +1,102 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the node daemon's configuration reloads
+var configReloadStats = &daemonConfigStats{
+	reloads: map[string]float64{},
+}
+
+// The configuration the node daemon is running with
+type daemonConfigStats struct {
+	generation float64
+	lastReload time.Time
+	reloads    map[string]float64
+	lock       sync.Mutex
+}
+
+// Represents the collector for the node daemon's active configuration
+type ConfigCollector struct {
+	// The number of times the node daemon's configuration has been applied since it started
+	generation *prometheus.Desc
+
+	// The time the configuration was last reloaded
+	lastReload *prometheus.Desc
+
+	// The number of reload attempts by result
+	reloads *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ConfigCollector instance
+func NewConfigCollector() *ConfigCollector {
+	subsystem := "daemon"
+	return &ConfigCollector{
+		generation: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "config_generation"),
+			"The generation of the configuration the node daemon is running with; it starts at 1 and goes up with each successful reload",
+			nil, nil,
+		),
+		lastReload: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "config_last_reload_timestamp_seconds"),
+			"The time the node daemon's configuration was last reloaded successfully",
+			nil, nil,
+		),
+		reloads: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "config_reloads_total"),
+			"The number of configuration reloads attempted since the node daemon started, by result",
+			[]string{"result"}, nil,
+		),
+		logPrefix: "Config Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ConfigCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.generation
+	channel <- collector.lastReload
+	channel <- collector.reloads
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ConfigCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	configReloadStats.lock.Lock()
+	defer configReloadStats.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.generation, prometheus.GaugeValue, configReloadStats.generation)
+	if !configReloadStats.lastReload.IsZero() {
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastReload, prometheus.GaugeValue, float64(configReloadStats.lastReload.Unix()))
+	}
+	for _, result := range []string{"success", "failure"} {
+		channel <- prometheus.MustNewConstMetric(
+			collector.reloads, prometheus.CounterValue, configReloadStats.reloads[result], result)
+	}
+}
+
+// Record the generation of the configuration the node daemon started with
+func RecordConfigGeneration(generation uint64) {
+	configReloadStats.lock.Lock()
+	defer configReloadStats.lock.Unlock()
+	configReloadStats.generation = float64(generation)
+}
+
+// Record a configuration reload attempt; the generation is only updated if it succeeded
+func RecordConfigReload(generation uint64, err error) {
+	configReloadStats.lock.Lock()
+	defer configReloadStats.lock.Unlock()
+	if err != nil {
+		configReloadStats.reloads["failure"]++
+		return
+	}
+	configReloadStats.reloads["success"]++
+	configReloadStats.generation = float64(generation)
+	configReloadStats.lastReload = time.Now()
+}
@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// The places the metrics can currently be coming from
+const (
+	dataSource_Primary     string = "primary"
+	dataSource_Fallback    string = "fallback"
+	dataSource_Live        string = "live"
+	dataSource_Snapshot    string = "snapshot"
+	dataSource_Unavailable string = "unavailable"
+)
+
+// Represents the collector for where the other metrics are coming from, so dashboards can tell
+// when they're looking at partial or out-of-date data instead of going blank
+type DataSourceCollector struct {
+	// Where each kind of data is currently coming from
+	dataSource *prometheus.Desc
+
+	// Whether or not any of the metrics are currently incomplete or coming from a fallback
+	degraded *prometheus.Desc
+
+	// The Execution client manager
+	ec *services.ExecutionClientManager
+
+	// The Beacon client manager
+	bc *services.BeaconClientManager
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new DataSourceCollector instance
+func NewDataSourceCollector(ec *services.ExecutionClientManager, bc *services.BeaconClientManager, stateLocker *StateLocker) *DataSourceCollector {
+	return &DataSourceCollector{
+		dataSource: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "data_source"),
+			"Where each kind of data is currently coming from: the primary or fallback client for `execution` and `beacon`, and a live or snapshot network state for `state`",
+			[]string{"data", "source"}, nil,
+		),
+		degraded: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "degraded"),
+			"Whether or not the metrics are running in degraded mode, because a fallback client or an old network state is in use or a collector could only report some of its metrics (1 if so, 0 if not)",
+			nil, nil,
+		),
+		ec:          ec,
+		bc:          bc,
+		stateLocker: stateLocker,
+		logPrefix:   "Data Source Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DataSourceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.dataSource
+	channel <- collector.degraded
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DataSourceCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	ecSource := getClientSource(collector.ec.GetActiveProvider(), collector.ec.IsUsingFallback())
+	bcSource := getClientSource(collector.bc.GetActiveProvider(), collector.bc.IsUsingFallback())
+	stateSource := dataSource_Live
+	if collector.stateLocker.GetState() == nil {
+		stateSource = dataSource_Unavailable
+	} else if collector.stateLocker.IsStale() {
+		stateSource = dataSource_Snapshot
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.dataSource, prometheus.GaugeValue, 1, "execution", ecSource)
+	channel <- prometheus.MustNewConstMetric(
+		collector.dataSource, prometheus.GaugeValue, 1, "beacon", bcSource)
+	channel <- prometheus.MustNewConstMetric(
+		collector.dataSource, prometheus.GaugeValue, 1, "state", stateSource)
+
+	degraded := float64(0)
+	if ecSource != dataSource_Primary ||
+		bcSource != dataSource_Primary ||
+		stateSource != dataSource_Live ||
+		len(getDegradedCollectors()) > 0 {
+		degraded = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.degraded, prometheus.GaugeValue, degraded)
+}
+
+// Get the source of a client's data from the provider its manager is using
+func getClientSource(activeProvider string, usingFallback bool) string {
+	if activeProvider == "" {
+		return dataSource_Unavailable
+	}
+	if usingFallback {
+		return dataSource_Fallback
+	}
+	return dataSource_Primary
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -70,6 +70,8 @@ func (collector *DemandCollector) Describe(channel chan<- *prometheus.Desc) {
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *DemandCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
@@ -93,5 +95,6 @@ func (collector *DemandCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *DemandCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
@@ -0,0 +1,86 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Shared bookkeeping for the node daemon's doppelganger check
+var doppelgangerStats = &validatorDoppelgangerStats{
+	detected: map[common.Address]types.ValidatorPubkey{},
+}
+
+// The results of the latest doppelganger check
+type validatorDoppelgangerStats struct {
+	checked  bool
+	detected map[common.Address]types.ValidatorPubkey
+	lock     sync.Mutex
+}
+
+// Represents the collector for the doppelganger check metrics
+type DoppelgangerCollector struct {
+	// Whether a minipool's validator was live on the Beacon Chain while the local validator client wasn't attesting
+	detected *prometheus.Desc
+
+	// Whether the latest check could tell the local validator client's attestations apart from any others
+	checked *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new DoppelgangerCollector instance
+func NewDoppelgangerCollector() *DoppelgangerCollector {
+	subsystem := "doppelganger"
+	return &DoppelgangerCollector{
+		detected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "detected"),
+			"1 if a minipool's validator was seen attesting on the Beacon Chain while the local validator client was stopped or waiting out its doppelganger detection",
+			[]string{"minipool", "pubkey"}, nil,
+		),
+		checked: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "checked"),
+			"1 if the latest doppelganger check could run, which requires the local validator client to have been quiet for a full epoch",
+			nil, nil,
+		),
+		logPrefix: "Doppelganger Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DoppelgangerCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.detected
+	channel <- collector.checked
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DoppelgangerCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	doppelgangerStats.lock.Lock()
+	defer doppelgangerStats.lock.Unlock()
+
+	for minipool, pubkey := range doppelgangerStats.detected {
+		channel <- prometheus.MustNewConstMetric(
+			collector.detected, prometheus.GaugeValue, 1, minipool.Hex(), pubkey.Hex())
+	}
+	checked := float64(0)
+	if doppelgangerStats.checked {
+		checked = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.checked, prometheus.GaugeValue, checked)
+}
+
+// Replace the detected doppelgangers with the results of the latest check
+func SetDoppelgangers(checked bool, detected map[common.Address]types.ValidatorPubkey) {
+	doppelgangerStats.lock.Lock()
+	defer doppelgangerStats.lock.Unlock()
+	doppelgangerStats.checked = checked
+	doppelgangerStats.detected = map[common.Address]types.ValidatorPubkey{}
+	for minipool, pubkey := range detected {
+		doppelgangerStats.detected[minipool] = pubkey
+	}
+}
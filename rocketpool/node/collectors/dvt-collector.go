@@ -0,0 +1,132 @@
+package collectors
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dvtaddon "github.com/rocket-pool/smartnode/addons/dvt"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+)
+
+// Settings
+const dvtHealthCheckTimeout = 5 * time.Second
+
+// Represents the collector for the distributed validator clusters
+type DvtCollector struct {
+	// Whether each of the cluster's nodes is passing its health check
+	nodeHealthy *prometheus.Desc
+
+	// How long each node's health check took
+	nodeLatency *prometheus.Desc
+
+	// The number of validators split into clusters, by whether they've been taken out of the Validator Client
+	validators *prometheus.Desc
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The DVT cluster manager
+	manager *dvt.Manager
+
+	// The client for the health checks
+	client *http.Client
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new DvtCollector instance
+func NewDvtCollector(cfg *config.RocketPoolConfig, manager *dvt.Manager) *DvtCollector {
+	subsystem := "dvt"
+	return &DvtCollector{
+		nodeHealthy: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_healthy"),
+			"Whether the distributed validator node behind the health URL is healthy (1) or not (0)",
+			[]string{"url"}, nil,
+		),
+		nodeLatency: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_health_check_seconds"),
+			"How long the distributed validator node's health check took",
+			[]string{"url"}, nil,
+		),
+		validators: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "validators"),
+			"The number of validators split into distributed validator clusters",
+			[]string{"provider", "state"}, nil,
+		),
+		cfg:     cfg,
+		manager: manager,
+		client: &http.Client{
+			Timeout: dvtHealthCheckTimeout,
+		},
+		logPrefix: "DVT Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DvtCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.nodeHealthy
+	channel <- collector.nodeLatency
+	channel <- collector.validators
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DvtCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	if collector.cfg.Dvt.GetEnabledParameter().Value != true {
+		return
+	}
+	dvtCfg := collector.cfg.Dvt.GetConfig().(*dvtaddon.DvtConfig)
+
+	// Count the split validators
+	type validatorKey struct {
+		provider string
+		state    string
+	}
+	counts := map[validatorKey]float64{}
+	for _, cluster := range collector.manager.GetClusters() {
+		state := "split"
+		if cluster.Retired {
+			state = "retired"
+		}
+		counts[validatorKey{cluster.Provider, state}]++
+	}
+	for key, count := range counts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.validators, prometheus.GaugeValue, count, key.provider, key.state)
+	}
+
+	// Check the cluster's nodes in parallel so a slow one doesn't hold up the others; a node that can't be reached
+	// counts as unhealthy rather than as a collector failure
+	var wg sync.WaitGroup
+	for _, url := range dvtCfg.GetHealthUrls() {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			start := time.Now()
+			healthy := collector.checkHealth(url)
+			latency := time.Since(start).Seconds()
+			healthyValue := float64(0)
+			if healthy {
+				healthyValue = 1
+			}
+			channel <- prometheus.MustNewConstMetric(
+				collector.nodeHealthy, prometheus.GaugeValue, healthyValue, url)
+			channel <- prometheus.MustNewConstMetric(
+				collector.nodeLatency, prometheus.GaugeValue, latency, url)
+		}(url)
+	}
+	wg.Wait()
+}
+
+// Check a node's health URL, which is healthy if it responds with a 200
+func (collector *DvtCollector) checkHealth(url string) bool {
+	response, err := collector.client.Get(url)
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+	return response.StatusCode == http.StatusOK
+}
@@ -0,0 +1,44 @@
+package collectors_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dvtaddon "github.com/rocket-pool/smartnode/addons/dvt"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors/harness"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+)
+
+func TestDvtCollector(t *testing.T) {
+	manager, err := dvt.NewManager("testdata/dvt")
+	if err != nil {
+		t.Fatalf("error loading DVT clusters: %s", err.Error())
+	}
+
+	// One of the cluster's nodes is healthy and the other isn't
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/healthy" {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := newConfig(t)
+	cfg.Dvt.GetEnabledParameter().Value = true
+	cfg.Dvt.GetConfig().(*dvtaddon.DvtConfig).HealthUrls.Value = server.URL + "/healthy," + server.URL + "/unhealthy"
+
+	output, err := harness.Collect(collectors.NewDvtCollector(cfg, manager))
+	if err != nil {
+		t.Fatalf("error collecting metrics: %s", err.Error())
+	}
+
+	// The server's port changes every run, so it's swapped for a fixed host
+	output = bytes.ReplaceAll(output, []byte(server.URL), []byte("http://dvt-node"))
+	output = harness.MaskValues(output, "rocketpool_dvt_node_health_check_seconds")
+	if err := harness.CompareGoldenOutput(output, goldenPath("dvt"), *update); err != nil {
+		t.Error(err)
+	}
+}
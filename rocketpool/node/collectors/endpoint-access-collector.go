@@ -0,0 +1,72 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
+)
+
+// Represents the collector for the requests the node daemon sends to its clients, by the subsystem that sent them
+type EndpointAccessCollector struct {
+	// The number of requests sent
+	requests *prometheus.Desc
+
+	// The number of requests that failed
+	errors *prometheus.Desc
+
+	// The total time spent waiting on requests
+	duration *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new EndpointAccessCollector instance
+func NewEndpointAccessCollector() *EndpointAccessCollector {
+	subsystem := "endpoint"
+	labels := []string{"client", "endpoint", "caller", "method"}
+	return &EndpointAccessCollector{
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The number of requests the node daemon has sent to its Execution and Beacon clients, by the subsystem that sent them and the client method",
+			labels, nil,
+		),
+		errors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "request_errors_total"),
+			"The number of requests to the Execution and Beacon clients that failed, by the subsystem that sent them and the client method",
+			labels, nil,
+		),
+		duration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "request_duration_seconds_total"),
+			"The total time spent waiting on requests to the Execution and Beacon clients, by the subsystem that sent them and the client method",
+			labels, nil,
+		),
+		logPrefix: "Endpoint Access Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *EndpointAccessCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requests
+	channel <- collector.errors
+	channel <- collector.duration
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *EndpointAccessCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// The counters are only kept while the access log is enabled
+	if !accesslog.IsEnabled() {
+		return
+	}
+
+	for _, stats := range accesslog.GetStats() {
+		labels := []string{string(stats.Client), stats.Endpoint, stats.Subsystem, stats.Method}
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.CounterValue, float64(stats.Requests), labels...)
+		channel <- prometheus.MustNewConstMetric(
+			collector.errors, prometheus.CounterValue, float64(stats.Errors), labels...)
+		channel <- prometheus.MustNewConstMetric(
+			collector.duration, prometheus.CounterValue, stats.Duration.Seconds(), labels...)
+	}
+}
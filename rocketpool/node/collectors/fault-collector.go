@@ -0,0 +1,49 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
+)
+
+// Wraps a collector that fault injection has been told to break, so it fails on every scrape the same way a real collector error would
+type FaultCollector struct {
+	// The collector being wrapped
+	collector prometheus.Collector
+
+	// The error to fail with
+	err error
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Wrap a collector if fault injection targets it by name (e.g. "demand" for the Demand Collector); otherwise return it unchanged
+func WithFaultInjection(name string, collector prometheus.Collector) prometheus.Collector {
+	err := faults.FailCollector(name)
+	if err == nil {
+		return collector
+	}
+	return &FaultCollector{
+		collector: collector,
+		err:       err,
+		logPrefix: fmt.Sprintf("%s Collector", name),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *FaultCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.collector.Describe(channel)
+}
+
+// Skip the wrapped collector and record the injected error instead
+func (collector *FaultCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.logError(collector.err)
+}
+
+// Log error messages
+func (collector *FaultCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
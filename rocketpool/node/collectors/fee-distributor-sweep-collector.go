@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Shared bookkeeping for the fee distributor sweeps sent by the node daemon
+var feeDistributorSweepStats = &nodeFeeDistributorSweepStats{}
+
+// The sweeps the node daemon has sent since it started
+type nodeFeeDistributorSweepStats struct {
+	count        float64
+	distributed  float64
+	nodeShare    float64
+	forwardedEth float64
+	lock         sync.Mutex
+}
+
+// Represents the collector for the automatic fee distributor sweep metrics
+type FeeDistributorSweepCollector struct {
+	// The number of times the node daemon has distributed the fee distributor
+	sweeps *prometheus.Desc
+
+	// The total amount of ETH the node daemon has distributed
+	distributedEth *prometheus.Desc
+
+	// The node's share of the distributed ETH
+	nodeShareEth *prometheus.Desc
+
+	// The amount of the node's share forwarded from the node wallet
+	forwardedEth *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new FeeDistributorSweepCollector instance
+func NewFeeDistributorSweepCollector() *FeeDistributorSweepCollector {
+	subsystem := "fee_distributor_sweep"
+	return &FeeDistributorSweepCollector{
+		sweeps: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sweeps_total"),
+			"The number of times the node daemon has automatically distributed the fee distributor since it started",
+			nil, nil,
+		),
+		distributedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "distributed_eth_total"),
+			"The total amount of ETH the node daemon has automatically distributed from the fee distributor since it started",
+			nil, nil,
+		),
+		nodeShareEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_share_eth_total"),
+			"The node's share of the ETH the node daemon has automatically distributed from the fee distributor since it started",
+			nil, nil,
+		),
+		forwardedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "forwarded_eth_total"),
+			"The amount of the node's share the node daemon has forwarded from the node wallet since it started",
+			nil, nil,
+		),
+		logPrefix: "Fee Distributor Sweep Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *FeeDistributorSweepCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.sweeps
+	channel <- collector.distributedEth
+	channel <- collector.nodeShareEth
+	channel <- collector.forwardedEth
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *FeeDistributorSweepCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	feeDistributorSweepStats.lock.Lock()
+	defer feeDistributorSweepStats.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.sweeps, prometheus.CounterValue, feeDistributorSweepStats.count)
+	channel <- prometheus.MustNewConstMetric(
+		collector.distributedEth, prometheus.CounterValue, feeDistributorSweepStats.distributed)
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeShareEth, prometheus.CounterValue, feeDistributorSweepStats.nodeShare)
+	channel <- prometheus.MustNewConstMetric(
+		collector.forwardedEth, prometheus.CounterValue, feeDistributorSweepStats.forwardedEth)
+}
+
+// Record that the node daemon distributed the fee distributor
+func RecordFeeDistributorSweep(amount *big.Int, nodeShare *big.Int) {
+	feeDistributorSweepStats.lock.Lock()
+	defer feeDistributorSweepStats.lock.Unlock()
+	feeDistributorSweepStats.count++
+	feeDistributorSweepStats.distributed += eth.WeiToEth(amount)
+	feeDistributorSweepStats.nodeShare += eth.WeiToEth(nodeShare)
+}
+
+// Record that the node daemon forwarded the node share of a sweep
+func RecordFeeDistributorForward(amount *big.Int) {
+	feeDistributorSweepStats.lock.Lock()
+	defer feeDistributorSweepStats.lock.Unlock()
+	feeDistributorSweepStats.forwardedEth += eth.WeiToEth(amount)
+}
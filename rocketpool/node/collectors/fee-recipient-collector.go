@@ -0,0 +1,130 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the fee recipient checks run by the node daemon
+var feeRecipientStats = &feeRecipientCheckStats{}
+
+// The results of the latest fee recipient check
+type feeRecipientCheckStats struct {
+	checked              bool
+	expected             common.Address
+	configured           common.Address
+	correct              bool
+	mismatchedValidators float64
+	corrections          float64
+	lastCheckTime        time.Time
+	lock                 sync.Mutex
+}
+
+// Represents the collector for the fee recipient watchdog metrics
+type FeeRecipientCollector struct {
+	// Whether or not the VC's fee recipient is correct
+	correct *prometheus.Desc
+
+	// The number of the node's validators that the VC reports the wrong fee recipient for
+	mismatchedValidators *prometheus.Desc
+
+	// The expected and configured fee recipients
+	info *prometheus.Desc
+
+	// The number of times the node daemon has corrected the fee recipient
+	corrections *prometheus.Desc
+
+	// The time of the latest fee recipient check
+	lastCheck *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new FeeRecipientCollector instance
+func NewFeeRecipientCollector() *FeeRecipientCollector {
+	subsystem := "fee_recipient"
+	return &FeeRecipientCollector{
+		correct: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "correct"),
+			"Whether or not the validator client's fee recipient matches the expected fee recipient (1 if it does, 0 if it doesn't)",
+			nil, nil,
+		),
+		mismatchedValidators: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mismatched_validators"),
+			"The number of the node's active or pending validators that the validator client reports the wrong fee recipient for",
+			nil, nil,
+		),
+		info: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "info"),
+			"The fee recipient the validator client should be using, and the one it's configured with",
+			[]string{"expected", "configured"}, nil,
+		),
+		corrections: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "corrections_total"),
+			"The number of times the node daemon has corrected the validator client's fee recipient since it started",
+			nil, nil,
+		),
+		lastCheck: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_check_timestamp_seconds"),
+			"The time of the latest fee recipient check",
+			nil, nil,
+		),
+		logPrefix: "Fee Recipient Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *FeeRecipientCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.correct
+	channel <- collector.mismatchedValidators
+	channel <- collector.info
+	channel <- collector.corrections
+	channel <- collector.lastCheck
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *FeeRecipientCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	feeRecipientStats.lock.Lock()
+	defer feeRecipientStats.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.corrections, prometheus.CounterValue, feeRecipientStats.corrections)
+
+	// Don't report anything else until the first check has run
+	if !feeRecipientStats.checked {
+		return
+	}
+
+	correct := float64(0)
+	if feeRecipientStats.correct {
+		correct = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.correct, prometheus.GaugeValue, correct)
+	channel <- prometheus.MustNewConstMetric(
+		collector.mismatchedValidators, prometheus.GaugeValue, feeRecipientStats.mismatchedValidators)
+	channel <- prometheus.MustNewConstMetric(
+		collector.info, prometheus.GaugeValue, 1, feeRecipientStats.expected.Hex(), feeRecipientStats.configured.Hex())
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastCheck, prometheus.GaugeValue, float64(feeRecipientStats.lastCheckTime.Unix()))
+}
+
+// Record the result of a fee recipient check
+func RecordFeeRecipientCheck(expected common.Address, configured common.Address, correct bool, mismatchedValidators int) {
+	feeRecipientStats.lock.Lock()
+	defer feeRecipientStats.lock.Unlock()
+	feeRecipientStats.checked = true
+	feeRecipientStats.expected = expected
+	feeRecipientStats.configured = configured
+	feeRecipientStats.correct = correct
+	feeRecipientStats.mismatchedValidators = float64(mismatchedValidators)
+	feeRecipientStats.lastCheckTime = time.Now()
+}
+
+// Record that the node daemon corrected the fee recipient
+func RecordFeeRecipientCorrection() {
+	feeRecipientStats.lock.Lock()
+	defer feeRecipientStats.lock.Unlock()
+	feeRecipientStats.corrections++
+}
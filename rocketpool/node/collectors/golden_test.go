@@ -0,0 +1,512 @@
+package collectors_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors/harness"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Run the tests with -update to regenerate the golden files from the collectors' current output
+var update = flag.Bool("update", false, "update the golden files with the collectors' output")
+
+// The fixtures the collectors are tested against
+const (
+	stateFixturePath     string = "testdata/state.json"
+	beaconFixturePath    string = "testdata/beacon.json"
+	contractsFixturePath string = "testdata/contracts.json"
+	goldenDir            string = "testdata/golden"
+)
+
+// The node in the state fixture
+var nodeAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+// Create a StateLocker from the state fixture
+func newStateLocker(t *testing.T) *collectors.StateLocker {
+	t.Helper()
+	stateLocker, err := harness.NewStateLockerFromFixture(stateFixturePath)
+	if err != nil {
+		t.Fatalf("error loading state fixture: %s", err.Error())
+	}
+	return stateLocker
+}
+
+// Create a RocketPool binding that's served by the contracts fixture
+func newRocketPool(t *testing.T) (*rocketpool.RocketPool, *harness.MockExecutionClient) {
+	t.Helper()
+	rp, ec, err := harness.NewRocketPoolFromFixture(contractsFixturePath)
+	if err != nil {
+		t.Fatalf("error loading contracts fixture: %s", err.Error())
+	}
+	return rp, ec
+}
+
+// Create a mock Beacon client from the Beacon fixture
+func newBeaconClient(t *testing.T) *harness.MockBeaconClient {
+	t.Helper()
+	bc, err := harness.NewMockBeaconClientFromFixture(beaconFixturePath)
+	if err != nil {
+		t.Fatalf("error loading Beacon fixture: %s", err.Error())
+	}
+	return bc
+}
+
+// Create a default config that keeps its files in a temporary directory
+func newConfig(t *testing.T) *config.RocketPoolConfig {
+	t.Helper()
+	return config.NewRocketPoolConfig(t.TempDir(), false)
+}
+
+// Create Execution and Beacon client managers that connect to mock servers for the given clients.
+// The servers are closed when the test finishes.
+func newClientManagers(t *testing.T, ec *harness.MockExecutionClient, bc *harness.MockBeaconClient) (*services.ExecutionClientManager, *services.BeaconClientManager) {
+	t.Helper()
+	ecServer := harness.NewMockExecutionServer(ec)
+	t.Cleanup(ecServer.Close)
+	bcServer := harness.NewMockBeaconServer(bc)
+	t.Cleanup(bcServer.Close)
+
+	// Native mode connects to the clients by their URLs instead of by their container names
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	cfg.Native.EcHttpUrl.Value = ecServer.URL
+	cfg.Native.CcHttpUrl.Value = bcServer.URL
+
+	ecManager, err := services.NewExecutionClientManager(cfg)
+	if err != nil {
+		t.Fatalf("error creating Execution client manager: %s", err.Error())
+	}
+	bcManager, err := services.NewBeaconClientManager(cfg)
+	if err != nil {
+		t.Fatalf("error creating Beacon client manager: %s", err.Error())
+	}
+	return ecManager, bcManager
+}
+
+// Get the path of a collector's golden file
+func goldenPath(name string) string {
+	return filepath.Join(goldenDir, name+".prom")
+}
+
+// A price source with fixed prices
+type fixedPriceSource struct {
+	ethPrice float64
+}
+
+func (s fixedPriceSource) GetPrice(asset prices.Asset) (float64, error) {
+	return s.ethPrice, nil
+}
+
+func (s fixedPriceSource) GetCurrency() string {
+	return "usd"
+}
+
+// Serve Snapshot's GraphQL API from a mock server until the test finishes.
+// The Snapshot API is always reached over HTTPS at a fixed domain, so every request sent with the default transport goes to the mock server.
+func mockSnapshotApi(t *testing.T, delegateAddress common.Address) {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var response string
+		switch request.URL.Query().Get("operationName") {
+		case "Proposals":
+			response = `{"data":{"proposals":[{"id":"0x01","state":"active"},{"id":"0x02","state":"closed"},{"id":"0x03","state":"closed"}]}}`
+		case "Votes":
+			// The node and its delegate both voted on the second proposal, which only counts once
+			response = `{"data":{"votes":[{"proposal":{"id":"0x01","state":"active"}},{"proposal":{"id":"0x02","state":"closed"}},{"proposal":{"id":"0x02","state":"closed"}}]}}`
+		case "Vp":
+			if strings.Contains(request.URL.Query().Get("query"), delegateAddress.Hex()) {
+				response = `{"data":{"vp":{"vp":1200.5}}}`
+			} else {
+				response = `{"data":{"vp":{"vp":350.25}}}`
+			}
+		default:
+			http.NotFound(writer, request)
+			return
+		}
+		_, _ = writer.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	// The test server's certificate is issued for example.com
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.ServerName = "example.com"
+	transport.DialContext = func(ctx context.Context, network string, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	t.Cleanup(func() {
+		http.DefaultTransport = defaultTransport
+	})
+}
+
+// A collector whose output is compared to its golden file, testdata/golden/<name>.prom
+type goldenTest struct {
+	name string
+
+	// Metrics whose values change between runs, such as ones that record the current time, which are left out of the comparison
+	masked []string
+
+	// Set up the collector's inputs and create it
+	newCollector func(t *testing.T) prometheus.Collector
+}
+
+func TestCollectorsGolden(t *testing.T) {
+	tests := []goldenTest{
+		{
+			name:   "auto-claim",
+			masked: []string{"rocketpool_auto_claim_last_claim_timestamp_seconds"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordAutoClaim(eth.EthToWei(12.5), eth.EthToWei(0.75), eth.EthToWei(5))
+				collectors.RecordAutoClaimFailure()
+				return collectors.NewAutoClaimCollector()
+			},
+		},
+		{
+			name:   "balance-history",
+			masked: []string{"rocketpool_balance_history_latest_snapshot_age_seconds"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				store := balancehistory.NewStore("testdata/balance-history.jsonl")
+				emptyNode := common.HexToAddress("0x2222222222222222222222222222222222222222")
+				return collectors.NewBalanceHistoryCollector(store, []common.Address{nodeAddress, emptyNode})
+			},
+		},
+		{
+			name: "beacon",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, ec := newRocketPool(t)
+				return collectors.NewBeaconCollector(rp, newBeaconClient(t), ec, nodeAddress, newStateLocker(t))
+			},
+		},
+		{
+			name: "client-diversity",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				return collectors.NewClientDiversityCollector(newBeaconClient(t), newStateLocker(t))
+			},
+		},
+		{
+			name:   "config",
+			masked: []string{"rocketpool_daemon_config_last_reload_timestamp_seconds"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordConfigGeneration(1)
+				collectors.RecordConfigReload(2, nil)
+				collectors.RecordConfigReload(3, errors.New("invalid config"))
+				return collectors.NewConfigCollector()
+			},
+		},
+		{
+			// Whether the data is degraded also depends on the collectors that ran before this one, so it's left out
+			name:   "data-source",
+			masked: []string{"rocketpool_degraded"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				_, ec := newRocketPool(t)
+				ecManager, bcManager := newClientManagers(t, ec, newBeaconClient(t))
+				return collectors.NewDataSourceCollector(ecManager, bcManager, newStateLocker(t))
+			},
+		},
+		{
+			name: "demand",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewDemandCollector(rp, newStateLocker(t))
+			},
+		},
+		{
+			name: "doppelganger",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.SetDoppelgangers(true, map[common.Address]types.ValidatorPubkey{
+					common.HexToAddress("0x0000000000000000000000000000000000001001"): types.BytesToValidatorPubkey(make([]byte, types.ValidatorPubkeyLength)),
+				})
+				return collectors.NewDoppelgangerCollector()
+			},
+		},
+		{
+			name:   "endpoint-access",
+			masked: []string{"rocketpool_endpoint_request_duration_seconds_total"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				if err := accesslog.Enable("", "node"); err != nil {
+					t.Fatalf("error enabling access log: %s", err.Error())
+				}
+				start := time.Now()
+				ctx := accesslog.WithSubsystem(context.Background(), "node.claimRewards")
+				accesslog.Record(ctx, accesslog.ClientType_Execution, "http://eth1:8545", "CallContract", start, nil)
+				accesslog.Record(ctx, accesslog.ClientType_Execution, "http://eth1:8545", "CallContract", start, errors.New("connection refused"))
+				accesslog.Record(context.Background(), accesslog.ClientType_Beacon, "http://eth2:5052", "GetBeaconHead", start, nil)
+				return collectors.NewEndpointAccessCollector()
+			},
+		},
+		{
+			name: "fee-distributor-sweep",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordFeeDistributorSweep(eth.EthToWei(1.5), eth.EthToWei(0.9))
+				collectors.RecordFeeDistributorForward(eth.EthToWei(0.9))
+				return collectors.NewFeeDistributorSweepCollector()
+			},
+		},
+		{
+			name:   "fee-recipient",
+			masked: []string{"rocketpool_fee_recipient_last_check_timestamp_seconds"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				smoothingPool := common.HexToAddress("0x00000000000000000000000000000000000005b0")
+				distributor := common.HexToAddress("0x00000000000000000000000000000000000005d1")
+				collectors.RecordFeeRecipientCheck(smoothingPool, distributor, false, 2)
+				collectors.RecordFeeRecipientCorrection()
+				return collectors.NewFeeRecipientCollector()
+			},
+		},
+		{
+			name:   "mev-relay",
+			masked: []string{"rocketpool_mev_relay_last_registration_check_timestamp_seconds"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordMevRelayStatus("flashbots", true, 120*time.Millisecond)
+				collectors.RecordMevRelayStatus("ultrasound", false, 2*time.Second)
+				collectors.RecordMevRelayError("ultrasound")
+				collectors.RecordMevRelayRegistrations("flashbots", 3, map[string]int{"fee_recipient": 1})
+				return collectors.NewMevRelayCollector()
+			},
+		},
+		{
+			name: "minipool",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewMinipoolCollector(rp, nodeAddress, newConfig(t), newStateLocker(t))
+			},
+		},
+		{
+			name:   "minipool-performance",
+			masked: []string{"rocketpool_minipool_performance_last_update_timestamp"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.SetMinipoolPerformance(rputils.MinipoolPerformanceReport{
+					StartEpoch:             249773,
+					EndEpoch:               249998,
+					NetworkAttestationRate: 0.98,
+					NetworkProposalRate:    0.99,
+					Minipools: []rputils.MinipoolPerformance{
+						{
+							MinipoolAddress:   common.HexToAddress("0x0000000000000000000000000000000000001001"),
+							ValidatorIndex:    100,
+							AttestationDuties: 225,
+							Attestations:      221,
+							AttestationRate:   221.0 / 225,
+							ProposalDuties:    1,
+							Proposals:         1,
+							Score:             1.0002,
+						},
+					},
+				})
+				return collectors.NewMinipoolPerformanceCollector()
+			},
+		},
+		{
+			name: "network",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewNetworkCollector(rp, newStateLocker(t))
+			},
+		},
+		{
+			// The rewards trees aren't in the fixtures, so the rewards metrics are left out
+			name: "node",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				cfg := newConfig(t)
+				rewardsInfo := rprewards.NewRewardsInfo(rp, cfg)
+				return collectors.NewNodeCollector(rp, newBeaconClient(t), []common.Address{nodeAddress}, cfg, rewardsInfo, fixedPriceSource{ethPrice: 2500}, newStateLocker(t))
+			},
+		},
+		{
+			name: "odao",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewOdaoCollector(rp, newStateLocker(t))
+			},
+		},
+		{
+			name: "overrides",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				cfg := newConfig(t)
+				cfg.Smartnode.ContractAddressOverrides.Value = "rocketStorage=0x1d8f8f00cfa6758d7be78336684788fb0ee0fa46, holesky:multicall=0x0000000000000000000000000000000000000001"
+				return collectors.NewOverridesCollector(cfg)
+			},
+		},
+		{
+			name: "performance",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewPerformanceCollector(rp, newStateLocker(t))
+			},
+		},
+		{
+			name:   "proposal",
+			masked: []string{"rocketpool_proposals_last_detected_timestamp"},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordBlockStreamStatus(true)
+				collectors.RecordProposal(7999994, 1500*time.Millisecond)
+				return collectors.NewProposalCollector()
+			},
+		},
+		{
+			name: "queue",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewQueueCollector(rp, nodeAddress, newConfig(t), newStateLocker(t))
+			},
+		},
+		{
+			name: "refund",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordMinipoolRefund(eth.EthToWei(0.25))
+				return collectors.NewRefundCollector()
+			},
+		},
+		{
+			name: "rpl",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewRplCollector(rp, newConfig(t), newStateLocker(t))
+			},
+		},
+		{
+			name: "safe-mode",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				collectors.RecordSafeMode(true, 4)
+				return collectors.NewSafeModeCollector()
+			},
+		},
+		{
+			name: "scrub-risk",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				minipool := common.HexToAddress("0x0000000000000000000000000000000000001002")
+				collectors.SetScrubRisks(map[common.Address][]string{
+					minipool: {"withdrawal_credentials"},
+				})
+				collectors.RecordScrubRiskBlockedStake(minipool, []string{"withdrawal_credentials"})
+				return collectors.NewScrubRiskCollector()
+			},
+		},
+		{
+			name: "smoothing-pool",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewSmoothingPoolCollector(rp, nil, newStateLocker(t))
+			},
+		},
+		{
+			name: "snapshot",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				delegateAddress := common.HexToAddress("0x00000000000000000000000000000000000000de")
+				mockSnapshotApi(t, delegateAddress)
+				return collectors.NewSnapshotCollector(rp, newConfig(t), nodeAddress, delegateAddress)
+			},
+		},
+		{
+			name: "state",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				return collectors.NewStateCollector(newStateLocker(t))
+			},
+		},
+		{
+			name: "supply",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				return collectors.NewSupplyCollector(rp, newStateLocker(t))
+			},
+		},
+		{
+			name: "sync",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				fixture, err := harness.LoadBeaconFixture(beaconFixturePath)
+				if err != nil {
+					t.Fatalf("error loading Beacon fixture: %s", err.Error())
+				}
+
+				// The network head is the later of the synced head and the slot for the current time, so genesis is moved into
+				// the future to keep it at the synced head
+				fixture.Eth2Config.GenesisTime = 4102444800
+				_, ec := newRocketPool(t)
+				ecManager, bcManager := newClientManagers(t, ec, harness.NewMockBeaconClient(fixture))
+				return collectors.NewSyncCollector(ecManager, bcManager)
+			},
+		},
+		{
+			name: "task",
+			masked: []string{
+				"rocketpool_task_last_run_timestamp_seconds",
+				"rocketpool_task_last_success_timestamp_seconds",
+				"rocketpool_task_last_duration_seconds",
+			},
+			newCollector: func(t *testing.T) prometheus.Collector {
+				start := time.Now()
+				collectors.RecordTaskRun("stake-prelaunch-minipools", start, nil)
+				collectors.RecordTaskRun("distribute-minipools", start, errors.New("insufficient balance"))
+				return collectors.NewTaskCollector()
+			},
+		},
+		{
+			name: "trusted-node",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				rp, _ := newRocketPool(t)
+				cfg := newConfig(t)
+				cfg.EnableODaoMetrics.Value = true
+				return collectors.NewTrustedNodeCollector(rp, newBeaconClient(t), nodeAddress, cfg, newStateLocker(t))
+			},
+		},
+		{
+			name: "validator-status",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				return collectors.NewValidatorStatusCollector(nodeAddress, newConfig(t), newStateLocker(t))
+			},
+		},
+		{
+			// The signer has the keys for two of the node's four unfinalized minipools
+			name: "web3signer",
+			newCollector: func(t *testing.T) prometheus.Collector {
+				server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					if request.URL.Path != "/api/v1/eth2/publicKeys" {
+						http.NotFound(writer, request)
+						return
+					}
+					_ = json.NewEncoder(writer).Encode([]string{
+						"0x" + strings.Repeat("a1", 48),
+						"0x" + strings.Repeat("a4", 48),
+					})
+				}))
+				t.Cleanup(server.Close)
+				return collectors.NewWeb3SignerCollector(web3signer.NewClient(server.URL), nodeAddress, newConfig(t), newStateLocker(t))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := test.newCollector(t)
+			if err := harness.CompareGolden(collector, goldenPath(test.name), *update, test.masked...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The responses a mock Beacon client serves, loaded from a JSON fixture.
+// Duties and liveness are keyed by epoch, then by validator index.
+type BeaconFixture struct {
+	ClientType      beacon.BeaconClientType       `json:"clientType"`
+	SyncStatus      beacon.SyncStatus             `json:"syncStatus"`
+	PeerCount       uint64                        `json:"peerCount"`
+	Eth2Config      beacon.Eth2Config             `json:"eth2Config"`
+	Head            beacon.BeaconHead             `json:"head"`
+	Blocks          map[string]beacon.BeaconBlock `json:"blocks"`
+	Validators      []beacon.ValidatorStatus      `json:"validators"`
+	SyncDuties      map[uint64]map[uint64]bool    `json:"syncDuties"`
+	ProposerDuties  map[uint64]map[uint64]uint64  `json:"proposerDuties"`
+	Liveness        map[uint64]map[uint64]bool    `json:"liveness"`
+	Committees      map[uint64][]beacon.Committee `json:"committees"`
+	Eth1Data        map[string]beacon.Eth1Data    `json:"eth1Data"`
+	StateRoots      map[string]common.Hash        `json:"stateRoots"`
+	DepositContract beacon.Eth2DepositContract    `json:"depositContract"`
+}
+
+// A Beacon client that serves the responses in a fixture instead of talking to a Beacon node.
+// It's read-only, so anything that would submit to the chain returns an error.
+type MockBeaconClient struct {
+	fixture *BeaconFixture
+}
+
+// Load a Beacon fixture from a JSON file
+func LoadBeaconFixture(path string) (*BeaconFixture, error) {
+	fixtureBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Beacon fixture [%s]: %w", path, err)
+	}
+
+	fixture := &BeaconFixture{}
+	err = json.Unmarshal(fixtureBytes, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Beacon fixture [%s]: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Create a mock Beacon client that serves the responses in a fixture
+func NewMockBeaconClient(fixture *BeaconFixture) *MockBeaconClient {
+	return &MockBeaconClient{
+		fixture: fixture,
+	}
+}
+
+// Create a mock Beacon client from a fixture file
+func NewMockBeaconClientFromFixture(path string) (*MockBeaconClient, error) {
+	fixture, err := LoadBeaconFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMockBeaconClient(fixture), nil
+}
+
+// Get the client's process configuration type
+func (c *MockBeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	return c.fixture.ClientType, nil
+}
+
+// Get the node's sync status
+func (c *MockBeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	return c.fixture.SyncStatus, nil
+}
+
+// Get the number of peers the node is connected to
+func (c *MockBeaconClient) GetPeerCount() (uint64, error) {
+	return c.fixture.PeerCount, nil
+}
+
+// Get the eth2 config
+func (c *MockBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return c.fixture.Eth2Config, nil
+}
+
+// Get the eth2 deposit contract info
+func (c *MockBeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	return c.fixture.DepositContract, nil
+}
+
+// Get the attestations in a Beacon chain block
+func (c *MockBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	block, exists := c.fixture.Blocks[blockId]
+	if !exists {
+		return nil, false, nil
+	}
+	return block.Attestations, true, nil
+}
+
+// Get a Beacon chain block
+func (c *MockBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	block, exists := c.fixture.Blocks[blockId]
+	return block, exists, nil
+}
+
+// Get the root of a Beacon state
+func (c *MockBeaconClient) GetBeaconStateRoot(stateId string) (common.Hash, bool, error) {
+	root, exists := c.fixture.StateRoots[stateId]
+	return root, exists, nil
+}
+
+// Get the beacon head
+func (c *MockBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	return c.fixture.Head, nil
+}
+
+// Get a validator's status by its index
+func (c *MockBeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	validatorIndex, err := strconv.ParseUint(index, 10, 64)
+	if err != nil {
+		return beacon.ValidatorStatus{}, fmt.Errorf("invalid validator index [%s]: %w", index, err)
+	}
+	for _, validator := range c.fixture.Validators {
+		if validator.Index == validatorIndex {
+			return validator, nil
+		}
+	}
+	return beacon.ValidatorStatus{}, nil
+}
+
+// Get a validator's status
+func (c *MockBeaconClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	for _, validator := range c.fixture.Validators {
+		if validator.Pubkey == pubkey {
+			return validator, nil
+		}
+	}
+	return beacon.ValidatorStatus{}, nil
+}
+
+// Get multiple validators' statuses
+func (c *MockBeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		status, _ := c.GetValidatorStatus(pubkey, opts)
+		statuses[pubkey] = status
+	}
+	return statuses, nil
+}
+
+// Get the statuses of multiple validators by their indices
+func (c *MockBeaconClient) GetValidatorStatusesByIndices(indices []uint64, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(indices))
+	for _, index := range indices {
+		for _, validator := range c.fixture.Validators {
+			if validator.Index == index {
+				statuses[validator.Pubkey] = validator
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// Get a validator's index
+func (c *MockBeaconClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+	for _, validator := range c.fixture.Validators {
+		if validator.Pubkey == pubkey {
+			return validator.Index, nil
+		}
+	}
+	return 0, fmt.Errorf("validator %s is not in the Beacon fixture", pubkey.Hex())
+}
+
+// Get whether validators have sync duties to perform at given epoch
+func (c *MockBeaconClient) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	duties := make(map[uint64]bool, len(indices))
+	for _, index := range indices {
+		duties[index] = c.fixture.SyncDuties[epoch][index]
+	}
+	return duties, nil
+}
+
+// Sums proposer duties per validators for a given epoch
+func (c *MockBeaconClient) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	duties := make(map[uint64]uint64, len(indices))
+	for _, index := range indices {
+		duties[index] = c.fixture.ProposerDuties[epoch][index]
+	}
+	return duties, nil
+}
+
+// Get whether validators were seen attesting or proposing at the given epoch
+func (c *MockBeaconClient) GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	liveness := make(map[uint64]bool, len(indices))
+	for _, index := range indices {
+		liveness[index] = c.fixture.Liveness[epoch][index]
+	}
+	return liveness, nil
+}
+
+// Get domain data for a domain type at a given epoch
+func (c *MockBeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	return nil, errors.New("the mock Beacon client can't sign anything")
+}
+
+// Perform a voluntary exit on a validator
+func (c *MockBeaconClient) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return errors.New("the mock Beacon client can't submit exits")
+}
+
+// Close the client connection
+func (c *MockBeaconClient) Close() error {
+	return nil
+}
+
+// Get the eth1 data for a Beacon chain block
+func (c *MockBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	data, exists := c.fixture.Eth1Data[blockId]
+	return data, exists, nil
+}
+
+// Get the attestation committees for an epoch, or the head epoch if none is given
+func (c *MockBeaconClient) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
+	targetEpoch := c.fixture.Head.Epoch
+	if epoch != nil {
+		targetEpoch = *epoch
+	}
+	return c.fixture.Committees[targetEpoch], nil
+}
+
+// Change a validator's withdrawal credentials
+func (c *MockBeaconClient) ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return errors.New("the mock Beacon client can't submit credential changes")
+}
+
+// Subscribe to new Beacon chain blocks
+func (c *MockBeaconClient) SubscribeToBlocks(ctx context.Context, handler func(beacon.BlockEvent)) error {
+	// There are no new blocks, so this just waits for the subscription to be cancelled
+	<-ctx.Done()
+	return ctx.Err()
+}
@@ -0,0 +1,648 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The parts of RocketStorage that contract lookups use
+const storageAbi string = `[
+	{"name":"getAddress","type":"function","stateMutability":"view","inputs":[{"name":"_key","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"getString","type":"function","stateMutability":"view","inputs":[{"name":"_key","type":"bytes32"}],"outputs":[{"name":"","type":"string"}]}
+]`
+
+// The default time between blocks, used for block timestamps
+const defaultSecondsPerBlock uint64 = 12
+
+// The contracts a mock Execution client serves, loaded from a JSON fixture.
+// Contracts are registered in RocketStorage under their names, so they can be looked up with rocketpool-go as usual.
+// Block timestamps are derived from the block number, starting at GenesisTime.
+type ContractsFixture struct {
+	StorageAddress  common.Address              `json:"storageAddress"`
+	ChainID         uint64                      `json:"chainId"`
+	BlockNumber     uint64                      `json:"blockNumber"`
+	GenesisTime     uint64                      `json:"genesisTime"`
+	SecondsPerBlock uint64                      `json:"secondsPerBlock"`
+	PeerCount       uint64                      `json:"peerCount"`
+	SyncProgress    *ethereum.SyncProgress      `json:"syncProgress"`
+	Balances        map[common.Address]*big.Int `json:"balances"`
+	Contracts       map[string]ContractFixture  `json:"contracts"`
+}
+
+// A contract in a contracts fixture.
+// Only the parts of the ABI that the calls and events use need to be included. Contracts that are deployed once per
+// minipool or node, like rocketMinipool, only have an ABI in RocketStorage, so their calls are listed by instance instead.
+type ContractFixture struct {
+	Address   common.Address                   `json:"address"`
+	Abi       json.RawMessage                  `json:"abi"`
+	Calls     []CallFixture                    `json:"calls"`
+	Events    []EventFixture                   `json:"events"`
+	Instances map[common.Address][]CallFixture `json:"instances"`
+}
+
+// The response to a contract call.
+// Values are JSON in the natural form for their ABI type: numbers or decimal strings for integers, hex strings for addresses
+// and bytes, and arrays for slices. If the args are left out, the call matches any args.
+type CallFixture struct {
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args"`
+	Result []json.RawMessage `json:"result"`
+}
+
+// An event a contract emitted, with its args keyed by name
+type EventFixture struct {
+	Event       string                     `json:"event"`
+	BlockNumber uint64                     `json:"blockNumber"`
+	Args        map[string]json.RawMessage `json:"args"`
+}
+
+// A contract the mock Execution client serves
+type mockContract struct {
+	name  string
+	abi   *abi.ABI
+	calls []mockCall
+}
+
+// A call response that's been encoded for its method
+type mockCall struct {
+	method *abi.Method
+	args   []byte
+	result []byte
+}
+
+// An Execution client that serves the contracts in a fixture instead of talking to a real client.
+// It's read-only, so anything that would send a transaction returns an error.
+type MockExecutionClient struct {
+	fixture     *ContractsFixture
+	storage     *abi.ABI
+	contracts   map[common.Address]*mockContract
+	addressKeys map[common.Hash]common.Address
+	abiKeys     map[common.Hash]string
+	logs        []types.Log
+}
+
+// Load a contracts fixture from a JSON file
+func LoadContractsFixture(path string) (*ContractsFixture, error) {
+	fixtureBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading contracts fixture [%s]: %w", path, err)
+	}
+
+	fixture := &ContractsFixture{}
+	err = json.Unmarshal(fixtureBytes, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing contracts fixture [%s]: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Create a mock Execution client that serves the contracts in a fixture
+func NewMockExecutionClient(fixture *ContractsFixture) (*MockExecutionClient, error) {
+	storage, err := abi.JSON(strings.NewReader(storageAbi))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing RocketStorage ABI: %w", err)
+	}
+	if fixture.SecondsPerBlock == 0 {
+		fixture.SecondsPerBlock = defaultSecondsPerBlock
+	}
+
+	client := &MockExecutionClient{
+		fixture:     fixture,
+		storage:     &storage,
+		contracts:   map[common.Address]*mockContract{},
+		addressKeys: map[common.Hash]common.Address{},
+		abiKeys:     map[common.Hash]string{},
+		logs:        []types.Log{},
+	}
+
+	// Sort the contract names so the logs are in the same order every time
+	names := make([]string, 0, len(fixture.Contracts))
+	for name := range fixture.Contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err = client.addContract(name, fixture.Contracts[name])
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract %s: %w", name, err)
+		}
+	}
+
+	// Logs are returned in the order they were emitted
+	sort.SliceStable(client.logs, func(i, j int) bool {
+		return client.logs[i].BlockNumber < client.logs[j].BlockNumber
+	})
+	for i := range client.logs {
+		client.logs[i].Index = uint(i)
+	}
+	return client, nil
+}
+
+// Create a mock Execution client from a fixture file
+func NewMockExecutionClientFromFixture(path string) (*MockExecutionClient, error) {
+	fixture, err := LoadContractsFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMockExecutionClient(fixture)
+}
+
+// Create a RocketPool binding that reads its contracts from a fixture file
+func NewRocketPoolFromFixture(path string) (*rocketpool.RocketPool, *MockExecutionClient, error) {
+	client, err := NewMockExecutionClientFromFixture(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	rp, err := rocketpool.NewRocketPool(client, client.fixture.StorageAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating RocketPool binding: %w", err)
+	}
+	return rp, client, nil
+}
+
+// Register a contract, encoding its calls and events
+func (c *MockExecutionClient) addContract(name string, fixture ContractFixture) error {
+	contractAbi, err := abi.JSON(bytes.NewReader(fixture.Abi))
+	if err != nil {
+		return fmt.Errorf("error parsing ABI: %w", err)
+	}
+	encodedAbi, err := rocketpool.EncodeAbiStr(string(fixture.Abi))
+	if err != nil {
+		return fmt.Errorf("error encoding ABI: %w", err)
+	}
+	c.abiKeys[crypto.Keccak256Hash([]byte("contract.abi"), []byte(name))] = encodedAbi
+
+	// Contracts that only have instances aren't registered under their name
+	if fixture.Address != (common.Address{}) {
+		calls, err := encodeCalls(&contractAbi, fixture.Calls)
+		if err != nil {
+			return err
+		}
+		c.contracts[fixture.Address] = &mockContract{
+			name:  name,
+			abi:   &contractAbi,
+			calls: calls,
+		}
+		c.addressKeys[crypto.Keccak256Hash([]byte("contract.address"), []byte(name))] = fixture.Address
+	}
+	for address, callFixtures := range fixture.Instances {
+		calls, err := encodeCalls(&contractAbi, callFixtures)
+		if err != nil {
+			return fmt.Errorf("error loading instance %s: %w", address.Hex(), err)
+		}
+		c.contracts[address] = &mockContract{
+			name:  name,
+			abi:   &contractAbi,
+			calls: calls,
+		}
+	}
+
+	for _, eventFixture := range fixture.Events {
+		log, err := encodeEvent(&contractAbi, fixture.Address, eventFixture)
+		if err != nil {
+			return fmt.Errorf("error encoding %s event: %w", eventFixture.Event, err)
+		}
+		c.logs = append(c.logs, log)
+	}
+	return nil
+}
+
+// Encode the call responses for a contract
+func encodeCalls(contractAbi *abi.ABI, callFixtures []CallFixture) ([]mockCall, error) {
+	calls := make([]mockCall, 0, len(callFixtures))
+	for _, callFixture := range callFixtures {
+		method, exists := contractAbi.Methods[callFixture.Method]
+		if !exists {
+			return nil, fmt.Errorf("method %s isn't in the ABI", callFixture.Method)
+		}
+		call := mockCall{
+			method: &method,
+		}
+		if callFixture.Args != nil {
+			args, err := decodeValues(method.Inputs, callFixture.Args)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding args for %s: %w", method.Name, err)
+			}
+			call.args, err = method.Inputs.Pack(args...)
+			if err != nil {
+				return nil, fmt.Errorf("error packing args for %s: %w", method.Name, err)
+			}
+		}
+		result, err := decodeValues(method.Outputs, callFixture.Result)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding result for %s: %w", method.Name, err)
+		}
+		call.result, err = method.Outputs.Pack(result...)
+		if err != nil {
+			return nil, fmt.Errorf("error packing result for %s: %w", method.Name, err)
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
+
+// Encode an event as a log
+func encodeEvent(contractAbi *abi.ABI, address common.Address, fixture EventFixture) (types.Log, error) {
+	event, exists := contractAbi.Events[fixture.Event]
+	if !exists {
+		return types.Log{}, fmt.Errorf("event isn't in the ABI")
+	}
+
+	topics := []common.Hash{event.ID}
+	nonIndexedValues := []interface{}{}
+	for _, input := range event.Inputs {
+		raw, exists := fixture.Args[input.Name]
+		if !exists {
+			return types.Log{}, fmt.Errorf("arg %s is missing", input.Name)
+		}
+		value, err := decodeValue(input.Type, raw)
+		if err != nil {
+			return types.Log{}, fmt.Errorf("error decoding arg %s: %w", input.Name, err)
+		}
+		if !input.Indexed {
+			nonIndexedValues = append(nonIndexedValues, value)
+			continue
+		}
+		topic, err := abi.MakeTopics([]interface{}{value})
+		if err != nil {
+			return types.Log{}, fmt.Errorf("error encoding arg %s as a topic: %w", input.Name, err)
+		}
+		topics = append(topics, topic[0][0])
+	}
+
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexedValues...)
+	if err != nil {
+		return types.Log{}, fmt.Errorf("error packing args: %w", err)
+	}
+	return types.Log{
+		Address:     address,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: fixture.BlockNumber,
+	}, nil
+}
+
+// Decode a list of JSON values for ABI arguments
+func decodeValues(arguments abi.Arguments, raw []json.RawMessage) ([]interface{}, error) {
+	if len(raw) != len(arguments) {
+		return nil, fmt.Errorf("expected %d values but got %d", len(arguments), len(raw))
+	}
+	values := make([]interface{}, len(arguments))
+	for i, argument := range arguments {
+		value, err := decodeValue(argument.Type, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding value %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Decode a JSON value into the Go type the ABI packer expects for its type
+func decodeValue(abiType abi.Type, raw json.RawMessage) (interface{}, error) {
+	switch abiType.T {
+	case abi.IntTy, abi.UintTy:
+		var number json.Number
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		switch typed := value.(type) {
+		case json.Number:
+			number = typed
+		case string:
+			number = json.Number(typed)
+		default:
+			return nil, fmt.Errorf("%s is not an integer", string(raw))
+		}
+		integer, ok := new(big.Int).SetString(number.String(), 0)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an integer", number.String())
+		}
+		if abiType.Size > 64 {
+			return integer, nil
+		}
+		goValue := reflect.New(abiType.GetType()).Elem()
+		if abiType.T == abi.UintTy {
+			goValue.SetUint(integer.Uint64())
+		} else {
+			goValue.SetInt(integer.Int64())
+		}
+		return goValue.Interface(), nil
+
+	case abi.BoolTy:
+		var value bool
+		err := json.Unmarshal(raw, &value)
+		return value, err
+
+	case abi.StringTy:
+		var value string
+		err := json.Unmarshal(raw, &value)
+		return value, err
+
+	case abi.AddressTy:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("%s is not an address", value)
+		}
+		return common.HexToAddress(value), nil
+
+	case abi.BytesTy, abi.FixedBytesTy:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		data, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if abiType.T == abi.BytesTy {
+			return data, nil
+		}
+		if len(data) != abiType.Size {
+			return nil, fmt.Errorf("expected %d bytes but got %d", abiType.Size, len(data))
+		}
+		goValue := reflect.New(abiType.GetType()).Elem()
+		reflect.Copy(goValue, reflect.ValueOf(data))
+		return goValue.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return nil, err
+		}
+		var goValue reflect.Value
+		if abiType.T == abi.SliceTy {
+			goValue = reflect.MakeSlice(abiType.GetType(), len(elements), len(elements))
+		} else {
+			if len(elements) != abiType.Size {
+				return nil, fmt.Errorf("expected %d elements but got %d", abiType.Size, len(elements))
+			}
+			goValue = reflect.New(abiType.GetType()).Elem()
+		}
+		for i, element := range elements {
+			value, err := decodeValue(*abiType.Elem, element)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding element %d: %w", i, err)
+			}
+			goValue.Index(i).Set(reflect.ValueOf(value))
+		}
+		return goValue.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("type %s isn't supported", abiType.String())
+	}
+}
+
+// Get the header for a block, with a timestamp derived from its number
+func (c *MockExecutionClient) getHeader(number uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Time:       c.fixture.GenesisTime + number*c.fixture.SecondsPerBlock,
+		Difficulty: big.NewInt(0),
+		Extra:      []byte{},
+	}
+}
+
+// Serve a call to RocketStorage's contract lookups
+func (c *MockExecutionClient) callStorage(data []byte) ([]byte, bool, error) {
+	method, err := c.storage.MethodById(data[:4])
+	if err != nil {
+		return nil, false, nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, true, fmt.Errorf("error decoding %s args: %w", method.Name, err)
+	}
+	key := common.Hash(args[0].([32]byte))
+
+	switch method.Name {
+	case "getAddress":
+		result, err := method.Outputs.Pack(c.addressKeys[key])
+		return result, true, err
+	case "getString":
+		result, err := method.Outputs.Pack(c.abiKeys[key])
+		return result, true, err
+	}
+	return nil, false, nil
+}
+
+// CodeAt returns some code for every contract in the fixture, and none for anything else
+func (c *MockExecutionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if _, exists := c.contracts[contract]; exists || contract == c.fixture.StorageAddress {
+		return []byte{0x00}, nil
+	}
+	return []byte{}, nil
+}
+
+// CallContract serves the call response from the fixture that matches the method and args
+func (c *MockExecutionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if call.To == nil {
+		return nil, errors.New("the mock Execution client can't deploy contracts")
+	}
+	if len(call.Data) < 4 {
+		return nil, fmt.Errorf("call to %s has no method selector", call.To.Hex())
+	}
+	if *call.To == c.fixture.StorageAddress {
+		result, handled, err := c.callStorage(call.Data)
+		if handled {
+			return result, err
+		}
+	}
+
+	contract, exists := c.contracts[*call.To]
+	if !exists {
+		return []byte{}, nil
+	}
+	method, err := contract.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("%s doesn't have a method with selector %x in the fixture", contract.name, call.Data[:4])
+	}
+	for _, response := range contract.calls {
+		if response.method.Name != method.Name {
+			continue
+		}
+		if response.args == nil || bytes.Equal(response.args, call.Data[4:]) {
+			return response.result, nil
+		}
+	}
+	return nil, fmt.Errorf("execution reverted: no response for %s.%s with args %x in the fixture", contract.name, method.Name, call.Data[4:])
+}
+
+// HeaderByHash isn't supported, since the mock blocks don't have hashes
+func (c *MockExecutionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return nil, ethereum.NotFound
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (c *MockExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.getHeader(c.fixture.BlockNumber), nil
+	}
+	if !number.IsUint64() || number.Uint64() > c.fixture.BlockNumber {
+		return nil, ethereum.NotFound
+	}
+	return c.getHeader(number.Uint64()), nil
+}
+
+// PendingCodeAt returns the code of the given account in the pending state.
+func (c *MockExecutionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return c.CodeAt(ctx, account, nil)
+}
+
+// PendingNonceAt retrieves the current pending nonce associated with an account.
+func (c *MockExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (c *MockExecutionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
+// a timely execution of a transaction.
+func (c *MockExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// EstimateGas isn't supported, since the mock Execution client can't run transactions
+func (c *MockExecutionClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 0, errors.New("the mock Execution client can't run transactions")
+}
+
+// SendTransaction isn't supported, since the mock Execution client can't run transactions
+func (c *MockExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return errors.New("the mock Execution client can't run transactions")
+}
+
+// FilterLogs returns the events in the fixture that match the filter query, in the order they were emitted
+func (c *MockExecutionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	fromBlock := uint64(0)
+	if query.FromBlock != nil {
+		fromBlock = query.FromBlock.Uint64()
+	}
+	toBlock := c.fixture.BlockNumber
+	if query.ToBlock != nil {
+		toBlock = query.ToBlock.Uint64()
+	}
+
+	logs := []types.Log{}
+	for _, log := range c.logs {
+		if log.BlockNumber < fromBlock || log.BlockNumber > toBlock {
+			continue
+		}
+		if len(query.Addresses) > 0 && !containsAddress(query.Addresses, log.Address) {
+			continue
+		}
+		if !matchesTopics(query.Topics, log.Topics) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// SubscribeFilterLogs isn't supported, since the mock chain never gets new blocks
+func (c *MockExecutionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("the mock Execution client doesn't support subscriptions")
+}
+
+// TransactionReceipt isn't supported, since the mock Execution client can't run transactions
+func (c *MockExecutionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+// BlockNumber returns the block number in the fixture
+func (c *MockExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.fixture.BlockNumber, nil
+}
+
+// BalanceAt returns the balance of an account in the fixture, or zero if it's not there
+func (c *MockExecutionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if balance, exists := c.fixture.Balances[account]; exists {
+		return new(big.Int).Set(balance), nil
+	}
+	return big.NewInt(0), nil
+}
+
+// TransactionByHash isn't supported, since the mock Execution client can't run transactions
+func (c *MockExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return nil, false, ethereum.NotFound
+}
+
+// NonceAt returns the account nonce of the given account.
+func (c *MockExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+// SyncProgress returns the sync progress in the fixture, which is nil if the client isn't syncing
+func (c *MockExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return c.fixture.SyncProgress, nil
+}
+
+// PeerCount returns the number of p2p peers in the fixture
+func (c *MockExecutionClient) PeerCount(ctx context.Context) (uint64, error) {
+	return c.fixture.PeerCount, nil
+}
+
+// ChainID returns the chain ID in the fixture
+func (c *MockExecutionClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(c.fixture.ChainID), nil
+}
+
+// Check if an address is in a list
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, candidate := range addresses {
+		if candidate == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a log's topics match a filter's, where each position is a list of alternatives and an empty list matches anything
+func matchesTopics(filter [][]common.Hash, topics []common.Hash) bool {
+	if len(filter) > len(topics) {
+		return false
+	}
+	for i, alternatives := range filter {
+		if len(alternatives) == 0 {
+			continue
+		}
+		matched := false
+		for _, topic := range alternatives {
+			if topic == topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+// Package harness provides helpers for exercising the node's Prometheus collectors without a live node.
+//
+// Collectors that read from a StateLocker can be driven entirely by a network state fixture (a JSON file
+// with the same contents as a state.NetworkState snapshot), and their output can be compared against a
+// golden file in the Prometheus text exposition format. Downstream forks can use the same helpers to
+// test their own collectors.
+package harness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// A serializable snapshot of the network state, used as a collector fixture.
+// Fixtures use the same format as the snapshots the node daemon persists between restarts.
+type StateFixture = state.Snapshot
+
+// Load a state fixture from a JSON file
+func LoadStateFixture(path string) (*StateFixture, error) {
+	return state.LoadSnapshot(path)
+}
+
+// Save a state fixture to a JSON file, such as one captured from a live node
+func SaveStateFixture(fixture *StateFixture, path string) error {
+	fixtureBytes, err := json.MarshalIndent(fixture, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing state fixture: %w", err)
+	}
+	err = os.WriteFile(path, fixtureBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing state fixture [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Create a state fixture from a network state; fixtures aren't checked against a chain, so they don't record its ID
+func NewStateFixture(networkState *state.NetworkState, totalEffectiveRplStake *big.Int) *StateFixture {
+	return state.NewSnapshot(networkState, totalEffectiveRplStake, 0)
+}
+
+// Create a StateLocker that's already populated with the state from a fixture file
+func NewStateLockerFromFixture(path string) (*collectors.StateLocker, error) {
+	fixture, err := LoadStateFixture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stateLocker := collectors.NewStateLocker()
+	stateLocker.UpdateState(fixture.NetworkState(), fixture.TotalEffectiveRplStake)
+	return stateLocker, nil
+}
+
+// Run a collector and return its output in the Prometheus text exposition format.
+// Metric families and their samples are sorted, so the output is stable across runs.
+func Collect(collector prometheus.Collector) ([]byte, error) {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(collector)
+	if err != nil {
+		return nil, fmt.Errorf("error registering collector: %w", err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("error gathering metrics: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	for _, family := range families {
+		_, err = expfmt.MetricFamilyToText(&buffer, family)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding metric family %s: %w", family.GetName(), err)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// Run a collector and compare its output to a golden file.
+// The values of any masked metrics, such as ones that record the current time, are left out of the comparison.
+// If update is set, the golden file is overwritten with the collector's output instead.
+func CompareGolden(collector prometheus.Collector, goldenPath string, update bool, masked ...string) error {
+	output, err := Collect(collector)
+	if err != nil {
+		return err
+	}
+	return CompareGoldenOutput(MaskValues(output, masked...), goldenPath, update)
+}
+
+// Compare metrics output, such as output from Collect that's had its run-specific values replaced, to a golden file.
+// If update is set, the golden file is overwritten with the output instead.
+func CompareGoldenOutput(output []byte, goldenPath string, update bool) error {
+	if update {
+		err := os.WriteFile(goldenPath, output, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing golden file [%s]: %w", goldenPath, err)
+		}
+		return nil
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("error reading golden file [%s]: %w", goldenPath, err)
+	}
+	if !bytes.Equal(expected, output) {
+		return fmt.Errorf("collector output does not match golden file [%s]\n--- expected ---\n%s\n--- actual ---\n%s", goldenPath, string(expected), string(output))
+	}
+	return nil
+}
+
+// Replace the values of the given metrics in metrics output with a placeholder
+func MaskValues(output []byte, metrics ...string) []byte {
+	for _, metric := range metrics {
+		pattern := regexp.MustCompile(fmt.Sprintf(`(?m)^(%s(\{[^}]*\})?) \S+$`, regexp.QuoteMeta(metric)))
+		output = pattern.ReplaceAll(output, []byte("$1 <masked>"))
+	}
+	return output
+}
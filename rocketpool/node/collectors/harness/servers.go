@@ -0,0 +1,234 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// A JSON-RPC request
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// A JSON-RPC response
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// A JSON-RPC error
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// The call args the Execution client's JSON-RPC API takes
+type rpcCallArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// The filter args the Execution client's JSON-RPC API takes
+type rpcFilterArgs struct {
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses json.RawMessage  `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+// Serve a mock Execution client over HTTP JSON-RPC, for code that connects to a client by its URL.
+// Only the read-only methods the daemon's client checks and contract calls use are supported.
+// The server has to be closed when it's no longer needed.
+func NewMockExecutionServer(client *MockExecutionClient) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var rpcReq rpcRequest
+		if err := json.NewDecoder(request.Body).Decode(&rpcReq); err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := rpcResponse{
+			Version: "2.0",
+			ID:      rpcReq.ID,
+		}
+		result, err := client.serveRpc(request.Context(), rpcReq.Method, rpcReq.Params)
+		if err != nil {
+			response.Error = &rpcError{
+				Code:    -32000,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(response)
+	}))
+}
+
+// Run a JSON-RPC method on the mock Execution client
+func (c *MockExecutionClient) serveRpc(ctx context.Context, method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "eth_chainId":
+		return hexutil.Uint64(c.fixture.ChainID), nil
+
+	case "net_version":
+		return strconv.FormatUint(c.fixture.ChainID, 10), nil
+
+	case "net_peerCount":
+		return hexutil.Uint64(c.fixture.PeerCount), nil
+
+	case "eth_blockNumber":
+		return hexutil.Uint64(c.fixture.BlockNumber), nil
+
+	case "eth_syncing":
+		progress := c.fixture.SyncProgress
+		if progress == nil {
+			return false, nil
+		}
+		return map[string]hexutil.Uint64{
+			"startingBlock": hexutil.Uint64(progress.StartingBlock),
+			"currentBlock":  hexutil.Uint64(progress.CurrentBlock),
+			"highestBlock":  hexutil.Uint64(progress.HighestBlock),
+		}, nil
+
+	case "eth_getBlockByNumber":
+		blockNumber, err := getBlockNumberParam(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		header, err := c.HeaderByNumber(ctx, blockNumber)
+		if err == ethereum.NotFound {
+			return nil, nil
+		}
+		return header, err
+
+	case "eth_getBalance", "eth_getCode":
+		var address common.Address
+		if err := getParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		if method == "eth_getCode" {
+			code, err := c.CodeAt(ctx, address, nil)
+			return hexutil.Bytes(code), err
+		}
+		balance, err := c.BalanceAt(ctx, address, nil)
+		return (*hexutil.Big)(balance), err
+
+	case "eth_call":
+		var args rpcCallArgs
+		if err := getParam(params, 0, &args); err != nil {
+			return nil, err
+		}
+		data := args.Data
+		if len(args.Input) > 0 {
+			data = args.Input
+		}
+		result, err := c.CallContract(ctx, ethereum.CallMsg{From: args.From, To: args.To, Data: data}, nil)
+		return hexutil.Bytes(result), err
+
+	case "eth_getLogs":
+		var args rpcFilterArgs
+		if err := getParam(params, 0, &args); err != nil {
+			return nil, err
+		}
+		query := ethereum.FilterQuery{
+			Topics: args.Topics,
+		}
+		if args.FromBlock != nil && *args.FromBlock >= 0 {
+			query.FromBlock = big.NewInt(args.FromBlock.Int64())
+		}
+		if args.ToBlock != nil && *args.ToBlock >= 0 {
+			query.ToBlock = big.NewInt(args.ToBlock.Int64())
+		}
+		if len(args.Addresses) > 0 && string(args.Addresses) != "null" {
+			var address common.Address
+			if err := json.Unmarshal(args.Addresses, &address); err == nil {
+				query.Addresses = []common.Address{address}
+			} else if err := json.Unmarshal(args.Addresses, &query.Addresses); err != nil {
+				return nil, fmt.Errorf("invalid filter addresses: %w", err)
+			}
+		}
+		return c.FilterLogs(ctx, query)
+	}
+
+	return nil, fmt.Errorf("the method %s does not exist/is not available", method)
+}
+
+// Decode a JSON-RPC param
+func getParam(params []json.RawMessage, index int, value interface{}) error {
+	if index >= len(params) {
+		return fmt.Errorf("missing value for required argument %d", index)
+	}
+	if err := json.Unmarshal(params[index], value); err != nil {
+		return fmt.Errorf("invalid argument %d: %w", index, err)
+	}
+	return nil
+}
+
+// Decode a block number JSON-RPC param, where the latest and pending blocks are nil
+func getBlockNumberParam(params []json.RawMessage, index int) (*big.Int, error) {
+	var blockNumber rpc.BlockNumber
+	if err := getParam(params, index, &blockNumber); err != nil {
+		return nil, err
+	}
+	if blockNumber < 0 {
+		return nil, nil
+	}
+	return big.NewInt(blockNumber.Int64()), nil
+}
+
+// Serve a mock Beacon client over the Beacon node HTTP API, for code that connects to a client by its URL.
+// Only the node status and config endpoints the daemon's client checks use are supported.
+// The server has to be closed when it's no longer needed.
+func NewMockBeaconServer(client *MockBeaconClient) *httptest.Server {
+	fixture := client.fixture
+	mux := http.NewServeMux()
+	serveJson := func(path string, data interface{}) {
+		mux.HandleFunc(path, func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(writer).Encode(map[string]interface{}{"data": data})
+		})
+	}
+
+	serveJson("/eth/v1/node/syncing", map[string]interface{}{
+		"head_slot":     strconv.FormatUint(fixture.SyncStatus.HeadSlot, 10),
+		"sync_distance": strconv.FormatUint(fixture.SyncStatus.SyncDistance, 10),
+		"is_syncing":    fixture.SyncStatus.Syncing,
+	})
+	serveJson("/eth/v1/node/peer_count", map[string]string{
+		"connected": strconv.FormatUint(fixture.PeerCount, 10),
+	})
+	serveJson("/eth/v1/config/spec", map[string]string{
+		"SECONDS_PER_SLOT":                 strconv.FormatUint(fixture.Eth2Config.SecondsPerSlot, 10),
+		"SLOTS_PER_EPOCH":                  strconv.FormatUint(fixture.Eth2Config.SlotsPerEpoch, 10),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": strconv.FormatUint(fixture.Eth2Config.EpochsPerSyncCommitteePeriod, 10),
+	})
+	serveJson("/eth/v1/beacon/genesis", map[string]string{
+		"genesis_time":            strconv.FormatUint(fixture.Eth2Config.GenesisTime, 10),
+		"genesis_fork_version":    hexutil.Encode(fixture.Eth2Config.GenesisForkVersion),
+		"genesis_validators_root": hexutil.Encode(fixture.Eth2Config.GenesisValidatorsRoot),
+	})
+	serveJson("/eth/v1/config/deposit_contract", map[string]string{
+		"chain_id": strconv.FormatUint(fixture.DepositContract.ChainID, 10),
+		"address":  fixture.DepositContract.Address.Hex(),
+	})
+
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,137 @@
+package collectors
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The logger for the errors the collectors run into
+var collectorLog = log.NewErrorLogger("collectors", color.FgRed)
+
+// Shared bookkeeping for the scrape duration and errors of every collector
+var collectorStats = &metaStats{
+	latencies: map[string]float64{},
+	errors:    map[string]float64{},
+	degraded:  map[string]bool{},
+}
+
+// The latest scrape duration, total error count, and degraded status of each collector
+type metaStats struct {
+	latencies map[string]float64
+	errors    map[string]float64
+	degraded  map[string]bool
+	lock      sync.Mutex
+}
+
+// Represents the collector for the health of the other collectors
+type MetaCollector struct {
+	// How long each collector took to run on its latest scrape
+	latency *prometheus.Desc
+
+	// The total number of errors each collector has hit
+	errors *prometheus.Desc
+
+	// Whether each collector could only report some of its metrics on its latest scrape
+	degraded *prometheus.Desc
+}
+
+// Create a new MetaCollector instance
+func NewMetaCollector() *MetaCollector {
+	subsystem := "collector"
+	return &MetaCollector{
+		latency: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_seconds"),
+			"How long each collector took to run on its latest scrape",
+			[]string{"collector"}, nil,
+		),
+		errors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "errors_total"),
+			"The total number of errors each collector has hit",
+			[]string{"collector"}, nil,
+		),
+		degraded: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "degraded"),
+			"Whether each collector could only report some of its metrics on its latest scrape (1 if so, 0 if not)",
+			[]string{"collector"}, nil,
+		),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *MetaCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.latency
+	channel <- collector.errors
+	channel <- collector.degraded
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *MetaCollector) Collect(channel chan<- prometheus.Metric) {
+	collectorStats.lock.Lock()
+	defer collectorStats.lock.Unlock()
+
+	for name, latency := range collectorStats.latencies {
+		channel <- prometheus.MustNewConstMetric(
+			collector.latency, prometheus.GaugeValue, latency, name)
+	}
+	for name, errors := range collectorStats.errors {
+		channel <- prometheus.MustNewConstMetric(
+			collector.errors, prometheus.CounterValue, errors, name)
+	}
+	for name, degraded := range collectorStats.degraded {
+		value := float64(0)
+		if degraded {
+			value = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.degraded, prometheus.GaugeValue, value, name)
+	}
+}
+
+// Record how long a collector took to run; meant to be deferred at the start of Collect()
+func recordCollectorLatency(logPrefix string, start time.Time) {
+	name := getCollectorName(logPrefix)
+	collectorStats.lock.Lock()
+	defer collectorStats.lock.Unlock()
+	collectorStats.latencies[name] = time.Since(start).Seconds()
+	if _, exists := collectorStats.errors[name]; !exists {
+		collectorStats.errors[name] = 0
+	}
+}
+
+// Record an error hit by a collector
+func recordCollectorError(logPrefix string) {
+	name := getCollectorName(logPrefix)
+	collectorStats.lock.Lock()
+	defer collectorStats.lock.Unlock()
+	collectorStats.errors[name]++
+}
+
+// Record whether a collector had to leave out some of its metrics on its latest scrape
+func recordCollectorDegraded(logPrefix string, degraded bool) {
+	name := getCollectorName(logPrefix)
+	collectorStats.lock.Lock()
+	defer collectorStats.lock.Unlock()
+	collectorStats.degraded[name] = degraded
+}
+
+// Get the names of the collectors that left out some of their metrics on their latest scrape
+func getDegradedCollectors() []string {
+	collectorStats.lock.Lock()
+	defer collectorStats.lock.Unlock()
+	names := []string{}
+	for name, degraded := range collectorStats.degraded {
+		if degraded {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Convert a collector's log prefix (e.g. "ODAO Stats Collector") into a label value (e.g. "odao_stats")
+func getCollectorName(logPrefix string) string {
+	name := strings.TrimSuffix(logPrefix, " Collector")
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
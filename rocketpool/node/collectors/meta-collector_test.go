@@ -0,0 +1,34 @@
+package collectors_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors/harness"
+)
+
+func TestMetaCollector(t *testing.T) {
+	// Run a collector that always succeeds, so its health is known
+	stateLocker := newStateLocker(t)
+	if _, err := harness.Collect(collectors.NewStateCollector(stateLocker)); err != nil {
+		t.Fatalf("error collecting state metrics: %s", err.Error())
+	}
+
+	output, err := harness.Collect(collectors.NewMetaCollector())
+	if err != nil {
+		t.Fatalf("error collecting metrics: %s", err.Error())
+	}
+
+	// The other tests' collectors are reported too, so only the state collector's samples are compared
+	var filtered bytes.Buffer
+	for _, line := range bytes.SplitAfter(output, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("#")) || bytes.Contains(line, []byte(`collector="state"`)) {
+			filtered.Write(line)
+		}
+	}
+	output = harness.MaskValues(filtered.Bytes(), "rocketpool_collector_latency_seconds")
+	if err := harness.CompareGoldenOutput(output, goldenPath("meta"), *update); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,198 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons a relay's registration for a validator can be wrong
+const (
+	RelayRegistrationMismatch_Missing      string = "missing"
+	RelayRegistrationMismatch_FeeRecipient string = "fee_recipient"
+	RelayRegistrationMismatch_GasLimit     string = "gas_limit"
+)
+
+// Shared bookkeeping for the MEV-boost relay checks run by the node daemon
+var mevRelayStats = &relayCheckStats{
+	relays: map[string]*relayStats{},
+}
+
+// The results of the relay checks, by relay name
+type relayCheckStats struct {
+	relays map[string]*relayStats
+	lock   sync.Mutex
+}
+
+// The results of the checks for a single relay
+type relayStats struct {
+	up                    bool
+	latency               float64
+	errors                float64
+	registrationsChecked  bool
+	registeredValidators  float64
+	mismatchedValidators  map[string]float64
+	mismatches            map[string]float64
+	lastRegistrationCheck time.Time
+}
+
+// Represents the collector for the MEV-boost relay health metrics
+type MevRelayCollector struct {
+	// Whether each relay responded to its latest status check
+	up *prometheus.Desc
+
+	// How long each relay took to respond to its latest status check
+	latency *prometheus.Desc
+
+	// The number of failed requests to each relay
+	errors *prometheus.Desc
+
+	// The number of the node's validators each relay has a registration for
+	registeredValidators *prometheus.Desc
+
+	// The number of the node's validators with a wrong or missing registration on each relay
+	mismatchedValidators *prometheus.Desc
+
+	// The total number of wrong or missing registrations found on each relay
+	mismatches *prometheus.Desc
+
+	// The time of the latest registration check for each relay
+	lastRegistrationCheck *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new MevRelayCollector instance
+func NewMevRelayCollector() *MevRelayCollector {
+	subsystem := "mev_relay"
+	return &MevRelayCollector{
+		up: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "up"),
+			"Whether the relay responded to its latest status check (1 if it did, 0 if it didn't)",
+			[]string{"relay"}, nil,
+		),
+		latency: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "response_seconds"),
+			"How long the relay took to respond to its latest status check",
+			[]string{"relay"}, nil,
+		),
+		errors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "errors_total"),
+			"The number of failed requests to the relay since the node daemon started",
+			[]string{"relay"}, nil,
+		),
+		registeredValidators: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "registered_validators"),
+			"The number of the node's active validators the relay has a registration for",
+			[]string{"relay"}, nil,
+		),
+		mismatchedValidators: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "registration_mismatched_validators"),
+			"The number of the node's active validators whose registration on the relay is missing or has the wrong settings, by reason",
+			[]string{"relay", "reason"}, nil,
+		),
+		mismatches: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "registration_mismatches_total"),
+			"The number of missing or wrong registrations found on the relay since the node daemon started, by reason",
+			[]string{"relay", "reason"}, nil,
+		),
+		lastRegistrationCheck: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_registration_check_timestamp_seconds"),
+			"The time of the latest registration check for the relay",
+			[]string{"relay"}, nil,
+		),
+		logPrefix: "MEV Relay Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *MevRelayCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.up
+	channel <- collector.latency
+	channel <- collector.errors
+	channel <- collector.registeredValidators
+	channel <- collector.mismatchedValidators
+	channel <- collector.mismatches
+	channel <- collector.lastRegistrationCheck
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *MevRelayCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	mevRelayStats.lock.Lock()
+	defer mevRelayStats.lock.Unlock()
+
+	for name, stats := range mevRelayStats.relays {
+		up := float64(0)
+		if stats.up {
+			up = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.up, prometheus.GaugeValue, up, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latency, prometheus.GaugeValue, stats.latency, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.errors, prometheus.CounterValue, stats.errors, name)
+		for reason, count := range stats.mismatches {
+			channel <- prometheus.MustNewConstMetric(
+				collector.mismatches, prometheus.CounterValue, count, name, reason)
+		}
+
+		// Don't report the registrations until they've been checked
+		if !stats.registrationsChecked {
+			continue
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.registeredValidators, prometheus.GaugeValue, stats.registeredValidators, name)
+		for _, reason := range []string{RelayRegistrationMismatch_Missing, RelayRegistrationMismatch_FeeRecipient, RelayRegistrationMismatch_GasLimit} {
+			channel <- prometheus.MustNewConstMetric(
+				collector.mismatchedValidators, prometheus.GaugeValue, stats.mismatchedValidators[reason], name, reason)
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastRegistrationCheck, prometheus.GaugeValue, float64(stats.lastRegistrationCheck.Unix()), name)
+	}
+}
+
+// Record the result of a relay status check
+func RecordMevRelayStatus(relay string, up bool, latency time.Duration) {
+	mevRelayStats.lock.Lock()
+	defer mevRelayStats.lock.Unlock()
+	stats := getRelayStats(relay)
+	stats.up = up
+	stats.latency = latency.Seconds()
+	if !up {
+		stats.errors++
+	}
+}
+
+// Record a failed registration request to a relay
+func RecordMevRelayError(relay string) {
+	mevRelayStats.lock.Lock()
+	defer mevRelayStats.lock.Unlock()
+	getRelayStats(relay).errors++
+}
+
+// Record the result of checking the node's validator registrations on a relay.
+// Mismatches are the number of validators with each kind of problem.
+func RecordMevRelayRegistrations(relay string, registeredValidators int, mismatches map[string]int) {
+	mevRelayStats.lock.Lock()
+	defer mevRelayStats.lock.Unlock()
+	stats := getRelayStats(relay)
+	stats.registrationsChecked = true
+	stats.registeredValidators = float64(registeredValidators)
+	stats.mismatchedValidators = map[string]float64{}
+	for reason, count := range mismatches {
+		stats.mismatchedValidators[reason] = float64(count)
+		stats.mismatches[reason] += float64(count)
+	}
+	stats.lastRegistrationCheck = time.Now()
+}
+
+// Get the stats for a relay, creating them if they don't exist yet; the caller must hold the lock
+func getRelayStats(relay string) *relayStats {
+	stats, exists := mevRelayStats.relays[relay]
+	if !exists {
+		stats = &relayStats{
+			mismatchedValidators: map[string]float64{},
+			mismatches:           map[string]float64{},
+		}
+		mevRelayStats.relays[relay] = stats
+	}
+	return stats
+}
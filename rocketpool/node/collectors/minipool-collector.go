@@ -0,0 +1,188 @@
+package collectors
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How often to re-estimate the staking APR; it's averaged over a week so it doesn't need to be fresh
+const stakingAprRefreshInterval time.Duration = time.Hour
+
+// Represents the collector for the economics of the node's individual minipools
+type MinipoolCollector struct {
+	// The commission each minipool earns on its borrowed ETH
+	nodeFee *prometheus.Desc
+
+	// The ETH the node deposited into each minipool
+	depositSize *prometheus.Desc
+
+	// The ETH each staking minipool is projected to earn for the node over a year
+	projectedAnnualIncome *prometheus.Desc
+
+	// The recent Beacon chain staking APR the projections are based on
+	stakingApr *prometheus.Desc
+
+	// The tags given to each minipool, one series per tag
+	tag *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The latest staking APR estimate and when it was made
+	cachedStakingApr     float64
+	lastStakingAprUpdate time.Time
+	lock                 sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new MinipoolCollector instance
+func NewMinipoolCollector(rp *rocketpool.RocketPool, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *MinipoolCollector {
+	subsystem := "minipool"
+	return &MinipoolCollector{
+		nodeFee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_fee"),
+			"The commission the minipool earns on its borrowed ETH, as a fraction",
+			[]string{"minipool", "label"}, nil,
+		),
+		depositSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposit_size_eth"),
+			"The amount of ETH the node deposited into the minipool",
+			[]string{"minipool", "label"}, nil,
+		),
+		projectedAnnualIncome: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "projected_annual_income_eth"),
+			"The amount of ETH the staking minipool is projected to earn for the node over a year at the recent staking APR",
+			[]string{"minipool", "label"}, nil,
+		),
+		stakingApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "staking_apr"),
+			"The recent Beacon chain staking APR estimated from the rETH exchange rate, as a fraction",
+			nil, nil,
+		),
+		tag: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "tag"),
+			"Set to 1 for each tag the minipool has been given",
+			[]string{"minipool", "tag"}, nil,
+		),
+		rp:          rp,
+		nodeAddress: nodeAddress,
+		cfg:         cfg,
+		stateLocker: stateLocker,
+		logPrefix:   "Minipool Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *MinipoolCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.nodeFee
+	channel <- collector.depositSize
+	channel <- collector.projectedAnnualIncome
+	channel <- collector.stakingApr
+	channel <- collector.tag
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *MinipoolCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+
+	// Get the minipool labels if they've been enabled; they're left blank otherwise
+	labels := map[common.Address]string{}
+	if collector.cfg.Smartnode.EnableMinipoolLabelsInMetrics.Value == true {
+		book, err := addressbook.Load(collector.cfg.Smartnode.GetMinipoolLabelsPath())
+		if err != nil {
+			collector.logError(err)
+		} else {
+			labels = book.GetLabels()
+		}
+	}
+
+	// Get the minipool tags if they've been enabled
+	tags := map[common.Address][]string{}
+	if collector.cfg.Smartnode.EnableMinipoolTagsInMetrics.Value == true {
+		tagBook, err := addressbook.LoadTags(collector.cfg.Smartnode.GetMinipoolTagsPath())
+		if err != nil {
+			collector.logError(err)
+		} else {
+			tags = tagBook.GetAllTags()
+		}
+	}
+
+	// Get the staking APR; the fees and deposits are still reported without it
+	stakingApr, err := collector.getStakingApr(state.ElBlockNumber, state.NetworkDetails.NodeFee)
+	if err != nil {
+		collector.logError(err)
+	} else {
+		channel <- prometheus.MustNewConstMetric(
+			collector.stakingApr, prometheus.GaugeValue, stakingApr)
+	}
+	recordCollectorDegraded(collector.logPrefix, err != nil)
+
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		address := mpd.MinipoolAddress.Hex()
+		label := labels[mpd.MinipoolAddress]
+		nodeFee := eth.WeiToEth(mpd.NodeFee)
+		depositSize := eth.WeiToEth(mpd.NodeDepositBalance)
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.nodeFee, prometheus.GaugeValue, nodeFee, address, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.depositSize, prometheus.GaugeValue, depositSize, address, label)
+		for _, tag := range tags[mpd.MinipoolAddress] {
+			channel <- prometheus.MustNewConstMetric(
+				collector.tag, prometheus.GaugeValue, 1, address, tag)
+		}
+		if err == nil && mpd.Status == types.Staking {
+			channel <- prometheus.MustNewConstMetric(
+				collector.projectedAnnualIncome, prometheus.GaugeValue, rputils.GetProjectedAnnualIncome(depositSize, nodeFee, stakingApr), address, label)
+		}
+	}
+}
+
+// Get the staking APR, re-estimating it if the cached one is too old
+func (collector *MinipoolCollector) getStakingApr(blockNumber uint64, nodeFee float64) (float64, error) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	if time.Since(collector.lastStakingAprUpdate) < stakingAprRefreshInterval {
+		return collector.cachedStakingApr, nil
+	}
+	stakingApr, err := rputils.GetRecentStakingApr(collector.rp, blockNumber, nodeFee)
+	if err != nil {
+		return 0, fmt.Errorf("Error estimating the staking APR: %w", err)
+	}
+	collector.cachedStakingApr = stakingApr
+	collector.lastStakingAprUpdate = time.Now()
+	return stakingApr, nil
+}
+
+// Log error messages
+func (collector *MinipoolCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -0,0 +1,144 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Shared bookkeeping for the node daemon's minipool performance scoring
+var minipoolPerformanceStats = &minipoolPerformanceScores{}
+
+// The latest performance report the node daemon made, and when it made it
+type minipoolPerformanceScores struct {
+	report     *rputils.MinipoolPerformanceReport
+	lastUpdate time.Time
+	lock       sync.Mutex
+}
+
+// Represents the collector for the minipool performance scores
+type MinipoolPerformanceCollector struct {
+	// The share of the network's attestation duties that were included on chain
+	networkAttestationRate *prometheus.Desc
+
+	// The share of the network's slots that had a block
+	networkProposalRate *prometheus.Desc
+
+	// The share of each minipool's attestation duties that were included on chain
+	attestationRate *prometheus.Desc
+
+	// The number of proposals each minipool was assigned
+	proposalDuties *prometheus.Desc
+
+	// The number of blocks each minipool proposed
+	proposals *prometheus.Desc
+
+	// Each minipool's attestation rate relative to the network's
+	score *prometheus.Desc
+
+	// The last epoch of the scoring window
+	endEpoch *prometheus.Desc
+
+	// When the scores were last updated
+	lastUpdate *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new MinipoolPerformanceCollector instance
+func NewMinipoolPerformanceCollector() *MinipoolPerformanceCollector {
+	subsystem := "minipool_performance"
+	return &MinipoolPerformanceCollector{
+		networkAttestationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "network_attestation_rate"),
+			"The share of the whole network's attestation duties in the scoring window that were included on chain",
+			nil, nil,
+		),
+		networkProposalRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "network_proposal_rate"),
+			"The share of the slots in the scoring window that had a block",
+			nil, nil,
+		),
+		attestationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_rate"),
+			"The share of the minipool's attestation duties in the scoring window that were included on chain",
+			[]string{"minipool"}, nil,
+		),
+		proposalDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposal_duties"),
+			"The number of blocks the minipool was assigned to propose in the scoring window",
+			[]string{"minipool"}, nil,
+		),
+		proposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals"),
+			"The number of blocks the minipool proposed in the scoring window",
+			[]string{"minipool"}, nil,
+		),
+		score: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "score"),
+			"The minipool's attestation rate relative to the network's, where 100 is the network average",
+			[]string{"minipool"}, nil,
+		),
+		endEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "end_epoch"),
+			"The last epoch of the scoring window",
+			nil, nil,
+		),
+		lastUpdate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_update_timestamp"),
+			"The time the minipools were last scored",
+			nil, nil,
+		),
+		logPrefix: "Minipool Performance Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *MinipoolPerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.networkAttestationRate
+	channel <- collector.networkProposalRate
+	channel <- collector.attestationRate
+	channel <- collector.proposalDuties
+	channel <- collector.proposals
+	channel <- collector.score
+	channel <- collector.endEpoch
+	channel <- collector.lastUpdate
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *MinipoolPerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	minipoolPerformanceStats.lock.Lock()
+	defer minipoolPerformanceStats.lock.Unlock()
+
+	// Nothing is reported until the first scoring run finishes
+	report := minipoolPerformanceStats.report
+	if report == nil {
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.networkAttestationRate, prometheus.GaugeValue, report.NetworkAttestationRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.networkProposalRate, prometheus.GaugeValue, report.NetworkProposalRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.endEpoch, prometheus.GaugeValue, float64(report.EndEpoch))
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastUpdate, prometheus.GaugeValue, float64(minipoolPerformanceStats.lastUpdate.Unix()))
+	for _, minipool := range report.Minipools {
+		address := minipool.MinipoolAddress.Hex()
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationRate, prometheus.GaugeValue, minipool.AttestationRate, address)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalDuties, prometheus.GaugeValue, float64(minipool.ProposalDuties), address)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposals, prometheus.GaugeValue, float64(minipool.Proposals), address)
+		channel <- prometheus.MustNewConstMetric(
+			collector.score, prometheus.GaugeValue, minipool.Score, address)
+	}
+}
+
+// Replace the minipool scores with the latest report
+func SetMinipoolPerformance(report rputils.MinipoolPerformanceReport) {
+	minipoolPerformanceStats.lock.Lock()
+	defer minipoolPerformanceStats.lock.Unlock()
+	minipoolPerformanceStats.report = &report
+	minipoolPerformanceStats.lastUpdate = time.Now()
+}
@@ -0,0 +1,109 @@
+package collectors
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Represents the collector for protocol-wide metrics that aren't covered by the demand, performance and supply collectors
+type NetworkCollector struct {
+	// The Deposit Pool balance minus the total capacity of the Minipool queue
+	nodeDemand *prometheus.Desc
+
+	// The recent APR of holding rETH
+	rethApr *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The latest rETH APR estimate and when it was made
+	cachedRethApr     float64
+	lastRethAprUpdate time.Time
+	lock              sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new NetworkCollector instance
+func NewNetworkCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *NetworkCollector {
+	subsystem := "network"
+	return &NetworkCollector{
+		nodeDemand: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_demand"),
+			"The Deposit Pool balance minus the total capacity of the Minipool queue; positive when there's ETH waiting for new minipools",
+			nil, nil,
+		),
+		rethApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "reth_apr"),
+			"The recent APR of holding rETH estimated from the growth of its exchange rate, as a fraction",
+			nil, nil,
+		),
+		rp:          rp,
+		stateLocker: stateLocker,
+		logPrefix:   "Network Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *NetworkCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.nodeDemand
+	channel <- collector.rethApr
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *NetworkCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+
+	nodeDemand := big.NewInt(0).Sub(state.NetworkDetails.DepositPoolBalance, state.NetworkDetails.QueueCapacity.Total)
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeDemand, prometheus.GaugeValue, eth.WeiToEth(nodeDemand))
+
+	// Get the rETH APR; node demand is still reported without it
+	rethApr, err := collector.getRethApr(state.ElBlockNumber)
+	if err != nil {
+		collector.logError(err)
+	} else {
+		channel <- prometheus.MustNewConstMetric(
+			collector.rethApr, prometheus.GaugeValue, rethApr)
+	}
+	recordCollectorDegraded(collector.logPrefix, err != nil)
+}
+
+// Get the rETH APR, re-estimating it if the cached one is too old
+func (collector *NetworkCollector) getRethApr(blockNumber uint64) (float64, error) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	if time.Since(collector.lastRethAprUpdate) < stakingAprRefreshInterval {
+		return collector.cachedRethApr, nil
+	}
+	rethApr, err := rputils.GetRecentRethApr(collector.rp, blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("Error estimating the rETH APR: %w", err)
+	}
+	collector.cachedRethApr = rethApr
+	collector.lastRethAprUpdate = time.Now()
+	return rethApr, nil
+}
+
+// Log error messages
+func (collector *NetworkCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -1,21 +1,24 @@
 package collectors
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -63,33 +66,36 @@ type NodeCollector struct {
 	// The unclaimed ETH rewards from the smoothing pool
 	unclaimedEthRewards *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The number of rewards intervals left out of the rewards above because their tree files are missing
+	missingRewardsIntervals *prometheus.Desc
 
-	// The beacon client
-	bc beacon.Client
+	// The price of ETH and RPL in the configured fiat currency
+	fiatPrice *prometheus.Desc
 
-	// The node's address
-	nodeAddress common.Address
+	// The node's wallet, staked RPL and Beacon Chain balances in the configured fiat currency
+	fiatValue *prometheus.Desc
 
-	// The event log interval for the current eth1 client
-	eventLogInterval *big.Int
+	// Whether the node's RPL is withdrawn to a different address than its ETH
+	rplWithdrawalAddressDiffers *prometheus.Desc
 
-	// The next block to start from when looking at cumulative RPL rewards
-	nextRewardsStartBlock *big.Int
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
 
-	// The cumulative amount of RPL earned
-	cumulativeRewards float64
+	// The beacon client
+	bc beacon.Client
 
-	// The claimed ETH rewards from SP
-	cumulativeClaimedEthRewards float64
+	// The addresses of the nodes to report on; the first one is this node, and the rest are monitored read-only
+	nodeAddresses []common.Address
 
-	// Map of reward intervals that have already been processed
-	handledIntervals map[uint64]bool
+	// The claimed and unclaimed rewards of each node, which are updated in the background
+	rewardsInfo *rprewards.RewardsInfo
 
 	// The Rocket Pool config
 	cfg *config.RocketPoolConfig
 
+	// The source of fiat prices; nil if fiat values are disabled
+	priceSource prices.CurrentPriceSource
+
 	// The thread-safe locker for the network state
 	stateLocker *StateLocker
 
@@ -98,81 +104,90 @@ type NodeCollector struct {
 }
 
 // Create a new NodeCollector instance
-func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
-
-	// Get the event log interval
-	eventLogInterval, err := cfg.GetEventLogInterval()
-	if err != nil {
-		log.Printf("Error getting event log interval: %s\n", err.Error())
-		return nil
-	}
+func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddresses []common.Address, cfg *config.RocketPoolConfig, rewardsInfo *rprewards.RewardsInfo, priceSource prices.CurrentPriceSource, stateLocker *StateLocker) *NodeCollector {
 
 	subsystem := "node"
 	return &NodeCollector{
 		totalStakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total_staked_rpl"),
 			"The total amount of RPL staked on the node",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		effectiveStakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "effective_staked_rpl"),
 			"The effective amount of RPL staked on the node (honoring the 150% collateral cap)",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		rplCollateral: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_collateral"),
 			"The RPL collateral level for the node",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		cumulativeRplRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cumulative_rpl_rewards"),
 			"The cumulative RPL rewards earned by the node",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		expectedRplRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "expected_rpl_rewards"),
 			"The expected RPL rewards for the node at the next rewards checkpoint",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		rplApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_apr"),
 			"The estimated APR of RPL for the node from the next rewards checkpoint",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		balances: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "balance"),
 			"How much ETH is in this node wallet",
-			[]string{"Token"}, nil,
+			[]string{"Token", "node"}, nil,
 		),
 		activeMinipoolCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_minipool_count"),
 			"The number of active minipools owned by the node",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		depositedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposited_eth"),
 			"The amount of ETH this node deposited into minipools",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		beaconShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_share"),
 			"The node's total share of its minipool's beacon chain balances",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		beaconBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_balance"),
 			"The total balances of all this node's validators on the beacon chain",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		unclaimedRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_rewards"),
 			"The RPL rewards from the last period that have not been claimed yet",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		claimedEthRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_eth_rewards"),
 			"The claimed ETH rewards from the smoothing pool",
-			nil, nil,
+			[]string{"node"}, nil,
 		),
 		unclaimedEthRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_eth_rewards"),
 			"The unclaimed ETH rewards from the smoothing pool",
-			nil, nil,
+			[]string{"node"}, nil,
+		),
+		missingRewardsIntervals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missing_rewards_intervals"),
+			"The number of rewards intervals left out of the rewards metrics because their tree files are missing",
+			[]string{"node"}, nil,
+		),
+		fiatPrice: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fiat_price"),
+			"The price of an asset in the configured fiat currency",
+			[]string{"asset", "currency"}, nil,
 		),
-		rp:               rp,
-		bc:               bc,
-		nodeAddress:      nodeAddress,
-		eventLogInterval: big.NewInt(int64(eventLogInterval)),
-		handledIntervals: map[uint64]bool{},
-		cfg:              cfg,
-		stateLocker:      stateLocker,
-		logPrefix:        "Node Collector",
+		fiatValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fiat_value"),
+			"The value of the node's wallet, staked RPL and Beacon Chain balances in the configured fiat currency",
+			[]string{"holding", "currency", "node"}, nil,
+		),
+		rplWithdrawalAddressDiffers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_withdrawal_address_differs"),
+			"1 if the node has an RPL withdrawal address that's different from its withdrawal address, 0 if not",
+			[]string{"node"}, nil,
+		),
+		rp:            rp,
+		bc:            bc,
+		nodeAddresses: nodeAddresses,
+		rewardsInfo:   rewardsInfo,
+		cfg:           cfg,
+		priceSource:   priceSource,
+		stateLocker:   stateLocker,
+		logPrefix:     "Node Collector",
 	}
 }
 
@@ -180,6 +195,7 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 func (collector *NodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.totalStakedRpl
 	channel <- collector.effectiveStakedRpl
+	channel <- collector.rplCollateral
 	channel <- collector.cumulativeRplRewards
 	channel <- collector.expectedRplRewards
 	channel <- collector.rplApr
@@ -187,21 +203,82 @@ func (collector *NodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.activeMinipoolCount
 	channel <- collector.depositedEth
 	channel <- collector.beaconShare
+	channel <- collector.beaconBalance
 	channel <- collector.unclaimedRewards
 	channel <- collector.claimedEthRewards
 	channel <- collector.unclaimedEthRewards
+	channel <- collector.missingRewardsIntervals
+	channel <- collector.fiatPrice
+	channel <- collector.fiatValue
+	channel <- collector.rplWithdrawalAddressDiffers
 }
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
 		return
 	}
 
-	nd := state.NodeDetailsByAddress[collector.nodeAddress]
-	minipools := state.MinipoolDetailsByNode[collector.nodeAddress]
+	// The metrics that need these are left out if they aren't available, rather than all of them
+	degraded := false
+	totalEffectiveStake := collector.stateLocker.GetTotalEffectiveRPLStake()
+	if totalEffectiveStake == nil {
+		degraded = true
+	}
+	var beaconHead *beacon.BeaconHead
+	head, err := collector.bc.GetBeaconHead()
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting beacon chain head: %w", err))
+		degraded = true
+	} else {
+		beaconHead = &head
+	}
+
+	// Get the fiat price of ETH; RPL is priced from it using the protocol's RPL price
+	var ethFiatPrice *float64
+	if collector.priceSource != nil {
+		price, err := collector.priceSource.GetPrice(prices.Asset_ETH)
+		if err != nil {
+			collector.logError(err)
+			degraded = true
+		} else {
+			ethFiatPrice = &price
+			currency := collector.priceSource.GetCurrency()
+			channel <- prometheus.MustNewConstMetric(
+				collector.fiatPrice, prometheus.GaugeValue, price, string(prices.Asset_ETH), currency)
+			channel <- prometheus.MustNewConstMetric(
+				collector.fiatPrice, prometheus.GaugeValue, price*eth.WeiToEth(state.NetworkDetails.RplPrice), string(prices.Asset_RPL), currency)
+		}
+	}
+
+	// Report on each node; one failing doesn't stop the others from being reported
+	for _, nodeAddress := range collector.nodeAddresses {
+		complete, err := collector.collectNode(channel, state, totalEffectiveStake, beaconHead, ethFiatPrice, nodeAddress)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error collecting metrics for node %s: %w", nodeAddress.Hex(), err))
+		}
+		if !complete {
+			degraded = true
+		}
+	}
+	recordCollectorDegraded(collector.logPrefix, degraded)
+}
+
+// Collect the metrics for a single node, returning false if any of them had to be left out.
+// The total effective stake, the Beacon head and the fiat price of ETH can be nil, in which case the metrics that depend on them are skipped.
+func (collector *NodeCollector) collectNode(channel chan<- prometheus.Metric, state *state.NetworkState, totalEffectiveStake *big.Int, beaconHead *beacon.BeaconHead, ethFiatPrice *float64, nodeAddress common.Address) (bool, error) {
+	nd, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return false, fmt.Errorf("the node isn't in the network state yet")
+	}
+	complete := true
+	minipools := state.MinipoolDetailsByNode[nodeAddress]
+	nodeLabel := nodeAddress.Hex()
 
 	// Sync
 	var wg errgroup.Group
@@ -210,7 +287,6 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 	rewardsInterval := state.NetworkDetails.IntervalDuration
 	inflationInterval := state.NetworkDetails.RPLInflationIntervalRate
 	totalRplSupply := state.NetworkDetails.RPLTotalSupply
-	totalEffectiveStake := collector.stateLocker.GetTotalEffectiveRPLStake()
 	nodeOperatorRewardsPercent := eth.WeiToEth(state.NetworkDetails.NodeOperatorRewardsPercent)
 	ethBalance := eth.WeiToEth(nd.BalanceETH)
 	oldRplBalance := eth.WeiToEth(nd.BalanceOldRPL)
@@ -219,109 +295,45 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 	var activeMinipoolCount float64
 	rplPrice := eth.WeiToEth(state.NetworkDetails.RplPrice)
 	collateralRatio := float64(0)
-	var beaconHead beacon.BeaconHead
-	unclaimedEthRewards := float64(0)
-	unclaimedRplRewards := float64(0)
-	if totalEffectiveStake == nil {
-		return
-	}
+	var rplWithdrawalAddressDiffers *float64
 
-	// Get the cumulative claimed and unclaimed RPL rewards
+	// Get the number of active minipools on the node. This is read from the chain rather than the state so minipools
+	// that were just closed drop out right away; the state's count is used if that fails.
 	wg.Go(func() error {
-		//legacyClaimNodeAddress := collector.cfg.Smartnode.GetLegacyClaimNodeAddress()
-		//legacyRewardsPoolAddress := collector.cfg.Smartnode.GetLegacyRewardsPoolAddress()
-
-		// Legacy rewards
-		unclaimedRplWei := big.NewInt(0)
-		unclaimedEthWei := big.NewInt(0)
-		newRewards := big.NewInt(0)
-		newClaimedEthRewards := big.NewInt(0)
-
-		// TODO: PERFORMANCE IMPROVEMENTS
-		/*newRewards, err := legacyrewards.CalculateLifetimeNodeRewards(collector.rp, collector.nodeAddress, collector.eventLogInterval, collector.nextRewardsStartBlock, &legacyRewardsPoolAddress, &legacyClaimNodeAddress)
-		if err != nil {
-			return fmt.Errorf("Error getting cumulative RPL rewards: %w", err)
-		}*/
-
-		// Get the claimed and unclaimed intervals
-		unclaimed, claimed, err := rprewards.GetClaimStatus(collector.rp, collector.nodeAddress)
-		if err != nil {
-			return err
+		minipoolCount, err := minipool.GetNodeActiveMinipoolCount(collector.rp, nodeAddress, nil)
+		if err == nil {
+			activeMinipoolCount = float64(minipoolCount)
+			return nil
 		}
-
-		// Get the info for each claimed interval
-		for _, claimedInterval := range claimed {
-			_, exists := collector.handledIntervals[claimedInterval]
-			if !exists {
-				intervalInfo, err := rprewards.GetIntervalInfo(collector.rp, collector.cfg, collector.nodeAddress, claimedInterval)
-				if err != nil {
-					return err
-				}
-				if !intervalInfo.TreeFileExists {
-					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
-				}
-
-				newRewards.Add(newRewards, &intervalInfo.CollateralRplAmount.Int)
-				newClaimedEthRewards.Add(newClaimedEthRewards, &intervalInfo.SmoothingPoolEthAmount.Int)
-				collector.handledIntervals[claimedInterval] = true
-			}
-		}
-		// Get the unclaimed rewards
-		for _, unclaimedInterval := range unclaimed {
-			intervalInfo, err := rprewards.GetIntervalInfo(collector.rp, collector.cfg, collector.nodeAddress, unclaimedInterval)
-			if err != nil {
-				return err
-			}
-			if !intervalInfo.TreeFileExists {
-				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
-			}
-			if intervalInfo.NodeExists {
-				unclaimedRplWei.Add(unclaimedRplWei, &intervalInfo.CollateralRplAmount.Int)
-				unclaimedEthWei.Add(unclaimedEthWei, &intervalInfo.SmoothingPoolEthAmount.Int)
-			}
-		}
-
-		// Get the block for the next rewards checkpoint
-		header, err := collector.rp.Client.HeaderByNumber(context.Background(), nil)
-		if err != nil {
-			return fmt.Errorf("Error getting latest block header: %w", err)
-		}
-
-		collector.cumulativeRewards += eth.WeiToEth(newRewards)
-		collector.cumulativeClaimedEthRewards += eth.WeiToEth(newClaimedEthRewards)
-		unclaimedRplRewards = eth.WeiToEth(unclaimedRplWei)
-		unclaimedEthRewards = eth.WeiToEth(unclaimedEthWei)
-		collector.nextRewardsStartBlock = big.NewInt(0).Add(header.Number, big.NewInt(1))
-
-		return nil
-	})
-
-	// Get the number of active minipools on the node
-	wg.Go(func() error {
-		minipoolCount := len(minipools)
+		collector.logError(fmt.Errorf("Error getting active minipool count for node %s: %w", nodeAddress.Hex(), err))
+		stateCount := len(minipools)
 		for _, mpd := range minipools {
 			if mpd.Finalised {
-				minipoolCount--
+				stateCount--
 			}
 		}
-		activeMinipoolCount = float64(minipoolCount)
+		activeMinipoolCount = float64(stateCount)
 		return nil
 	})
 
-	// Get the beacon head
+	// Check if the node's RPL goes to a different address than its ETH; the metric is left out if this can't be read
 	wg.Go(func() error {
-		_beaconHead, err := collector.bc.GetBeaconHead()
+		rplWithdrawalAddress, isSet, err := rputils.GetNodeRplWithdrawalAddress(collector.rp, nodeAddress, nil)
 		if err != nil {
-			return fmt.Errorf("Error getting beacon chain head: %w", err)
+			collector.logError(fmt.Errorf("Error getting RPL withdrawal address for node %s: %w", nodeAddress.Hex(), err))
+			return nil
+		}
+		differs := float64(0)
+		if isSet && rplWithdrawalAddress != nd.WithdrawalAddress {
+			differs = 1
 		}
-		beaconHead = _beaconHead
+		rplWithdrawalAddressDiffers = &differs
 		return nil
 	})
 
 	// Wait for data
 	if err := wg.Wait(); err != nil {
-		collector.logError(err)
-		return
+		return false, err
 	}
 
 	// Calculate the estimated rewards
@@ -332,7 +344,7 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 		totalRplAtNextCheckpoint = 0
 	}
 	estimatedRewards := float64(0)
-	if totalEffectiveStake.Cmp(big.NewInt(0)) == 1 {
+	if totalEffectiveStake != nil && totalEffectiveStake.Cmp(big.NewInt(0)) == 1 {
 		estimatedRewards = effectiveStakedRpl / eth.WeiToEth(totalEffectiveStake) * totalRplAtNextCheckpoint * nodeOperatorRewardsPercent
 	}
 
@@ -344,62 +356,103 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 		collateralRatio = rplPrice * stakedRpl / (activeMinipoolCount * 16.0)
 	}
 
-	// Calculate the total deposits and corresponding beacon chain balance share
-	opts := &bind.CallOpts{
-		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
-	}
-	minipoolDetails, err := eth2.GetBeaconBalancesFromState(collector.rp, minipools, state, beaconHead, opts)
-	if err != nil {
-		collector.logError(err)
-		return
-	}
-	totalDepositBalance := float64(0)
-	totalNodeShare := float64(0)
-	totalBeaconBalance := float64(0)
-	for _, minipool := range minipoolDetails {
-		totalDepositBalance += eth.WeiToEth(minipool.NodeDeposit)
-		totalNodeShare += eth.WeiToEth(minipool.NodeBalance)
-		totalBeaconBalance += eth.WeiToEth(minipool.TotalBalance)
-	}
-
-	// Update all the metrics
+	// Update the metrics that only need the network state
 	channel <- prometheus.MustNewConstMetric(
-		collector.totalStakedRpl, prometheus.GaugeValue, stakedRpl)
+		collector.totalStakedRpl, prometheus.GaugeValue, stakedRpl, nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.effectiveStakedRpl, prometheus.GaugeValue, effectiveStakedRpl)
+		collector.effectiveStakedRpl, prometheus.GaugeValue, effectiveStakedRpl, nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.rplCollateral, prometheus.GaugeValue, collateralRatio)
-	channel <- prometheus.MustNewConstMetric(
-		collector.cumulativeRplRewards, prometheus.GaugeValue, collector.cumulativeRewards)
-	channel <- prometheus.MustNewConstMetric(
-		collector.expectedRplRewards, prometheus.GaugeValue, estimatedRewards)
-	channel <- prometheus.MustNewConstMetric(
-		collector.rplApr, prometheus.GaugeValue, rplApr)
-	channel <- prometheus.MustNewConstMetric(
-		collector.balances, prometheus.GaugeValue, ethBalance, "ETH")
-	channel <- prometheus.MustNewConstMetric(
-		collector.balances, prometheus.GaugeValue, oldRplBalance, "Legacy RPL")
-	channel <- prometheus.MustNewConstMetric(
-		collector.balances, prometheus.GaugeValue, newRplBalance, "New RPL")
-	channel <- prometheus.MustNewConstMetric(
-		collector.balances, prometheus.GaugeValue, rethBalance, "rETH")
-	channel <- prometheus.MustNewConstMetric(
-		collector.activeMinipoolCount, prometheus.GaugeValue, activeMinipoolCount)
-	channel <- prometheus.MustNewConstMetric(
-		collector.depositedEth, prometheus.GaugeValue, totalDepositBalance)
+		collector.rplCollateral, prometheus.GaugeValue, collateralRatio, nodeLabel)
+	if totalEffectiveStake != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.expectedRplRewards, prometheus.GaugeValue, estimatedRewards, nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.rplApr, prometheus.GaugeValue, rplApr, nodeLabel)
+	} else {
+		complete = false
+	}
 	channel <- prometheus.MustNewConstMetric(
-		collector.beaconShare, prometheus.GaugeValue, totalNodeShare)
+		collector.balances, prometheus.GaugeValue, ethBalance, "ETH", nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.beaconBalance, prometheus.GaugeValue, totalBeaconBalance)
+		collector.balances, prometheus.GaugeValue, oldRplBalance, "Legacy RPL", nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.unclaimedRewards, prometheus.GaugeValue, unclaimedRplRewards)
+		collector.balances, prometheus.GaugeValue, newRplBalance, "New RPL", nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.unclaimedEthRewards, prometheus.GaugeValue, unclaimedEthRewards)
+		collector.balances, prometheus.GaugeValue, rethBalance, "rETH", nodeLabel)
 	channel <- prometheus.MustNewConstMetric(
-		collector.claimedEthRewards, prometheus.GaugeValue, collector.cumulativeClaimedEthRewards)
+		collector.activeMinipoolCount, prometheus.GaugeValue, activeMinipoolCount, nodeLabel)
+	if rplWithdrawalAddressDiffers != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.rplWithdrawalAddressDiffers, prometheus.GaugeValue, *rplWithdrawalAddressDiffers, nodeLabel)
+	} else {
+		complete = false
+	}
+	if ethFiatPrice != nil {
+		currency := collector.priceSource.GetCurrency()
+		rplFiatPrice := *ethFiatPrice * rplPrice
+		channel <- prometheus.MustNewConstMetric(
+			collector.fiatValue, prometheus.GaugeValue, *ethFiatPrice*ethBalance, "wallet_eth", currency, nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.fiatValue, prometheus.GaugeValue, (oldRplBalance+newRplBalance)*rplFiatPrice, "wallet_rpl", currency, nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.fiatValue, prometheus.GaugeValue, stakedRpl*rplFiatPrice, "staked_rpl", currency, nodeLabel)
+	}
+
+	// Calculate the total deposits and corresponding beacon chain balance share
+	if beaconHead != nil {
+		opts := &bind.CallOpts{
+			BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+		}
+		minipoolDetails, err := eth2.GetBeaconBalancesFromState(collector.rp, minipools, state, *beaconHead, opts)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error getting Beacon balances for node %s: %w", nodeLabel, err))
+			complete = false
+		} else {
+			totalDepositBalance := float64(0)
+			totalNodeShare := float64(0)
+			totalBeaconBalance := float64(0)
+			for _, minipool := range minipoolDetails {
+				totalDepositBalance += eth.WeiToEth(minipool.NodeDeposit)
+				totalNodeShare += eth.WeiToEth(minipool.NodeBalance)
+				totalBeaconBalance += eth.WeiToEth(minipool.TotalBalance)
+			}
+			channel <- prometheus.MustNewConstMetric(
+				collector.depositedEth, prometheus.GaugeValue, totalDepositBalance, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.beaconShare, prometheus.GaugeValue, totalNodeShare, nodeLabel)
+			channel <- prometheus.MustNewConstMetric(
+				collector.beaconBalance, prometheus.GaugeValue, totalBeaconBalance, nodeLabel)
+			if ethFiatPrice != nil {
+				currency := collector.priceSource.GetCurrency()
+				channel <- prometheus.MustNewConstMetric(
+					collector.fiatValue, prometheus.GaugeValue, *ethFiatPrice*totalNodeShare, "beacon_share", currency, nodeLabel)
+				channel <- prometheus.MustNewConstMetric(
+					collector.fiatValue, prometheus.GaugeValue, *ethFiatPrice*totalBeaconBalance, "beacon_balance", currency, nodeLabel)
+			}
+		}
+	} else {
+		complete = false
+	}
+
+	// Report the rewards once they've been calculated; they're left out until then rather than reported as zero
+	rewards := collector.rewardsInfo.GetNodeRewards(nodeAddress)
+	if rewards != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.cumulativeRplRewards, prometheus.GaugeValue, eth.WeiToEth(rewards.CumulativeRpl), nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.unclaimedRewards, prometheus.GaugeValue, eth.WeiToEth(rewards.UnclaimedRpl), nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.unclaimedEthRewards, prometheus.GaugeValue, eth.WeiToEth(rewards.UnclaimedEth), nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.claimedEthRewards, prometheus.GaugeValue, eth.WeiToEth(rewards.CumulativeEth), nodeLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missingRewardsIntervals, prometheus.GaugeValue, float64(len(rewards.MissingIntervals)), nodeLabel)
+	}
+	return complete, nil
 }
 
 // Log error messages
 func (collector *NodeCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
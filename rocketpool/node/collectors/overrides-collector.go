@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Represents the collector for the contract address override metrics
+type OverridesCollector struct {
+	// Whether or not any contract address overrides are active
+	overridesActive *prometheus.Desc
+
+	// The contracts that have been overridden and the addresses they point to
+	overriddenContract *prometheus.Desc
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new OverridesCollector instance
+func NewOverridesCollector(cfg *config.RocketPoolConfig) *OverridesCollector {
+	subsystem := "overrides"
+	return &OverridesCollector{
+		overridesActive: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Whether or not the node is running with contract address overrides (1 if so, 0 if not)",
+			nil, nil,
+		),
+		overriddenContract: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "contract"),
+			"A contract whose address has been overridden in the node's config",
+			[]string{"contract", "address"}, nil,
+		),
+		cfg:       cfg,
+		logPrefix: "Overrides Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *OverridesCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.overridesActive
+	channel <- collector.overriddenContract
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *OverridesCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	overrides, err := collector.cfg.Smartnode.GetContractAddressOverrides()
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+
+	overridesActive := float64(0)
+	if len(overrides) > 0 {
+		overridesActive = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.overridesActive, prometheus.GaugeValue, overridesActive)
+
+	for name, address := range overrides {
+		channel <- prometheus.MustNewConstMetric(
+			collector.overriddenContract, prometheus.GaugeValue, 1, name, address.Hex())
+	}
+}
+
+// Log error messages
+func (collector *OverridesCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -84,6 +84,8 @@ func (collector *PerformanceCollector) Describe(channel chan<- *prometheus.Desc)
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *PerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
@@ -113,5 +115,6 @@ func (collector *PerformanceCollector) Collect(channel chan<- prometheus.Metric)
 
 // Log error messages
 func (collector *PerformanceCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
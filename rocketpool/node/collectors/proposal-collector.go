@@ -0,0 +1,119 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the proposals detected on the node daemon's block stream
+var proposalStats = &blockProposalStats{}
+
+// The proposals by the node's validators that the node daemon has seen since it started
+type blockProposalStats struct {
+	streamConnected bool
+	count           float64
+	lastSlot        float64
+	lastTime        float64
+	lastDelay       float64
+	lock            sync.Mutex
+}
+
+// Represents the collector for the proposals detected on the Beacon block stream
+type ProposalCollector struct {
+	// Whether the node daemon is currently receiving blocks from the Beacon node's event stream
+	streamConnected *prometheus.Desc
+
+	// The number of blocks proposed by the node's validators
+	proposals *prometheus.Desc
+
+	// The slot of the most recent proposal
+	lastSlot *prometheus.Desc
+
+	// The time the most recent proposal was detected
+	lastTime *prometheus.Desc
+
+	// How long after the start of its slot the most recent proposal was detected
+	lastDelay *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ProposalCollector instance
+func NewProposalCollector() *ProposalCollector {
+	subsystem := "proposals"
+	return &ProposalCollector{
+		streamConnected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "block_stream_connected"),
+			"Whether the node daemon is receiving new blocks from the Beacon node's event stream (1) or not (0)",
+			nil, nil,
+		),
+		proposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "detected_total"),
+			"The number of blocks proposed by the node's validators that the node daemon has seen since it started",
+			nil, nil,
+		),
+		lastSlot: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_slot"),
+			"The slot of the most recent block proposed by one of the node's validators",
+			nil, nil,
+		),
+		lastTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_detected_timestamp"),
+			"The Unix timestamp when the most recent proposal by one of the node's validators was detected",
+			nil, nil,
+		),
+		lastDelay: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_detection_delay_seconds"),
+			"How long after the start of its slot the most recent proposal by one of the node's validators was detected",
+			nil, nil,
+		),
+		logPrefix: "Proposal Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ProposalCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.streamConnected
+	channel <- collector.proposals
+	channel <- collector.lastSlot
+	channel <- collector.lastTime
+	channel <- collector.lastDelay
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ProposalCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	proposalStats.lock.Lock()
+	defer proposalStats.lock.Unlock()
+
+	streamConnected := float64(0)
+	if proposalStats.streamConnected {
+		streamConnected = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.streamConnected, prometheus.GaugeValue, streamConnected)
+	channel <- prometheus.MustNewConstMetric(
+		collector.proposals, prometheus.CounterValue, proposalStats.count)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastSlot, prometheus.GaugeValue, proposalStats.lastSlot)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastTime, prometheus.GaugeValue, proposalStats.lastTime)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastDelay, prometheus.GaugeValue, proposalStats.lastDelay)
+}
+
+// Record whether the node daemon is receiving blocks from the Beacon node's event stream
+func RecordBlockStreamStatus(connected bool) {
+	proposalStats.lock.Lock()
+	defer proposalStats.lock.Unlock()
+	proposalStats.streamConnected = connected
+}
+
+// Record that one of the node's validators proposed a block
+func RecordProposal(slot uint64, detectionDelay time.Duration) {
+	proposalStats.lock.Lock()
+	defer proposalStats.lock.Unlock()
+	proposalStats.count++
+	proposalStats.lastSlot = float64(slot)
+	proposalStats.lastTime = float64(time.Now().Unix())
+	proposalStats.lastDelay = detectionDelay.Seconds()
+}
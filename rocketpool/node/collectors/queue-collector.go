@@ -0,0 +1,217 @@
+package collectors
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How often to re-estimate the Deposit Pool's inflow rate; it's averaged over a week so it doesn't need to be fresh
+const depositInflowRefreshInterval time.Duration = time.Hour
+
+// Represents the collector for the Deposit Pool inflow and the positions of the node's minipools in the queue
+type QueueCollector struct {
+	// The number of minipools waiting in the queue
+	queueLength *prometheus.Desc
+
+	// The ETH deposited into the Deposit Pool per day, averaged over the last week
+	depositInflowRate *prometheus.Desc
+
+	// The position of each of the node's queued minipools, starting at 1
+	position *prometheus.Desc
+
+	// The ETH the minipools ahead of each of the node's queued minipools still need
+	ethAhead *prometheus.Desc
+
+	// The estimated time until each of the node's queued minipools is assigned
+	estimatedAssignmentTime *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The latest inflow rate estimate and when it was made
+	cachedDepositInflowRate     float64
+	lastDepositInflowRateUpdate time.Time
+	lock                        sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new QueueCollector instance
+func NewQueueCollector(rp *rocketpool.RocketPool, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *QueueCollector {
+	subsystem := "queue"
+	return &QueueCollector{
+		queueLength: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "length"),
+			"The number of minipools waiting in the queue for ETH from the Deposit Pool",
+			nil, nil,
+		),
+		depositInflowRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposit_inflow_eth_per_day"),
+			"The amount of ETH deposited into the Deposit Pool per day, averaged over the last week",
+			nil, nil,
+		),
+		position: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_position"),
+			"The position of the node's minipool in the queue, where 1 is the next to be assigned",
+			[]string{"minipool", "label"}, nil,
+		),
+		ethAhead: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_eth_ahead"),
+			"The amount of ETH the minipools ahead of the node's minipool in the queue still need, estimated from the queue's average capacity per minipool",
+			[]string{"minipool", "label"}, nil,
+		),
+		estimatedAssignmentTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_estimated_assignment_seconds"),
+			"The estimated number of seconds until the node's minipool is assigned ETH from the Deposit Pool, based on the recent inflow rate",
+			[]string{"minipool", "label"}, nil,
+		),
+		rp:          rp,
+		nodeAddress: nodeAddress,
+		cfg:         cfg,
+		stateLocker: stateLocker,
+		logPrefix:   "Queue Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *QueueCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.queueLength
+	channel <- collector.depositInflowRate
+	channel <- collector.position
+	channel <- collector.ethAhead
+	channel <- collector.estimatedAssignmentTime
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *QueueCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	complete := true
+
+	// Get the inflow rate; the queue positions are still reported without it
+	inflowRate, inflowErr := collector.getDepositInflowRate(state.ElBlockNumber)
+	if inflowErr != nil {
+		collector.logError(inflowErr)
+		complete = false
+	} else {
+		channel <- prometheus.MustNewConstMetric(
+			collector.depositInflowRate, prometheus.GaugeValue, inflowRate)
+	}
+
+	// Get the queue length
+	length, err := minipool.GetQueueTotalLength(collector.rp, opts)
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting the minipool queue length: %w", err))
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.queueLength, prometheus.GaugeValue, float64(length))
+	if length == 0 {
+		recordCollectorDegraded(collector.logPrefix, !complete)
+		return
+	}
+
+	// Each minipool's share of the queue's capacity, which is what the ones ahead are assumed to need
+	averageCapacity := eth.WeiToEth(state.NetworkDetails.QueueCapacity.Total) / float64(length)
+	depositPoolBalance := eth.WeiToEth(state.NetworkDetails.DepositPoolBalance)
+
+	// Get the minipool labels if they've been enabled; they're left blank otherwise
+	labels := map[common.Address]string{}
+	if collector.cfg.Smartnode.EnableMinipoolLabelsInMetrics.Value == true {
+		book, err := addressbook.Load(collector.cfg.Smartnode.GetMinipoolLabelsPath())
+		if err != nil {
+			collector.logError(err)
+		} else {
+			labels = book.GetLabels()
+		}
+	}
+
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		if mpd.Status != types.Initialized || mpd.Finalised {
+			continue
+		}
+		position, err := minipool.GetQueuePositionOfMinipool(collector.rp, mpd.MinipoolAddress, opts)
+		if err != nil {
+			collector.logError(err)
+			complete = false
+			continue
+		}
+		if position < 1 {
+			continue
+		}
+		address := mpd.MinipoolAddress.Hex()
+		label := labels[mpd.MinipoolAddress]
+		ethAhead := averageCapacity * float64(position-1)
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.position, prometheus.GaugeValue, float64(position), address, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.ethAhead, prometheus.GaugeValue, ethAhead, address, label)
+
+		// The minipool is assigned once the Deposit Pool has enough for it and everything ahead of it
+		if inflowErr != nil {
+			continue
+		}
+		shortfall := ethAhead + averageCapacity - depositPoolBalance
+		if shortfall <= 0 {
+			channel <- prometheus.MustNewConstMetric(
+				collector.estimatedAssignmentTime, prometheus.GaugeValue, 0, address, label)
+		} else if inflowRate > 0 {
+			seconds := shortfall / inflowRate * (24 * time.Hour).Seconds()
+			channel <- prometheus.MustNewConstMetric(
+				collector.estimatedAssignmentTime, prometheus.GaugeValue, seconds, address, label)
+		}
+	}
+	recordCollectorDegraded(collector.logPrefix, !complete)
+}
+
+// Get the Deposit Pool's inflow rate, re-estimating it if the cached one is too old
+func (collector *QueueCollector) getDepositInflowRate(blockNumber uint64) (float64, error) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	if time.Since(collector.lastDepositInflowRateUpdate) < depositInflowRefreshInterval {
+		return collector.cachedDepositInflowRate, nil
+	}
+	inflowRate, err := rputils.GetRecentDepositInflowRate(collector.rp, blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("Error estimating the Deposit Pool inflow rate: %w", err)
+	}
+	collector.cachedDepositInflowRate = inflowRate
+	collector.lastDepositInflowRateUpdate = time.Now()
+	return inflowRate, nil
+}
+
+// Log error messages
+func (collector *QueueCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Shared bookkeeping for the refunds sent by the node daemon
+var refundStats = &minipoolRefundStats{}
+
+// The refunds the node daemon has sent since it started
+type minipoolRefundStats struct {
+	count float64
+	eth   float64
+	lock  sync.Mutex
+}
+
+// Represents the collector for the automatic minipool refund metrics
+type RefundCollector struct {
+	// The number of minipools the node daemon has refunded
+	refunds *prometheus.Desc
+
+	// The total amount of ETH the node daemon has refunded
+	refundedEth *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new RefundCollector instance
+func NewRefundCollector() *RefundCollector {
+	subsystem := "auto_refund"
+	return &RefundCollector{
+		refunds: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "refunds_total"),
+			"The number of minipools the node daemon has automatically refunded since it started",
+			nil, nil,
+		),
+		refundedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "swept_eth_total"),
+			"The total amount of ETH the node daemon has automatically refunded from minipools since it started",
+			nil, nil,
+		),
+		logPrefix: "Refund Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RefundCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.refunds
+	channel <- collector.refundedEth
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RefundCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	refundStats.lock.Lock()
+	defer refundStats.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.refunds, prometheus.CounterValue, refundStats.count)
+	channel <- prometheus.MustNewConstMetric(
+		collector.refundedEth, prometheus.CounterValue, refundStats.eth)
+}
+
+// Record that the node daemon refunded a minipool
+func RecordMinipoolRefund(amount *big.Int) {
+	refundStats.lock.Lock()
+	defer refundStats.lock.Unlock()
+	refundStats.count++
+	refundStats.eth += eth.WeiToEth(amount)
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -73,9 +73,12 @@ func (collector *RplCollector) Describe(channel chan<- *prometheus.Desc) {
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *RplCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
 		return
 	}
 
@@ -85,21 +88,24 @@ func (collector *RplCollector) Collect(channel chan<- prometheus.Metric) {
 	lastCheckpoint := state.NetworkDetails.IntervalStart
 	rewardsInterval := state.NetworkDetails.IntervalDuration
 	nextRewardsTime := float64(lastCheckpoint.Add(rewardsInterval).Unix()) * 1000
-	if totalEffectiveStake == nil {
-		return
-	}
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.rplPrice, prometheus.GaugeValue, rplPriceFloat)
 	channel <- prometheus.MustNewConstMetric(
 		collector.totalValueStaked, prometheus.GaugeValue, totalValueStakedFloat)
-	channel <- prometheus.MustNewConstMetric(
-		collector.totalEffectiveStaked, prometheus.GaugeValue, eth.WeiToEth(totalEffectiveStake))
 	channel <- prometheus.MustNewConstMetric(
 		collector.checkpointTime, prometheus.GaugeValue, nextRewardsTime)
+
+	// The total effective stake isn't calculated on every state update, so it may not be available yet
+	if totalEffectiveStake != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.totalEffectiveStaked, prometheus.GaugeValue, eth.WeiToEth(totalEffectiveStake))
+	}
+	recordCollectorDegraded(collector.logPrefix, totalEffectiveStake == nil)
 }
 
 // Log error messages
 func (collector *RplCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
@@ -0,0 +1,77 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the node daemon's safe mode status
+var safeModeStats = &daemonSafeModeStats{}
+
+// The safe mode status of the node daemon, determined at startup
+type daemonSafeModeStats struct {
+	isSafeMode     bool
+	recentRestarts float64
+	lock           sync.Mutex
+}
+
+// Represents the collector for the node daemon's safe mode status
+type SafeModeCollector struct {
+	// Whether or not the node daemon is running in safe mode
+	safeMode *prometheus.Desc
+
+	// The number of times the node daemon restarted within the crash loop window before it started
+	recentRestarts *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new SafeModeCollector instance
+func NewSafeModeCollector() *SafeModeCollector {
+	subsystem := "daemon"
+	return &SafeModeCollector{
+		safeMode: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "safe_mode"),
+			"Whether or not the node daemon is running in safe mode with its automatic tasks disabled (1 if it is, 0 if it isn't)",
+			nil, nil,
+		),
+		recentRestarts: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "recent_restarts"),
+			"The number of times the node daemon restarted shortly before its latest start",
+			nil, nil,
+		),
+		logPrefix: "Safe Mode Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SafeModeCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.safeMode
+	channel <- collector.recentRestarts
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SafeModeCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	safeModeStats.lock.Lock()
+	defer safeModeStats.lock.Unlock()
+
+	safeMode := float64(0)
+	if safeModeStats.isSafeMode {
+		safeMode = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.safeMode, prometheus.GaugeValue, safeMode)
+	channel <- prometheus.MustNewConstMetric(
+		collector.recentRestarts, prometheus.GaugeValue, safeModeStats.recentRestarts)
+}
+
+// Record the node daemon's safe mode status
+func RecordSafeMode(isSafeMode bool, recentRestarts int) {
+	safeModeStats.lock.Lock()
+	defer safeModeStats.lock.Unlock()
+	safeModeStats.isSafeMode = isSafeMode
+	safeModeStats.recentRestarts = float64(recentRestarts)
+}
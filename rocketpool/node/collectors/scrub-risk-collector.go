@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the node daemon's scrub-risk self-check
+var scrubRiskStats = &minipoolScrubRiskStats{
+	risks: map[common.Address]map[string]bool{},
+}
+
+// The prelaunch minipools that failed the scrub-risk self-check, and the stakes it has blocked
+type minipoolScrubRiskStats struct {
+	risks         map[common.Address]map[string]bool
+	blockedStakes float64
+	lock          sync.Mutex
+}
+
+// Represents the collector for the scrub-risk self-check metrics
+type ScrubRiskCollector struct {
+	// Whether a prelaunch minipool failed one of the checks
+	riskDetected *prometheus.Desc
+
+	// The number of prelaunch minipools that failed at least one check
+	minipoolsAtRisk *prometheus.Desc
+
+	// The number of stake transactions the self-check has blocked
+	blockedStakes *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ScrubRiskCollector instance
+func NewScrubRiskCollector() *ScrubRiskCollector {
+	subsystem := "scrub_risk"
+	return &ScrubRiskCollector{
+		riskDetected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "detected"),
+			"1 if a prelaunch minipool's deposit data failed one of the checks the Oracle DAO uses to scrub minipools",
+			[]string{"minipool", "check"}, nil,
+		),
+		minipoolsAtRisk: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipools"),
+			"The number of prelaunch minipools whose deposit data failed at least one scrub check",
+			nil, nil,
+		),
+		blockedStakes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "blocked_stakes_total"),
+			"The number of stake transactions the node daemon has refused to send since it started because of a failed scrub check",
+			nil, nil,
+		),
+		logPrefix: "Scrub Risk Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ScrubRiskCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.riskDetected
+	channel <- collector.minipoolsAtRisk
+	channel <- collector.blockedStakes
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ScrubRiskCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	scrubRiskStats.lock.Lock()
+	defer scrubRiskStats.lock.Unlock()
+
+	for minipool, checks := range scrubRiskStats.risks {
+		for check := range checks {
+			channel <- prometheus.MustNewConstMetric(
+				collector.riskDetected, prometheus.GaugeValue, 1, minipool.Hex(), check)
+		}
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.minipoolsAtRisk, prometheus.GaugeValue, float64(len(scrubRiskStats.risks)))
+	channel <- prometheus.MustNewConstMetric(
+		collector.blockedStakes, prometheus.CounterValue, scrubRiskStats.blockedStakes)
+}
+
+// Replace the failed checks of the node's prelaunch minipools with the results of the latest self-check
+func SetScrubRisks(risks map[common.Address][]string) {
+	scrubRiskStats.lock.Lock()
+	defer scrubRiskStats.lock.Unlock()
+	scrubRiskStats.risks = map[common.Address]map[string]bool{}
+	for minipool, checks := range risks {
+		addScrubRisks(minipool, checks)
+	}
+}
+
+// Record that the node daemon refused to stake a minipool because it failed some of the checks
+func RecordScrubRiskBlockedStake(minipool common.Address, checks []string) {
+	scrubRiskStats.lock.Lock()
+	defer scrubRiskStats.lock.Unlock()
+	addScrubRisks(minipool, checks)
+	scrubRiskStats.blockedStakes++
+}
+
+// Add failed checks for a minipool; the lock must be held
+func addScrubRisks(minipool common.Address, checks []string) {
+	if len(checks) == 0 {
+		return
+	}
+	minipoolChecks, exists := scrubRiskStats.risks[minipool]
+	if !exists {
+		minipoolChecks = map[string]bool{}
+		scrubRiskStats.risks[minipool] = minipoolChecks
+	}
+	for _, check := range checks {
+		minipoolChecks[check] = true
+	}
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -49,6 +49,8 @@ func (collector *SmoothingPoolCollector) Describe(channel chan<- *prometheus.Des
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *SmoothingPoolCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
@@ -63,5 +65,6 @@ func (collector *SmoothingPoolCollector) Collect(channel chan<- prometheus.Metri
 
 // Log error messages
 func (collector *SmoothingPoolCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
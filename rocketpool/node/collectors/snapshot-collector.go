@@ -106,6 +106,7 @@ func (collector *SnapshotCollector) Describe(channel chan<- *prometheus.Desc) {
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *SnapshotCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
 
 	// Sync
 	var wg errgroup.Group
@@ -216,5 +217,6 @@ func (collector *SnapshotCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *SnapshotCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the freshness of the node daemon's network state
+type StateCollector struct {
+	// Whether or not the network state was restored from a snapshot and hasn't been refreshed yet
+	stale *prometheus.Desc
+
+	// The Beacon slot the network state was taken at
+	beaconSlot *prometheus.Desc
+
+	// The EL block the network state was taken at
+	elBlock *prometheus.Desc
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new StateCollector instance
+func NewStateCollector(stateLocker *StateLocker) *StateCollector {
+	subsystem := "state"
+	return &StateCollector{
+		stale: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "stale"),
+			"Whether or not the network state was restored from disk after a restart and hasn't been refreshed yet (1 if it is, 0 if it isn't)",
+			nil, nil,
+		),
+		beaconSlot: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_slot"),
+			"The Beacon slot the network state was taken at",
+			nil, nil,
+		),
+		elBlock: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "el_block"),
+			"The EL block the network state was taken at",
+			nil, nil,
+		),
+		stateLocker: stateLocker,
+		logPrefix:   "State Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *StateCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.stale
+	channel <- collector.beaconSlot
+	channel <- collector.elBlock
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *StateCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	stale := float64(0)
+	if collector.stateLocker.IsStale() {
+		stale = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.stale, prometheus.GaugeValue, stale)
+	channel <- prometheus.MustNewConstMetric(
+		collector.beaconSlot, prometheus.GaugeValue, float64(state.BeaconSlotNumber))
+	channel <- prometheus.MustNewConstMetric(
+		collector.elBlock, prometheus.GaugeValue, float64(state.ElBlockNumber))
+}
@@ -1,6 +1,7 @@
 package collectors
 
 import (
+	"fmt"
 	"math/big"
 	"sync"
 
@@ -11,6 +12,12 @@ type StateLocker struct {
 	state               *state.NetworkState
 	totalEffectiveStake *big.Int
 
+	// True if the state was restored from a snapshot and hasn't been refreshed since
+	stale bool
+
+	// The EL block of the state that was last saved to or restored from a snapshot
+	snapshotBlock uint64
+
 	// Internal fields
 	lock *sync.Mutex
 }
@@ -28,6 +35,7 @@ func (l *StateLocker) UpdateState(state *state.NetworkState, totalEffectiveStake
 	if totalEffectiveStake != nil {
 		l.totalEffectiveStake = totalEffectiveStake
 	}
+	l.stale = false
 }
 
 func (l *StateLocker) GetState() *state.NetworkState {
@@ -41,3 +49,54 @@ func (l *StateLocker) GetTotalEffectiveRPLStake() *big.Int {
 	defer l.lock.Unlock()
 	return l.totalEffectiveStake
 }
+
+// Check if the current state was restored from a snapshot and hasn't been refreshed yet
+func (l *StateLocker) IsStale() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.stale
+}
+
+// Restore the state from a snapshot saved by a previous run; it's marked stale until the next update.
+// Snapshots from a different chain, such as one saved before the node switched networks, are rejected.
+func (l *StateLocker) LoadSnapshot(path string, chainID uint) (*state.Snapshot, error) {
+	snapshot, err := state.LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.ChainID != chainID {
+		return nil, fmt.Errorf("the snapshot is from chain %d, but the node is on chain %d", snapshot.ChainID, chainID)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.state = snapshot.NetworkState()
+	l.totalEffectiveStake = snapshot.TotalEffectiveRplStake
+	l.stale = true
+	l.snapshotBlock = snapshot.ElBlockNumber
+	return snapshot, nil
+}
+
+// Save the current state to a snapshot so it can be restored after a restart
+func (l *StateLocker) SaveSnapshot(path string, chainID uint) error {
+	l.lock.Lock()
+	networkState := l.state
+	totalEffectiveStake := l.totalEffectiveStake
+	stale := l.stale
+	snapshotBlock := l.snapshotBlock
+	l.lock.Unlock()
+
+	// Don't overwrite a snapshot with itself, or rewrite it when the state hasn't moved to a new block
+	if networkState == nil || stale || networkState.ElBlockNumber == snapshotBlock {
+		return nil
+	}
+	err := state.NewSnapshot(networkState, totalEffectiveStake, chainID).Save(path)
+	if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	l.snapshotBlock = networkState.ElBlockNumber
+	l.lock.Unlock()
+	return nil
+}
@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -78,13 +79,16 @@ func (collector *SupplyCollector) Describe(channel chan<- *prometheus.Desc) {
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
 	// Get the latest state
 	state := collector.stateLocker.GetState()
 	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
 		return
 	}
 
-	// Sync
+	// Sync; the counts are looked up separately so one failing doesn't stop the other from being reported
 	var wg errgroup.Group
 	nodeCount := float64(-1)
 	nodeFee := state.NetworkDetails.NodeFee
@@ -93,12 +97,14 @@ func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
 	stakingCount := float64(-1)
 	dissolvedCount := float64(-1)
 	finalizedCount := float64(-1)
+	var nodeCountErr, minipoolCountErr error
 
 	// Get total number of Rocket Pool nodes
 	wg.Go(func() error {
 		nodeCountUint, err := node.GetNodeCount(collector.rp, nil)
 		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool nodes: %w", err)
+			nodeCountErr = fmt.Errorf("Error getting total number of Rocket Pool nodes: %w", err)
+			return nil
 		}
 
 		nodeCount = float64(nodeCountUint)
@@ -109,54 +115,64 @@ func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
 	wg.Go(func() error {
 		minipoolCounts, err := minipool.GetMinipoolCountPerStatus(collector.rp, nil)
 		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
+			minipoolCountErr = fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
+			return nil
+		}
+		finalizedCountUint, err := minipool.GetFinalisedMinipoolCount(collector.rp, nil)
+		if err != nil {
+			minipoolCountErr = fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
+			return nil
 		}
 
 		initializedCount = float64(minipoolCounts.Initialized.Uint64())
 		prelaunchCount = float64(minipoolCounts.Prelaunch.Uint64())
 		stakingCount = float64(minipoolCounts.Staking.Uint64())
 		dissolvedCount = float64(minipoolCounts.Dissolved.Uint64())
-
-		finalizedCountUint, err := minipool.GetFinalisedMinipoolCount(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
-		}
-
 		finalizedCount = float64(finalizedCountUint)
 		return nil
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
-		collector.logError(err)
-		return
-	}
+	wg.Wait()
 
-	channel <- prometheus.MustNewConstMetric(
-		collector.nodeCount, prometheus.GaugeValue, nodeCount)
 	channel <- prometheus.MustNewConstMetric(
 		collector.nodeFee, prometheus.GaugeValue, nodeFee)
-	channel <- prometheus.MustNewConstMetric(
-		collector.minipoolCount, prometheus.GaugeValue, initializedCount, "initialized")
-	channel <- prometheus.MustNewConstMetric(
-		collector.minipoolCount, prometheus.GaugeValue, prelaunchCount, "prelaunch")
-	channel <- prometheus.MustNewConstMetric(
-		collector.minipoolCount, prometheus.GaugeValue, stakingCount, "staking")
-	channel <- prometheus.MustNewConstMetric(
-		collector.minipoolCount, prometheus.GaugeValue, dissolvedCount, "dissolved")
-	channel <- prometheus.MustNewConstMetric(
-		collector.minipoolCount, prometheus.GaugeValue, finalizedCount, "finalized")
 
-	// Set the total and active count
-	totalMinipoolCount := initializedCount + prelaunchCount + stakingCount + dissolvedCount + finalizedCount
-	activeMinipoolCount := totalMinipoolCount - finalizedCount
-	channel <- prometheus.MustNewConstMetric(
-		collector.totalMinipools, prometheus.GaugeValue, totalMinipoolCount)
-	channel <- prometheus.MustNewConstMetric(
-		collector.activeMinipools, prometheus.GaugeValue, activeMinipoolCount)
+	if nodeCountErr == nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.nodeCount, prometheus.GaugeValue, nodeCount)
+	} else {
+		collector.logError(nodeCountErr)
+	}
+
+	if minipoolCountErr == nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCount, prometheus.GaugeValue, initializedCount, "initialized")
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCount, prometheus.GaugeValue, prelaunchCount, "prelaunch")
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCount, prometheus.GaugeValue, stakingCount, "staking")
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCount, prometheus.GaugeValue, dissolvedCount, "dissolved")
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCount, prometheus.GaugeValue, finalizedCount, "finalized")
+
+		// Set the total and active count
+		totalMinipoolCount := initializedCount + prelaunchCount + stakingCount + dissolvedCount + finalizedCount
+		activeMinipoolCount := totalMinipoolCount - finalizedCount
+		channel <- prometheus.MustNewConstMetric(
+			collector.totalMinipools, prometheus.GaugeValue, totalMinipoolCount)
+		channel <- prometheus.MustNewConstMetric(
+			collector.activeMinipools, prometheus.GaugeValue, activeMinipoolCount)
+	} else {
+		collector.logError(minipoolCountErr)
+	}
+
+	recordCollectorDegraded(collector.logPrefix, nodeCountErr != nil || minipoolCountErr != nil)
 }
 
 // Log error messages
 func (collector *SupplyCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
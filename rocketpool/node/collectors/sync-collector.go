@@ -0,0 +1,250 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings
+const (
+	// How far back the sync rate is measured over when estimating the time left
+	syncRateWindow = 10 * time.Minute
+
+	syncClient_Execution string = "execution"
+	syncClient_Consensus string = "consensus"
+)
+
+// A point in a client's sync, used to work out how quickly it's catching up
+type syncSample struct {
+	time      time.Time
+	remaining float64
+}
+
+// Represents the collector for the sync progress of the Execution and Consensus clients
+type SyncCollector struct {
+	// How far through its sync each client is
+	progress *prometheus.Desc
+
+	// The latest block or slot each client has
+	head *prometheus.Desc
+
+	// The block or slot the network is at
+	networkHead *prometheus.Desc
+
+	// The number of peers each client is connected to
+	peers *prometheus.Desc
+
+	// The estimated time until each client is synced
+	eta *prometheus.Desc
+
+	// The Execution client manager
+	ec *services.ExecutionClientManager
+
+	// The Beacon client manager
+	bc *services.BeaconClientManager
+
+	// The Beacon config, loaded on the first collection
+	eth2Config *beacon.Eth2Config
+
+	// The recent progress of each client
+	samples map[string][]syncSample
+
+	// Prefix for logging
+	logPrefix string
+
+	// Internal fields
+	lock *sync.Mutex
+}
+
+// Create a new SyncCollector instance
+func NewSyncCollector(ec *services.ExecutionClientManager, bc *services.BeaconClientManager) *SyncCollector {
+	subsystem := "sync"
+	return &SyncCollector{
+		progress: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "progress_percent"),
+			"How far through its sync the client is, as a percentage",
+			[]string{"client"}, nil,
+		),
+		head: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "head"),
+			"The latest block (execution) or slot (consensus) the client has",
+			[]string{"client"}, nil,
+		),
+		networkHead: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "network_head"),
+			"The block (execution) or slot (consensus) the network is currently at",
+			[]string{"client"}, nil,
+		),
+		peers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "peers"),
+			"The number of peers the client is connected to",
+			[]string{"client"}, nil,
+		),
+		eta: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eta_seconds"),
+			"The estimated time until the client is synced, based on how quickly it has caught up recently; 0 once it's synced",
+			[]string{"client"}, nil,
+		),
+		ec:        ec,
+		bc:        bc,
+		samples:   map[string][]syncSample{},
+		logPrefix: "Sync Collector",
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SyncCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.progress
+	channel <- collector.head
+	channel <- collector.networkHead
+	channel <- collector.peers
+	channel <- collector.eta
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SyncCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Prometheus can collect in parallel, but the samples have to be taken one at a time
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Each client is reported on its own so one being down doesn't hide the other
+	degraded := false
+	if err := collector.collectExecution(channel); err != nil {
+		collector.logError(fmt.Errorf("Error getting Execution client sync status: %w", err))
+		degraded = true
+	}
+	if err := collector.collectConsensus(channel); err != nil {
+		collector.logError(fmt.Errorf("Error getting Consensus client sync status: %w", err))
+		degraded = true
+	}
+	recordCollectorDegraded(collector.logPrefix, degraded)
+}
+
+// Report the Execution client's sync progress
+func (collector *SyncCollector) collectExecution(channel chan<- prometheus.Metric) error {
+	progress, err := collector.ec.SyncProgress(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// A client that isn't syncing doesn't report its progress, so its head is the network's
+	var head, networkHead uint64
+	if progress == nil {
+		head, err = collector.ec.BlockNumber(context.Background())
+		if err != nil {
+			return err
+		}
+		networkHead = head
+	} else {
+		head = progress.CurrentBlock
+		networkHead = progress.HighestBlock
+		if networkHead < head {
+			networkHead = head
+		}
+	}
+
+	peers, err := collector.ec.PeerCount(context.Background())
+	if err != nil {
+		return err
+	}
+
+	collector.sendMetrics(channel, syncClient_Execution, head, networkHead, peers, progress != nil)
+	return nil
+}
+
+// Report the Consensus client's sync progress
+func (collector *SyncCollector) collectConsensus(channel chan<- prometheus.Metric) error {
+	if collector.eth2Config == nil {
+		eth2Config, err := collector.bc.GetEth2Config()
+		if err != nil {
+			return err
+		}
+		collector.eth2Config = &eth2Config
+	}
+	status, err := collector.bc.GetSyncStatus()
+	if err != nil {
+		return err
+	}
+	peers, err := collector.bc.GetPeerCount()
+	if err != nil {
+		return err
+	}
+
+	// The network is at the slot for the current time, even if the last few of them were empty
+	networkHead := status.HeadSlot + status.SyncDistance
+	secondsSinceGenesis := time.Now().Unix() - int64(collector.eth2Config.GenesisTime)
+	if secondsSinceGenesis > 0 && collector.eth2Config.SecondsPerSlot > 0 {
+		wallClockSlot := uint64(secondsSinceGenesis) / collector.eth2Config.SecondsPerSlot
+		if wallClockSlot > networkHead {
+			networkHead = wallClockSlot
+		}
+	}
+
+	collector.sendMetrics(channel, syncClient_Consensus, status.HeadSlot, networkHead, peers, status.Syncing)
+	return nil
+}
+
+// Record a client's progress and send its metrics
+func (collector *SyncCollector) sendMetrics(channel chan<- prometheus.Metric, client string, head uint64, networkHead uint64, peers uint64, syncing bool) {
+	progress := float64(100)
+	if syncing && networkHead > 0 {
+		progress = float64(head) / float64(networkHead) * 100
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.progress, prometheus.GaugeValue, progress, client)
+	channel <- prometheus.MustNewConstMetric(
+		collector.head, prometheus.GaugeValue, float64(head), client)
+	channel <- prometheus.MustNewConstMetric(
+		collector.networkHead, prometheus.GaugeValue, float64(networkHead), client)
+	channel <- prometheus.MustNewConstMetric(
+		collector.peers, prometheus.GaugeValue, float64(peers), client)
+
+	// The ETA is left out until there's enough progress to estimate it from
+	if !syncing {
+		delete(collector.samples, client)
+		channel <- prometheus.MustNewConstMetric(
+			collector.eta, prometheus.GaugeValue, 0, client)
+		return
+	}
+	eta, ok := collector.estimateTimeToSync(client, float64(networkHead-head))
+	if ok {
+		channel <- prometheus.MustNewConstMetric(
+			collector.eta, prometheus.GaugeValue, eta.Seconds(), client)
+	}
+}
+
+// Add a sample of how far behind a client is, and estimate how long it will take to catch up from how quickly
+// that has been shrinking over the rate window. Returns false if it isn't catching up.
+func (collector *SyncCollector) estimateTimeToSync(client string, remaining float64) (time.Duration, bool) {
+	now := time.Now()
+	samples := append(collector.samples[client], syncSample{
+		time:      now,
+		remaining: remaining,
+	})
+	for len(samples) > 1 && now.Sub(samples[0].time) > syncRateWindow {
+		samples = samples[1:]
+	}
+	collector.samples[client] = samples
+
+	oldest := samples[0]
+	elapsed := now.Sub(oldest.time).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	rate := (oldest.remaining - remaining) / elapsed
+	if rate <= 0 {
+		return 0, false
+	}
+	return time.Duration(remaining / rate * float64(time.Second)), true
+}
+
+// Log error messages
+func (collector *SyncCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
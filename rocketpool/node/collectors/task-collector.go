@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Shared bookkeeping for the node daemon's periodic tasks
+var taskStats = &daemonTaskStats{
+	tasks: map[string]*taskRunStats{},
+}
+
+// The run history of every periodic task
+type daemonTaskStats struct {
+	tasks map[string]*taskRunStats
+	lock  sync.Mutex
+}
+
+// The run history of a single periodic task
+type taskRunStats struct {
+	lastRun             float64
+	lastSuccess         float64
+	lastDuration        float64
+	consecutiveFailures float64
+	runs                float64
+	failures            float64
+}
+
+// Represents the collector for the health of the node daemon's task loop
+type TaskCollector struct {
+	// The time each task last finished running
+	lastRun *prometheus.Desc
+
+	// The time each task last finished without an error
+	lastSuccess *prometheus.Desc
+
+	// How long each task took on its latest run
+	lastDuration *prometheus.Desc
+
+	// The number of times in a row each task has failed
+	consecutiveFailures *prometheus.Desc
+
+	// The total number of times each task has run
+	runs *prometheus.Desc
+
+	// The total number of times each task has failed
+	failures *prometheus.Desc
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new TaskCollector instance
+func NewTaskCollector() *TaskCollector {
+	subsystem := "task"
+	labels := []string{"task"}
+	return &TaskCollector{
+		lastRun: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+			"The Unix time each of the node daemon's tasks last finished running",
+			labels, nil,
+		),
+		lastSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_success_timestamp_seconds"),
+			"The Unix time each of the node daemon's tasks last finished without an error, or 0 if it hasn't succeeded yet",
+			labels, nil,
+		),
+		lastDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_duration_seconds"),
+			"How long each of the node daemon's tasks took on its latest run",
+			labels, nil,
+		),
+		consecutiveFailures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consecutive_failures"),
+			"The number of times in a row each of the node daemon's tasks has failed",
+			labels, nil,
+		),
+		runs: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "runs_total"),
+			"The total number of times each of the node daemon's tasks has run since the daemon started",
+			labels, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The total number of times each of the node daemon's tasks has failed since the daemon started",
+			labels, nil,
+		),
+		logPrefix: "Task Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TaskCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.lastRun
+	channel <- collector.lastSuccess
+	channel <- collector.lastDuration
+	channel <- collector.consecutiveFailures
+	channel <- collector.runs
+	channel <- collector.failures
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TaskCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	taskStats.lock.Lock()
+	defer taskStats.lock.Unlock()
+
+	for name, stats := range taskStats.tasks {
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastRun, prometheus.GaugeValue, stats.lastRun, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastSuccess, prometheus.GaugeValue, stats.lastSuccess, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastDuration, prometheus.GaugeValue, stats.lastDuration, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.consecutiveFailures, prometheus.GaugeValue, stats.consecutiveFailures, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.runs, prometheus.CounterValue, stats.runs, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failures, prometheus.CounterValue, stats.failures, name)
+	}
+}
+
+// Record the result of one of the node daemon's tasks
+func RecordTaskRun(name string, start time.Time, err error) {
+	now := time.Now()
+	taskStats.lock.Lock()
+	defer taskStats.lock.Unlock()
+
+	stats, exists := taskStats.tasks[name]
+	if !exists {
+		stats = &taskRunStats{}
+		taskStats.tasks[name] = stats
+	}
+	stats.lastRun = float64(now.Unix())
+	stats.lastDuration = now.Sub(start).Seconds()
+	stats.runs++
+	if err != nil {
+		stats.consecutiveFailures++
+		stats.failures++
+	} else {
+		stats.consecutiveFailures = 0
+		stats.lastSuccess = float64(now.Unix())
+	}
+}
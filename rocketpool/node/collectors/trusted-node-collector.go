@@ -2,8 +2,6 @@ package collectors
 
 import (
 	"fmt"
-	"log"
-	"math/big"
 	"strconv"
 	"sync"
 	"time"
@@ -58,9 +56,6 @@ type TrustedNodeCollector struct {
 	cacheTime     time.Time
 	cachedMetrics []prometheus.Metric
 
-	// The event log interval for the current eth1 client
-	eventLogInterval *big.Int
-
 	// The thread-safe locker for the network state
 	stateLocker *StateLocker
 
@@ -71,13 +66,6 @@ type TrustedNodeCollector struct {
 // Create a new NodeCollector instance
 func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *TrustedNodeCollector {
 
-	// Get the event log interval
-	eventLogInterval, err := cfg.GetEventLogInterval()
-	if err != nil {
-		log.Printf("Error getting event log interval: %s\n", err.Error())
-		return nil
-	}
-
 	subsystem := "trusted_node"
 	return &TrustedNodeCollector{
 		proposalCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposal_count"),
@@ -104,13 +92,12 @@ func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAd
 			"Whether each member has participated in the current prices update interval",
 			[]string{"member"}, nil,
 		),
-		enabled:          cfg.EnableODaoMetrics.Value.(bool),
-		rp:               rp,
-		bc:               bc,
-		nodeAddress:      nodeAddress,
-		eventLogInterval: big.NewInt(int64(eventLogInterval)),
-		stateLocker:      stateLocker,
-		logPrefix:        "ODAO Stats Collector",
+		enabled:     cfg.EnableODaoMetrics.Value.(bool),
+		rp:          rp,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+		logPrefix:   "ODAO Stats Collector",
 	}
 }
 
@@ -187,6 +174,7 @@ func (collector *TrustedNodeCollector) collectSlowMetrics(memberIds map[common.A
 
 // Collect the latest metric values and pass them to Prometheus
 func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
 
 	if !collector.enabled {
 		return
@@ -230,19 +218,20 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 		return nil
 	})
 
-	// Only collect fresh participation metrics from chain every 60 seconds as it updates infrequently and takes longer to collect
-	now := time.Now()
-	if now.Unix() > collector.cacheTime.Add(time.Second*60).Unix() {
-		collector.collectSlowMetrics(memberIds)
-		collector.cacheTime = now
-	}
-
 	// Wait for data
 	if err := wg.Wait(); err != nil {
 		collector.logError(err)
 		return
 	}
 
+	// Only collect fresh participation metrics from chain every 60 seconds as it updates infrequently and takes longer to collect.
+	// This needs the member IDs for its labels, so it has to wait for them.
+	now := time.Now()
+	if now.Unix() > collector.cacheTime.Add(time.Second*60).Unix() {
+		collector.collectSlowMetrics(memberIds)
+		collector.cacheTime = now
+	}
+
 	lock := sync.Mutex{}
 
 	// Get member ETH balances
@@ -336,5 +325,6 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 
 // Log error messages
 func (collector *TrustedNodeCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
 }
@@ -0,0 +1,174 @@
+package collectors
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Epoch used by the Beacon chain for stages that haven't been scheduled yet
+const farFutureEpoch uint64 = math.MaxUint64
+
+// Shared bookkeeping for the validator status transitions seen by the node daemon
+var validatorTransitions = &transitionStats{
+	counts: map[transitionKey]float64{},
+}
+
+// A transition from one Beacon status to another
+type transitionKey struct {
+	from string
+	to   string
+}
+
+// The total number of each kind of validator status transition
+type transitionStats struct {
+	counts map[transitionKey]float64
+	lock   sync.Mutex
+}
+
+// Represents the collector for the Beacon status of the node's validators
+type ValidatorStatusCollector struct {
+	// The number of the node's validators in each Beacon status
+	statusCount *prometheus.Desc
+
+	// The number of times one of the node's validators has changed Beacon status
+	transitions *prometheus.Desc
+
+	// The epoch at which each validator will reach its next lifecycle stage
+	nextStageEpoch *prometheus.Desc
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ValidatorStatusCollector instance
+func NewValidatorStatusCollector(nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *ValidatorStatusCollector {
+	subsystem := "validator_status"
+	return &ValidatorStatusCollector{
+		statusCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "count"),
+			"The number of the node's validators in each Beacon status",
+			[]string{"status"}, nil,
+		),
+		transitions: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transitions_total"),
+			"The number of times one of the node's validators has changed Beacon status since the daemon started",
+			[]string{"from", "to"}, nil,
+		),
+		nextStageEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "next_stage_epoch"),
+			"The epoch at which the validator will reach its next lifecycle stage",
+			[]string{"minipool", "stage", "label"}, nil,
+		),
+		nodeAddress: nodeAddress,
+		cfg:         cfg,
+		stateLocker: stateLocker,
+		logPrefix:   "Validator Status Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ValidatorStatusCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.statusCount
+	channel <- collector.transitions
+	channel <- collector.nextStageEpoch
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ValidatorStatusCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Report the transitions even if the state isn't ready yet
+	validatorTransitions.lock.Lock()
+	for key, count := range validatorTransitions.counts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.transitions, prometheus.CounterValue, count, key.from, key.to)
+	}
+	validatorTransitions.lock.Unlock()
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	// Get the minipool labels if they've been enabled; they're left blank otherwise
+	labels := map[common.Address]string{}
+	if collector.cfg.Smartnode.EnableMinipoolLabelsInMetrics.Value == true {
+		book, err := addressbook.Load(collector.cfg.Smartnode.GetMinipoolLabelsPath())
+		if err != nil {
+			collector.logError(err)
+		} else {
+			labels = book.GetLabels()
+		}
+	}
+
+	statusCounts := map[beacon.ValidatorState]float64{}
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		statusCounts[validator.Status]++
+
+		stage, epoch, scheduled := GetValidatorNextStage(validator)
+		if scheduled {
+			channel <- prometheus.MustNewConstMetric(
+				collector.nextStageEpoch, prometheus.GaugeValue, float64(epoch), mpd.MinipoolAddress.Hex(), stage, labels[mpd.MinipoolAddress])
+		}
+	}
+
+	for status, count := range statusCounts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.statusCount, prometheus.GaugeValue, count, string(status))
+	}
+}
+
+// Record that one of the node's validators changed Beacon status
+func RecordValidatorStatusChange(from beacon.ValidatorState, to beacon.ValidatorState) {
+	validatorTransitions.lock.Lock()
+	defer validatorTransitions.lock.Unlock()
+	validatorTransitions.counts[transitionKey{from: string(from), to: string(to)}]++
+}
+
+// Get the next lifecycle stage of a validator and the epoch it will be reached at.
+// Returns false if that epoch hasn't been scheduled by the Beacon chain yet.
+func GetValidatorNextStage(validator beacon.ValidatorStatus) (string, uint64, bool) {
+	var stage string
+	var epoch uint64
+	switch validator.Status {
+	case beacon.ValidatorState_PendingInitialized:
+		stage, epoch = "activation_eligible", validator.ActivationEligibilityEpoch
+	case beacon.ValidatorState_PendingQueued:
+		stage, epoch = "active", validator.ActivationEpoch
+	case beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+		stage, epoch = "exited", validator.ExitEpoch
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed:
+		stage, epoch = "withdrawable", validator.WithdrawableEpoch
+	default:
+		return "", 0, false
+	}
+
+	if epoch == farFutureEpoch {
+		return stage, 0, false
+	}
+	return stage, epoch, true
+}
+
+// Log error messages
+func (collector *ValidatorStatusCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
@@ -0,0 +1,129 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+)
+
+// Represents the collector for the Web3Signer remote signer
+type Web3SignerCollector struct {
+	// Whether the signer is responding
+	up *prometheus.Desc
+
+	// Whether the signer has the key for each of the node's minipools
+	keyAvailable *prometheus.Desc
+
+	// The number of the node's minipools that the signer doesn't have a key for
+	missingKeys *prometheus.Desc
+
+	// The Web3Signer client
+	signer *web3signer.Client
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new Web3SignerCollector instance
+func NewWeb3SignerCollector(signer *web3signer.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *Web3SignerCollector {
+	subsystem := "web3signer"
+	return &Web3SignerCollector{
+		up: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "up"),
+			"Whether the Web3Signer remote signer is responding",
+			nil, nil,
+		),
+		keyAvailable: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "key_available"),
+			"Whether Web3Signer has the validator key for the minipool",
+			[]string{"minipool", "label"}, nil,
+		),
+		missingKeys: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missing_keys"),
+			"The number of the node's minipools that Web3Signer doesn't have a validator key for",
+			nil, nil,
+		),
+		signer:      signer,
+		nodeAddress: nodeAddress,
+		cfg:         cfg,
+		stateLocker: stateLocker,
+		logPrefix:   "Web3Signer Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *Web3SignerCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.up
+	channel <- collector.keyAvailable
+	channel <- collector.missingKeys
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *Web3SignerCollector) Collect(channel chan<- prometheus.Metric) {
+	defer recordCollectorLatency(collector.logPrefix, time.Now())
+
+	// Check the signer and get the keys it has
+	pubkeys, err := collector.signer.GetPublicKeys()
+	if err != nil {
+		collector.logError(err)
+		channel <- prometheus.MustNewConstMetric(
+			collector.up, prometheus.GaugeValue, 0)
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.up, prometheus.GaugeValue, 1)
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		recordCollectorDegraded(collector.logPrefix, true)
+		return
+	}
+
+	// Get the minipool labels if they've been enabled; they're left blank otherwise
+	labels := map[common.Address]string{}
+	if collector.cfg.Smartnode.EnableMinipoolLabelsInMetrics.Value == true {
+		book, err := addressbook.Load(collector.cfg.Smartnode.GetMinipoolLabelsPath())
+		if err != nil {
+			collector.logError(err)
+		} else {
+			labels = book.GetLabels()
+		}
+	}
+
+	// Check the signer has the key of every minipool that still needs one
+	missingKeys := float64(0)
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		available := float64(0)
+		if pubkeys[mpd.Pubkey] {
+			available = 1
+		} else {
+			missingKeys++
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.keyAvailable, prometheus.GaugeValue, available, mpd.MinipoolAddress.Hex(), labels[mpd.MinipoolAddress])
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.missingKeys, prometheus.GaugeValue, missingKeys)
+	recordCollectorDegraded(collector.logPrefix, false)
+}
+
+// Log error messages
+func (collector *Web3SignerCollector) logError(err error) {
+	collectorLog.Printlnf("[%s] %s", collector.logPrefix, err.Error())
+	recordCollectorError(collector.logPrefix)
+}
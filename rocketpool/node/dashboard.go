@@ -0,0 +1,360 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often the metric types are refreshed from the registry and the provisioned dashboard is rewritten if it changed
+	dashboardRefreshInterval time.Duration = 15 * time.Minute
+
+	// The generated dashboard is served by the metrics exporter on this route
+	dashboardRoute string = "/dashboard"
+
+	dashboardUid           string = "rocketpool-generated"
+	dashboardTitle         string = "Rocket Pool Node (Generated)"
+	dashboardSchemaVersion int    = 37
+	dashboardMetricPrefix  string = "rocketpool_"
+
+	// Panel layout; Grafana's grid is 24 units wide
+	dashboardPanelWidth  int = 8
+	dashboardPanelHeight int = 8
+	dashboardGridWidth   int = 24
+)
+
+// Matches the string form of a Prometheus metric description; the variable labels are listed with spaces or commas
+// depending on the client library version
+var descPattern = regexp.MustCompile(`^Desc\{fqName: ("(?:[^"\\]|\\.)*"), help: ("(?:[^"\\]|\\.)*"), constLabels: \{.*\}, variableLabels: [\[{](.*)[\]}]\}$`)
+
+// A metric declared by one of the collectors
+type dashboardMetric struct {
+	name       string
+	help       string
+	labels     []string
+	group      string
+	metricType string
+}
+
+// Builds a Grafana dashboard with a panel for every metric the registered collectors declare, so new metrics show up
+// without editing the dashboard by hand
+type dashboardGenerator struct {
+	gatherer prometheus.Gatherer
+	logger   log.ColorLogger
+
+	metrics     map[string]*dashboardMetric
+	groups      []string
+	lastWritten []byte
+	lock        sync.Mutex
+}
+
+// Create a dashboard generator for the metrics in a registry
+func newDashboardGenerator(gatherer prometheus.Gatherer, logger log.ColorLogger) *dashboardGenerator {
+	return &dashboardGenerator{
+		gatherer: gatherer,
+		logger:   logger,
+		metrics:  map[string]*dashboardMetric{},
+		groups:   []string{},
+	}
+}
+
+// Add the metrics a collector declares to the dashboard, in a row named after the collector
+func (g *dashboardGenerator) addCollector(group string, collector prometheus.Collector) {
+	descs := make(chan *prometheus.Desc)
+	go func() {
+		collector.Describe(descs)
+		close(descs)
+	}()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.groups = append(g.groups, group)
+	for desc := range descs {
+		metric, err := parseDesc(desc)
+		if err != nil {
+			g.logger.Warnf("skipping metric in the generated dashboard: %s", err.Error())
+			continue
+		}
+		metric.group = group
+		g.metrics[metric.name] = metric
+	}
+}
+
+// Refresh the metric types from the registry; metrics that haven't reported a value yet are typed by their name
+func (g *dashboardGenerator) refreshTypes() error {
+	families, err := g.gatherer.Gather()
+	if err != nil && len(families) == 0 {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, family := range families {
+		if metric, exists := g.metrics[family.GetName()]; exists {
+			metric.metricType = family.GetType().String()
+		}
+	}
+	return nil
+}
+
+// Build the dashboard JSON
+func (g *dashboardGenerator) build() ([]byte, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	// Sort the metrics into their rows
+	metricsByGroup := map[string][]*dashboardMetric{}
+	for _, metric := range g.metrics {
+		metricsByGroup[metric.group] = append(metricsByGroup[metric.group], metric)
+	}
+
+	panels := []grafanaPanel{}
+	id := 1
+	y := 0
+	for _, group := range g.groups {
+		metrics := metricsByGroup[group]
+		if len(metrics) == 0 {
+			continue
+		}
+		sort.Slice(metrics, func(i, j int) bool {
+			return metrics[i].name < metrics[j].name
+		})
+		delete(metricsByGroup, group)
+
+		panels = append(panels, grafanaPanel{
+			Id:      id,
+			Type:    "row",
+			Title:   group,
+			GridPos: grafanaGridPos{X: 0, Y: y, W: dashboardGridWidth, H: 1},
+			Panels:  []grafanaPanel{},
+		})
+		id++
+		y++
+
+		x := 0
+		for _, metric := range metrics {
+			if x+dashboardPanelWidth > dashboardGridWidth {
+				x = 0
+				y += dashboardPanelHeight
+			}
+			panels = append(panels, metric.panel(id, x, y))
+			id++
+			x += dashboardPanelWidth
+		}
+		y += dashboardPanelHeight
+	}
+
+	dashboard := grafanaDashboard{
+		Uid:           dashboardUid,
+		Title:         dashboardTitle,
+		Tags:          []string{"rocketpool", "generated"},
+		Editable:      true,
+		SchemaVersion: dashboardSchemaVersion,
+		Refresh:       "1m",
+		Time:          grafanaTimeRange{From: "now-24h", To: "now"},
+		Panels:        panels,
+	}
+	dashboard.Templating.List = []grafanaVariable{
+		{
+			Name:  "datasource",
+			Label: "Data Source",
+			Type:  "datasource",
+			Query: "prometheus",
+		},
+	}
+	return json.MarshalIndent(dashboard, "", "  ")
+}
+
+// Write the dashboard to the given path if it changed since it was last written
+func (g *dashboardGenerator) provision(path string) error {
+	contents, err := g.build()
+	if err != nil {
+		return fmt.Errorf("error building dashboard: %w", err)
+	}
+	if bytes.Equal(contents, g.lastWritten) {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating dashboard folder: %w", err)
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, contents, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing dashboard to %s: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving dashboard to %s: %w", path, err)
+	}
+	g.lastWritten = contents
+	return nil
+}
+
+// Keep the metric types up to date, rewriting the provisioned dashboard when they change.
+// The dashboard isn't provisioned if the path is empty.
+func (g *dashboardGenerator) run(path string) {
+	if path != "" {
+		g.logger.Printlnf("Provisioning the generated Grafana dashboard to %s.", path)
+	}
+	for {
+		if err := g.refreshTypes(); err != nil {
+			g.logger.Printlnf("Error refreshing the generated dashboard's metric types: %s", err.Error())
+		}
+		if path != "" {
+			if err := g.provision(path); err != nil {
+				g.logger.Printlnf("Error provisioning the generated dashboard: %s", err.Error())
+			}
+		}
+		time.Sleep(dashboardRefreshInterval)
+	}
+}
+
+// Register the dashboard route on the metrics exporter
+func (g *dashboardGenerator) registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(dashboardRoute, func(w http.ResponseWriter, r *http.Request) {
+		contents, err := g.build()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error building dashboard: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(contents)
+	})
+}
+
+// Create the panel for a metric
+func (m *dashboardMetric) panel(id int, x int, y int) grafanaPanel {
+	legend := strings.TrimPrefix(m.name, dashboardMetricPrefix)
+	if len(m.labels) > 0 {
+		labels := make([]string, len(m.labels))
+		for i, label := range m.labels {
+			labels[i] = fmt.Sprintf("{{%s}}", label)
+		}
+		legend = strings.Join(labels, " ")
+	}
+
+	// Counters are charted by how much they grew, everything else by its value
+	expr := m.name
+	title := m.name
+	if m.isCounter() {
+		expr = fmt.Sprintf("increase(%s[$__rate_interval])", m.name)
+		title = fmt.Sprintf("%s (increase)", m.name)
+	}
+
+	datasource := &grafanaDatasource{Type: "prometheus", Uid: "${datasource}"}
+	return grafanaPanel{
+		Id:          id,
+		Type:        "timeseries",
+		Title:       title,
+		Description: m.help,
+		Datasource:  datasource,
+		GridPos:     grafanaGridPos{X: x, Y: y, W: dashboardPanelWidth, H: dashboardPanelHeight},
+		Targets: []grafanaTarget{
+			{
+				RefId:        "A",
+				Datasource:   datasource,
+				Expr:         expr,
+				LegendFormat: legend,
+			},
+		},
+	}
+}
+
+// Check if a metric is a counter, going by its name if it hasn't reported a value yet
+func (m *dashboardMetric) isCounter() bool {
+	if m.metricType == "" {
+		return strings.HasSuffix(m.name, "_total")
+	}
+	return m.metricType == "COUNTER"
+}
+
+// Get a metric's name, help and labels from its description
+func parseDesc(desc *prometheus.Desc) (*dashboardMetric, error) {
+	matches := descPattern.FindStringSubmatch(desc.String())
+	if matches == nil {
+		return nil, fmt.Errorf("unexpected metric description format: %s", desc.String())
+	}
+	name, err := strconv.Unquote(matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metric name in %s: %w", desc.String(), err)
+	}
+	help, err := strconv.Unquote(matches[2])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metric help in %s: %w", desc.String(), err)
+	}
+	return &dashboardMetric{
+		name: name,
+		help: help,
+		labels: strings.FieldsFunc(matches[3], func(r rune) bool {
+			return r == ' ' || r == ','
+		}),
+	}, nil
+}
+
+// The subset of Grafana's dashboard model used by the generated dashboard
+type grafanaDashboard struct {
+	Uid           string           `json:"uid"`
+	Title         string           `json:"title"`
+	Tags          []string         `json:"tags"`
+	Editable      bool             `json:"editable"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Refresh       string           `json:"refresh"`
+	Time          grafanaTimeRange `json:"time"`
+	Templating    struct {
+		List []grafanaVariable `json:"list"`
+	} `json:"templating"`
+	Panels []grafanaPanel `json:"panels"`
+}
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+type grafanaVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+type grafanaPanel struct {
+	Id          int                `json:"id"`
+	Type        string             `json:"type"`
+	Title       string             `json:"title"`
+	Description string             `json:"description,omitempty"`
+	Datasource  *grafanaDatasource `json:"datasource,omitempty"`
+	GridPos     grafanaGridPos     `json:"gridPos"`
+	Targets     []grafanaTarget    `json:"targets,omitempty"`
+	Panels      []grafanaPanel     `json:"panels,omitempty"`
+}
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	Uid  string `json:"uid"`
+}
+type grafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+type grafanaTarget struct {
+	RefId        string             `json:"refId"`
+	Datasource   *grafanaDatasource `json:"datasource"`
+	Expr         string             `json:"expr"`
+	LegendFormat string             `json:"legendFormat"`
+}
@@ -68,16 +68,16 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	}
 
 	// Check if auto-distributing is disabled
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_DistributeMinipools)
 	distributeThreshold := cfg.Smartnode.DistributeThreshold.Value.(float64)
 	disabled := false
 	if gasThreshold == 0 {
-		logger.Println("Automatic tx gas threshold is 0, disabling auto-distribute.")
+		logger.Println("Automatic tx gas threshold (or this task's gas ceiling) is 0, disabling auto-distribute.")
 		disabled = true
 	} else {
 		// Safety clamp
 		if distributeThreshold >= 8 {
-			logger.Printlnf("WARNING: Auto-distribute threshold is more than 8 ETH (%.6f ETH), reducing to 7.5 ETH for safety", distributeThreshold)
+			logger.Warnf("Auto-distribute threshold is more than 8 ETH (%.6f ETH), reducing to 7.5 ETH for safety", distributeThreshold)
 			distributeThreshold = 7.5
 		} else if distributeThreshold == 0 {
 			logger.Println("Auto-distribute threshold is 0, disabling auto-distribute.")
@@ -98,7 +98,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -163,12 +163,21 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) can have their balances distributed...", len(minipools))
 
+	// Make sure the balances will go where they're expected to before sending them anywhere
+	minipoolAddresses := make([]common.Address, len(minipools))
+	for i, mpd := range minipools {
+		minipoolAddresses[i] = mpd.MinipoolAddress
+	}
+	if err := services.RequireWithdrawalAddressSafety(t.c, minipoolAddresses...); err != nil {
+		return err
+	}
+
 	// Distribute minipools
 	successCount := 0
 	for _, mpd := range minipools {
 		success, err := t.distributeMinipool(mpd, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not distribute balance of minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.Errorw("Could not distribute balance of minipool", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Err(err))
 			return err
 		}
 		if success {
@@ -213,7 +222,7 @@ func (t *distributeMinipools) getDistributableMinipools(nodeAddress common.Addre
 func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Distributing minipool %s (total balance of %.6f ETH)...", mpd.MinipoolAddress.Hex(), eth.WeiToEth(mpd.Balance))
+	t.log.Infow("Distributing minipool...", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("balanceEth", eth.WeiToEth(mpd.Balance)))
 
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
 	if err != nil {
@@ -245,7 +254,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -273,7 +282,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	}
 
 	// Log
-	t.log.Printlnf("Successfully distributed balance of minipool %s.", mp.GetAddress().Hex())
+	t.log.Infow("Successfully distributed balance of minipool.", log.Any("minipool", mp.GetAddress().Hex()), log.Any("tx", hash.Hex()))
 
 	// Return
 	return true, nil
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	apiminipool "github.com/rocket-pool/smartnode/rocketpool/api/minipool"
+	apinode "github.com/rocket-pool/smartnode/rocketpool/api/node"
+	apiwallet "github.com/rocket-pool/smartnode/rocketpool/api/wallet"
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/ical"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const nodeApiPrefix string = "/api/v1"
+
+// The only route that changes the daemon's state; everything else is read-only
+const nodeApiReloadConfigRoute string = nodeApiPrefix + "/config/reload"
+
+// The duties calendar feed; calendar apps can't send headers, so it also accepts the read-only calendar token as a query parameter
+const nodeApiCalendarRoute string = nodeApiPrefix + "/node/calendar.ics"
+
+// The currency reward events are valued in if the request doesn't pick one
+const nodeApiDefaultCurrency string = "usd"
+
+// The date format accepted for reward event ranges, alongside RFC 3339 timestamps
+const nodeApiDateFormat string = "2006-01-02"
+
+// Runs the HTTP API that exposes the node's status to external tooling
+func runHttpApiServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, reloader *configReloader) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Return if the API is disabled
+	if cfg.Smartnode.EnableNodeApi.Value == false {
+		return nil
+	}
+	token := cfg.Smartnode.NodeApiToken.Value.(string)
+	if token == "" {
+		return fmt.Errorf("The node HTTP API is enabled but no API token has been set; refusing to start it without authentication.")
+	}
+	calendarToken := cfg.Smartnode.NodeApiCalendarToken.Value.(string)
+	if calendarToken == token {
+		return fmt.Errorf("The node HTTP API calendar token is the same as the API token; refusing to start the API with a full-access token allowed in calendar URLs.")
+	}
+
+	// Register the routes
+	mux := http.NewServeMux()
+	mux.HandleFunc(nodeApiPrefix+"/health", func(w http.ResponseWriter, r *http.Request) {
+		isSafeMode, recentRestarts := getSafeModeStatus()
+		response := &api.NodeHealthResponse{
+			SafeMode:       isSafeMode,
+			RecentRestarts: recentRestarts,
+			StateStale:     stateLocker.IsStale(),
+		}
+		if networkState := stateLocker.GetState(); networkState != nil {
+			response.StateLoaded = true
+			response.StateSlot = networkState.BeaconSlotNumber
+		}
+		apiutils.WriteResponse(w, response, nil)
+	})
+	mux.HandleFunc(nodeApiPrefix+"/node/status", func(w http.ResponseWriter, r *http.Request) {
+		response, err := apinode.GetStatus(c)
+		apiutils.WriteResponse(w, response, err)
+	})
+	mux.HandleFunc(nodeApiPrefix+"/node/rewards", func(w http.ResponseWriter, r *http.Request) {
+		response, err := apinode.GetRewards(c)
+		apiutils.WriteResponse(w, response, err)
+	})
+	mux.HandleFunc(nodeApiPrefix+"/node/reward-events", func(w http.ResponseWriter, r *http.Request) {
+		response, err := getRewardEvents(c, r)
+		apiutils.WriteResponse(w, response, err)
+	})
+	mux.HandleFunc(nodeApiCalendarRoute, func(w http.ResponseWriter, r *http.Request) {
+		response, err := apinode.GetDutiesCalendar(c)
+		if err != nil {
+			apiutils.WriteResponse(w, response, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		_, _ = w.Write([]byte(ical.Write("Rocket Pool Node Duties", response.Events)))
+	})
+	mux.HandleFunc(nodeApiPrefix+"/minipools", func(w http.ResponseWriter, r *http.Request) {
+		response, err := apiminipool.GetStatus(c)
+		apiutils.WriteResponse(w, response, err)
+	})
+	mux.HandleFunc(nodeApiPrefix+"/wallet/status", func(w http.ResponseWriter, r *http.Request) {
+		response, err := apiwallet.GetStatus(c)
+		apiutils.WriteResponse(w, response, err)
+	})
+	mux.HandleFunc(nodeApiReloadConfigRoute, func(w http.ResponseWriter, r *http.Request) {
+		response, err := reloader.reload(fmt.Sprintf("HTTP API (%s)", r.RemoteAddr))
+		apiutils.WriteResponse(w, response, err)
+	})
+
+	// Start the HTTP server
+	port := cfg.Smartnode.NodeApiPort.Value.(uint16)
+	logger.Printlnf("Starting node HTTP API on port %d.", port)
+	err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", port), authenticate(token, calendarToken, logger, mux))
+	if err != nil {
+		return fmt.Errorf("Error running node HTTP API server: %w", err)
+	}
+
+	return nil
+
+}
+
+// Get the node's reward events for the range in the request's query.
+// Takes "from" and "to" as dates or RFC 3339 timestamps, where a "to" date includes that whole day, and an optional "currency".
+func getRewardEvents(c *cli.Context, r *http.Request) (*api.NodeRewardEventsResponse, error) {
+	query := r.URL.Query()
+	from, err := parseRangeTime(query.Get("from"), false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	to, err := parseRangeTime(query.Get("to"), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to: %w", err)
+	}
+	currency := query.Get("currency")
+	if currency == "" {
+		currency = nodeApiDefaultCurrency
+	}
+
+	// Custom price URLs aren't accepted here so API clients can't make the daemon request arbitrary URLs
+	priceSource, err := prices.NewHistoricalPriceSource(prices.Source_CoinGecko, currency)
+	if err != nil {
+		return nil, err
+	}
+	return apinode.GetRewardEvents(c, from, to, priceSource)
+}
+
+// Parse one end of a time range; a bare date at the end of the range is extended to the end of that day
+func parseRangeTime(value string, isEnd bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("a date is required")
+	}
+	if date, err := time.Parse(nodeApiDateFormat, value); err == nil {
+		if isEnd {
+			date = date.Add(24*time.Hour - time.Nanosecond)
+		}
+		return date, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is not a date (%s) or an RFC 3339 timestamp", value, nodeApiDateFormat)
+	}
+	return timestamp, nil
+}
+
+// Wraps a handler so it only serves authenticated requests; everything but the config reload must be a GET.
+// The calendar feed also accepts the calendar token in the URL, which doesn't give access to anything else.
+func authenticate(token string, calendarToken string, logger log.ColorLogger, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// Check the token
+		providedToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		authenticated := subtle.ConstantTimeCompare([]byte(providedToken), []byte(token)) == 1
+		if !authenticated && providedToken == "" && r.URL.Path == nodeApiCalendarRoute && calendarToken != "" {
+			authenticated = subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(calendarToken)) == 1
+		}
+		if !authenticated {
+			logger.Printlnf("Rejected unauthenticated request for %s from %s.", r.URL.Path, r.RemoteAddr)
+			w.WriteHeader(http.StatusUnauthorized)
+			apiutils.WriteErrorResponse(w, fmt.Errorf("missing or invalid API token"))
+			return
+		}
+
+		// The API is read-only, apart from reloading the config
+		allowedMethod := http.MethodGet
+		if r.URL.Path == nodeApiReloadConfigRoute {
+			allowedMethod = http.MethodPost
+		}
+		if r.Method != allowedMethod {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			apiutils.WriteErrorResponse(w, fmt.Errorf("method %s is not allowed", r.Method))
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,232 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	dvtaddon "github.com/rocket-pool/smartnode/addons/dvt"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Manage DVT keys task
+type manageDvtKeys struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	rp      *rocketpool.RocketPool
+	d       *client.Client
+	bc      beacon.Client
+	manager *dvt.Manager
+
+	// The fee recipient each cluster has already been warned about, so the warning isn't repeated every run
+	feeRecipientWarnings map[rptypes.ValidatorPubkey]common.Address
+}
+
+// Create manage DVT keys task
+func newManageDvtKeys(c *cli.Context, logger log.ColorLogger) (*manageDvtKeys, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := services.GetDvtManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageDvtKeys{
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		rp:      rp,
+		d:       d,
+		bc:      bc,
+		manager: manager,
+
+		feeRecipientWarnings: map[rptypes.ValidatorPubkey]common.Address{},
+	}, nil
+
+}
+
+// Split the keys of new minipools into clusters, and take them out of the Validator Client once they've been staked
+func (t *manageDvtKeys) run(state *state.NetworkState) error {
+
+	// Check if the addon is enabled
+	if t.cfg.Dvt.GetEnabledParameter().Value != true {
+		return nil
+	}
+	dvtCfg := t.cfg.Dvt.GetConfig().(*dvtaddon.DvtConfig)
+
+	// Prysm keeps all of the keys in a single wallet, so they can't be taken out one at a time
+	cc, _ := t.cfg.GetSelectedConsensusClient()
+	if cc == cfgtypes.ConsensusClient_Prysm {
+		return fmt.Errorf("distributed validators aren't supported with the Prysm Validator Client")
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check the node's minipools
+	retired := 0
+	var feeRecipient *common.Address
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Finalised || mpd.IsVacant {
+			continue
+		}
+		cluster, exists := t.manager.GetCluster(mpd.Pubkey)
+
+		// Obol clusters keep the fee recipient they were split with, so joining or leaving the Smoothing Pool leaves
+		// them paying the wrong address until the split is redone
+		if exists && cluster.Provider == string(dvtaddon.Provider_Obol) && cluster.FeeRecipient != (common.Address{}) {
+			if feeRecipient == nil {
+				correctFeeRecipient, err := getCorrectFeeRecipient(t.rp, t.bc, nodeAccount.Address, state)
+				if err != nil {
+					return fmt.Errorf("error getting fee recipient: %w", err)
+				}
+				feeRecipient = &correctFeeRecipient
+			}
+			t.checkFeeRecipient(cluster, *feeRecipient)
+		}
+
+		switch mpd.Status {
+		case rptypes.Initialized, rptypes.Prelaunch:
+			// Split the key while the minipool is still waiting to be staked
+			if exists {
+				continue
+			}
+			if err := t.splitKey(dvtCfg, mpd, state); err != nil {
+				t.log.Printlnf("Error splitting the key of minipool %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			}
+
+		case rptypes.Staking:
+			// Take the key out of the Validator Client before the validator is activated, so only the cluster runs it.
+			// Minipools that were staked before the addon was enabled are left alone.
+			if !exists || cluster.Retired {
+				continue
+			}
+			moved, err := t.manager.Retire(mpd.Pubkey, t.cfg.Smartnode.GetValidatorKeychainPath())
+			if err != nil {
+				t.log.Printlnf("Error removing the key of minipool %s from the Validator Client: %s", mpd.MinipoolAddress.Hex(), err.Error())
+				continue
+			}
+			t.log.Printlnf("Moved %d key file(s) for minipool %s out of the Validator Client; it's now run by its %s cluster.", len(moved), mpd.MinipoolAddress.Hex(), cluster.Provider)
+			retired++
+		}
+	}
+
+	// Restart the VC so it stops using the retired keys
+	if retired > 0 {
+		t.log.Println("Restarting the Validator Client to unload the retired keys...")
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return fmt.Errorf("error restarting the Validator Client: %w", err)
+		}
+	}
+
+	return nil
+
+}
+
+// Split a minipool's validator key with the selected provider's tool
+func (t *manageDvtKeys) splitKey(dvtCfg *dvtaddon.DvtConfig, mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState) error {
+	key, err := t.w.GetValidatorKeyByPubkey(mpd.Pubkey)
+	if err != nil {
+		return err
+	}
+
+	var cluster dvt.Cluster
+	switch dvtCfg.GetProvider() {
+	case dvtaddon.Provider_Obol:
+		network, err := getCharonNetwork(t.cfg.Smartnode.Network.Value.(cfgtypes.Network))
+		if err != nil {
+			return err
+		}
+		feeRecipient, err := getCorrectFeeRecipient(t.rp, t.bc, mpd.NodeAddress, state)
+		if err != nil {
+			return fmt.Errorf("error getting fee recipient: %w", err)
+		}
+		nodes := int(dvtCfg.ClusterSize.Value.(uint16))
+		cluster, err = t.manager.SplitObol(dvtCfg.CharonPath.Value.(string), network, nodes, key, mpd.MinipoolAddress, feeRecipient)
+		if err != nil {
+			return err
+		}
+
+	case dvtaddon.Provider_Ssv:
+		operatorIds, operatorKeys, err := dvtCfg.GetSsvOperators()
+		if err != nil {
+			return err
+		}
+		cluster, err = t.manager.SplitSsv(dvtCfg.SsvKeysPath.Value.(string), operatorIds, operatorKeys, mpd.NodeAddress, key, mpd.MinipoolAddress)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown DVT provider %s", dvtCfg.GetProvider())
+	}
+
+	t.log.Printlnf("Split the key of minipool %s into a %d-node %s cluster in %s.", mpd.MinipoolAddress.Hex(), cluster.Nodes, cluster.Provider, cluster.Directory)
+	t.log.Println("Make sure the cluster is running with these keys before the minipool is staked; your Validator Client will stop using the key at that point.")
+	return nil
+}
+
+// Warn if a cluster was split with a different fee recipient than the one the node should be using now
+func (t *manageDvtKeys) checkFeeRecipient(cluster dvt.Cluster, feeRecipient common.Address) {
+	if cluster.FeeRecipient == feeRecipient {
+		delete(t.feeRecipientWarnings, cluster.Pubkey)
+		return
+	}
+	if warned, exists := t.feeRecipientWarnings[cluster.Pubkey]; exists && warned == feeRecipient {
+		return
+	}
+	t.log.Warnf("the %s cluster for minipool %s was split with fee recipient %s, but the node's fee recipient is now %s because it joined or left the Smoothing Pool. The fee recipient is part of the cluster's lock file in %s, so the cluster must be recreated with the new one; until then its rewards go to the wrong address.", cluster.Provider, cluster.Minipool.Hex(), cluster.FeeRecipient.Hex(), feeRecipient.Hex(), cluster.Directory)
+	t.feeRecipientWarnings[cluster.Pubkey] = feeRecipient
+}
+
+// Get the name Charon uses for a network
+func getCharonNetwork(network cfgtypes.Network) (string, error) {
+	switch network {
+	case cfgtypes.Network_Mainnet:
+		return "mainnet", nil
+	case cfgtypes.Network_Prater:
+		return "goerli", nil
+	default:
+		return "", fmt.Errorf("Obol clusters aren't supported on the %s network", network)
+	}
+}
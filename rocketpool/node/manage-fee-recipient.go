@@ -8,9 +8,11 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -76,6 +78,13 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return err
 	}
 
+	// Get the latest config, since auto-correction can be toggled by reloading it
+	cfg, err := services.GetConfig(m.c)
+	if err != nil {
+		return err
+	}
+	m.cfg = cfg
+
 	// Log
 	m.log.Println("Checking for correct fee recipient...")
 
@@ -85,23 +94,10 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return err
 	}
 
-	// Get the fee recipient info for the node
-	var feeRecipientInfo *rputils.FeeRecipientInfo
-	if !state.IsAtlasDeployed {
-		feeRecipientInfo, err = rputils.GetFeeRecipientInfo_Legacy(m.rp, m.bc, nodeAccount.Address, nil)
-	} else {
-		feeRecipientInfo, err = rputils.GetFeeRecipientInfo_Atlas(m.rp, m.bc, nodeAccount.Address, state)
-	}
-	if err != nil {
-		return fmt.Errorf("error getting fee recipient info: %w", err)
-	}
-
 	// Get the correct fee recipient address
-	var correctFeeRecipient common.Address
-	if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
-		correctFeeRecipient = feeRecipientInfo.SmoothingPoolAddress
-	} else {
-		correctFeeRecipient = feeRecipientInfo.FeeDistributorAddress
+	correctFeeRecipient, err := getCorrectFeeRecipient(m.rp, m.bc, nodeAccount.Address, state)
+	if err != nil {
+		return err
 	}
 
 	// Check if the VC is using the correct fee recipient
@@ -110,13 +106,35 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return fmt.Errorf("error validating fee recipient files: %w", err)
 	}
 
+	// Ask the VC which fee recipient each validator is actually using, so the metrics only count real mismatches
+	configuredFeeRecipient, _, err := rpsvc.GetFeeRecipientFromFile(m.cfg)
+	if err != nil {
+		return fmt.Errorf("error reading fee recipient file: %w", err)
+	}
+	mismatchedValidators := m.getMismatchedValidators(correctFeeRecipient, nodeAccount.Address, state)
+
+	// Report the result to the watchdog metrics
+	filesCorrect := fileExists && correctAddress
+	collectors.RecordFeeRecipientCheck(correctFeeRecipient, configuredFeeRecipient, filesCorrect && mismatchedValidators == 0, mismatchedValidators)
+
+	if filesCorrect {
+		// The files are all correct, so regenerating them won't help; the VC picks them up when it restarts
+		if mismatchedValidators > 0 {
+			m.log.Warnf("Your fee recipient files are correct, but your validator client reports a different fee recipient than %s for %d of your validators. It may not have been restarted since the files changed, or it may have a per-validator override; please check it manually.", correctFeeRecipient.Hex(), mismatchedValidators)
+		}
+		return nil
+	}
+
+	// Only warn about it if auto-correction is disabled
+	if m.cfg.Smartnode.AutoCorrectFeeRecipient.Value == false {
+		m.log.Warnf("Your validator client is not using the correct fee recipient of %s, and %d of your validators could be penalized if they propose a block! Auto-correction is disabled, so please fix this manually.", correctFeeRecipient.Hex(), mismatchedValidators)
+		return nil
+	}
+
 	if !fileExists {
 		m.log.Println("Fee recipient files don't all exist, regenerating...")
-	} else if !correctAddress {
-		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	} else {
-		// Files are all correct, return.
-		return nil
+		m.log.Warnf("Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	}
 
 	// Regenerate the fee recipient files
@@ -133,6 +151,8 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return nil
 	}
 
+	collectors.RecordFeeRecipientCorrection()
+
 	// Restart the VC
 	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
 	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
@@ -145,3 +165,53 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	return nil
 
 }
+
+// Get the number of the node's pending or active validators that the VC reports a different fee recipient for.
+// If the VC can't be reached, the validators after the failed request aren't counted.
+func (m *manageFeeRecipient) getMismatchedValidators(correctFeeRecipient common.Address, nodeAddress common.Address, state *state.NetworkState) int {
+	km := keymanager.NewClient(m.cfg.Smartnode.KeymanagerApiUrl.Value.(string), m.cfg.Smartnode.GetKeymanagerApiTokenPath())
+	count := 0
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		switch validator.Status {
+		case beacon.ValidatorState_PendingInitialized,
+			beacon.ValidatorState_PendingQueued,
+			beacon.ValidatorState_ActiveOngoing,
+			beacon.ValidatorState_ActiveExiting:
+		default:
+			continue
+		}
+
+		feeRecipient, err := km.GetFeeRecipient(mpd.Pubkey)
+		if err != nil {
+			m.log.Warnf("Couldn't get the fee recipient of validator %s from the validator client, so the mismatched validator count is incomplete: %s", mpd.Pubkey.Hex(), err.Error())
+			break
+		}
+		if feeRecipient != correctFeeRecipient {
+			count++
+		}
+	}
+	return count
+}
+
+// Get the fee recipient the node's validators should be using
+func getCorrectFeeRecipient(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, state *state.NetworkState) (common.Address, error) {
+	var feeRecipientInfo *rputils.FeeRecipientInfo
+	var err error
+	if !state.IsAtlasDeployed {
+		feeRecipientInfo, err = rputils.GetFeeRecipientInfo_Legacy(rp, bc, nodeAddress, nil)
+	} else {
+		feeRecipientInfo, err = rputils.GetFeeRecipientInfo_Atlas(rp, bc, nodeAddress, state)
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting fee recipient info: %w", err)
+	}
+
+	if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
+		return feeRecipientInfo.SmoothingPoolAddress, nil
+	}
+	return feeRecipientInfo.FeeDistributorAddress, nil
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often every validator's graffiti is read back from the Validator client, in case it was changed outside the Smartnode
+	graffitiRecheckInterval time.Duration = time.Hour
+)
+
+// Manage graffiti task
+type manageGraffiti struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+
+	// The graffiti each validator was last known to have in the Validator client
+	known map[types.ValidatorPubkey]string
+
+	// The time every validator's graffiti was last read from the Validator client
+	lastRecheck time.Time
+}
+
+// Create manage graffiti task
+func newManageGraffiti(c *cli.Context, logger log.ColorLogger) (*manageGraffiti, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageGraffiti{
+		c:     c,
+		log:   logger,
+		cfg:   cfg,
+		w:     w,
+		known: map[types.ValidatorPubkey]string{},
+	}, nil
+
+}
+
+// Update the graffiti of any validators that aren't using the one they should be
+func (t *manageGraffiti) run(state *state.NetworkState) error {
+
+	// Check if graffiti management is enabled
+	if t.cfg.Smartnode.EnableGraffitiManagement.Value != true {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking validator graffiti...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the graffiti settings; these are loaded each time so changes to the templates are picked up right away
+	settings, err := graffiti.LoadSettings(t.cfg)
+	if err != nil {
+		return fmt.Errorf("error loading graffiti settings: %w", err)
+	}
+	km := keymanager.NewClient(t.cfg.Smartnode.KeymanagerApiUrl.Value.(string), t.cfg.Smartnode.GetKeymanagerApiTokenPath())
+
+	// Only ask the Validator client about validators whose graffiti should have changed, apart from a periodic full recheck
+	now := time.Now()
+	if now.Sub(t.lastRecheck) >= graffitiRecheckInterval {
+		t.known = map[types.ValidatorPubkey]string{}
+		t.lastRecheck = now
+	}
+
+	// Check each of the node's validators
+	updateCount := 0
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Finalised {
+			continue
+		}
+
+		index := ""
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			index = fmt.Sprint(validator.Index)
+		}
+		expected := settings.GetGraffiti(mpd.Pubkey, index, now)
+		current, exists := t.known[mpd.Pubkey]
+		if exists && current == expected {
+			continue
+		}
+
+		if !exists {
+			current, err = km.GetGraffiti(mpd.Pubkey)
+			if err != nil {
+				t.log.Warnf("Couldn't get the graffiti of validator %s: %s", mpd.Pubkey.Hex(), err.Error())
+				continue
+			}
+			t.known[mpd.Pubkey] = current
+			if current == expected {
+				continue
+			}
+		}
+
+		if err := km.SetGraffiti(mpd.Pubkey, expected); err != nil {
+			t.log.Warnf("Couldn't set the graffiti of validator %s: %s", mpd.Pubkey.Hex(), err.Error())
+			delete(t.known, mpd.Pubkey)
+			continue
+		}
+		t.known[mpd.Pubkey] = expected
+		t.log.Printlnf("Changed the graffiti of validator %s from '%s' to '%s'.", mpd.Pubkey.Hex(), current, expected)
+		updateCount++
+	}
+
+	if updateCount > 0 {
+		t.log.Printlnf("Updated the graffiti of %d validator(s).", updateCount)
+	}
+
+	// Return
+	return nil
+
+}
@@ -5,15 +5,24 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
+// How often the node rewards reported by the metrics are recalculated
+const rewardsInfoUpdateInterval time.Duration = 5 * time.Minute
+
 func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
 
 	// Get services
@@ -41,6 +50,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	if err != nil {
 		return err
 	}
+	balanceHistory, err := services.GetBalanceHistory(c)
+	if err != nil {
+		return err
+	}
+	dvtManager, err := services.GetDvtManager(c)
+	if err != nil {
+		return err
+	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -55,6 +72,30 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	if err != nil {
 		return fmt.Errorf("Error getting node account: %w", err)
 	}
+	nodeAddresses, err := getMetricsNodeAddresses(cfg, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(nodeAddresses) > 1 {
+		logger.Printlnf("Monitoring %d other node(s) in read-only mode.", len(nodeAddresses)-1)
+	}
+
+	// Keep the node rewards up to date in the background so scrapes don't have to read the rewards trees
+	rewardsInfo := rprewards.NewRewardsInfo(rp, cfg)
+	go rewardsInfo.Run(nodeAddresses, rewardsInfoUpdateInterval, logger)
+
+	// Get the fiat price source; the fiat metrics are left out if it can't be created
+	priceSource, err := prices.NewCurrentPriceSource(
+		cfg.Smartnode.FiatPriceSource.Value.(string),
+		cfg.Smartnode.FiatCurrency.Value.(string),
+		cfg.Smartnode.FiatPriceUrl.Value.(string),
+		ec,
+		cfg.Smartnode.GetChainlinkEthUsdFeedAddress(),
+	)
+	if err != nil {
+		logger.Warnf("fiat values will not be reported: %s", err.Error())
+		priceSource = nil
+	}
 
 	// Create the collectors
 	demandCollector := collectors.NewDemandCollector(rp, stateLocker)
@@ -62,22 +103,91 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	supplyCollector := collectors.NewSupplyCollector(rp, stateLocker)
 	rplCollector := collectors.NewRplCollector(rp, cfg, stateLocker)
 	odaoCollector := collectors.NewOdaoCollector(rp, stateLocker)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
+	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAddresses, cfg, rewardsInfo, priceSource, stateLocker)
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	beaconFallbackCollector := collectors.NewBeaconFallbackCollector(bc)
+	metaCollector := collectors.NewMetaCollector()
+	overridesCollector := collectors.NewOverridesCollector(cfg)
+	validatorStatusCollector := collectors.NewValidatorStatusCollector(nodeAccount.Address, cfg, stateLocker)
+	refundCollector := collectors.NewRefundCollector()
+	feeDistributorSweepCollector := collectors.NewFeeDistributorSweepCollector()
+	scrubRiskCollector := collectors.NewScrubRiskCollector()
+	doppelgangerCollector := collectors.NewDoppelgangerCollector()
+	feeRecipientCollector := collectors.NewFeeRecipientCollector()
+	clientDiversityCollector := collectors.NewClientDiversityCollector(bc, stateLocker)
+	safeModeCollector := collectors.NewSafeModeCollector()
+	autoClaimCollector := collectors.NewAutoClaimCollector()
+	taskCollector := collectors.NewTaskCollector()
+	stateCollector := collectors.NewStateCollector(stateLocker)
+	proposalCollector := collectors.NewProposalCollector()
+	mevRelayCollector := collectors.NewMevRelayCollector()
+	configCollector := collectors.NewConfigCollector()
+	endpointAccessCollector := collectors.NewEndpointAccessCollector()
+	dataSourceCollector := collectors.NewDataSourceCollector(ec, bc, stateLocker)
+	minipoolCollector := collectors.NewMinipoolCollector(rp, nodeAccount.Address, cfg, stateLocker)
+	balanceHistoryCollector := collectors.NewBalanceHistoryCollector(balanceHistory, nodeAddresses)
+	dvtCollector := collectors.NewDvtCollector(cfg, dvtManager)
+	syncCollector := collectors.NewSyncCollector(ec, bc)
+	networkCollector := collectors.NewNetworkCollector(rp, stateLocker)
+	queueCollector := collectors.NewQueueCollector(rp, nodeAccount.Address, cfg, stateLocker)
+	minipoolPerformanceCollector := collectors.NewMinipoolPerformanceCollector()
 
-	// Set up Prometheus
+	// Set up Prometheus; collectors can be made to fail on purpose in builds with fault injection enabled.
+	// Every collector also gets a row in the generated Grafana dashboard.
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(demandCollector)
-	registry.MustRegister(performanceCollector)
-	registry.MustRegister(supplyCollector)
-	registry.MustRegister(rplCollector)
-	registry.MustRegister(odaoCollector)
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(trustedNodeCollector)
-	registry.MustRegister(beaconCollector)
-	registry.MustRegister(smoothingPoolCollector)
+	dashboard := newDashboardGenerator(registry, logger)
+	register := func(name string, collector prometheus.Collector) {
+		registry.MustRegister(collectors.WithFaultInjection(name, collector))
+		dashboard.addCollector(name, collector)
+	}
+	register("demand", demandCollector)
+	register("performance", performanceCollector)
+	register("supply", supplyCollector)
+	register("rpl", rplCollector)
+	register("odao", odaoCollector)
+	register("node", nodeCollector)
+	register("odao_stats", trustedNodeCollector)
+	register("beacon", beaconCollector)
+	register("sp", smoothingPoolCollector)
+	register("beacon_fallback", beaconFallbackCollector)
+	registry.MustRegister(metaCollector)
+	dashboard.addCollector("meta", metaCollector)
+	register("overrides", overridesCollector)
+	register("validator_status", validatorStatusCollector)
+	register("refund", refundCollector)
+	register("fee_distributor_sweep", feeDistributorSweepCollector)
+	register("scrub_risk", scrubRiskCollector)
+	register("doppelganger", doppelgangerCollector)
+	register("fee_recipient", feeRecipientCollector)
+	register("client_diversity", clientDiversityCollector)
+	register("safe_mode", safeModeCollector)
+	register("auto-claim", autoClaimCollector)
+	register("task", taskCollector)
+	register("state", stateCollector)
+	register("proposals", proposalCollector)
+	register("mev_relay", mevRelayCollector)
+	register("config", configCollector)
+	register("endpoint_access", endpointAccessCollector)
+	register("data_source", dataSourceCollector)
+	register("minipool", minipoolCollector)
+	register("balance_history", balanceHistoryCollector)
+	register("dvt", dvtCollector)
+	register("sync", syncCollector)
+	register("network", networkCollector)
+	register("queue", queueCollector)
+	register("minipool_performance", minipoolPerformanceCollector)
+
+	// Check the Web3Signer keys if they live there
+	if cfg.Smartnode.UseWeb3Signer.Value == true {
+		signerUrl, err := cfg.Smartnode.GetWeb3SignerUrl()
+		if err != nil {
+			return fmt.Errorf("Error getting Web3Signer URL: %w", err)
+		}
+		web3SignerCollector := collectors.NewWeb3SignerCollector(web3signer.NewClient(signerUrl), nodeAccount.Address, cfg, stateLocker)
+		register("web3signer", web3SignerCollector)
+	}
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
@@ -87,9 +197,35 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 			return fmt.Errorf("Error getting node delegate: %w", err)
 		}
 		snapshotCollector := collectors.NewSnapshotCollector(rp, cfg, nodeAccount.Address, votingDelegate)
-		registry.MustRegister(snapshotCollector)
+		register("snapshot", snapshotCollector)
+	}
+
+	// Start the metrics stream for bandwidth-constrained subscribers
+	go func() {
+		err := runMetricsStreamServer(c, logger, registry)
+		if err != nil {
+			logger.Println(err)
+		}
+	}()
+
+	// Record the stats history for Grafana if enabled
+	if cfg.Smartnode.EnableStatsHistory.Value == true {
+		history, err := newStatsHistory(cfg.Smartnode.GetStatsHistoryPath(), cfg.Smartnode.StatsHistoryRetentionDays.Value.(uint64), registry, logger)
+		if err != nil {
+			return fmt.Errorf("Error loading stats history: %w", err)
+		}
+		history.registerRoutes(http.DefaultServeMux)
+		go history.run()
 	}
 
+	// Serve the generated Grafana dashboard, and provision it to the data folder if enabled
+	dashboard.registerRoutes(http.DefaultServeMux)
+	dashboardPath := ""
+	if cfg.Smartnode.ProvisionGrafanaDashboard.Value == true {
+		dashboardPath = cfg.Smartnode.GetGrafanaDashboardPath()
+	}
+	go dashboard.run(dashboardPath)
+
 	// Start the HTTP server
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	metricsAddress := c.GlobalString("metricsAddress")
@@ -103,6 +239,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
             <body>
             <h1>Rocket Pool Metrics Exporter</h1>
             <p><a href='` + metricsPath + `'>Metrics</a></p>
+            <p><a href='` + dashboardRoute + `'>Grafana Dashboard</a></p>
             </body>
             </html>`,
 		))
@@ -115,3 +252,18 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	return nil
 
 }
+
+// Get the addresses of the nodes to include in the node metrics, starting with this node
+func getMetricsNodeAddresses(cfg *config.RocketPoolConfig, nodeAddress common.Address) ([]common.Address, error) {
+	monitoredNodes, err := cfg.Smartnode.GetMonitoredNodes()
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing monitored nodes: %w", err)
+	}
+	addresses := []common.Address{nodeAddress}
+	for _, address := range monitoredNodes {
+		if address != nodeAddress {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,351 @@
+package node
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	metricsStreamServiceName     string = "rocketpool.node.MetricsStream"
+	defaultMetricsStreamInterval uint64 = 60
+	minMetricsStreamInterval     uint64 = 15
+
+	// Every this many updates, the full set of metrics is sent again so subscribers can recover from missed updates
+	metricsStreamKeyframeInterval uint64 = 60
+)
+
+// The gRPC service definition for the metrics stream.
+// Messages are protobuf Structs, so clients can use any gRPC tooling without generated code:
+//
+//	rpc Subscribe(google.protobuf.Struct) returns (stream google.protobuf.Struct)
+//
+// The request can contain "intervalSeconds" (the time between updates) and "prefixes" (a list of metric name
+// prefixes to include). Metric keys (the name and labels) are only sent once: each subscription gives its metrics
+// numeric IDs, and the rest of the stream refers to them by ID. Each update contains "seq", "keyframe", "names" (the
+// IDs given out since the previous update, mapped to their metric keys), "ids" and "values" (the IDs and new values of
+// the metrics that changed, as parallel lists) and "removed" (the IDs of the metrics that no longer exist, which won't
+// be reused). Keyframes contain every metric and the names of all of the current IDs, so a subscriber that missed
+// updates can recover; all other updates only contain the metrics that changed since the previous one.
+var metricsStreamServiceDesc = grpc.ServiceDesc{
+	ServiceName: metricsStreamServiceName,
+	HandlerType: (*metricsStreamService)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       subscribeHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rocketpool/node/metrics-stream.go",
+}
+
+type metricsStreamService interface {
+	subscribe(request *structpb.Struct, stream grpc.ServerStream) error
+}
+
+// Streams delta-encoded metrics from the Prometheus registry to subscribers
+type metricsStreamServer struct {
+	sampler *metricsSampler
+	logger  log.ColorLogger
+}
+
+// The IDs a subscription has given its metrics
+type metricIds struct {
+	ids    map[string]uint64
+	nextId uint64
+}
+
+// Caches samples of the registry so multiple subscribers don't each run the collectors
+type metricsSampler struct {
+	gatherer   prometheus.Gatherer
+	lastSample map[string]float64
+	lastTime   time.Time
+	lock       sync.Mutex
+}
+
+// Runs the gRPC metrics stream alongside the metrics exporter
+func runMetricsStreamServer(c *cli.Context, logger log.ColorLogger, gatherer prometheus.Gatherer) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Return if the stream is disabled
+	if cfg.Smartnode.EnableMetricsStream.Value == false {
+		return nil
+	}
+	token := cfg.Smartnode.NodeApiToken.Value.(string)
+	if token == "" {
+		return fmt.Errorf("The metrics stream is enabled but no node API token has been set; refusing to start it without authentication.")
+	}
+
+	// Without TLS the token would be sent in plain text, so the stream is only reachable locally
+	options := []grpc.ServerOption{grpc.StreamInterceptor(authenticateStream(token, logger))}
+	address := "127.0.0.1"
+	if cfg.Smartnode.EnableMetricsStreamTls.Value == true {
+		creds, err := credentials.NewServerTLSFromFile(cfg.Smartnode.GetMetricsStreamCertPath(), cfg.Smartnode.GetMetricsStreamKeyPath())
+		if err != nil {
+			return fmt.Errorf("Error loading the metrics stream's TLS certificate: %w", err)
+		}
+		options = append(options, grpc.Creds(creds))
+		address = "0.0.0.0"
+	}
+
+	// Create the server
+	server := grpc.NewServer(options...)
+	server.RegisterService(&metricsStreamServiceDesc, &metricsStreamServer{
+		sampler: &metricsSampler{
+			gatherer: gatherer,
+		},
+		logger: logger,
+	})
+
+	// Start listening
+	port := cfg.Smartnode.MetricsStreamPort.Value.(uint16)
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", address, port))
+	if err != nil {
+		return fmt.Errorf("Error listening for metrics stream subscribers: %w", err)
+	}
+	logger.Printlnf("Starting metrics stream on %s:%d.", address, port)
+	err = server.Serve(listener)
+	if err != nil {
+		return fmt.Errorf("Error running metrics stream server: %w", err)
+	}
+
+	return nil
+
+}
+
+// Rejects streams that don't provide the API token
+func authenticateStream(token string, logger log.ColorLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var providedToken string
+		md, exists := metadata.FromIncomingContext(stream.Context())
+		if exists {
+			if values := md.Get("authorization"); len(values) > 0 {
+				providedToken = strings.TrimPrefix(values[0], "Bearer ")
+			}
+		}
+		if subtle.ConstantTimeCompare([]byte(providedToken), []byte(token)) != 1 {
+			logger.Printlnf("Rejected unauthenticated metrics stream subscription to %s.", info.FullMethod)
+			return status.Error(codes.Unauthenticated, "missing or invalid API token")
+		}
+		return handler(srv, stream)
+	}
+}
+
+// Decodes a subscription request and passes it to the service
+func subscribeHandler(srv interface{}, stream grpc.ServerStream) error {
+	request := &structpb.Struct{}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	return srv.(metricsStreamService).subscribe(request, stream)
+}
+
+// Send metric updates to a subscriber until it disconnects
+func (s *metricsStreamServer) subscribe(request *structpb.Struct, stream grpc.ServerStream) error {
+
+	// Parse the options
+	interval := defaultMetricsStreamInterval
+	var prefixes []string
+	if value, exists := request.GetFields()["intervalSeconds"]; exists {
+		interval = uint64(value.GetNumberValue())
+		if interval < minMetricsStreamInterval {
+			interval = minMetricsStreamInterval
+		}
+	}
+	if value, exists := request.GetFields()["prefixes"]; exists {
+		for _, prefix := range value.GetListValue().GetValues() {
+			prefixes = append(prefixes, prefix.GetStringValue())
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	var seq uint64
+	previous := map[string]float64{}
+	ids := &metricIds{
+		ids: map[string]uint64{},
+	}
+	for {
+		// Get the latest metrics
+		sample, err := s.sampler.sample(time.Duration(minMetricsStreamInterval) * time.Second)
+		if err != nil {
+			s.logger.Printlnf("Error gathering metrics for the stream: %s", err.Error())
+		} else {
+			current := filterMetrics(sample, prefixes)
+			keyframe := seq%metricsStreamKeyframeInterval == 0
+			update, changed := getMetricsDelta(previous, current, keyframe, ids)
+			if changed {
+				update.Fields["seq"] = structpb.NewNumberValue(float64(seq))
+				if err := stream.SendMsg(update); err != nil {
+					return err
+				}
+				seq++
+			}
+			previous = current
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+}
+
+// Get the latest sample of the registry, reusing the last one if it's newer than the max age
+func (s *metricsSampler) sample(maxAge time.Duration) (map[string]float64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.lastSample != nil && time.Since(s.lastTime) < maxAge {
+		return s.lastSample, nil
+	}
+
+	families, err := s.gatherer.Gather()
+	if err != nil && len(families) == 0 {
+		return nil, err
+	}
+
+	// Flatten each metric into a single value keyed by its name and labels
+	sample := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+			}
+			var labelSuffix string
+			if len(labels) > 0 {
+				labelSuffix = fmt.Sprintf("{%s}", strings.Join(labels, ","))
+			}
+			name := family.GetName()
+
+			switch {
+			case metric.Gauge != nil:
+				sample[name+labelSuffix] = metric.GetGauge().GetValue()
+			case metric.Counter != nil:
+				sample[name+labelSuffix] = metric.GetCounter().GetValue()
+			case metric.Untyped != nil:
+				sample[name+labelSuffix] = metric.GetUntyped().GetValue()
+			case metric.Summary != nil:
+				// Quantiles and buckets are too large to stream, so only the totals are sent
+				sample[name+"_sum"+labelSuffix] = metric.GetSummary().GetSampleSum()
+				sample[name+"_count"+labelSuffix] = float64(metric.GetSummary().GetSampleCount())
+			case metric.Histogram != nil:
+				sample[name+"_sum"+labelSuffix] = metric.GetHistogram().GetSampleSum()
+				sample[name+"_count"+labelSuffix] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	s.lastSample = sample
+	s.lastTime = time.Now()
+	return sample, nil
+}
+
+// Get the metrics that start with one of the prefixes, or all of them if there aren't any prefixes
+func filterMetrics(sample map[string]float64, prefixes []string) map[string]float64 {
+	filtered := make(map[string]float64, len(sample))
+	for key, value := range sample {
+		if len(prefixes) == 0 {
+			filtered[key] = value
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				filtered[key] = value
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// Build an update with the metrics that were added, changed, or removed since the previous sample, giving IDs to the
+// new ones. Keyframes include every metric. Returns false if there's nothing to send.
+func getMetricsDelta(previous map[string]float64, current map[string]float64, keyframe bool, ids *metricIds) (*structpb.Struct, bool) {
+	keys := make([]string, 0, len(current))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	names := map[string]*structpb.Value{}
+	changedIds := []*structpb.Value{}
+	values := []*structpb.Value{}
+	for _, key := range keys {
+		id, exists := ids.ids[key]
+		if !exists {
+			id = ids.nextId
+			ids.ids[key] = id
+			ids.nextId++
+		}
+		if keyframe || !exists {
+			names[fmt.Sprint(id)] = structpb.NewStringValue(key)
+		}
+		value := current[key]
+		previousValue, existed := previous[key]
+		if keyframe || !existed || previousValue != value {
+			changedIds = append(changedIds, structpb.NewNumberValue(float64(id)))
+			values = append(values, structpb.NewNumberValue(value))
+		}
+	}
+
+	// Removed metrics give up their IDs, so a metric that comes back is named again under a new one
+	removedKeys := []string{}
+	for key := range ids.ids {
+		if _, exists := current[key]; !exists {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+	removed := []*structpb.Value{}
+	for _, key := range removedKeys {
+		if _, existed := previous[key]; existed && !keyframe {
+			removed = append(removed, structpb.NewNumberValue(float64(ids.ids[key])))
+		}
+		delete(ids.ids, key)
+	}
+
+	if !keyframe && len(changedIds) == 0 && len(removed) == 0 {
+		return nil, false
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"keyframe": structpb.NewBoolValue(keyframe),
+			"names":    structpb.NewStructValue(&structpb.Struct{Fields: names}),
+			"ids":      structpb.NewListValue(&structpb.ListValue{Values: changedIds}),
+			"values":   structpb.NewListValue(&structpb.ListValue{Values: values}),
+			"removed":  structpb.NewListValue(&structpb.ListValue{Values: removed}),
+		},
+	}, true
+}
+
+// Make sure the server satisfies the service definition
+var _ metricsStreamService = (*metricsStreamServer)(nil)
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -15,12 +16,15 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -29,16 +33,46 @@ var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
 
+// How long a task that keeps failing with the same error goes before it's recorded in the journal again
+var taskErrorJournalInterval, _ = time.ParseDuration("6h")
+
+// The last error each task recorded in the journal, so repeated failures don't flood it
+type taskJournalError struct {
+	message string
+	time    time.Time
+}
+
+var lastTaskErrors = map[string]taskJournalError{}
+var lastTaskErrorsLock sync.Mutex
+
 const (
 	MaxConcurrentEth1Requests = 200
 
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	DownloadRewardsTreesColor    = color.FgGreen
 	MetricsColor                 = color.FgHiYellow
+	HttpApiColor                 = color.FgCyan
+	TrackValidatorStatusColor    = color.FgHiMagenta
+	RefundMinipoolsColor         = color.FgWhite
 	ManageFeeRecipientColor      = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DistributeMinipoolsColor     = color.FgHiGreen
+	ClaimRewardsColor            = color.FgGreen
+	CommissionUpgradesColor      = color.FgHiBlue
+	WatchProposalsColor          = color.FgHiGreen
+	CheckMevRelaysColor          = color.FgHiYellow
+	ReloadConfigColor            = color.FgHiCyan
+	AutoVotePdaoColor            = color.FgHiMagenta
+	WatchProtocolSettingsColor   = color.FgHiWhite
+	ManageGraffitiColor          = color.FgHiCyan
+	WatchRescueNodeColor         = color.FgHiBlue
+	RecordBalanceHistoryColor    = color.FgHiWhite
+	ManageDvtKeysColor           = color.FgHiGreen
+	CheckScrubRiskColor          = color.FgHiRed
+	CheckDoppelgangersColor      = color.FgHiRed
+	SweepFeeDistributorColor     = color.FgHiYellow
+	MinipoolPerformanceColor     = color.FgHiCyan
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -59,8 +93,32 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Get the config
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Configure logging
+	logSettings, err := cfg.Smartnode.GetLogSettings("node")
+	if err != nil {
+		return fmt.Errorf("error getting log settings: %w", err)
+	}
+	if err := log.Configure(logSettings); err != nil {
+		return fmt.Errorf("error configuring logging: %w", err)
+	}
+
+	// Initialize loggers
+	errorLog := log.NewErrorLogger("node", ErrorColor)
+	warningLog := log.NewWarningLogger("node", WarningColor)
+	updateLog := log.NewModuleLogger("updates", UpdateColor)
+
+	// Check for a crash loop before doing anything that could fail again
+	isSafeMode := checkSafeMode(cfg, &warningLog)
+	go clearCrashCounterWhenStable(cfg, &errorLog)
+
 	// Handle the initial fee recipient file deployment
-	err := deployDefaultFeeRecipientFile(c)
+	err = deployDefaultFeeRecipientFile(c)
 	if err != nil {
 		return err
 	}
@@ -73,6 +131,11 @@ func run(c *cli.Context) error {
 
 	// Configure
 	configureHTTP()
+	if cfg.Smartnode.EnableEndpointAccessLog.Value == true {
+		if err := accesslog.Enable(cfg.Smartnode.GetEndpointAccessLogPath("node"), "node"); err != nil {
+			warningLog.Warnf("couldn't enable the endpoint access log: %s", err.Error())
+		}
+	}
 
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
@@ -80,10 +143,6 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -102,46 +161,75 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
-	// Initialize loggers
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	// Masquerading only tracks the node's address without its wallet, so the only tasks that can run are the routine
+	// ones the transactor key can send
+	isMasquerading := w.IsMasquerading()
+	isTransactorOnly := isMasquerading && w.HasTransactorKey()
+	if isTransactorOnly {
+		warningLog.Printlnf("The wallet is masquerading as node %s without its node wallet, so only the routine tasks that can use the transactor key will run.", nodeAccount.Address.Hex())
+	} else if isMasquerading {
+		warningLog.Printlnf("The wallet is masquerading as node %s, so the daemon is in read-only mode and automatic tasks are disabled.", nodeAccount.Address.Hex())
+	}
 
-	// Create the state manager
-	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
+	// Include any monitored nodes in the network state so their metrics are available
+	stateNodeAddresses, err := getMetricsNodeAddresses(cfg, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
-	stateLocker := collectors.NewStateLocker()
 
-	// Initialize tasks
-	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
+	// Make it clear if the daemon is running against overridden contracts
+	overrides, err := cfg.Smartnode.GetContractAddressOverrides()
+	if err != nil {
+		return fmt.Errorf("error parsing contract address overrides: %w", err)
+	}
+	if len(overrides) > 0 {
+		warningLog.Warn("contract address overrides are active! The following contracts will use custom addresses instead of the built-in ones:")
+		for name, address := range overrides {
+			warningLog.Printlnf("\t%s: %s", name, address.Hex())
+		}
+	}
+
+	// Create the state manager; validator statuses are requested in batches so large nodes don't time out
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, eth2.GetValidatorStatusBatcher(bc), &updateLog)
 	if err != nil {
 		return err
 	}
-	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor))
+	stateLocker := collectors.NewStateLocker()
+
+	// Restore the last network state so the metrics are available before the first update completes
+	snapshotPath := cfg.Smartnode.GetNetworkStateSnapshotPath()
+	snapshot, err := stateLocker.LoadSnapshot(snapshotPath, cfg.Smartnode.GetChainID())
+	if err == nil {
+		updateLog.Printlnf("Restored the network state from slot %d; it will be marked as stale until it has been refreshed.", snapshot.BeaconSlotNumber)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		warningLog.Warnf("couldn't restore the saved network state, metrics will be unavailable until it has been refreshed: %s", err.Error())
+	}
+
+	// Initialize tasks
+	tasks, err := createTasks(c)
 	if err != nil {
 		return err
 	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	reloader, err := newConfigReloader(c, log.NewModuleLogger("reload-config", ReloadConfigColor))
 	if err != nil {
 		return err
 	}
-	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
+	watchProposals, err := newWatchProposals(c, log.NewModuleLogger("watch-proposals", WatchProposalsColor), nodeAccount.Address, stateLocker)
 	if err != nil {
 		return err
 	}
-	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor))
+	watchRescueNode, err := newWatchRescueNode(c, log.NewModuleLogger("watch-rescue-node", WatchRescueNodeColor))
 	if err != nil {
 		return err
 	}
-	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
+	recordBalanceHistory, err := newRecordBalanceHistory(c, log.NewModuleLogger("record-balance-history", RecordBalanceHistoryColor), stateNodeAddresses)
 	if err != nil {
 		return err
 	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(3)
 
 	// Timestamp for caching total effective RPL stake
 	lastTotalEffectiveStakeTime := time.Unix(0, 0)
@@ -149,6 +237,7 @@ func run(c *cli.Context) error {
 	// Run task loop
 	isAtlasDeployedMasterFlag := false
 	go func() {
+		defer wg.Done()
 		for {
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
@@ -172,13 +261,19 @@ func run(c *cli.Context) error {
 				updateTotalEffectiveStake = true
 				lastTotalEffectiveStakeTime = time.Now() // Even if the call below errors out, this will prevent contant errors related to this flag
 			}
-			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
+			updateStart := time.Now()
+			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, stateNodeAddresses, updateTotalEffectiveStake)
+			collectors.RecordTaskRun("update_network_state", updateStart, err)
 			if err != nil {
 				errorLog.Println(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
 			stateLocker.UpdateState(state, totalEffectiveStake)
+			err = stateLocker.SaveSnapshot(snapshotPath, cfg.Smartnode.GetChainID())
+			if err != nil {
+				errorLog.Printlnf("Error saving network state snapshot: %s", err.Error())
+			}
 
 			// Check for Atlas
 			if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
@@ -186,61 +281,322 @@ func run(c *cli.Context) error {
 				isAtlasDeployedMasterFlag = true
 			}
 
-			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
-				errorLog.Println(err)
+			// Take the daily balance snapshots; this only reads the state, so it runs in safe mode and while masquerading too
+			runTask(c, "record_balance_history", recordBalanceHistory, state, &errorLog)
+
+			// Don't run any automatic tasks in safe mode
+			if isSafeMode {
+				warningLog.Println("The node daemon is running in safe mode, so automatic tasks are disabled. Restart the daemon once you've fixed the cause of the crashes.")
+				time.Sleep(reloader.getTaskInterval())
+				continue
 			}
+
+			// Apply a reloaded config to the tasks that read their settings when they're created
+			if reloader.takeTasksStale() {
+				err := tasks.applyReloadedConfig(c)
+				if err != nil {
+					errorLog.Printlnf("Error applying the reloaded config to the tasks, they will keep their previous settings: %s", err.Error())
+				}
+			}
+
+			// Without the node wallet, only the routine tasks run
+			if isMasquerading {
+				if isTransactorOnly {
+					runTask(c, "sweep_fee_distributor", tasks.sweepFeeDistributor, state, &errorLog)
+				}
+				time.Sleep(reloader.getTaskInterval())
+				continue
+			}
+
+			// Check for validator status changes
+			runTask(c, "track_validator_status", tasks.trackValidatorStatus, state, &errorLog)
+
+			// Check if the validators are attesting from another machine while the local validator client isn't
+			runTask(c, "check_doppelgangers", tasks.checkDoppelgangers, state, &errorLog)
+
+			// Score the minipools' attestations and proposals against the network for the metrics
+			runTask(c, "score_minipool_performance", tasks.scorePerformance, state, &errorLog)
+
+			// Manage the fee recipient for the node
+			runTask(c, "manage_fee_recipient", tasks.manageFeeRecipient, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Keep the validators' graffiti up to date
+			runTask(c, "manage_graffiti", tasks.manageGraffiti, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Split new minipools' keys into distributed validator clusters
+			runTask(c, "manage_dvt_keys", tasks.manageDvtKeys, state, &errorLog)
 			time.Sleep(taskCooldown)
 
 			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(c, "download_rewards_trees", tasks.downloadRewardsTrees, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Run the rewards claim check
+			runTask(c, "claim_rewards", tasks.claimRewards, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Check the prelaunch minipools for anything the Oracle DAO would scrub them for
+			runTask(c, "check_scrub_risk", tasks.checkScrubRisk, state, &errorLog)
 			time.Sleep(taskCooldown)
 
 			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(c, "stake_prelaunch_minipools", tasks.stakePrelaunchMinipools, state, &errorLog)
 			time.Sleep(taskCooldown)
 
 			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(c, "distribute_minipools", tasks.distributeMinipools, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Run the fee distributor sweep check
+			runTask(c, "sweep_fee_distributor", tasks.sweepFeeDistributor, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Run the minipool refund check
+			runTask(c, "refund_minipools", tasks.refundMinipools, state, &errorLog)
 			time.Sleep(taskCooldown)
 
 			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(c, "reduce_bonds", tasks.reduceBonds, state, &errorLog)
 			time.Sleep(taskCooldown)
 
 			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(c, "promote_minipools", tasks.promoteMinipools, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Check for actions that would raise the node's commission
+			runTask(c, "check_commission_upgrades", tasks.checkCommissionUpgrades, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Check the MEV-boost relays and the validators' registrations with them
+			runTask(c, "check_mev_relays", tasks.checkMevRelays, state, &errorLog)
+			time.Sleep(taskCooldown)
 
-			time.Sleep(tasksInterval)
+			// Copy the auto-vote delegate's protocol DAO votes
+			runTask(c, "auto_vote_pdao", tasks.autoVotePdao, state, &errorLog)
+			time.Sleep(taskCooldown)
+
+			// Check for changes to the protocol settings
+			runTask(c, "watch_protocol_settings", tasks.watchProtocolSettings, state, &errorLog)
+
+			time.Sleep(reloader.getTaskInterval())
 		}
-		wg.Done()
 	}()
 
+	// Watch for proposals by the node's validators
+	go watchProposals.run()
+
+	// Detach from the Rescue Node when its window closes
+	go watchRescueNode.run()
+
+	// Reload the config on request
+	go reloader.listenForSignals()
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewModuleLogger("metrics", MetricsColor), stateLocker)
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Run the HTTP API
+	go func() {
+		err := runHttpApiServer(c, log.NewModuleLogger("http-api", HttpApiColor), stateLocker, reloader)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 
 }
 
+// A periodic task run by the node daemon
+type task interface {
+	run(state *state.NetworkState) error
+}
+
+// The tasks run on each pass of the task loop
+type daemonTasks struct {
+	manageFeeRecipient      *manageFeeRecipient
+	distributeMinipools     *distributeMinipools
+	stakePrelaunchMinipools *stakePrelaunchMinipools
+	promoteMinipools        *promoteMinipools
+	downloadRewardsTrees    *downloadRewardsTrees
+	reduceBonds             *reduceBonds
+	trackValidatorStatus    *trackValidatorStatus
+	refundMinipools         *refundMinipools
+	claimRewards            *claimRewards
+	checkCommissionUpgrades *checkCommissionUpgrades
+	checkMevRelays          *checkMevRelays
+	autoVotePdao            *autoVotePdao
+	watchProtocolSettings   *watchProtocolSettings
+	manageGraffiti          *manageGraffiti
+	manageDvtKeys           *manageDvtKeys
+	checkScrubRisk          *checkScrubRisk
+	checkDoppelgangers      *checkDoppelgangers
+	sweepFeeDistributor     *sweepFeeDistributor
+	scorePerformance        *scoreMinipoolPerformance
+}
+
+// Create the tasks with the current config
+func createTasks(c *cli.Context) (*daemonTasks, error) {
+	var err error
+	tasks := &daemonTasks{}
+	tasks.manageFeeRecipient, err = newManageFeeRecipient(c, log.NewModuleLogger("manage-fee-recipient", ManageFeeRecipientColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.distributeMinipools, err = newDistributeMinipools(c, log.NewModuleLogger("distribute-minipools", DistributeMinipoolsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.stakePrelaunchMinipools, err = newStakePrelaunchMinipools(c, log.NewModuleLogger("stake-prelaunch-minipools", StakePrelaunchMinipoolsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.promoteMinipools, err = newPromoteMinipools(c, log.NewModuleLogger("promote-minipools", PromoteMinipoolsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.downloadRewardsTrees, err = newDownloadRewardsTrees(c, log.NewModuleLogger("download-rewards-trees", DownloadRewardsTreesColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.reduceBonds, err = newReduceBonds(c, log.NewModuleLogger("reduce-bonds", ReduceBondAmountColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.trackValidatorStatus, err = newTrackValidatorStatus(c, log.NewModuleLogger("track-validator-status", TrackValidatorStatusColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.refundMinipools, err = newRefundMinipools(c, log.NewModuleLogger("refund-minipools", RefundMinipoolsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.claimRewards, err = newClaimRewards(c, log.NewModuleLogger("claim-rewards", ClaimRewardsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.checkCommissionUpgrades, err = newCheckCommissionUpgrades(c, log.NewModuleLogger("check-commission-upgrades", CommissionUpgradesColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.checkMevRelays, err = newCheckMevRelays(c, log.NewModuleLogger("check-mev-relays", CheckMevRelaysColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.autoVotePdao, err = newAutoVotePdao(c, log.NewModuleLogger("auto-vote-pdao", AutoVotePdaoColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.watchProtocolSettings, err = newWatchProtocolSettings(c, log.NewModuleLogger("watch-protocol-settings", WatchProtocolSettingsColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.manageGraffiti, err = newManageGraffiti(c, log.NewModuleLogger("manage-graffiti", ManageGraffitiColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.manageDvtKeys, err = newManageDvtKeys(c, log.NewModuleLogger("manage-dvt-keys", ManageDvtKeysColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.checkScrubRisk, err = newCheckScrubRisk(c, log.NewModuleLogger("check-scrub-risk", CheckScrubRiskColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.checkDoppelgangers, err = newCheckDoppelgangers(c, log.NewModuleLogger("check-doppelgangers", CheckDoppelgangersColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.sweepFeeDistributor, err = newSweepFeeDistributor(c, log.NewModuleLogger("sweep-fee-distributor", SweepFeeDistributorColor))
+	if err != nil {
+		return nil, err
+	}
+	tasks.scorePerformance, err = newScoreMinipoolPerformance(c, log.NewModuleLogger("score-minipool-performance", MinipoolPerformanceColor))
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Recreate the tasks that copy their gas and threshold settings from the config when they're created.
+// The others read the config on every run, or only keep state that shouldn't be reset.
+func (t *daemonTasks) applyReloadedConfig(c *cli.Context) error {
+	distributeMinipools, err := newDistributeMinipools(c, log.NewModuleLogger("distribute-minipools", DistributeMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewModuleLogger("stake-prelaunch-minipools", StakePrelaunchMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	promoteMinipools, err := newPromoteMinipools(c, log.NewModuleLogger("promote-minipools", PromoteMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	reduceBonds, err := newReduceBonds(c, log.NewModuleLogger("reduce-bonds", ReduceBondAmountColor))
+	if err != nil {
+		return err
+	}
+	refundMinipools, err := newRefundMinipools(c, log.NewModuleLogger("refund-minipools", RefundMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	claimRewards, err := newClaimRewards(c, log.NewModuleLogger("claim-rewards", ClaimRewardsColor))
+	if err != nil {
+		return err
+	}
+	sweepFeeDistributor, err := newSweepFeeDistributor(c, log.NewModuleLogger("sweep-fee-distributor", SweepFeeDistributorColor))
+	if err != nil {
+		return err
+	}
+
+	t.distributeMinipools = distributeMinipools
+	t.stakePrelaunchMinipools = stakePrelaunchMinipools
+	t.promoteMinipools = promoteMinipools
+	t.reduceBonds = reduceBonds
+	t.refundMinipools = refundMinipools
+	t.claimRewards = claimRewards
+	t.sweepFeeDistributor = sweepFeeDistributor
+	return nil
+}
+
+// Run a task, logging any error and recording the result for the task loop metrics and the event journal.
+// A task that keeps failing with the same error is only recorded in the journal again once taskErrorJournalInterval has passed.
+func runTask(c *cli.Context, name string, t task, state *state.NetworkState, errorLog *log.ColorLogger) {
+	start := time.Now()
+	err := t.run(state)
+	collectors.RecordTaskRun(name, start, err)
+
+	lastTaskErrorsLock.Lock()
+	defer lastTaskErrorsLock.Unlock()
+	if err == nil {
+		delete(lastTaskErrors, name)
+		return
+	}
+
+	errorLog.Errorw("Task failed", log.Any("task", name), log.Err(err))
+	message := fmt.Sprintf("Task %s failed: %s", name, err.Error())
+	if last, exists := lastTaskErrors[name]; exists && last.message == message && time.Since(last.time) < taskErrorJournalInterval {
+		return
+	}
+	lastTaskErrors[name] = taskJournalError{message: message, time: time.Now()}
+	services.RecordEvent(c, journal.Event{
+		Type:      journal.EventType_DaemonError,
+		Automatic: true,
+		Message:   message,
+	})
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -338,14 +694,13 @@ func printAtlasMessage(log *log.ColorLogger) {
 * |    DECENTRALISED STAKING PROTOCOL FOR ETHEREUM    |
 * +---------------------------------------------------+
 *
-* ================ Atlas has launched! ================
-`)
+* ================ Atlas has launched! ================`)
 }
 
 // Update the latest network state at each cycle
-func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, nodeAddress common.Address, calculateTotalEffectiveStake bool) (*state.NetworkState, *big.Int, error) {
+func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, nodeAddresses []common.Address, calculateTotalEffectiveStake bool) (*state.NetworkState, *big.Int, error) {
 	// Get the state of the network
-	state, totalEffectiveStake, err := m.GetHeadStateForNode(nodeAddress, calculateTotalEffectiveStake)
+	state, totalEffectiveStake, err := m.GetHeadStateForNodes(nodeAddresses, calculateTotalEffectiveStake)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error updating network state: %w", err)
 	}
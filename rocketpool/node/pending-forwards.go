@@ -0,0 +1,124 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The funds the tasks forward from the node wallet
+const (
+	pendingForward_FeeDistributorEth string = "feeDistributorEth"
+	pendingForward_AutoClaimRpl      string = "autoClaimRpl"
+	pendingForward_AutoClaimEth      string = "autoClaimEth"
+)
+
+// The amounts that landed on the node wallet but haven't been forwarded yet, in wei, persisted across restarts so a
+// forward that fails is retried on later runs instead of being left for the user to notice
+type pendingForwards struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create the store for the pending forwards
+func newPendingForwards(path string) *pendingForwards {
+	return &pendingForwards{
+		path: path,
+	}
+}
+
+// Get the amount still to be forwarded for some funds
+func (p *pendingForwards) get(name string) (*big.Int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	amounts, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	return amounts[name], nil
+}
+
+// Add to the amount still to be forwarded for some funds
+func (p *pendingForwards) add(name string, amount *big.Int) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	amounts, err := p.load()
+	if err != nil {
+		return err
+	}
+	amounts[name] = big.NewInt(0).Add(amounts[name], amount)
+	return p.save(amounts)
+}
+
+// Take an amount that has been forwarded off of what's still to be forwarded for some funds
+func (p *pendingForwards) subtract(name string, amount *big.Int) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	amounts, err := p.load()
+	if err != nil {
+		return err
+	}
+	remaining := big.NewInt(0).Sub(amounts[name], amount)
+	if remaining.Sign() < 0 {
+		remaining.SetUint64(0)
+	}
+	amounts[name] = remaining
+	return p.save(amounts)
+}
+
+// Load the pending amounts; a missing file means nothing is pending
+func (p *pendingForwards) load() (map[string]*big.Int, error) {
+	amounts := map[string]*big.Int{
+		pendingForward_FeeDistributorEth: big.NewInt(0),
+		pendingForward_AutoClaimRpl:      big.NewInt(0),
+		pendingForward_AutoClaimEth:      big.NewInt(0),
+	}
+	bytes, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return amounts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pending forwards [%s]: %w", p.path, err)
+	}
+
+	saved := map[string]string{}
+	if err := yaml.Unmarshal(bytes, &saved); err != nil {
+		return nil, fmt.Errorf("error parsing pending forwards [%s]: %w", p.path, err)
+	}
+	for name, amountString := range saved {
+		amount, ok := big.NewInt(0).SetString(amountString, 10)
+		if !ok {
+			return nil, fmt.Errorf("pending forwards [%s] has an invalid amount for %s: %s", p.path, name, amountString)
+		}
+		amounts[name] = amount
+	}
+	return amounts, nil
+}
+
+// Save the pending amounts, leaving out the ones that have been forwarded
+func (p *pendingForwards) save(amounts map[string]*big.Int) error {
+	saved := map[string]string{}
+	for name, amount := range amounts {
+		if amount.Sign() > 0 {
+			saved[name] = amount.String()
+		}
+	}
+	data, err := yaml.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("error serializing pending forwards: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return fmt.Errorf("error creating data directory: %w", err)
+	}
+	if err := os.WriteFile(p.path, data, 0644); err != nil {
+		return fmt.Errorf("error saving pending forwards [%s]: %w", p.path, err)
+	}
+	return nil
+}
@@ -60,7 +60,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		return nil, err
 	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_PromoteMinipools)
 
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
@@ -75,7 +75,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -135,7 +135,7 @@ func (t *promoteMinipools) run(state *state.NetworkState) error {
 	for _, mpd := range minipools {
 		_, err := t.promoteMinipool(mpd, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not promote minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.Errorw("Could not promote minipool", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Err(err))
 			return err
 		}
 	}
@@ -169,7 +169,7 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 			if remainingTime < 0 {
 				vacantMinipools = append(vacantMinipools, mpd)
 			} else {
-				t.log.Printlnf("Minipool %s has %s left until it can be promoted.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.Infow("Minipool can't be promoted yet.", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("remaining", remainingTime.String()))
 			}
 		}
 	}
@@ -183,7 +183,7 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Promoting minipool %s...", mpd.MinipoolAddress.Hex())
+	t.log.Infow("Promoting minipool...", log.Any("minipool", mpd.MinipoolAddress.Hex()))
 
 	// Get the updated minipool interface
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
@@ -216,7 +216,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -255,7 +255,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	}
 
 	// Log
-	t.log.Printlnf("Successfully promoted minipool %s.", mpd.MinipoolAddress.Hex())
+	t.log.Infow("Successfully promoted minipool.", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("tx", hash.Hex()))
 
 	// Return
 	return true, nil
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record balance history task
+type recordBalanceHistory struct {
+	log           log.ColorLogger
+	store         *balancehistory.Store
+	nodeAddresses []common.Address
+
+	// The day each node was last recorded on, so the file is only read once per node per day
+	lastRecorded map[common.Address]string
+}
+
+// Create record balance history task
+func newRecordBalanceHistory(c *cli.Context, logger log.ColorLogger, nodeAddresses []common.Address) (*recordBalanceHistory, error) {
+
+	// Get services
+	store, err := services.GetBalanceHistory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &recordBalanceHistory{
+		log:           logger,
+		store:         store,
+		nodeAddresses: nodeAddresses,
+		lastRecorded:  map[common.Address]string{},
+	}, nil
+
+}
+
+// Take today's balance snapshot of each node if it hasn't been taken yet
+func (t *recordBalanceHistory) run(state *state.NetworkState) error {
+	now := time.Now()
+	today := balancehistory.GetDate(now)
+	for _, nodeAddress := range t.nodeAddresses {
+		if t.lastRecorded[nodeAddress] == today {
+			continue
+		}
+
+		snapshot, err := getBalanceSnapshot(state, nodeAddress, now)
+		if err != nil {
+			return fmt.Errorf("error getting balance snapshot for node %s: %w", nodeAddress.Hex(), err)
+		}
+		recorded, err := t.store.Record(snapshot)
+		if err != nil {
+			return fmt.Errorf("error recording balance snapshot for node %s: %w", nodeAddress.Hex(), err)
+		}
+		if recorded {
+			t.log.Printlnf("Recorded the balance snapshot for node %s for %s.", nodeAddress.Hex(), today)
+		}
+		t.lastRecorded[nodeAddress] = today
+	}
+	return nil
+}
+
+// Get a snapshot of a node's balances from the network state
+func getBalanceSnapshot(state *state.NetworkState, nodeAddress common.Address, now time.Time) (balancehistory.Snapshot, error) {
+	nd, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return balancehistory.Snapshot{}, fmt.Errorf("the node isn't in the network state yet")
+	}
+
+	// Add up the Beacon balances of the active minipools
+	activeMinipools := 0
+	beaconBalanceGwei := uint64(0)
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		activeMinipools++
+		if validator, exists := state.ValidatorDetails[mpd.Pubkey]; exists {
+			beaconBalanceGwei += validator.Balance
+		}
+	}
+
+	// The collateral ratio is the value of the staked RPL as a fraction of the borrowed ETH
+	stakedRpl := eth.WeiToEth(nd.RplStake)
+	collateralRatio := float64(0)
+	borrowedEth := eth.WeiToEth(nd.EthMatched)
+	if borrowedEth > 0 {
+		collateralRatio = eth.WeiToEth(state.NetworkDetails.RplPrice) * stakedRpl / borrowedEth
+	}
+
+	return balancehistory.Snapshot{
+		Time:            now,
+		Node:            nodeAddress,
+		EthBalance:      eth.WeiToEth(nd.BalanceETH),
+		RplBalance:      eth.WeiToEth(nd.BalanceRPL) + eth.WeiToEth(nd.BalanceOldRPL),
+		RethBalance:     eth.WeiToEth(nd.BalanceRETH),
+		StakedRpl:       stakedRpl,
+		BeaconBalance:   float64(beaconBalanceGwei) / 1e9,
+		ActiveMinipools: activeMinipools,
+		CollateralRatio: collateralRatio,
+	}, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -73,10 +74,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	}
 
 	// Check if auto-bond-reduction is disabled
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_ReduceBonds)
 	disabled := false
 	if gasThreshold == 0 {
-		logger.Println("Automatic tx gas threshold is 0, disabling auto-reduce.")
+		logger.Println("Automatic tx gas threshold (or this task's gas ceiling) is 0, disabling auto-reduce.")
 		disabled = true
 	}
 
@@ -93,7 +94,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -180,7 +181,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	for _, mp := range minipools {
 		success, err := t.reduceBond(mp, windowStart, windowLength, latestBlockTime, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("could not reduce bond for minipool %s: %w", mp.MinipoolAddress.Hex(), err))
+			t.log.Errorw("Could not reduce bond for minipool", log.Any("minipool", mp.MinipoolAddress.Hex()), log.Err(err))
 			return err
 		}
 		if success {
@@ -247,7 +248,8 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	t.log.Printlnf("\tYour withdrawal address will receive %.6f ETH.", nodeShare)
 	t.log.Printlnf("\trETH pool stakers will receive %.6f ETH.\n", rEthShare)
 
-	opts, err := t.w.GetNodeAccountTransactor()
+	// Anyone can distribute the fee distributor, so this can use the transactor key
+	opts, err := t.w.GetRoutineTransactor()
 	if err != nil {
 		return false, err
 	}
@@ -271,7 +273,7 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -332,7 +334,7 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 				reduceableMinipools = append(reduceableMinipools, mpd)
 			} else {
 				remainingTime := windowStart - timeSinceReductionStart
-				t.log.Printlnf("Minipool %s has %s left until it can have its bond reduced.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.Infow("Minipool can't have its bond reduced yet.", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("remaining", remainingTime.String()))
 			}
 		}
 	}
@@ -346,7 +348,7 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
+	t.log.Infow("Reducing bond for minipool...", log.Any("minipool", mpd.MinipoolAddress.Hex()))
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -381,7 +383,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -418,7 +420,14 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	}
 
 	// Log
-	t.log.Printlnf("Successfully reduced bond for minipool %s.", mpd.MinipoolAddress.Hex())
+	t.log.Infow("Successfully reduced bond for minipool.", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("tx", hash.Hex()))
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_BondReduced,
+		Automatic: true,
+		Minipool:  &mpd.MinipoolAddress,
+		TxHash:    &hash,
+		Message:   fmt.Sprintf("Reduced the bond for minipool %s.", mpd.MinipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
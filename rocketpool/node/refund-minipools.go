@@ -0,0 +1,242 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Refund minipools task
+type refundMinipools struct {
+	c               *cli.Context
+	log             log.ColorLogger
+	cfg             *config.RocketPoolConfig
+	w               *wallet.Wallet
+	rp              *rocketpool.RocketPool
+	gasThreshold    float64
+	refundThreshold *big.Int
+	disabled        bool
+	maxFee          *big.Int
+	maxPriorityFee  *big.Int
+	gasLimit        uint64
+}
+
+// Create refund minipools task
+func newRefundMinipools(c *cli.Context, logger log.ColorLogger) (*refundMinipools, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-refunding is disabled
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_RefundMinipools)
+	refundThreshold := cfg.Smartnode.AutoRefundThreshold.Value.(float64)
+	disabled := false
+	if cfg.Smartnode.EnableAutoRefund.Value == false {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold (or this task's gas ceiling) is 0, disabling auto-refund.")
+		disabled = true
+	} else if refundThreshold <= 0 {
+		logger.Println("Auto-refund threshold is 0, disabling auto-refund.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &refundMinipools{
+		c:               c,
+		log:             logger,
+		cfg:             cfg,
+		w:               w,
+		rp:              rp,
+		gasThreshold:    gasThreshold,
+		refundThreshold: eth.EthToWei(refundThreshold),
+		disabled:        disabled,
+		maxFee:          maxFee,
+		maxPriorityFee:  priorityFee,
+		gasLimit:        0,
+	}, nil
+
+}
+
+// Refund minipools
+func (t *refundMinipools) run(state *state.NetworkState) error {
+
+	// Check if auto-refund is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for minipools to refund...")
+
+	// Get the latest state
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get refundable minipools
+	minipools, totalRefund := t.getRefundableMinipools(nodeAccount.Address, state)
+	if len(minipools) == 0 {
+		return nil
+	}
+	if totalRefund.Cmp(t.refundThreshold) < 0 {
+		t.log.Printlnf("%d minipool(s) have a combined refund balance of %.6f ETH, which is below the auto-refund threshold of %.6f ETH.", len(minipools), eth.WeiToEth(totalRefund), eth.WeiToEth(t.refundThreshold))
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d minipool(s) have a combined refund balance of %.6f ETH, refunding...", len(minipools), eth.WeiToEth(totalRefund))
+
+	// Refund minipools
+	for _, mpd := range minipools {
+		success, err := t.refundMinipool(mpd, opts)
+		if err != nil {
+			t.log.Errorw("Could not refund minipool", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Err(err))
+			return err
+		}
+		if success {
+			collectors.RecordMinipoolRefund(mpd.NodeRefundBalance)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the minipools with a node refund balance, and the total amount they hold
+func (t *refundMinipools) getRefundableMinipools(nodeAddress common.Address, state *state.NetworkState) ([]*rpstate.NativeMinipoolDetails, *big.Int) {
+
+	refundableMinipools := []*rpstate.NativeMinipoolDetails{}
+	totalRefund := big.NewInt(0)
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Finalised || mpd.NodeRefundBalance == nil || mpd.NodeRefundBalance.Sign() == 0 {
+			continue
+		}
+		refundableMinipools = append(refundableMinipools, mpd)
+		totalRefund.Add(totalRefund, mpd.NodeRefundBalance)
+	}
+
+	// Return
+	return refundableMinipools, totalRefund
+
+}
+
+// Refund a minipool
+func (t *refundMinipools) refundMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
+
+	// Log
+	t.log.Infow("Refunding minipool...", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("refundEth", eth.WeiToEth(mpd.NodeRefundBalance)))
+
+	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
+	if err != nil {
+		return false, fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := mp.EstimateRefundGas(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to refund minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Refund minipool
+	hash, err := mp.Refund(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Infow("Successfully refunded minipool.", log.Any("minipool", mp.GetAddress().Hex()), log.Any("tx", hash.Hex()))
+
+	// Return
+	return true, nil
+
+}
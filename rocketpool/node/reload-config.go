@@ -0,0 +1,369 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The settings the node daemon can pick up without restarting, by config section.
+// Everything else is read once at startup, so changing it needs a restart.
+var reloadableSettings = map[string][]string{
+	"root": {
+		"useFallbackClients",
+	},
+	"smartnode": {
+		"manualMaxFee",
+		"priorityFee",
+		"minipoolStakeGasThreshold",
+		"distributeThreshold",
+		"enableAutoRefund",
+		"autoRefundThreshold",
+		"enableAutoClaim",
+		"autoClaimRplThreshold",
+		"autoClaimEthThreshold",
+		"autoClaimRestakePercent",
+		"autoClaimMaxFee",
+		"autoClaimForwardAddress",
+		"autoClaimForwardPercent",
+		"autoClaimOperatingFloat",
+		"autoCorrectFeeRecipient",
+		"taskInterval",
+		"gasOracle",
+		"taskGasCeilings",
+		"pdaoAutoVoteDelegate",
+	},
+	"fallbackNormal": {
+		"ecHttpUrl",
+		"ccHttpUrl",
+	},
+	"fallbackPrysm": {
+		"ecHttpUrl",
+		"ccHttpUrl",
+	},
+}
+
+// Settings that don't affect the node daemon, so changing them doesn't need a restart either.
+// The Rescue Node only changes the validator client, and its window is watched from the settings file directly.
+var ignoredSettings = map[string]bool{
+	"root.version":                  true,
+	"addons-rescue-node.enabled":    true,
+	"addons-rescue-node.username":   true,
+	"addons-rescue-node.password":   true,
+	"addons-rescue-node.domain":     true,
+	"addons-rescue-node.expiration": true,
+}
+
+// An entry in the config reload audit log
+type configReloadEntry struct {
+	Time            time.Time              `json:"time"`
+	Source          string                 `json:"source"`
+	Generation      uint64                 `json:"generation"`
+	Applied         []api.NodeConfigChange `json:"applied"`
+	RestartRequired []string               `json:"restartRequired"`
+	Warnings        []string               `json:"warnings,omitempty"`
+	Error           string                 `json:"error,omitempty"`
+}
+
+// Reloads the daemon's settings from the settings file while it's running
+type configReloader struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	ec  *services.ExecutionClientManager
+	bc  *services.BeaconClientManager
+
+	// The number of times the config has been applied, starting with 1 for the config the daemon started with
+	generation uint64
+
+	// Set when the tasks need to be recreated to pick up new settings
+	tasksStale bool
+
+	lock sync.Mutex
+}
+
+// Create the config reloader
+func newConfigReloader(c *cli.Context, logger log.ColorLogger) (*configReloader, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	collectors.RecordConfigGeneration(1)
+
+	// Return the reloader
+	return &configReloader{
+		c:          c,
+		log:        logger,
+		cfg:        cfg,
+		ec:         ec,
+		bc:         bc,
+		generation: 1,
+	}, nil
+
+}
+
+// Reload the config whenever the daemon receives a SIGHUP
+func (r *configReloader) listenForSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		_, _ = r.reload("SIGHUP")
+	}
+}
+
+// Load the settings file and apply the changes to the reloadable settings in place.
+// Changes to any other setting are reported, but only take effect once the daemon is restarted.
+func (r *configReloader) reload(source string) (*api.NodeConfigReloadResponse, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	response, err := r.applySettingsFile()
+	entry := configReloadEntry{
+		Time:            time.Now(),
+		Source:          source,
+		Generation:      r.generation,
+		Applied:         response.Applied,
+		RestartRequired: response.RestartRequired,
+		Warnings:        response.Warnings,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		r.log.Printlnf("Couldn't reload the config (requested by %s): %s", source, err.Error())
+	} else {
+		r.logReload(source, response)
+	}
+	collectors.RecordConfigReload(r.generation, err)
+
+	// Keep a record of every reload attempt
+	if auditErr := r.writeAuditEntry(entry); auditErr != nil {
+		r.log.Warnf("couldn't write the config reload audit log: %s", auditErr.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get the delay between each pass of the task loop
+func (r *configReloader) getTaskInterval() time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	interval, err := r.cfg.Smartnode.GetTaskInterval()
+	if err != nil {
+		return tasksInterval
+	}
+	return interval
+}
+
+// Check if the tasks need to be recreated to pick up new settings, clearing the flag
+func (r *configReloader) takeTasksStale() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stale := r.tasksStale
+	r.tasksStale = false
+	return stale
+}
+
+// Compare the settings file with the running config and apply the reloadable changes; the caller must hold the lock
+func (r *configReloader) applySettingsFile() (*api.NodeConfigReloadResponse, error) {
+	response := &api.NodeConfigReloadResponse{
+		Generation:      r.generation,
+		Applied:         []api.NodeConfigChange{},
+		RestartRequired: []string{},
+		Warnings:        []string{},
+	}
+
+	// Load and check the new settings
+	settingsFile := os.ExpandEnv(r.c.GlobalString("settings"))
+	newCfg, err := rputils.LoadConfigFromFile(settingsFile)
+	if err != nil {
+		return response, fmt.Errorf("error loading settings file [%s]: %w", settingsFile, err)
+	}
+	if newCfg == nil {
+		return response, fmt.Errorf("settings file [%s] not found", settingsFile)
+	}
+	if errors := newCfg.Validate(); len(errors) > 0 {
+		return response, fmt.Errorf("the new settings are invalid: %s", strings.Join(errors, " "))
+	}
+
+	// Find the changes
+	oldSettings := r.cfg.Serialize()
+	newSettings := newCfg.Serialize()
+	sections := []string{}
+	for section := range newSettings {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	// Apply the changes to a copy of the running config, which only replaces it once it's complete.
+	// The tasks, collectors, and HTTP API read the running config without a lock, so it's never changed in place.
+	updatedCfg := copyConfig(r.cfg)
+	changedFallback := false
+	for _, section := range sections {
+		ids := []string{}
+		for id := range newSettings[section] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			oldValue := oldSettings[section][id]
+			newValue := newSettings[section][id]
+			setting := fmt.Sprintf("%s.%s", section, id)
+			if oldValue == newValue || ignoredSettings[setting] {
+				continue
+			}
+			if !isReloadableSetting(section, id) {
+				response.RestartRequired = append(response.RestartRequired, setting)
+				continue
+			}
+
+			// Update the copy of the config
+			oldParam := getConfigParameter(updatedCfg, section, id)
+			newParam := getConfigParameter(newCfg, section, id)
+			if oldParam == nil || newParam == nil {
+				response.RestartRequired = append(response.RestartRequired, setting)
+				continue
+			}
+			oldParam.Value = newParam.Value
+			response.Applied = append(response.Applied, api.NodeConfigChange{
+				Setting:  setting,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+			if section != "smartnode" {
+				changedFallback = true
+			}
+		}
+	}
+	if len(response.Applied) == 0 {
+		return response, nil
+	}
+
+	// Swap in the new config; the tasks that copy their settings are recreated from it on their next run
+	r.cfg = updatedCfg
+	services.SetConfig(updatedCfg)
+
+	// Switch to the new fallback clients
+	if changedFallback {
+		if _, err := r.ec.UpdateFallbackClient(r.cfg); err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("couldn't switch to the new fallback Execution client: %s", err.Error()))
+		}
+		if _, err := r.bc.UpdateFallbackClient(r.cfg); err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("couldn't switch to the new fallback Beacon client: %s", err.Error()))
+		}
+	}
+
+	r.generation++
+	r.tasksStale = true
+	response.Generation = r.generation
+	return response, nil
+}
+
+// Log the result of a successful reload
+func (r *configReloader) logReload(source string, response *api.NodeConfigReloadResponse) {
+	if len(response.Applied) == 0 {
+		r.log.Printlnf("Reloaded the config (requested by %s); no reloadable settings changed.", source)
+	} else {
+		r.log.Printlnf("Reloaded the config (requested by %s); now running config generation %d.", source, response.Generation)
+		for _, change := range response.Applied {
+			r.log.Printlnf("\t%s: %s -> %s", change.Setting, change.OldValue, change.NewValue)
+		}
+	}
+	for _, warning := range response.Warnings {
+		r.log.Warnf("%s", warning)
+	}
+	if len(response.RestartRequired) > 0 {
+		r.log.Printlnf("The following settings were also changed, but the node daemon must be restarted to use them: %s", strings.Join(response.RestartRequired, ", "))
+	}
+}
+
+// Append an entry to the config reload audit log
+func (r *configReloader) writeAuditEntry(entry configReloadEntry) error {
+	path := r.cfg.Smartnode.GetNodeConfigReloadLogPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating data directory: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing audit entry: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Check if a setting can be changed without restarting the daemon
+func isReloadableSetting(section string, id string) bool {
+	for _, reloadableId := range reloadableSettings[section] {
+		if reloadableId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy a config, including the metadata that isn't stored in its parameters
+func copyConfig(cfg *config.RocketPoolConfig) *config.RocketPoolConfig {
+	cfgCopy := cfg.CreateCopy()
+	cfgCopy.Title = cfg.Title
+	cfgCopy.Version = cfg.Version
+	cfgCopy.SchemaVersion = cfg.SchemaVersion
+	cfgCopy.AppliedMigrations = cfg.AppliedMigrations
+	cfgCopy.UnrecognizedSettings = cfg.UnrecognizedSettings
+	return cfgCopy
+}
+
+// Get a parameter from a config by its section and ID
+func getConfigParameter(cfg *config.RocketPoolConfig, section string, id string) *cfgtypes.Parameter {
+	var params []*cfgtypes.Parameter
+	if section == "root" {
+		params = cfg.GetParameters()
+	} else {
+		subconfig, exists := cfg.GetSubconfigs()[section]
+		if !exists {
+			return nil
+		}
+		params = subconfig.GetParameters()
+	}
+	for _, param := range params {
+		if param.ID == id {
+			return param
+		}
+	}
+	return nil
+}
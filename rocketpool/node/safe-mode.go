@@ -0,0 +1,116 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+var crashLoopWindow, _ = time.ParseDuration("30m")
+var stableRunDuration, _ = time.ParseDuration("15m")
+
+// The safe mode status of the running daemon
+var safeModeStatus struct {
+	isSafeMode     bool
+	recentRestarts int
+	lock           sync.Mutex
+}
+
+// The start times of the node daemon, persisted across restarts to detect crash loops
+type crashCounter struct {
+	RecentStarts []time.Time `yaml:"recentStarts"`
+}
+
+// Record that the daemon has started, and check whether it has restarted often enough recently to run in safe mode
+func checkSafeMode(cfg *config.RocketPoolConfig, warningLog *log.ColorLogger) bool {
+
+	path := cfg.Smartnode.GetNodeCrashCounterPath()
+	threshold := int(cfg.Smartnode.SafeModeCrashThreshold.Value.(uint16))
+
+	// Load the previous start times; a missing or broken counter just starts over
+	var counter crashCounter
+	bytes, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		warningLog.Warnf("couldn't read the crash counter at %s: %s", path, err.Error())
+	} else if err == nil {
+		err = yaml.Unmarshal(bytes, &counter)
+		if err != nil {
+			warningLog.Warnf("couldn't parse the crash counter at %s, resetting it: %s", path, err.Error())
+			counter = crashCounter{}
+		}
+	}
+
+	// Only count the starts within the crash loop window
+	now := time.Now()
+	recentStarts := []time.Time{}
+	for _, start := range counter.RecentStarts {
+		if now.Sub(start) < crashLoopWindow {
+			recentStarts = append(recentStarts, start)
+		}
+	}
+	recentRestarts := len(recentStarts)
+
+	// Save this start
+	counter.RecentStarts = append(recentStarts, now)
+	err = saveCrashCounter(path, &counter)
+	if err != nil {
+		warningLog.Warnf("couldn't save the crash counter to %s: %s", path, err.Error())
+	}
+
+	isSafeMode := threshold > 0 && recentRestarts >= threshold
+	safeModeStatus.lock.Lock()
+	safeModeStatus.isSafeMode = isSafeMode
+	safeModeStatus.recentRestarts = recentRestarts
+	safeModeStatus.lock.Unlock()
+	collectors.RecordSafeMode(isSafeMode, recentRestarts)
+
+	if isSafeMode {
+		warningLog.Println("=== SAFE MODE ===")
+		warningLog.Printlnf("The node daemon has restarted %d times in the last %s, so it is starting in safe mode.", recentRestarts, crashLoopWindow)
+		warningLog.Println("All automatic tasks are disabled; only the metrics exporter and the node HTTP API will run.")
+		warningLog.Println("Please check the daemon's logs for the cause of the restarts, fix your configuration or remove any corrupted cache files, and restart the daemon.")
+		warningLog.Printlnf("Safe mode will be lifted on the next restart once the daemon has run for %s, or you can delete %s to lift it immediately.", stableRunDuration, path)
+	}
+	return isSafeMode
+
+}
+
+// Clear the crash counter once the daemon has been running without crashing for a while
+func clearCrashCounterWhenStable(cfg *config.RocketPoolConfig, errorLog *log.ColorLogger) {
+	time.Sleep(stableRunDuration)
+	path := cfg.Smartnode.GetNodeCrashCounterPath()
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		errorLog.Printlnf("Error clearing the crash counter at %s: %s", path, err.Error())
+	}
+}
+
+// Get the safe mode status of the running daemon
+func getSafeModeStatus() (bool, int) {
+	safeModeStatus.lock.Lock()
+	defer safeModeStatus.lock.Unlock()
+	return safeModeStatus.isSafeMode, safeModeStatus.recentRestarts
+}
+
+// Write the crash counter to disk
+func saveCrashCounter(path string, counter *crashCounter) error {
+	data, err := yaml.Marshal(counter)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating data directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
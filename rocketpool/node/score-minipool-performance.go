@@ -0,0 +1,106 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Score minipool performance task
+type scoreMinipoolPerformance struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	bc  beacon.Client
+
+	// Scoring reads every block in the window, so it runs in the background and only once per window
+	lock       sync.Mutex
+	isRunning  bool
+	lastScored time.Time
+}
+
+// Create score minipool performance task
+func newScoreMinipoolPerformance(c *cli.Context, logger log.ColorLogger) (*scoreMinipoolPerformance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &scoreMinipoolPerformance{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		bc:  bc,
+	}, nil
+
+}
+
+// Score the node's minipools against the network for the metrics, if the last scores are older than the scoring window
+func (t *scoreMinipoolPerformance) run(state *state.NetworkState) error {
+
+	// The scores are only used by the metrics
+	if t.cfg.EnableMetrics.Value != true {
+		return nil
+	}
+
+	// Check if it's time to score them again
+	window := time.Duration(rputils.DefaultPerformanceEpochs*state.BeaconConfig.SecondsPerEpoch) * time.Second
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.isRunning || time.Since(t.lastScored) < window {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	t.isRunning = true
+	go t.score(state, nodeAccount.Address)
+	return nil
+
+}
+
+// Score the minipools and hand the report to the metrics
+func (t *scoreMinipoolPerformance) score(state *state.NetworkState, nodeAddress common.Address) {
+	start := time.Now()
+	report, err := rputils.GetMinipoolPerformance(t.bc, state, nodeAddress, rputils.DefaultPerformanceEpochs)
+
+	// Failures wait for the next window too, so a Beacon node that can't serve the history isn't asked for it constantly
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.isRunning = false
+	t.lastScored = time.Now()
+	if err != nil {
+		t.log.Errorf("error scoring minipool performance: %s", err.Error())
+		return
+	}
+	collectors.SetMinipoolPerformance(report)
+	t.log.Printlnf("Scored %d minipool(s) over epochs %d to %d in %s (network attestation rate %.2f%%).", len(report.Minipools), report.StartEpoch, report.EndEpoch, time.Since(start).Round(time.Second), report.NetworkAttestationRate*100)
+}
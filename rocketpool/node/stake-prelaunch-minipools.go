@@ -16,10 +16,12 @@ import (
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -40,6 +42,7 @@ type stakePrelaunchMinipools struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+	scrubChecker   *scrubRiskChecker
 }
 
 // Create stake prelaunch minipools task
@@ -66,8 +69,12 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	scrubChecker, err := newScrubRiskChecker(c)
+	if err != nil {
+		return nil, err
+	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_StakePrelaunchMinipools)
 
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
@@ -82,7 +89,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -101,6 +108,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
+		scrubChecker:   scrubChecker,
 	}, nil
 
 }
@@ -139,12 +147,22 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
+	// Re-run the Oracle DAO's scrub checks right before staking
+	risks, err := t.scrubChecker.checkMinipools(state, minipools)
+	if err != nil {
+		return fmt.Errorf("error checking minipools for scrub risks before staking: %w", err)
+	}
+
 	// Stake minipools
 	successCount := 0
 	for _, mpd := range minipools {
+		if minipoolRisks, exists := risks[mpd.MinipoolAddress]; exists {
+			t.blockStake(mpd, minipoolRisks)
+			continue
+		}
 		success, err := t.stakeMinipool(mpd, state, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.Errorw("Could not stake minipool", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Err(err))
 			return err
 		}
 		if success {
@@ -190,7 +208,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 			if remainingTime < 0 {
 				prelaunchMinipools = append(prelaunchMinipools, mpd)
 			} else {
-				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.Infow("Minipool can't be staked yet.", log.Any("minipool", mpd.MinipoolAddress.Hex()), log.Any("remaining", remainingTime.String()))
 			}
 		}
 	}
@@ -204,7 +222,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Staking minipool %s...", mpd.MinipoolAddress.Hex())
+	t.log.Infow("Staking minipool...", log.Any("minipool", mpd.MinipoolAddress.Hex()))
 
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
 	if err != nil {
@@ -240,6 +258,16 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 		return false, err
 	}
 
+	// Make sure the deposit data would pass the scrub checks
+	risks, err := t.scrubChecker.checkStakeDepositData(state, mpd, depositData, depositDataRoot)
+	if err != nil {
+		return false, err
+	}
+	if len(risks) > 0 {
+		t.blockStake(mpd, risks)
+		return false, nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -262,7 +290,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -305,9 +333,28 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Log
-	t.log.Printlnf("Successfully staked minipool %s.", mp.GetAddress().Hex())
+	t.log.Infow("Successfully staked minipool.", log.Any("minipool", mp.GetAddress().Hex()), log.Any("tx", hash.Hex()))
+	minipoolAddress := mp.GetAddress()
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_MinipoolStaked,
+		Automatic: true,
+		Minipool:  &minipoolAddress,
+		TxHash:    &hash,
+		Message:   fmt.Sprintf("Staked minipool %s.", minipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
 
 }
+
+// Refuse to stake a minipool that failed the scrub checks, and raise the alert
+func (t *stakePrelaunchMinipools) blockStake(mpd *rpstate.NativeMinipoolDetails, risks []scrubRisk) {
+	checks := make([]string, 0, len(risks))
+	for _, risk := range risks {
+		checks = append(checks, risk.check)
+	}
+	collectors.RecordScrubRiskBlockedStake(mpd.MinipoolAddress, checks)
+	t.log.Errorw("ALERT: Minipool failed the scrub checks, so it will not be staked.", log.Any("minipool", mpd.MinipoolAddress.Hex()))
+	logScrubRisks(&t.log, mpd.MinipoolAddress, risks)
+}
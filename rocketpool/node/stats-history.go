@@ -0,0 +1,344 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	statsHistoryInterval time.Duration = 15 * time.Minute
+
+	// The expired samples are only removed from the file once a day, since it means rewriting the whole thing
+	statsHistoryCompactionInterval time.Duration = 24 * time.Hour
+
+	// The routes are served by the metrics exporter under this prefix
+	statsHistoryRoutePrefix string = "/grafana"
+)
+
+// The metrics recorded in the stats history; the rest are either too noisy or only useful live
+var statsHistoryPrefixes = []string{
+	"rocketpool_node_",
+	"rocketpool_rpl_",
+	"rocketpool_smoothing_pool_",
+	"rocketpool_beacon_",
+}
+
+// A sample of the node's stats, stored as one line of the history file
+type statsHistoryRecord struct {
+	Time   int64              `json:"time"`
+	Values map[string]float64 `json:"values"`
+}
+
+// A query from Grafana's JSON datasource
+type statsHistoryQuery struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+	MaxDataPoints int `json:"maxDataPoints"`
+}
+
+// A series for Grafana's JSON datasource; each datapoint is a value and a timestamp in milliseconds
+type statsHistorySeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// A single point of a series for Grafana's Infinity datasource
+type statsHistoryPoint struct {
+	Time  int64   `json:"time"`
+	Value float64 `json:"value"`
+}
+
+// Records samples of the node's key stats and serves them to Grafana
+type statsHistory struct {
+	path      string
+	retention time.Duration
+	sampler   *metricsSampler
+	logger    log.ColorLogger
+
+	records        []statsHistoryRecord
+	lastCompaction time.Time
+	lock           sync.RWMutex
+}
+
+// Create the stats history, loading the samples that have already been recorded
+func newStatsHistory(path string, retentionDays uint64, gatherer prometheus.Gatherer, logger log.ColorLogger) (*statsHistory, error) {
+	history := &statsHistory{
+		path:      path,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		sampler: &metricsSampler{
+			gatherer: gatherer,
+		},
+		logger: logger,
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening stats history [%s]: %w", path, err)
+	}
+	defer file.Close()
+
+	// Skip lines that can't be read, such as one that was cut off by a crash
+	skipped := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record statsHistoryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			skipped++
+			continue
+		}
+		history.records = append(history.records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stats history [%s]: %w", path, err)
+	}
+	if skipped > 0 {
+		logger.Warnf("skipped %d unreadable line(s) in the stats history.", skipped)
+	}
+	sort.SliceStable(history.records, func(i, j int) bool {
+		return history.records[i].Time < history.records[j].Time
+	})
+
+	// Drop anything that expired while the daemon was down
+	if err := history.compact(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Record a sample at a regular interval
+func (h *statsHistory) run() {
+	h.logger.Printlnf("Recording stats history to %s (%d samples loaded).", h.path, len(h.records))
+	for {
+		if err := h.record(); err != nil {
+			h.logger.Printlnf("Error recording stats history: %s", err.Error())
+		}
+		if time.Since(h.lastCompaction) > statsHistoryCompactionInterval {
+			if err := h.compact(); err != nil {
+				h.logger.Printlnf("Error removing expired stats history: %s", err.Error())
+			}
+		}
+		time.Sleep(statsHistoryInterval)
+	}
+}
+
+// Take a sample of the stats and append it to the history
+func (h *statsHistory) record() error {
+	sample, err := h.sampler.sample(time.Duration(minMetricsStreamInterval) * time.Second)
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+	values := filterMetrics(sample, statsHistoryPrefixes)
+	if len(values) == 0 {
+		return nil
+	}
+	record := statsHistoryRecord{
+		Time:   time.Now().Unix(),
+		Values: values,
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing stats sample: %w", err)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("error creating stats history folder: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening stats history [%s]: %w", h.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing stats history [%s]: %w", h.path, err)
+	}
+	h.records = append(h.records, record)
+	return nil
+}
+
+// Remove the samples that are older than the retention period, rewriting the file if there were any
+func (h *statsHistory) compact() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.lastCompaction = time.Now()
+
+	cutoff := time.Now().Add(-h.retention).Unix()
+	expired := sort.Search(len(h.records), func(i int) bool {
+		return h.records[i].Time >= cutoff
+	})
+	if expired == 0 {
+		return nil
+	}
+	h.records = append([]statsHistoryRecord{}, h.records[expired:]...)
+
+	// Write to a temp file first so a crash can't lose the whole history
+	var builder strings.Builder
+	for _, record := range h.records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("error serializing stats sample: %w", err)
+		}
+		builder.Write(line)
+		builder.WriteByte('\n')
+	}
+	tempPath := h.path + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("error writing stats history [%s]: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, h.path); err != nil {
+		return fmt.Errorf("error moving stats history into place [%s]: %w", h.path, err)
+	}
+	return nil
+}
+
+// Get the names of every stat in the history that contains the filter
+func (h *statsHistory) getTargets(filter string) []string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	seen := map[string]bool{}
+	targets := []string{}
+	for _, record := range h.records {
+		for name := range record.Values {
+			if !seen[name] && strings.Contains(name, filter) {
+				seen[name] = true
+				targets = append(targets, name)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// Get the samples of a stat between two times, keeping at most maxPoints of them (or all of them if it's 0)
+func (h *statsHistory) getSeries(target string, from time.Time, to time.Time, maxPoints int) []statsHistoryPoint {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	points := []statsHistoryPoint{}
+	for _, record := range h.records {
+		if record.Time < from.Unix() || record.Time > to.Unix() {
+			continue
+		}
+		if value, exists := record.Values[target]; exists {
+			points = append(points, statsHistoryPoint{
+				Time:  record.Time * 1000,
+				Value: value,
+			})
+		}
+	}
+
+	// Thin out long ranges evenly so Grafana doesn't have to
+	if maxPoints > 0 && len(points) > maxPoints {
+		thinned := make([]statsHistoryPoint, 0, maxPoints)
+		step := float64(len(points)) / float64(maxPoints)
+		for i := 0; i < maxPoints; i++ {
+			thinned = append(thinned, points[int(float64(i)*step)])
+		}
+		points = thinned
+	}
+	return points
+}
+
+// Register the Grafana routes on the metrics exporter.
+// "/", "/search" and "/query" follow the SimpleJSON protocol used by Grafana's JSON datasource, and "/series" returns
+// a single stat as a plain list of points for the Infinity datasource.
+func (h *statsHistory) registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(statsHistoryRoutePrefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		// Grafana's connection test
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc(statsHistoryRoutePrefix+"/search", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Target string `json:"target"`
+		}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&request)
+		}
+		writeStatsHistoryResponse(w, h.getTargets(request.Target))
+	})
+
+	mux.HandleFunc(statsHistoryRoutePrefix+"/query", func(w http.ResponseWriter, r *http.Request) {
+		var query statsHistoryQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, fmt.Sprintf("invalid query: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+		response := make([]statsHistorySeries, 0, len(query.Targets))
+		for _, target := range query.Targets {
+			points := h.getSeries(target.Target, query.Range.From, query.Range.To, query.MaxDataPoints)
+			series := statsHistorySeries{
+				Target:     target.Target,
+				Datapoints: make([][2]float64, len(points)),
+			}
+			for i, point := range points {
+				series.Datapoints[i] = [2]float64{point.Value, float64(point.Time)}
+			}
+			response = append(response, series)
+		}
+		writeStatsHistoryResponse(w, response)
+	})
+
+	mux.HandleFunc(statsHistoryRoutePrefix+"/series", func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		if target == "" {
+			http.Error(w, "missing target", http.StatusBadRequest)
+			return
+		}
+
+		// The range is given in milliseconds, which is how Grafana's ${__from} and ${__to} variables are formatted
+		from := time.Unix(0, 0)
+		to := time.Now()
+		if value := r.URL.Query().Get("from"); value != "" {
+			ms, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid from time: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+			from = time.UnixMilli(ms)
+		}
+		if value := r.URL.Query().Get("to"); value != "" {
+			ms, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid to time: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+			to = time.UnixMilli(ms)
+		}
+		writeStatsHistoryResponse(w, h.getSeries(target, from, to, 0))
+	})
+}
+
+// Write a JSON response to Grafana
+func writeStatsHistoryResponse(w http.ResponseWriter, response interface{}) {
+	bytes, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error serializing response: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(bytes)
+}
@@ -0,0 +1,310 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Sweep fee distributor task
+type sweepFeeDistributor struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasThreshold   float64
+	sweepThreshold *big.Int
+	forwardAddress *common.Address
+	pending        *pendingForwards
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+// Create sweep fee distributor task
+func newSweepFeeDistributor(c *cli.Context, logger log.ColorLogger) (*sweepFeeDistributor, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if sweeping is disabled
+	gasThreshold := cfg.Smartnode.GetTaskGasThreshold(config.Task_SweepFeeDistributor)
+	sweepThreshold := cfg.Smartnode.FeeDistributorSweepThreshold.Value.(float64)
+	disabled := false
+	if sweepThreshold <= 0 {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold (or this task's gas ceiling) is 0, disabling the fee distributor sweep.")
+		disabled = true
+	}
+
+	// Get the address to forward the node share to
+	var forwardAddress *common.Address
+	forwardAddressString := cfg.Smartnode.FeeDistributorSweepForwardAddress.Value.(string)
+	if forwardAddressString != "" {
+		if !common.IsHexAddress(forwardAddressString) {
+			return nil, fmt.Errorf("Fee distributor sweep forward address [%s] is not a valid address", forwardAddressString)
+		}
+		address := common.HexToAddress(forwardAddressString)
+		forwardAddress = &address
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &sweepFeeDistributor{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasThreshold:   gasThreshold,
+		sweepThreshold: eth.EthToWei(sweepThreshold),
+		forwardAddress: forwardAddress,
+		pending:        newPendingForwards(cfg.Smartnode.GetPendingForwardsPath()),
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+	}, nil
+
+}
+
+// Distribute the fee distributor's balance once it's above the threshold
+func (t *sweepFeeDistributor) run(state *state.NetworkState) error {
+
+	// Check if sweeping is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Check if Atlas has been deployed yet
+	if !state.IsAtlasDeployed {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check the distributor's balance
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return fmt.Errorf("node %s isn't in the network state", nodeAccount.Address.Hex())
+	}
+	if nodeDetails.FeeDistributorInitialised && nodeDetails.DistributorBalance.Cmp(t.sweepThreshold) >= 0 {
+
+		// Log
+		t.log.Printlnf("The fee distributor has a balance of %.6f ETH (%.6f ETH of which is yours), which is above the sweep threshold of %.6f ETH. Distributing...", eth.WeiToEth(nodeDetails.DistributorBalance), eth.WeiToEth(nodeDetails.DistributorBalanceNodeETH), eth.WeiToEth(t.sweepThreshold))
+
+		// Make sure the node share will go where it's expected to
+		if err := services.RequireWithdrawalAddressSafety(t.c); err != nil {
+			return err
+		}
+
+		// Distribute
+		success, err := t.distribute(nodeDetails.FeeDistributorAddress, nodeDetails.DistributorBalance, nodeDetails.DistributorBalanceNodeETH, state)
+		if err != nil {
+			return fmt.Errorf("Could not distribute the fee distributor balance: %w", err)
+		}
+
+		// The node share only lands on the node wallet if it's also the withdrawal address
+		if success && t.forwardAddress != nil {
+			if nodeDetails.WithdrawalAddress != nodeAccount.Address {
+				t.log.Printlnf("The node share was sent to your withdrawal address (%s), so there's nothing on the node wallet to forward.", nodeDetails.WithdrawalAddress.Hex())
+			} else if err := t.pending.add(pendingForward_FeeDistributorEth, nodeDetails.DistributorBalanceNodeETH); err != nil {
+				return fmt.Errorf("The fee distributor was swept but the node share could not be queued to be forwarded to %s, please send it manually with `rocketpool node send`: %w", t.forwardAddress.Hex(), err)
+			}
+		}
+	}
+
+	// Forward the node share, along with any from earlier sweeps that couldn't be forwarded at the time
+	if t.forwardAddress != nil {
+		if err := t.forwardNodeShare(); err != nil {
+			return fmt.Errorf("The node share from the fee distributor sweep could not be forwarded to %s, it will be retried on the next run: %w", t.forwardAddress.Hex(), err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Distribute the fee distributor's balance
+func (t *sweepFeeDistributor) distribute(distributorAddress common.Address, balance *big.Int, nodeShare *big.Int, state *state.NetworkState) (bool, error) {
+
+	distributor, err := node.NewDistributor(t.rp, distributorAddress, &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error creating binding for fee distributor %s: %w", distributorAddress.Hex(), err)
+	}
+
+	// Anyone can distribute the fee distributor, so this can use the transactor key
+	opts, err := t.w.GetRoutineTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := distributor.EstimateDistributeGas(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to distribute the fee distributor balance: %w", err)
+	}
+	if !t.applyGas(opts, gasInfo) {
+		return false, nil
+	}
+
+	// Distribute
+	hash, err := distributor.Distribute(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	message := fmt.Sprintf("Distributed %.6f ETH from the fee distributor (%.6f ETH to your withdrawal address).", eth.WeiToEth(balance), eth.WeiToEth(nodeShare))
+	t.log.Infow(message, log.Any("tx", hash.Hex()))
+	collectors.RecordFeeDistributorSweep(balance, nodeShare)
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_FeeDistributorSwept,
+		Automatic: true,
+		TxHash:    &hash,
+		Message:   message,
+	})
+
+	// Return
+	return true, nil
+
+}
+
+// Forward the node shares waiting on the node wallet to the forward address
+func (t *sweepFeeDistributor) forwardNodeShare() error {
+
+	nodeShare, err := t.pending.get(pendingForward_FeeDistributorEth)
+	if err != nil {
+		return err
+	}
+	if nodeShare.Sign() == 0 {
+		return nil
+	}
+
+	// Sending from the node wallet needs the node key, which the transactor key can't stand in for
+	if t.w.IsMasquerading() {
+		t.log.Infow("The node share is on the node wallet, but it can't be forwarded until the node wallet is available again.", log.Any("amountEth", eth.WeiToEth(nodeShare)), log.Any("forwardAddress", t.forwardAddress.Hex()))
+		return nil
+	}
+
+	// Send the ETH
+	t.log.Infow("Forwarding the node share...", log.Any("amountEth", eth.WeiToEth(nodeShare)), log.Any("forwardAddress", t.forwardAddress.Hex()))
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	opts.Value = nodeShare
+	gasInfo, err := eth.EstimateSendTransactionGas(t.rp.Client, *t.forwardAddress, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to forward ETH: %w", err)
+	}
+	if !t.applyGas(opts, gasInfo) {
+		return fmt.Errorf("gas is too high to forward ETH right now")
+	}
+	hash, err := eth.SendTransaction(t.rp.Client, *t.forwardAddress, t.w.GetChainID(), opts)
+	if err != nil {
+		return fmt.Errorf("error forwarding ETH: %w", err)
+	}
+
+	// It's no longer pending once it's been sent, so a slow transaction can't get it sent twice
+	if err := t.pending.subtract(pendingForward_FeeDistributorEth, nodeShare); err != nil {
+		return err
+	}
+	if err := api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log); err != nil {
+		return err
+	}
+
+	// Log
+	message := fmt.Sprintf("Forwarded %.6f ETH from the fee distributor sweep to %s.", eth.WeiToEth(nodeShare), t.forwardAddress.Hex())
+	t.log.Infow(message, log.Any("tx", hash.Hex()))
+	collectors.RecordFeeDistributorForward(nodeShare)
+	services.RecordEvent(t.c, journal.Event{
+		Type:      journal.EventType_RewardsForwarded,
+		Automatic: true,
+		TxHash:    &hash,
+		Message:   message,
+	})
+
+	// Return
+	return nil
+
+}
+
+// Set the gas for a transaction, returning false if it's above the task's gas threshold
+func (t *sweepFeeDistributor) applyGas(opts *bind.TransactOpts, gasInfo rocketpool.GasInfo) bool {
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		var err error
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			t.log.Printlnf("Error getting the max fee: %s", err.Error())
+			return false
+		}
+	}
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+		return false
+	}
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+	return true
+}
@@ -0,0 +1,118 @@
+package node
+
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Track validator status task
+type trackValidatorStatus struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	w            *wallet.Wallet
+	lastStatuses map[types.ValidatorPubkey]beacon.ValidatorState
+}
+
+// Create track validator status task
+func newTrackValidatorStatus(c *cli.Context, logger log.ColorLogger) (*trackValidatorStatus, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &trackValidatorStatus{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+	}, nil
+
+}
+
+// Check the node's validators for Beacon status changes
+func (t *trackValidatorStatus) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the minipool labels so the changes can be reported with them
+	book, err := addressbook.Load(t.cfg.Smartnode.GetMinipoolLabelsPath())
+	if err != nil {
+		return err
+	}
+
+	// Get the current statuses
+	currentEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	statuses := map[types.ValidatorPubkey]beacon.ValidatorState{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		statuses[mpd.Pubkey] = validator.Status
+
+		// Don't report anything on the first run since there's nothing to compare against
+		if t.lastStatuses == nil {
+			continue
+		}
+
+		// Report any changes
+		previousStatus, tracked := t.lastStatuses[mpd.Pubkey]
+		if !tracked {
+			// New validators start out as pending
+			previousStatus = beacon.ValidatorState_PendingInitialized
+		}
+		if previousStatus == validator.Status {
+			continue
+		}
+		collectors.RecordValidatorStatusChange(previousStatus, validator.Status)
+		t.log.Printlnf("Minipool %s (validator %d) changed Beacon status from %s to %s at epoch %d.", book.Format(mpd.MinipoolAddress), validator.Index, previousStatus, validator.Status, currentEpoch)
+		if validator.Status == beacon.ValidatorState_ActiveSlashed {
+			t.log.Warnf("the validator for minipool %s has been slashed!", book.Format(mpd.MinipoolAddress))
+		}
+
+		// Report when the next stage is expected
+		stage, epoch, scheduled := collectors.GetValidatorNextStage(validator)
+		if stage == "" {
+			continue
+		}
+		if !scheduled {
+			t.log.Printlnf("\tIt will become %s once the Beacon chain schedules it.", stage)
+			continue
+		}
+		stageTime := time.Unix(int64(state.BeaconConfig.GenesisTime+epoch*state.BeaconConfig.SecondsPerEpoch), 0)
+		t.log.Printlnf("\tIt will become %s at epoch %d (around %s, in %s).", stage, epoch, stageTime.Format(time.RFC1123), time.Until(stageTime).Round(time.Minute))
+	}
+
+	// Log the initial tracking info
+	if t.lastStatuses == nil {
+		t.log.Printlnf("Tracking the Beacon status of %d validator(s).", len(statuses))
+	}
+	t.lastStatuses = statuses
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The time to wait before resubscribing after the block stream drops
+var blockStreamRetryDelay, _ = time.ParseDuration("30s")
+
+// Watches the Beacon node's block stream so proposals by the node's validators are seen as soon as they're published,
+// instead of on the next pass of the task loop
+type watchProposals struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	bc          beacon.Client
+	nodeAddress common.Address
+	stateLocker *collectors.StateLocker
+	lastSlot    uint64
+	connected   bool
+}
+
+// Create the proposal watcher
+func newWatchProposals(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address, stateLocker *collectors.StateLocker) (*watchProposals, error) {
+
+	// Get services
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the watcher
+	return &watchProposals{
+		c:           c,
+		log:         logger,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+	}, nil
+
+}
+
+// Subscribe to the block stream, resubscribing whenever it drops
+func (t *watchProposals) run() {
+	for {
+		err := t.bc.SubscribeToBlocks(context.Background(), t.handleBlock)
+		if t.connected {
+			t.connected = false
+			collectors.RecordBlockStreamStatus(false)
+		}
+		if err != nil {
+			t.log.Printlnf("The Beacon block stream isn't available (%s); proposals will only be seen by the regular status checks until it is. Retrying in %s.", err.Error(), blockStreamRetryDelay)
+		}
+		time.Sleep(blockStreamRetryDelay)
+	}
+}
+
+// Check if a new block was proposed by one of the node's validators
+func (t *watchProposals) handleBlock(event beacon.BlockEvent) {
+	if !t.connected {
+		t.connected = true
+		collectors.RecordBlockStreamStatus(true)
+		t.log.Println("Receiving new blocks from the Beacon node.")
+	}
+
+	// Ignore blocks that have already been checked, since reorgs can announce the same slot more than once
+	if event.Slot <= t.lastSlot {
+		return
+	}
+	t.lastSlot = event.Slot
+
+	// Wait for the network state to be loaded, since it has the node's validator indices
+	state := t.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	// Get the proposer
+	block, exists, err := t.bc.GetBeaconBlock(event.BlockRoot.Hex())
+	if err != nil {
+		t.log.Printlnf("Error getting block %s for slot %d: %s", event.BlockRoot.Hex(), event.Slot, err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	// Check if it was one of the node's validators
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || validator.Index != block.ProposerIndex {
+			continue
+		}
+
+		slotTime := time.Unix(int64(state.BeaconConfig.GenesisTime+block.Slot*state.BeaconConfig.SecondsPerSlot), 0)
+		delay := time.Since(slotTime)
+		collectors.RecordProposal(block.Slot, delay)
+		t.log.Printlnf("Minipool %s (validator %d) proposed the block for slot %d!", mpd.MinipoolAddress.Hex(), validator.Index, block.Slot)
+		if block.HasExecutionPayload {
+			t.log.Printlnf("\tExecution block %d, fee recipient %s, detected %s after the start of the slot.", block.ExecutionBlockNumber, block.FeeRecipient.Hex(), delay.Round(time.Millisecond))
+		}
+		return
+	}
+}
@@ -0,0 +1,355 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The protocol settings recorded by the last check
+type protocolSettingsSnapshot struct {
+	Network       string            `json:"network"`
+	ElBlockNumber uint64            `json:"elBlockNumber"`
+	Settings      map[string]string `json:"settings"`
+}
+
+// A protocol setting and how to read it, formatted for display
+type protocolSetting struct {
+	name string
+	get  func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error)
+}
+
+// The settings that exist on every deployment
+var protocolSettings = []protocolSetting{
+	// Commission
+	{"Minimum node commission", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetMinimumNodeFee(rp, opts))
+	}},
+	{"Target node commission", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetTargetNodeFee(rp, opts))
+	}},
+	{"Maximum node commission", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetMaximumNodeFee(rp, opts))
+	}},
+	{"Node commission demand range", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatWei(protocol.GetNodeFeeDemandRange(rp, opts))
+	}},
+
+	// Deposit pool
+	{"Deposits enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetDepositEnabled(rp, opts))
+	}},
+	{"Deposit assignments enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetAssignDepositsEnabled(rp, opts))
+	}},
+	{"Minimum deposit", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatWei(protocol.GetMinimumDeposit(rp, opts))
+	}},
+	{"Maximum deposit pool size", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatWei(protocol.GetMaximumDepositPoolSize(rp, opts))
+	}},
+	{"Maximum deposit assignments", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatUint(protocol.GetMaximumDepositAssignments(rp, opts))
+	}},
+
+	// Nodes
+	{"Node registration enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetNodeRegistrationEnabled(rp, opts))
+	}},
+	{"Node deposits enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetNodeDepositEnabled(rp, opts))
+	}},
+	{"Minimum RPL stake per minipool", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetMinimumPerMinipoolStake(rp, opts))
+	}},
+	{"Maximum RPL stake per minipool", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetMaximumPerMinipoolStake(rp, opts))
+	}},
+
+	// Minipools
+	{"Minipool launch timeout", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		timeout, err := protocol.GetMinipoolLaunchTimeout(rp, opts)
+		return timeout.String(), err
+	}},
+	{"Minipool withdrawable submissions enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetMinipoolSubmitWithdrawableEnabled(rp, opts))
+	}},
+	{"Scrub period", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatSeconds(tnsettings.GetScrubPeriod(rp, opts))
+	}},
+	{"Scrub penalty enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(tnsettings.GetScrubPenaltyEnabled(rp, opts))
+	}},
+
+	// Network
+	{"Balance submissions enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetSubmitBalancesEnabled(rp, opts))
+	}},
+	{"Balance submission frequency (blocks)", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatUint(protocol.GetSubmitBalancesFrequency(rp, opts))
+	}},
+	{"Price submissions enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetSubmitPricesEnabled(rp, opts))
+	}},
+	{"Price submission frequency (blocks)", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatUint(protocol.GetSubmitPricesFrequency(rp, opts))
+	}},
+	{"Oracle DAO consensus threshold", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetNodeConsensusThreshold(rp, opts))
+	}},
+	{"Target rETH collateral rate", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetTargetRethCollateralRate(rp, opts))
+	}},
+
+	// Inflation and rewards
+	{"RPL inflation interval rate", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		rate, err := protocol.GetInflationIntervalRate(rp, opts)
+		return strconv.FormatFloat(rate, 'f', -1, 64), err
+	}},
+	{"RPL inflation start time", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		startTime, err := protocol.GetInflationStartTime(rp, opts)
+		return time.Unix(int64(startTime), 0).UTC().Format(time.RFC1123), err
+	}},
+	{"Rewards interval", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatSeconds(protocol.GetRewardsClaimIntervalTime(rp, opts))
+	}},
+	{"Node operator rewards share", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetRewardsClaimerPerc(rp, "rocketClaimNode", opts))
+	}},
+	{"Oracle DAO rewards share", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetRewardsClaimerPerc(rp, "rocketClaimTrustedNode", opts))
+	}},
+	{"Protocol DAO rewards share", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatPercent(protocol.GetRewardsClaimerPerc(rp, "rocketClaimDAO", opts))
+	}},
+}
+
+// The settings that were added in Atlas, which can't be read before it's deployed
+var atlasProtocolSettings = []protocolSetting{
+	{"Vacant minipools enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetVacantMinipoolsEnabled(rp, opts))
+	}},
+	{"Bond reductions enabled", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatBool(protocol.GetBondReductionEnabled(rp, opts))
+	}},
+	{"Promotion scrub period", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatSeconds(tnsettings.GetPromotionScrubPeriod(rp, opts))
+	}},
+	{"Bond reduction window start", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatSeconds(tnsettings.GetBondReductionWindowStart(rp, opts))
+	}},
+	{"Bond reduction window length", func(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+		return formatSeconds(tnsettings.GetBondReductionWindowLength(rp, opts))
+	}},
+}
+
+// Watch protocol settings task
+type watchProtocolSettings struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+
+	// The settings recorded by the last check, loaded from disk on the first run
+	snapshot *protocolSettingsSnapshot
+}
+
+// Create watch protocol settings task
+func newWatchProtocolSettings(c *cli.Context, logger log.ColorLogger) (*watchProtocolSettings, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &watchProtocolSettings{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		rp:  rp,
+	}, nil
+
+}
+
+// Read the protocol settings and report any that have changed since the last check
+func (t *watchProtocolSettings) run(state *state.NetworkState) error {
+
+	network := fmt.Sprint(t.cfg.Smartnode.Network.Value)
+	path := t.cfg.Smartnode.GetProtocolSettingsSnapshotPath()
+
+	// Load the settings recorded before the daemon started
+	if t.snapshot == nil {
+		snapshot, err := loadProtocolSettingsSnapshot(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.log.Warnf("couldn't load the previous protocol settings, they will be recorded again: %s", err.Error())
+		}
+		if snapshot != nil && snapshot.Network == network {
+			t.snapshot = snapshot
+		}
+	}
+
+	// Read the current settings at the same block as the network state
+	settings, err := getProtocolSettings(t.rp, state)
+	if err != nil {
+		return fmt.Errorf("error getting protocol settings: %w", err)
+	}
+	current := &protocolSettingsSnapshot{
+		Network:       network,
+		ElBlockNumber: state.ElBlockNumber,
+		Settings:      settings,
+	}
+
+	// Record the first set of settings without reporting anything
+	if t.snapshot == nil {
+		t.log.Printlnf("Recorded %d protocol settings at block %d; you'll be notified here when any of them change.", len(settings), state.ElBlockNumber)
+		return t.save(path, current)
+	}
+	if t.snapshot.ElBlockNumber >= state.ElBlockNumber {
+		return nil
+	}
+
+	// Report the changes
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changes := []string{}
+	for _, name := range names {
+		previous, exists := t.snapshot.Settings[name]
+		if !exists {
+			// Settings that only just became readable, e.g. after an upgrade, aren't changes
+			continue
+		}
+		if previous != settings[name] {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", name, previous, settings[name]))
+		}
+	}
+	if len(changes) > 0 {
+		t.log.Printlnf("NOTICE: %d protocol setting(s) changed between blocks %d and %d:", len(changes), t.snapshot.ElBlockNumber, state.ElBlockNumber)
+		for _, change := range changes {
+			t.log.Printlnf("\t%s", change)
+		}
+	}
+
+	return t.save(path, current)
+
+}
+
+// Save the current settings so changes made while the daemon is down are still reported
+func (t *watchProtocolSettings) save(path string, snapshot *protocolSettingsSnapshot) error {
+	t.snapshot = snapshot
+	bytes, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing protocol settings: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating protocol settings folder: %w", err)
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing protocol settings [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving protocol settings into place [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the settings saved by a previous check
+func loadProtocolSettingsSnapshot(path string) (*protocolSettingsSnapshot, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot protocolSettingsSnapshot
+	if err := json.Unmarshal(bytes, &snapshot); err != nil {
+		return nil, fmt.Errorf("error deserializing protocol settings [%s]: %w", path, err)
+	}
+	if snapshot.Settings == nil {
+		snapshot.Settings = map[string]string{}
+	}
+	return &snapshot, nil
+}
+
+// Read all of the protocol settings at the network state's block
+func getProtocolSettings(rp *rocketpool.RocketPool, state *state.NetworkState) (map[string]string, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	settingsToRead := append([]protocolSetting{}, protocolSettings...)
+	if state.IsAtlasDeployed {
+		settingsToRead = append(settingsToRead, atlasProtocolSettings...)
+	}
+
+	settings := make(map[string]string, len(settingsToRead))
+	var lock sync.Mutex
+	var wg errgroup.Group
+	wg.SetLimit(MaxConcurrentEth1Requests)
+	for _, setting := range settingsToRead {
+		setting := setting
+		wg.Go(func() error {
+			value, err := setting.get(rp, opts)
+			if err != nil {
+				return fmt.Errorf("error getting %s: %w", setting.name, err)
+			}
+			lock.Lock()
+			settings[setting.name] = value
+			lock.Unlock()
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Formatters for the setting values
+func formatPercent(value float64, err error) (string, error) {
+	return strconv.FormatFloat(value*100, 'f', -1, 64) + "%", err
+}
+func formatWei(value *big.Int, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(eth.WeiToEth(value), 'f', -1, 64) + " ETH", nil
+}
+func formatBool(value bool, err error) (string, error) {
+	return strconv.FormatBool(value), err
+}
+func formatUint(value uint64, err error) (string, error) {
+	return strconv.FormatUint(value, 10), err
+}
+func formatSeconds(value uint64, err error) (string, error) {
+	return (time.Duration(value) * time.Second).String(), err
+}
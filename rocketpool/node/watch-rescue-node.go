@@ -0,0 +1,104 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Settings
+const (
+	rescueNodeCheckInterval time.Duration = time.Minute
+	rescueNodeWarningWindow time.Duration = 24 * time.Hour
+)
+
+// Watches the Rescue Node window and points the validator client back at the node's own Beacon Node when it closes.
+// This runs outside of the task loop, since that stops while the Beacon Node is down, which is exactly when the Rescue Node is in use.
+type watchRescueNode struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	d      *client.Client
+	warned bool
+}
+
+// Create the Rescue Node watcher
+func newWatchRescueNode(c *cli.Context, logger log.ColorLogger) (*watchRescueNode, error) {
+
+	// Get services
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the watcher
+	return &watchRescueNode{
+		c:   c,
+		log: logger,
+		d:   d,
+	}, nil
+
+}
+
+// Check the Rescue Node window periodically
+func (t *watchRescueNode) run() {
+	for {
+		if err := t.check(); err != nil {
+			t.log.Printlnf("Error checking the Rescue Node window: %s", err.Error())
+		}
+		time.Sleep(rescueNodeCheckInterval)
+	}
+}
+
+// Check if the Rescue Node window is closing or has closed, and detach from it if so
+func (t *watchRescueNode) check() error {
+
+	// Load the settings file fresh, since the window is opened by the CLI while the daemon is running
+	settingsFile := os.ExpandEnv(t.c.GlobalString("settings"))
+	cfg, err := rputils.LoadConfigFromFile(settingsFile)
+	if err != nil {
+		return fmt.Errorf("error loading settings file [%s]: %w", settingsFile, err)
+	}
+	if cfg == nil || cfg.RescueNode.GetEnabledParameter().Value != true {
+		t.warned = false
+		return nil
+	}
+	rescueCfg := cfg.RescueNode.GetConfig().(*rescue_node.RescueNodeConfig)
+
+	// Warn once when the window is about to close
+	expiration := rescueCfg.GetExpiration()
+	remaining := time.Until(expiration)
+	if remaining > 0 {
+		if remaining < rescueNodeWarningWindow && !t.warned {
+			t.log.Printlnf("The Rescue Node window closes at %s. Your validator client will go back to your own Beacon Node then, so make sure it's synced by that time.", expiration.Format(time.RFC1123))
+			t.warned = true
+		}
+		return nil
+	}
+
+	// Disable the Rescue Node so the validator client gets the node's own Beacon Node endpoint
+	t.log.Printlnf("The Rescue Node window closed at %s, detaching from the Rescue Node...", expiration.Format(time.RFC1123))
+	rescueCfg.Enabled.Value = false
+	rescueCfg.Username.Value = ""
+	rescueCfg.Password.Value = ""
+	if err := rputils.SaveConfig(cfg, settingsFile); err != nil {
+		return fmt.Errorf("error saving settings file [%s]: %w", settingsFile, err)
+	}
+	t.warned = false
+
+	// Recreate the validator client with the restored endpoint
+	if err := validator.ReconnectValidator(cfg, &t.log, t.d, cfg.GenerateEnvironmentVariables()); err != nil {
+		return fmt.Errorf("the Rescue Node has been disabled, but the validator client couldn't be reconnected to your Beacon Node; please run `rocketpool service start` to finish detaching: %w", err)
+	}
+	t.log.Println("Your validator client is connected to your own Beacon Node again.")
+	return nil
+
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/rocketpool/bnproxy"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
@@ -124,12 +125,17 @@ func main() {
 			Name:  "use-protected-api",
 			Usage: "Set this to true to use the Flashbots Protect RPC instead of your local Execution Client. Useful to ensure your transactions aren't front-run.",
 		},
+		cli.BoolFlag{
+			Name:  "simulate",
+			Usage: "Set this to true to run transactions through eth_call and eth_estimateGas instead of signing and broadcasting them. The simulated transactions are added to the API response.",
+		},
 	}
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
+	bnproxy.RegisterCommands(app, "bn-proxy", []string{"p"})
 
 	// Get command being run
 	var commandName string
@@ -0,0 +1,308 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+)
+
+// Settings
+const (
+	// How far back to look for this member's submissions when the watchtower starts (about 3 days)
+	submissionScanLookback uint64 = 21600
+)
+
+// Check oDAO duties task
+type checkOdaoDuties struct {
+	c    *cli.Context
+	log  log.ColorLogger
+	w    *wallet.Wallet
+	rp   *rocketpool.RocketPool
+	coll *collectors.OdaoDutiesCollector
+
+	// The latest submission blocks seen so far, so they survive the network reaching consensus on a newer block
+	lastPriceSubmissionBlock    uint64
+	lastBalancesSubmissionBlock uint64
+
+	// Finds this member's submission events; scannedBlock is the latest block that has been scanned
+	scanner      *logscan.Scanner
+	scannedBlock uint64
+}
+
+// Create check oDAO duties task
+func newCheckOdaoDuties(c *cli.Context, logger log.ColorLogger, coll *collectors.OdaoDutiesCollector) (*checkOdaoDuties, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, fmt.Errorf("error getting event log interval: %w", err)
+	}
+
+	// Return task
+	return &checkOdaoDuties{
+		c:       c,
+		log:     logger,
+		w:       w,
+		rp:      rp,
+		coll:    coll,
+		scanner: logscan.NewScanner(ec, uint64(eventLogInterval)),
+	}, nil
+
+}
+
+// Update the duty metrics for this member
+func (t *checkOdaoDuties) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Find the submissions made since the last scan; the storage checks below still catch any it misses
+	scanStart := time.Now()
+	if err := t.scanSubmissions(state, nodeAccount.Address); err != nil {
+		t.log.Warnf("couldn't scan for this member's submissions: %s", err.Error())
+	}
+	scanDuration := time.Since(scanStart)
+	scanLag := uint64(0)
+	if state.ElBlockNumber > t.scannedBlock {
+		scanLag = state.ElBlockNumber - t.scannedBlock
+	}
+
+	// Check the prices submissions
+	priceLag, err := t.updateSubmission(state, nodeAccount.Address, opts, SubmissionKey,
+		state.NetworkDetails.PricesBlock, state.NetworkDetails.LatestReportablePricesBlock, &t.lastPriceSubmissionBlock)
+	if err != nil {
+		return fmt.Errorf("error checking prices submissions: %w", err)
+	}
+
+	// Check the balances submissions
+	balancesLag, err := t.updateSubmission(state, nodeAccount.Address, opts, "network.balances.submitted.node",
+		state.NetworkDetails.BalancesBlock.Uint64(), state.NetworkDetails.LatestReportableBalancesBlock.Uint64(), &t.lastBalancesSubmissionBlock)
+	if err != nil {
+		return fmt.Errorf("error checking balances submissions: %w", err)
+	}
+
+	// Check for a challenge
+	isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, nodeAccount.Address, opts)
+	if err != nil {
+		return fmt.Errorf("error checking challenge status: %w", err)
+	}
+	if isChallenged {
+		t.log.Warn("this node is currently challenged! The challenge response check will respond to it automatically if the node is online.")
+	}
+
+	// Get the proposals this member could vote on since it joined
+	joinedTime, err := trustednode.GetMemberJoinedTime(t.rp, nodeAccount.Address, opts)
+	if err != nil {
+		return fmt.Errorf("error getting member join time: %w", err)
+	}
+	proposals, err := dao.GetDAOProposalsWithMember(t.rp, "rocketDAONodeTrustedProposals", nodeAccount.Address, opts)
+	if err != nil {
+		return fmt.Errorf("error getting proposals: %w", err)
+	}
+	var eligible, voted, unvotedActive float64
+	for _, proposal := range proposals {
+		if proposal.CreatedTime < joinedTime {
+			continue
+		}
+		switch proposal.State {
+		case rptypes.Pending, rptypes.Cancelled:
+			// Voting never opened
+			continue
+		case rptypes.Active:
+			if !proposal.MemberVoted {
+				unvotedActive++
+			}
+		}
+		eligible++
+		if proposal.MemberVoted {
+			voted++
+		}
+	}
+
+	// Update the metrics
+	t.coll.UpdateLock.Lock()
+	defer t.coll.UpdateLock.Unlock()
+
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	secondsSinceGenesis := time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second
+	t.coll.LatestBlockTime = float64(genesisTime.Add(secondsSinceGenesis).Unix())
+
+	t.coll.LastPriceSubmissionBlock = float64(t.lastPriceSubmissionBlock)
+	t.coll.PriceSubmissionLag = float64(priceLag)
+	t.coll.LastBalancesSubmissionBlock = float64(t.lastBalancesSubmissionBlock)
+	t.coll.BalancesSubmissionLag = float64(balancesLag)
+	t.coll.IsChallenged = 0
+	if isChallenged {
+		t.coll.IsChallenged = 1
+	}
+	t.coll.EligibleProposals = eligible
+	t.coll.VotedProposals = voted
+	t.coll.UnvotedActiveProposals = unvotedActive
+	t.coll.SubmissionScanLag = float64(scanLag)
+	t.coll.SubmissionScanDuration = scanDuration.Seconds()
+	t.coll.SubmissionScanChunkSize = float64(t.scanner.GetChunkSize())
+
+	return nil
+
+}
+
+// Record this member's latest submission for a reporting duty, returning how many blocks an outstanding submission has been owed for
+func (t *checkOdaoDuties) updateSubmission(state *state.NetworkState, nodeAddress common.Address, opts *bind.CallOpts, submissionKey string, consensusBlock uint64, latestReportableBlock uint64, lastSubmissionBlock *uint64) (uint64, error) {
+
+	// Check the latest reportable block first, then fall back to the one the network last agreed on
+	for _, block := range []uint64{latestReportableBlock, consensusBlock} {
+		if block == 0 || block <= *lastSubmissionBlock {
+			break
+		}
+		hasSubmitted, err := t.hasSubmitted(nodeAddress, opts, submissionKey, block)
+		if err != nil {
+			return 0, err
+		}
+		if hasSubmitted {
+			*lastSubmissionBlock = block
+			break
+		}
+	}
+
+	// Nothing is owed if the network already agreed on the latest block or this member has submitted for it
+	if latestReportableBlock <= consensusBlock || latestReportableBlock <= *lastSubmissionBlock || state.ElBlockNumber < latestReportableBlock {
+		return 0, nil
+	}
+	return state.ElBlockNumber - latestReportableBlock, nil
+
+}
+
+// Check whether the node has submitted anything for a block under the given submission key
+func (t *checkOdaoDuties) hasSubmitted(nodeAddress common.Address, opts *bind.CallOpts, submissionKey string, blockNumber uint64) (bool, error) {
+
+	blockNumberBuf := make([]byte, 32)
+	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
+	return t.rp.RocketStorage.GetBool(opts, crypto.Keccak256Hash([]byte(submissionKey), nodeAddress.Bytes(), blockNumberBuf))
+
+}
+
+// Scan the blocks since the last scan for this member's prices and balances submission events
+func (t *checkOdaoDuties) scanSubmissions(state *state.NetworkState, nodeAddress common.Address) error {
+
+	// Get the range to scan, looking back a limited distance on the first run
+	toBlock := state.ElBlockNumber
+	fromBlock := t.scannedBlock + 1
+	if t.scannedBlock == 0 || toBlock-t.scannedBlock > submissionScanLookback {
+		fromBlock = 0
+		if toBlock > submissionScanLookback {
+			fromBlock = toBlock - submissionScanLookback
+		}
+	}
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	// Get the contracts
+	pricesContract, err := t.rp.GetContract("rocketNetworkPrices", nil)
+	if err != nil {
+		return fmt.Errorf("error getting prices contract: %w", err)
+	}
+	balancesContract, err := t.rp.GetContract("rocketNetworkBalances", nil)
+	if err != nil {
+		return fmt.Errorf("error getting balances contract: %w", err)
+	}
+	pricesEvent, exists := pricesContract.ABI.Events["PricesSubmitted"]
+	if !exists {
+		return fmt.Errorf("prices contract doesn't have a PricesSubmitted event")
+	}
+	balancesEvent, exists := balancesContract.ABI.Events["BalancesSubmitted"]
+	if !exists {
+		return fmt.Errorf("balances contract doesn't have a BalancesSubmitted event")
+	}
+
+	// Scan both contracts at once since the submitter is indexed in both events
+	logs, err := t.scanner.FilterLogs(
+		[]common.Address{*pricesContract.Address, *balancesContract.Address},
+		[][]common.Hash{{pricesEvent.ID, balancesEvent.ID}, {common.BytesToHash(nodeAddress.Bytes())}},
+		fromBlock, toBlock,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Record the latest block each kind of submission was for
+	for _, eventLog := range logs {
+		if len(eventLog.Topics) == 0 {
+			continue
+		}
+		switch eventLog.Topics[0] {
+		case pricesEvent.ID:
+			block, err := getSubmittedBlock(pricesEvent, eventLog)
+			if err != nil {
+				return err
+			}
+			if block > t.lastPriceSubmissionBlock {
+				t.lastPriceSubmissionBlock = block
+			}
+		case balancesEvent.ID:
+			block, err := getSubmittedBlock(balancesEvent, eventLog)
+			if err != nil {
+				return err
+			}
+			if block > t.lastBalancesSubmissionBlock {
+				t.lastBalancesSubmissionBlock = block
+			}
+		}
+	}
+	t.scannedBlock = toBlock
+	return nil
+
+}
+
+// Get the block a prices or balances submission event was for
+func getSubmittedBlock(event abi.Event, eventLog types.Log) (uint64, error) {
+	values := map[string]interface{}{}
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(values, eventLog.Data); err != nil {
+		return 0, fmt.Errorf("error decoding %s event in transaction %s: %w", event.Name, eventLog.TxHash.Hex(), err)
+	}
+	block, ok := values["block"].(*big.Int)
+	if !ok {
+		return 0, fmt.Errorf("%s event in transaction %s doesn't have a block", event.Name, eventLog.TxHash.Hex())
+	}
+	return block.Uint64(), nil
+}
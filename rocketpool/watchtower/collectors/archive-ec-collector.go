@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for the archive EC endpoint metrics
+type ArchiveEcCollector struct {
+
+	// The total number of requests sent to each endpoint
+	requestsDesc *prometheus.Desc
+
+	// The total number of requests that failed on each endpoint
+	errorsDesc *prometheus.Desc
+
+	// The total number of requests that were rate-limited by each endpoint
+	rateLimitsDesc *prometheus.Desc
+
+	// The duration of the latest request to each endpoint
+	latencyDesc *prometheus.Desc
+
+	// The archive EC manager
+	archiveEc *services.ArchiveClientManager
+}
+
+// Create a new ArchiveEcCollector instance
+func NewArchiveEcCollector(archiveEc *services.ArchiveClientManager) *ArchiveEcCollector {
+	subsystem := "archive_ec"
+	labels := []string{"endpoint", "host"}
+	return &ArchiveEcCollector{
+		requestsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The total number of requests sent to each archive EC endpoint",
+			labels, nil,
+		),
+		errorsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "errors_total"),
+			"The total number of requests that failed on each archive EC endpoint",
+			labels, nil,
+		),
+		rateLimitsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rate_limits_total"),
+			"The total number of requests that were rate-limited by each archive EC endpoint",
+			labels, nil,
+		),
+		latencyDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_seconds"),
+			"How long the latest request to each archive EC endpoint took",
+			labels, nil,
+		),
+		archiveEc: archiveEc,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ArchiveEcCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requestsDesc
+	channel <- collector.errorsDesc
+	channel <- collector.rateLimitsDesc
+	channel <- collector.latencyDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ArchiveEcCollector) Collect(channel chan<- prometheus.Metric) {
+	for i, stats := range collector.archiveEc.GetEndpointStats() {
+		// Only expose the host, since archive URLs often contain API keys
+		host := "unknown"
+		parsedUrl, err := url.Parse(stats.Url)
+		if err == nil {
+			host = parsedUrl.Host
+		}
+		endpoint := fmt.Sprint(i)
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.requestsDesc, prometheus.CounterValue, float64(stats.Requests), endpoint, host)
+		channel <- prometheus.MustNewConstMetric(
+			collector.errorsDesc, prometheus.CounterValue, float64(stats.Errors), endpoint, host)
+		channel <- prometheus.MustNewConstMetric(
+			collector.rateLimitsDesc, prometheus.CounterValue, float64(stats.RateLimits), endpoint, host)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latencyDesc, prometheus.GaugeValue, stats.LastLatency.Seconds(), endpoint, host)
+	}
+}
@@ -0,0 +1,170 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for this Oracle DAO member's own duties
+type OdaoDutiesCollector struct {
+
+	// The latest prices block this member submitted prices for
+	lastPriceSubmissionBlockDesc *prometheus.Desc
+
+	// How many blocks this member has owed an outstanding prices submission for
+	priceSubmissionLagDesc *prometheus.Desc
+
+	// The latest balances block this member submitted balances for
+	lastBalancesSubmissionBlockDesc *prometheus.Desc
+
+	// How many blocks this member has owed an outstanding balances submission for
+	balancesSubmissionLagDesc *prometheus.Desc
+
+	// Whether this member is currently challenged
+	isChallengedDesc *prometheus.Desc
+
+	// The number of proposals this member could have voted on
+	eligibleProposalsDesc *prometheus.Desc
+
+	// The number of those proposals this member voted on
+	votedProposalsDesc *prometheus.Desc
+
+	// The number of active proposals this member still needs to vote on
+	unvotedActiveProposalsDesc *prometheus.Desc
+
+	// The time of the latest block that the check was run against
+	latestBlockTimeDesc *prometheus.Desc
+
+	// How many blocks the submission event scan is behind the latest block
+	submissionScanLagDesc *prometheus.Desc
+
+	// How long the latest submission event scan took
+	submissionScanDurationDesc *prometheus.Desc
+
+	// The number of blocks the submission event scan currently requests at once
+	submissionScanChunkSizeDesc *prometheus.Desc
+
+	// Counters
+	LastPriceSubmissionBlock    float64
+	PriceSubmissionLag          float64
+	LastBalancesSubmissionBlock float64
+	BalancesSubmissionLag       float64
+	IsChallenged                float64
+	EligibleProposals           float64
+	VotedProposals              float64
+	UnvotedActiveProposals      float64
+	LatestBlockTime             float64
+	SubmissionScanLag           float64
+	SubmissionScanDuration      float64
+	SubmissionScanChunkSize     float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new OdaoDutiesCollector instance
+func NewOdaoDutiesCollector() *OdaoDutiesCollector {
+	subsystem := "odao_duties"
+	return &OdaoDutiesCollector{
+		lastPriceSubmissionBlockDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_price_submission_block"),
+			"The latest prices block this member has submitted prices for",
+			nil, nil,
+		),
+		priceSubmissionLagDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "price_submission_lag_blocks"),
+			"How many blocks have passed since the latest reportable prices block without this member submitting for it, or 0 if it is up to date",
+			nil, nil,
+		),
+		lastBalancesSubmissionBlockDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_balances_submission_block"),
+			"The latest balances block this member has submitted network balances for",
+			nil, nil,
+		),
+		balancesSubmissionLagDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "balances_submission_lag_blocks"),
+			"How many blocks have passed since the latest reportable balances block without this member submitting for it, or 0 if it is up to date",
+			nil, nil,
+		),
+		isChallengedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "is_challenged"),
+			"1 if this member is currently challenged and must respond before the challenge window ends, 0 otherwise",
+			nil, nil,
+		),
+		eligibleProposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eligible_proposals"),
+			"The number of Oracle DAO proposals that have opened for voting since this member joined",
+			nil, nil,
+		),
+		votedProposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "voted_proposals"),
+			"The number of eligible Oracle DAO proposals this member has voted on",
+			nil, nil,
+		),
+		unvotedActiveProposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unvoted_active_proposals"),
+			"The number of Oracle DAO proposals open for voting that this member has not voted on yet",
+			nil, nil,
+		),
+		latestBlockTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latest_block_time"),
+			"The time of the latest block that the check was run against",
+			nil, nil,
+		),
+		submissionScanLagDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submission_scan_lag_blocks"),
+			"How many blocks the scan for this member's submission events is behind the latest block, or 0 if it is caught up",
+			nil, nil,
+		),
+		submissionScanDurationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submission_scan_duration_seconds"),
+			"How long the latest scan for this member's submission events took",
+			nil, nil,
+		),
+		submissionScanChunkSizeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submission_scan_chunk_size_blocks"),
+			"The number of blocks the submission event scan requests from the Execution client at once, which shrinks when the client rejects larger requests",
+			nil, nil,
+		),
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *OdaoDutiesCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.lastPriceSubmissionBlockDesc
+	channel <- collector.priceSubmissionLagDesc
+	channel <- collector.lastBalancesSubmissionBlockDesc
+	channel <- collector.balancesSubmissionLagDesc
+	channel <- collector.isChallengedDesc
+	channel <- collector.eligibleProposalsDesc
+	channel <- collector.votedProposalsDesc
+	channel <- collector.unvotedActiveProposalsDesc
+	channel <- collector.latestBlockTimeDesc
+	channel <- collector.submissionScanLagDesc
+	channel <- collector.submissionScanDurationDesc
+	channel <- collector.submissionScanChunkSizeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *OdaoDutiesCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastPriceSubmissionBlockDesc, prometheus.GaugeValue, collector.LastPriceSubmissionBlock)
+	channel <- prometheus.MustNewConstMetric(
+		collector.priceSubmissionLagDesc, prometheus.GaugeValue, collector.PriceSubmissionLag)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastBalancesSubmissionBlockDesc, prometheus.GaugeValue, collector.LastBalancesSubmissionBlock)
+	channel <- prometheus.MustNewConstMetric(
+		collector.balancesSubmissionLagDesc, prometheus.GaugeValue, collector.BalancesSubmissionLag)
+	channel <- prometheus.MustNewConstMetric(
+		collector.isChallengedDesc, prometheus.GaugeValue, collector.IsChallenged)
+	channel <- prometheus.MustNewConstMetric(
+		collector.eligibleProposalsDesc, prometheus.GaugeValue, collector.EligibleProposals)
+	channel <- prometheus.MustNewConstMetric(
+		collector.votedProposalsDesc, prometheus.GaugeValue, collector.VotedProposals)
+	channel <- prometheus.MustNewConstMetric(
+		collector.unvotedActiveProposalsDesc, prometheus.GaugeValue, collector.UnvotedActiveProposals)
+	channel <- prometheus.MustNewConstMetric(
+		collector.latestBlockTimeDesc, prometheus.GaugeValue, collector.LatestBlockTime)
+	channel <- prometheus.MustNewConstMetric(
+		collector.submissionScanLagDesc, prometheus.GaugeValue, collector.SubmissionScanLag)
+	channel <- prometheus.MustNewConstMetric(
+		collector.submissionScanDurationDesc, prometheus.GaugeValue, collector.SubmissionScanDuration)
+	channel <- prometheus.MustNewConstMetric(
+		collector.submissionScanChunkSizeDesc, prometheus.GaugeValue, collector.SubmissionScanChunkSize)
+}
@@ -0,0 +1,88 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the coordination of this member's submissions with the rest of the Oracle DAO
+type SubmissionCollector struct {
+
+	// The number of submissions that were skipped because the Oracle DAO had already reached consensus
+	avoidedSubmissionsDesc *prometheus.Desc
+
+	// The random delay used before the latest submission
+	submissionDelayDesc *prometheus.Desc
+
+	// Counters, keyed by submission type and then by reason
+	avoidedSubmissions map[string]map[string]float64
+
+	// The latest delays, keyed by submission type
+	submissionDelays map[string]float64
+
+	// Mutex
+	lock *sync.Mutex
+}
+
+// Create a new SubmissionCollector instance
+func NewSubmissionCollector() *SubmissionCollector {
+	subsystem := "submissions"
+	return &SubmissionCollector{
+		avoidedSubmissionsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "avoided_total"),
+			"The number of duplicate submissions this member skipped because the Oracle DAO had already reached consensus",
+			[]string{"type", "reason"}, nil,
+		),
+		submissionDelayDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "delay_seconds"),
+			"The random delay this member waited before its latest submission",
+			[]string{"type"}, nil,
+		),
+		avoidedSubmissions: map[string]map[string]float64{},
+		submissionDelays:   map[string]float64{},
+		lock:               &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SubmissionCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.avoidedSubmissionsDesc
+	channel <- collector.submissionDelayDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SubmissionCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	for submissionType, reasons := range collector.avoidedSubmissions {
+		for reason, count := range reasons {
+			channel <- prometheus.MustNewConstMetric(
+				collector.avoidedSubmissionsDesc, prometheus.CounterValue, count, submissionType, reason)
+		}
+	}
+	for submissionType, delay := range collector.submissionDelays {
+		channel <- prometheus.MustNewConstMetric(
+			collector.submissionDelayDesc, prometheus.GaugeValue, delay, submissionType)
+	}
+}
+
+// Record that a submission was skipped
+func (collector *SubmissionCollector) RecordAvoidedSubmission(submissionType string, reason string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	reasons, exists := collector.avoidedSubmissions[submissionType]
+	if !exists {
+		reasons = map[string]float64{}
+		collector.avoidedSubmissions[submissionType] = reasons
+	}
+	reasons[reason]++
+}
+
+// Record the delay before a submission
+func (collector *SubmissionCollector) RecordSubmissionDelay(submissionType string, delay time.Duration) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.submissionDelays[submissionType] = delay.Seconds()
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -171,24 +170,23 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 			strings.Contains(errMessage, "Internal error") { // Besu
 
 			// The state was missing so fall back to the archive node
-			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
-			if archiveEcUrl != "" {
-				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
-				if err != nil {
-					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
-					return
-				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+			archiveEc, err := services.GetArchiveEthClient(t.cfg)
+			if err != nil {
+				t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
+				return
+			}
+			if archiveEc != nil {
+				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), strings.Join(archiveEc.GetUrls(), ", "))
+				client, err = rocketpool.NewRocketPool(archiveEc, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
 				if err != nil {
-					t.handleError(fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", err))
+					t.handleError(fmt.Errorf("Error creating Rocket Pool client connected to archive EC: %w", err))
 					return
 				}
 
 				// Get the rETH address from the archive EC
 				address, err = client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
 				if err != nil {
-					t.handleError(fmt.Errorf("%s Error verifying rETH address with Archive EC: %w", err))
+					t.handleError(fmt.Errorf("Error verifying rETH address with Archive EC: %w", err))
 					return
 				}
 			} else {
@@ -14,7 +14,7 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector, odaoDutiesCollector *collectors.OdaoDutiesCollector, submissionCollector *collectors.SubmissionCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -36,6 +36,18 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	registry.MustRegister(scrubCollector)
 	registry.MustRegister(bondReductionCollector)
 	registry.MustRegister(soloMigrationCollector)
+	registry.MustRegister(odaoDutiesCollector)
+	registry.MustRegister(submissionCollector)
+
+	// Track the archive EC endpoints if any are configured
+	archiveEc, err := services.GetArchiveEthClient(cfg)
+	if err != nil {
+		return err
+	}
+	if archiveEc != nil {
+		registry.MustRegister(collectors.NewArchiveEcCollector(archiveEc))
+	}
+
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -96,7 +96,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warn("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -399,7 +399,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 	}
 	isOptedIn, err := node.GetSmoothingPoolRegistrationState(t.rp, nodeAddress, &opts)
 	if err != nil {
-		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err.Error())
 		isOptedIn = false
 	}
 
@@ -422,7 +422,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		// Get the opt out time
 		optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
 		if err != nil {
-			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err.Error())
 		} else if optOutTime != time.Unix(0, 0) {
 			// Get the time of the epoch before this one
 			blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
@@ -504,7 +504,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,463 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	replayTaskPrices   string = "prices"
+	replayTaskBalances string = "balances"
+	replayTaskScrub    string = "scrub"
+	replayTaskRewards  string = "rewards"
+
+	defaultReplayStep uint64 = 225 // Roughly one day of epochs
+)
+
+// Replays the watchtower's decision logic against a range of historical epochs without submitting anything
+type replayWatchtower struct {
+	log        log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	rp         *rocketpool.RocketPool
+	bc         beacon.Client
+	eth2Config beacon.Eth2Config
+	tasks      map[string]bool
+
+	// Task implementations used for the calculations
+	submitRplPrice        *submitRplPrice
+	submitNetworkBalances *submitNetworkBalances
+	submitScrubMinipools  *submitScrubMinipools
+
+	// Values that would have been submitted, by EL block, so they can be compared to the canonical ones once consensus was reached
+	pendingPrices   map[uint64]*big.Int
+	pendingBalances map[uint64]networkBalances
+}
+
+// Run the watchtower replay
+func runReplay(c *cli.Context) error {
+
+	// Configure
+	configureHTTP()
+	logger := log.NewModuleLogger("replay", ReplayColor)
+	errorLog := log.NewErrorLogger("replay", ErrorColor)
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon config: %w", err)
+	}
+
+	// Get the range to replay
+	startEpoch := c.Uint64("start-epoch")
+	endEpoch := c.Uint64("end-epoch")
+	step := c.Uint64("step")
+	if step == 0 {
+		step = defaultReplayStep
+	}
+	if endEpoch < startEpoch {
+		return fmt.Errorf("the end epoch (%d) must not be before the start epoch (%d)", endEpoch, startEpoch)
+	}
+	beaconHead, err := bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	if endEpoch > beaconHead.FinalizedEpoch {
+		return fmt.Errorf("the end epoch (%d) is not finalized yet (the latest finalized epoch is %d)", endEpoch, beaconHead.FinalizedEpoch)
+	}
+
+	// Get the tasks to replay
+	tasks := map[string]bool{}
+	for _, task := range strings.Split(c.String("tasks"), ",") {
+		task = strings.TrimSpace(task)
+		switch task {
+		case replayTaskPrices, replayTaskBalances, replayTaskScrub, replayTaskRewards:
+			tasks[task] = true
+		case "":
+		default:
+			return fmt.Errorf("unknown task [%s]; valid tasks are %s, %s, %s, and %s", task, replayTaskPrices, replayTaskBalances, replayTaskScrub, replayTaskRewards)
+		}
+	}
+
+	// Use the archive EC for all of the historical queries if one is set
+	client := rp
+	archiveEc, err := services.GetArchiveEthClient(cfg)
+	if err != nil {
+		return fmt.Errorf("error connecting to archive EC: %w", err)
+	}
+	if archiveEc != nil {
+		logger.Printlnf("Using archive EC [%s] for historical queries.", strings.Join(archiveEc.GetUrls(), ", "))
+		client, err = rocketpool.NewRocketPool(archiveEc, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			return fmt.Errorf("error creating Rocket Pool client connected to archive EC: %w", err)
+		}
+	}
+
+	// Create the task implementations; none of them will submit anything, so their submission metrics are never served
+	submissionCollector := collectors.NewSubmissionCollector()
+	priceTask, err := newSubmitRplPrice(c, logger, errorLog, submissionCollector)
+	if err != nil {
+		return fmt.Errorf("error creating rpl price replay: %w", err)
+	}
+	balancesTask, err := newSubmitNetworkBalances(c, logger, errorLog, submissionCollector)
+	if err != nil {
+		return fmt.Errorf("error creating network balances replay: %w", err)
+	}
+	scrubTask, err := newSubmitScrubMinipools(c, logger, errorLog, nil)
+	if err != nil {
+		return fmt.Errorf("error creating scrub replay: %w", err)
+	}
+	priceTask.rp = client
+	priceTask.ec = client.Client
+	balancesTask.rp = client
+	balancesTask.ec = client.Client
+	scrubTask.rp = client
+	scrubTask.ec = client.Client
+	scrubTask.dryRun = true
+
+	r := &replayWatchtower{
+		log:                   logger,
+		cfg:                   cfg,
+		rp:                    client,
+		bc:                    bc,
+		eth2Config:            eth2Config,
+		tasks:                 tasks,
+		submitRplPrice:        priceTask,
+		submitNetworkBalances: balancesTask,
+		submitScrubMinipools:  scrubTask,
+		pendingPrices:         map[uint64]*big.Int{},
+		pendingBalances:       map[uint64]networkBalances{},
+	}
+
+	// Replay the range
+	logger.Printlnf("Replaying the watchtower from epoch %d to epoch %d (every %d epochs). Nothing will be submitted.", startEpoch, endEpoch, step)
+	for epoch := startEpoch; epoch <= endEpoch; epoch += step {
+		err := r.replayEpoch(epoch)
+		if err != nil {
+			errorLog.Println(fmt.Errorf("error replaying epoch %d: %w", epoch, err))
+		}
+	}
+	if r.tasks[replayTaskRewards] {
+		err := r.replayRewardsTrees(startEpoch, endEpoch)
+		if err != nil {
+			errorLog.Println(fmt.Errorf("error replaying rewards trees: %w", err))
+		}
+	}
+
+	// Report anything that never reached consensus in the range
+	for block := range r.pendingPrices {
+		logger.Printlnf("Prices for block %d had not reached consensus by epoch %d.", block, endEpoch)
+	}
+	for block := range r.pendingBalances {
+		logger.Printlnf("Balances for block %d had not reached consensus by epoch %d.", block, endEpoch)
+	}
+	logger.Println("Replay complete.")
+	return nil
+
+}
+
+// Replay the price, balance, and scrub checks at the first slot of an epoch
+func (r *replayWatchtower) replayEpoch(epoch uint64) error {
+
+	// Get the network state for the epoch
+	block, err := r.getProposedBlock(epoch * r.eth2Config.SlotsPerEpoch)
+	if err != nil {
+		return err
+	}
+	client, err := eth1.GetBestApiClient(r.rp, r.cfg, r.printMessage, big.NewInt(0).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		return err
+	}
+	m, err := state.NewNetworkStateManager(client, r.cfg, client.Client, r.bc, &r.log)
+	if err != nil {
+		return fmt.Errorf("error creating network state manager: %w", err)
+	}
+	r.log.Printlnf("=== Epoch %d (Beacon slot %d, EL block %d) ===", epoch, block.Slot, block.ExecutionBlockNumber)
+	networkState, err := m.GetStateForSlot(block.Slot)
+	if err != nil {
+		return fmt.Errorf("error getting network state for slot %d: %w", block.Slot, err)
+	}
+
+	if r.tasks[replayTaskPrices] {
+		if err := r.replayPrices(networkState); err != nil {
+			r.log.Printlnf("Error replaying RPL price: %s", err.Error())
+		}
+	}
+	if r.tasks[replayTaskBalances] {
+		if err := r.replayBalances(networkState); err != nil {
+			r.log.Printlnf("Error replaying network balances: %s", err.Error())
+		}
+	}
+	if r.tasks[replayTaskScrub] {
+		r.submitScrubMinipools.checkMinipools(networkState)
+	}
+
+	return nil
+
+}
+
+// Replay the RPL price calculation for the state's reportable block
+func (r *replayWatchtower) replayPrices(networkState *state.NetworkState) error {
+
+	// Compare the canonical price against what would have been submitted
+	pricesBlock := networkState.NetworkDetails.PricesBlock
+	if price, exists := r.pendingPrices[pricesBlock]; exists {
+		canonicalPrice := networkState.NetworkDetails.RplPrice
+		if price.Cmp(canonicalPrice) == 0 {
+			r.log.Printlnf("Prices for block %d reached consensus on %s wei, which matches the replayed price.", pricesBlock, canonicalPrice.String())
+		} else {
+			r.log.Warnf("prices for block %d reached consensus on %s wei, but the replayed price was %s wei!", pricesBlock, canonicalPrice.String(), price.String())
+		}
+		delete(r.pendingPrices, pricesBlock)
+	}
+
+	// Check if a submission would have been made
+	if !networkState.NetworkDetails.SubmitPricesEnabled {
+		return nil
+	}
+	blockNumber := networkState.NetworkDetails.LatestReportablePricesBlock
+	if blockNumber <= pricesBlock {
+		return nil
+	}
+	if _, exists := r.pendingPrices[blockNumber]; exists {
+		return nil
+	}
+
+	// Get the epoch of the reportable block
+	targetEpoch, _, err := r.getEpochForBlock(blockNumber)
+	if err != nil {
+		return err
+	}
+
+	// Get RPL price at block
+	var rplPrice *big.Int
+	twapEpoch := r.cfg.Smartnode.RplTwapEpoch.Value.(uint64)
+	if targetEpoch < twapEpoch {
+		rplPrice, err = r.submitRplPrice.getRplPrice(blockNumber)
+	} else {
+		rplPrice, err = r.submitRplPrice.getRplTwap(blockNumber)
+	}
+	if err != nil {
+		return err
+	}
+	r.log.Printlnf("Would have submitted an RPL price of %.6f ETH (%s wei) for block %d.", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6), rplPrice.String(), blockNumber)
+
+	// Get the effective RPL stake
+	if networkState.IsAtlasDeployed {
+		_, effectiveRplStake, err := networkState.CalculateTrueEffectiveStakes(false)
+		if err != nil {
+			return fmt.Errorf("error getting total effective RPL stake: %w", err)
+		}
+		r.log.Printlnf("Would have submitted a total effective RPL stake of %.6f RPL for block %d.", eth.WeiToEth(effectiveRplStake), blockNumber)
+	}
+
+	r.pendingPrices[blockNumber] = rplPrice
+	return nil
+
+}
+
+// Replay the network balance calculation for the state's reportable block
+func (r *replayWatchtower) replayBalances(networkState *state.NetworkState) error {
+
+	// Compare the canonical balances against what would have been submitted
+	balancesBlock := networkState.NetworkDetails.BalancesBlock.Uint64()
+	if balances, exists := r.pendingBalances[balancesBlock]; exists {
+		totalEth := getTotalEth(balances)
+		details := networkState.NetworkDetails
+		if totalEth.Cmp(details.TotalETHBalance) == 0 && balances.MinipoolsStaking.Cmp(details.StakingETHBalance) == 0 && balances.RETHSupply.Cmp(details.TotalRETHSupply) == 0 {
+			r.log.Printlnf("Balances for block %d reached consensus and match the replayed balances.", balancesBlock)
+		} else {
+			r.log.Warnf("balances for block %d reached consensus on different values than the replayed ones!", balancesBlock)
+			r.log.Printlnf("\tTotal ETH: %s wei (replayed %s wei)", details.TotalETHBalance.String(), totalEth.String())
+			r.log.Printlnf("\tStaking ETH: %s wei (replayed %s wei)", details.StakingETHBalance.String(), balances.MinipoolsStaking.String())
+			r.log.Printlnf("\trETH supply: %s wei (replayed %s wei)", details.TotalRETHSupply.String(), balances.RETHSupply.String())
+		}
+		delete(r.pendingBalances, balancesBlock)
+	}
+
+	// Check if a submission would have been made
+	if !networkState.NetworkDetails.SubmitBalancesEnabled {
+		return nil
+	}
+	blockNumber := networkState.NetworkDetails.LatestReportableBalancesBlock.Uint64()
+	if blockNumber <= balancesBlock {
+		return nil
+	}
+	if _, exists := r.pendingBalances[blockNumber]; exists {
+		return nil
+	}
+
+	// Get the Beacon slot of the reportable block
+	requiredEpoch, slotNumber, err := r.getEpochForBlock(blockNumber)
+	if err != nil {
+		return err
+	}
+	if requiredEpoch < r.cfg.Smartnode.BalancesModernizationEpoch.Value.(uint64) {
+		r.log.Printlnf("Balances for block %d would have used the legacy reporting behavior, which can't be replayed.", blockNumber)
+		return nil
+	}
+
+	// Get network balances at block
+	header, err := r.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("error getting header for EL block %d: %w", blockNumber, err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	balances, err := r.submitNetworkBalances.getNetworkBalances(header, header.Number, slotNumber, blockTime, networkState.IsAtlasDeployed)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	r.log.Printlnf("Would have submitted these balances for block %d:", blockNumber)
+	r.log.Printlnf("\tTotal ETH: %s wei", getTotalEth(balances).String())
+	r.log.Printlnf("\tStaking ETH: %s wei", balances.MinipoolsStaking.String())
+	r.log.Printlnf("\trETH supply: %s wei", balances.RETHSupply.String())
+
+	r.pendingBalances[blockNumber] = balances
+	return nil
+
+}
+
+// Regenerate the rewards trees for the intervals whose snapshots fall in the epoch range and compare them to the canonical roots
+func (r *replayWatchtower) replayRewardsTrees(startEpoch uint64, endEpoch uint64) error {
+
+	// Get the current interval
+	currentIndexBig, err := rewards.GetRewardIndex(r.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting current reward index: %w", err)
+	}
+	startSlot := startEpoch * r.eth2Config.SlotsPerEpoch
+	endSlot := (endEpoch+1)*r.eth2Config.SlotsPerEpoch - 1
+
+	// Work backwards through the intervals until the snapshots are before the range
+	for index := currentIndexBig.Uint64(); index > 0; index-- {
+		interval := index - 1
+		event, err := rprewards.GetRewardSnapshotEvent(r.rp, r.cfg, interval)
+		if err != nil {
+			return fmt.Errorf("error getting event for interval %d: %w", interval, err)
+		}
+		snapshotSlot := event.ConsensusBlock.Uint64()
+		if snapshotSlot < startSlot {
+			break
+		}
+		if snapshotSlot > endSlot {
+			continue
+		}
+
+		err = r.replayRewardsTree(interval, event)
+		if err != nil {
+			r.log.Printlnf("Error replaying rewards tree for interval %d: %s", interval, err.Error())
+		}
+	}
+
+	return nil
+
+}
+
+// Regenerate a single rewards tree and compare it to the canonical root
+func (r *replayWatchtower) replayRewardsTree(interval uint64, event rewards.RewardsEvent) error {
+
+	generationPrefix := fmt.Sprintf("[Interval %d Replay]", interval)
+	r.log.Printlnf("%s Regenerating the rewards tree (Beacon block %s, EL block %s)...", generationPrefix, event.ConsensusBlock.String(), event.ExecutionBlock.String())
+
+	// Get the state for the snapshot
+	client, err := eth1.GetBestApiClient(r.rp, r.cfg, r.printMessage, event.ExecutionBlock)
+	if err != nil {
+		return err
+	}
+	elBlockHeader, err := client.Client.HeaderByNumber(context.Background(), event.ExecutionBlock)
+	if err != nil {
+		return fmt.Errorf("error getting execution block: %w", err)
+	}
+	m, err := state.NewNetworkStateManager(client, r.cfg, client.Client, r.bc, &r.log)
+	if err != nil {
+		return fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := m.GetStateForSlot(event.ConsensusBlock.Uint64())
+	if err != nil {
+		return fmt.Errorf("error getting state for beacon slot %d: %w", event.ConsensusBlock.Uint64(), err)
+	}
+
+	// Generate the tree
+	treegen, err := rprewards.NewTreeGenerator(r.log, generationPrefix, client, r.cfg, r.bc, interval, event.IntervalStartTime, event.IntervalEndTime, event.ConsensusBlock.Uint64(), elBlockHeader, event.IntervalsPassed.Uint64(), networkState)
+	if err != nil {
+		return fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+
+	// Compare the roots
+	root := common.BytesToHash(rewardsFile.MerkleTree.Root())
+	if root != event.MerkleRoot {
+		r.log.Printlnf("%s WARNING: the replayed tree has a root of %s, but the canonical root is %s!", generationPrefix, root.Hex(), event.MerkleRoot.Hex())
+	} else {
+		r.log.Printlnf("%s The replayed tree's root of %s matches the canonical root.", generationPrefix, root.Hex())
+	}
+	return nil
+
+}
+
+// Gets the target Beacon block, or if it was missing, the first one under it that wasn't missing
+func (r *replayWatchtower) getProposedBlock(targetSlot uint64) (beacon.BeaconBlock, error) {
+	for {
+		block, exists, err := r.bc.GetBeaconBlock(fmt.Sprint(targetSlot))
+		if err != nil {
+			return beacon.BeaconBlock{}, fmt.Errorf("error getting Beacon block %d: %w", targetSlot, err)
+		}
+		if exists {
+			return block, nil
+		}
+		targetSlot--
+	}
+}
+
+// Get the Beacon epoch and slot that correspond to an EL block
+func (r *replayWatchtower) getEpochForBlock(blockNumber uint64) (uint64, uint64, error) {
+	header, err := r.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting header for EL block %d: %w", blockNumber, err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	genesisTime := time.Unix(int64(r.eth2Config.GenesisTime), 0)
+	slotNumber := uint64(blockTime.Sub(genesisTime).Seconds()) / r.eth2Config.SecondsPerSlot
+	return slotNumber / r.eth2Config.SlotsPerEpoch, slotNumber, nil
+}
+
+// Prints a message to the log
+func (r *replayWatchtower) printMessage(message string) {
+	r.log.Println(message)
+}
@@ -0,0 +1,124 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Submission types, used as metric labels
+const (
+	submissionType_Prices   string = "prices"
+	submissionType_Balances string = "balances"
+	submissionType_Rewards  string = "rewards"
+)
+
+// Reasons a submission was skipped, used as metric labels
+const (
+	avoidedReason_ConsensusExecuted string = "consensus_executed"
+)
+
+// Coordinates this member's submissions with the rest of the Oracle DAO, so it doesn't spend gas on transactions
+// that would revert because the other members already reached consensus. Only executed consensus counts: a submission
+// that meets the threshold is the one that executes the update, so the submission counts alone are never a reason to skip.
+type submissionCoordinator struct {
+	cfg    *config.RocketPoolConfig
+	rp     *rocketpool.RocketPool
+	log    log.ColorLogger
+	coll   *collectors.SubmissionCollector
+	random *rand.Rand
+	lock   *sync.Mutex
+}
+
+// Create a submission coordinator
+func newSubmissionCoordinator(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, logger log.ColorLogger, coll *collectors.SubmissionCollector) *submissionCoordinator {
+	return &submissionCoordinator{
+		cfg:    cfg,
+		rp:     rp,
+		log:    logger,
+		coll:   coll,
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		lock:   &sync.Mutex{},
+	}
+}
+
+// Wait for a random part of the submission delay window and check if the prices for a block still need to be submitted
+func (s *submissionCoordinator) shouldSubmitPrices(blockNumber uint64) (bool, error) {
+	s.waitForSubmissionWindow(submissionType_Prices)
+
+	// Check if consensus was already executed
+	pricesBlock, err := network.GetPricesBlock(s.rp, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting the latest prices block: %w", err)
+	}
+	if pricesBlock >= blockNumber {
+		s.skip(submissionType_Prices, avoidedReason_ConsensusExecuted, fmt.Sprintf("The Oracle DAO has already set the prices for block %d, skipping submission.", blockNumber))
+		return false, nil
+	}
+	return true, nil
+}
+
+// Wait for a random part of the submission delay window and check if the balances for a block still need to be submitted
+func (s *submissionCoordinator) shouldSubmitBalances(blockNumber uint64) (bool, error) {
+	s.waitForSubmissionWindow(submissionType_Balances)
+
+	// Check if consensus was already executed
+	balancesBlock, err := network.GetBalancesBlock(s.rp, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting the latest balances block: %w", err)
+	}
+	if balancesBlock >= blockNumber {
+		s.skip(submissionType_Balances, avoidedReason_ConsensusExecuted, fmt.Sprintf("The Oracle DAO has already set the network balances for block %d, skipping submission.", blockNumber))
+		return false, nil
+	}
+	return true, nil
+}
+
+// Wait for a random part of the submission delay window and check if the rewards snapshot for an interval still needs to be submitted
+func (s *submissionCoordinator) shouldSubmitRewards(index *big.Int) (bool, error) {
+	s.waitForSubmissionWindow(submissionType_Rewards)
+
+	// Check if consensus was already executed
+	currentIndex, err := rewards.GetRewardIndex(s.rp, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting the current rewards interval: %w", err)
+	}
+	if currentIndex.Cmp(index) > 0 {
+		s.skip(submissionType_Rewards, avoidedReason_ConsensusExecuted, fmt.Sprintf("The Oracle DAO has already submitted the rewards snapshot for interval %s, skipping submission.", index.String()))
+		return false, nil
+	}
+	return true, nil
+}
+
+// Wait for a random part of the submission delay window, so the members don't all submit in the same block
+func (s *submissionCoordinator) waitForSubmissionWindow(submissionType string) {
+	window := time.Duration(s.cfg.Smartnode.WatchtowerSubmissionDelayWindow.Value.(uint64)) * time.Second
+	if window <= 0 {
+		s.coll.RecordSubmissionDelay(submissionType, 0)
+		return
+	}
+
+	s.lock.Lock()
+	delay := time.Duration(s.random.Int63n(int64(window)))
+	s.lock.Unlock()
+
+	s.coll.RecordSubmissionDelay(submissionType, delay)
+	s.log.Printlnf("Waiting %s before submitting %s...", delay.Round(time.Second), submissionType)
+	time.Sleep(delay)
+}
+
+// Log and record a skipped submission
+func (s *submissionCoordinator) skip(submissionType string, reason string, message string) {
+	s.log.Println(message)
+	s.coll.RecordAvoidedSubmission(submissionType, reason)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/legacy"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -32,17 +33,18 @@ import (
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c          *cli.Context
-	log        log.ColorLogger
-	errLog     log.ColorLogger
-	cfg        *config.RocketPoolConfig
-	w          *wallet.Wallet
-	ec         rocketpool.ExecutionClient
-	rp         *rocketpool.RocketPool
-	bc         beacon.Client
-	lock       *sync.Mutex
-	isRunning  bool
-	legacyImpl *legacy.SubmitNetworkBalances
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	ec          rocketpool.ExecutionClient
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	lock        *sync.Mutex
+	isRunning   bool
+	legacyImpl  *legacy.SubmitNetworkBalances
+	coordinator *submissionCoordinator
 }
 
 // Network balance info
@@ -63,7 +65,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, submissionCollector *collectors.SubmissionCollector) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -96,17 +98,18 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitNetworkBalances{
-		c:          c,
-		log:        logger,
-		errLog:     errorLogger,
-		cfg:        cfg,
-		w:          w,
-		ec:         ec,
-		rp:         rp,
-		bc:         bc,
-		lock:       lock,
-		isRunning:  false,
-		legacyImpl: legacyImpl,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		w:           w,
+		ec:          ec,
+		rp:          rp,
+		bc:          bc,
+		lock:        lock,
+		isRunning:   false,
+		legacyImpl:  legacyImpl,
+		coordinator: newSubmissionCoordinator(cfg, rp, logger, submissionCollector),
 	}, nil
 
 }
@@ -280,13 +283,7 @@ func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Add
 func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEth(balances)
 
 	blockNumberBuf := make([]byte, 32)
 	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
@@ -506,18 +503,21 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(mpd *rpstate.NativeMin
 func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEth(balances)
 
 	ratio := eth.WeiToEth(totalEth) / eth.WeiToEth(balances.RETHSupply)
 	t.log.Printlnf("Total ETH = %s\n", totalEth)
 	t.log.Printlnf("Calculated ratio = %.6f\n", ratio)
 
+	// Make sure the Oracle DAO still needs these balances
+	shouldSubmit, err := t.coordinator.shouldSubmitBalances(balances.Block)
+	if err != nil {
+		return err
+	}
+	if !shouldSubmit {
+		return nil
+	}
+
 	// Log
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
@@ -563,3 +563,15 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	return nil
 
 }
+
+// Calculate the total ETH balance to submit for a set of network balances
+func getTotalEth(balances networkBalances) *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Sub(totalEth, balances.NodeCreditBalance)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+	totalEth.Add(totalEth, balances.DistributorShareTotal)
+	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	return totalEth
+}
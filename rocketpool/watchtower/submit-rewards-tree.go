@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -47,10 +48,11 @@ type submitRewardsTree struct {
 	isRunning        bool
 	generationPrefix string
 	m                *state.NetworkStateManager
+	coordinator      *submissionCoordinator
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree, error) {
+func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, submissionCollector *collectors.SubmissionCollector) (*submitRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -88,6 +90,7 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
 		m:                m,
+		coordinator:      newSubmissionCoordinator(cfg, rp, logger, submissionCollector),
 	}
 
 	return generator, nil
@@ -250,13 +253,13 @@ func (t *submitRewardsTree) isExistingFileValid(rewardsTreePath string, interval
 		var proofWrapper rprewards.RewardsFile
 		fileBytes, err := os.ReadFile(rewardsTreePath)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
 		err = json.Unmarshal(fileBytes, &proofWrapper)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
@@ -307,7 +310,7 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 
 	// Log
 	if uint64(intervalsPassed) > 1 {
-		t.log.Printlnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
+		t.log.Warnf("%d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.generationPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
@@ -403,6 +406,15 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 // Submit rewards info to the contracts
 func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock uint64, executionBlock uint64, rewardsFile *rprewards.RewardsFile, cid string, intervalsPassed *big.Int) error {
 
+	// Make sure the Oracle DAO still needs this snapshot
+	shouldSubmit, err := t.coordinator.shouldSubmitRewards(index)
+	if err != nil {
+		return err
+	}
+	if !shouldSubmit {
+		return nil
+	}
+
 	treeRootBytes, err := hex.DecodeString(hexutil.RemovePrefix(rewardsFile.MerkleRoot))
 	if err != nil {
 		return fmt.Errorf("Error decoding merkle root: %w", err)
@@ -23,6 +23,7 @@ import (
 	"github.com/urfave/cli"
 
 	v110_network "github.com/rocket-pool/rocketpool-go/legacy/v1.1.0/network"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -196,21 +197,22 @@ type poolObserveResponse struct {
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	ec        rocketpool.ExecutionClient
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	oio       *contracts.OneInchOracle
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	ec          rocketpool.ExecutionClient
+	w           *wallet.Wallet
+	rp          *rocketpool.RocketPool
+	oio         *contracts.OneInchOracle
+	bc          beacon.Client
+	lock        *sync.Mutex
+	isRunning   bool
+	coordinator *submissionCoordinator
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, submissionCollector *collectors.SubmissionCollector) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -241,16 +243,17 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitRplPrice{
-		c:      c,
-		log:    logger,
-		errLog: errorLogger,
-		cfg:    cfg,
-		ec:     ec,
-		w:      w,
-		rp:     rp,
-		oio:    oio,
-		bc:     bc,
-		lock:   lock,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		ec:          ec,
+		w:           w,
+		rp:          rp,
+		oio:         oio,
+		bc:          bc,
+		lock:        lock,
+		coordinator: newSubmissionCoordinator(cfg, rp, logger, submissionCollector),
 	}, nil
 
 }
@@ -621,6 +624,17 @@ func (t *submitRplPrice) printMessage(message string) {
 // Submit RPL price and total effective RPL stake
 func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveRplStake *big.Int, isAtlasDeployed bool) error {
 
+	// Make sure the Oracle DAO still needs this price
+	if isAtlasDeployed {
+		shouldSubmit, err := t.coordinator.shouldSubmitPrices(blockNumber)
+		if err != nil {
+			return err
+		}
+		if !shouldSubmit {
+			return nil
+		}
+	}
+
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
 
@@ -1051,7 +1065,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 	if index == indexToSubmit {
 
 		// Get the current network recommended max fee
-		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
 		}
@@ -36,6 +36,7 @@ const MinipoolBatchSize = 20
 const BlockStartOffset = 100000
 const ScrubSafetyDivider = 2
 const MinScrubSafetyTime = time.Duration(0) * time.Hour
+const scrubCheckPrefix = "[Minipool Scrub]"
 
 // Submit scrub minipools task
 type submitScrubMinipools struct {
@@ -51,6 +52,7 @@ type submitScrubMinipools struct {
 	coll      *collectors.ScrubCollector
 	lock      *sync.Mutex
 	isRunning bool
+	dryRun    bool
 }
 
 type iterationData struct {
@@ -151,100 +153,94 @@ func (t *submitScrubMinipools) run(state *state.NetworkState, isAtlasDeployed bo
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
-		checkPrefix := "[Minipool Scrub]"
-		t.log.Printlnf("%s Starting scrub check in a separate thread.", checkPrefix)
+		t.log.Printlnf("%s Starting scrub check in a separate thread.", scrubCheckPrefix)
+		t.checkMinipools(state)
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+	}()
 
-		t.it = new(iterationData)
+	// Return
+	return nil
 
-		// Get minipools in prelaunch status
-		prelaunchMinipools := []rpstate.NativeMinipoolDetails{}
-		for _, mpd := range state.MinipoolDetails {
-			if mpd.Status == types.Prelaunch {
-				prelaunchMinipools = append(prelaunchMinipools, mpd)
-			}
-		}
+}
 
-		t.it.totalMinipools = len(prelaunchMinipools)
-		if t.it.totalMinipools == 0 {
-			t.log.Printlnf("%s No minipools in prelaunch.", checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+// Check the prelaunch minipools in the provided state and scrub any with invalid deposits
+func (t *submitScrubMinipools) checkMinipools(state *state.NetworkState) {
 
-		t.it.minipools = make(map[minipool.Minipool]*minipoolDetails, t.it.totalMinipools)
+	checkPrefix := scrubCheckPrefix
+	t.it = new(iterationData)
 
-		// Get the correct withdrawal credentials and validator pubkeys for each minipool
-		opts := &bind.CallOpts{
-			BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
-		}
-		t.initializeMinipoolDetails(prelaunchMinipools, opts)
-
-		// Step 1: Verify the Beacon credentials if they exist
-		t.verifyBeaconWithdrawalCredentials(state)
-
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
+	// Get minipools in prelaunch status
+	prelaunchMinipools := []rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetails {
+		if mpd.Status == types.Prelaunch {
+			prelaunchMinipools = append(prelaunchMinipools, mpd)
 		}
+	}
 
-		// Get various elements needed to do eth1 prestake and deposit contract searches
-		err := t.getEth1SearchArtifacts(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	t.it.totalMinipools = len(prelaunchMinipools)
+	if t.it.totalMinipools == 0 {
+		t.log.Printlnf("%s No minipools in prelaunch.", checkPrefix)
+		return
+	}
 
-		// Step 2: Verify the MinipoolPrestaked events
-		t.verifyPrestakeEvents()
+	t.it.minipools = make(map[minipool.Minipool]*minipoolDetails, t.it.totalMinipools)
 
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// Get the correct withdrawal credentials and validator pubkeys for each minipool
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	t.initializeMinipoolDetails(prelaunchMinipools, opts)
 
-		// Step 3: Verify the deposit data of the remaining minipools
-		err = t.verifyDeposits()
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	// Step 1: Verify the Beacon credentials if they exist
+	t.verifyBeaconWithdrawalCredentials(state)
 
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
+		t.printFinalTally(checkPrefix)
+		return
+	}
 
-		// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
-		err = t.checkSafetyScrub(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	// Get various elements needed to do eth1 prestake and deposit contract searches
+	err := t.getEth1SearchArtifacts(state)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
 
-		// Log and return
+	// Step 2: Verify the MinipoolPrestaked events
+	t.verifyPrestakeEvents()
+
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
 		t.printFinalTally(checkPrefix)
-		t.it = nil
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+		return
+	}
 
-	// Return
-	return nil
+	// Step 3: Verify the deposit data of the remaining minipools
+	err = t.verifyDeposits()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
+
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
+		t.printFinalTally(checkPrefix)
+		return
+	}
+
+	// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
+	err = t.checkSafetyScrub(state)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
+
+	// Log and return
+	t.printFinalTally(checkPrefix)
+	t.it = nil
 
 }
 
@@ -293,11 +289,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 			expectedCreds := details.expectedWithdrawalCredentials
 			beaconCreds := status.WithdrawalCredentials
 			if beaconCreds != expectedCreds {
-				t.log.Println("=== SCRUB DETECTED ON BEACON CHAIN ===")
-				t.log.Printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
-				t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
-				t.log.Printlnf("\tActual creds: %s", beaconCreds.Hex())
-				t.log.Println("======================================")
+				t.log.Warnw("SCRUB DETECTED ON BEACON CHAIN", log.Any("minipool", minipool.GetAddress().Hex()), log.Any("expectedCreds", expectedCreds.Hex()), log.Any("actualCreds", beaconCreds.Hex()))
 				minipoolsToScrub = append(minipoolsToScrub, minipool)
 				t.it.badOnBeaconCount++
 			} else {
@@ -315,7 +307,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.Errorw("ALERT: Couldn't scrub minipool", log.Any("minipool", minipool.GetAddress().Hex()), log.Err(err))
 		}
 	}
 
@@ -390,10 +382,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 		err = prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
 		if err != nil {
 			// The signature is illegal
-			t.log.Println("=== SCRUB DETECTED ON PRESTAKE EVENT ===")
-			t.log.Printlnf("Invalid prestake data for minipool %s:", minipool.GetAddress().Hex())
-			t.log.Printlnf("\tError: %s", err.Error())
-			t.log.Println("========================================")
+			t.log.Warnw("SCRUB DETECTED ON PRESTAKE EVENT: invalid prestake data", log.Any("minipool", minipool.GetAddress().Hex()), log.Err(err))
 
 			// Remove this minipool from the list of things to process in the next step
 			minipoolsToScrub = append(minipoolsToScrub, minipool)
@@ -409,7 +398,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.Errorw("ALERT: Couldn't scrub minipool", log.Any("minipool", minipool.GetAddress().Hex()), log.Err(err))
 		}
 	}
 
@@ -454,22 +443,13 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 			err := prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
 			if err != nil {
 				// This isn't a valid deposit, so ignore it
-				t.log.Printlnf("Invalid deposit for minipool %s:", minipool.GetAddress().Hex())
-				t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
-				t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
-				t.log.Printlnf("\tError: %s", err.Error())
+				t.log.Infow("Invalid deposit for minipool", log.Any("minipool", minipool.GetAddress().Hex()), log.Any("tx", deposit.TxHash.Hex()), log.Any("block", deposit.BlockNumber), log.Any("txIndex", deposit.TxIndex), log.Any("depositIndex", depositIndex), log.Err(err))
 			} else {
 				// This is a valid deposit
 				expectedCreds := details.expectedWithdrawalCredentials
 				actualCreds := deposit.WithdrawalCredentials
 				if actualCreds != expectedCreds {
-					t.log.Println("=== SCRUB DETECTED ON DEPOSIT CONTRACT ===")
-					t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
-					t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
-					t.log.Printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
-					t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
-					t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
-					t.log.Println("==========================================")
+					t.log.Warnw("SCRUB DETECTED ON DEPOSIT CONTRACT", log.Any("minipool", minipool.GetAddress().Hex()), log.Any("tx", deposit.TxHash.Hex()), log.Any("block", deposit.BlockNumber), log.Any("txIndex", deposit.TxIndex), log.Any("depositIndex", depositIndex), log.Any("expectedCreds", expectedCreds.Hex()), log.Any("actualCreds", actualCreds.Hex()))
 					minipoolsToScrub = append(minipoolsToScrub, minipool)
 					t.it.badOnDepositContract++
 				} else {
@@ -487,7 +467,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.Errorw("ALERT: Couldn't scrub minipool", log.Any("minipool", minipool.GetAddress().Hex()), log.Err(err))
 		}
 	}
 
@@ -504,7 +484,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	// Warn if there are any remaining minipools - this should never happen
 	remainingMinipools := len(t.it.minipools)
 	if remainingMinipools > 0 {
-		t.log.Printlnf("WARNING: %d minipools did not have deposit information", remainingMinipools)
+		t.log.Warnf("%d minipools did not have deposit information", remainingMinipools)
 	} else {
 		return nil
 	}
@@ -524,18 +504,14 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 
 		// Verify this is actually a prelaunch minipool
 		if mpd.Status != types.Prelaunch {
-			t.log.Printlnf("\tMinipool %s is under review but is in %d status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
+			t.log.Printlnf("\tMinipool %s is under review but is in %s status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
 			continue
 		}
 
 		// Check the time it entered prelaunch against the safety period
 		statusTime := time.Unix(mpd.StatusTime.Int64(), 0)
 		if t.it.stateBlockTime.Sub(statusTime) > safetyPeriod {
-			t.log.Println("=== SAFETY SCRUB DETECTED ===")
-			t.log.Printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
-			t.log.Printlnf("\tTime since prelaunch: %s", time.Since(statusTime))
-			t.log.Printlnf("\tSafety scrub period: %s", safetyPeriod)
-			t.log.Println("=============================")
+			t.log.Warnw("SAFETY SCRUB DETECTED", log.Any("minipool", minipool.GetAddress().Hex()), log.Any("timeSincePrelaunch", time.Since(statusTime).String()), log.Any("safetyPeriod", safetyPeriod.String()))
 			minipoolsToScrub = append(minipoolsToScrub, minipool)
 			t.it.safetyScrubs++
 			// Remove this minipool from the list of things to process in the next step
@@ -547,7 +523,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.Errorw("ALERT: Couldn't scrub minipool", log.Any("minipool", minipool.GetAddress().Hex()), log.Err(err))
 		}
 	}
 
@@ -558,8 +534,14 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 // Submit minipool scrub status
 func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
 
+	// Only log the vote when replaying historical blocks
+	if t.dryRun {
+		t.log.Infow("[DRY RUN] Would have voted to scrub minipool.", log.Any("minipool", mp.GetAddress().Hex()))
+		return nil
+	}
+
 	// Log
-	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
+	t.log.Infow("Voting to scrub minipool...", log.Any("minipool", mp.GetAddress().Hex()))
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -597,7 +579,7 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
+	t.log.Infow("Successfully voted to scrub the minipool.", log.Any("minipool", mp.GetAddress().Hex()), log.Any("tx", hash.Hex()))
 
 	// Return
 	return nil
@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -44,6 +45,8 @@ const (
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
 	UpdateColor                    = color.FgHiWhite
+	ReplayColor                    = color.FgHiBlue
+	CheckOdaoDutiesColor           = color.FgBlue
 )
 
 // Register watchtower command
@@ -55,12 +58,55 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
+		Subcommands: []cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replay the watchtower's duties against a range of historical epochs and log what it would have submitted, without submitting anything",
+				UsageText: "rocketpool watchtower replay --start-epoch epoch --end-epoch epoch [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "start-epoch",
+						Usage: "The first epoch to replay",
+					},
+					cli.Uint64Flag{
+						Name:  "end-epoch",
+						Usage: "The last epoch to replay; this must be finalized",
+					},
+					cli.Uint64Flag{
+						Name:  "step",
+						Usage: "The number of epochs between each replayed state",
+						Value: defaultReplayStep,
+					},
+					cli.StringFlag{
+						Name:  "tasks",
+						Usage: "A comma-separated list of the duties to replay (prices, balances, scrub, rewards)",
+						Value: "prices,balances,scrub,rewards",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return runReplay(c)
+				},
+			},
+		},
 	})
 }
 
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Configure logging
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	logSettings, err := cfg.Smartnode.GetLogSettings("watchtower")
+	if err != nil {
+		return fmt.Errorf("error getting log settings: %w", err)
+	}
+	if err := log.Configure(logSettings); err != nil {
+		return fmt.Errorf("error configuring logging: %w", err)
+	}
+
 	// Configure
 	configureHTTP()
 
@@ -70,10 +116,6 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -91,10 +133,32 @@ func run(c *cli.Context) error {
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
+	odaoDutiesCollector := collectors.NewOdaoDutiesCollector()
+	submissionCollector := collectors.NewSubmissionCollector()
 
 	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	errorLog := log.NewErrorLogger("watchtower", ErrorColor)
+	updateLog := log.NewModuleLogger("updates", UpdateColor)
+
+	// Record the requests sent to the clients if requested
+	if cfg.Smartnode.EnableEndpointAccessLog.Value == true {
+		if err := accesslog.Enable(cfg.Smartnode.GetEndpointAccessLogPath("watchtower"), "watchtower"); err != nil {
+			errorLog.Warnf("couldn't enable the endpoint access log: %s", err.Error())
+		}
+	}
+
+	// Make it clear if the daemon is running against overridden contracts
+	overrides, err := cfg.Smartnode.GetContractAddressOverrides()
+	if err != nil {
+		return fmt.Errorf("error parsing contract address overrides: %w", err)
+	}
+	if len(overrides) > 0 {
+		warningLog := log.NewWarningLogger("watchtower", WarningColor)
+		warningLog.Warn("contract address overrides are active! The following contracts will use custom addresses instead of the built-in ones:")
+		for name, address := range overrides {
+			warningLog.Printlnf("\t%s: %s", name, address.Hex())
+		}
+	}
 
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
@@ -108,47 +172,57 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
+	// A masquerading wallet can't sign submissions, so it's never treated as an Oracle DAO member
+	isMasquerading := w.IsMasquerading()
+	if isMasquerading {
+		updateLog.Printlnf("The wallet is masquerading as node %s, so Oracle DAO duties are disabled.", nodeAccount.Address.Hex())
+	}
+
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, log.NewModuleLogger("respond-challenges", RespondChallengesColor), m)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewModuleLogger("submit-rpl-price", SubmitRplPriceColor), errorLog, submissionCollector)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewModuleLogger("submit-network-balances", SubmitNetworkBalancesColor), errorLog, submissionCollector)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewModuleLogger("dissolve-timed-out-minipools", DissolveTimedOutMinipoolsColor))
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewModuleLogger("submit-scrub-minipools", SubmitScrubMinipoolsColor), errorLog, scrubCollector)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
-	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewModuleLogger("submit-rewards-tree", SubmitRewardsTreeColor), errorLog, m, submissionCollector)
 	if err != nil {
 		return fmt.Errorf("error during rewards tree check: %w", err)
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
+	/*processPenalties, err := newProcessPenalties(c, log.NewModuleLogger("process-penalties", ProcessPenaltiesColor), errorLog)
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewModuleLogger("generate-rewards-tree", SubmitRewardsTreeColor), errorLog, m)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector)
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewModuleLogger("cancel-bond-reductions", CancelBondsColor), errorLog, bondReductionCollector)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, soloMigrationCollector)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewModuleLogger("check-solo-migrations", CheckSoloMigrationsColor), errorLog, soloMigrationCollector)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
+	checkOdaoDuties, err := newCheckOdaoDuties(c, log.NewModuleLogger("check-odao-duties", CheckOdaoDutiesColor), odaoDutiesCollector)
+	if err != nil {
+		return fmt.Errorf("error during oDAO duties check: %w", err)
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
@@ -160,6 +234,7 @@ func run(c *cli.Context) error {
 	// Run task loop
 	isAtlasDeployedMasterFlag := false
 	go func() {
+		defer wg.Done()
 		for {
 			// Randomize the next interval
 			randomSeconds := rand.Intn(int(secondsDelta))
@@ -197,6 +272,9 @@ func run(c *cli.Context) error {
 				time.Sleep(taskCooldown)
 				continue
 			}
+			if isMasquerading {
+				isOnOdao = false
+			}
 
 			// Run the manual rewards tree generation
 			if err := generateRewardsTree.run(); err != nil {
@@ -221,49 +299,55 @@ func run(c *cli.Context) error {
 
 				// Run the rewards tree submission check
 				if err := submitRewardsTree.run(isOnOdao, state, latestBlock.Slot, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "submit_rewards_tree"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the challenge check
 				if err := respondChallenges.run(isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "respond_challenges"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the price submission check
 				if err := submitRplPrice.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "submit_rpl_price"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the network balance submission check
 				if err := submitNetworkBalances.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "submit_network_balances"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool dissolve check
 				if err := dissolveTimedOutMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "dissolve_timed_out_minipools"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool scrub check
 				if err := submitScrubMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "submit_scrub_minipools"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the bond cancel check
 				if err := cancelBondReductions.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "cancel_bond_reductions"), log.Err(err))
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the solo migration check
 				if err := checkSoloMigrations.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "check_solo_migrations"), log.Err(err))
+				}
+				time.Sleep(taskCooldown)
+
+				// Update the oDAO duty metrics
+				if err := checkOdaoDuties.run(state); err != nil {
+					errorLog.Errorw("Task failed", log.Any("task", "check_odao_duties"), log.Err(err))
 				}
 				/*time.Sleep(taskCooldown)
 
@@ -285,18 +369,17 @@ func run(c *cli.Context) error {
 
 				// Run the rewards tree submission check
 				if err := submitRewardsTree.run(isOnOdao, nil, latestBlock.Slot, isAtlasDeployed); err != nil {
-					errorLog.Println(err)
+					errorLog.Errorw("Task failed", log.Any("task", "submit_rewards_tree"), log.Err(err))
 				}
 			}
 
 			time.Sleep(interval)
 		}
-		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewModuleLogger("metrics", MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, odaoDutiesCollector, submissionCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -337,8 +420,7 @@ func printAtlasMessage(log *log.ColorLogger) {
 * |    DECENTRALISED STAKING PROTOCOL FOR ETHEREUM    |
 * +---------------------------------------------------+
 *
-* ================ Atlas has launched! ================
-`)
+* ================ Atlas has launched! ================`)
 }
 
 // Update the latest network state at each cycle
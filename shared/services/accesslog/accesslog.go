@@ -0,0 +1,225 @@
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Settings
+const (
+	// The size the access log can grow to before it's rotated
+	maxLogSize int64 = 100 * 1024 * 1024
+)
+
+// The context key for the subsystem a request is attributed to
+type subsystemKey struct{}
+
+// The kind of client a request was sent to
+type ClientType string
+
+const (
+	ClientType_Execution ClientType = "ec"
+	ClientType_Archive   ClientType = "archive-ec"
+	ClientType_Beacon    ClientType = "bc"
+)
+
+// An entry in the access log
+type Entry struct {
+	Time       time.Time  `json:"time"`
+	Client     ClientType `json:"client"`
+	Endpoint   string     `json:"endpoint"`
+	Subsystem  string     `json:"subsystem"`
+	Method     string     `json:"method"`
+	DurationMs float64    `json:"durationMs"`
+	Outcome    string     `json:"outcome"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// The requests a subsystem has sent with one method to one endpoint
+type Stats struct {
+	Client    ClientType
+	Endpoint  string
+	Subsystem string
+	Method    string
+	Requests  uint64
+	Errors    uint64
+	Duration  time.Duration
+}
+
+// The identity of a set of stats
+type statsKey struct {
+	client    ClientType
+	endpoint  string
+	subsystem string
+	method    string
+}
+
+var (
+	enabled          atomic.Bool
+	defaultSubsystem string
+	logPath          string
+	logFile          *os.File
+	logSize          int64
+	logLock          sync.Mutex
+	stats            = map[statsKey]*Stats{}
+	statsLock        sync.Mutex
+)
+
+// Start recording the requests this process sends to its clients, appending each one to the log at the given path.
+// The per-subsystem counters are still kept if the path is empty.
+// Requests whose context wasn't tagged with a subsystem are attributed to the given default, such as the daemon's name.
+func Enable(path string, subsystem string) error {
+	logLock.Lock()
+	defer logLock.Unlock()
+	defaultSubsystem = subsystem
+
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("error creating access log directory: %w", err)
+		}
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("error opening access log [%s]: %w", path, err)
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("error checking access log [%s]: %w", path, err)
+		}
+		logPath = path
+		logFile = file
+		logSize = info.Size()
+	}
+
+	enabled.Store(true)
+	return nil
+}
+
+// Check if requests are being recorded
+func IsEnabled() bool {
+	return enabled.Load()
+}
+
+// Tag a context with the subsystem, such as a task or collector, that requests sent with it are attributed to
+func WithSubsystem(ctx context.Context, subsystem string) context.Context {
+	return context.WithValue(ctx, subsystemKey{}, subsystem)
+}
+
+// Record a request sent to a client through one of the client manager's methods.
+// It's attributed to the subsystem the context was tagged with, or to the default one if it wasn't tagged.
+func Record(ctx context.Context, client ClientType, endpoint string, method string, start time.Time, err error) {
+	if !enabled.Load() {
+		return
+	}
+	duration := time.Since(start)
+	subsystem, ok := ctx.Value(subsystemKey{}).(string)
+	if !ok {
+		logLock.Lock()
+		subsystem = defaultSubsystem
+		logLock.Unlock()
+	}
+
+	// Update the counters
+	key := statsKey{
+		client:    client,
+		endpoint:  endpoint,
+		subsystem: subsystem,
+		method:    method,
+	}
+	statsLock.Lock()
+	entryStats, exists := stats[key]
+	if !exists {
+		entryStats = &Stats{
+			Client:    client,
+			Endpoint:  endpoint,
+			Subsystem: subsystem,
+			Method:    method,
+		}
+		stats[key] = entryStats
+	}
+	entryStats.Requests++
+	entryStats.Duration += duration
+	if err != nil {
+		entryStats.Errors++
+	}
+	statsLock.Unlock()
+
+	// Write the log entry
+	entry := Entry{
+		Time:       start,
+		Client:     client,
+		Endpoint:   endpoint,
+		Subsystem:  subsystem,
+		Method:     method,
+		DurationMs: float64(duration.Microseconds()) / 1000,
+		Outcome:    "success",
+	}
+	if err != nil {
+		entry.Outcome = "error"
+		entry.Error = err.Error()
+	}
+	writeEntry(entry)
+}
+
+// Get a snapshot of the per-subsystem counters, sorted by client, endpoint, subsystem, and method
+func GetStats() []Stats {
+	statsLock.Lock()
+	snapshot := make([]Stats, 0, len(stats))
+	for _, entryStats := range stats {
+		snapshot = append(snapshot, *entryStats)
+	}
+	statsLock.Unlock()
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		a, b := snapshot[i], snapshot[j]
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		if a.Subsystem != b.Subsystem {
+			return a.Subsystem < b.Subsystem
+		}
+		return a.Method < b.Method
+	})
+	return snapshot
+}
+
+// Append an entry to the access log, rotating it if it's grown too large
+func writeEntry(entry Entry) {
+	logLock.Lock()
+	defer logLock.Unlock()
+	if logFile == nil {
+		return
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	if logSize+int64(len(line)) > maxLogSize {
+		logFile.Close()
+		_ = os.Rename(logPath, logPath+".1")
+		file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			// Stop logging rather than failing every request
+			logFile = nil
+			return
+		}
+		logFile = file
+		logSize = 0
+	}
+
+	written, _ := logFile.Write(line)
+	logSize += int64(written)
+}
@@ -0,0 +1,140 @@
+package addressbook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The longest label a minipool can have
+const MaxLabelLength int = 64
+
+// Human-friendly labels for the node's minipools, stored as a YAML map of address to label.
+// The file is written by the API and read by the daemon, so it's loaded fresh by each user rather than cached.
+type AddressBook struct {
+	path   string
+	labels map[common.Address]string
+}
+
+// Load the address book from the file at the given path; a missing file is an empty address book
+func Load(path string) (*AddressBook, error) {
+	book := &AddressBook{
+		path:   path,
+		labels: map[common.Address]string{},
+	}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading minipool labels file [%s]: %w", path, err)
+	}
+
+	var entries map[string]string
+	if err := yaml.Unmarshal(bytes, &entries); err != nil {
+		return nil, fmt.Errorf("error deserializing minipool labels file [%s]: %w", path, err)
+	}
+	for addressString, label := range entries {
+		if !common.IsHexAddress(addressString) {
+			return nil, fmt.Errorf("minipool labels file [%s] has an invalid address '%s'", path, addressString)
+		}
+		book.labels[common.HexToAddress(addressString)] = label
+	}
+	return book, nil
+}
+
+// Save the address book to its file
+func (b *AddressBook) Save() error {
+	entries := map[string]string{}
+	for address, label := range b.labels {
+		entries[address.Hex()] = label
+	}
+	bytes, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("error serializing minipool labels: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return fmt.Errorf("error creating minipool labels directory: %w", err)
+	}
+	if err := os.WriteFile(b.path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing minipool labels file [%s]: %w", b.path, err)
+	}
+	return nil
+}
+
+// Get the label of a minipool, or an empty string if it doesn't have one
+func (b *AddressBook) GetLabel(address common.Address) string {
+	return b.labels[address]
+}
+
+// Get the labels of all of the minipools that have one
+func (b *AddressBook) GetLabels() map[common.Address]string {
+	labels := make(map[common.Address]string, len(b.labels))
+	for address, label := range b.labels {
+		labels[address] = label
+	}
+	return labels
+}
+
+// Set the label of a minipool; an empty label removes it.
+// Labels have to be unique so they can be used in place of the address.
+func (b *AddressBook) SetLabel(address common.Address, label string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		delete(b.labels, address)
+		return nil
+	}
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+	for otherAddress, otherLabel := range b.labels {
+		if otherAddress != address && strings.EqualFold(otherLabel, label) {
+			return fmt.Errorf("minipool %s already has the label '%s'", otherAddress.Hex(), otherLabel)
+		}
+	}
+	b.labels[address] = label
+	return nil
+}
+
+// Get the minipool with the given label; the match ignores case
+func (b *AddressBook) FindLabel(label string) (common.Address, bool) {
+	label = strings.TrimSpace(label)
+	for address, existing := range b.labels {
+		if strings.EqualFold(existing, label) {
+			return address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// Format a minipool address for display, including its label if it has one
+func (b *AddressBook) Format(address common.Address) string {
+	label, exists := b.labels[address]
+	if !exists {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", label, address.Hex())
+}
+
+// Check that a label can be used for a minipool
+func ValidateLabel(label string) error {
+	if len(label) > MaxLabelLength {
+		return fmt.Errorf("the label '%s' is longer than %d characters", label, MaxLabelLength)
+	}
+	if common.IsHexAddress(label) {
+		return fmt.Errorf("the label '%s' can't be an address", label)
+	}
+	for _, r := range label {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("the label '%s' has a character that can't be printed", label)
+		}
+	}
+	return nil
+}
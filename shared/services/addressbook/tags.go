@@ -0,0 +1,155 @@
+package addressbook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The longest tag a minipool can have
+const MaxTagLength int = 32
+
+// Free-form tags for the node's minipools, such as "exit" or "testing", stored as a YAML map of address to tag list.
+// Unlike labels, tags don't have to be unique and a minipool can have several of them.
+// Like the address book, the file is written by the API and read by the daemon, so it's loaded fresh by each user.
+type TagBook struct {
+	path string
+	tags map[common.Address][]string
+}
+
+// Load the tag book from the file at the given path; a missing file is an empty tag book
+func LoadTags(path string) (*TagBook, error) {
+	book := &TagBook{
+		path: path,
+		tags: map[common.Address][]string{},
+	}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading minipool tags file [%s]: %w", path, err)
+	}
+
+	var entries map[string][]string
+	if err := yaml.Unmarshal(bytes, &entries); err != nil {
+		return nil, fmt.Errorf("error deserializing minipool tags file [%s]: %w", path, err)
+	}
+	for addressString, tags := range entries {
+		if !common.IsHexAddress(addressString) {
+			return nil, fmt.Errorf("minipool tags file [%s] has an invalid address '%s'", path, addressString)
+		}
+		if len(tags) > 0 {
+			book.tags[common.HexToAddress(addressString)] = tags
+		}
+	}
+	return book, nil
+}
+
+// Save the tag book to its file
+func (b *TagBook) Save() error {
+	entries := map[string][]string{}
+	for address, tags := range b.tags {
+		entries[address.Hex()] = tags
+	}
+	bytes, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("error serializing minipool tags: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return fmt.Errorf("error creating minipool tags directory: %w", err)
+	}
+	if err := os.WriteFile(b.path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing minipool tags file [%s]: %w", b.path, err)
+	}
+	return nil
+}
+
+// Get the tags of a minipool, sorted alphabetically
+func (b *TagBook) GetTags(address common.Address) []string {
+	tags := append([]string{}, b.tags[address]...)
+	sort.Strings(tags)
+	return tags
+}
+
+// Get the tags of all of the minipools that have one
+func (b *TagBook) GetAllTags() map[common.Address][]string {
+	allTags := make(map[common.Address][]string, len(b.tags))
+	for address := range b.tags {
+		allTags[address] = b.GetTags(address)
+	}
+	return allTags
+}
+
+// Check if a minipool has a tag; the match ignores case
+func (b *TagBook) HasTag(address common.Address, tag string) bool {
+	tag = normalizeTag(tag)
+	for _, existing := range b.tags[address] {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Add a tag to a minipool. Tags are stored in lower case so they can be matched regardless of how they're typed.
+// Returns false if the minipool already had the tag.
+func (b *TagBook) AddTag(address common.Address, tag string) (bool, error) {
+	tag = normalizeTag(tag)
+	if err := ValidateTag(tag); err != nil {
+		return false, err
+	}
+	if b.HasTag(address, tag) {
+		return false, nil
+	}
+	b.tags[address] = append(b.tags[address], tag)
+	return true, nil
+}
+
+// Remove a tag from a minipool; returns false if the minipool didn't have it
+func (b *TagBook) RemoveTag(address common.Address, tag string) bool {
+	tag = normalizeTag(tag)
+	tags := b.tags[address]
+	for i, existing := range tags {
+		if existing != tag {
+			continue
+		}
+		tags = append(tags[:i], tags[i+1:]...)
+		if len(tags) == 0 {
+			delete(b.tags, address)
+		} else {
+			b.tags[address] = tags
+		}
+		return true
+	}
+	return false
+}
+
+// Check that a tag can be used for a minipool
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("the tag can't be blank")
+	}
+	if len(tag) > MaxTagLength {
+		return fmt.Errorf("the tag '%s' is longer than %d characters", tag, MaxTagLength)
+	}
+	for _, r := range tag {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			continue
+		}
+		return fmt.Errorf("the tag '%s' can only contain letters, numbers, dashes, underscores, and periods", tag)
+	}
+	return nil
+}
+
+// Get the form a tag is stored in
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The number of times each archive endpoint can be tried for a single request
+	archiveAttemptsPerEndpoint int = 3
+)
+
+var archiveRateLimitBackoff, _ = time.ParseDuration("2s")
+
+// This is a proxy for one or more archive-mode ETH clients, which spreads heavy historical queries across them in round-robin order.
+type ArchiveClientManager struct {
+	endpoints []*archiveEndpoint
+	next      int
+	lock      sync.Mutex
+	logger    log.ColorLogger
+}
+
+// A single archive EC endpoint and its request statistics
+type archiveEndpoint struct {
+	name   string
+	client *ethclient.Client
+	stats  ArchiveEndpointStats
+	lock   sync.Mutex
+}
+
+// The request statistics of an archive EC endpoint
+type ArchiveEndpointStats struct {
+	Url         string
+	Requests    uint64
+	Errors      uint64
+	RateLimits  uint64
+	LastLatency time.Duration
+}
+
+// Creates a new ArchiveClientManager instance for the provided archive EC URLs
+func NewArchiveClientManager(urls []string) (*ArchiveClientManager, error) {
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no archive EC URLs were provided")
+	}
+
+	endpoints := make([]*archiveEndpoint, 0, len(urls))
+	for i, url := range urls {
+		client, err := dialEthClient(url)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to archive EC at [%s]: %w", url, err)
+		}
+		endpoints = append(endpoints, &archiveEndpoint{
+			name:   fmt.Sprintf("endpoint-%d", i+1),
+			client: client,
+			stats: ArchiveEndpointStats{
+				Url: url,
+			},
+		})
+	}
+
+	return &ArchiveClientManager{
+		endpoints: endpoints,
+		logger:    log.NewModuleLogger("archive-ec-manager", color.FgYellow),
+	}, nil
+
+}
+
+// Get the URLs of the archive EC endpoints
+func (p *ArchiveClientManager) GetUrls() []string {
+	urls := make([]string, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		urls = append(urls, endpoint.stats.Url)
+	}
+	return urls
+}
+
+// Get a snapshot of the request statistics of each archive EC endpoint
+func (p *ArchiveClientManager) GetEndpointStats() []ArchiveEndpointStats {
+	stats := make([]ArchiveEndpointStats, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		endpoint.lock.Lock()
+		stats = append(stats, endpoint.stats)
+		endpoint.lock.Unlock()
+	}
+	return stats
+}
+
+/// ========================
+/// ContractCaller Functions
+/// ========================
+
+// CodeAt returns the code of the given account. This is needed to differentiate
+// between contract internal errors and the local chain being out of sync.
+func (p *ArchiveClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	result, err := p.runFunction(ctx, "CodeAt", func(client *ethclient.Client) (interface{}, error) {
+		return client.CodeAt(ctx, contract, blockNumber)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), err
+}
+
+// CallContract executes an Ethereum contract call with the specified data as the
+// input.
+func (p *ArchiveClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	result, err := p.runFunction(ctx, "CallContract", func(client *ethclient.Client) (interface{}, error) {
+		return client.CallContract(ctx, call, blockNumber)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), err
+}
+
+/// ============================
+/// ContractTransactor Functions
+/// ============================
+
+// HeaderByHash returns the block header with the given hash.
+func (p *ArchiveClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	result, err := p.runFunction(ctx, "HeaderByHash", func(client *ethclient.Client) (interface{}, error) {
+		return client.HeaderByHash(ctx, hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Header), err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (p *ArchiveClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	result, err := p.runFunction(ctx, "HeaderByNumber", func(client *ethclient.Client) (interface{}, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Header), err
+}
+
+// PendingCodeAt returns the code of the given account in the pending state.
+func (p *ArchiveClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	result, err := p.runFunction(ctx, "PendingCodeAt", func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingCodeAt(ctx, account)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), err
+}
+
+// PendingNonceAt retrieves the current pending nonce associated with an account.
+func (p *ArchiveClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	result, err := p.runFunction(ctx, "PendingNonceAt", func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (p *ArchiveClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	result, err := p.runFunction(ctx, "SuggestGasPrice", func(client *ethclient.Client) (interface{}, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), err
+}
+
+// SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
+// a timely execution of a transaction.
+func (p *ArchiveClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	result, err := p.runFunction(ctx, "SuggestGasTipCap", func(client *ethclient.Client) (interface{}, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), err
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific
+// transaction based on the current pending state of the backend blockchain.
+// There is no guarantee that this is the true gas limit requirement as other
+// transactions may be added or removed by miners, but it should provide a basis
+// for setting a reasonable default.
+func (p *ArchiveClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	result, err := p.runFunction(ctx, "EstimateGas", func(client *ethclient.Client) (interface{}, error) {
+		return client.EstimateGas(ctx, call)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+// SendTransaction injects the transaction into the pending pool for execution.
+func (p *ArchiveClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := p.runFunction(ctx, "SendTransaction", func(client *ethclient.Client) (interface{}, error) {
+		return nil, client.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+/// ==========================
+/// ContractFilterer Functions
+/// ==========================
+
+// FilterLogs executes a log filter operation, blocking during execution and
+// returning all the results in one batch.
+//
+// TODO(karalabe): Deprecate when the subscription one can return past data too.
+func (p *ArchiveClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	result, err := p.runFunction(ctx, "FilterLogs", func(client *ethclient.Client) (interface{}, error) {
+		return client.FilterLogs(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]types.Log), err
+}
+
+// SubscribeFilterLogs creates a background log filtering operation, returning
+// a subscription immediately, which can be used to stream the found events.
+func (p *ArchiveClientManager) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	result, err := p.runFunction(ctx, "SubscribeFilterLogs", func(client *ethclient.Client) (interface{}, error) {
+		return client.SubscribeFilterLogs(ctx, query, ch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(ethereum.Subscription), err
+}
+
+/// =======================
+/// DeployBackend Functions
+/// =======================
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// Note that the receipt is not available for pending transactions.
+func (p *ArchiveClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	result, err := p.runFunction(ctx, "TransactionReceipt", func(client *ethclient.Client) (interface{}, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Receipt), err
+}
+
+/// ================
+/// Client functions
+/// ================
+
+// BlockNumber returns the most recent block number
+func (p *ArchiveClientManager) BlockNumber(ctx context.Context) (uint64, error) {
+	result, err := p.runFunction(ctx, "BlockNumber", func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockNumber(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (p *ArchiveClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	result, err := p.runFunction(ctx, "BalanceAt", func(client *ethclient.Client) (interface{}, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), err
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (p *ArchiveClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	result, err := p.runFunction(ctx, "TransactionByHash", func(client *ethclient.Client) (interface{}, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, hash)
+		result := []interface{}{tx, isPending}
+		return result, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// TODO: Can we just use the named return values inside the closer to skip this?
+	resultArray := result.([]interface{})
+	tx = resultArray[0].(*types.Transaction)
+	isPending = resultArray[1].(bool)
+	return tx, isPending, err
+}
+
+// NonceAt returns the account nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the latest known block.
+func (p *ArchiveClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	result, err := p.runFunction(ctx, "NonceAt", func(client *ethclient.Client) (interface{}, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm. If there's
+// no sync currently running, it returns nil.
+func (p *ArchiveClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	result, err := p.runFunction(ctx, "SyncProgress", func(client *ethclient.Client) (interface{}, error) {
+		return client.SyncProgress(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.SyncProgress), err
+}
+
+/// ==================
+/// Internal functions
+/// ==================
+
+// Runs a function on the archive endpoints in round-robin order, moving on to the next endpoint if one is rate-limited or disconnected
+func (p *ArchiveClientManager) runFunction(ctx context.Context, method string, function ecFunction) (interface{}, error) {
+
+	var lastErr error
+	attempts := len(p.endpoints) * archiveAttemptsPerEndpoint
+	for attempt := 0; attempt < attempts; attempt++ {
+		endpoint := p.getNextEndpoint()
+
+		// Run the function and record how it went
+		start := time.Now()
+		result, err := function(endpoint.client)
+		latency := time.Since(start)
+		accesslog.Record(ctx, accesslog.ClientType_Archive, endpoint.name, method, start, err)
+		isRateLimited := err != nil && isRateLimitError(err)
+		isDisconnected := err != nil && strings.Contains(err.Error(), "dial tcp")
+
+		endpoint.lock.Lock()
+		endpoint.stats.Requests++
+		endpoint.stats.LastLatency = latency
+		if err != nil {
+			endpoint.stats.Errors++
+		}
+		if isRateLimited {
+			endpoint.stats.RateLimits++
+		}
+		url := endpoint.stats.Url
+		endpoint.lock.Unlock()
+
+		if err == nil {
+			return result, nil
+		}
+
+		// Errors that aren't caused by the endpoint itself are returned as-is
+		if !isRateLimited && !isDisconnected {
+			return nil, err
+		}
+		lastErr = err
+
+		// Back off a little more each time every endpoint has been rate-limited
+		if isRateLimited {
+			p.logger.Warnf("Archive EC [%s] is rate-limiting requests (%s), trying the next endpoint...", url, err.Error())
+			if (attempt+1)%len(p.endpoints) == 0 {
+				time.Sleep(archiveRateLimitBackoff * time.Duration((attempt+1)/len(p.endpoints)))
+			}
+		} else {
+			p.logger.Warnf("Archive EC [%s] disconnected (%s), trying the next endpoint...", url, err.Error())
+		}
+	}
+
+	return nil, fmt.Errorf("all archive ECs failed after %d attempts: %w", attempts, lastErr)
+
+}
+
+// Get the next endpoint to use
+func (p *ArchiveClientManager) getNextEndpoint() *archiveEndpoint {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	endpoint := p.endpoints[p.next]
+	p.next = (p.next + 1) % len(p.endpoints)
+	return endpoint
+}
+
+// Returns true if the error was caused by the endpoint rate-limiting requests
+func isRateLimitError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "429") ||
+		strings.Contains(message, "too many requests") ||
+		strings.Contains(message, "rate limit") || // Most providers
+		strings.Contains(message, "rate exceeded") || // Infura
+		strings.Contains(message, "compute units") // Alchemy
+}
@@ -0,0 +1,96 @@
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// A place backups can be uploaded to and downloaded from
+type Destination interface {
+	// Upload a backup with the given file name
+	Upload(name string, data []byte) error
+
+	// Download the backup with the given file name
+	Download(name string) ([]byte, error)
+
+	// A description of the destination for the user
+	String() string
+}
+
+// Create a destination from its location, which is one of:
+//   - a local directory, as a plain path or a file:// URL
+//   - an S3-compatible bucket, as s3://endpoint/bucket/prefix
+//   - a directory on an SFTP server, as sftp://user@host:port/path
+func NewDestination(location string) (Destination, error) {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return nil, fmt.Errorf("no backup destination was provided")
+	}
+
+	// Plain paths are local directories
+	if !strings.Contains(location, "://") {
+		return newLocalDestination(location)
+	}
+
+	parsedUrl, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing backup destination [%s]: %w", location, err)
+	}
+	switch parsedUrl.Scheme {
+	case "file":
+		return newLocalDestination(parsedUrl.Path)
+	case "s3":
+		return newS3Destination(parsedUrl)
+	case "sftp":
+		return newSftpDestination(parsedUrl)
+	default:
+		return nil, fmt.Errorf("unsupported backup destination type '%s'; use a local path, s3://, or sftp://", parsedUrl.Scheme)
+	}
+}
+
+// A directory on the local filesystem, such as a mounted network or removable drive
+type localDestination struct {
+	dir string
+}
+
+// Create a local destination
+func newLocalDestination(dir string) (*localDestination, error) {
+	expandedDir, err := homedir.Expand(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding backup directory [%s]: %w", dir, err)
+	}
+	return &localDestination{
+		dir: expandedDir,
+	}, nil
+}
+
+// Write the backup to the directory
+func (d *localDestination) Upload(name string, data []byte) error {
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return fmt.Errorf("error creating backup directory [%s]: %w", d.dir, err)
+	}
+	path := filepath.Join(d.dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error writing backup to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Read the backup from the directory
+func (d *localDestination) Download(name string) ([]byte, error) {
+	path := filepath.Join(d.dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup from [%s]: %w", path, err)
+	}
+	return data, nil
+}
+
+func (d *localDestination) String() string {
+	return d.dir
+}
@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Settings
+const (
+	encryptionVersion uint = 1
+
+	// The same scrypt cost geth uses for its standard keystores
+	scryptN      int = 1 << 18
+	scryptR      int = 8
+	scryptP      int = 1
+	scryptKeyLen int = 32
+	saltLength   int = 32
+
+	// The minimum length of a backup passphrase
+	MinPassphraseLength int = 12
+)
+
+// A blob encrypted with AES-256-GCM, using a key derived from a passphrase with scrypt
+type encryptedBlob struct {
+	Version    uint         `json:"version"`
+	Kdf        string       `json:"kdf"`
+	KdfParams  scryptParams `json:"kdfParams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// The scrypt parameters used to derive the key
+type scryptParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"keyLen"`
+	Salt   string `json:"salt"`
+}
+
+// Encrypt data with a passphrase
+func Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("the passphrase must be at least %d characters long", MinPassphraseLength)
+	}
+
+	// Derive the key
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+	params := scryptParams{
+		N:      scryptN,
+		R:      scryptR,
+		P:      scryptP,
+		KeyLen: scryptKeyLen,
+		Salt:   hex.EncodeToString(salt),
+	}
+	gcm, err := getCipher(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the data
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	return json.MarshalIndent(encryptedBlob{
+		Version:    encryptionVersion,
+		Kdf:        "scrypt",
+		KdfParams:  params,
+		Cipher:     "aes-256-gcm",
+		Nonce:      hex.EncodeToString(nonce),
+		Ciphertext: hex.EncodeToString(ciphertext),
+	}, "", "  ")
+}
+
+// Decrypt data that was encrypted with Encrypt
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	var blob encryptedBlob
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return nil, fmt.Errorf("error decoding encrypted backup: %w", err)
+	}
+	if blob.Version != encryptionVersion || blob.Kdf != "scrypt" || blob.Cipher != "aes-256-gcm" {
+		return nil, fmt.Errorf("unsupported backup format (version %d, %s, %s)", blob.Version, blob.Kdf, blob.Cipher)
+	}
+
+	// Only the parameters Encrypt writes are accepted, so a modified file can't make the KDF use huge amounts of memory
+	// or shrink the key to AES-128
+	params := blob.KdfParams
+	if params.N != scryptN || params.R != scryptR || params.P != scryptP || params.KeyLen != scryptKeyLen {
+		return nil, fmt.Errorf("unsupported scrypt parameters (n %d, r %d, p %d, key length %d)", params.N, params.R, params.P, params.KeyLen)
+	}
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding salt: %w", err)
+	}
+	if len(salt) != saltLength {
+		return nil, fmt.Errorf("invalid salt length %d", len(salt))
+	}
+	nonce, err := hex.DecodeString(blob.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(blob.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ciphertext: %w", err)
+	}
+
+	gcm, err := getCipher(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(nonce))
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt the backup; the passphrase is incorrect or the file has been modified")
+	}
+	return plaintext, nil
+}
+
+// Derive the key from the passphrase and create the cipher
+func getCipher(passphrase string, salt []byte, params scryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const (
+	defaultS3Region  string        = "us-east-1"
+	s3RequestTimeout time.Duration = 60 * time.Second
+)
+
+// A bucket on an S3-compatible object store, using path-style requests signed with AWS Signature Version 4.
+// Credentials are read from the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN variables.
+type s3Destination struct {
+	endpoint     string
+	bucket       string
+	prefix       string
+	region       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+}
+
+// Create an S3 destination from a URL of the form s3://endpoint/bucket/prefix?region=region
+func newS3Destination(location *url.URL) (*s3Destination, error) {
+	if location.Host == "" {
+		return nil, fmt.Errorf("the S3 destination must include the endpoint host, such as s3://s3.us-east-1.amazonaws.com/bucket")
+	}
+	parts := strings.SplitN(strings.Trim(location.Path, "/"), "/", 2)
+	if parts[0] == "" {
+		return nil, fmt.Errorf("the S3 destination must include a bucket, such as s3://%s/bucket", location.Host)
+	}
+	prefix := ""
+	if len(parts) > 1 {
+		prefix = parts[1]
+	}
+
+	// Get the region from the URL, the environment, or the default
+	region := location.Query().Get("region")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables must be set to use an S3 destination")
+	}
+
+	client := *net.GetHttpClient(net.HttpTarget_Backup)
+	client.Timeout = s3RequestTimeout
+	return &s3Destination{
+		endpoint:     location.Host,
+		bucket:       parts[0],
+		prefix:       prefix,
+		region:       region,
+		accessKey:    accessKey,
+		secretKey:    secretKey,
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		client:       &client,
+	}, nil
+}
+
+// Upload the backup as an object in the bucket
+func (d *s3Destination) Upload(name string, data []byte) error {
+	_, err := d.request(http.MethodPut, name, data)
+	if err != nil {
+		return fmt.Errorf("error uploading backup to %s: %w", d.String(), err)
+	}
+	return nil
+}
+
+// Download the backup object from the bucket
+func (d *s3Destination) Download(name string) ([]byte, error) {
+	data, err := d.request(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading backup from %s: %w", d.String(), err)
+	}
+	return data, nil
+}
+
+func (d *s3Destination) String() string {
+	return fmt.Sprintf("s3://%s/%s", d.endpoint, path.Join(d.bucket, d.prefix))
+}
+
+// Send a signed request for an object in the bucket
+func (d *s3Destination) request(method string, name string, body []byte) ([]byte, error) {
+	objectUrl := url.URL{
+		Scheme: "https",
+		Host:   d.endpoint,
+		Path:   "/" + path.Join(d.bucket, d.prefix, name),
+	}
+	request, err := http.NewRequest(method, objectUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the request (https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html)
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+	request.Header.Set("x-amz-date", amzDate)
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", request.URL.Host, payloadHash, amzDate)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	if d.sessionToken != "" {
+		request.Header.Set("x-amz-security-token", d.sessionToken)
+		canonicalHeaders += fmt.Sprintf("x-amz-security-token:%s\n", d.sessionToken)
+		signedHeaders += ";x-amz-security-token"
+	}
+	canonicalRequest := strings.Join([]string{
+		method,
+		request.URL.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, d.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+	signingKey := hmacSha256([]byte("AWS4"+d.secretKey), date)
+	signingKey = hmacSha256(signingKey, d.region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", d.accessKey, scope, signedHeaders, signature))
+
+	// Send it
+	response, err := d.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, string(responseBody))
+	}
+	return responseBody, nil
+}
+
+// Get the hex-encoded SHA-256 hash of some data
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// Get the HMAC-SHA256 of a message
+func hmacSha256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
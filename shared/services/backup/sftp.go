@@ -0,0 +1,119 @@
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// A directory on an SFTP server.
+// This runs the system's sftp client in batch mode, so it uses the user's SSH config and keys; password logins aren't supported.
+type sftpDestination struct {
+	target string
+	port   string
+	dir    string
+}
+
+// Create an SFTP destination from a URL of the form sftp://user@host:port/path
+func newSftpDestination(location *url.URL) (*sftpDestination, error) {
+	if location.Hostname() == "" {
+		return nil, fmt.Errorf("the SFTP destination must include a host, such as sftp://user@host/backups")
+	}
+	if _, hasPassword := location.User.Password(); hasPassword {
+		return nil, fmt.Errorf("the SFTP destination can't include a password; set up an SSH key for the server instead")
+	}
+
+	target := location.Hostname()
+	if username := location.User.Username(); username != "" {
+		target = fmt.Sprintf("%s@%s", username, target)
+	}
+	dir := location.Path
+	if dir == "" {
+		dir = "."
+	}
+	return &sftpDestination{
+		target: target,
+		port:   location.Port(),
+		dir:    dir,
+	}, nil
+}
+
+// Upload the backup to the server
+func (d *sftpDestination) Upload(name string, data []byte) error {
+	tempFile, err := createTempBackupFile(data)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile)
+
+	err = d.run(fmt.Sprintf("put %s %s", quoteSftpPath(tempFile), quoteSftpPath(path.Join(d.dir, name))))
+	if err != nil {
+		return fmt.Errorf("error uploading backup to %s: %w", d.String(), err)
+	}
+	return nil
+}
+
+// Download the backup from the server
+func (d *sftpDestination) Download(name string) ([]byte, error) {
+	tempFile, err := createTempBackupFile(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile)
+
+	err = d.run(fmt.Sprintf("get %s %s", quoteSftpPath(path.Join(d.dir, name)), quoteSftpPath(tempFile)))
+	if err != nil {
+		return nil, fmt.Errorf("error downloading backup from %s: %w", d.String(), err)
+	}
+	data, err := os.ReadFile(tempFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading downloaded backup: %w", err)
+	}
+	return data, nil
+}
+
+func (d *sftpDestination) String() string {
+	if d.port != "" {
+		return fmt.Sprintf("sftp://%s:%s%s", d.target, d.port, d.dir)
+	}
+	return fmt.Sprintf("sftp://%s%s", d.target, d.dir)
+}
+
+// Run a command with the sftp client
+func (d *sftpDestination) run(command string) error {
+	args := []string{"-b", "-"}
+	if d.port != "" {
+		args = append(args, "-P", d.port)
+	}
+	args = append(args, d.target)
+
+	cmd := exec.Command("sftp", args...)
+	cmd.Stdin = strings.NewReader(command + "\n")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Create a private temporary file for a backup in transit
+func createTempBackupFile(data []byte) (string, error) {
+	file, err := os.CreateTemp("", "rp-wallet-backup-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("error writing temporary file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// Quote a path for an sftp batch command
+func quoteSftpPath(path string) string {
+	return "\"" + strings.ReplaceAll(path, "\"", "\\\"") + "\""
+}
@@ -0,0 +1,217 @@
+package balancehistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The format of a snapshot's date; snapshots are taken once per UTC day
+const DateFormat string = "2006-01-02"
+
+// The periods, in days, that balance changes are reported over
+var DeltaPeriods = []int{1, 7, 30, 365}
+
+// A daily snapshot of a node's balances, stored as one line of the history file
+type Snapshot struct {
+	Date            string         `json:"date"`
+	Time            time.Time      `json:"time"`
+	Node            common.Address `json:"node"`
+	EthBalance      float64        `json:"ethBalance"`
+	RplBalance      float64        `json:"rplBalance"`
+	RethBalance     float64        `json:"rethBalance"`
+	StakedRpl       float64        `json:"stakedRpl"`
+	BeaconBalance   float64        `json:"beaconBalance"`
+	ActiveMinipools int            `json:"activeMinipools"`
+	CollateralRatio float64        `json:"collateralRatio"`
+}
+
+// The change in a node's balances between its latest snapshot and the one from a number of days before it
+type Delta struct {
+	Days            int     `json:"days"`
+	From            string  `json:"from"`
+	To              string  `json:"to"`
+	EthBalance      float64 `json:"ethBalance"`
+	RplBalance      float64 `json:"rplBalance"`
+	RethBalance     float64 `json:"rethBalance"`
+	StakedRpl       float64 `json:"stakedRpl"`
+	BeaconBalance   float64 `json:"beaconBalance"`
+	CollateralRatio float64 `json:"collateralRatio"`
+}
+
+// A small time-series store of daily balance snapshots, kept as one JSON snapshot per line so it survives
+// being cut off by a crash. It's shared by the daemon and the API, so the file is reopened for each access.
+type Store struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a store backed by the file at the given path
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+	}
+}
+
+// Get the date a snapshot taken at the given time belongs to
+func GetDate(t time.Time) string {
+	return t.UTC().Format(DateFormat)
+}
+
+// Add a snapshot unless the node already has one for the same day.
+// Returns true if the snapshot was added.
+func (s *Store) Record(snapshot Snapshot) (bool, error) {
+	if snapshot.Time.IsZero() {
+		snapshot.Time = time.Now()
+	}
+	snapshot.Date = GetDate(snapshot.Time)
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return false, fmt.Errorf("error serializing balance snapshot: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snapshots, err := s.readAll()
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range snapshots {
+		if existing.Node == snapshot.Node && existing.Date == snapshot.Date {
+			return false, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return false, fmt.Errorf("error creating balance history directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("error opening balance history [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return false, fmt.Errorf("error writing balance history [%s]: %w", s.path, err)
+	}
+	return true, nil
+}
+
+// Check if the node already has a snapshot for the day the given time falls on
+func (s *Store) HasSnapshot(node common.Address, t time.Time) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snapshots, err := s.readAll()
+	if err != nil {
+		return false, err
+	}
+	date := GetDate(t)
+	for _, snapshot := range snapshots {
+		if snapshot.Node == node && snapshot.Date == date {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Read the node's snapshots from the given time onwards, oldest first.
+// A zero time reads all of them.
+func (s *Store) Read(node common.Address, since time.Time) ([]Snapshot, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snapshots, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	nodeSnapshots := []Snapshot{}
+	for _, snapshot := range snapshots {
+		if snapshot.Node != node {
+			continue
+		}
+		if !since.IsZero() && snapshot.Time.Before(since) {
+			continue
+		}
+		nodeSnapshots = append(nodeSnapshots, snapshot)
+	}
+	sort.SliceStable(nodeSnapshots, func(i, j int) bool {
+		return nodeSnapshots[i].Time.Before(nodeSnapshots[j].Time)
+	})
+	return nodeSnapshots, nil
+}
+
+// Read every snapshot in the file, skipping lines that can't be parsed
+func (s *Store) readAll() ([]Snapshot, error) {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening balance history [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+
+	snapshots := []Snapshot{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading balance history [%s]: %w", s.path, err)
+	}
+	return snapshots, nil
+}
+
+// Get the changes between the latest of the provided snapshots and the latest one at least the given number of days
+// older, for each period. The snapshots must be sorted oldest first; periods without an old enough snapshot are skipped.
+func GetDeltas(snapshots []Snapshot, periods []int) []Delta {
+	deltas := []Delta{}
+	if len(snapshots) == 0 {
+		return deltas
+	}
+	latest := snapshots[len(snapshots)-1]
+	latestDate, err := time.Parse(DateFormat, latest.Date)
+	if err != nil {
+		return deltas
+	}
+
+	for _, days := range periods {
+		cutoff := GetDate(latestDate.AddDate(0, 0, -days))
+		var baseline *Snapshot
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			if snapshots[i].Date <= cutoff {
+				baseline = &snapshots[i]
+				break
+			}
+		}
+		if baseline == nil {
+			continue
+		}
+		deltas = append(deltas, Delta{
+			Days:            days,
+			From:            baseline.Date,
+			To:              latest.Date,
+			EthBalance:      latest.EthBalance - baseline.EthBalance,
+			RplBalance:      latest.RplBalance - baseline.RplBalance,
+			RethBalance:     latest.RethBalance - baseline.RethBalance,
+			StakedRpl:       latest.StakedRpl - baseline.StakedRpl,
+			BeaconBalance:   latest.BeaconBalance - baseline.BeaconBalance,
+			CollateralRatio: latest.CollateralRatio - baseline.CollateralRatio,
+		})
+	}
+	return deltas
+}
@@ -1,28 +1,78 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// The default amount of time to wait after the primary BC fails before switching back to it
+const defaultBcReconnectDelay time.Duration = 60 * time.Second
+
+// How often to measure the response time of each BC when latency-based routing is enabled
+const bcLatencyProbeInterval time.Duration = 15 * time.Second
+
+// The weight given to each new latency sample in the moving average, so one slow response doesn't flip the routing
+const bcLatencySmoothing float64 = 0.3
+
+// The classes of Beacon requests that can be routed independently
+type bcRequestClass int
+
+const (
+	// Requests that always prefer the primary client
+	bcRequestClass_Default bcRequestClass = iota
+
+	// Time-critical requests such as duty lookups that should go to the most responsive client
+	bcRequestClass_LatencySensitive
+
+	// Large queries such as the state downloads used for rewards tree generation
+	bcRequestClass_Heavy
+)
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
 type BeaconClientManager struct {
+	primaryBcUrl    string
+	fallbackBcUrl   string
 	primaryBc       beacon.Client
 	fallbackBc      beacon.Client
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// Guards the fallback client, which the config reloader can replace while requests are running,
+	// and the primary's failover state, which the metrics collectors read
+	lock sync.RWMutex
+
+	// Failover tracking
+	reconnectDelay  time.Duration
+	primaryFailTime time.Time
+	failoverCount   uint64
+
+	// Latency-based routing
+	routingModes    map[bcRequestClass]cfgtypes.BcRoutingMode
+	primaryLatency  time.Duration
+	fallbackLatency time.Duration
+	latencyLock     sync.Mutex
+	isProbing       bool
+
+	// Validator indices that have already been resolved, persisted across restarts
+	indexCache *beacon.ValidatorIndexCache
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -44,7 +94,11 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		primaryProvider = cfg.Native.CcHttpUrl.Value.(string)
 		selectedCC = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
-		primaryProvider = fmt.Sprintf("http://%s:%d", BnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
+		if cfg.ConsensusCommon.EnableBnProxy.Value == true {
+			primaryProvider = cfg.GetBnProxyUrl()
+		} else {
+			primaryProvider = fmt.Sprintf("http://%s:%d", BnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
+		}
 		selectedCC = cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
 		selectedConsensusConfig, err := cfg.GetSelectedConsensusClientConfig()
@@ -58,19 +112,7 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 	}
 
 	// Fallback CC
-	var fallbackProvider string
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-		} else {
-			switch selectedCC {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
-			default:
-				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-			}
-		}
-	}
+	fallbackProvider := getFallbackBcUrl(cfg, selectedCC)
 
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
@@ -79,23 +121,153 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
 	}
 
-	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
-	}, nil
+	// Get the delay before switching back to the primary after a failover
+	logger := log.NewModuleLogger("bc-manager", color.FgHiBlue)
+	reconnectDelay := defaultBcReconnectDelay
+	reconnectDelayString, ok := cfg.ReconnectDelay.Value.(string)
+	if ok && reconnectDelayString != "" {
+		delay, err := time.ParseDuration(reconnectDelayString)
+		if err != nil {
+			logger.Warnf("Couldn't parse reconnect delay [%s] (%s), using the default of %s", reconnectDelayString, err.Error(), defaultBcReconnectDelay)
+		} else {
+			reconnectDelay = delay
+		}
+	}
+
+	// Get the routing for each request class
+	routingModes := map[bcRequestClass]cfgtypes.BcRoutingMode{}
+	if mode, ok := cfg.LatencySensitiveBcRouting.Value.(cfgtypes.BcRoutingMode); ok {
+		routingModes[bcRequestClass_LatencySensitive] = mode
+	}
+	if mode, ok := cfg.HeavyBcRouting.Value.(cfgtypes.BcRoutingMode); ok {
+		routingModes[bcRequestClass_Heavy] = mode
+	}
+
+	manager := &BeaconClientManager{
+		primaryBcUrl:   primaryProvider,
+		fallbackBcUrl:  fallbackProvider,
+		primaryBc:      primaryBc,
+		fallbackBc:     fallbackBc,
+		logger:         logger,
+		primaryReady:   true,
+		fallbackReady:  fallbackBc != nil,
+		reconnectDelay: reconnectDelay,
+		routingModes:   routingModes,
+		indexCache:     beacon.NewValidatorIndexCache(cfg.Smartnode.GetValidatorIndexCachePath(), fmt.Sprint(cfg.Smartnode.Network.Value)),
+	}
+
+	// Keep measuring both clients in the background if any request class is routed by latency
+	if fallbackBc != nil && manager.isLatencyRoutingEnabled() {
+		manager.isProbing = true
+		go manager.probeLatencies()
+	}
+
+	return manager, nil
 
 }
 
+// Get the URL of the fallback BC from the config, or an empty string if there isn't one
+func getFallbackBcUrl(cfg *config.RocketPoolConfig, selectedCC cfgtypes.ConsensusClient) string {
+	if cfg.UseFallbackClients.Value == false {
+		return ""
+	}
+	if cfg.IsNativeMode {
+		return cfg.FallbackNormal.CcHttpUrl.Value.(string)
+	}
+	switch selectedCC {
+	case cfgtypes.ConsensusClient_Prysm:
+		return cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+	default:
+		return cfg.FallbackNormal.CcHttpUrl.Value.(string)
+	}
+}
+
+// Switch to a new fallback BC if its URL has changed in the config.
+// Returns true if the fallback was changed.
+func (m *BeaconClientManager) UpdateFallbackClient(cfg *config.RocketPoolConfig) (bool, error) {
+	selectedCC, _ := cfg.GetSelectedConsensusClient()
+	fallbackBcUrl := getFallbackBcUrl(cfg, selectedCC)
+	m.lock.RLock()
+	currentUrl := m.fallbackBcUrl
+	m.lock.RUnlock()
+	if fallbackBcUrl == currentUrl {
+		return false, nil
+	}
+
+	var fallbackBc beacon.Client
+	if fallbackBcUrl != "" {
+		fallbackBc = client.NewStandardHttpClient(fallbackBcUrl)
+	}
+
+	// Forget the old client's latency so the routing doesn't favor the new one based on stale samples
+	m.latencyLock.Lock()
+	m.fallbackLatency = 0
+	m.latencyLock.Unlock()
+
+	m.lock.Lock()
+	oldFallbackBc := m.fallbackBc
+	m.fallbackBcUrl = fallbackBcUrl
+	m.fallbackBc = fallbackBc
+	m.fallbackReady = fallbackBc != nil
+	startProbing := fallbackBc != nil && !m.isProbing && m.isLatencyRoutingEnabled()
+	if startProbing {
+		m.isProbing = true
+	}
+	m.lock.Unlock()
+
+	if oldFallbackBc != nil {
+		oldFallbackBc.Close()
+	}
+	if startProbing {
+		go m.probeLatencies()
+	}
+	return true, nil
+}
+
+// Get the fallback client, or nil if there isn't one
+func (m *BeaconClientManager) getFallbackClient() beacon.Client {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.fallbackBc
+}
+
+// Returns true if requests can be sent to the primary client
+func (m *BeaconClientManager) isPrimaryReady() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.primaryReady
+}
+
+// Get the fallback client, or nil if there isn't one, and whether requests can be sent to it
+func (m *BeaconClientManager) getFallbackState() (beacon.Client, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.fallbackBc, m.fallbackReady
+}
+
+// Returns true if requests can be sent to the fallback client
+func (m *BeaconClientManager) isFallbackReady() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.fallbackReady
+}
+
+// Flag a fallback client as ready or unavailable, unless it has been replaced since it was checked
+func (m *BeaconClientManager) setFallbackReady(fallbackBc beacon.Client, ready bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.fallbackBc == fallbackBc {
+		m.fallbackReady = ready
+	}
+}
+
 /// ======================
 /// BeaconClient Functions
 /// ======================
 
 // Get the client's process mode
 func (m *BeaconClientManager) GetClientType() (beacon.BeaconClientType, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetClientType", func(client beacon.Client) (interface{}, error) {
 		return client.GetClientType()
 	})
 	if err != nil {
@@ -106,7 +278,7 @@ func (m *BeaconClientManager) GetClientType() (beacon.BeaconClientType, error) {
 
 // Get the client's sync status
 func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetSyncStatus", func(client beacon.Client) (interface{}, error) {
 		return client.GetSyncStatus()
 	})
 	if err != nil {
@@ -115,9 +287,20 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the number of peers the client is connected to
+func (m *BeaconClientManager) GetPeerCount() (uint64, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetPeerCount", func(client beacon.Client) (interface{}, error) {
+		return client.GetPeerCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetEth2Config", func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2Config()
 	})
 	if err != nil {
@@ -128,7 +311,7 @@ func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetEth2DepositContract", func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2DepositContract()
 	})
 	if err != nil {
@@ -139,7 +322,7 @@ func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContra
 
 // Get the attestations in a Beacon chain block
 func (m *BeaconClientManager) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(bcRequestClass_Heavy, "GetAttestations", func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetAttestations(blockId)
 	})
 	if err != nil {
@@ -150,7 +333,7 @@ func (m *BeaconClientManager) GetAttestations(blockId string) ([]beacon.Attestat
 
 // Get a Beacon chain block
 func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(bcRequestClass_Heavy, "GetBeaconBlock", func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetBeaconBlock(blockId)
 	})
 	if err != nil {
@@ -159,9 +342,20 @@ func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock
 	return result1.(beacon.BeaconBlock), result2.(bool), nil
 }
 
+// Get the root of a Beacon state
+func (m *BeaconClientManager) GetBeaconStateRoot(stateId string) (common.Hash, bool, error) {
+	result1, result2, err := m.runFunction2(bcRequestClass_Default, "GetBeaconStateRoot", func(client beacon.Client) (interface{}, interface{}, error) {
+		return client.GetBeaconStateRoot(stateId)
+	})
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	return result1.(common.Hash), result2.(bool), nil
+}
+
 // Get the Beacon chain's head information
 func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_LatencySensitive, "GetBeaconHead", func(client beacon.Client) (interface{}, error) {
 		return client.GetBeaconHead()
 	})
 	if err != nil {
@@ -172,51 +366,131 @@ func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 
 // Get a validator's status by its index
 func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetValidatorStatusByIndex", func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatusByIndex(index, opts)
 	})
 	if err != nil {
 		return beacon.ValidatorStatus{}, err
 	}
-	return result.(beacon.ValidatorStatus), nil
+	status := result.(beacon.ValidatorStatus)
+	m.cacheValidatorIndex(status)
+	return status, nil
 }
 
 // Get a validator's status by its pubkey
 func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Default, "GetValidatorStatus", func(client beacon.Client) (interface{}, error) {
+		if index, exists := m.indexCache.Get(pubkey); exists {
+			status, err := client.GetValidatorStatusByIndex(strconv.FormatUint(index, 10), opts)
+			if err != nil || (status.Exists && status.Pubkey == pubkey) {
+				return status, err
+			}
+		}
 		return client.GetValidatorStatus(pubkey, opts)
 	})
 	if err != nil {
 		return beacon.ValidatorStatus{}, err
 	}
-	return result.(beacon.ValidatorStatus), nil
+	status := result.(beacon.ValidatorStatus)
+	m.cacheValidatorIndex(status)
+	return status, nil
 }
 
-// Get the statuses of multiple validators by their pubkeys
+// Get the statuses of multiple validators by their pubkeys.
+// Validators with a cached index are looked up by it, so the Beacon node doesn't have to resolve their pubkeys again.
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
-		return client.GetValidatorStatuses(pubkeys, opts)
+	// Split the validators by whether or not their indices are already known
+	indices := []uint64{}
+	indexedPubkeys := map[types.ValidatorPubkey]bool{}
+	unindexedPubkeys := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if index, exists := m.indexCache.Get(pubkey); exists {
+			if !indexedPubkeys[pubkey] {
+				indices = append(indices, index)
+				indexedPubkeys[pubkey] = true
+			}
+		} else {
+			unindexedPubkeys = append(unindexedPubkeys, pubkey)
+		}
+	}
+
+	statuses := map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+	if len(indices) > 0 {
+		result, err := m.runFunction1(bcRequestClass_Heavy, "GetValidatorStatuses", func(client beacon.Client) (interface{}, error) {
+			return client.GetValidatorStatusesByIndices(indices, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for pubkey, status := range result.(map[types.ValidatorPubkey]beacon.ValidatorStatus) {
+			if indexedPubkeys[pubkey] {
+				statuses[pubkey] = status
+			}
+		}
+
+		// Look up any validator that didn't come back under its cached index by its pubkey instead
+		for pubkey := range indexedPubkeys {
+			if _, exists := statuses[pubkey]; !exists {
+				unindexedPubkeys = append(unindexedPubkeys, pubkey)
+			}
+		}
+	}
+
+	if len(unindexedPubkeys) > 0 {
+		result, err := m.runFunction1(bcRequestClass_Heavy, "GetValidatorStatuses", func(client beacon.Client) (interface{}, error) {
+			return client.GetValidatorStatuses(unindexedPubkeys, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for pubkey, status := range result.(map[types.ValidatorPubkey]beacon.ValidatorStatus) {
+			statuses[pubkey] = status
+		}
+	}
+
+	// Put an empty status in for null pubkeys, matching the clients
+	statuses[types.ValidatorPubkey{}] = beacon.ValidatorStatus{}
+
+	m.indexCache.AddStatuses(statuses)
+	m.saveIndexCache()
+	return statuses, nil
+}
+
+// Get the statuses of multiple validators by their indices
+func (m *BeaconClientManager) GetValidatorStatusesByIndices(indices []uint64, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(bcRequestClass_Heavy, "GetValidatorStatusesByIndices", func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatusesByIndices(indices, opts)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
+	statuses := result.(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	m.indexCache.AddStatuses(statuses)
+	m.saveIndexCache()
+	return statuses, nil
 }
 
-// Get a validator's index
+// Get a validator's index, using the cache if it's already been resolved
 func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	if index, exists := m.indexCache.Get(pubkey); exists {
+		return index, nil
+	}
+
+	result, err := m.runFunction1(bcRequestClass_Default, "GetValidatorIndex", func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorIndex(pubkey)
 	})
 	if err != nil {
 		return 0, err
 	}
-	return result.(uint64), nil
+	index := result.(uint64)
+	m.indexCache.Add(pubkey, index)
+	m.saveIndexCache()
+	return index, nil
 }
 
 // Get a validator's sync duties
 func (m *BeaconClientManager) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_LatencySensitive, "GetValidatorSyncDuties", func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorSyncDuties(indices, epoch)
 	})
 	if err != nil {
@@ -227,7 +501,7 @@ func (m *BeaconClientManager) GetValidatorSyncDuties(indices []uint64, epoch uin
 
 // Get a validator's proposer duties
 func (m *BeaconClientManager) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_LatencySensitive, "GetValidatorProposerDuties", func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorProposerDuties(indices, epoch)
 	})
 	if err != nil {
@@ -236,9 +510,20 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []uint64, epoch
 	return result.(map[uint64]uint64), nil
 }
 
+// Get whether validators were seen attesting or proposing at the given epoch
+func (m *BeaconClientManager) GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	result, err := m.runFunction1(bcRequestClass_LatencySensitive, "GetValidatorLiveness", func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorLiveness(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]bool), nil
+}
+
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_LatencySensitive, "GetDomainData", func(client beacon.Client) (interface{}, error) {
 		return client.GetDomainData(domainType, epoch, useGenesisFork)
 	})
 	if err != nil {
@@ -249,7 +534,7 @@ func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, use
 
 // Voluntarily exit a validator
 func (m *BeaconClientManager) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(bcRequestClass_Default, "ExitValidator", func(client beacon.Client) error {
 		return client.ExitValidator(validatorIndex, epoch, signature)
 	})
 	return err
@@ -257,7 +542,7 @@ func (m *BeaconClientManager) ExitValidator(validatorIndex, epoch uint64, signat
 
 // Close the connection to the Beacon client
 func (m *BeaconClientManager) Close() error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(bcRequestClass_Default, "Close", func(client beacon.Client) error {
 		return client.Close()
 	})
 	return err
@@ -265,7 +550,7 @@ func (m *BeaconClientManager) Close() error {
 
 // Get the EL data for a CL block
 func (m *BeaconClientManager) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(bcRequestClass_Heavy, "GetEth1DataForEth2Block", func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetEth1DataForEth2Block(blockId)
 	})
 	if err != nil {
@@ -276,7 +561,7 @@ func (m *BeaconClientManager) GetEth1DataForEth2Block(blockId string) (beacon.Et
 
 // Get the attestation committees for an epoch
 func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(bcRequestClass_Heavy, "GetCommitteesForEpoch", func(client beacon.Client) (interface{}, error) {
 		return client.GetCommitteesForEpoch(epoch)
 	})
 	if err != nil {
@@ -287,7 +572,7 @@ func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Com
 
 // Change the withdrawal credentials for a validator
 func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(bcRequestClass_Default, "ChangeWithdrawalCredentials", func(client beacon.Client) error {
 		return client.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
 	})
 	if err != nil {
@@ -296,23 +581,201 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex uint64,
 	return nil
 }
 
+// Subscribe to new blocks, calling the handler for each one until the context is cancelled or the stream ends
+func (m *BeaconClientManager) SubscribeToBlocks(ctx context.Context, handler func(beacon.BlockEvent)) error {
+	err := m.runFunction0(bcRequestClass_LatencySensitive, "SubscribeToBlocks", func(client beacon.Client) error {
+		return client.SubscribeToBlocks(ctx, handler)
+	})
+	return err
+}
+
+/// ===================
+/// Failover Functions
+/// ===================
+
+// Returns true if the manager is currently routing requests to the fallback client
+func (m *BeaconClientManager) IsUsingFallback() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return !m.primaryReady && m.fallbackReady
+}
+
+// Get the URL of the client that requests are currently routed to, or an empty string if no client is ready
+func (m *BeaconClientManager) GetActiveProvider() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if m.primaryReady {
+		return m.primaryBcUrl
+	}
+	if m.fallbackReady {
+		return m.fallbackBcUrl
+	}
+	return ""
+}
+
+// Get the number of times the manager has failed over from the primary client to the fallback client
+func (m *BeaconClientManager) GetFailoverCount() uint64 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.failoverCount
+}
+
+// Get the smoothed response times of the primary and fallback clients; a value of 0 means the client hasn't been measured yet
+func (m *BeaconClientManager) GetLatencies() (time.Duration, time.Duration) {
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+	return m.primaryLatency, m.fallbackLatency
+}
+
+// Flag the primary client as unavailable and record the failover.
+// The fail time is refreshed on every failure so the reconnect delay counts from the last one, not the first.
+func (m *BeaconClientManager) setPrimaryFailed(reason string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.primaryFailTime = time.Now()
+	if !m.primaryReady {
+		return
+	}
+	m.primaryReady = false
+	if m.fallbackReady {
+		m.failoverCount++
+		m.logger.Warnf("Primary Beacon client failed (%s), switching to fallback at %s. Will not try the primary again for at least %s.", reason, m.fallbackBcUrl, m.reconnectDelay)
+	}
+}
+
+// Flag the primary client as ready again once it's been healthy for the reconnect delay since its last failure,
+// so a flapping client doesn't cause constant switching
+func (m *BeaconClientManager) setPrimaryRecovered() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.primaryReady {
+		return
+	}
+	if m.fallbackReady && time.Since(m.primaryFailTime) < m.reconnectDelay {
+		return
+	}
+	if m.fallbackReady {
+		m.logger.Printlnf("Primary Beacon client at %s has recovered, switching back from fallback.", m.primaryBcUrl)
+	}
+	m.primaryReady = true
+}
+
+/// ===================
+/// Routing Functions
+/// ===================
+
+// Returns true if any request class is configured to be routed by latency
+func (m *BeaconClientManager) isLatencyRoutingEnabled() bool {
+	for _, mode := range m.routingModes {
+		if mode == cfgtypes.BcRoutingMode_Fastest || mode == cfgtypes.BcRoutingMode_Slowest {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the client a request of the given class should be sent to, or nil if it should follow the normal primary-then-fallback order.
+// The returned bool is true if the client is the primary.
+func (m *BeaconClientManager) getRoutedClient(class bcRequestClass) (beacon.Client, bool) {
+	// Routing only applies while both clients are healthy
+	if !m.isPrimaryReady() || !m.isFallbackReady() {
+		return nil, false
+	}
+
+	mode := m.routingModes[class]
+	if mode != cfgtypes.BcRoutingMode_Fastest && mode != cfgtypes.BcRoutingMode_Slowest {
+		return nil, false
+	}
+
+	// Wait until both clients have been measured
+	primaryLatency, fallbackLatency := m.GetLatencies()
+	if primaryLatency == 0 || fallbackLatency == 0 {
+		return nil, false
+	}
+
+	// Ties go to the primary for the fastest mode and to the fallback for the slowest mode
+	primaryIsFaster := primaryLatency <= fallbackLatency
+	if (mode == cfgtypes.BcRoutingMode_Fastest) == primaryIsFaster {
+		return m.primaryBc, true
+	}
+	return m.getFallbackClient(), false
+}
+
+// Flag the client a routed request was sent to as unavailable after it disconnected
+func (m *BeaconClientManager) setRoutedClientFailed(client beacon.Client, isPrimary bool, err error) {
+	if isPrimary {
+		m.setPrimaryFailed(fmt.Sprintf("disconnected: %s", err.Error()))
+		return
+	}
+	m.logger.Warnf("Fallback Beacon client disconnected (%s)", err.Error())
+	m.setFallbackReady(client, false)
+}
+
+// Periodically measure the response time of each client
+func (m *BeaconClientManager) probeLatencies() {
+	for {
+		m.recordLatency(true, measureBcLatency(m.primaryBc))
+		if fallbackBc := m.getFallbackClient(); fallbackBc != nil {
+			m.recordLatency(false, measureBcLatency(fallbackBc))
+		}
+		time.Sleep(bcLatencyProbeInterval)
+	}
+}
+
+// Fold a new latency sample into a client's moving average; a sample of 0 means the probe failed and is ignored
+func (m *BeaconClientManager) recordLatency(isPrimary bool, sample time.Duration) {
+	if sample == 0 {
+		return
+	}
+
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	latency := &m.fallbackLatency
+	if isPrimary {
+		latency = &m.primaryLatency
+	}
+	if *latency == 0 {
+		*latency = sample
+		return
+	}
+	*latency = time.Duration(bcLatencySmoothing*float64(sample) + (1-bcLatencySmoothing)*float64(*latency))
+}
+
+// Time a lightweight request against a client, returning 0 if it failed
+func measureBcLatency(client beacon.Client) time.Duration {
+	start := time.Now()
+	_, err := client.GetSyncStatus()
+	if err != nil {
+		return 0
+	}
+	elapsed := time.Since(start)
+	if elapsed == 0 {
+		// Keep the sample distinguishable from a failed probe
+		elapsed = time.Nanosecond
+	}
+	return elapsed
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
 
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
+	fallbackBc, fallbackReady := m.getFallbackState()
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
+		FallbackEnabled: fallbackBc != nil,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
+		primaryReady := m.isPrimaryReady()
+		status.PrimaryClientStatus.IsWorking = primaryReady
+		status.PrimaryClientStatus.IsSynced = primaryReady
 		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
+			status.FallbackClientStatus.IsWorking = fallbackReady
+			status.FallbackClientStatus.IsSynced = fallbackReady
 		}
 		return status
 	}
@@ -322,12 +785,21 @@ func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	// Get the fallback BC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+		status.FallbackClientStatus = checkBcStatus(fallbackBc)
 	}
 
 	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+	primaryHealthy := (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	m.setFallbackReady(fallbackBc, status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+	if !primaryHealthy {
+		reason := status.PrimaryClientStatus.Error
+		if reason == "" {
+			reason = fmt.Sprintf("still syncing, %.2f%%", status.PrimaryClientStatus.SyncProgress*100)
+		}
+		m.setPrimaryFailed(reason)
+	} else {
+		m.setPrimaryRecovered()
+	}
 
 	return status
 
@@ -361,19 +833,53 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 
 }
 
+// Run a function on a client, recording it in the access log
+func callBcFunction0(method string, function bcFunction0, client beacon.Client, isPrimary bool) error {
+	start := time.Now()
+	err := function(client)
+	accesslog.Record(context.Background(), accesslog.ClientType_Beacon, getEndpointName(isPrimary), method, start, err)
+	return err
+}
+
+// Run a function on a client, recording it in the access log
+func callBcFunction1(method string, function bcFunction1, client beacon.Client, isPrimary bool) (interface{}, error) {
+	start := time.Now()
+	result, err := function(client)
+	accesslog.Record(context.Background(), accesslog.ClientType_Beacon, getEndpointName(isPrimary), method, start, err)
+	return result, err
+}
+
+// Run a function on a client, recording it in the access log
+func callBcFunction2(method string, function bcFunction2, client beacon.Client, isPrimary bool) (interface{}, interface{}, error) {
+	start := time.Now()
+	result1, result2, err := function(client)
+	accesslog.Record(context.Background(), accesslog.ClientType_Beacon, getEndpointName(isPrimary), method, start, err)
+	return result1, result2, err
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
+func (m *BeaconClientManager) runFunction0(class bcRequestClass, method string, function bcFunction0) error {
+
+	// Send the request to the preferred client for its class if both are healthy
+	if client, isPrimary := m.getRoutedClient(class); client != nil {
+		faults.DelayBcResponse(isPrimary)
+		err := callBcFunction0(method, function, client, isPrimary)
+		if err == nil || !m.isDisconnected(err) {
+			return err
+		}
+		m.setRoutedClientFailed(client, isPrimary, err)
+	}
 
 	// Check if we can use the primary
-	if m.primaryReady {
+	if m.isPrimaryReady() {
 		// Try to run the function on the primary
-		err := function(m.primaryBc)
+		faults.DelayBcResponse(true)
+		err := callBcFunction0(method, function, m.primaryBc, true)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
+				m.setPrimaryFailed(fmt.Sprintf("disconnected: %s", err.Error()))
+				return m.runFunction0(class, method, function)
 			}
 			// If it's a different error, just return it
 			return err
@@ -382,14 +888,16 @@ func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 		return nil
 	}
 
-	if m.fallbackReady {
+	fallbackBc, fallbackReady := m.getFallbackState()
+	if fallbackReady && fallbackBc != nil {
 		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
+		faults.DelayBcResponse(false)
+		err := callBcFunction0(method, function, fallbackBc, false)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
+				m.logger.Warnf("Fallback Beacon client disconnected (%s)", err.Error())
+				m.setFallbackReady(fallbackBc, false)
 				return fmt.Errorf("all Beacon clients failed")
 			}
 
@@ -404,18 +912,28 @@ func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
+func (m *BeaconClientManager) runFunction1(class bcRequestClass, method string, function bcFunction1) (interface{}, error) {
+
+	// Send the request to the preferred client for its class if both are healthy
+	if client, isPrimary := m.getRoutedClient(class); client != nil {
+		faults.DelayBcResponse(isPrimary)
+		result, err := callBcFunction1(method, function, client, isPrimary)
+		if err == nil || !m.isDisconnected(err) {
+			return result, err
+		}
+		m.setRoutedClientFailed(client, isPrimary, err)
+	}
 
 	// Check if we can use the primary
-	if m.primaryReady {
+	if m.isPrimaryReady() {
 		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
+		faults.DelayBcResponse(true)
+		result, err := callBcFunction1(method, function, m.primaryBc, true)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
+				m.setPrimaryFailed(fmt.Sprintf("disconnected: %s", err.Error()))
+				return m.runFunction1(class, method, function)
 			}
 			// If it's a different error, just return it
 			return nil, err
@@ -424,14 +942,16 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		return result, nil
 	}
 
-	if m.fallbackReady {
+	fallbackBc, fallbackReady := m.getFallbackState()
+	if fallbackReady && fallbackBc != nil {
 		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
+		faults.DelayBcResponse(false)
+		result, err := callBcFunction1(method, function, fallbackBc, false)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
+				m.logger.Warnf("Fallback Beacon client disconnected (%s)", err.Error())
+				m.setFallbackReady(fallbackBc, false)
 				return nil, fmt.Errorf("all Beacon clients failed")
 			}
 			// If it's a different error, just return it
@@ -446,18 +966,28 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
+func (m *BeaconClientManager) runFunction2(class bcRequestClass, method string, function bcFunction2) (interface{}, interface{}, error) {
+
+	// Send the request to the preferred client for its class if both are healthy
+	if client, isPrimary := m.getRoutedClient(class); client != nil {
+		faults.DelayBcResponse(isPrimary)
+		result1, result2, err := callBcFunction2(method, function, client, isPrimary)
+		if err == nil || !m.isDisconnected(err) {
+			return result1, result2, err
+		}
+		m.setRoutedClientFailed(client, isPrimary, err)
+	}
 
 	// Check if we can use the primary
-	if m.primaryReady {
+	if m.isPrimaryReady() {
 		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
+		faults.DelayBcResponse(true)
+		result1, result2, err := callBcFunction2(method, function, m.primaryBc, true)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
+				m.setPrimaryFailed(fmt.Sprintf("disconnected: %s", err.Error()))
+				return m.runFunction2(class, method, function)
 			}
 			// If it's a different error, just return it
 			return nil, nil, err
@@ -466,14 +996,16 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 		return result1, result2, nil
 	}
 
-	if m.fallbackReady {
+	fallbackBc, fallbackReady := m.getFallbackState()
+	if fallbackReady && fallbackBc != nil {
 		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
+		faults.DelayBcResponse(false)
+		result1, result2, err := callBcFunction2(method, function, fallbackBc, false)
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
+				m.logger.Warnf("Fallback Beacon client disconnected (%s)", err.Error())
+				m.setFallbackReady(fallbackBc, false)
 				return nil, nil, fmt.Errorf("all Beacon clients failed")
 			}
 			// If it's a different error, just return it
@@ -491,3 +1023,20 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 func (m *BeaconClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
 }
+
+// Add a validator's index to the cache if it exists on the Beacon chain
+func (m *BeaconClientManager) cacheValidatorIndex(status beacon.ValidatorStatus) {
+	if !status.Exists {
+		return
+	}
+	m.indexCache.Add(status.Pubkey, status.Index)
+	m.saveIndexCache()
+}
+
+// Persist any newly resolved validator indices.
+// The cache is only an optimization, so failing to save it isn't an error for the caller.
+func (m *BeaconClientManager) saveIndexCache() {
+	if err := m.indexCache.Save(); err != nil {
+		m.logger.Warnf("Couldn't save the validator index cache: %s", err.Error())
+	}
+}
@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -10,12 +12,17 @@ import (
 type ValidatorStatusOptions struct {
 	Epoch *uint64
 	Slot  *uint64
+
+	// A named state such as "finalized", which takes precedence over the epoch and slot
+	StateId string
 }
 
 // API response types
 type SyncStatus struct {
-	Syncing  bool
-	Progress float64
+	Syncing      bool
+	Progress     float64
+	HeadSlot     uint64
+	SyncDistance uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
@@ -63,6 +70,11 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	Graffiti             string
+}
+type BlockEvent struct {
+	Slot      uint64
+	BlockRoot common.Hash
 }
 
 type Committee struct {
@@ -113,21 +125,26 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetPeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
 	GetBeaconBlock(blockId string) (BeaconBlock, bool, error)
+	GetBeaconStateRoot(stateId string) (common.Hash, bool, error)
 	GetBeaconHead() (BeaconHead, error)
 	GetValidatorStatusByIndex(index string, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
+	GetValidatorStatusesByIndices(indices []uint64, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error)
 	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
+	GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) ([]Committee, error)
 	ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	SubscribeToBlocks(ctx context.Context, handler func(BlockEvent)) error
 }
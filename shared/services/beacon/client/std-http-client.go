@@ -1,7 +1,9 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -20,6 +22,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 // Config
@@ -28,19 +31,23 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestPeerCountPath                   = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
 	RequestCommitteePath                   = "/eth/v1/beacon/states/%s/committees"
 	RequestFinalityCheckpointsPath         = "/eth/v1/beacon/states/%s/finality_checkpoints"
 	RequestForkPath                        = "/eth/v1/beacon/states/%s/fork"
+	RequestStateRootPath                   = "/eth/v1/beacon/states/%s/root"
 	RequestValidatorsPath                  = "/eth/v1/beacon/states/%s/validators"
 	RequestVoluntaryExitPath               = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestAttestationsPath                = "/eth/v1/beacon/blocks/%s/attestations"
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorLiveness               = "/eth/v1/validator/liveness/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	RequestEventsPath                      = "/eth/v1/events?topics=%s"
 
 	MaxRequestValidatorsCount     = 600
 	threadLimit               int = 6
@@ -82,12 +89,23 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 	// Return response
 	return beacon.SyncStatus{
-		Syncing:  syncStatus.Data.IsSyncing,
-		Progress: progress,
+		Syncing:      syncStatus.Data.IsSyncing,
+		Progress:     progress,
+		HeadSlot:     uint64(syncStatus.Data.HeadSlot),
+		SyncDistance: uint64(syncStatus.Data.SyncDistance),
 	}, nil
 
 }
 
+// Get the number of peers the node is connected to
+func (c *StandardHttpClient) GetPeerCount() (uint64, error) {
+	peerCount, err := c.getPeerCount()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -261,6 +279,42 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 	}
 
 	// Build validator status map
+	statuses := getValidatorStatusMap(validators)
+
+	// Put an empty status in for null pubkeys
+	statuses[nullPubkey] = beacon.ValidatorStatus{}
+
+	// Return
+	return statuses, nil
+
+}
+
+// Get the statuses of multiple validators by their indices
+func (c *StandardHttpClient) GetValidatorStatusesByIndices(indices []uint64, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+
+	// Convert indices into strings
+	indexStrings := make([]string, len(indices))
+	for i, index := range indices {
+		indexStrings[i] = strconv.FormatUint(index, 10)
+	}
+
+	// Get validators
+	validators, err := c.getValidatorsByOpts(indexStrings, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return validator status map
+	return getValidatorStatusMap(validators), nil
+
+}
+
+// Build a map of validator pubkeys to their statuses from a validators response
+func getValidatorStatusMap(validators ValidatorsResponse) map[types.ValidatorPubkey]beacon.ValidatorStatus {
+
+	// The null validator pubkey
+	nullPubkey := types.ValidatorPubkey{}
+
 	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus)
 	for _, validator := range validators.Data {
 
@@ -290,11 +344,7 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 
 	}
 
-	// Put an empty status in for null pubkeys
-	statuses[nullPubkey] = beacon.ValidatorStatus{}
-
-	// Return
-	return statuses, nil
+	return statuses
 
 }
 
@@ -373,6 +423,46 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []uint64, epoch
 	return proposerMap, nil
 }
 
+// Get whether validators were seen attesting or proposing at the given epoch
+func (c *StandardHttpClient) GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+
+	// Convert incoming uint64 validator indices into an array of string for the request
+	indicesStrings := make([]string, len(indices))
+
+	for i, index := range indices {
+		indicesStrings[i] = strconv.FormatUint(index, 10)
+	}
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorLiveness, strconv.FormatUint(epoch, 10)), indicesStrings)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator liveness: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response LivenessResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator liveness data: %w", err)
+	}
+
+	// Map the results
+	livenessMap := make(map[uint64]bool)
+
+	for _, index := range indices {
+		livenessMap[index] = false
+	}
+	for _, liveness := range response.Data {
+		if liveness.IsLive {
+			livenessMap[uint64(liveness.Index)] = true
+		}
+	}
+
+	return livenessMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
 
@@ -504,6 +594,7 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 	beaconBlock := beacon.BeaconBlock{
 		Slot:          uint64(block.Data.Message.Slot),
 		ProposerIndex: uint64(block.Data.Message.ProposerIndex),
+		Graffiti:      strings.TrimRight(string(block.Data.Message.Body.Graffiti), "\x00"),
 	}
 
 	// Execution payload only exists after the merge, so check for its existence
@@ -532,6 +623,15 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 	return beaconBlock, true, nil
 }
 
+// Get the root of a Beacon state, such as "head", "finalized", or a slot number
+func (c *StandardHttpClient) GetBeaconStateRoot(stateId string) (common.Hash, bool, error) {
+	response, exists, err := c.getStateRoot(stateId)
+	if err != nil || !exists {
+		return common.Hash{}, false, err
+	}
+	return common.BytesToHash(response.Data.Root), true, nil
+}
+
 // Get the attestation committees for the given epoch, or the current epoch if nil
 func (c *StandardHttpClient) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
 	response, err := c.getCommittees("head", epoch)
@@ -567,6 +667,67 @@ func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex uint64,
 	})
 }
 
+// Subscribe to new blocks from the Beacon node's event stream, calling the handler for each one until the context is cancelled or the stream ends
+func (c *StandardHttpClient) SubscribeToBlocks(ctx context.Context, handler func(beacon.BlockEvent)) error {
+
+	// Open the stream
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, fmt.Sprintf(RequestEventsPath, "block")), nil)
+	if err != nil {
+		return fmt.Errorf("Could not create block event request: %w", err)
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	response, err := net.GetHttpClient(net.HttpTarget_BeaconNode).Do(request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("Could not subscribe to block events: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not subscribe to block events: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read events until the stream ends; each one is a set of "field: value" lines followed by a blank line
+	scanner := bufio.NewScanner(response.Body)
+	var eventType string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if eventType == "block" && data.Len() > 0 {
+				var event BlockEvent
+				if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+					return fmt.Errorf("Could not decode block event: %w", err)
+				}
+				handler(beacon.BlockEvent{
+					Slot:      uint64(event.Slot),
+					BlockRoot: event.Block,
+				})
+			}
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Error reading block events: %w", err)
+	}
+	return fmt.Errorf("The block event stream was closed by the Beacon node")
+
+}
+
 // Get sync status
 func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
@@ -583,6 +744,22 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get the peer count
+func (c *StandardHttpClient) getPeerCount() (PeerCountResponse, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: %w", err)
+	}
+	if status != http.StatusOK {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not decode node peer count: %w", err)
+	}
+	return peerCount, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -690,6 +867,8 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	var stateId string
 	if opts == nil {
 		stateId = "head"
+	} else if opts.StateId != "" {
+		stateId = opts.StateId
 	} else if opts.Slot != nil {
 		stateId = strconv.FormatInt(int64(*opts.Slot), 10)
 	} else if opts.Epoch != nil {
@@ -800,6 +979,25 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 	return beaconBlock, true, nil
 }
 
+// Get the root of a Beacon state
+func (c *StandardHttpClient) getStateRoot(stateId string) (StateRootResponse, bool, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestStateRootPath, stateId))
+	if err != nil {
+		return StateRootResponse{}, false, fmt.Errorf("Could not get state root for state %s: %w", stateId, err)
+	}
+	if status == http.StatusNotFound {
+		return StateRootResponse{}, false, nil
+	}
+	if status != http.StatusOK {
+		return StateRootResponse{}, false, fmt.Errorf("Could not get state root for state %s: HTTP status %d; response body: '%s'", stateId, status, string(responseBody))
+	}
+	var stateRoot StateRootResponse
+	if err := json.Unmarshal(responseBody, &stateRoot); err != nil {
+		return StateRootResponse{}, false, fmt.Errorf("Could not decode state root for state %s: %w", stateId, err)
+	}
+	return stateRoot, true, nil
+}
+
 // Get the committees for the epoch
 func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (CommitteesResponse, error) {
 	query := ""
@@ -837,7 +1035,7 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 func (c *StandardHttpClient) getRequest(requestPath string) ([]byte, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := net.GetHttpClient(net.HttpTarget_BeaconNode).Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -867,7 +1065,7 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := net.GetHttpClient(net.HttpTarget_BeaconNode).Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
 	if err != nil {
 		return []byte{}, 0, err
 	}
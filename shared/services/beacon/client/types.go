@@ -36,6 +36,11 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger `json:"SECONDS_PER_SLOT"`
@@ -90,6 +95,7 @@ type BeaconBlockResponse struct {
 					DepositCount uinteger  `json:"deposit_count"`
 					BlockHash    byteArray `json:"block_hash"`
 				} `json:"eth1_data"`
+				Graffiti         byteArray     `json:"graffiti"`
 				Attestations     []Attestation `json:"attestations"`
 				ExecutionPayload *struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
@@ -99,6 +105,11 @@ type BeaconBlockResponse struct {
 		} `json:"message"`
 	} `json:"data"`
 }
+type StateRootResponse struct {
+	Data struct {
+		Root byteArray `json:"root"`
+	} `json:"data"`
+}
 type ValidatorsResponse struct {
 	Data []Validator `json:"data"`
 }
@@ -131,6 +142,13 @@ type ProposerDutiesResponse struct {
 type ProposerDuty struct {
 	ValidatorIndex uinteger `json:"validator_index"`
 }
+type LivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+type ValidatorLiveness struct {
+	Index  uinteger `json:"index"`
+	IsLive bool     `json:"is_live"`
+}
 
 type CommitteesResponse struct {
 	Data []Committee `json:"data"`
@@ -150,6 +168,11 @@ type Attestation struct {
 	} `json:"data"`
 }
 
+type BlockEvent struct {
+	Slot  uinteger    `json:"slot"`
+	Block common.Hash `json:"block"`
+}
+
 // Unsigned integer type
 type uinteger uint64
 
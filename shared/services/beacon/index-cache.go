@@ -0,0 +1,151 @@
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The contents of the validator index cache file
+type validatorIndexCacheFile struct {
+	Network string            `json:"network"`
+	Indices map[string]uint64 `json:"indices"`
+}
+
+// A persistent cache of validator pubkeys to their indices on the Beacon chain.
+// A validator's index never changes once it's been assigned, so entries never expire; the cache is only
+// discarded if it was written for a different network.
+type ValidatorIndexCache struct {
+	path    string
+	network string
+	indices map[types.ValidatorPubkey]uint64
+	dirty   bool
+	lock    sync.Mutex
+}
+
+// Create a validator index cache backed by the file at the given path, loading any indices that have already been saved.
+// A missing or unreadable file just starts an empty cache.
+func NewValidatorIndexCache(path string, network string) *ValidatorIndexCache {
+	return &ValidatorIndexCache{
+		path:    path,
+		network: network,
+		indices: readIndexCacheFile(path, network),
+	}
+}
+
+// Read the indices saved in a cache file for the given network; a missing, unreadable, or other network's file has none
+func readIndexCacheFile(path string, network string) map[types.ValidatorPubkey]uint64 {
+	indices := map[types.ValidatorPubkey]uint64{}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return indices
+	}
+	var file validatorIndexCacheFile
+	if err := json.Unmarshal(bytes, &file); err != nil || file.Network != network {
+		return indices
+	}
+	for pubkeyString, index := range file.Indices {
+		pubkey, err := types.HexToValidatorPubkey(pubkeyString)
+		if err != nil {
+			continue
+		}
+		indices[pubkey] = index
+	}
+	return indices
+}
+
+// Get the cached index of a validator
+func (c *ValidatorIndexCache) Get(pubkey types.ValidatorPubkey) (uint64, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	index, exists := c.indices[pubkey]
+	return index, exists
+}
+
+// Add a validator's index to the cache
+func (c *ValidatorIndexCache) Add(pubkey types.ValidatorPubkey, index uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if existing, exists := c.indices[pubkey]; exists && existing == index {
+		return
+	}
+	c.indices[pubkey] = index
+	c.dirty = true
+}
+
+// Add the indices of every validator in a set of statuses that exists on the Beacon chain
+func (c *ValidatorIndexCache) AddStatuses(statuses map[types.ValidatorPubkey]ValidatorStatus) {
+	for pubkey, status := range statuses {
+		if status.Exists && status.Pubkey == pubkey {
+			c.Add(pubkey, status.Index)
+		}
+	}
+}
+
+// Write the cache to disk if any indices have been added since it was last saved.
+// Other processes (the node, watchtower, and API containers) share the file, so it's locked and re-read first and
+// any indices they saved in the meantime are merged in rather than overwritten.
+func (c *ValidatorIndexCache) Save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("error creating validator index cache directory: %w", err)
+	}
+	unlock, err := lockIndexCacheFile(c.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	for pubkey, index := range readIndexCacheFile(c.path, c.network) {
+		if _, exists := c.indices[pubkey]; !exists {
+			c.indices[pubkey] = index
+		}
+	}
+
+	file := validatorIndexCacheFile{
+		Network: c.network,
+		Indices: make(map[string]uint64, len(c.indices)),
+	}
+	for pubkey, index := range c.indices {
+		file.Indices[pubkey.Hex()] = index
+	}
+	bytes, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("error serializing validator index cache: %w", err)
+	}
+
+	// Write to a temp file first so other processes never read a partial cache
+	tempFile, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary validator index cache: %w", err)
+	}
+	tempPath := tempFile.Name()
+	_, err = tempFile.Write(bytes)
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error writing validator index cache [%s]: %w", tempPath, err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error setting permissions on validator index cache [%s]: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, c.path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error replacing validator index cache [%s]: %w", c.path, err)
+	}
+
+	c.dirty = false
+	return nil
+}
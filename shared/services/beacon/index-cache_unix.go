@@ -0,0 +1,26 @@
+//go:build linux || darwin || freebsd
+
+package beacon
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Take an exclusive lock on the lock file next to the cache so only one process updates it at a time.
+// The returned function releases the lock.
+func lockIndexCacheFile(path string) (func(), error) {
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening validator index cache lock [%s]: %w", path+".lock", err)
+	}
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		lockFile.Close()
+		return nil, fmt.Errorf("error locking validator index cache [%s]: %w", path+".lock", err)
+	}
+	return func() {
+		_ = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+		lockFile.Close()
+	}, nil
+}
@@ -11,6 +11,7 @@ const P2pPortID string = "p2pPort"
 const ApiPortID string = "apiPort"
 const OpenApiPortID string = "openApiPort"
 const DoppelgangerDetectionID string = "doppelgangerDetection"
+const EnableBnProxyID string = "enableBnProxy"
 
 // Defaults
 const defaultGraffiti string = ""
@@ -19,6 +20,9 @@ const defaultP2pPort uint16 = 9001
 const defaultBnApiPort uint16 = 5052
 const defaultOpenBnApiPort bool = false
 const defaultDoppelgangerDetection bool = true
+const defaultEnableBnProxy bool = false
+const defaultBnProxyPort uint16 = 5053
+const defaultBnProxyCacheTtl uint64 = 6
 
 // Env var names
 const CustomGraffitiEnvVar string = "CUSTOM_GRAFFITI"
@@ -44,6 +48,15 @@ type ConsensusCommonConfig struct {
 
 	// Toggle for enabling doppelganger detection
 	DoppelgangerDetection config.Parameter `yaml:"doppelgangerDetection,omitempty"`
+
+	// Toggle for routing the VC and daemon through the caching Beacon proxy
+	EnableBnProxy config.Parameter `yaml:"enableBnProxy,omitempty"`
+
+	// The port the caching Beacon proxy listens on
+	BnProxyPort config.Parameter `yaml:"bnProxyPort,omitempty"`
+
+	// The number of seconds the caching Beacon proxy keeps responses for
+	BnProxyCacheTtl config.Parameter `yaml:"bnProxyCacheTtl,omitempty"`
 }
 
 // Create a new ConsensusCommonParams struct
@@ -125,6 +138,42 @@ func NewConsensusCommonConfig(cfg *RocketPoolConfig) *ConsensusCommonConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		EnableBnProxy: config.Parameter{
+			ID:                   EnableBnProxyID,
+			Name:                 "Enable Caching Beacon Proxy",
+			Description:          "Enable this to run a small caching proxy in front of your Consensus client's HTTP API. Your Validator Client and the Smartnode daemons will talk to the proxy instead, which merges identical requests (such as validator statuses and duties) so your Consensus client only has to answer them once.\n\nThis is useful for low-powered machines that struggle during busy epochs.\n\n[orange]NOTE: Prysm's Validator Client uses gRPC instead of the HTTP API, so only the Smartnode daemons will use the proxy with Prysm.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultEnableBnProxy},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator, config.ContainerID_BnProxy},
+			EnvironmentVariables: []string{"ENABLE_BN_PROXY"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BnProxyPort: config.Parameter{
+			ID:                   "bnProxyPort",
+			Name:                 "Caching Beacon Proxy Port",
+			Description:          "The port the caching Beacon proxy should listen on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultBnProxyPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator, config.ContainerID_BnProxy},
+			EnvironmentVariables: []string{"BN_PROXY_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BnProxyCacheTtl: config.Parameter{
+			ID:                   "bnProxyCacheTtl",
+			Name:                 "Caching Beacon Proxy TTL",
+			Description:          "The number of seconds the caching Beacon proxy should keep a response before asking your Consensus client for it again.\n\nKeep this below the slot time (12 seconds) so your Validator Client always sees fresh data.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultBnProxyCacheTtl},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_BnProxy},
+			EnvironmentVariables: []string{"BN_PROXY_CACHE_TTL"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -137,6 +186,9 @@ func (cfg *ConsensusCommonConfig) GetParameters() []*config.Parameter {
 		&cfg.ApiPort,
 		&cfg.OpenApiPort,
 		&cfg.DoppelgangerDetection,
+		&cfg.EnableBnProxy,
+		&cfg.BnProxyPort,
+		&cfg.BnProxyCacheTtl,
 	}
 }
 
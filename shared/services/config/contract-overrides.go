@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Names of the contracts that can have their addresses overridden
+const (
+	StorageContractName            string = "rocketStorage"
+	RplTokenContractName           string = "rocketTokenRPL"
+	RethContractName               string = "rocketTokenRETH"
+	RplFaucetContractName          string = "rocketRPLFaucet"
+	OneInchOracleContractName      string = "oneInchOracle"
+	SnapshotDelegationContractName string = "snapshotDelegation"
+	RplTwapPoolContractName        string = "rplTwapPool"
+	MulticallContractName          string = "multicall"
+	BalanceBatcherContractName     string = "balanceBatcher"
+)
+
+// Get the names of the contracts that can be overridden, in sorted order
+func getOverridableContractNames() []string {
+	names := []string{
+		StorageContractName,
+		RplTokenContractName,
+		RethContractName,
+		RplFaucetContractName,
+		OneInchOracleContractName,
+		SnapshotDelegationContractName,
+		RplTwapPoolContractName,
+		MulticallContractName,
+		BalanceBatcherContractName,
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse the contract address overrides that apply to the currently selected network.
+// Returns a map of contract name to address.
+func (cfg *SmartnodeConfig) GetContractAddressOverrides() (map[string]common.Address, error) {
+	overrides := map[string]common.Address{}
+	value, ok := cfg.ContractAddressOverrides.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return overrides, nil
+	}
+
+	network := cfg.Network.Value.(config.Network)
+	validNames := map[string]bool{}
+	for _, name := range getOverridableContractNames() {
+		validNames[name] = true
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// Split the entry into the contract and address
+		elements := strings.Split(entry, "=")
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid contract address override [%s]: expected the format 'contract=address' or 'network:contract=address'", entry)
+		}
+		name := strings.TrimSpace(elements[0])
+		address := strings.TrimSpace(elements[1])
+
+		// Handle network-specific entries
+		if strings.Contains(name, ":") {
+			nameElements := strings.SplitN(name, ":", 2)
+			entryNetwork := config.Network(strings.TrimSpace(nameElements[0]))
+			name = strings.TrimSpace(nameElements[1])
+			if entryNetwork != network {
+				continue
+			}
+		}
+
+		// Validate the entry
+		if !validNames[name] {
+			return nil, fmt.Errorf("invalid contract address override [%s]: unknown contract '%s' (supported contracts: %s)", entry, name, strings.Join(getOverridableContractNames(), ", "))
+		}
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid contract address override [%s]: '%s' is not a valid address", entry, address)
+		}
+		if _, exists := overrides[name]; exists {
+			return nil, fmt.Errorf("invalid contract address override [%s]: contract '%s' is overridden more than once", entry, name)
+		}
+		overrides[name] = common.HexToAddress(address)
+	}
+
+	return overrides, nil
+}
+
+// Check if any contract address overrides are active on the currently selected network
+func (cfg *SmartnodeConfig) HasContractAddressOverrides() bool {
+	overrides, err := cfg.GetContractAddressOverrides()
+	return err == nil && len(overrides) > 0
+}
+
+// Get the address of a contract on the currently selected network, taking any overrides into account.
+// Invalid override settings are ignored here; they're reported by the config validation instead.
+func (cfg *SmartnodeConfig) getContractAddress(name string, addresses map[config.Network]string) string {
+	overrides, err := cfg.GetContractAddressOverrides()
+	if err == nil {
+		if address, exists := overrides[name]; exists {
+			return address.Hex()
+		}
+	}
+	return addresses[cfg.Network.Value.(config.Network)]
+}
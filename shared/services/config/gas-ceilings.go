@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Names of the automatic tasks that support their own gas ceiling
+const (
+	Task_StakePrelaunchMinipools string = "stake-prelaunch-minipools"
+	Task_ClaimRewards            string = "claim-rewards"
+	Task_RefundMinipools         string = "refund-minipools"
+	Task_ReduceBonds             string = "reduce-bonds"
+	Task_DistributeMinipools     string = "distribute-minipools"
+	Task_PromoteMinipools        string = "promote-minipools"
+	Task_SweepFeeDistributor     string = "sweep-fee-distributor"
+)
+
+// The automatic tasks that support their own gas ceiling
+var TaskGasCeilingTasks = []string{
+	Task_StakePrelaunchMinipools,
+	Task_ClaimRewards,
+	Task_RefundMinipools,
+	Task_ReduceBonds,
+	Task_DistributeMinipools,
+	Task_PromoteMinipools,
+	Task_SweepFeeDistributor,
+}
+
+// Parse the per-task gas ceilings.
+// Returns a map of task name to its max fee ceiling in gwei.
+func (cfg *SmartnodeConfig) GetTaskGasCeilings() (map[string]float64, error) {
+	ceilings := map[string]float64{}
+	value, ok := cfg.TaskGasCeilings.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return ceilings, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		elements := strings.Split(entry, "=")
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid gas ceiling [%s]: expected the format 'task=gwei'", entry)
+		}
+		task := strings.TrimSpace(elements[0])
+		if !isTaskGasCeilingTask(task) {
+			return nil, fmt.Errorf("invalid gas ceiling [%s]: unknown task '%s' (supported tasks: %s)", entry, task, strings.Join(TaskGasCeilingTasks, ", "))
+		}
+		if _, exists := ceilings[task]; exists {
+			return nil, fmt.Errorf("invalid gas ceiling [%s]: task '%s' has more than one ceiling", entry, task)
+		}
+		ceiling, err := strconv.ParseFloat(strings.TrimSpace(elements[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas ceiling [%s]: '%s' is not a number", entry, strings.TrimSpace(elements[1]))
+		}
+		if ceiling < 0 {
+			return nil, fmt.Errorf("invalid gas ceiling [%s]: it can't be negative", entry)
+		}
+		ceilings[task] = ceiling
+	}
+
+	return ceilings, nil
+}
+
+// Get the gas ceiling for a task, if one has been set
+func (cfg *SmartnodeConfig) GetTaskGasCeiling(task string) (float64, bool) {
+	ceilings, err := cfg.GetTaskGasCeilings()
+	if err != nil {
+		return 0, false
+	}
+	ceiling, exists := ceilings[task]
+	return ceiling, exists
+}
+
+// Get the max fee threshold (in gwei) a task's transactions are deferred until, which is its own ceiling
+// if one has been set or the Automatic TX Gas Threshold if not
+func (cfg *SmartnodeConfig) GetTaskGasThreshold(task string) float64 {
+	if ceiling, exists := cfg.GetTaskGasCeiling(task); exists {
+		return ceiling
+	}
+	return cfg.AutoTxGasThreshold.Value.(float64)
+}
+
+// Check if a task supports its own gas ceiling
+func isTaskGasCeilingTask(task string) bool {
+	for _, name := range TaskGasCeilingTasks {
+		if name == task {
+			return true
+		}
+	}
+	return false
+}
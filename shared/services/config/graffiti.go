@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get the messages the graffiti rotates through
+func (cfg *SmartnodeConfig) GetGraffitiMessages() []string {
+	messages := []string{}
+	value, ok := cfg.GraffitiMessages.Value.(string)
+	if !ok {
+		return messages
+	}
+	for _, message := range strings.Split(value, "|") {
+		message = strings.TrimSpace(message)
+		if message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// Get how long each graffiti message is used before moving to the next one
+func (cfg *SmartnodeConfig) GetGraffitiRotationInterval() (time.Duration, error) {
+	value, ok := cfg.GraffitiRotationInterval.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		value = defaultGraffitiRotation
+	}
+	interval, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid duration: %w", value, err)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("'%s' must be a positive duration", value)
+	}
+	return interval, nil
+}
+
+// Get the values of the graffiti template variables that are the same for every validator
+func (cfg *RocketPoolConfig) GetGraffitiVariables() map[string]string {
+	envVars := cfg.GenerateEnvironmentVariables()
+	return map[string]string{
+		"graffiti":  envVars["GRAFFITI"],
+		"prefix":    envVars["GRAFFITI_PREFIX"],
+		"custom":    envVars[CustomGraffitiEnvVar],
+		"ec":        envVars["EC_CLIENT"],
+		"cc":        envVars["CC_CLIENT"],
+		"ecVersion": cfg.getExecutionClientVersion(),
+		"ccVersion": cfg.getValidatorClientVersion(),
+		"nickname":  cfg.Smartnode.NodeNickname.Value.(string),
+	}
+}
+
+// Get the version of the locally managed Execution client from its container tag
+func (cfg *RocketPoolConfig) getExecutionClientVersion() string {
+	if cfg.IsNativeMode || cfg.ExecutionClientMode.Value.(config.Mode) != config.Mode_Local {
+		return ""
+	}
+	switch cfg.ExecutionClient.Value.(config.ExecutionClient) {
+	case config.ExecutionClient_Geth:
+		return getImageVersion(cfg.Geth.ContainerTag.Value.(string))
+	case config.ExecutionClient_Nethermind:
+		return getImageVersion(cfg.Nethermind.ContainerTag.Value.(string))
+	case config.ExecutionClient_Besu:
+		return getImageVersion(cfg.Besu.ContainerTag.Value.(string))
+	}
+	return ""
+}
+
+// Get the version of the Validator client from its container tag
+func (cfg *RocketPoolConfig) getValidatorClientVersion() string {
+	ccConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return ""
+	}
+	return getImageVersion(ccConfig.GetValidatorImage())
+}
+
+// Get the version part of a container tag, such as `v4.5.0` from `sigp/lighthouse:v4.5.0`
+func getImageVersion(image string) string {
+	index := strings.LastIndex(image, ":")
+	if index == -1 {
+		return ""
+	}
+	return image[index+1:]
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A planned period when the node will be offline for maintenance
+type MaintenanceWindow struct {
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Get the planned maintenance windows, in the order they were entered
+func (cfg *SmartnodeConfig) GetMaintenanceWindows() ([]MaintenanceWindow, error) {
+	windows := []MaintenanceWindow{}
+	value, ok := cfg.MaintenanceWindows.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return windows, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		startString, durationString, found := strings.Cut(entry, "/")
+		if !found {
+			return nil, fmt.Errorf("'%s' must be a start time and a duration separated by a slash", entry)
+		}
+		start, err := time.Parse(time.RFC3339, strings.TrimSpace(startString))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' has an invalid start time: %w", entry, err)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(durationString))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' has an invalid duration: %w", entry, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("'%s' must have a positive duration", entry)
+		}
+		windows = append(windows, MaintenanceWindow{
+			Start:    start,
+			Duration: duration,
+		})
+	}
+	return windows, nil
+}
@@ -2,17 +2,108 @@ package migration
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-version"
 )
 
+const (
+	// The section and key the settings file records its schema version under
+	rootSectionName  string = "root"
+	SchemaVersionKey string = "schemaVersion"
+)
+
+// A single step that upgrades a serialized config from the previous schema version to SchemaVersion
+type Migration struct {
+	SchemaVersion uint64
+	Description   string
+	Migrate       func(serializedConfig map[string]map[string]string) error
+}
+
+// The result of upgrading a serialized config
+type MigrationResult struct {
+	FromSchemaVersion uint64
+	ToSchemaVersion   uint64
+	Applied           []string
+}
+
 type ConfigUpgrader struct {
 	Version     *version.Version
 	UpgradeFunc func(serializedConfig map[string]map[string]string) error
 }
 
-func UpdateConfig(serializedConfig map[string]map[string]string) error {
+// The ordered migrations; new ones must be appended with the next schema version.
+// Settings files from before schema versions were recorded are treated as schema 0.
+var migrations = []Migration{
+	{
+		SchemaVersion: 1,
+		Description:   "Apply the upgrades keyed on the Smartnode version that wrote the settings file",
+		Migrate:       applyLegacyUpgrades,
+	},
+}
+
+// Get the schema version settings files are written with
+func GetCurrentSchemaVersion() uint64 {
+	return migrations[len(migrations)-1].SchemaVersion
+}
+
+// Upgrade a serialized config to the current schema version.
+// The migrations are applied to a copy, so the provided config is only changed if all of them succeed.
+func UpdateConfig(serializedConfig map[string]map[string]string) (MigrationResult, error) {
+
+	// Get the config's schema version
+	result := MigrationResult{
+		Applied: []string{},
+	}
+	fromVersion, err := getSchemaVersionFromConfig(serializedConfig)
+	if err != nil {
+		return result, err
+	}
+	currentVersion := GetCurrentSchemaVersion()
+	result.FromSchemaVersion = fromVersion
+	result.ToSchemaVersion = fromVersion
+	if fromVersion > currentVersion {
+		return result, fmt.Errorf("the settings file has schema version %d, which is newer than this Smartnode supports (%d); it was likely written by a newer release", fromVersion, currentVersion)
+	}
+	if fromVersion == currentVersion {
+		return result, nil
+	}
+
+	// Apply the migrations in order
+	migratedConfig := copyConfig(serializedConfig)
+	previousVersion := uint64(0)
+	for _, migration := range migrations {
+		if migration.SchemaVersion <= previousVersion {
+			return result, fmt.Errorf("migration to schema version %d is out of order", migration.SchemaVersion)
+		}
+		previousVersion = migration.SchemaVersion
+		if migration.SchemaVersion <= fromVersion {
+			continue
+		}
+
+		err := migration.Migrate(migratedConfig)
+		if err != nil {
+			return result, fmt.Errorf("error migrating config to schema version %d (%s): %w", migration.SchemaVersion, migration.Description, err)
+		}
+		result.Applied = append(result.Applied, migration.Description)
+	}
+
+	// Record the new schema version and commit the changes
+	migratedConfig[rootSectionName][SchemaVersionKey] = fmt.Sprint(currentVersion)
+	for name := range serializedConfig {
+		delete(serializedConfig, name)
+	}
+	for name, section := range migratedConfig {
+		serializedConfig[name] = section
+	}
+	result.ToSchemaVersion = currentVersion
+	return result, nil
+
+}
+
+// Apply the upgrades for releases before the settings file recorded its schema version
+func applyLegacyUpgrades(serializedConfig map[string]map[string]string) error {
 
 	// Get the config's version
 	configVersion, err := getVersionFromConfig(serializedConfig)
@@ -67,16 +158,34 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 
 }
 
+// Get the schema version of the given config; configs that don't have one are schema 0
+func getSchemaVersionFromConfig(serializedConfig map[string]map[string]string) (uint64, error) {
+	rootConfig, exists := serializedConfig[rootSectionName]
+	if !exists {
+		return 0, fmt.Errorf("expected a section called `%s` but it didn't exist", rootSectionName)
+	}
+
+	schemaVersionString, exists := rootConfig[SchemaVersionKey]
+	if !exists || schemaVersionString == "" {
+		return 0, nil
+	}
+	schemaVersion, err := strconv.ParseUint(schemaVersionString, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing schema version [%s] from config file: %w", schemaVersionString, err)
+	}
+	return schemaVersion, nil
+}
+
 // Get the Smartnode version that the given config was built with
 func getVersionFromConfig(serializedConfig map[string]map[string]string) (*version.Version, error) {
-	rootConfig, exists := serializedConfig["root"]
+	rootConfig, exists := serializedConfig[rootSectionName]
 	if !exists {
-		return nil, fmt.Errorf("expected a section called `root` but it didn't exist")
+		return nil, fmt.Errorf("expected a section called `%s` but it didn't exist", rootSectionName)
 	}
 
 	configVersionString, exists := rootConfig["version"]
 	if !exists {
-		return nil, fmt.Errorf("expected a `root` setting named `version` but it didn't exist")
+		return nil, fmt.Errorf("expected a `%s` setting named `version` but it didn't exist", rootSectionName)
 	}
 
 	configVersion, err := version.NewVersion(strings.TrimPrefix(configVersionString, "v"))
@@ -95,3 +204,16 @@ func parseVersion(versionString string) (*version.Version, error) {
 	}
 	return parsedVersion, nil
 }
+
+// Make a deep copy of a serialized config
+func copyConfig(serializedConfig map[string]map[string]string) map[string]map[string]string {
+	configCopy := make(map[string]map[string]string, len(serializedConfig))
+	for name, section := range serializedConfig {
+		sectionCopy := make(map[string]string, len(section))
+		for key, value := range section {
+			sectionCopy[key] = value
+		}
+		configCopy[name] = sectionCopy
+	}
+	return configCopy
+}
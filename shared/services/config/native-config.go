@@ -24,6 +24,21 @@ type NativeConfig struct {
 
 	// The command for stopping the validator container in native mode
 	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty"`
+
+	// Toggle for letting the service commands manage systemd units
+	ManageSystemdUnits config.Parameter `yaml:"manageSystemdUnits,omitempty"`
+
+	// The user that the systemd units run as
+	SystemdUser config.Parameter `yaml:"systemdUser,omitempty"`
+
+	// The command for running the EC
+	EcCommand config.Parameter `yaml:"ecCommand,omitempty"`
+
+	// The command for running the CC
+	CcCommand config.Parameter `yaml:"ccCommand,omitempty"`
+
+	// The command for running the VC
+	VcCommand config.Parameter `yaml:"vcCommand,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -112,6 +127,66 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		ManageSystemdUnits: config.Parameter{
+			ID:                   "manageSystemdUnits",
+			Name:                 "Manage systemd Units",
+			Description:          "Enable this to have `rocketpool service start`, `stop`, and `status` generate and manage systemd units for the node daemon, the watchtower, and any clients you provide commands for below, instead of managing them yourself.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SystemdUser: config.Parameter{
+			ID:                   "systemdUser",
+			Name:                 "systemd Unit User",
+			Description:          "The user account that the generated systemd units will run as. It must be able to read your Smartnode data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "rp"},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcCommand: config.Parameter{
+			ID:                   "ecCommand",
+			Name:                 "Execution Client Command",
+			Description:          "The full command (with absolute paths) that runs your Execution client, used for its systemd unit. Leave this blank if you don't want the Smartnode to manage your Execution client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CcCommand: config.Parameter{
+			ID:                   "ccCommand",
+			Name:                 "Consensus Client Command",
+			Description:          "The full command (with absolute paths) that runs your Consensus client's Beacon Node, used for its systemd unit. Leave this blank if you don't want the Smartnode to manage your Beacon Node.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VcCommand: config.Parameter{
+			ID:                   "vcCommand",
+			Name:                 "Validator Client Command",
+			Description:          "The full command (with absolute paths) that runs your Validator client, used for its systemd unit. Leave this blank if you don't want the Smartnode to manage your Validator client.\n\nIf you set this, you can use `systemctl restart rp-validator` in your VC Restart Script and `systemctl stop rp-validator` in your Validator Stop Command.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 }
@@ -124,6 +199,11 @@ func (cfg *NativeConfig) GetParameters() []*config.Parameter {
 		&cfg.CcHttpUrl,
 		&cfg.ValidatorRestartCommand,
 		&cfg.ValidatorStopCommand,
+		&cfg.ManageSystemdUnits,
+		&cfg.SystemdUser,
+		&cfg.EcCommand,
+		&cfg.CcCommand,
+		&cfg.VcCommand,
 	}
 }
 
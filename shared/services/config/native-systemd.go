@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	SystemdUnitPrefix string = "rp-"
+	systemdUnitSuffix string = ".service"
+)
+
+// A systemd unit for one of the Native mode services
+type SystemdUnit struct {
+	ID       config.ContainerID
+	Name     string
+	Contents string
+}
+
+// Generate the systemd units for the Native mode services.
+// Units for the daemon and watchtower are always included; clients are only included if a command has been set for them.
+func (cfg *RocketPoolConfig) GenerateSystemdUnits(daemonPath string) ([]SystemdUnit, error) {
+	if !cfg.IsNativeMode {
+		return nil, fmt.Errorf("systemd units are only available in Native mode")
+	}
+	user := strings.TrimSpace(cfg.Native.SystemdUser.Value.(string))
+	if user == "" {
+		return nil, fmt.Errorf("the systemd unit user must be set")
+	}
+
+	// filepath.Abs turns an empty path into the working directory, so empty paths have to be caught first
+	if strings.TrimSpace(daemonPath) == "" {
+		return nil, fmt.Errorf("the path of the daemon must be set")
+	}
+	if strings.TrimSpace(cfg.RocketPoolDirectory) == "" {
+		return nil, fmt.Errorf("the Rocket Pool directory must be set")
+	}
+	daemonPath, err := filepath.Abs(daemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the absolute path of the daemon: %w", err)
+	}
+	settingsPath, err := filepath.Abs(filepath.Join(cfg.RocketPoolDirectory, "user-settings.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("error getting the absolute path of the settings file: %w", err)
+	}
+
+	// Clients come first so the Smartnode services can be ordered after them
+	units := []SystemdUnit{}
+	clientUnits := []string{}
+	ecCommand := strings.TrimSpace(cfg.Native.EcCommand.Value.(string))
+	if ecCommand != "" {
+		unit := newSystemdUnit(config.ContainerID_Eth1, "Rocket Pool Execution Client", user, ecCommand, nil)
+		units = append(units, unit)
+		clientUnits = append(clientUnits, unit.Name)
+	}
+	ccCommand := strings.TrimSpace(cfg.Native.CcCommand.Value.(string))
+	var ccUnits []string
+	if ccCommand != "" {
+		unit := newSystemdUnit(config.ContainerID_Eth2, "Rocket Pool Beacon Node", user, ccCommand, clientUnits)
+		units = append(units, unit)
+		ccUnits = []string{unit.Name}
+		clientUnits = append(clientUnits, unit.Name)
+	}
+	vcCommand := strings.TrimSpace(cfg.Native.VcCommand.Value.(string))
+	if vcCommand != "" {
+		units = append(units, newSystemdUnit(config.ContainerID_Validator, "Rocket Pool Validator Client", user, vcCommand, ccUnits))
+	}
+
+	// Add the Smartnode services
+	nodeCommand := fmt.Sprintf("%s --settings %s node", quoteSystemdArg(daemonPath), quoteSystemdArg(settingsPath))
+	watchtowerCommand := fmt.Sprintf("%s --settings %s watchtower", quoteSystemdArg(daemonPath), quoteSystemdArg(settingsPath))
+	units = append(units,
+		newSystemdUnit(config.ContainerID_Node, "Rocket Pool Node Daemon", user, nodeCommand, clientUnits),
+		newSystemdUnit(config.ContainerID_Watchtower, "Rocket Pool Watchtower", user, watchtowerCommand, clientUnits),
+	)
+	return units, nil
+}
+
+// Get the names of the systemd units that the service commands manage in Native mode
+func (cfg *RocketPoolConfig) GetSystemdUnitNames() []string {
+	names := []string{}
+	if strings.TrimSpace(cfg.Native.EcCommand.Value.(string)) != "" {
+		names = append(names, GetSystemdUnitName(config.ContainerID_Eth1))
+	}
+	if strings.TrimSpace(cfg.Native.CcCommand.Value.(string)) != "" {
+		names = append(names, GetSystemdUnitName(config.ContainerID_Eth2))
+	}
+	if strings.TrimSpace(cfg.Native.VcCommand.Value.(string)) != "" {
+		names = append(names, GetSystemdUnitName(config.ContainerID_Validator))
+	}
+	return append(names, GetSystemdUnitName(config.ContainerID_Node), GetSystemdUnitName(config.ContainerID_Watchtower))
+}
+
+// Get the name of the systemd unit for a service
+func GetSystemdUnitName(id config.ContainerID) string {
+	return SystemdUnitPrefix + string(id) + systemdUnitSuffix
+}
+
+// Quote an argument for a systemd ExecStart line so spaces, quotes, and systemd's % and $ expansions are taken literally
+func quoteSystemdArg(arg string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$")
+	return `"` + replacer.Replace(arg) + `"`
+}
+
+// Create a systemd unit that runs a command, starting after the units it depends on
+func newSystemdUnit(id config.ContainerID, description string, user string, command string, dependencies []string) SystemdUnit {
+	var sb strings.Builder
+	sb.WriteString("# This file is generated by `rocketpool service start`; changes to it will be overwritten.\n")
+	sb.WriteString("[Unit]\n")
+	fmt.Fprintf(&sb, "Description=%s\n", description)
+	after := append([]string{"network-online.target"}, dependencies...)
+	fmt.Fprintf(&sb, "After=%s\n", strings.Join(after, " "))
+	sb.WriteString("Wants=network-online.target\n")
+	if len(dependencies) > 0 {
+		fmt.Fprintf(&sb, "Wants=%s\n", strings.Join(dependencies, " "))
+	}
+	sb.WriteString("\n[Service]\n")
+	sb.WriteString("Type=simple\n")
+	fmt.Fprintf(&sb, "User=%s\n", user)
+	sb.WriteString("Restart=always\n")
+	sb.WriteString("RestartSec=5\n")
+	sb.WriteString("TimeoutStopSec=300\n")
+	fmt.Fprintf(&sb, "ExecStart=%s\n", command)
+	sb.WriteString("\n[Install]\n")
+	sb.WriteString("WantedBy=multi-user.target\n")
+
+	return SystemdUnit{
+		ID:       id,
+		Name:     GetSystemdUnitName(id),
+		Contents: sb.String(),
+	}
+}
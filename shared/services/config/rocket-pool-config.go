@@ -6,10 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/alessio/shellescape"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
 	"github.com/rocket-pool/smartnode/shared"
@@ -24,6 +27,7 @@ const (
 	rootConfigName string = "root"
 
 	ApiContainerName          string = "api"
+	BnProxyContainerName      string = "bn-proxy"
 	Eth1ContainerName         string = "eth1"
 	Eth1FallbackContainerName string = "eth1-fallback"
 	Eth2ContainerName         string = "eth2"
@@ -47,12 +51,20 @@ const defaultExporterMetricsPort uint16 = 9103
 const defaultWatchtowerMetricsPort uint16 = 9104
 const defaultEcMetricsPort uint16 = 9105
 
+// The maximum length of a block's graffiti, in bytes
+const maxGraffitiLength int = 32
+
 // The master configuration struct
 type RocketPoolConfig struct {
 	Title string `yaml:"-"`
 
 	Version string `yaml:"-"`
 
+	// The settings file's schema, the migrations that were applied to reach it, and any settings that weren't recognized afterwards
+	SchemaVersion        uint64   `yaml:"-"`
+	AppliedMigrations    []string `yaml:"-"`
+	UnrecognizedSettings []string `yaml:"-"`
+
 	RocketPoolDirectory string `yaml:"-"`
 
 	IsNativeMode bool `yaml:"-"`
@@ -65,6 +77,10 @@ type RocketPoolConfig struct {
 	UseFallbackClients config.Parameter `yaml:"useFallbackClients,omitempty"`
 	ReconnectDelay     config.Parameter `yaml:"reconnectDelay,omitempty"`
 
+	// Routing for each class of Beacon requests when the primary and fallback clients are both healthy
+	LatencySensitiveBcRouting config.Parameter `yaml:"latencySensitiveBcRouting,omitempty"`
+	HeavyBcRouting            config.Parameter `yaml:"heavyBcRouting,omitempty"`
+
 	// Consensus client settings
 	ConsensusClientMode     config.Parameter `yaml:"consensusClientMode,omitempty"`
 	ConsensusClient         config.Parameter `yaml:"consensusClient,omitempty"`
@@ -123,6 +139,8 @@ type RocketPoolConfig struct {
 
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
+	RescueNode         addontypes.SmartnodeAddon `yaml:"addon-rescue-node,omitempty"`
+	Dvt                addontypes.SmartnodeAddon `yaml:"addon-dvt,omitempty"`
 }
 
 // Load configuration settings from a file
@@ -237,6 +255,56 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		LatencySensitiveBcRouting: config.Parameter{
+			ID:                   "latencySensitiveBcRouting",
+			Name:                 "Latency-Sensitive Request Routing",
+			Description:          "Select which Consensus client the Smartnode should use for time-critical requests, such as duty lookups and chain head queries, while both your primary and fallback clients are healthy. The Smartnode continuously measures how quickly each client responds to decide which one is faster.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.BcRoutingMode_Primary},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Primary",
+				Description: "Always send these requests to your primary client, and only use the fallback if the primary goes offline.",
+				Value:       config.BcRoutingMode_Primary,
+			}, {
+				Name:        "Fastest",
+				Description: "Send these requests to whichever client has been responding faster recently.",
+				Value:       config.BcRoutingMode_Fastest,
+			}, {
+				Name:        "Slowest",
+				Description: "Send these requests to whichever client has been responding slower recently, keeping the faster one free for time-critical work.",
+				Value:       config.BcRoutingMode_Slowest,
+			}},
+		},
+
+		HeavyBcRouting: config.Parameter{
+			ID:                   "heavyBcRouting",
+			Name:                 "Heavy Request Routing",
+			Description:          "Select which Consensus client the Smartnode should use for large queries, such as the validator statuses, committees, and blocks downloaded during rewards tree generation, while both your primary and fallback clients are healthy. Sending these to the slower client keeps the faster one responsive for your duties.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.BcRoutingMode_Primary},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Primary",
+				Description: "Always send these requests to your primary client, and only use the fallback if the primary goes offline.",
+				Value:       config.BcRoutingMode_Primary,
+			}, {
+				Name:        "Fastest",
+				Description: "Send these requests to whichever client has been responding faster recently.",
+				Value:       config.BcRoutingMode_Fastest,
+			}, {
+				Name:        "Slowest",
+				Description: "Send these requests to whichever client has been responding slower recently, keeping the faster one free for time-critical work.",
+				Value:       config.BcRoutingMode_Slowest,
+			}},
+		},
+
 		ConsensusClientMode: config.Parameter{
 			ID:                   "consensusClientMode",
 			Name:                 "Consensus Client Mode",
@@ -469,6 +537,8 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
+	cfg.RescueNode = addons.NewRescueNode()
+	cfg.Dvt = addons.NewDistributedValidators()
 
 	// Apply the default values for mainnet
 	cfg.Smartnode.Network.Value = cfg.Smartnode.Network.Options[0].Value
@@ -525,6 +595,8 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExecutionClient,
 		&cfg.UseFallbackClients,
 		&cfg.ReconnectDelay,
+		&cfg.LatencySensitiveBcRouting,
+		&cfg.HeavyBcRouting,
 		&cfg.ConsensusClientMode,
 		&cfg.ConsensusClient,
 		&cfg.ExternalConsensusClient,
@@ -570,6 +642,8 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
+		"addons-rescue-node": cfg.RescueNode.GetConfig(),
+		"addons-dvt":         cfg.Dvt.GetConfig(),
 	}
 }
 
@@ -602,6 +676,11 @@ func (cfg *RocketPoolConfig) ChangeNetwork(newNetwork config.Network) {
 
 }
 
+// Get the URL of the caching Beacon proxy
+func (cfg *RocketPoolConfig) GetBnProxyUrl() string {
+	return fmt.Sprintf("http://%s:%d", BnProxyContainerName, cfg.ConsensusCommon.BnProxyPort.Value)
+}
+
 // Get the configuration for the selected execution client
 func (cfg *RocketPoolConfig) GetEventLogInterval() (int, error) {
 	if cfg.IsNativeMode {
@@ -745,6 +824,7 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 	masterMap[rootConfigName]["rpDir"] = cfg.RocketPoolDirectory
 	masterMap[rootConfigName]["isNative"] = fmt.Sprint(cfg.IsNativeMode)
 	masterMap[rootConfigName]["version"] = fmt.Sprintf("v%s", shared.RocketPoolVersion) // Update the version with the current Smartnode version
+	masterMap[rootConfigName][migration.SchemaVersionKey] = fmt.Sprint(migration.GetCurrentSchemaVersion())
 
 	// Serialize the subconfigs
 	for name, subconfig := range cfg.GetSubconfigs() {
@@ -761,11 +841,13 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 // Deserializes a settings file into this config
 func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string) error {
 
-	// Upgrade the config to the latest version
-	err := migration.UpdateConfig(masterMap)
+	// Upgrade the config to the latest schema
+	result, err := migration.UpdateConfig(masterMap)
 	if err != nil {
 		return fmt.Errorf("error upgrading configuration to v%s: %w", shared.RocketPoolVersion, err)
 	}
+	cfg.SchemaVersion = result.ToSchemaVersion
+	cfg.AppliedMigrations = result.Applied
 
 	// Get the network
 	network := config.Network_Mainnet
@@ -811,9 +893,44 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 		}
 	}
 
+	// Report anything the migrations didn't carry over, since it won't be saved again
+	cfg.UnrecognizedSettings = cfg.getUnrecognizedSettings(masterMap)
+
 	return nil
 }
 
+// Get the settings in a serialized config that don't belong to any parameter, in "section.id" form
+func (cfg *RocketPoolConfig) getUnrecognizedSettings(masterMap map[string]map[string]string) []string {
+	knownSettings := map[string]map[string]bool{
+		rootConfigName: {
+			"rpDir":                    true,
+			"isNative":                 true,
+			"version":                  true,
+			migration.SchemaVersionKey: true,
+		},
+	}
+	for _, param := range cfg.GetParameters() {
+		knownSettings[rootConfigName][param.ID] = true
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		knownSettings[name] = map[string]bool{}
+		for _, param := range subconfig.GetParameters() {
+			knownSettings[name][param.ID] = true
+		}
+	}
+
+	unrecognized := []string{}
+	for name, section := range masterMap {
+		for id := range section {
+			if !knownSettings[name][id] {
+				unrecognized = append(unrecognized, fmt.Sprintf("%s.%s", name, id))
+			}
+		}
+	}
+	sort.Strings(unrecognized)
+	return unrecognized
+}
+
 // Generates a collection of environment variables based on this config's settings
 func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 
@@ -924,7 +1041,8 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	identifier := ""
 	versionString := fmt.Sprintf("v%s", shared.RocketPoolVersion)
 	envVars["ROCKET_POOL_VERSION"] = versionString
-	if len(versionString) < 8 {
+	clientDiversityGraffiti := cfg.Smartnode.EnableClientDiversityGraffiti.Value == true
+	if len(versionString) < 8 || clientDiversityGraffiti {
 		ecInitial := strings.ToUpper(string(envVars["EC_CLIENT"][0]))
 
 		var ccInitial string
@@ -941,6 +1059,17 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	envVars["GRAFFITI_PREFIX"] = graffitiPrefix
 
 	customGraffiti := envVars[CustomGraffitiEnvVar]
+	if clientDiversityGraffiti {
+		// The client tag always takes priority, so trim the custom graffiti if it would overflow
+		maxCustomLength := maxGraffitiLength - len(graffitiPrefix) - len(" ()")
+		if maxCustomLength < 0 {
+			maxCustomLength = 0
+		}
+		for len(customGraffiti) > maxCustomLength {
+			_, size := utf8.DecodeLastRuneInString(customGraffiti)
+			customGraffiti = customGraffiti[:len(customGraffiti)-size]
+		}
+	}
 	if customGraffiti == "" {
 		envVars["GRAFFITI"] = graffitiPrefix
 	} else {
@@ -953,6 +1082,23 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		envVars["CC_HOSTNAME"] = ccUrl.Hostname()
 	}
 
+	// Route the VC through the caching Beacon proxy if enabled
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ConsensusCommon.EnableBnProxy.Value == true {
+		envVars["BN_PROXY_UPSTREAM"] = envVars["CC_API_ENDPOINT"]
+		envVars["CC_API_ENDPOINT"] = cfg.GetBnProxyUrl()
+	}
+
+	// Point the VC at Web3Signer if the keys live there
+	if cfg.Smartnode.UseWeb3Signer.Value == true {
+		signerUrl, err := cfg.Smartnode.GetWeb3SignerUrl()
+		if err == nil {
+			signerFlags := getWeb3SignerVcFlags(consensusClient, signerUrl)
+			if signerFlags != "" {
+				envVars["VC_ADDITIONAL_FLAGS"] = strings.TrimSpace(envVars["VC_ADDITIONAL_FLAGS"] + " " + signerFlags)
+			}
+		}
+	}
+
 	// Fallback parameters
 	if cfg.UseFallbackClients.Value == true {
 		switch consensusClient {
@@ -1009,6 +1155,8 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 
 	// Addons
 	cfg.GraffitiWallWriter.UpdateEnvVars(envVars)
+	cfg.RescueNode.UpdateEnvVars(envVars)
+	cfg.Dvt.UpdateEnvVars(envVars)
 
 	return envVars
 
@@ -1132,6 +1280,130 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Ensure the node HTTP API can't be accessed without a token
+	if cfg.Smartnode.EnableNodeApi.Value == true && cfg.Smartnode.NodeApiToken.Value.(string) == "" {
+		errors = append(errors, "You have the node HTTP API enabled but don't have an API token set. Please enter a token to secure the API, or disable it.")
+	}
+	if calendarToken := cfg.Smartnode.NodeApiCalendarToken.Value.(string); calendarToken != "" && calendarToken == cfg.Smartnode.NodeApiToken.Value.(string) {
+		errors = append(errors, "Your node HTTP API calendar token is the same as your API token. Please use a different calendar token, since it's passed in the calendar URL and only gives access to the calendar.")
+	}
+	if cfg.Smartnode.EnableMetricsStream.Value == true {
+		if cfg.EnableMetrics.Value == false {
+			errors = append(errors, "You have the metrics stream enabled but metrics are disabled. Please enable metrics to use the stream, or disable it.")
+		}
+		if cfg.Smartnode.NodeApiToken.Value.(string) == "" {
+			errors = append(errors, "You have the metrics stream enabled but don't have a node HTTP API token set. Please enter a token to secure the stream, or disable it.")
+		}
+	}
+
+	if cfg.Smartnode.EnableStatsHistory.Value == true && cfg.EnableMetrics.Value == false {
+		errors = append(errors, "You have the stats history enabled but metrics are disabled. Please enable metrics to record the history, or disable it.")
+	}
+	if cfg.Smartnode.ProvisionGrafanaDashboard.Value == true && cfg.EnableMetrics.Value == false {
+		errors = append(errors, "You have dashboard provisioning enabled but metrics are disabled. Please enable metrics to generate the dashboard, or disable it.")
+	}
+
+	// Ensure there's a usable Web3Signer URL
+	if cfg.Smartnode.UseWeb3Signer.Value == true {
+		if _, err := cfg.Smartnode.GetWeb3SignerUrl(); err != nil {
+			errors = append(errors, fmt.Sprintf("You have Web3Signer enabled but its URL is invalid: %s", err.Error()))
+		}
+	}
+
+	// Ensure the task interval is usable
+	if _, err := cfg.Smartnode.GetTaskInterval(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your task interval is invalid: %s", err.Error()))
+	}
+
+	// Ensure the monitored nodes are valid addresses
+	if _, err := cfg.Smartnode.GetMonitoredNodes(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your monitored nodes are invalid: %s", err.Error()))
+	}
+
+	// Ensure the maintenance windows are well-formed
+	if _, err := cfg.Smartnode.GetMaintenanceWindows(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your maintenance windows are invalid: %s", err.Error()))
+	}
+
+	// Ensure the graffiti rotation interval is valid
+	if _, err := cfg.Smartnode.GetGraffitiRotationInterval(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your graffiti rotation interval is invalid: %s", err.Error()))
+	}
+
+	// Ensure the contract address overrides are well-formed
+	if _, err := cfg.Smartnode.GetContractAddressOverrides(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your contract address overrides are invalid: %s", err.Error()))
+	}
+
+	// Ensure the transaction presets are well-formed
+	if _, err := cfg.Smartnode.GetTransactionPresets(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your transaction presets are invalid: %s", err.Error()))
+	}
+
+	// Ensure the per-task gas ceilings are well-formed
+	if _, err := cfg.Smartnode.GetTaskGasCeilings(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your per-task gas ceilings are invalid: %s", err.Error()))
+	}
+
+	// Ensure the outbound HTTP proxy settings are well-formed
+	if _, err := cfg.Smartnode.GetHttpClientSettings(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your HTTP proxy settings are invalid: %s", err.Error()))
+	}
+
+	// Ensure the per-module log levels are well-formed
+	if _, err := cfg.Smartnode.GetLogSettings("node"); err != nil {
+		errors = append(errors, fmt.Sprintf("Your log settings are invalid: %s", err.Error()))
+	}
+
+	// Ensure the auto-vote delegate is an address
+	if autoVoteDelegate, ok := cfg.Smartnode.PdaoAutoVoteDelegate.Value.(string); ok && autoVoteDelegate != "" && !common.IsHexAddress(autoVoteDelegate) {
+		errors = append(errors, fmt.Sprintf("The auto-vote delegate [%s] is not a valid address.", autoVoteDelegate))
+	}
+
+	// Ensure the archive EC endpoints are URLs
+	for _, url := range cfg.Smartnode.GetArchiveEcUrls() {
+		if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "wss://") && !strings.HasPrefix(url, "ws://") {
+			errors = append(errors, fmt.Sprintf("The archive EC URL [%s] is not a valid URL. Please make sure it starts with http://, https://, ws://, or wss://.", url))
+		}
+	}
+
+	// Ensure the rewards file sources are HTTP(S) URLs
+	for _, url := range append(splitUrlList(cfg.Smartnode.RewardsFileIpfsGateways.Value.(string)), splitUrlList(cfg.Smartnode.RewardsFileMirrors.Value.(string))...) {
+		if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+			errors = append(errors, fmt.Sprintf("The rewards file source [%s] is not a valid URL. Please make sure it starts with https://.", url))
+		}
+	}
+
+	// Ensure the auto-claim settings are in range
+	if cfg.Smartnode.EnableAutoClaim.Value == true {
+		restakePercent := cfg.Smartnode.AutoClaimRestakePercent.Value.(float64)
+		if restakePercent < 0 || restakePercent > 100 {
+			errors = append(errors, fmt.Sprintf("The auto-claim restake percent must be between 0 and 100 (it is currently %.2f).", restakePercent))
+		}
+		if cfg.Smartnode.AutoClaimRplThreshold.Value.(float64) <= 0 && cfg.Smartnode.AutoClaimEthThreshold.Value.(float64) <= 0 {
+			errors = append(errors, "You have auto-claim enabled but both of its thresholds are 0, so it will never claim. Please set an RPL or ETH threshold, or disable auto-claim.")
+		}
+		forwardAddress := cfg.Smartnode.AutoClaimForwardAddress.Value.(string)
+		if forwardAddress != "" && !common.IsHexAddress(forwardAddress) {
+			errors = append(errors, fmt.Sprintf("The auto-claim forward address [%s] is not a valid address.", forwardAddress))
+		}
+		forwardPercent := cfg.Smartnode.AutoClaimForwardPercent.Value.(float64)
+		if forwardPercent < 0 || forwardPercent > 100 {
+			errors = append(errors, fmt.Sprintf("The auto-claim forward percent must be between 0 and 100 (it is currently %.2f).", forwardPercent))
+		}
+		if cfg.Smartnode.AutoClaimOperatingFloat.Value.(float64) < 0 {
+			errors = append(errors, "The auto-claim operating float can't be negative.")
+		}
+	}
+
+	// Ensure the expected withdrawal addresses are addresses
+	for _, param := range []*config.Parameter{&cfg.Smartnode.ExpectedWithdrawalAddress, &cfg.Smartnode.ExpectedRplWithdrawalAddress} {
+		address := param.Value.(string)
+		if address != "" && !common.IsHexAddress(address) {
+			errors = append(errors, fmt.Sprintf("The %s [%s] is not a valid address.", strings.ToLower(param.Name), address))
+		}
+	}
+
 	return errors
 }
 
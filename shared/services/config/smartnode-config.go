@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 // Constants
@@ -25,6 +29,29 @@ const (
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	NodeCrashCounterFile               string = "node-crash-counter.yml"
+	PendingForwardsFile                string = "pending-forwards.yml"
+	NetworkStateSnapshotFile           string = "network-state.json"
+	NodeConfigReloadLogFile            string = "node-config-reloads.log"
+	EndpointAccessLogFormat            string = "endpoint-access-%s.log"
+	DaemonLogsFolder                   string = "logs"
+	DaemonLogFormat                    string = "%s.log"
+	ValidatorIndexCacheFile            string = "validator-indices.json"
+	ProtocolSettingsSnapshotFile       string = "protocol-settings.json"
+	StatsHistoryFile                   string = "stats-history.jsonl"
+	MetricsStreamCertFile              string = "metrics-stream-cert.pem"
+	MetricsStreamKeyFile               string = "metrics-stream-key.pem"
+	GrafanaDashboardFile               string = "grafana-dashboards/rocketpool-generated.json"
+	EventJournalFile                   string = "events.jsonl"
+	BalanceHistoryFile                 string = "balance-history.jsonl"
+	ValidatorGraffitiFile              string = "validator-graffiti.yml"
+	KeymanagerApiTokenFile             string = "keymanager-api-token.txt"
+	MinipoolLabelsFile                 string = "minipool-labels.yml"
+	MinipoolTagsFile                   string = "minipool-tags.yml"
+	MasqueradeAddressFile              string = "masquerade-address"
+	TransactorKeyFile                  string = "transactor-key.json"
+	SlashingProtectionFile             string = "slashing-protection.json"
+	DvtFolder                          string = "dvt"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
@@ -35,9 +62,16 @@ const (
 
 // Defaults
 const (
-	defaultProjectName       string = "rocketpool"
-	WatchtowerMaxFeeDefault  uint64 = 200
-	WatchtowerPrioFeeDefault uint64 = 3
+	defaultProjectName            string = "rocketpool"
+	defaultNodeApiPort            uint16 = 9110
+	defaultMetricsStreamPort      uint16 = 9111
+	defaultSafeModeCrashThreshold uint16 = 5
+	defaultTaskInterval           string = "5m"
+	defaultGraffitiRotation       string = "24h"
+	defaultKeymanagerApiPort      uint16 = 5062
+	minimumTaskInterval                  = time.Minute
+	WatchtowerMaxFeeDefault       uint64 = 200
+	WatchtowerPrioFeeDefault      uint64 = 3
 )
 
 // Configuration for the Smartnode
@@ -69,18 +103,96 @@ type SmartnodeConfig struct {
 	// Manual priority fee override
 	PriorityFee config.Parameter `yaml:"priorityFee,omitempty"`
 
+	// Named bundles of transaction settings for the CLI
+	TransactionPresets config.Parameter `yaml:"transactionPresets,omitempty"`
+
+	// Where to get gas price suggestions from
+	GasOracle config.Parameter `yaml:"gasOracle,omitempty"`
+
+	// Max fee ceilings for individual automatic tasks
+	TaskGasCeilings config.Parameter `yaml:"taskGasCeilings,omitempty"`
+
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// Toggle for automatically refunding minipools
+	EnableAutoRefund config.Parameter `yaml:"enableAutoRefund,omitempty"`
+
+	// The combined refund balance of the node's minipools before auto-refund kicks in
+	AutoRefundThreshold config.Parameter `yaml:"autoRefundThreshold,omitempty"`
+
+	// The fee distributor balance that triggers an automatic distribution
+	FeeDistributorSweepThreshold config.Parameter `yaml:"feeDistributorSweepThreshold,omitempty"`
+
+	// The address to forward the node share of each fee distributor sweep to
+	FeeDistributorSweepForwardAddress config.Parameter `yaml:"feeDistributorSweepForwardAddress,omitempty"`
+
+	// Toggle for automatically claiming rewards after each checkpoint
+	EnableAutoClaim config.Parameter `yaml:"enableAutoClaim,omitempty"`
+
+	// The amount of unclaimed RPL that triggers an automatic claim
+	AutoClaimRplThreshold config.Parameter `yaml:"autoClaimRplThreshold,omitempty"`
+
+	// The amount of unclaimed Smoothing Pool ETH that triggers an automatic claim
+	AutoClaimEthThreshold config.Parameter `yaml:"autoClaimEthThreshold,omitempty"`
+
+	// The percentage of automatically claimed RPL to restake
+	AutoClaimRestakePercent config.Parameter `yaml:"autoClaimRestakePercent,omitempty"`
+
+	// The gas price ceiling for automatic claims
+	AutoClaimMaxFee config.Parameter `yaml:"autoClaimMaxFee,omitempty"`
+
+	// The cold address to forward part of each automatic claim to
+	AutoClaimForwardAddress config.Parameter `yaml:"autoClaimForwardAddress,omitempty"`
+
+	// The percentage of each automatic claim to forward
+	AutoClaimForwardPercent config.Parameter `yaml:"autoClaimForwardPercent,omitempty"`
+
+	// The ETH to keep on the node wallet for gas when forwarding
+	AutoClaimOperatingFloat config.Parameter `yaml:"autoClaimOperatingFloat,omitempty"`
+
+	// Toggle for automatically correcting the validator client's fee recipient
+	AutoCorrectFeeRecipient config.Parameter `yaml:"autoCorrectFeeRecipient,omitempty"`
+
+	// The withdrawal address the node is expected to have
+	ExpectedWithdrawalAddress config.Parameter `yaml:"expectedWithdrawalAddress,omitempty"`
+
+	// The RPL withdrawal address the node is expected to have
+	ExpectedRplWithdrawalAddress config.Parameter `yaml:"expectedRplWithdrawalAddress,omitempty"`
+
+	// A signature from the withdrawal address proving the node operator controls it
+	WithdrawalAddressProof config.Parameter `yaml:"withdrawalAddressProof,omitempty"`
+
+	// Toggle for adding the client diversity tag to the validator's graffiti
+	EnableClientDiversityGraffiti config.Parameter `yaml:"enableClientDiversityGraffiti,omitempty"`
+
+	// The number of recent restarts of the node daemon before it starts in safe mode
+	SafeModeCrashThreshold config.Parameter `yaml:"safeModeCrashThreshold,omitempty"`
+
+	// The delay between each pass of the node daemon's task loop
+	TaskInterval config.Parameter `yaml:"taskInterval,omitempty"`
+
+	// The default location to upload encrypted wallet backups to
+	WalletBackupDestination config.Parameter `yaml:"walletBackupDestination,omitempty"`
+
+	// The address whose protocol DAO votes the node daemon copies
+	PdaoAutoVoteDelegate config.Parameter `yaml:"pdaoAutoVoteDelegate,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// Additional IPFS gateways to download rewards tree files from
+	RewardsFileIpfsGateways config.Parameter `yaml:"rewardsFileIpfsGateways,omitempty"`
+
+	// HTTPS mirrors to download rewards tree files from
+	RewardsFileMirrors config.Parameter `yaml:"rewardsFileMirrors,omitempty"`
+
 	// Token for Oracle DAO members to use when uploading Merkle trees to Web3.Storage
 	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty"`
 
@@ -90,12 +202,126 @@ type SmartnodeConfig struct {
 	// Manual override for the watchtower's priority fee
 	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
 
+	// The window the watchtower picks a random delay from before each submission
+	WatchtowerSubmissionDelayWindow config.Parameter `yaml:"watchtowerSubmissionDelayWindow,omitempty"`
+
 	// The epoch to switch over to TWAP for RPL price reporting
 	RplTwapEpoch config.Parameter `yaml:"rplTwapEpoch,omitempty"`
 
 	// The epoch to start using the new network balance calculation implementation
 	BalancesModernizationEpoch config.Parameter `yaml:"balancesModernizationEpoch,omitempty"`
 
+	// Manual overrides for the built-in contract addresses
+	ContractAddressOverrides config.Parameter `yaml:"contractAddressOverrides,omitempty"`
+
+	// Toggle for the node daemon's HTTP API
+	EnableNodeApi config.Parameter `yaml:"enableNodeApi,omitempty"`
+
+	// The port for the node daemon's HTTP API
+	NodeApiPort config.Parameter `yaml:"nodeApiPort,omitempty"`
+
+	// The bearer token required to access the node daemon's HTTP API
+	NodeApiToken config.Parameter `yaml:"nodeApiToken,omitempty"`
+
+	// The read-only token that calendar apps can pass in the URL to subscribe to the duties calendar
+	NodeApiCalendarToken config.Parameter `yaml:"nodeApiCalendarToken,omitempty"`
+
+	// Toggle for the node daemon's gRPC metrics stream
+	EnableMetricsStream config.Parameter `yaml:"enableMetricsStream,omitempty"`
+
+	// The port for the node daemon's gRPC metrics stream
+	MetricsStreamPort config.Parameter `yaml:"metricsStreamPort,omitempty"`
+
+	// Toggle for serving the metrics stream over TLS so it can be reached from other machines
+	EnableMetricsStreamTls config.Parameter `yaml:"enableMetricsStreamTls,omitempty"`
+
+	// Other nodes to include in the node metrics, for monitoring a fleet from one node
+	MonitoredNodes config.Parameter `yaml:"monitoredNodes,omitempty"`
+
+	// Toggle for recording the node's key stats locally and serving them to Grafana as a JSON datasource
+	EnableStatsHistory config.Parameter `yaml:"enableStatsHistory,omitempty"`
+
+	// The number of days of stats history to keep
+	StatsHistoryRetentionDays config.Parameter `yaml:"statsHistoryRetentionDays,omitempty"`
+
+	// Toggle for writing the dashboard generated from the node metrics to the data folder for Grafana to provision
+	ProvisionGrafanaDashboard config.Parameter `yaml:"provisionGrafanaDashboard,omitempty"`
+
+	// Planned periods when the node will be offline for maintenance
+	MaintenanceWindows config.Parameter `yaml:"maintenanceWindows,omitempty"`
+
+	// Toggle for managing the validators' graffiti through the Validator client's keymanager API
+	EnableGraffitiManagement config.Parameter `yaml:"enableGraffitiManagement,omitempty"`
+
+	// The template for the validators' graffiti
+	GraffitiTemplate config.Parameter `yaml:"graffitiTemplate,omitempty"`
+
+	// Messages the graffiti rotates through
+	GraffitiMessages config.Parameter `yaml:"graffitiMessages,omitempty"`
+
+	// How long each graffiti message is used before moving to the next one
+	GraffitiRotationInterval config.Parameter `yaml:"graffitiRotationInterval,omitempty"`
+
+	// A name for the node that can be used in the graffiti
+	NodeNickname config.Parameter `yaml:"nodeNickname,omitempty"`
+
+	// The URL of the Validator client's keymanager API
+	KeymanagerApiUrl config.Parameter `yaml:"keymanagerApiUrl,omitempty"`
+
+	// Toggle for adding the minipool labels to the metrics
+	EnableMinipoolLabelsInMetrics config.Parameter `yaml:"enableMinipoolLabelsInMetrics,omitempty"`
+
+	// Toggle for reporting the minipool tags as metrics
+	EnableMinipoolTagsInMetrics config.Parameter `yaml:"enableMinipoolTagsInMetrics,omitempty"`
+
+	// Toggle for keeping validator keys in Web3Signer instead of local keystores
+	UseWeb3Signer config.Parameter `yaml:"useWeb3Signer,omitempty"`
+
+	// The URL of the Web3Signer remote signer
+	Web3SignerUrl config.Parameter `yaml:"web3SignerUrl,omitempty"`
+
+	// Where the metrics get fiat prices from
+	FiatPriceSource config.Parameter `yaml:"fiatPriceSource,omitempty"`
+
+	// The fiat currency the metrics report values in
+	FiatCurrency config.Parameter `yaml:"fiatCurrency,omitempty"`
+
+	// The URL of a custom fiat price source
+	FiatPriceUrl config.Parameter `yaml:"fiatPriceUrl,omitempty"`
+
+	// The proxy for outbound HTTP connections
+	HttpProxy config.Parameter `yaml:"httpProxy,omitempty"`
+
+	// The IP version for outbound HTTP connections
+	HttpIpMode config.Parameter `yaml:"httpIpMode,omitempty"`
+
+	// Per-endpoint proxy overrides
+	HttpProxyOverrides config.Parameter `yaml:"httpProxyOverrides,omitempty"`
+
+	// Toggle for recording every request the daemons send to the Execution and Beacon clients
+	EnableEndpointAccessLog config.Parameter `yaml:"enableEndpointAccessLog,omitempty"`
+
+	// The format of the daemons' logs
+	LogFormat config.Parameter `yaml:"logFormat,omitempty"`
+
+	// The lowest severity of the messages the daemons log
+	LogLevel config.Parameter `yaml:"logLevel,omitempty"`
+
+	// Per-module log levels
+	LogModuleLevels config.Parameter `yaml:"logModuleLevels,omitempty"`
+
+	// The size in MB the daemons' log files are rotated at; 0 disables the log files
+	LogFileMaxSize config.Parameter `yaml:"logFileMaxSize,omitempty"`
+
+	// How many rotated log files are kept
+	LogFileMaxBackups config.Parameter `yaml:"logFileMaxBackups,omitempty"`
+
+	// The number of validators to request from the Beacon node at once
+	ValidatorStatusBatchSize config.Parameter `yaml:"validatorStatusBatchSize,omitempty"`
+
+	// The number of validator status requests to have in flight at once
+	ValidatorStatusConcurrency config.Parameter `yaml:"validatorStatusConcurrency,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -127,6 +353,9 @@ type SmartnodeConfig struct {
 	// The Snapshot API domain
 	snapshotApiDomain map[config.Network]string `yaml:"-"`
 
+	// The URL of the Snapshot sequencer that signed votes are sent to
+	snapshotSequencerUrl map[config.Network]string `yaml:"-"`
+
 	// The contract address of rETH
 	rethAddress map[config.Network]string `yaml:"-"`
 
@@ -178,6 +407,9 @@ type SmartnodeConfig struct {
 	// The UniswapV3 pool address for each network (used for RPL price TWAP info)
 	rplTwapPoolAddress map[config.Network]string `yaml:"-"`
 
+	// The Chainlink ETH/USD price feed address for each network (used for fiat metrics)
+	chainlinkEthUsdFeedAddress map[config.Network]string `yaml:"-"`
+
 	// The multicall contract address
 	multicallAddress map[config.Network]string `yaml:"-"`
 
@@ -268,6 +500,51 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		TransactionPresets: config.Parameter{
+			ID:                   "transactionPresets",
+			Name:                 "Transaction Presets",
+			Description:          "Named bundles of transaction settings that you can select on any CLI command with `--preset <name>`, instead of repeating the same flags. Presets are separated by semicolons, and each one takes the form `name: setting=value, setting=value`. For example:\n\n`urgent: maxFee=80, prioFee=3; overnight: maxFee=15, prioFee=0.5, deadline=12h; private: route=private`\n\nSupported settings:\n- `maxFee`: the max fee in gwei\n- `prioFee`: the max priority fee in gwei\n- `route`: `public` to submit through your Execution client, or `private` to submit through the Flashbots Protect RPC so the transaction isn't visible in the public mempool\n- `deadline`: how long the CLI should wait for the transaction to be included before giving up, such as `30m`\n\nThe --maxFee and --maxPrioFee flags take precedence over a preset's fees.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GasOracle: config.Parameter{
+			ID:                   "gasOracle",
+			Name:                 "Gas Oracle",
+			Description:          "Select where the Smartnode gets its gas price suggestions from, for both the CLI's suggested max fees and the node's automatic transactions.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.GasOracle_ExecutionClient},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Execution Client",
+				Description: "Derive EIP-1559 fee suggestions from the base fees and priority fees of recent blocks, using your Execution client's fee history. This doesn't rely on any third-party service. If your Execution client can't provide the fee history, the Smartnode will fall back to the external oracles.",
+				Value:       config.GasOracle_ExecutionClient,
+			}, {
+				Name:        "External",
+				Description: "Use the suggestions from the beaconcha.in gas tracker, falling back to Etherscan and then your Execution client's fee history if they aren't available.",
+				Value:       config.GasOracle_External,
+			}},
+		},
+
+		TaskGasCeilings: config.Parameter{
+			ID:                   "taskGasCeilings",
+			Name:                 "Per-Task Gas Ceilings",
+			Description:          "Max fee ceilings (in gwei) for individual automatic tasks, overriding the Automatic TX Gas Threshold for those tasks. The node will defer a task's transactions until the suggested max fee falls below its ceiling. Entries are separated by commas and take the form `task=gwei`, for example:\n\n`distribute-minipools=15, refund-minipools=20, reduce-bonds=40`\n\nSupported tasks: " + strings.Join(TaskGasCeilingTasks, ", ") + ".\n\nA ceiling of 0 disables that task's non-essential transactions, just like the Automatic TX Gas Threshold.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		AutoTxGasThreshold: config.Parameter{
 			ID:   "minipoolStakeGasThreshold",
 			Name: "Automatic TX Gas Threshold",
@@ -282,117 +559,905 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
-		DistributeThreshold: config.Parameter{
-			ID:                   "distributeThreshold",
-			Name:                 "Auto-Distribute Threshold",
-			Description:          "The Smartnode will regularly check the balance of each of your minipools on the Execution Layer (**not** the Beacon Chain).\nIf any of them have a balance greater than this threshold (in ETH), the Smartnode will automatically distribute the balance. This will send your share of the balance to your withdrawal address.\n\nMust be less than 8 ETH.\n\nSet this to 0 to disable automatic distributes.\n[orange]WARNING: if you disable automatic distribution, you **must** ensure you distribute your minipool's balance before it reaches 8 ETH or you will no longer be able to distribute your rewards until you exit the minipool!",
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(1)},
+		DistributeThreshold: config.Parameter{
+			ID:                   "distributeThreshold",
+			Name:                 "Auto-Distribute Threshold",
+			Description:          "The Smartnode will regularly check the balance of each of your minipools on the Execution Layer (**not** the Beacon Chain).\nIf any of them have a balance greater than this threshold (in ETH), the Smartnode will automatically distribute the balance. This will send your share of the balance to your withdrawal address.\n\nMust be less than 8 ETH.\n\nSet this to 0 to disable automatic distributes.\n[orange]WARNING: if you disable automatic distribution, you **must** ensure you distribute your minipool's balance before it reaches 8 ETH or you will no longer be able to distribute your rewards until you exit the minipool!",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableAutoRefund: config.Parameter{
+			ID:                   "enableAutoRefund",
+			Name:                 "Enable Auto-Refund",
+			Description:          "Enable this to have the Smartnode periodically check your minipools for ETH that belongs to you (their node refund balance), and automatically refund it to your withdrawal address once the combined amount reaches the Auto-Refund Threshold.\n\nRefunds will only be sent when the network's gas price is below the Automatic TX Gas Threshold.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoRefundThreshold: config.Parameter{
+			ID:                   "autoRefundThreshold",
+			Name:                 "Auto-Refund Threshold",
+			Description:          "The combined node refund balance (in ETH) across all of your minipools that must be reached before the Smartnode automatically refunds them. Only used if Auto-Refund is enabled.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FeeDistributorSweepThreshold: config.Parameter{
+			ID:                   "feeDistributorSweepThreshold",
+			Name:                 "Fee Distributor Sweep Threshold",
+			Description:          "The Smartnode will regularly check the balance of your node's fee distributor. If it's greater than this threshold (in ETH) and the gas price is below the Automatic TX Gas Threshold, the Smartnode will automatically distribute it. This will send your share of the balance to your withdrawal address and the rest to the rETH contract.\n\nSet this to 0 to disable automatic fee distributor sweeps.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FeeDistributorSweepForwardAddress: config.Parameter{
+			ID:                   "feeDistributorSweepForwardAddress",
+			Name:                 "Fee Distributor Sweep Forward Address",
+			Description:          "A cold address to forward your share of each automatic fee distributor sweep to. This only applies when your withdrawal address is your node wallet, since your share is sent straight to your withdrawal address otherwise.\n\nLeave this blank to keep your share on your node wallet. Only used if the Fee Distributor Sweep Threshold is set.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableAutoClaim: config.Parameter{
+			ID:                   "enableAutoClaim",
+			Name:                 "Enable Auto-Claim Rewards",
+			Description:          "Enable this to have the Smartnode automatically claim your rewards after each rewards checkpoint, once your unclaimed RPL or Smoothing Pool ETH reaches its Auto-Claim Threshold. Claimed ETH and any RPL that isn't restaked are sent to your withdrawal address.\n\nClaims will only be sent when the network's gas price is below the Auto-Claim Gas Ceiling.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimRplThreshold: config.Parameter{
+			ID:                   "autoClaimRplThreshold",
+			Name:                 "Auto-Claim RPL Threshold",
+			Description:          "The amount of unclaimed RPL (collateral and Oracle DAO rewards combined) that will trigger an automatic claim. Any Smoothing Pool ETH from the same intervals is claimed along with it.\n\nSet this to 0 to never claim just because of RPL rewards. Only used if Auto-Claim is enabled.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimEthThreshold: config.Parameter{
+			ID:                   "autoClaimEthThreshold",
+			Name:                 "Auto-Claim ETH Threshold",
+			Description:          "The amount of unclaimed Smoothing Pool ETH that will trigger an automatic claim. Any RPL from the same intervals is claimed along with it.\n\nSet this to 0 to never claim just because of Smoothing Pool rewards. Only used if Auto-Claim is enabled.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimRestakePercent: config.Parameter{
+			ID:                   "autoClaimRestakePercent",
+			Name:                 "Auto-Claim Restake Percent",
+			Description:          "The percentage (0 to 100) of the RPL from each automatic claim that will be restaked on your node instead of being sent to your withdrawal address. Smoothing Pool ETH is never restaked.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimMaxFee: config.Parameter{
+			ID:                   "autoClaimMaxFee",
+			Name:                 "Auto-Claim Gas Ceiling",
+			Description:          "The highest gas price (in gwei) the Smartnode will pay for an automatic claim. If the network's gas price is above this, the claim will wait until it drops.\n\nSet this to 0 to use the Automatic TX Gas Threshold instead.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimForwardAddress: config.Parameter{
+			ID:                   "autoClaimForwardAddress",
+			Name:                 "Auto-Claim Forward Address",
+			Description:          "A cold address to forward part of each automatic claim to, so only an operating float stays on your node wallet. This only applies when your withdrawal address is your node wallet, since the rewards are sent straight to your withdrawal address otherwise.\n\nLeave this blank to keep the claimed rewards on your node wallet. Only used if Auto-Claim is enabled.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimForwardPercent: config.Parameter{
+			ID:                   "autoClaimForwardPercent",
+			Name:                 "Auto-Claim Forward Percent",
+			Description:          "The percentage (0 to 100) of the claimed Smoothing Pool ETH and of the claimed RPL that isn't restaked to forward to the Auto-Claim Forward Address after each automatic claim.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimOperatingFloat: config.Parameter{
+			ID:                   "autoClaimOperatingFloat",
+			Name:                 "Auto-Claim Operating Float",
+			Description:          "The amount of ETH to always leave on your node wallet for gas when forwarding claimed ETH. Less ETH is forwarded if sending the full percentage would take your node wallet below this.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoCorrectFeeRecipient: config.Parameter{
+			ID:                   "autoCorrectFeeRecipient",
+			Name:                 "Auto-Correct Fee Recipient",
+			Description:          "When enabled, the Smartnode will automatically fix your Validator Client's fee recipient and restart it if the fee recipient doesn't match your fee distributor or the Smoothing Pool.\n\n[orange]WARNING: If you disable this, the Smartnode will only warn you about an incorrect fee recipient. Proposing blocks with the wrong fee recipient will get you penalized!",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExpectedWithdrawalAddress: config.Parameter{
+			ID:                   "expectedWithdrawalAddress",
+			Name:                 "Expected Withdrawal Address",
+			Description:          "The withdrawal address you expect your node to have. If this is set, the Smartnode will refuse to exit, close, or distribute the balance of any minipool while your node's withdrawal address (or a pending change to it) is anything else.\n\nLeave this blank to skip the check.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExpectedRplWithdrawalAddress: config.Parameter{
+			ID:                   "expectedRplWithdrawalAddress",
+			Name:                 "Expected RPL Withdrawal Address",
+			Description:          "The RPL withdrawal address you expect your node to have. If your node doesn't have a separate RPL withdrawal address, its primary withdrawal address is used for RPL, so that is what will be checked.\n\nLeave this blank to skip the check.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WithdrawalAddressProof: config.Parameter{
+			ID:                   "withdrawalAddressProof",
+			Name:                 "Withdrawal Address Proof",
+			Description:          "An optional signature from your withdrawal address, proving that you control it. Sign the message shown by `rocketpool node withdrawal-safety` with your withdrawal address's wallet and paste the signature here.\n\nIf this is set, the Smartnode will refuse to exit, close, or distribute the balance of any minipool unless your node's withdrawal address is the one that made the signature.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableClientDiversityGraffiti: config.Parameter{
+			ID:                   "enableClientDiversityGraffiti",
+			Name:                 "Client Diversity Graffiti",
+			Description:          "Enable this to always include a standardized tag for your Execution and Consensus clients (such as `RP-GL` for Geth and Lighthouse) in the graffiti of the blocks you propose, along with your Smartnode version. This helps the community measure client diversity across Rocket Pool.\n\nIf your custom graffiti doesn't fit alongside the tag, it will be shortened.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SafeModeCrashThreshold: config.Parameter{
+			ID:                   "safeModeCrashThreshold",
+			Name:                 "Safe Mode Crash Threshold",
+			Description:          "If the node daemon restarts this many times within a short period, it will start in safe mode: all automatic tasks (such as staking, distributing, and fee recipient management) are disabled, and only the metrics and HTTP API stay active. This gives you a chance to fix a bad configuration or a corrupted cache without the node repeatedly submitting transactions.\n\nSet this to 0 to disable safe mode.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultSafeModeCrashThreshold},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TaskInterval: config.Parameter{
+			ID:                   "taskInterval",
+			Name:                 "Task Interval",
+			Description:          "The delay between each pass of the node daemon's automatic tasks, such as staking prelaunch minipools and claiming rewards. An example format is \"10m30s\" - this would make it 10 minutes and 30 seconds. The minimum is 1 minute.\n\nThis can be changed without restarting the node daemon by reloading its configuration.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletBackupDestination: config.Parameter{
+			ID:                   "walletBackupDestination",
+			Name:                 "Wallet Backup Destination",
+			Description:          "The default location for `rocketpool wallet backup` to upload encrypted wallet backups to. This can be a local directory (such as a mounted USB drive), an S3-compatible bucket in the form `s3://endpoint/bucket/path`, or a directory on an SFTP server in the form `sftp://user@host:port/path`.\n\nS3 credentials are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables, and SFTP uses your SSH keys.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PdaoAutoVoteDelegate: config.Parameter{
+			ID:                   "pdaoAutoVoteDelegate",
+			Name:                 "Auto-Vote Delegate",
+			Description:          "The address of someone you trust to vote on Rocket Pool's protocol DAO proposals. If this is set, the node daemon will watch the active proposals and cast the same vote as this address on any proposal your node hasn't voted on yet, using your node's own voting power.\n\nUnlike an on-chain delegate, this doesn't require a transaction and your node's votes are recorded under its own address. Leave it blank to disable automatic voting.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMode: config.Parameter{
+			ID:                   "rewardsTreeMode",
+			Name:                 "Rewards Tree Mode",
+			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Download",
+				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
+				Value:       config.RewardsMode_Download,
+			}, {
+				Name:        "Generate",
+				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
+				Value:       config.RewardsMode_Generate,
+			}},
+		},
+
+		ArchiveECUrl: config.Parameter{
+			ID:                   "archiveECUrl",
+			Name:                 "Archive-Mode EC URL",
+			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nYou can enter several comma-separated URLs to spread heavy historical queries across them; requests will be load-balanced between them and retried on another endpoint if one is rate-limited or goes offline.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsFileIpfsGateways: config.Parameter{
+			ID:                   "rewardsFileIpfsGateways",
+			Name:                 "Rewards File IPFS Gateways",
+			Description:          "A comma-separated list of additional IPFS gateways (such as `https://cloudflare-ipfs.com`) to download missing rewards tree files from. They will be tried before the default gateways.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsFileMirrors: config.Parameter{
+			ID:                   "rewardsFileMirrors",
+			Name:                 "Rewards File Mirrors",
+			Description:          "A comma-separated list of HTTPS mirrors to download missing rewards tree files from if the IPFS gateways are unavailable. Each mirror must serve the compressed files by name (e.g. `https://mirror.example.com/rewards-trees/rp-rewards-mainnet-1.json.zst`); enter the URL of the folder that holds them.\n\nFiles from mirrors are always checked against the Merkle root submitted by the Oracle DAO before they're saved.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3StorageApiToken: config.Parameter{
+			ID:                   "web3StorageApiToken",
+			Name:                 "Web3.Storage API Token",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerMaxFeeOverride: config.Parameter{
+			ID:                   "watchtowerMaxFeeOverride",
+			Name:                 "Watchtower Max Fee Override",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		WatchtowerPrioFeeOverride: config.Parameter{
+			ID:                   "watchtowerPrioFeeOverride",
+			Name:                 "Watchtower Priority Fee Override",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		WatchtowerSubmissionDelayWindow: config.Parameter{
+			ID:                   "watchtowerSubmissionDelayWindow",
+			Name:                 "Watchtower Submission Delay Window",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The watchtower waits a random number of seconds up to this value before submitting prices, balances, or a rewards snapshot, then checks whether the Oracle DAO has already reached consensus. This spreads the members' submissions out so late ones aren't sent just to revert. Set it to 0 to submit immediately.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RplTwapEpoch: config.Parameter{
+			ID:          "rplTwapEpoch",
+			Name:        "RPL TWAP Epoch",
+			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from spot prices to TWAP for RPL price submission.",
+			Type:        config.ParameterType_Uint,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: uint64(193414),
+				config.Network_Prater:  uint64(162094),
+				config.Network_Devnet:  uint64(162094),
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		BalancesModernizationEpoch: config.Parameter{
+			ID:          "balancesModernizationEpoch",
+			Name:        "Balances Modernization Epoch",
+			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from the old network balance calculation method to the new one.",
+			Type:        config.ParameterType_Uint,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: uint64(194089),
+				config.Network_Prater:  uint64(162094),
+				config.Network_Devnet:  uint64(162094),
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		ContractAddressOverrides: config.Parameter{
+			ID:                   "contractAddressOverrides",
+			Name:                 "Contract Address Overrides",
+			Description:          "[orange]**For advanced users and testing only.**\n\n[white]A comma-separated list of contract addresses to use instead of the Smartnode's built-in ones, such as when testing an upcoming deployment. Each entry takes the form `contract=address`, or `network:contract=address` to restrict it to a single network.\n\nSupported contracts: " + strings.Join(getOverridableContractNames(), ", ") + ".\n\nLeave this blank unless you know exactly what you're doing.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableNodeApi: config.Parameter{
+			ID:                   "enableNodeApi",
+			Name:                 "Enable Node HTTP API",
+			Description:          "Enable a read-only HTTP API in the node container that serves your node's status, minipool details, rewards info, and wallet status as JSON. This is useful for integrating dashboards or mobile apps without running the CLI.\n\nRequests must include the API token below in an `Authorization: Bearer <token>` header.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"ENABLE_NODE_API"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeApiPort: config.Parameter{
+			ID:                   "nodeApiPort",
+			Name:                 "Node HTTP API Port",
+			Description:          "The port the node container's HTTP API should listen on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeApiPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"NODE_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeApiToken: config.Parameter{
+			ID:                   "nodeApiToken",
+			Name:                 "Node HTTP API Token",
+			Description:          "The secret token that clients must provide to access the node container's HTTP API. Use a long, random value and keep it private.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeApiCalendarToken: config.Parameter{
+			ID:                   "nodeApiCalendarToken",
+			Name:                 "Node HTTP API Calendar Token",
+			Description:          "A separate token that only gives access to the duties calendar feed (`/api/v1/node/calendar.ics`). Calendar apps can't send headers, so they pass it in the URL as `?token=...`, where it can end up in logs and synced calendar settings; that's why it can't be used for anything else. It must be different from the Node HTTP API Token. Leave it blank to only allow the calendar with the full API token in the Authorization header.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableMetricsStream: config.Parameter{
+			ID:                   "enableMetricsStream",
+			Name:                 "Enable Metrics Stream",
+			Description:          "Enable a gRPC service in the node container that streams your node's metrics to subscribers. After the first update, only the metrics that changed are sent, which uses far less bandwidth than full Prometheus scrapes. This is useful for monitoring a node remotely over a metered or slow connection.\n\nThis requires metrics to be enabled. Subscribers must provide the Node HTTP API token in an `authorization: Bearer <token>` header.\n\nThe stream only listens on localhost unless Metrics Stream TLS is enabled, so the token is never sent over the network in plain text.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"ENABLE_METRICS_STREAM"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MetricsStreamPort: config.Parameter{
+			ID:                   "metricsStreamPort",
+			Name:                 "Metrics Stream Port",
+			Description:          "The port the node container's gRPC metrics stream should listen on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultMetricsStreamPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"METRICS_STREAM_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableMetricsStreamTls: config.Parameter{
+			ID:                   "enableMetricsStreamTls",
+			Name:                 "Enable Metrics Stream TLS",
+			Description:          fmt.Sprintf("Serve the metrics stream over TLS and listen on every interface, so it can be reached from other machines. The certificate and its private key are read from `%s` and `%s` in your Smartnode's data folder, in PEM format.\n\nWhen this is disabled, the stream only listens on localhost; in Docker mode, that means it can only be reached from inside the node container (for example, through an SSH tunnel and `docker exec`).", MetricsStreamCertFile, MetricsStreamKeyFile),
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableEndpointAccessLog: config.Parameter{
+			ID:                   "enableEndpointAccessLog",
+			Name:                 "Enable Endpoint Access Log",
+			Description:          "Enable this to have the node and watchtower daemons record every request they send to your Execution and Beacon clients, including which subsystem sent it (the daemon itself, unless a task identifies its own requests), the method, how long it took, and whether it succeeded. The requests are written to `endpoint-access-node.log` and `endpoint-access-watchtower.log` in the Smartnode's data folder, and the node's per-subsystem totals are added to its metrics.\n\nThis is useful for finding out which part of the Smartnode is using up the request quota of an RPC provider, but it adds a small amount of overhead to every request.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFormat: config.Parameter{
+			ID:                   "logFormat",
+			Name:                 "Log Format",
+			Description:          "The format the node and watchtower daemons write their logs in.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogFormat_Console},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Console",
+				Description: "Colored, human-readable lines with a timestamp.",
+				Value:       config.LogFormat_Console,
+			}, {
+				Name:        "JSON",
+				Description: "One JSON object per line with the time, level, module, and message, for log collectors such as Loki or the ELK stack.",
+				Value:       config.LogFormat_Json,
+			}},
+		},
+
+		LogLevel: config.Parameter{
+			ID:                   "logLevel",
+			Name:                 "Log Level",
+			Description:          "The lowest severity of the messages the node and watchtower daemons log.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogLevel_Info},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Debug",
+				Description: "Log everything, including detailed messages meant for troubleshooting.",
+				Value:       config.LogLevel_Debug,
+			}, {
+				Name:        "Info",
+				Description: "Log what the daemons are doing, along with any warnings and errors.",
+				Value:       config.LogLevel_Info,
+			}, {
+				Name:        "Warning",
+				Description: "Only log warnings and errors.",
+				Value:       config.LogLevel_Warn,
+			}, {
+				Name:        "Error",
+				Description: "Only log errors.",
+				Value:       config.LogLevel_Error,
+			}},
+		},
+
+		LogModuleLevels: config.Parameter{
+			ID:                   "logModuleLevels",
+			Name:                 "Module Log Levels",
+			Description:          "A comma-separated list of log levels for specific modules in the format `module=level`, which replace the Log Level for those modules. For example, `claim-rewards=debug,collectors=warn`.\n\nThe modules are the node and watchtower tasks (such as `claim-rewards` or `submit-rpl-price`), `collectors` for the metrics collectors, `ec-manager` and `bc-manager` for the client connections, and `node`, `watchtower`, and `daemon` for everything else. The levels are `debug`, `info`, `warn`, and `error`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFileMaxSize: config.Parameter{
+			ID:                   "logFileMaxSize",
+			Name:                 "Log File Size",
+			Description:          "Set this to have the node and watchtower daemons write their logs to `logs/node.log` and `logs/watchtower.log` in the Smartnode's data folder as well as to the console. Each file is rotated once it reaches this size, in MB.\n\nSet it to 0 to only log to the console.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFileMaxBackups: config.Parameter{
+			ID:                   "logFileMaxBackups",
+			Name:                 "Log File Backups",
+			Description:          "How many rotated log files to keep for each daemon when the Log File Size is set. The oldest file is deleted when a new one is rotated out.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ValidatorStatusBatchSize: config.Parameter{
+			ID:                   "validatorStatusBatchSize",
+			Name:                 "Validator Status Batch Size",
+			Description:          "The number of validators whose status is requested from your Beacon node at once. Lower this if status requests time out on a node with many validators, or raise it if your Beacon node handles large requests well.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ValidatorStatusConcurrency: config.Parameter{
+			ID:                   "validatorStatusConcurrency",
+			Name:                 "Validator Status Concurrency",
+			Description:          "The number of validator status requests that can be sent to your Beacon node at the same time. Lower this if your Beacon node or its provider rate-limits you.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(4)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MonitoredNodes: config.Parameter{
+			ID:                   "monitoredNodes",
+			Name:                 "Monitored Nodes",
+			Description:          "A comma-separated list of other node addresses to include in this node's metrics. Each node's metrics are labeled with its address, so one Prometheus and Grafana stack can watch a whole fleet of nodes.\n\nThe monitored nodes are read-only: this node never sends transactions for them or needs their wallets.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableStatsHistory: config.Parameter{
+			ID:                   "enableStatsHistory",
+			Name:                 "Enable Stats History",
+			Description:          "Enable this to have the node container record a sample of your node's key stats (such as its RPL stake, balances, and rewards) every 15 minutes in `stats-history.jsonl` in the Smartnode's data folder.\n\nThe history is served from the metrics port under `/grafana` in the format used by Grafana's JSON and Infinity datasources, so you can chart it in Grafana even if your Prometheus doesn't keep data for long. This requires metrics to be enabled.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StatsHistoryRetentionDays: config.Parameter{
+			ID:                   "statsHistoryRetentionDays",
+			Name:                 "Stats History Retention",
+			Description:          "The number of days of stats history to keep. Older samples are deleted.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(365)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ProvisionGrafanaDashboard: config.Parameter{
+			ID:                   "provisionGrafanaDashboard",
+			Name:                 "Provision Generated Dashboard",
+			Description:          "The node container generates a Grafana dashboard with a panel for every metric it exports, served from the metrics port under `/dashboard`. New metrics are added to it automatically.\n\nEnable this to also have it written to `grafana-dashboards/rocketpool-generated.json` in the Smartnode's data folder and kept up to date, so Grafana can provision it from there. This requires metrics to be enabled.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Grafana},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaintenanceWindows: config.Parameter{
+			ID:                   "maintenanceWindows",
+			Name:                 "Maintenance Windows",
+			Description:          "A comma-separated list of times you plan to take the node offline for maintenance, each written as a start time and a duration such as `2024-03-01T02:00:00Z/4h`.\n\nThey're included in the duties calendar from `rocketpool node export-calendar` so you can plan them around your other obligations.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableGraffitiManagement: config.Parameter{
+			ID:                   "enableGraffitiManagement",
+			Name:                 "Enable Graffiti Management",
+			Description:          "Enable this to have the node container set each validator's graffiti through your Validator client's keymanager API, using the graffiti template below and any per-validator graffiti set with `rocketpool node set-graffiti`.\n\nThis lets you change your graffiti without editing your Validator client's configuration or restarting it. Your Validator client's keymanager API must be enabled.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GraffitiTemplate: config.Parameter{
+			ID:                   "graffitiTemplate",
+			Name:                 "Graffiti Template",
+			Description:          "The graffiti for your validators' proposals when graffiti management is enabled. It can use these variables:\n\n`{graffiti}`: the standard Rocket Pool graffiti, including your Custom Graffiti\n`{prefix}`: the Rocket Pool version tag, such as `RP v1.10.0`\n`{custom}`: your Custom Graffiti\n`{ec}` and `{cc}`: the names of your Execution and Consensus clients\n`{ecVersion}` and `{ccVersion}`: the versions of your Execution and Validator clients\n`{nickname}`: your node's nickname\n`{index}`: the validator's index\n`{message}`: the current graffiti message\n\nLeave it blank to use the standard graffiti. Graffiti longer than 32 bytes is cut short.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GraffitiMessages: config.Parameter{
+			ID:                   "graffitiMessages",
+			Name:                 "Graffiti Messages",
+			Description:          "A list of messages separated by `|` that the `{message}` variable in the graffiti template rotates through, such as `gm|wagmi|hello from Rocket Pool`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GraffitiRotationInterval: config.Parameter{
+			ID:                   "graffitiRotationInterval",
+			Name:                 "Graffiti Rotation Interval",
+			Description:          "How long each graffiti message is used before moving to the next one, such as `12h` or `168h`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultGraffitiRotation},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeNickname: config.Parameter{
+			ID:                   "nodeNickname",
+			Name:                 "Node Nickname",
+			Description:          "A name for your node, which can be included in your graffiti with the `{nickname}` variable.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiUrl: config.Parameter{
+			ID:                   "keymanagerApiUrl",
+			Name:                 "Keymanager API URL",
+			Description:          fmt.Sprintf("The URL of your Validator client's keymanager API, which is used to manage your graffiti and to move your keys to Web3Signer. The node container authenticates with the token in `%s` in your validator keys folder.", KeymanagerApiTokenFile),
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: fmt.Sprintf("http://%s:%d", ValidatorContainerName, defaultKeymanagerApiPort)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableMinipoolLabelsInMetrics: config.Parameter{
+			ID:                   "enableMinipoolLabelsInMetrics",
+			Name:                 "Add Minipool Labels to Metrics",
+			Description:          "Enable this to add the labels you've given your minipools with `rocketpool minipool set-label` to the metrics for each minipool, so your dashboards can show them instead of the minipool addresses.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableMinipoolTagsInMetrics: config.Parameter{
+			ID:                   "enableMinipoolTagsInMetrics",
+			Name:                 "Add Minipool Tags to Metrics",
+			Description:          "Enable this to report the tags you've given your minipools with `rocketpool minipool tag` as a `rocketpool_minipool_tag` metric with `minipool` and `tag` labels, so your dashboards can filter or group minipools by tag.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		RewardsTreeMode: config.Parameter{
-			ID:                   "rewardsTreeMode",
-			Name:                 "Rewards Tree Mode",
-			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
+		UseWeb3Signer: config.Parameter{
+			ID:                   "useWeb3Signer",
+			Name:                 "Use Web3Signer",
+			Description:          "Enable this to keep your validator keys in a Web3Signer remote signer instead of local keystores. New keys, and the keys recreated by `rocketpool wallet rebuild`, are imported into Web3Signer through its keymanager API (start it with `--key-manager-api-enabled` and a slashing protection database), and your Validator Client is pointed at the signer.\n\nRun `rocketpool wallet rebuild` after enabling this to move your existing keys: it removes them from your Validator Client, imports them into Web3Signer along with their slashing protection history, and deletes the local keystores.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerUrl: config.Parameter{
+			ID:                   "web3SignerUrl",
+			Name:                 "Web3Signer URL",
+			Description:          "The URL of your Web3Signer remote signer, such as `http://web3signer:9000`. It must be reachable from both the Smartnode and your Validator Client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"WEB3SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FiatPriceSource: config.Parameter{
+			ID:                   "fiatPriceSource",
+			Name:                 "Fiat Price Source",
+			Description:          "Select where the node gets the price of ETH from, so the metrics can report your wallet, staked RPL and Beacon Chain balances in a fiat currency. RPL values are derived from the ETH price and the protocol's RPL price.",
 			Type:                 config.ParameterType_Choice,
-			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			Default:              map[config.Network]interface{}{config.Network_All: prices.Source_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 			Options: []config.ParameterOption{{
-				Name:        "Download",
-				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
-				Value:       config.RewardsMode_Download,
+				Name:        "None",
+				Description: "Don't report any fiat values.",
+				Value:       prices.Source_None,
 			}, {
-				Name:        "Generate",
-				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
-				Value:       config.RewardsMode_Generate,
+				Name:        "CoinGecko",
+				Description: "Get the price of ETH from CoinGecko's public API. This supports most fiat currencies.",
+				Value:       prices.Source_CoinGecko,
+			}, {
+				Name:        "Chainlink",
+				Description: "Read the price of ETH from the Chainlink ETH/USD price feed using your Execution client, so no third-party service is contacted. This only supports USD, and is only available on Mainnet.",
+				Value:       prices.Source_Chainlink,
+			}, {
+				Name:        "Custom",
+				Description: "Get the price of ETH from the URL in the Custom Fiat Price URL setting.",
+				Value:       prices.Source_Custom,
 			}},
 		},
 
-		ArchiveECUrl: config.Parameter{
-			ID:                   "archiveECUrl",
-			Name:                 "Archive-Mode EC URL",
-			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+		FiatCurrency: config.Parameter{
+			ID:                   "fiatCurrency",
+			Name:                 "Fiat Currency",
+			Description:          "The fiat currency to report values in, such as `usd`, `eur` or `gbp`. This is added to the metrics as the `currency` label.",
 			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			Default:              map[config.Network]interface{}{config.Network_All: "usd"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
-			CanBeBlank:           true,
+			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		Web3StorageApiToken: config.Parameter{
-			ID:                   "web3StorageApiToken",
-			Name:                 "Web3.Storage API Token",
-			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+		FiatPriceUrl: config.Parameter{
+			ID:                   "fiatPriceUrl",
+			Name:                 "Custom Fiat Price URL",
+			Description:          "The URL the Custom fiat price source uses. It must return JSON with a `price` field, and can contain the `{asset}` and `{currency}` placeholders, which are replaced with `ETH` and the Fiat Currency.",
 			Type:                 config.ParameterType_String,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
 
-		WatchtowerMaxFeeOverride: config.Parameter{
-			ID:                   "watchtowerMaxFeeOverride",
-			Name:                 "Watchtower Max Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
-			EnvironmentVariables: []string{},
-			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
-		},
-
-		WatchtowerPrioFeeOverride: config.Parameter{
-			ID:                   "watchtowerPrioFeeOverride",
-			Name:                 "Watchtower Priority Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		HttpProxy: config.Parameter{
+			ID:                   "httpProxy",
+			Name:                 "HTTP Proxy",
+			Description:          "The proxy the Smartnode sends its outbound HTTP requests through, such as `socks5h://127.0.0.1:9050` for a local Tor client or `http://proxy.example:3128` for an HTTP proxy. This covers requests to your Execution and Beacon clients, price feeds, gas oracles, rewards file downloads, and update checks.\n\nLeave this blank to connect directly (the standard `HTTP_PROXY` and `HTTPS_PROXY` environment variables are still honored). Connections to the clients Rocket Pool runs for you locally never use it, unless you set an override for them below.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
-			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		RplTwapEpoch: config.Parameter{
-			ID:          "rplTwapEpoch",
-			Name:        "RPL TWAP Epoch",
-			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from spot prices to TWAP for RPL price submission.",
-			Type:        config.ParameterType_Uint,
-			Default: map[config.Network]interface{}{
-				config.Network_Mainnet: uint64(193414),
-				config.Network_Prater:  uint64(162094),
-				config.Network_Devnet:  uint64(162094),
-			},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		HttpIpMode: config.Parameter{
+			ID:                   "httpIpMode",
+			Name:                 "Outbound IP Version",
+			Description:          "The IP version the Smartnode uses for its outbound HTTP connections. When a proxy is set, this applies to the connection to the proxy.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.IpMode_Auto},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Automatic",
+				Description: "Use IPv4 or IPv6, whichever each host supports.",
+				Value:       config.IpMode_Auto,
+			}, {
+				Name:        "IPv4 Only",
+				Description: "Only connect over IPv4.",
+				Value:       config.IpMode_IPv4,
+			}, {
+				Name:        "IPv6 Only",
+				Description: "Only connect over IPv6, for machines without IPv4 connectivity.",
+				Value:       config.IpMode_IPv6,
+			}},
 		},
 
-		BalancesModernizationEpoch: config.Parameter{
-			ID:          "balancesModernizationEpoch",
-			Name:        "Balances Modernization Epoch",
-			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from the old network balance calculation method to the new one.",
-			Type:        config.ParameterType_Uint,
-			Default: map[config.Network]interface{}{
-				config.Network_Mainnet: uint64(194089),
-				config.Network_Prater:  uint64(162094),
-				config.Network_Devnet:  uint64(162094),
-			},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		HttpProxyOverrides: config.Parameter{
+			ID:                   "httpProxyOverrides",
+			Name:                 "HTTP Proxy Overrides",
+			Description:          "A comma-separated list of proxies for specific endpoints in the format `endpoint=proxy`, which replace the HTTP Proxy for those endpoints. Use `direct` instead of a proxy to connect to an endpoint directly. For example, `ec=direct,prices=socks5h://127.0.0.1:9050`.\n\nThe supported endpoints are `ec` (Execution clients), `bn` (Beacon Nodes), `prices` (fiat price feeds), `gas` (gas oracles), `rewards` (rewards file downloads), `updates` (update checks), `relays` (MEV-boost relay checks), `snapshot` (Snapshot voting), `backup` (wallet backup uploads), and `lookups` (timezone detection, client diversity data, and checkpoint sync provider checks).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
-			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
 		txWatchUrl: map[config.Network]string{
@@ -509,6 +1574,12 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet:  "",
 		},
 
+		snapshotSequencerUrl: map[config.Network]string{
+			config.Network_Mainnet: "https://seq.snapshot.org",
+			config.Network_Prater:  "https://testnet.seq.snapshot.org",
+			config.Network_Devnet:  "",
+		},
+
 		previousRewardsPoolAddresses: map[config.Network]map[string][]common.Address{
 			config.Network_Mainnet: {
 				"v1.1.0": []common.Address{
@@ -556,6 +1627,12 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet:  "0x5cE71E603B138F7e65029Cc1918C0566ed0dBD4B",
 		},
 
+		chainlinkEthUsdFeedAddress: map[config.Network]string{
+			config.Network_Mainnet: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+			config.Network_Prater:  "",
+			config.Network_Devnet:  "",
+		},
+
 		multicallAddress: map[config.Network]string{
 			config.Network_Mainnet: "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
 			config.Network_Prater:  "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
@@ -616,16 +1693,122 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.DataPath,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
+		&cfg.TransactionPresets,
+		&cfg.GasOracle,
+		&cfg.TaskGasCeilings,
 		&cfg.AutoTxGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.EnableAutoRefund,
+		&cfg.AutoRefundThreshold,
+		&cfg.FeeDistributorSweepThreshold,
+		&cfg.FeeDistributorSweepForwardAddress,
+		&cfg.EnableAutoClaim,
+		&cfg.AutoClaimRplThreshold,
+		&cfg.AutoClaimEthThreshold,
+		&cfg.AutoClaimRestakePercent,
+		&cfg.AutoClaimMaxFee,
+		&cfg.AutoClaimForwardAddress,
+		&cfg.AutoClaimForwardPercent,
+		&cfg.AutoClaimOperatingFloat,
+		&cfg.AutoCorrectFeeRecipient,
+		&cfg.ExpectedWithdrawalAddress,
+		&cfg.ExpectedRplWithdrawalAddress,
+		&cfg.WithdrawalAddressProof,
+		&cfg.EnableClientDiversityGraffiti,
+		&cfg.SafeModeCrashThreshold,
+		&cfg.TaskInterval,
+		&cfg.WalletBackupDestination,
+		&cfg.PdaoAutoVoteDelegate,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
+		&cfg.RewardsFileIpfsGateways,
+		&cfg.RewardsFileMirrors,
 		&cfg.Web3StorageApiToken,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
+		&cfg.WatchtowerSubmissionDelayWindow,
 		&cfg.RplTwapEpoch,
 		&cfg.BalancesModernizationEpoch,
+		&cfg.ContractAddressOverrides,
+		&cfg.EnableNodeApi,
+		&cfg.NodeApiPort,
+		&cfg.NodeApiToken,
+		&cfg.NodeApiCalendarToken,
+		&cfg.EnableMetricsStream,
+		&cfg.MetricsStreamPort,
+		&cfg.EnableMetricsStreamTls,
+		&cfg.EnableEndpointAccessLog,
+		&cfg.LogFormat,
+		&cfg.LogLevel,
+		&cfg.LogModuleLevels,
+		&cfg.LogFileMaxSize,
+		&cfg.LogFileMaxBackups,
+		&cfg.ValidatorStatusBatchSize,
+		&cfg.ValidatorStatusConcurrency,
+		&cfg.MonitoredNodes,
+		&cfg.EnableStatsHistory,
+		&cfg.StatsHistoryRetentionDays,
+		&cfg.ProvisionGrafanaDashboard,
+		&cfg.MaintenanceWindows,
+		&cfg.EnableGraffitiManagement,
+		&cfg.GraffitiTemplate,
+		&cfg.GraffitiMessages,
+		&cfg.GraffitiRotationInterval,
+		&cfg.NodeNickname,
+		&cfg.KeymanagerApiUrl,
+		&cfg.EnableMinipoolLabelsInMetrics,
+		&cfg.EnableMinipoolTagsInMetrics,
+		&cfg.UseWeb3Signer,
+		&cfg.Web3SignerUrl,
+		&cfg.FiatPriceSource,
+		&cfg.FiatCurrency,
+		&cfg.FiatPriceUrl,
+		&cfg.HttpProxy,
+		&cfg.HttpIpMode,
+		&cfg.HttpProxyOverrides,
+	}
+}
+
+// Parse the addresses of the other nodes to include in the node metrics
+func (cfg *SmartnodeConfig) GetMonitoredNodes() ([]common.Address, error) {
+	nodes := []common.Address{}
+	value, ok := cfg.MonitoredNodes.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nodes, nil
+	}
+
+	seen := map[common.Address]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("'%s' is not a valid address", entry)
+		}
+		address := common.HexToAddress(entry)
+		if !seen[address] {
+			seen[address] = true
+			nodes = append(nodes, address)
+		}
+	}
+	return nodes, nil
+}
+
+// Get the delay between each pass of the node daemon's task loop
+func (cfg *SmartnodeConfig) GetTaskInterval() (time.Duration, error) {
+	value, ok := cfg.TaskInterval.Value.(string)
+	if !ok || value == "" {
+		value = defaultTaskInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid duration: %w", value, err)
 	}
+	if interval < minimumTaskInterval {
+		return 0, fmt.Errorf("%s is shorter than the minimum of %s", interval, minimumTaskInterval)
+	}
+	return interval, nil
 }
 
 // Getters for the non-editable parameters
@@ -686,6 +1869,206 @@ func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
 }
 
+func (cfg *SmartnodeConfig) GetNodeCrashCounterPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeCrashCounterFile)
+	}
+
+	return filepath.Join(DaemonDataPath, NodeCrashCounterFile)
+}
+
+func (cfg *SmartnodeConfig) GetPendingForwardsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), PendingForwardsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, PendingForwardsFile)
+}
+
+func (cfg *SmartnodeConfig) GetNetworkStateSnapshotPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NetworkStateSnapshotFile)
+	}
+
+	return filepath.Join(DaemonDataPath, NetworkStateSnapshotFile)
+}
+
+func (cfg *SmartnodeConfig) GetNodeConfigReloadLogPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeConfigReloadLogFile)
+	}
+
+	return filepath.Join(DaemonDataPath, NodeConfigReloadLogFile)
+}
+
+func (cfg *SmartnodeConfig) GetProtocolSettingsSnapshotPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ProtocolSettingsSnapshotFile)
+	}
+
+	return filepath.Join(DaemonDataPath, ProtocolSettingsSnapshotFile)
+}
+
+func (cfg *SmartnodeConfig) GetEventJournalPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), EventJournalFile)
+	}
+
+	return filepath.Join(DaemonDataPath, EventJournalFile)
+}
+
+func (cfg *SmartnodeConfig) GetBalanceHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), BalanceHistoryFile)
+	}
+
+	return filepath.Join(DaemonDataPath, BalanceHistoryFile)
+}
+
+func (cfg *SmartnodeConfig) GetDvtPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DvtFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DvtFolder)
+}
+
+func (cfg *SmartnodeConfig) GetStatsHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), StatsHistoryFile)
+	}
+
+	return filepath.Join(DaemonDataPath, StatsHistoryFile)
+}
+
+func (cfg *SmartnodeConfig) GetMetricsStreamCertPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MetricsStreamCertFile)
+	}
+
+	return filepath.Join(DaemonDataPath, MetricsStreamCertFile)
+}
+
+func (cfg *SmartnodeConfig) GetMetricsStreamKeyPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MetricsStreamKeyFile)
+	}
+
+	return filepath.Join(DaemonDataPath, MetricsStreamKeyFile)
+}
+
+func (cfg *SmartnodeConfig) GetGrafanaDashboardPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GrafanaDashboardFile)
+	}
+
+	return filepath.Join(DaemonDataPath, GrafanaDashboardFile)
+}
+
+func (cfg *SmartnodeConfig) GetValidatorGraffitiPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ValidatorGraffitiFile)
+	}
+
+	return filepath.Join(DaemonDataPath, ValidatorGraffitiFile)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerApiTokenPath() string {
+	return filepath.Join(cfg.GetValidatorKeychainPath(), KeymanagerApiTokenFile)
+}
+
+func (cfg *SmartnodeConfig) GetMinipoolLabelsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MinipoolLabelsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, MinipoolLabelsFile)
+}
+
+func (cfg *SmartnodeConfig) GetMinipoolTagsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MinipoolTagsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, MinipoolTagsFile)
+}
+
+func (cfg *SmartnodeConfig) GetMasqueradeAddressPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MasqueradeAddressFile)
+	}
+
+	return filepath.Join(DaemonDataPath, MasqueradeAddressFile)
+}
+
+func (cfg *SmartnodeConfig) GetTransactorKeyPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), TransactorKeyFile)
+	}
+
+	return filepath.Join(DaemonDataPath, TransactorKeyFile)
+}
+
+// Get the path of the slashing protection history exported from the Validator client for Web3Signer
+func (cfg *SmartnodeConfig) GetSlashingProtectionPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionFile)
+	}
+
+	return filepath.Join(DaemonDataPath, SlashingProtectionFile)
+}
+
+func (cfg *SmartnodeConfig) GetValidatorIndexCachePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ValidatorIndexCacheFile)
+	}
+
+	return filepath.Join(DaemonDataPath, ValidatorIndexCacheFile)
+}
+
+// Get the path of the endpoint access log for one of the daemons, such as "node" or "watchtower"
+func (cfg *SmartnodeConfig) GetEndpointAccessLogPath(daemon string) string {
+	filename := fmt.Sprintf(EndpointAccessLogFormat, daemon)
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), filename)
+	}
+
+	return filepath.Join(DaemonDataPath, filename)
+}
+
+// Get the path of a daemon's log file
+func (cfg *SmartnodeConfig) GetDaemonLogPath(daemon string) string {
+	filename := fmt.Sprintf(DaemonLogFormat, daemon)
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DaemonLogsFolder, filename)
+	}
+
+	return filepath.Join(DaemonDataPath, DaemonLogsFolder, filename)
+}
+
+// Get the settings for a daemon's logs
+func (cfg *SmartnodeConfig) GetLogSettings(daemon string) (log.Settings, error) {
+	moduleLevels, err := log.ParseModuleLevels(cfg.LogModuleLevels.Value.(string))
+	if err != nil {
+		return log.Settings{}, err
+	}
+	format, _ := cfg.LogFormat.Value.(config.LogFormat)
+	level, _ := cfg.LogLevel.Value.(config.LogLevel)
+	settings := log.Settings{
+		Format:       format,
+		Level:        level,
+		ModuleLevels: moduleLevels,
+	}
+
+	maxSize := cfg.LogFileMaxSize.Value.(uint64)
+	if maxSize > 0 {
+		settings.FilePath = cfg.GetDaemonLogPath(daemon)
+		settings.MaxFileSize = int64(maxSize) * 1024 * 1024
+		settings.MaxFileBackups = int(cfg.LogFileMaxBackups.Value.(uint64))
+	}
+	return settings, nil
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-keys")
@@ -703,23 +2086,23 @@ func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetStorageAddress() string {
-	return cfg.storageAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(StorageContractName, cfg.storageAddress)
 }
 
 func (cfg *SmartnodeConfig) GetOneInchOracleAddress() string {
-	return cfg.oneInchOracleAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(OneInchOracleContractName, cfg.oneInchOracleAddress)
 }
 
 func (cfg *SmartnodeConfig) GetRplTokenAddress() string {
-	return cfg.rplTokenAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(RplTokenContractName, cfg.rplTokenAddress)
 }
 
 func (cfg *SmartnodeConfig) GetRplFaucetAddress() string {
-	return cfg.rplFaucetAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(RplFaucetContractName, cfg.rplFaucetAddress)
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotDelegationAddress() string {
-	return cfg.snapshotDelegationAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(SnapshotDelegationContractName, cfg.snapshotDelegationAddress)
 }
 
 func (cfg *SmartnodeConfig) GetSmartnodeContainerTag() string {
@@ -738,6 +2121,10 @@ func (cfg *SmartnodeConfig) GetSnapshotApiDomain() string {
 	return cfg.snapshotApiDomain[cfg.Network.Value.(config.Network)]
 }
 
+func (cfg *SmartnodeConfig) GetSnapshotSequencerUrl() string {
+	return cfg.snapshotSequencerUrl[cfg.Network.Value.(config.Network)]
+}
+
 func (cfg *SmartnodeConfig) GetVotingSnapshotID() [32]byte {
 	// So the contract wants a Keccak'd hash of the voting ID, but Snapshot's service wants ASCII so it can display the ID in plain text; we have to do this to make it play nicely with Snapshot
 	buffer := [32]byte{}
@@ -756,7 +2143,7 @@ func (cfg *SmartnodeConfig) GetConfigTitle() string {
 }
 
 func (cfg *SmartnodeConfig) GetRethAddress() common.Address {
-	return common.HexToAddress(cfg.rethAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getContractAddress(RethContractName, cfg.rethAddress))
 }
 
 func getDefaultDataDir(config *RocketPoolConfig) string {
@@ -779,6 +2166,27 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+// Get the URLs of the archive-mode ECs to spread historical queries across
+func (cfg *SmartnodeConfig) GetArchiveEcUrls() []string {
+	return splitUrlList(cfg.ArchiveECUrl.Value.(string))
+}
+
+// Get the URLs to try when downloading a rewards tree file, in order: the user's IPFS gateways, the default IPFS gateways, then the user's HTTPS mirrors
+func (cfg *SmartnodeConfig) GetRewardsFileUrls(cid string, filename string) []string {
+	urls := []string{}
+	for _, gateway := range splitUrlList(cfg.RewardsFileIpfsGateways.Value.(string)) {
+		urls = append(urls, fmt.Sprintf("%s/ipfs/%s/%s", gateway, cid, filename))
+	}
+	urls = append(urls,
+		fmt.Sprintf(PrimaryRewardsFileUrl, cid, filename),
+		fmt.Sprintf(SecondaryRewardsFileUrl, cid, filename),
+	)
+	for _, mirror := range splitUrlList(cfg.RewardsFileMirrors.Value.(string)) {
+		urls = append(urls, fmt.Sprintf("%s/%s", mirror, filename))
+	}
+	return urls
+}
+
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
@@ -860,15 +2268,19 @@ func (cfg *SmartnodeConfig) GetZkSyncEraMessengerAddress() string {
 }
 
 func (cfg *SmartnodeConfig) GetRplTwapPoolAddress() string {
-	return cfg.rplTwapPoolAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(RplTwapPoolContractName, cfg.rplTwapPoolAddress)
+}
+
+func (cfg *SmartnodeConfig) GetChainlinkEthUsdFeedAddress() string {
+	return cfg.chainlinkEthUsdFeedAddress[cfg.Network.Value.(config.Network)]
 }
 
 func (cfg *SmartnodeConfig) GetMulticallAddress() string {
-	return cfg.multicallAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(MulticallContractName, cfg.multicallAddress)
 }
 
 func (cfg *SmartnodeConfig) GetBalanceBatcherAddress() string {
-	return cfg.balancebatcherAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getContractAddress(BalanceBatcherContractName, cfg.balancebatcherAddress)
 }
 
 func (cfg *SmartnodeConfig) GetFlashbotsProtectUrl() string {
@@ -902,3 +2314,43 @@ func getNetworkOptions() []config.ParameterOption {
 
 	return options
 }
+
+// Split a comma-separated list of base URLs, dropping empty entries and trailing slashes
+func splitUrlList(value string) []string {
+	urls := []string{}
+	for _, url := range strings.Split(value, ",") {
+		url = strings.TrimRight(strings.TrimSpace(url), "/")
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// Get the settings for the outbound HTTP clients.
+// The clients Rocket Pool runs locally are connected to directly unless they have an override.
+func (cfg *SmartnodeConfig) GetHttpClientSettings() (net.HttpClientSettings, error) {
+	proxy := strings.TrimSpace(cfg.HttpProxy.Value.(string))
+	if err := net.ValidateProxy(proxy); err != nil {
+		return net.HttpClientSettings{}, err
+	}
+	overrides, err := net.ParseProxyOverrides(cfg.HttpProxyOverrides.Value.(string))
+	if err != nil {
+		return net.HttpClientSettings{}, err
+	}
+	if !cfg.parent.IsNativeMode {
+		if _, exists := overrides[net.HttpTarget_ExecutionClient]; !exists && cfg.parent.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+			overrides[net.HttpTarget_ExecutionClient] = net.HttpProxy_Direct
+		}
+		if _, exists := overrides[net.HttpTarget_BeaconNode]; !exists && cfg.parent.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+			overrides[net.HttpTarget_BeaconNode] = net.HttpProxy_Direct
+		}
+	}
+
+	ipMode, _ := cfg.HttpIpMode.Value.(config.IpMode)
+	return net.HttpClientSettings{
+		Proxy:     proxy,
+		IpMode:    ipMode,
+		Overrides: overrides,
+	}, nil
+}
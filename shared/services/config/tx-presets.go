@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The route a transaction is submitted through
+type TransactionRoute string
+
+const (
+	TransactionRoute_Public  TransactionRoute = "public"
+	TransactionRoute_Private TransactionRoute = "private"
+)
+
+// A named bundle of transaction settings that can be selected with the CLI's --preset flag.
+// Fields that are left at zero fall back to the usual defaults.
+type TransactionPreset struct {
+	Name           string
+	MaxFee         float64
+	MaxPriorityFee float64
+	Route          TransactionRoute
+	Deadline       time.Duration
+}
+
+// Parse the transaction presets.
+// Returns a map of preset name to preset.
+func (cfg *SmartnodeConfig) GetTransactionPresets() (map[string]TransactionPreset, error) {
+	presets := map[string]TransactionPreset{}
+	value, ok := cfg.TransactionPresets.Value.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return presets, nil
+	}
+
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// Split the entry into the name and settings
+		elements := strings.SplitN(entry, ":", 2)
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid transaction preset [%s]: expected the format 'name: setting=value, setting=value'", entry)
+		}
+		name := strings.TrimSpace(elements[0])
+		if name == "" || strings.ContainsAny(name, " \t=,") {
+			return nil, fmt.Errorf("invalid transaction preset [%s]: '%s' is not a valid preset name", entry, name)
+		}
+		if _, exists := presets[name]; exists {
+			return nil, fmt.Errorf("invalid transaction preset [%s]: preset '%s' is defined more than once", entry, name)
+		}
+
+		preset := TransactionPreset{
+			Name:  name,
+			Route: TransactionRoute_Public,
+		}
+		for _, setting := range strings.Split(elements[1], ",") {
+			setting = strings.TrimSpace(setting)
+			if setting == "" {
+				continue
+			}
+			settingElements := strings.Split(setting, "=")
+			if len(settingElements) != 2 {
+				return nil, fmt.Errorf("invalid transaction preset [%s]: expected 'setting=value' but got '%s'", name, setting)
+			}
+			key := strings.TrimSpace(settingElements[0])
+			settingValue := strings.TrimSpace(settingElements[1])
+
+			var err error
+			switch key {
+			case "maxFee":
+				preset.MaxFee, err = strconv.ParseFloat(settingValue, 64)
+				if err == nil && preset.MaxFee < 0 {
+					err = fmt.Errorf("it can't be negative")
+				}
+			case "prioFee":
+				preset.MaxPriorityFee, err = strconv.ParseFloat(settingValue, 64)
+				if err == nil && preset.MaxPriorityFee < 0 {
+					err = fmt.Errorf("it can't be negative")
+				}
+			case "route":
+				preset.Route = TransactionRoute(settingValue)
+				if preset.Route != TransactionRoute_Public && preset.Route != TransactionRoute_Private {
+					err = fmt.Errorf("expected '%s' or '%s'", TransactionRoute_Public, TransactionRoute_Private)
+				}
+			case "deadline":
+				preset.Deadline, err = time.ParseDuration(settingValue)
+				if err == nil && preset.Deadline < 0 {
+					err = fmt.Errorf("it can't be negative")
+				}
+			default:
+				return nil, fmt.Errorf("invalid transaction preset [%s]: unknown setting '%s' (supported settings: maxFee, prioFee, route, deadline)", name, key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid transaction preset [%s]: invalid %s '%s': %w", name, key, settingValue, err)
+			}
+		}
+
+		if preset.MaxFee != 0 && preset.MaxPriorityFee > preset.MaxFee {
+			return nil, fmt.Errorf("invalid transaction preset [%s]: the priority fee can't be higher than the max fee", name)
+		}
+		presets[name] = preset
+	}
+
+	return presets, nil
+}
+
+// Get a transaction preset by name
+func (cfg *SmartnodeConfig) GetTransactionPreset(name string) (TransactionPreset, error) {
+	presets, err := cfg.GetTransactionPresets()
+	if err != nil {
+		return TransactionPreset{}, err
+	}
+	preset, exists := presets[name]
+	if !exists {
+		names := make([]string, 0, len(presets))
+		for presetName := range presets {
+			names = append(names, presetName)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return TransactionPreset{}, fmt.Errorf("transaction preset '%s' does not exist; no presets have been defined in the Smartnode settings", name)
+		}
+		return TransactionPreset{}, fmt.Errorf("transaction preset '%s' does not exist (available presets: %s)", name, strings.Join(names, ", "))
+	}
+	return preset, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get the URL of the Web3Signer remote signer, checking that it's usable
+func (cfg *SmartnodeConfig) GetWeb3SignerUrl() (string, error) {
+	value, ok := cfg.Web3SignerUrl.Value.(string)
+	value = strings.TrimSuffix(strings.TrimSpace(value), "/")
+	if !ok || value == "" {
+		return "", fmt.Errorf("a Web3Signer URL is required")
+	}
+	signerUrl, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not a valid URL: %w", value, err)
+	}
+	if signerUrl.Scheme != "http" && signerUrl.Scheme != "https" {
+		return "", fmt.Errorf("'%s' must start with http:// or https://", value)
+	}
+	return value, nil
+}
+
+// Get the flags that point a Validator client at the Web3Signer remote signer.
+// Lighthouse doesn't have any, so its keys are registered as remote keys through its keymanager API instead.
+func getWeb3SignerVcFlags(client config.ConsensusClient, signerUrl string) string {
+	switch client {
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--externalSigner.url=%s --externalSigner.fetch", signerUrl)
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--web3-signer-url=%s", signerUrl)
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=%s/api/v1/eth2/publicKeys", signerUrl, signerUrl)
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=external-signer", signerUrl)
+	default:
+		return ""
+	}
+}
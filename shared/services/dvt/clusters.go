@@ -0,0 +1,183 @@
+package dvt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Config
+const (
+	ClustersFile = "clusters.json"
+	RetiredDir   = "retired"
+	DirMode      = 0700
+	FileMode     = 0600
+)
+
+// A validator whose key has been split into a distributed validator cluster
+type Cluster struct {
+	Pubkey       rptypes.ValidatorPubkey `json:"pubkey"`
+	Minipool     common.Address          `json:"minipool"`
+	Provider     string                  `json:"provider"`
+	Directory    string                  `json:"directory"`
+	Nodes        int                     `json:"nodes"`
+	Operators    []string                `json:"operators,omitempty"`
+	FeeRecipient common.Address          `json:"feeRecipient"`
+	SplitTime    time.Time               `json:"splitTime"`
+	Retired      bool                    `json:"retired"`
+	RetiredTime  time.Time               `json:"retiredTime,omitempty"`
+}
+
+// Keeps track of the validators that have been split into clusters, along with the split keys of each one.
+// Each validator gets its own folder; the list of clusters is kept in a JSON file next to them.
+type Manager struct {
+	path     string
+	clusters map[rptypes.ValidatorPubkey]Cluster
+	lock     sync.Mutex
+}
+
+// Create a manager for the split keys in the given folder, loading the clusters that are already there
+func NewManager(path string) (*Manager, error) {
+	m := &Manager{
+		path:     path,
+		clusters: map[rptypes.ValidatorPubkey]Cluster{},
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(path, ClustersFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading DVT cluster list: %w", err)
+	}
+	clusters := []Cluster{}
+	if err := json.Unmarshal(bytes, &clusters); err != nil {
+		return nil, fmt.Errorf("error deserializing DVT cluster list: %w", err)
+	}
+	for _, cluster := range clusters {
+		m.clusters[cluster.Pubkey] = cluster
+	}
+	return m, nil
+}
+
+// Get the cluster for a validator, if its key has been split
+func (m *Manager) GetCluster(pubkey rptypes.ValidatorPubkey) (Cluster, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	cluster, exists := m.clusters[pubkey]
+	return cluster, exists
+}
+
+// Get all of the clusters, ordered by when they were split
+func (m *Manager) GetClusters() []Cluster {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	clusters := make([]Cluster, 0, len(m.clusters))
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].SplitTime.Before(clusters[j].SplitTime)
+	})
+	return clusters
+}
+
+// Get the folder a validator's split keys are written to
+func (m *Manager) GetClusterDir(pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(m.path, "0x"+pubkey.Hex())
+}
+
+// Record a newly split validator
+func (m *Manager) addCluster(cluster Cluster) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.clusters[cluster.Pubkey] = cluster
+	return m.save()
+}
+
+// Move a validator's keys out of the Validator Client's keystores so only the cluster can use it.
+// The files are moved into the cluster's folder rather than deleted, so they can be restored by hand.
+// Prysm keeps every key in one wallet file, so its keys can't be moved individually.
+func (m *Manager) Retire(pubkey rptypes.ValidatorPubkey, keychainPath string) ([]string, error) {
+	cluster, exists := m.GetCluster(pubkey)
+	if !exists {
+		return nil, fmt.Errorf("validator %s hasn't been split into a cluster", pubkey.Hex())
+	}
+
+	// Find every keystore and secret file or folder named after the validator
+	pubkeyHex := strings.ToLower(pubkey.Hex())
+	matches := []string{}
+	err := filepath.WalkDir(keychainPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == keychainPath || !strings.Contains(strings.ToLower(entry.Name()), pubkeyHex) {
+			return nil
+		}
+		matches = append(matches, path)
+		if entry.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching the validator keystores for %s: %w", pubkey.Hex(), err)
+	}
+
+	// Move them, keeping their layout so they can be put back where they were
+	retiredDir := filepath.Join(cluster.Directory, RetiredDir)
+	moved := []string{}
+	for _, match := range matches {
+		relativePath, err := filepath.Rel(keychainPath, match)
+		if err != nil {
+			return moved, err
+		}
+		destination := filepath.Join(retiredDir, relativePath)
+		if err := os.MkdirAll(filepath.Dir(destination), DirMode); err != nil {
+			return moved, fmt.Errorf("error creating folder for retired key %s: %w", relativePath, err)
+		}
+		if err := os.Rename(match, destination); err != nil {
+			return moved, fmt.Errorf("error moving retired key %s: %w", relativePath, err)
+		}
+		moved = append(moved, relativePath)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	cluster.Retired = true
+	cluster.RetiredTime = time.Now()
+	m.clusters[pubkey] = cluster
+	return moved, m.save()
+}
+
+// Save the cluster list; the lock must be held
+func (m *Manager) save() error {
+	clusters := make([]Cluster, 0, len(m.clusters))
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].SplitTime.Before(clusters[j].SplitTime)
+	})
+	bytes, err := json.MarshalIndent(clusters, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing DVT cluster list: %w", err)
+	}
+	if err := os.MkdirAll(m.path, DirMode); err != nil {
+		return fmt.Errorf("error creating DVT folder: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(m.path, ClustersFile), bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing DVT cluster list: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package dvt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	dvtaddon "github.com/rocket-pool/smartnode/addons/dvt"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/secret"
+)
+
+// Config
+const (
+	keystoreFileName = "keystore-0.json"
+	passwordFileName = "keystore-0.txt"
+	splitKeysDir     = "original"
+)
+
+// An EIP-2335 keystore, as read by the splitting tools
+type splitKeystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  string                 `json:"pubkey"`
+}
+
+// Split a minipool's validator key into an Obol cluster with `charon create cluster --split-existing-keys`.
+// The cluster's lock file and each node's key shares are written to the validator's folder.
+// The fee recipient is baked into the lock file, so the split has to be redone if it changes.
+func (m *Manager) SplitObol(charonPath string, network string, nodes int, key *eth2types.BLSPrivateKey, minipool common.Address, feeRecipient common.Address) (Cluster, error) {
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	clusterDir := m.GetClusterDir(pubkey)
+	keyDir, err := writeSplitKeystore(clusterDir, key)
+	if err != nil {
+		return Cluster{}, err
+	}
+	defer os.RemoveAll(keyDir)
+
+	// The withdrawal address is the minipool, since that's what the validator's withdrawal credentials point to
+	output, err := exec.Command(charonPath, "create", "cluster",
+		"--name", fmt.Sprintf("rocketpool-%s", minipool.Hex()),
+		"--network", network,
+		"--nodes", fmt.Sprint(nodes),
+		"--split-existing-keys",
+		"--split-keys-dir", keyDir,
+		"--withdrawal-addresses", minipool.Hex(),
+		"--fee-recipient-addresses", feeRecipient.Hex(),
+		"--cluster-dir", filepath.Join(clusterDir, "cluster"),
+	).CombinedOutput()
+	if err != nil {
+		return Cluster{}, fmt.Errorf("error running charon: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	cluster := Cluster{
+		Pubkey:    pubkey,
+		Minipool:  minipool,
+		Provider:     string(dvtaddon.Provider_Obol),
+		Directory:    clusterDir,
+		Nodes:        nodes,
+		FeeRecipient: feeRecipient,
+		SplitTime:    time.Now(),
+	}
+	return cluster, m.addCluster(cluster)
+}
+
+// Split a minipool's validator key into SSV key shares for the given operators with `ssv-keys`.
+// The key shares file is written to the validator's folder; it still has to be registered with the SSV Network
+// contract by the owner.
+func (m *Manager) SplitSsv(ssvKeysPath string, operatorIds []string, operatorKeys []string, owner common.Address, key *eth2types.BLSPrivateKey, minipool common.Address) (Cluster, error) {
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	clusterDir := m.GetClusterDir(pubkey)
+	keyDir, err := writeSplitKeystore(clusterDir, key)
+	if err != nil {
+		return Cluster{}, err
+	}
+	defer os.RemoveAll(keyDir)
+	password, err := os.ReadFile(filepath.Join(keyDir, passwordFileName))
+	if err != nil {
+		return Cluster{}, fmt.Errorf("error reading keystore password: %w", err)
+	}
+	defer secret.Wipe(password)
+
+	// The owner nonce counts the validators the owner has registered with SSV. This assumes every validator the node
+	// has registered was split here, since the SSV contract isn't read directly.
+	ownerNonce := 0
+	for _, cluster := range m.GetClusters() {
+		if cluster.Provider == string(dvtaddon.Provider_Ssv) {
+			ownerNonce++
+		}
+	}
+
+	// The keystore password is left off of the command line so other users can't read it from the process list;
+	// ssv-keys asks for it when it isn't provided, so it's answered on stdin instead
+	cmd := exec.Command(ssvKeysPath,
+		"--keystore", filepath.Join(keyDir, keystoreFileName),
+		"--operator-ids", strings.Join(operatorIds, ","),
+		"--operator-keys", strings.Join(operatorKeys, ","),
+		"--owner-address", owner.Hex(),
+		"--owner-nonce", fmt.Sprint(ownerNonce),
+		"--output-folder", filepath.Join(clusterDir, "keyshares"),
+	)
+	stdin := append(password, '\n')
+	defer secret.Wipe(stdin)
+	cmd.Stdin = bytes.NewReader(stdin)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return Cluster{}, fmt.Errorf("error running ssv-keys: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	cluster := Cluster{
+		Pubkey:    pubkey,
+		Minipool:  minipool,
+		Provider:  string(dvtaddon.Provider_Ssv),
+		Directory: clusterDir,
+		Nodes:     len(operatorIds),
+		Operators: operatorIds,
+		SplitTime: time.Now(),
+	}
+	return cluster, m.addCluster(cluster)
+}
+
+// Write a validator key to a temporary EIP-2335 keystore with its password next to it, in the layout Charon expects.
+// Returns the folder the keystore was written to; the caller must remove it once it's done.
+func writeSplitKeystore(clusterDir string, key *eth2types.BLSPrivateKey) (string, error) {
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return "", fmt.Errorf("error generating keystore password: %w", err)
+	}
+	encryptor := eth2ks.New(eth2ks.WithCipher("scrypt"))
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting validator key: %w", err)
+	}
+	keystoreBytes, err := json.Marshal(splitKeystore{
+		Crypto:  encryptedKey,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    "",
+		Pubkey:  rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding validator keystore: %w", err)
+	}
+
+	keyDir := filepath.Join(clusterDir, splitKeysDir)
+	if err := os.MkdirAll(keyDir, DirMode); err != nil {
+		return "", fmt.Errorf("error creating keystore folder: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(keyDir, keystoreFileName), keystoreBytes, FileMode); err != nil {
+		os.RemoveAll(keyDir)
+		return "", fmt.Errorf("error writing validator keystore: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(keyDir, passwordFileName), []byte(password), FileMode); err != nil {
+		os.RemoveAll(keyDir)
+		return "", fmt.Errorf("error writing keystore password: %w", err)
+	}
+	return keyDir, nil
+}
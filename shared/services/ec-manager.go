@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -13,7 +14,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/accesslog"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -29,6 +33,12 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// Guards the fallback client and the readiness flags, which the config reloader and the status checks change while requests are running
+	lock sync.RWMutex
+
+	// Set when transactions should be simulated instead of sent
+	simulator *simulation.Simulator
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -50,28 +60,16 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 	}
 
 	// Get the fallback EC url, if applicable
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-		} else {
-			cc, _ := cfg.GetSelectedConsensusClient()
-			switch cc {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackEcUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
-			default:
-				fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-			}
-		}
-	}
+	fallbackEcUrl = getFallbackEcUrl(cfg)
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primaryEc, err := dialEthClient(primaryEcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
 
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackEc, err = dialEthClient(fallbackEcUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
@@ -82,13 +80,87 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		fallbackEcUrl: fallbackEcUrl,
 		primaryEc:     primaryEc,
 		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
+		logger:        log.NewModuleLogger("ec-manager", color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
 	}, nil
 
 }
 
+// Get the URL of the fallback EC from the config, or an empty string if there isn't one
+func getFallbackEcUrl(cfg *config.RocketPoolConfig) string {
+	if cfg.UseFallbackClients.Value == false {
+		return ""
+	}
+	if cfg.IsNativeMode {
+		return cfg.FallbackNormal.EcHttpUrl.Value.(string)
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Prysm:
+		return cfg.FallbackPrysm.EcHttpUrl.Value.(string)
+	default:
+		return cfg.FallbackNormal.EcHttpUrl.Value.(string)
+	}
+}
+
+// Reconnect to the fallback EC if its URL has changed in the config.
+// Returns true if the fallback was changed.
+func (p *ExecutionClientManager) UpdateFallbackClient(cfg *config.RocketPoolConfig) (bool, error) {
+	fallbackEcUrl := getFallbackEcUrl(cfg)
+	p.lock.RLock()
+	currentUrl := p.fallbackEcUrl
+	p.lock.RUnlock()
+	if fallbackEcUrl == currentUrl {
+		return false, nil
+	}
+
+	var fallbackEc *ethclient.Client
+	if fallbackEcUrl != "" {
+		var err error
+		fallbackEc, err = dialEthClient(fallbackEcUrl)
+		if err != nil {
+			return false, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+		}
+	}
+
+	p.lock.Lock()
+	oldFallbackEc := p.fallbackEc
+	p.fallbackEcUrl = fallbackEcUrl
+	p.fallbackEc = fallbackEc
+	p.fallbackReady = fallbackEc != nil
+	p.lock.Unlock()
+
+	// Requests that already picked the old client fail over like any other disconnection
+	if oldFallbackEc != nil {
+		oldFallbackEc.Close()
+	}
+	return true, nil
+}
+
+// Get the fallback client and whether each client is ready
+func (p *ExecutionClientManager) getState() (*ethclient.Client, bool, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.fallbackEc, p.primaryReady, p.fallbackReady
+}
+
+// Flag the primary client as ready or unavailable
+func (p *ExecutionClientManager) setPrimaryReady(ready bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.primaryReady = ready
+}
+
+// Flag a fallback client as ready or unavailable, unless it has been replaced since it was checked
+func (p *ExecutionClientManager) setFallbackReady(fallbackEc *ethclient.Client, ready bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.fallbackEc == fallbackEc {
+		p.fallbackReady = ready
+	}
+}
+
 /// ========================
 /// ContractCaller Functions
 /// ========================
@@ -96,7 +168,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "CodeAt", func(client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -108,7 +180,7 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "CallContract", func(client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
 	if err != nil {
@@ -123,7 +195,7 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "HeaderByHash", func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
 	})
 	if err != nil {
@@ -135,7 +207,7 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "HeaderByNumber", func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
 	})
 	if err != nil {
@@ -146,7 +218,7 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "PendingCodeAt", func(client *ethclient.Client) (interface{}, error) {
 		return client.PendingCodeAt(ctx, account)
 	})
 	if err != nil {
@@ -157,7 +229,7 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "PendingNonceAt", func(client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
@@ -169,7 +241,7 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "SuggestGasPrice", func(client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
@@ -181,7 +253,7 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "SuggestGasTipCap", func(client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasTipCap(ctx)
 	})
 	if err != nil {
@@ -190,13 +262,25 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the base fees and the given priority fee percentiles of the
+// blockCount blocks up to lastBlock (or the latest block if lastBlock is nil).
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(ctx, "FeeHistory", func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "EstimateGas", func(client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
@@ -207,7 +291,10 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	if p.simulator != nil {
+		return p.simulator.Simulate(ctx, tx)
+	}
+	_, err := p.runFunction(ctx, "SendTransaction", func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -222,7 +309,7 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "FilterLogs", func(client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -234,7 +321,7 @@ func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.
 // SubscribeFilterLogs creates a background log filtering operation, returning
 // a subscription immediately, which can be used to stream the found events.
 func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "SubscribeFilterLogs", func(client *ethclient.Client) (interface{}, error) {
 		return client.SubscribeFilterLogs(ctx, query, ch)
 	})
 	if err != nil {
@@ -250,7 +337,7 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "TransactionReceipt", func(client *ethclient.Client) (interface{}, error) {
 		return client.TransactionReceipt(ctx, txHash)
 	})
 	if err != nil {
@@ -265,7 +352,7 @@ func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash
 
 // BlockNumber returns the most recent block number
 func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "BlockNumber", func(client *ethclient.Client) (interface{}, error) {
 		return client.BlockNumber(ctx)
 	})
 	if err != nil {
@@ -277,7 +364,7 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "BalanceAt", func(client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -288,7 +375,7 @@ func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.A
 
 // TransactionByHash returns the transaction with the given hash.
 func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "TransactionByHash", func(client *ethclient.Client) (interface{}, error) {
 		tx, isPending, err := client.TransactionByHash(ctx, hash)
 		result := []interface{}{tx, isPending}
 		return result, err
@@ -307,7 +394,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "NonceAt", func(client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -319,7 +406,7 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, "SyncProgress", func(client *ethclient.Client) (interface{}, error) {
 		return client.SyncProgress(ctx)
 	})
 	if err != nil {
@@ -328,23 +415,58 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// PeerCount returns the number of p2p peers the client is connected to.
+func (p *ExecutionClientManager) PeerCount(ctx context.Context) (uint64, error) {
+	result, err := p.runFunction(ctx, "PeerCount", func(client *ethclient.Client) (interface{}, error) {
+		return client.PeerCount(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+/// ===================
+/// Failover Functions
+/// ===================
+
+// Returns true if the manager is currently routing requests to the fallback client
+func (p *ExecutionClientManager) IsUsingFallback() bool {
+	_, primaryReady, fallbackReady := p.getState()
+	return !primaryReady && fallbackReady
+}
+
+// Get the URL of the client that requests are currently routed to, or an empty string if no client is ready
+func (p *ExecutionClientManager) GetActiveProvider() string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.primaryReady {
+		return p.primaryEcUrl
+	}
+	if p.fallbackReady {
+		return p.fallbackEcUrl
+	}
+	return ""
+}
+
 /// ==================
 /// Internal functions
 /// ==================
 
 func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.ClientManagerStatus {
 
+	fallbackEc, primaryReady, fallbackReady := p.getState()
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: p.fallbackEc != nil,
+		FallbackEnabled: fallbackEc != nil,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if p.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = p.primaryReady
-		status.PrimaryClientStatus.IsSynced = p.primaryReady
+		status.PrimaryClientStatus.IsWorking = primaryReady
+		status.PrimaryClientStatus.IsSynced = primaryReady
 		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = p.fallbackReady
-			status.FallbackClientStatus.IsSynced = p.fallbackReady
+			status.FallbackClientStatus.IsWorking = fallbackReady
+			status.FallbackClientStatus.IsSynced = fallbackReady
 		}
 		return status
 	}
@@ -353,15 +475,15 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
 
 	// Flag if primary client is ready
-	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	p.setPrimaryReady(status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
+		status.FallbackClientStatus = checkEcStatus(fallbackEc)
 		// Check if fallback is using the expected network
 		expectedChainID := cfg.Smartnode.GetChainID()
 		if status.FallbackClientStatus.NetworkId != expectedChainID {
-			p.fallbackReady = false
+			p.setFallbackReady(fallbackEc, false)
 			colorReset := "\033[0m"
 			colorYellow := "\033[33m"
 			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", colorYellow, getNetworkNameFromId(status.FallbackClientStatus.NetworkId), colorReset, status.FallbackClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
@@ -369,7 +491,7 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 		}
 	}
 
-	p.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+	p.setFallbackReady(fallbackEc, status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
 
 	return status
 }
@@ -456,18 +578,19 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
+func (p *ExecutionClientManager) runFunction(ctx context.Context, method string, function ecFunction) (interface{}, error) {
 
 	// Check if we can use the primary
-	if p.primaryReady {
+	fallbackEc, primaryReady, fallbackReady := p.getState()
+	if primaryReady {
 		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
+		result, err := runEcFunction(ctx, method, function, p.primaryEc, true)
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
-				return p.runFunction(function)
+				p.logger.Warnf("Primary Execution client disconnected (%s), using fallback...", err.Error())
+				p.setPrimaryReady(false)
+				return p.runFunction(ctx, method, function)
 			}
 
 			// If it's a different error, just return it
@@ -478,14 +601,14 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
-	if p.fallbackReady {
+	if fallbackReady {
 		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
+		result, err := runEcFunction(ctx, method, function, fallbackEc, false)
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
-				p.fallbackReady = false
+				p.logger.Warnf("Fallback Execution client disconnected (%s)", err.Error())
+				p.setFallbackReady(fallbackEc, false)
 				return nil, fmt.Errorf("all Execution clients failed")
 			}
 
@@ -500,6 +623,27 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 	return nil, fmt.Errorf("no Execution clients were ready")
 }
 
+// Run a function on one of the clients, unless fault injection drops the call
+func runEcFunction(ctx context.Context, method string, function ecFunction, client *ethclient.Client, isPrimary bool) (interface{}, error) {
+	start := time.Now()
+	err := faults.DropEcCall(isPrimary)
+	if err != nil {
+		accesslog.Record(ctx, accesslog.ClientType_Execution, getEndpointName(isPrimary), method, start, err)
+		return nil, err
+	}
+	result, err := function(client)
+	accesslog.Record(ctx, accesslog.ClientType_Execution, getEndpointName(isPrimary), method, start, err)
+	return result, err
+}
+
+// Get the name of a primary or fallback client for the access log
+func getEndpointName(isPrimary bool) string {
+	if isPrimary {
+		return "primary"
+	}
+	return "fallback"
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
@@ -0,0 +1,127 @@
+//go:build faults
+// +build faults
+
+package faults
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables that control which faults are injected
+const (
+	// Which Execution clients should have their calls dropped (primary, fallback, or all)
+	DropEcEnvVar string = "ROCKETPOOL_FAULT_DROP_EC"
+
+	// How long to delay every Beacon client response, e.g. "5s"
+	BcDelayEnvVar string = "ROCKETPOOL_FAULT_BC_DELAY"
+
+	// Which Beacon clients the delay applies to (primary, fallback, or all); defaults to all
+	BcDelayTargetEnvVar string = "ROCKETPOOL_FAULT_BC_DELAY_TARGET"
+
+	// A comma-separated list of metrics collectors that should fail on every scrape, e.g. "demand,beacon"
+	FailCollectorEnvVar string = "ROCKETPOOL_FAULT_FAIL_COLLECTOR"
+)
+
+// The faults loaded from the environment
+type settings struct {
+	dropEc           Target
+	bcDelay          time.Duration
+	bcDelayTarget    Target
+	failedCollectors map[string]bool
+}
+
+var (
+	loadOnce sync.Once
+	loaded   settings
+)
+
+// Returns true since this binary was built with fault injection support
+func Enabled() bool {
+	return true
+}
+
+// Get the error to return instead of running an Execution client call, or nil if the call should go through
+func DropEcCall(isPrimary bool) error {
+	s := getSettings()
+	if !s.dropEc.matches(isPrimary) {
+		return nil
+	}
+
+	// Mimic a refused connection so the client managers treat it like a real outage
+	return fmt.Errorf("dial tcp: connection refused (injected fault, %s client)", clientName(isPrimary))
+}
+
+// Wait for the configured delay before letting a Beacon client response through
+func DelayBcResponse(isPrimary bool) {
+	s := getSettings()
+	if s.bcDelay > 0 && s.bcDelayTarget.matches(isPrimary) {
+		time.Sleep(s.bcDelay)
+	}
+}
+
+// Get the error a metrics collector should fail with on every scrape, or nil if it should run normally
+func FailCollector(name string) error {
+	s := getSettings()
+	if !s.failedCollectors[name] {
+		return nil
+	}
+	return fmt.Errorf("injected fault for collector %s", name)
+}
+
+// Load the faults from the environment the first time they're needed
+func getSettings() settings {
+	loadOnce.Do(func() {
+		loaded = settings{
+			dropEc:           parseTarget(DropEcEnvVar, Target_None),
+			bcDelayTarget:    parseTarget(BcDelayTargetEnvVar, Target_All),
+			failedCollectors: map[string]bool{},
+		}
+
+		delayString := os.Getenv(BcDelayEnvVar)
+		if delayString != "" {
+			delay, err := time.ParseDuration(delayString)
+			if err != nil {
+				fmt.Printf("WARNING: Couldn't parse %s [%s] (%s), Beacon responses will not be delayed\n", BcDelayEnvVar, delayString, err.Error())
+			} else {
+				loaded.bcDelay = delay
+			}
+		}
+
+		for _, name := range strings.Split(os.Getenv(FailCollectorEnvVar), ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				loaded.failedCollectors[name] = true
+			}
+		}
+
+		fmt.Printf("WARNING: Fault injection is active (drop EC calls: %s, BC delay: %s on %s, failed collectors: %s)\n",
+			loaded.dropEc, loaded.bcDelay, loaded.bcDelayTarget, os.Getenv(FailCollectorEnvVar))
+	})
+	return loaded
+}
+
+// Parse a target from an environment variable, using the default if it isn't set
+func parseTarget(envVar string, defaultTarget Target) Target {
+	value := Target(strings.ToLower(strings.TrimSpace(os.Getenv(envVar))))
+	switch value {
+	case "":
+		return defaultTarget
+	case Target_None, Target_Primary, Target_Fallback, Target_All:
+		return value
+	default:
+		fmt.Printf("WARNING: Unknown value for %s [%s], expected primary, fallback, all, or none\n", envVar, value)
+		return Target_None
+	}
+}
+
+// Get a readable name for a client
+func clientName(isPrimary bool) string {
+	if isPrimary {
+		return "primary"
+	}
+	return "fallback"
+}
@@ -0,0 +1,23 @@
+//go:build !faults
+// +build !faults
+
+package faults
+
+// Returns false since this binary was built without fault injection support
+func Enabled() bool {
+	return false
+}
+
+// Fault injection is compiled out, so Execution client calls always go through
+func DropEcCall(isPrimary bool) error {
+	return nil
+}
+
+// Fault injection is compiled out, so Beacon client responses are never delayed
+func DelayBcResponse(isPrimary bool) {
+}
+
+// Fault injection is compiled out, so collectors always run normally
+func FailCollector(name string) error {
+	return nil
+}
@@ -0,0 +1,30 @@
+// Package faults injects failures into the Smartnode's client and metrics layers so resilience features such as
+// client failover and retries can be exercised deterministically in integration tests and staging.
+//
+// The hooks are no-ops unless the binary is built with the "faults" build tag (e.g. `go build -tags faults`);
+// the faults themselves are then selected with the ROCKETPOOL_FAULT_* environment variables.
+package faults
+
+// Which of the primary and fallback clients a fault applies to
+type Target string
+
+const (
+	Target_None     Target = "none"
+	Target_Primary  Target = "primary"
+	Target_Fallback Target = "fallback"
+	Target_All      Target = "all"
+)
+
+// Returns true if the fault applies to the given client
+func (t Target) matches(isPrimary bool) bool {
+	switch t {
+	case Target_All:
+		return true
+	case Target_Primary:
+		return isPrimary
+	case Target_Fallback:
+		return !isPrimary
+	default:
+		return false
+	}
+}
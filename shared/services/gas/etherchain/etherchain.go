@@ -6,6 +6,8 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 const gasNowUrl string = "https://beaconcha.in/api/v1/execution/gasnow"
@@ -41,7 +43,7 @@ type GasFeeSuggestion struct {
 func GetGasPrices() (GasFeeSuggestion, error) {
 
 	// Send request
-	response, err := http.Get(gasNowUrl)
+	response, err := net.GetHttpClient(net.HttpTarget_GasOracles).Get(gasNowUrl)
 	if err != nil {
 		return GasFeeSuggestion{}, err
 	}
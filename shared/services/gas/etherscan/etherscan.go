@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 const gasOracleUrl string = "https://api.etherscan.io/api?module=gastracker&action=gasoracle"
@@ -31,7 +33,7 @@ type GasFeeSuggestion struct {
 func GetGasPrices() (GasFeeSuggestion, error) {
 
 	// Send request
-	response, err := http.Get(gasOracleUrl)
+	response, err := net.GetHttpClient(net.HttpTarget_GasOracles).Get(gasOracleUrl)
 	if err != nil {
 		return GasFeeSuggestion{}, err
 	}
@@ -0,0 +1,143 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// Settings
+const (
+	// The number of recent blocks to sample
+	blockCount uint64 = 20
+
+	// How much the base fee has to move over the sampled blocks to count as a trend, in percent
+	trendThreshold float64 = 5
+)
+
+// The priority fee percentiles to sample, from slowest to fastest
+var rewardPercentiles = []float64{25, 50, 75, 90}
+
+// A client that can provide the fee history of recent blocks (eth_feeHistory)
+type FeeHistoryProvider interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// The direction the base fee has been moving in
+type BaseFeeTrend string
+
+const (
+	BaseFeeTrend_Rising  BaseFeeTrend = "rising"
+	BaseFeeTrend_Falling BaseFeeTrend = "falling"
+	BaseFeeTrend_Steady  BaseFeeTrend = "steady"
+)
+
+// EIP-1559 fee suggestions derived from the Execution client's recent fee history.
+// The max fees include their priority fees, and leave headroom for the base fee to rise over the next few blocks.
+type GasFeeSuggestion struct {
+	BaseFeeWei          *big.Int     `json:"baseFeeWei"`
+	BaseFeeTrend        BaseFeeTrend `json:"baseFeeTrend"`
+	BaseFeeChangePct    float64      `json:"baseFeeChangePct"`
+	BlocksSampled       uint64       `json:"blocksSampled"`
+	RapidWei            *big.Int     `json:"rapidWei"`
+	RapidPriorityWei    *big.Int     `json:"rapidPriorityWei"`
+	FastWei             *big.Int     `json:"fastWei"`
+	FastPriorityWei     *big.Int     `json:"fastPriorityWei"`
+	StandardWei         *big.Int     `json:"standardWei"`
+	StandardPriorityWei *big.Int     `json:"standardPriorityWei"`
+	SlowWei             *big.Int     `json:"slowWei"`
+	SlowPriorityWei     *big.Int     `json:"slowPriorityWei"`
+}
+
+// Get gas prices from the fee history of the latest blocks
+func GetGasPrices(client FeeHistoryProvider) (GasFeeSuggestion, error) {
+
+	// Get the fee history
+	history, err := client.FeeHistory(context.Background(), blockCount, nil, rewardPercentiles)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Could not get the fee history from the Execution client: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("The Execution client returned an empty fee history")
+	}
+
+	// The last base fee is the one for the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	oldestBaseFee := history.BaseFee[0]
+	changePct := float64(0)
+	if oldestBaseFee.Sign() > 0 {
+		change := new(big.Float).SetInt(new(big.Int).Sub(baseFee, oldestBaseFee))
+		change.Quo(change, new(big.Float).SetInt(oldestBaseFee))
+		changePct, _ = change.Float64()
+		changePct *= 100
+	}
+	trend := BaseFeeTrend_Steady
+	if changePct >= trendThreshold {
+		trend = BaseFeeTrend_Rising
+	} else if changePct <= -trendThreshold {
+		trend = BaseFeeTrend_Falling
+	}
+
+	// Get the median of each priority fee percentile across the sampled blocks
+	priorityFees := make([]*big.Int, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		samples := []*big.Int{}
+		for _, blockRewards := range history.Reward {
+			if i < len(blockRewards) && blockRewards[i] != nil {
+				samples = append(samples, blockRewards[i])
+			}
+		}
+		priorityFees[i] = median(samples)
+	}
+
+	// Make sure faster suggestions never tip less than slower ones
+	for i := 1; i < len(priorityFees); i++ {
+		if priorityFees[i].Cmp(priorityFees[i-1]) < 0 {
+			priorityFees[i] = new(big.Int).Set(priorityFees[i-1])
+		}
+	}
+
+	return GasFeeSuggestion{
+		BaseFeeWei:          new(big.Int).Set(baseFee),
+		BaseFeeTrend:        trend,
+		BaseFeeChangePct:    changePct,
+		BlocksSampled:       uint64(len(history.Reward)),
+		SlowPriorityWei:     priorityFees[0],
+		SlowWei:             getMaxFee(baseFee, 100, priorityFees[0]),
+		StandardPriorityWei: priorityFees[1],
+		StandardWei:         getMaxFee(baseFee, 125, priorityFees[1]),
+		FastPriorityWei:     priorityFees[2],
+		FastWei:             getMaxFee(baseFee, 150, priorityFees[2]),
+		RapidPriorityWei:    priorityFees[3],
+		RapidWei:            getMaxFee(baseFee, 200, priorityFees[3]),
+	}, nil
+
+}
+
+// Get a max fee that covers the base fee growing to the given percentage, plus the priority fee
+func getMaxFee(baseFee *big.Int, basePct int64, priorityFee *big.Int) *big.Int {
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(basePct))
+	maxFee.Div(maxFee, big.NewInt(100))
+	return maxFee.Add(maxFee, priorityFee)
+}
+
+// Get the median of a set of values, or zero if there are none
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return new(big.Int).Set(sorted[middle])
+	}
+	result := new(big.Int).Add(sorted[middle-1], sorted[middle])
+	return result.Div(result, big.NewInt(2))
+}
@@ -7,9 +7,12 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -27,6 +30,22 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 	if isNew {
 		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
 	}
+	oracle := getGasOracle(cfg)
+
+	// Get the fee suggestions from the Execution client's fee history, at most once
+	var feeSuggestion *feehistory.GasFeeSuggestion
+	var feeSuggestionErr error
+	getEcSuggestion := func() (*feehistory.GasFeeSuggestion, error) {
+		if feeSuggestion == nil && feeSuggestionErr == nil {
+			response, err := rp.FeeSuggestion()
+			if err != nil {
+				feeSuggestionErr = err
+			} else {
+				feeSuggestion = &response.Suggestion
+			}
+		}
+		return feeSuggestion, feeSuggestionErr
+	}
 
 	// Get the current settings from the CLI arguments
 	maxFeeGwei, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
@@ -39,14 +58,17 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		}
 	}
 
-	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
+	// Get the priority fee - prioritize the CLI arguments, then the config file setting, then the recent priority fees on the network
 	if maxPriorityFeeGwei == 0 {
 		maxPriorityFee := eth.GweiToWei(cfg.Smartnode.PriorityFee.Value.(float64))
-		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
+		if maxPriorityFee != nil && maxPriorityFee.Uint64() != 0 {
+			maxPriorityFeeGwei = eth.WeiToGwei(maxPriorityFee)
+		} else if suggestion, err := getEcSuggestion(); err == nil && suggestion.StandardPriorityWei.Sign() > 0 {
+			maxPriorityFeeGwei = math.RoundUp(eth.WeiToGwei(suggestion.StandardPriorityWei), 2)
+			fmt.Printf("%sNOTE: max priority fee not set, using the median priority fee of recent blocks (%.2f gwei)%s\n", colorYellow, maxPriorityFeeGwei, colorReset)
+		} else {
 			fmt.Printf("%sNOTE: max priority fee not set or set to 0, defaulting to 2 gwei%s\n", colorYellow, colorReset)
 			maxPriorityFeeGwei = 2
-		} else {
-			maxPriorityFeeGwei = eth.WeiToGwei(maxPriorityFee)
 		}
 	}
 
@@ -67,28 +89,21 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 	} else {
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
+			maxFeeWei, err := getHeadlessMaxFeeWei(oracle, func() (*big.Int, error) {
+				suggestion, err := getEcSuggestion()
+				if err != nil {
+					return nil, err
+				}
+				return suggestion.RapidWei, nil
+			})
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
-			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, gasInfo, maxPriorityFeeGwei, gasLimit)
-
-			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-				etherscanData, err := etherscan.GetGasPrices()
-				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, gasInfo, maxPriorityFeeGwei, gasLimit)
-				} else {
-					return fmt.Errorf("Error getting gas price suggestions: %w", err)
-				}
+			maxFeeGwei, err = promptForMaxFee(oracle, getEcSuggestion, gasInfo, maxPriorityFeeGwei, gasLimit)
+			if err != nil {
+				return err
 			}
 		}
 		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", colorBlue, maxFeeGwei, maxPriorityFeeGwei, colorReset)
@@ -123,8 +138,46 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 }
 
-// Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
+// Get the suggested max fee for service operations, using the configured gas oracle
+func GetHeadlessMaxFeeWei(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient) (*big.Int, error) {
+	return getHeadlessMaxFeeWei(getGasOracle(cfg), func() (*big.Int, error) {
+		provider, ok := ec.(feehistory.FeeHistoryProvider)
+		if !ok {
+			return nil, fmt.Errorf("the Execution client doesn't provide fee history")
+		}
+		suggestion, err := feehistory.GetGasPrices(provider)
+		if err != nil {
+			return nil, err
+		}
+		return suggestion.RapidWei, nil
+	})
+}
+
+// Get the suggested max fee for service operations from the selected oracle, falling back to the other one if it's unavailable
+func getHeadlessMaxFeeWei(oracle cfgtypes.GasOracle, getEcMaxFee func() (*big.Int, error)) (*big.Int, error) {
+	if oracle != cfgtypes.GasOracle_External {
+		maxFee, err := getEcMaxFee()
+		if err == nil {
+			return maxFee, nil
+		}
+		fmt.Printf("%sWarning: couldn't get gas estimates from the Execution client's fee history - %s\nFalling back to the external oracles%s\n", colorYellow, err.Error(), colorReset)
+		return getExternalHeadlessMaxFeeWei()
+	}
+
+	maxFee, err := getExternalHeadlessMaxFeeWei()
+	if err == nil {
+		return maxFee, nil
+	}
+	fmt.Printf("%sWarning: %s\nFalling back to the Execution client's fee history%s\n", colorYellow, err.Error(), colorReset)
+	maxFee, ecErr := getEcMaxFee()
+	if ecErr != nil {
+		return nil, fmt.Errorf("Error getting gas price suggestions: %w", ecErr)
+	}
+	return maxFee, nil
+}
+
+// Get the suggested max fee for service operations from Etherchain or Etherscan
+func getExternalHeadlessMaxFeeWei() (*big.Int, error) {
 	etherchainData, err := etherchain.GetGasPrices()
 	if err == nil {
 		return etherchainData.RapidWei, nil
@@ -139,6 +192,97 @@ func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
 }
 
+// Print the suggestions from the selected oracle and ask the user for a max fee, falling back to the other oracle if it's unavailable
+func promptForMaxFee(oracle cfgtypes.GasOracle, getEcSuggestion func() (*feehistory.GasFeeSuggestion, error), gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) (float64, error) {
+	if oracle != cfgtypes.GasOracle_External {
+		suggestion, err := getEcSuggestion()
+		if err == nil {
+			return handleFeeHistoryGasPrices(*suggestion, gasInfo, priorityFee, gasLimit), nil
+		}
+		fmt.Printf("%sWarning: couldn't get gas estimates from the Execution client's fee history - %s\nFalling back to Etherchain%s\n", colorYellow, err.Error(), colorReset)
+	}
+
+	// Try to get the latest gas prices from Etherchain
+	etherchainData, err := etherchain.GetGasPrices()
+	if err == nil {
+		return handleEtherchainGasPrices(etherchainData, gasInfo, priorityFee, gasLimit), nil
+	}
+
+	// Fallback to Etherscan
+	fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
+	etherscanData, err := etherscan.GetGasPrices()
+	if err == nil {
+		return handleEtherscanGasPrices(etherscanData, gasInfo, priorityFee, gasLimit), nil
+	}
+	if oracle != cfgtypes.GasOracle_External {
+		return 0, fmt.Errorf("Error getting gas price suggestions: %w", err)
+	}
+
+	// Fallback to the Execution client
+	fmt.Printf("%sWarning: couldn't get gas estimates from Etherscan - %s\nFalling back to the Execution client's fee history%s\n", colorYellow, err.Error(), colorReset)
+	suggestion, err := getEcSuggestion()
+	if err != nil {
+		return 0, fmt.Errorf("Error getting gas price suggestions: %w", err)
+	}
+	return handleFeeHistoryGasPrices(*suggestion, gasInfo, priorityFee, gasLimit), nil
+}
+
+// Get the gas oracle selected in the config
+func getGasOracle(cfg *config.RocketPoolConfig) cfgtypes.GasOracle {
+	oracle, ok := cfg.Smartnode.GasOracle.Value.(cfgtypes.GasOracle)
+	if !ok {
+		return cfgtypes.GasOracle_ExecutionClient
+	}
+	return oracle
+}
+
+func handleFeeHistoryGasPrices(gasSuggestion feehistory.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
+
+	// Each tier leaves room for the base fee to rise by a different amount before the transaction stops being includable
+	baseFeeGwei := eth.WeiToGwei(gasSuggestion.BaseFeeWei)
+	tiers := []struct {
+		name       string
+		multiplier float64
+	}{
+		{"Rapid", 2},
+		{"Fast", 1.5},
+		{"Standard", 1.25},
+		{"Slow", 1},
+	}
+
+	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
+	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
+	var fastGwei float64
+	for _, tier := range tiers {
+		tierGwei := math.RoundUp(baseFeeGwei*tier.multiplier+priorityFee, 0)
+		tierEth := tierGwei / eth.WeiPerGwei
+
+		var lowLimit float64
+		var highLimit float64
+		if gasLimit == 0 {
+			lowLimit = tierEth * float64(gasInfo.EstGasLimit)
+			highLimit = tierEth * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowLimit = tierEth * float64(gasLimit)
+			highLimit = lowLimit
+		}
+		fmt.Printf("| %-9s | %-9s | %.4f to %.4f ETH |\n",
+			tier.name, fmt.Sprintf("%d gwei", int(tierGwei)), lowLimit, highLimit)
+		if tier.name == "Fast" {
+			fastGwei = tierGwei
+		}
+	}
+	fmt.Printf("+==============================================+\n\n%s", colorReset)
+
+	fmt.Printf("The base fee for the next block is %.2f gwei (%s, %+.1f%% over the last %d blocks).\n", baseFeeGwei, gasSuggestion.BaseFeeTrend, gasSuggestion.BaseFeeChangePct, gasSuggestion.BlocksSampled)
+	fmt.Printf("Recent priority fees: %.2f gwei (slow), %.2f gwei (standard), %.2f gwei (fast).\n",
+		eth.WeiToGwei(gasSuggestion.SlowPriorityWei), eth.WeiToGwei(gasSuggestion.StandardPriorityWei), eth.WeiToGwei(gasSuggestion.FastPriorityWei))
+	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
+
+	return promptForDesiredMaxFee(fastGwei)
+
+}
+
 func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
 	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
@@ -207,28 +351,7 @@ func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInf
 
 	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
 
-	for {
-		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
-			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
-			"Not a valid gas price, try again:")
-
-		if desiredPrice == "" {
-			return fastGwei
-		}
-
-		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
-		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
-			continue
-		}
-		if desiredPriceFloat <= 0 {
-			fmt.Println("Max fee must be greater than zero.")
-			continue
-		}
-
-		return desiredPriceFloat
-	}
+	return promptForDesiredMaxFee(fastGwei)
 
 }
 
@@ -285,19 +408,25 @@ func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo
 
 	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
 
+	return promptForDesiredMaxFee(fastGwei)
+
+}
+
+// Ask the user for a max fee, defaulting to the given one
+func promptForDesiredMaxFee(defaultGwei float64) float64 {
 	for {
 		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(defaultGwei)),
 			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
 			"Not a valid gas price, try again:")
 
 		if desiredPrice == "" {
-			return fastGwei
+			return defaultGwei
 		}
 
 		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
 		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
 			continue
 		}
 		if desiredPriceFloat <= 0 {
@@ -0,0 +1,143 @@
+package graffiti
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The maximum length of a block's graffiti, in bytes
+const MaxLength int = 32
+
+// The template used when the node doesn't have one
+const defaultTemplate string = "{graffiti}"
+
+// Everything needed to work out the graffiti of the node's validators
+type Settings struct {
+	Template           string
+	Variables          map[string]string
+	Messages           []string
+	RotationInterval   time.Duration
+	ValidatorTemplates map[types.ValidatorPubkey]string
+}
+
+// Load the graffiti settings from the config and the per-validator graffiti file
+func LoadSettings(cfg *config.RocketPoolConfig) (*Settings, error) {
+	rotationInterval, err := cfg.Smartnode.GetGraffitiRotationInterval()
+	if err != nil {
+		return nil, fmt.Errorf("error getting graffiti rotation interval: %w", err)
+	}
+	validatorTemplates, err := LoadValidatorGraffiti(cfg.Smartnode.GetValidatorGraffitiPath())
+	if err != nil {
+		return nil, err
+	}
+	template := strings.TrimSpace(cfg.Smartnode.GraffitiTemplate.Value.(string))
+	if template == "" {
+		template = defaultTemplate
+	}
+	return &Settings{
+		Template:           template,
+		Variables:          cfg.GetGraffitiVariables(),
+		Messages:           cfg.Smartnode.GetGraffitiMessages(),
+		RotationInterval:   rotationInterval,
+		ValidatorTemplates: validatorTemplates,
+	}, nil
+}
+
+// Get the template a validator uses, and whether it's specific to that validator
+func (s *Settings) GetTemplate(pubkey types.ValidatorPubkey) (string, bool) {
+	if template, exists := s.ValidatorTemplates[pubkey]; exists {
+		return template, true
+	}
+	return s.Template, false
+}
+
+// Get the graffiti a validator should use at the given time; the index is left blank if the validator doesn't have one yet
+func (s *Settings) GetGraffiti(pubkey types.ValidatorPubkey, index string, now time.Time) string {
+	template, _ := s.GetTemplate(pubkey)
+	variables := map[string]string{}
+	for name, value := range s.Variables {
+		variables[name] = value
+	}
+	variables["index"] = index
+	variables["message"] = GetMessage(s.Messages, s.RotationInterval, now)
+	return Render(template, variables)
+}
+
+// Fill in the variables in a graffiti template, such as `{nickname}`, and cut the result down to the maximum length.
+// Unknown variables are left as they are.
+func Render(template string, variables map[string]string) string {
+	replacements := []string{}
+	for name, value := range variables {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	graffiti := strings.NewReplacer(replacements...).Replace(template)
+	graffiti = strings.TrimSpace(graffiti)
+	for len(graffiti) > MaxLength {
+		_, size := utf8.DecodeLastRuneInString(graffiti)
+		graffiti = graffiti[:len(graffiti)-size]
+	}
+	return graffiti
+}
+
+// Get the message to use at the given time, moving to the next one after each interval
+func GetMessage(messages []string, interval time.Duration, now time.Time) string {
+	if len(messages) == 0 || interval <= 0 {
+		return ""
+	}
+	index := (now.Unix() / int64(interval.Seconds())) % int64(len(messages))
+	return messages[index]
+}
+
+// Load the graffiti templates set for individual validators
+func LoadValidatorGraffiti(path string) (map[types.ValidatorPubkey]string, error) {
+	templates := map[types.ValidatorPubkey]string{}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator graffiti file [%s]: %w", path, err)
+	}
+
+	var entries map[string]string
+	if err := yaml.Unmarshal(bytes, &entries); err != nil {
+		return nil, fmt.Errorf("error deserializing validator graffiti file [%s]: %w", path, err)
+	}
+	for pubkeyString, template := range entries {
+		pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(pubkeyString, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("validator graffiti file [%s] has an invalid pubkey '%s': %w", path, pubkeyString, err)
+		}
+		templates[pubkey] = template
+	}
+	return templates, nil
+}
+
+// Save the graffiti templates set for individual validators
+func SaveValidatorGraffiti(path string, templates map[types.ValidatorPubkey]string) error {
+	entries := map[string]string{}
+	for pubkey, template := range templates {
+		entries[pubkey.Hex()] = template
+	}
+	bytes, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("error serializing validator graffiti: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating validator graffiti directory: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing validator graffiti file [%s]: %w", path, err)
+	}
+	return nil
+}
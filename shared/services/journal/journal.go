@@ -0,0 +1,206 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Settings
+const (
+	// The size the journal can grow to before it's rotated
+	maxJournalSize int64 = 10 * 1024 * 1024
+
+	// The number of rotated journal files to keep
+	maxRotatedJournals int = 5
+)
+
+// The kind of event recorded in the journal
+type EventType string
+
+const (
+	EventType_WalletSaved          EventType = "wallet_saved"
+	EventType_MinipoolCreated      EventType = "minipool_created"
+	EventType_MinipoolStaked       EventType = "minipool_staked"
+	EventType_MinipoolExited       EventType = "minipool_exited"
+	EventType_RewardsClaimed       EventType = "rewards_claimed"
+	EventType_RewardsForwarded     EventType = "rewards_forwarded"
+	EventType_FeeDistributorSwept  EventType = "fee_distributor_swept"
+	EventType_BondReductionStarted EventType = "bond_reduction_started"
+	EventType_BondReduced          EventType = "bond_reduced"
+	EventType_DaemonError          EventType = "daemon_error"
+)
+
+// All of the event types, in the order they're listed to users
+var EventTypes = []EventType{
+	EventType_WalletSaved,
+	EventType_MinipoolCreated,
+	EventType_MinipoolStaked,
+	EventType_MinipoolExited,
+	EventType_RewardsClaimed,
+	EventType_RewardsForwarded,
+	EventType_FeeDistributorSwept,
+	EventType_BondReductionStarted,
+	EventType_BondReduced,
+	EventType_DaemonError,
+}
+
+// An event in the journal
+type Event struct {
+	Time      time.Time       `json:"time"`
+	Type      EventType       `json:"type"`
+	Automatic bool            `json:"automatic,omitempty"`
+	Minipool  *common.Address `json:"minipool,omitempty"`
+	TxHash    *common.Hash    `json:"txHash,omitempty"`
+	Message   string          `json:"message"`
+}
+
+// Limits on the events read from the journal; zero values don't filter anything
+type Filter struct {
+	Types []EventType
+	Since time.Time
+	Limit int
+}
+
+// An append-only journal of the node's important lifecycle events, stored as one JSON event per line.
+// It's shared by the daemon and the API, so it's reopened for each event rather than held open.
+type Journal struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a journal backed by the file at the given path
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path: path,
+	}
+}
+
+// Append an event to the journal, rotating it if it's grown too large.
+// The time is filled in if it isn't set.
+func (j *Journal) Record(event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error serializing journal event: %w", err)
+	}
+	line = append(line, '\n')
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("error creating journal directory: %w", err)
+	}
+	if info, err := os.Stat(j.path); err == nil && info.Size()+int64(len(line)) > maxJournalSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening journal [%s]: %w", j.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("error writing journal [%s]: %w", j.path, err)
+	}
+	return nil
+}
+
+// Read the events in the journal that match the filter, oldest first.
+// If the filter has a limit, the most recent events are kept.
+func (j *Journal) Read(filter Filter) ([]Event, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	types := map[EventType]bool{}
+	for _, eventType := range filter.Types {
+		types[eventType] = true
+	}
+
+	// Read the rotated files first since they're older
+	events := []Event{}
+	for i := maxRotatedJournals; i >= 0; i-- {
+		path := j.getRotatedPath(i)
+		fileEvents, err := readJournalFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range fileEvents {
+			if len(types) > 0 && !types[event.Type] {
+				continue
+			}
+			if !filter.Since.IsZero() && event.Time.Before(filter.Since) {
+				continue
+			}
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].Time.Before(events[b].Time)
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+	return events, nil
+}
+
+// Shift each rotated journal up by one, dropping the oldest, and move the current journal into the first slot
+func (j *Journal) rotate() error {
+	_ = os.Remove(j.getRotatedPath(maxRotatedJournals))
+	for i := maxRotatedJournals - 1; i >= 0; i-- {
+		err := os.Rename(j.getRotatedPath(i), j.getRotatedPath(i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error rotating journal [%s]: %w", j.getRotatedPath(i), err)
+		}
+	}
+	return nil
+}
+
+// Get the path of a rotated journal, where 0 is the current one
+func (j *Journal) getRotatedPath(index int) string {
+	if index == 0 {
+		return j.path
+	}
+	return fmt.Sprintf("%s.%d", j.path, index)
+}
+
+// Read all of the events in a journal file, skipping lines that can't be parsed
+func readJournalFile(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading journal [%s]: %w", path, err)
+	}
+	return events, nil
+}
@@ -0,0 +1,250 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// How long to wait for the Validator client to respond
+const requestTimeout time.Duration = 10 * time.Second
+
+// The graffiti routes of the keymanager API
+const graffitiPath string = "/eth/v1/validator/0x%s/graffiti"
+
+// The fee recipient routes of the keymanager API
+const feeRecipientPath string = "/eth/v1/validator/0x%s/feerecipient"
+
+// The gas limit routes of the keymanager API
+const gasLimitPath string = "/eth/v1/validator/0x%s/gas_limit"
+
+// The remote key routes of the keymanager API
+const remoteKeysPath string = "/eth/v1/remotekeys"
+
+// The local keystore routes of the keymanager API
+const keystoresPath string = "/eth/v1/keystores"
+
+// A client for a Validator client's keymanager API (https://ethereum.github.io/keymanager-APIs/)
+type Client struct {
+	url       string
+	tokenPath string
+	client    http.Client
+}
+
+type graffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+type graffitiResponse struct {
+	Data struct {
+		Pubkey   string `json:"pubkey"`
+		Graffiti string `json:"graffiti"`
+	} `json:"data"`
+}
+
+// Create a new keymanager API client.
+// The token is read from its file on each request, since the Validator client can regenerate it when it restarts.
+func NewClient(url string, tokenPath string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		tokenPath: tokenPath,
+		client: http.Client{
+			Timeout: requestTimeout,
+		},
+	}
+}
+
+// Get the graffiti the Validator client uses for a validator
+func (c *Client) GetGraffiti(pubkey types.ValidatorPubkey) (string, error) {
+	responseBody, err := c.request(http.MethodGet, fmt.Sprintf(graffitiPath, pubkey.Hex()), nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	var response graffitiResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return "", fmt.Errorf("error decoding graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.Graffiti, nil
+}
+
+// Set the graffiti the Validator client uses for a validator
+func (c *Client) SetGraffiti(pubkey types.ValidatorPubkey, graffiti string) error {
+	requestBody, err := json.Marshal(graffitiRequest{
+		Graffiti: graffiti,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing graffiti: %w", err)
+	}
+	if _, err := c.request(http.MethodPost, fmt.Sprintf(graffitiPath, pubkey.Hex()), requestBody); err != nil {
+		return fmt.Errorf("error setting graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+type feeRecipientResponse struct {
+	Data struct {
+		Pubkey     string `json:"pubkey"`
+		EthAddress string `json:"ethaddress"`
+	} `json:"data"`
+}
+
+// Get the fee recipient the Validator client uses for a validator
+func (c *Client) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	responseBody, err := c.request(http.MethodGet, fmt.Sprintf(feeRecipientPath, pubkey.Hex()), nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	var response feeRecipientResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return common.Address{}, fmt.Errorf("error decoding fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	if !common.IsHexAddress(response.Data.EthAddress) {
+		return common.Address{}, fmt.Errorf("the Validator client returned an invalid fee recipient for validator %s: [%s]", pubkey.Hex(), response.Data.EthAddress)
+	}
+	return common.HexToAddress(response.Data.EthAddress), nil
+}
+
+type gasLimitResponse struct {
+	Data struct {
+		Pubkey   string `json:"pubkey"`
+		GasLimit string `json:"gas_limit"`
+	} `json:"data"`
+}
+
+// Get the gas limit the Validator client registers with MEV-boost relays for a validator
+func (c *Client) GetGasLimit(pubkey types.ValidatorPubkey) (uint64, error) {
+	responseBody, err := c.request(http.MethodGet, fmt.Sprintf(gasLimitPath, pubkey.Hex()), nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	var response gasLimitResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return 0, fmt.Errorf("error decoding gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("the Validator client returned an invalid gas limit for validator %s: [%s]", pubkey.Hex(), response.Data.GasLimit)
+	}
+	return gasLimit, nil
+}
+
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url"`
+}
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKey `json:"remote_keys"`
+}
+type importRemoteKeysResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// Tell the Validator client to use a remote signer for a validator.
+// Validators it already has a remote key for are treated as imported.
+func (c *Client) ImportRemoteKey(pubkey types.ValidatorPubkey, signerUrl string) error {
+	requestBody, err := json.Marshal(importRemoteKeysRequest{
+		RemoteKeys: []remoteKey{{
+			Pubkey: "0x" + pubkey.Hex(),
+			Url:    signerUrl,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing remote key: %w", err)
+	}
+	responseBody, err := c.request(http.MethodPost, remoteKeysPath, requestBody)
+	if err != nil {
+		return fmt.Errorf("error importing remote key for validator %s: %w", pubkey.Hex(), err)
+	}
+	var response importRemoteKeysResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return fmt.Errorf("error decoding remote key import for validator %s: %w", pubkey.Hex(), err)
+	}
+	for _, result := range response.Data {
+		if result.Status != "imported" && result.Status != "duplicate" {
+			return fmt.Errorf("the Validator client could not import the remote key for validator %s: %s (%s)", pubkey.Hex(), result.Status, result.Message)
+		}
+	}
+	return nil
+}
+
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type deleteKeystoresResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+	SlashingProtection string `json:"slashing_protection"`
+}
+
+// Remove validators' local keystores from the Validator client so it stops signing with them, and get their
+// slashing protection history as an EIP-3076 interchange. Validators it doesn't have a local keystore for are skipped.
+func (c *Client) DeleteKeystores(pubkeys []types.ValidatorPubkey) (string, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = "0x" + pubkey.Hex()
+	}
+	requestBody, err := json.Marshal(deleteKeystoresRequest{
+		Pubkeys: pubkeyStrings,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error serializing keystore deletion: %w", err)
+	}
+	responseBody, err := c.request(http.MethodDelete, keystoresPath, requestBody)
+	if err != nil {
+		return "", fmt.Errorf("error deleting keystores: %w", err)
+	}
+	var response deleteKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return "", fmt.Errorf("error decoding keystore deletion response: %w", err)
+	}
+	for i, result := range response.Data {
+		if result.Status == "error" && i < len(pubkeys) {
+			return "", fmt.Errorf("the Validator client could not delete the keystore for validator %s: %s", pubkeys[i].Hex(), result.Message)
+		}
+	}
+	return response.SlashingProtection, nil
+}
+
+// Send an authenticated request to the keymanager API
+func (c *Client) request(method string, path string, body []byte) ([]byte, error) {
+	token, err := os.ReadFile(c.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keymanager API token [%s]: %w", c.tokenPath, err)
+	}
+
+	request, err := http.NewRequest(method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("the Validator client responded with %s: %s", response.Status, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+}
@@ -0,0 +1,131 @@
+package mevboost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Relay API paths (https://flashbots.github.io/relay-specs/)
+const (
+	relayStatusPath       string = "/eth/v1/builder/status"
+	relayRegistrationPath string = "/relay/v1/data/validator_registration?pubkey=%s"
+)
+
+// Settings
+const requestTimeout time.Duration = 15 * time.Second
+
+// The registration a validator sent to a relay through MEV-boost
+type ValidatorRegistration struct {
+	FeeRecipient common.Address
+	GasLimit     uint64
+	Timestamp    time.Time
+}
+
+// A client for the public data API of a MEV-boost relay
+type RelayClient struct {
+	baseUrl string
+	client  *http.Client
+}
+
+// The registration as the relay returns it
+type registrationResponse struct {
+	Message struct {
+		FeeRecipient common.Address `json:"fee_recipient"`
+		GasLimit     string         `json:"gas_limit"`
+		Timestamp    string         `json:"timestamp"`
+	} `json:"message"`
+}
+
+// Create a client for a relay, given its URL as configured in MEV-boost (which includes the relay's public key)
+func NewRelayClient(relayUrl string) (*RelayClient, error) {
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing relay URL: %w", err)
+	}
+	if parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+		return nil, fmt.Errorf("relay URL [%s] must include a scheme and host", relayUrl)
+	}
+
+	client := *net.GetHttpClient(net.HttpTarget_Relays)
+	client.Timeout = requestTimeout
+	return &RelayClient{
+		baseUrl: fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host),
+		client:  &client,
+	}, nil
+}
+
+// Check that the relay is up, returning how long it took to respond
+func (c *RelayClient) CheckStatus() (time.Duration, error) {
+	start := time.Now()
+	_, status, err := c.getRequest(relayStatusPath)
+	latency := time.Since(start)
+	if err != nil {
+		return latency, fmt.Errorf("error checking relay status: %w", err)
+	}
+	if status != http.StatusOK {
+		return latency, fmt.Errorf("relay status check returned HTTP status %d", status)
+	}
+	return latency, nil
+}
+
+// Get the latest registration the relay has for a validator; returns false if it doesn't have one
+func (c *RelayClient) GetValidatorRegistration(pubkey types.ValidatorPubkey) (ValidatorRegistration, bool, error) {
+	body, status, err := c.getRequest(fmt.Sprintf(relayRegistrationPath, pubkey.Hex()))
+	if err != nil {
+		return ValidatorRegistration{}, false, fmt.Errorf("error getting registration for validator %s: %w", pubkey.Hex(), err)
+	}
+
+	// Relays report unregistered validators as either not found or a bad request
+	if status == http.StatusNotFound || status == http.StatusBadRequest || status == http.StatusNoContent {
+		return ValidatorRegistration{}, false, nil
+	}
+	if status != http.StatusOK {
+		return ValidatorRegistration{}, false, fmt.Errorf("error getting registration for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(body))
+	}
+
+	var response registrationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ValidatorRegistration{}, false, fmt.Errorf("error decoding registration for validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Message.GasLimit, 10, 64)
+	if err != nil {
+		return ValidatorRegistration{}, false, fmt.Errorf("error parsing gas limit '%s' for validator %s: %w", response.Message.GasLimit, pubkey.Hex(), err)
+	}
+	timestamp, err := strconv.ParseInt(response.Message.Timestamp, 10, 64)
+	if err != nil {
+		return ValidatorRegistration{}, false, fmt.Errorf("error parsing timestamp '%s' for validator %s: %w", response.Message.Timestamp, pubkey.Hex(), err)
+	}
+
+	return ValidatorRegistration{
+		FeeRecipient: response.Message.FeeRecipient,
+		GasLimit:     gasLimit,
+		Timestamp:    time.Unix(timestamp, 0),
+	}, true, nil
+}
+
+// Make a GET request to the relay
+func (c *RelayClient) getRequest(path string) ([]byte, int, error) {
+	response, err := c.client.Get(c.baseUrl + path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, response.StatusCode, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+
+	"github.com/rocket-pool/smartnode/shared/utils/secret"
 )
 
 // Config
@@ -30,7 +32,8 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 	return (err == nil)
 }
 
-// Get the password
+// Get the password.
+// Only use this when the password has to be handed out, such as for an export; GetPasswordBuffer keeps it out of the heap.
 func (pm *PasswordManager) GetPassword() (string, error) {
 
 	// Read from disk
@@ -38,12 +41,27 @@ func (pm *PasswordManager) GetPassword() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("Could not read password from disk: %w", err)
 	}
+	defer secret.Wipe(password)
 
 	// Return
 	return string(password), nil
 
 }
 
+// Get the password in a locked buffer, which the caller must destroy as soon as it's done with it
+func (pm *PasswordManager) GetPasswordBuffer() (*secret.Buffer, error) {
+
+	// Read from disk
+	password, err := os.ReadFile(pm.passwordPath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read password from disk: %w", err)
+	}
+
+	// Move it into the buffer, wiping the copy on the heap
+	return secret.FromBytes(password), nil
+
+}
+
 // Set the password
 func (pm *PasswordManager) SetPassword(password string) error {
 
@@ -0,0 +1,214 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Price source names that only provide current prices
+const (
+	Source_Chainlink string = "chainlink"
+	Source_Custom    string = "custom"
+)
+
+// Settings
+const (
+	coinGeckoCurrentUrl    string        = "https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s"
+	currentPriceCacheTime  time.Duration = 5 * time.Minute
+	chainlinkMaxStaleness  time.Duration = 2 * time.Hour
+	chainlinkCurrency      string        = "usd"
+	chainlinkAggregatorAbi string        = `[
+		{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+		{"inputs":[],"name":"latestRoundData","outputs":[{"internalType":"uint80","name":"roundId","type":"uint80"},{"internalType":"int256","name":"answer","type":"int256"},{"internalType":"uint256","name":"startedAt","type":"uint256"},{"internalType":"uint256","name":"updatedAt","type":"uint256"},{"internalType":"uint80","name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}
+	]`
+)
+
+// Provides the current price of an asset in a fiat currency
+type CurrentPriceSource interface {
+	GetPrice(asset Asset) (float64, error)
+	GetCurrency() string
+}
+
+// Create a current price source.
+// The source can be "coingecko", "chainlink", "custom", or "none". Chainlink reads the ETH/USD feed at the provided
+// address through the Execution client, so it only supports ETH in USD. Custom sources use a URL that returns JSON
+// with a "price" field, and can contain the {asset} and {currency} placeholders.
+// Returns nil if the source is "none".
+func NewCurrentPriceSource(source string, currency string, customUrl string, ec bind.ContractCaller, chainlinkFeedAddress string) (CurrentPriceSource, error) {
+	currency = strings.ToLower(strings.TrimSpace(currency))
+	if currency == "" {
+		return nil, fmt.Errorf("a fiat currency is required")
+	}
+
+	var getPrice func(asset Asset, currency string) (float64, error)
+	switch source {
+	case Source_None, "":
+		return nil, nil
+	case Source_CoinGecko:
+		getPrice = getCurrentCoinGeckoPrice
+	case Source_Chainlink:
+		if currency != chainlinkCurrency {
+			return nil, fmt.Errorf("the Chainlink price source only supports %s, not %s", strings.ToUpper(chainlinkCurrency), strings.ToUpper(currency))
+		}
+		if !common.IsHexAddress(chainlinkFeedAddress) {
+			return nil, fmt.Errorf("there is no Chainlink ETH/USD feed on this network")
+		}
+		feed, err := newChainlinkFeed(ec, common.HexToAddress(chainlinkFeedAddress))
+		if err != nil {
+			return nil, err
+		}
+		getPrice = func(asset Asset, currency string) (float64, error) {
+			if asset != Asset_ETH {
+				return 0, fmt.Errorf("the Chainlink price source only provides the price of %s", Asset_ETH)
+			}
+			return feed.getLatestPrice()
+		}
+	case Source_Custom:
+		if !strings.HasPrefix(customUrl, "http://") && !strings.HasPrefix(customUrl, "https://") {
+			return nil, fmt.Errorf("custom price source URL [%s] must start with http:// or https://", customUrl)
+		}
+		getPrice = func(asset Asset, currency string) (float64, error) {
+			return getCurrentCustomPrice(customUrl, asset, currency)
+		}
+	default:
+		return nil, fmt.Errorf("unknown price source '%s'; expected '%s', '%s', '%s', or '%s'", source, Source_CoinGecko, Source_Chainlink, Source_Custom, Source_None)
+	}
+
+	return &cachedCurrentPriceSource{
+		currency:  currency,
+		prices:    map[Asset]float64{},
+		fetchTime: map[Asset]time.Time{},
+		getPrice:  getPrice,
+	}, nil
+}
+
+// A price source that only requests each asset's price once every few minutes
+type cachedCurrentPriceSource struct {
+	currency  string
+	prices    map[Asset]float64
+	fetchTime map[Asset]time.Time
+	getPrice  func(asset Asset, currency string) (float64, error)
+	lock      sync.Mutex
+}
+
+// Get the currency prices are reported in
+func (s *cachedCurrentPriceSource) GetCurrency() string {
+	return s.currency
+}
+
+// Get the current price of an asset
+func (s *cachedCurrentPriceSource) GetPrice(asset Asset) (float64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if time.Since(s.fetchTime[asset]) < currentPriceCacheTime {
+		return s.prices[asset], nil
+	}
+	price, err := s.getPrice(asset, s.currency)
+	if err != nil {
+		return 0, fmt.Errorf("error getting current %s price: %w", asset, err)
+	}
+	s.prices[asset] = price
+	s.fetchTime[asset] = time.Now()
+	return price, nil
+}
+
+// Get an asset's current price from CoinGecko's simple price API
+func getCurrentCoinGeckoPrice(asset Asset, currency string) (float64, error) {
+	id, exists := coinGeckoIds[asset]
+	if !exists {
+		return 0, fmt.Errorf("CoinGecko does not have an ID for %s", asset)
+	}
+
+	response := map[string]map[string]float64{}
+	err := getJson(fmt.Sprintf(coinGeckoCurrentUrl, id, currency), &response)
+	if err != nil {
+		return 0, err
+	}
+	price, exists := response[id][currency]
+	if !exists {
+		return 0, fmt.Errorf("CoinGecko did not return a price in %s", strings.ToUpper(currency))
+	}
+	return price, nil
+}
+
+// Get an asset's current price from a custom URL
+func getCurrentCustomPrice(urlTemplate string, asset Asset, currency string) (float64, error) {
+	url := strings.NewReplacer(
+		customSourcePlaceholder, string(asset),
+		"{currency}", currency,
+	).Replace(urlTemplate)
+
+	var response struct {
+		Price *float64 `json:"price"`
+	}
+	err := getJson(url, &response)
+	if err != nil {
+		return 0, err
+	}
+	if response.Price == nil {
+		return 0, fmt.Errorf("response from %s did not have a price", url)
+	}
+	return *response.Price, nil
+}
+
+// A Chainlink price feed aggregator
+type chainlinkFeed struct {
+	contract *bind.BoundContract
+	address  common.Address
+	decimals uint8
+}
+
+// Bind to a Chainlink price feed aggregator
+func newChainlinkFeed(ec bind.ContractCaller, address common.Address) (*chainlinkFeed, error) {
+	if ec == nil {
+		return nil, fmt.Errorf("the Chainlink price source needs an Execution client")
+	}
+	feedAbi, err := abi.JSON(strings.NewReader(chainlinkAggregatorAbi))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Chainlink aggregator ABI: %w", err)
+	}
+	return &chainlinkFeed{
+		contract: bind.NewBoundContract(address, feedAbi, ec, nil, nil),
+		address:  address,
+	}, nil
+}
+
+// Get the latest price from the feed, making sure it's recent
+func (f *chainlinkFeed) getLatestPrice() (float64, error) {
+	opts := &bind.CallOpts{Context: context.Background()}
+
+	// The decimals never change, so they only need to be read once
+	if f.decimals == 0 {
+		out := []interface{}{}
+		if err := f.contract.Call(opts, &out, "decimals"); err != nil {
+			return 0, fmt.Errorf("error getting decimals of Chainlink feed %s: %w", f.address.Hex(), err)
+		}
+		f.decimals = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	}
+
+	out := []interface{}{}
+	if err := f.contract.Call(opts, &out, "latestRoundData"); err != nil {
+		return 0, fmt.Errorf("error getting latest round of Chainlink feed %s: %w", f.address.Hex(), err)
+	}
+	answer := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	updatedAt := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	if answer.Sign() <= 0 {
+		return 0, fmt.Errorf("Chainlink feed %s returned an invalid price", f.address.Hex())
+	}
+	if age := time.Since(time.Unix(updatedAt.Int64(), 0)); age > chainlinkMaxStaleness {
+		return 0, fmt.Errorf("Chainlink feed %s hasn't been updated in %s", f.address.Hex(), age.Round(time.Minute))
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(f.decimals)), nil))
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(answer), scale).Float64()
+	return price, nil
+}
@@ -0,0 +1,180 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Assets that can be priced
+type Asset string
+
+const (
+	Asset_ETH Asset = "ETH"
+	Asset_RPL Asset = "RPL"
+)
+
+// Price source names
+const (
+	Source_CoinGecko string = "coingecko"
+	Source_None      string = "none"
+)
+
+// Settings
+const (
+	coinGeckoHistoryUrl     string        = "https://api.coingecko.com/api/v3/coins/%s/history?date=%s&localization=false"
+	rateLimitRetries        int           = 5
+	rateLimitRetryDelay     time.Duration = 15 * time.Second
+	customSourcePlaceholder string        = "{asset}"
+)
+
+// The CoinGecko IDs of each asset
+var coinGeckoIds = map[Asset]string{
+	Asset_ETH: "ethereum",
+	Asset_RPL: "rocket-pool",
+}
+
+// Provides the price of an asset in a fiat currency on a given day
+type HistoricalPriceSource interface {
+	GetPrice(asset Asset, date time.Time) (float64, error)
+	GetCurrency() string
+}
+
+// Create a historical price source.
+// The source can be "coingecko", "none", or a URL that returns JSON with a "price" field. Custom URLs can contain the
+// {asset}, {currency}, and {date} (in YYYY-MM-DD format) placeholders, which are replaced for each request.
+// Returns nil if the source is "none".
+func NewHistoricalPriceSource(source string, currency string) (HistoricalPriceSource, error) {
+	currency = strings.ToLower(strings.TrimSpace(currency))
+	if currency == "" {
+		return nil, fmt.Errorf("a fiat currency is required")
+	}
+
+	switch {
+	case source == Source_None:
+		return nil, nil
+	case source == Source_CoinGecko:
+		return &cachedPriceSource{
+			currency: currency,
+			prices:   map[string]float64{},
+			getPrice: getCoinGeckoPrice,
+		}, nil
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		if !strings.Contains(source, customSourcePlaceholder) {
+			return nil, fmt.Errorf("custom price source URL [%s] must contain the %s placeholder", source, customSourcePlaceholder)
+		}
+		return &cachedPriceSource{
+			currency: currency,
+			prices:   map[string]float64{},
+			getPrice: func(asset Asset, currency string, date time.Time) (float64, error) {
+				return getCustomPrice(source, asset, currency, date)
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown price source '%s'; expected '%s', '%s', or a URL", source, Source_CoinGecko, Source_None)
+	}
+}
+
+// A price source that only requests each asset's price once per day
+type cachedPriceSource struct {
+	currency string
+	prices   map[string]float64
+	getPrice func(asset Asset, currency string, date time.Time) (float64, error)
+}
+
+// Get the currency prices are reported in
+func (s *cachedPriceSource) GetCurrency() string {
+	return s.currency
+}
+
+// Get the price of an asset on the day of the provided time (in UTC)
+func (s *cachedPriceSource) GetPrice(asset Asset, date time.Time) (float64, error) {
+	date = date.UTC()
+	key := fmt.Sprintf("%s-%s", asset, date.Format("2006-01-02"))
+	if price, exists := s.prices[key]; exists {
+		return price, nil
+	}
+
+	price, err := s.getPrice(asset, s.currency, date)
+	if err != nil {
+		return 0, fmt.Errorf("error getting %s price on %s: %w", asset, date.Format("2006-01-02"), err)
+	}
+	s.prices[key] = price
+	return price, nil
+}
+
+// Get an asset's price from CoinGecko's history API
+func getCoinGeckoPrice(asset Asset, currency string, date time.Time) (float64, error) {
+	id, exists := coinGeckoIds[asset]
+	if !exists {
+		return 0, fmt.Errorf("CoinGecko does not have an ID for %s", asset)
+	}
+
+	var response struct {
+		MarketData struct {
+			CurrentPrice map[string]float64 `json:"current_price"`
+		} `json:"market_data"`
+	}
+	err := getJson(fmt.Sprintf(coinGeckoHistoryUrl, id, date.Format("02-01-2006")), &response)
+	if err != nil {
+		return 0, err
+	}
+	price, exists := response.MarketData.CurrentPrice[currency]
+	if !exists {
+		return 0, fmt.Errorf("CoinGecko did not return a price in %s", strings.ToUpper(currency))
+	}
+	return price, nil
+}
+
+// Get an asset's price from a custom URL
+func getCustomPrice(urlTemplate string, asset Asset, currency string, date time.Time) (float64, error) {
+	url := strings.NewReplacer(
+		customSourcePlaceholder, string(asset),
+		"{currency}", currency,
+		"{date}", date.Format("2006-01-02"),
+	).Replace(urlTemplate)
+
+	var response struct {
+		Price *float64 `json:"price"`
+	}
+	err := getJson(url, &response)
+	if err != nil {
+		return 0, err
+	}
+	if response.Price == nil {
+		return 0, fmt.Errorf("response from %s did not have a price", url)
+	}
+	return *response.Price, nil
+}
+
+// Get a JSON response from a URL, waiting and retrying if the server is rate limiting requests
+func getJson(url string, result interface{}) error {
+	for attempt := 0; ; attempt++ {
+		response, err := net.GetHttpClient(net.HttpTarget_Prices).Get(url)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests && attempt < rateLimitRetries {
+			time.Sleep(rateLimitRetryDelay)
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("request failed with code %d", response.StatusCode)
+		}
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("could not decode price response: %w", err)
+		}
+		return nil
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/urfave/cli"
 )
 
@@ -44,6 +45,14 @@ func RequireNodePassword(c *cli.Context) error {
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	// A masquerading wallet only needs its address, so it doesn't need a password or seed
+	isMasquerading, err := getNodeWalletMasquerading(c)
+	if err != nil {
+		return err
+	}
+	if isMasquerading {
+		return nil
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -155,6 +164,36 @@ func RequireNodeTrusted(c *cli.Context) error {
 	return nil
 }
 
+// Make sure the node's withdrawal addresses are the ones it's configured to expect, and that the given minipools'
+// validators withdraw to them, before doing anything that moves the node's funds
+func RequireWithdrawalAddressSafety(c *cli.Context, minipoolAddresses ...common.Address) error {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return err
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	bc, err := GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	safety, err := rputils.CheckWithdrawalSafety(rp, bc, cfg, nodeAccount.Address, minipoolAddresses)
+	if err != nil {
+		return fmt.Errorf("error checking the node's withdrawal addresses: %w", err)
+	}
+	return safety.Error()
+}
+
 //
 // Service synchronization
 //
@@ -176,6 +215,13 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 }
 
 func WaitNodeWallet(c *cli.Context, verbose bool) error {
+	isMasquerading, err := getNodeWalletMasquerading(c)
+	if err != nil {
+		return err
+	}
+	if isMasquerading {
+		return nil
+	}
 	if err := WaitNodePassword(c, verbose); err != nil {
 		return err
 	}
@@ -267,6 +313,15 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	return w.GetInitialized()
 }
 
+// Check if the node wallet is masquerading as another node
+func getNodeWalletMasquerading(c *cli.Context) (bool, error) {
+	w, err := GetWallet(c)
+	if err != nil {
+		return false, err
+	}
+	return w.IsMasquerading(), nil
+}
+
 // Check if the RocketStorage contract is loaded
 func getRocketStorageLoaded(c *cli.Context) (bool, error) {
 	cfg, err := GetConfig(c)
@@ -360,12 +415,13 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Rocke
 
 	// Check the EC status
 	mgrStatus := ecMgr.CheckStatus(cfg)
-	if ecMgr.primaryReady {
+	fallbackEc, primaryReady, fallbackReady := ecMgr.getState()
+	if primaryReady {
 		return true, nil, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if ecMgr.fallbackReady {
+	if fallbackReady {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary execution client is unavailable (%s), using fallback execution client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -385,7 +441,7 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Rocke
 	// Is the fallback working and syncing? If so, wait for it
 	if mgrStatus.FallbackEnabled && mgrStatus.FallbackClientStatus.IsWorking && mgrStatus.FallbackClientStatus.Error == "" {
 		log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.SyncProgress*100)
-		return false, ecMgr.fallbackEc, nil
+		return false, fallbackEc, nil
 	}
 
 	// If neither client is working, report the errors
@@ -400,12 +456,12 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
 	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
+	if bcMgr.isPrimaryReady() {
 		return true, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
+	if bcMgr.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -584,7 +640,7 @@ func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool,
 		// Check sync status
 		if syncStatus.Syncing {
 			if verbose {
-				log.Println("Eth 2.0 node syncing: %.2f%%\n", syncStatus.Progress*100)
+				log.Printf("Eth 2.0 node syncing: %.2f%%\n", syncStatus.Progress*100)
 			}
 		} else {
 			return true, nil
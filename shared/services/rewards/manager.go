@@ -22,10 +22,12 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 const (
-	scanningWindowSize uint64 = 10000
+	scanningWindowSize         uint64        = 10000
+	rewardsFileDownloadTimeout time.Duration = 2 * time.Minute
 )
 
 // Gets the intervals the node can claim and the intervals that have already been claimed
@@ -94,6 +96,7 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	info.CID = event.MerkleTreeCID
 	info.StartTime = event.IntervalStartTime
 	info.EndTime = event.IntervalEndTime
+	info.MerkleRoot = event.MerkleRoot
 	merkleRootCanon := event.MerkleRoot
 
 	// Check if the tree file exists
@@ -331,8 +334,9 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 	}
 }
 
-// Downloads a single rewards file
-func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+// Downloads a single rewards file, verifies it against the Merkle root that was submitted on-chain, and saves it.
+// The IPFS gateways are tried first, followed by any HTTPS mirrors in the config.
+func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, merkleRoot common.Hash, isDaemon bool) error {
 
 	// Determine file name and path
 	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon))
@@ -343,52 +347,89 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
 
 	// Create URL list
-	urls := []string{
-		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, ipfsFilename),
-		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, ipfsFilename),
-	}
+	urls := cfg.Smartnode.GetRewardsFileUrls(cid, ipfsFilename)
 
 	// Attempt downloads
 	errBuilder := strings.Builder{}
 	for _, url := range urls {
-		resp, err := http.Get(url)
+		decompressedBytes, err := downloadRewardsFileFromUrl(url)
 		if err != nil {
 			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
 			continue
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed with status %s\n", url, resp.Status))
+		// Make sure it matches the canonical Merkle root
+		var proofWrapper RewardsFile
+		err = json.Unmarshal(decompressedBytes, &proofWrapper)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error deserializing %s: %s\n", url, err.Error()))
 			continue
-		} else {
-			// If we got here, we have a successful download
-			bytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error reading response bytes from %s: %s\n", url, err.Error()))
-				continue
-			}
+		}
+		merkleRootFromFile := common.HexToHash(proofWrapper.MerkleRoot)
+		if merkleRootFromFile != merkleRoot {
+			errBuilder.WriteString(fmt.Sprintf("The file from %s has a Merkle root of %s but the canonical root is %s\n", url, merkleRootFromFile.Hex(), merkleRoot.Hex()))
+			continue
+		}
 
-			// Decompress it
-			decompressedBytes, err := decompressFile(bytes)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
-				continue
-			}
+		// The root in the file is only a claim, so rebuild the tree from the node rewards and make sure it matches too
+		verification, err := VerifyRewardsTree(&proofWrapper)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error rebuilding the Merkle tree from %s: %s\n", url, err.Error()))
+			continue
+		}
+		if verification.ComputedMerkleRoot != merkleRoot {
+			errBuilder.WriteString(fmt.Sprintf("The node rewards in the file from %s have a Merkle root of %s but the canonical root is %s\n", url, verification.ComputedMerkleRoot.Hex(), merkleRoot.Hex()))
+			continue
+		}
+		if len(verification.InvalidProofs) > 0 {
+			errBuilder.WriteString(fmt.Sprintf("The file from %s has invalid Merkle proofs for %d nodes\n", url, len(verification.InvalidProofs)))
+			continue
+		}
 
-			// Write the file
-			err = os.WriteFile(rewardsTreePath, decompressedBytes, 0644)
-			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
-			}
-			return nil
+		// Write the file to a temporary path first so a partial write can't leave a corrupted file behind
+		err = os.MkdirAll(filepath.Dir(rewardsTreePath), 0755)
+		if err != nil {
+			return fmt.Errorf("error creating rewards tree folder: %w", err)
+		}
+		tempPath := rewardsTreePath + ".tmp"
+		err = os.WriteFile(tempPath, decompressedBytes, 0644)
+		if err != nil {
+			return fmt.Errorf("error saving interval %d file to %s: %w", interval, tempPath, err)
 		}
+		err = os.Rename(tempPath, rewardsTreePath)
+		if err != nil {
+			return fmt.Errorf("error moving interval %d file to %s: %w", interval, rewardsTreePath, err)
+		}
+		return nil
 	}
 
 	return fmt.Errorf(errBuilder.String())
 
 }
 
+// Downloads and decompresses a rewards file from a single URL
+func downloadRewardsFileFromUrl(url string) ([]byte, error) {
+	// Copy the shared client so a stalled gateway can't hang the caller, without changing its other users
+	client := *net.GetHttpClient(net.HttpTarget_Rewards)
+	client.Timeout = rewardsFileDownloadTimeout
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response bytes: %w", err)
+	}
+
+	return decompressFile(bytes)
+}
+
 // Decompresses a rewards file
 func decompressFile(compressedBytes []byte) ([]byte, error) {
 	decoder, err := zstd.NewReader(nil)
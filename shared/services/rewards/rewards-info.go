@@ -0,0 +1,161 @@
+package rewards
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The rewards a node has earned across all of the rewards intervals
+type NodeRewards struct {
+	CumulativeRpl     *big.Int
+	CumulativeEth     *big.Int
+	CumulativeOdaoRpl *big.Int
+	UnclaimedRpl      *big.Int
+	UnclaimedEth      *big.Int
+	UnclaimedOdaoRpl  *big.Int
+	UpdateTime        time.Time
+
+	// The intervals whose rewards trees aren't on disk; they're left out of the totals above
+	MissingIntervals []uint64
+}
+
+// Tracks the claimed and unclaimed rewards of nodes.
+// A rewards tree never changes once it's been published, so each node's share of an interval is only read from disk once;
+// updates after that only need to check which intervals have been claimed.
+type RewardsInfo struct {
+	rp  *rocketpool.RocketPool
+	cfg *config.RocketPoolConfig
+
+	// The node's share of each interval that's already been read, guarded by updateLock
+	intervals  map[common.Address]map[uint64]IntervalInfo
+	updateLock sync.Mutex
+
+	// The latest rewards for each node, guarded by rewardsLock
+	nodeRewards map[common.Address]*NodeRewards
+	rewardsLock sync.RWMutex
+}
+
+// Create a new RewardsInfo instance. Only the rewards trees on disk are used; the node's download task fetches missing ones.
+func NewRewardsInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig) *RewardsInfo {
+	return &RewardsInfo{
+		rp:          rp,
+		cfg:         cfg,
+		intervals:   map[common.Address]map[uint64]IntervalInfo{},
+		nodeRewards: map[common.Address]*NodeRewards{},
+	}
+}
+
+// Get the latest rewards for a node without touching the chain or the disk, or nil if they haven't been calculated yet
+func (r *RewardsInfo) GetNodeRewards(nodeAddress common.Address) *NodeRewards {
+	r.rewardsLock.RLock()
+	defer r.rewardsLock.RUnlock()
+	return r.nodeRewards[nodeAddress]
+}
+
+// Recalculate a node's rewards from its current claim status
+func (r *RewardsInfo) Update(nodeAddress common.Address) (*NodeRewards, error) {
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	// Get the claimed and unclaimed intervals
+	unclaimed, claimed, err := GetClaimStatus(r.rp, nodeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := &NodeRewards{
+		CumulativeRpl:     big.NewInt(0),
+		CumulativeEth:     big.NewInt(0),
+		CumulativeOdaoRpl: big.NewInt(0),
+		UnclaimedRpl:      big.NewInt(0),
+		UnclaimedEth:      big.NewInt(0),
+		UnclaimedOdaoRpl:  big.NewInt(0),
+	}
+
+	// Get the info for each claimed interval
+	for _, claimedInterval := range claimed {
+		intervalInfo, err := r.getIntervalInfo(nodeAddress, claimedInterval)
+		if err != nil {
+			return nil, err
+		}
+		if !intervalInfo.TreeFileExists {
+			rewards.MissingIntervals = append(rewards.MissingIntervals, claimedInterval)
+			continue
+		}
+		if intervalInfo.NodeExists {
+			rewards.CumulativeRpl.Add(rewards.CumulativeRpl, &intervalInfo.CollateralRplAmount.Int)
+			rewards.CumulativeEth.Add(rewards.CumulativeEth, &intervalInfo.SmoothingPoolEthAmount.Int)
+			rewards.CumulativeOdaoRpl.Add(rewards.CumulativeOdaoRpl, &intervalInfo.ODaoRplAmount.Int)
+		}
+	}
+
+	// Get the unclaimed rewards
+	for _, unclaimedInterval := range unclaimed {
+		intervalInfo, err := r.getIntervalInfo(nodeAddress, unclaimedInterval)
+		if err != nil {
+			return nil, err
+		}
+		if !intervalInfo.TreeFileExists {
+			rewards.MissingIntervals = append(rewards.MissingIntervals, unclaimedInterval)
+			continue
+		}
+		if intervalInfo.NodeExists {
+			rewards.UnclaimedRpl.Add(rewards.UnclaimedRpl, &intervalInfo.CollateralRplAmount.Int)
+			rewards.UnclaimedEth.Add(rewards.UnclaimedEth, &intervalInfo.SmoothingPoolEthAmount.Int)
+			rewards.UnclaimedOdaoRpl.Add(rewards.UnclaimedOdaoRpl, &intervalInfo.ODaoRplAmount.Int)
+		}
+	}
+	rewards.UpdateTime = time.Now()
+
+	r.rewardsLock.Lock()
+	r.nodeRewards[nodeAddress] = rewards
+	r.rewardsLock.Unlock()
+	return rewards, nil
+}
+
+// Keep the rewards for the given nodes up to date until the process exits
+func (r *RewardsInfo) Run(nodeAddresses []common.Address, interval time.Duration, logger log.ColorLogger) {
+	for {
+		for _, nodeAddress := range nodeAddresses {
+			rewards, err := r.Update(nodeAddress)
+			if err != nil {
+				logger.Printlnf("Error updating rewards for node %s: %s", nodeAddress.Hex(), err.Error())
+				continue
+			}
+			if len(rewards.MissingIntervals) > 0 {
+				logger.Printlnf("WARNING: the rewards tree files for intervals %v are missing, so node %s's rewards for them are left out.", rewards.MissingIntervals, nodeAddress.Hex())
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Get a node's share of an interval, reading the tree file only if it hasn't been read successfully before
+func (r *RewardsInfo) getIntervalInfo(nodeAddress common.Address, interval uint64) (IntervalInfo, error) {
+	nodeIntervals, exists := r.intervals[nodeAddress]
+	if !exists {
+		nodeIntervals = map[uint64]IntervalInfo{}
+		r.intervals[nodeAddress] = nodeIntervals
+	}
+	if info, exists := nodeIntervals[interval]; exists {
+		return info, nil
+	}
+
+	info, err := GetIntervalInfo(r.rp, r.cfg, nodeAddress, interval)
+	if err != nil {
+		return IntervalInfo{}, err
+	}
+
+	// Don't keep missing or invalid trees so they're checked again next time
+	if info.TreeFileExists && info.MerkleRootValid {
+		info.MerkleProof = nil
+		nodeIntervals[interval] = info
+	}
+	return info, nil
+}
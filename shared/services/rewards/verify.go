@@ -0,0 +1,157 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// The result of checking a rewards tree file's contents against its own Merkle root
+type TreeVerification struct {
+	// The Merkle root rebuilt from the node rewards in the file
+	ComputedMerkleRoot common.Hash
+
+	// The nodes whose Merkle proofs in the file don't lead to the file's Merkle root
+	InvalidProofs []common.Address
+}
+
+// Load a rewards tree file from disk
+func LoadRewardsFile(path string) (*RewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	var rewardsFile RewardsFile
+	err = json.Unmarshal(fileBytes, &rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return &rewardsFile, nil
+}
+
+// Rebuild the Merkle tree from a rewards file's node rewards and check every node's proof against the file's Merkle root.
+// This uses the same leaf encoding as the tree generators, so a file that wasn't tampered with reproduces its root exactly.
+func VerifyRewardsTree(rewardsFile *RewardsFile) (TreeVerification, error) {
+	verification := TreeVerification{
+		InvalidProofs: []common.Address{},
+	}
+
+	// Get the leaf data for each node that received rewards
+	leaves := map[common.Address][]byte{}
+	totalData := make([][]byte, 0, len(rewardsFile.NodeRewards))
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		leaf, err := getNodeLeafData(address, rewardsForNode)
+		if err != nil {
+			return verification, err
+		}
+		if leaf == nil {
+			continue
+		}
+		leaves[address] = leaf
+		totalData = append(totalData, leaf)
+	}
+	if len(totalData) == 0 {
+		return verification, fmt.Errorf("the rewards file doesn't have any nodes with rewards")
+	}
+
+	// Rebuild the tree
+	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
+	if err != nil {
+		return verification, fmt.Errorf("error generating Merkle Tree: %w", err)
+	}
+	verification.ComputedMerkleRoot = common.BytesToHash(tree.Root())
+
+	// Check the proofs against the root the file claims, so a proof that doesn't match is reported even if the root is wrong too
+	fileRoot := common.HexToHash(rewardsFile.MerkleRoot)
+	for address, leaf := range leaves {
+		proof, err := rewardsFile.NodeRewards[address].GetMerkleProof()
+		if err != nil {
+			return verification, fmt.Errorf("error deserializing merkle proof for node %s: %w", address.Hex(), err)
+		}
+		if !verifyMerkleProof(leaf, proof, fileRoot) {
+			verification.InvalidProofs = append(verification.InvalidProofs, address)
+		}
+	}
+	sort.Slice(verification.InvalidProofs, func(i, j int) bool {
+		return bytes.Compare(verification.InvalidProofs[i].Bytes(), verification.InvalidProofs[j].Bytes()) < 0
+	})
+
+	return verification, nil
+}
+
+// Download the rewards tree file published under a CID, without saving it
+func DownloadPublishedRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string) (*RewardsFile, error) {
+	ipfsFilename := filepath.Base(cfg.Smartnode.GetRewardsTreePath(interval, true)) + config.RewardsTreeIpfsExtension
+	errBuilder := strings.Builder{}
+	for _, url := range cfg.Smartnode.GetRewardsFileUrls(cid, ipfsFilename) {
+		decompressedBytes, err := downloadRewardsFileFromUrl(url)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
+			continue
+		}
+		var rewardsFile RewardsFile
+		err = json.Unmarshal(decompressedBytes, &rewardsFile)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error deserializing %s: %s\n", url, err.Error()))
+			continue
+		}
+		return &rewardsFile, nil
+	}
+	return nil, fmt.Errorf(errBuilder.String())
+}
+
+// Get a node's leaf data, which is address[20] :: network[32] :: RPL[32] :: ETH[32].
+// Returns nil if the node didn't receive any rewards, since those nodes aren't in the tree.
+func getNodeLeafData(address common.Address, rewardsForNode *NodeRewardsInfo) ([]byte, error) {
+	if rewardsForNode.CollateralRpl == nil || rewardsForNode.OracleDaoRpl == nil || rewardsForNode.SmoothingPoolEth == nil {
+		return nil, fmt.Errorf("node %s is missing some of its rewards", address.Hex())
+	}
+	if rewardsForNode.CollateralRpl.Sign() < 0 || rewardsForNode.OracleDaoRpl.Sign() < 0 || rewardsForNode.SmoothingPoolEth.Sign() < 0 {
+		return nil, fmt.Errorf("node %s has negative rewards", address.Hex())
+	}
+	if rewardsForNode.CollateralRpl.Sign() == 0 && rewardsForNode.OracleDaoRpl.Sign() == 0 && rewardsForNode.SmoothingPoolEth.Sign() == 0 {
+		return nil, nil
+	}
+
+	nodeData := make([]byte, 0, 20+32*3)
+	nodeData = append(nodeData, address.Bytes()...)
+
+	networkBytes := make([]byte, 32)
+	big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork).FillBytes(networkBytes)
+	nodeData = append(nodeData, networkBytes...)
+
+	rplRewards := big.NewInt(0).Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+	rplRewardsBytes := make([]byte, 32)
+	rplRewards.FillBytes(rplRewardsBytes)
+	nodeData = append(nodeData, rplRewardsBytes...)
+
+	ethRewardsBytes := make([]byte, 32)
+	rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
+	nodeData = append(nodeData, ethRewardsBytes...)
+
+	return nodeData, nil
+}
+
+// Check a Merkle proof the way the rewards distributor contract does, hashing each pair of nodes in sorted order
+func verifyMerkleProof(leaf []byte, proof []common.Hash, root common.Hash) bool {
+	hash := crypto.Keccak256(leaf)
+	for _, sibling := range proof {
+		if bytes.Compare(hash, sibling.Bytes()) <= 0 {
+			hash = crypto.Keccak256(hash, sibling.Bytes())
+		} else {
+			hash = crypto.Keccak256(sibling.Bytes(), hash)
+		}
+	}
+	return common.BytesToHash(hash) == root
+}
@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -11,7 +12,11 @@ import (
 
 // Wait for a transaction
 func (c *Client) WaitForTransaction(txHash common.Hash) (api.APIResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
+	// Simulated transactions were never broadcast, so there's nothing to wait for
+	if c.simulate {
+		return api.APIResponse{Status: "success"}, nil
+	}
+	responseBytes, err := c.waitForTransactionWithDeadline(txHash)
 	if err != nil {
 		return api.APIResponse{}, fmt.Errorf("Error waiting for tx: %w", err)
 	}
@@ -24,3 +29,27 @@ func (c *Client) WaitForTransaction(txHash common.Hash) (api.APIResponse, error)
 	}
 	return response, nil
 }
+
+// Wait for a transaction, giving up once the deadline from the selected transaction preset has passed
+func (c *Client) waitForTransactionWithDeadline(txHash common.Hash) ([]byte, error) {
+	if c.txDeadline == 0 {
+		return c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
+	}
+
+	type waitResult struct {
+		responseBytes []byte
+		err           error
+	}
+	resultChannel := make(chan waitResult, 1)
+	go func() {
+		responseBytes, err := c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
+		resultChannel <- waitResult{responseBytes, err}
+	}()
+
+	select {
+	case result := <-resultChannel:
+		return result.responseBytes, result.err
+	case <-time.After(c.txDeadline):
+		return nil, fmt.Errorf("transaction %s was not included within the preset's deadline of %s. It may still be included later; to replace it, resubmit the action with a higher fee and the same nonce using --nonce", txHash.Hex(), c.txDeadline)
+	}
+}
@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -28,7 +29,9 @@ import (
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
+	"github.com/rocket-pool/smartnode/shared/utils/secret"
 )
 
 // Config
@@ -38,7 +41,7 @@ const (
 
 	LegacyBackupFolder       string = "old_config_backup"
 	SettingsFile             string = "user-settings.yml"
-	BackupSettingsFile       string = "user-settings-backup.yml"
+	BackupSettingsFile       string = rp.BackupSettingsFile
 	LegacyConfigFile         string = "config.yml"
 	LegacySettingsFile       string = "settings.yml"
 	PrometheusConfigTemplate string = "prometheus.tmpl"
@@ -94,17 +97,49 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	useProtectedApi    bool
+	simulate           bool
+	txDeadline         time.Duration
+	network            cfgtypes.Network
+	isIsolatedNetwork  bool
 }
 
 // Create new Rocket Pool client from CLI context
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-	return NewClient(c.GlobalString("config-path"),
+	configPath, err := GetConfigPathFromCtx(c)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(configPath,
 		c.GlobalString("daemon-path"),
 		c.GlobalFloat64("maxFee"),
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
 		c.GlobalBool("debug"))
+	if err != nil {
+		return nil, err
+	}
+	client.simulate = c.GlobalBool("simulate")
+
+	// Remember the selected network so a new config in its folder is set up for it
+	if c.GlobalString("network") != "" {
+		client.network, err = ParseSelectableNetwork(c.GlobalString("network"))
+		if err != nil {
+			return nil, err
+		}
+		client.isIsolatedNetwork = (configPath != c.GlobalString("config-path"))
+	}
+
+	// Apply the transaction preset if one was selected
+	if c.GlobalString("preset") != "" {
+		err = client.applyTransactionPreset(c.GlobalString("preset"))
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
 }
 
 // Create new Rocket Pool client
@@ -165,11 +200,46 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	isNew := false
 	if cfg == nil {
 		cfg = config.NewRocketPoolConfig(c.configPath, c.daemonPath != "")
+		if c.network != cfgtypes.Network_Unknown {
+			applyNetworkDefaults(cfg, c.network, c.isIsolatedNetwork)
+		}
 		isNew = true
 	}
 	return cfg, isNew, nil
 }
 
+// Get the folder the client's config lives in
+func (c *Client) GetConfigPath() string {
+	return c.configPath
+}
+
+// Apply the settings from a named transaction preset; explicitly provided fees take precedence over the preset's
+func (c *Client) applyTransactionPreset(name string) error {
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading the Smartnode configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before using transaction presets.")
+	}
+	preset, err := cfg.Smartnode.GetTransactionPreset(name)
+	if err != nil {
+		return err
+	}
+
+	if c.originalMaxFee == 0 {
+		c.maxFee = preset.MaxFee
+		c.originalMaxFee = preset.MaxFee
+	}
+	if c.originalMaxPrioFee == 0 {
+		c.maxPrioFee = preset.MaxPriorityFee
+		c.originalMaxPrioFee = preset.MaxPriorityFee
+	}
+	c.useProtectedApi = (preset.Route == config.TransactionRoute_Private)
+	c.txDeadline = preset.Deadline
+	return nil
+}
+
 // Load the backup config
 func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	settingsFilePath := filepath.Join(c.configPath, BackupSettingsFile)
@@ -191,6 +261,16 @@ func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	return rp.SaveConfig(cfg, expandedPath)
 }
 
+// Restore the settings from before the last upgrade, returning the version that wrote them
+func (c *Client) RollbackConfig() (string, error) {
+	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.RollbackConfig(expandedPath)
+}
+
 // Remove the upgrade flag file
 func (c *Client) RemoveUpgradeFlagFile() error {
 	expandedPath, err := homedir.Expand(c.configPath)
@@ -276,7 +356,7 @@ func (c *Client) UpdatePrometheusConfiguration(settings map[string]string) error
 	}
 	err = os.Chmod(prometheusConfigPath, 0664)
 	if err != nil {
-		return fmt.Errorf("Could not set Prometheus config file permissions: %w", shellescape.Quote(prometheusConfigPath), err)
+		return fmt.Errorf("Could not set Prometheus config file permissions for %s: %w", shellescape.Quote(prometheusConfigPath), err)
 	}
 
 	return nil
@@ -543,6 +623,22 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version, path str
 
 }
 
+// Route the CLI's outbound HTTP clients through the proxy and IP settings in the config
+func (c *Client) ConfigureHttpClients() error {
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	httpSettings, err := cfg.Smartnode.GetHttpClientSettings()
+	if err != nil {
+		return fmt.Errorf("error getting HTTP proxy settings: %w", err)
+	}
+	if err := netutils.ConfigureHttpClients(httpSettings); err != nil {
+		return fmt.Errorf("error configuring HTTP proxy: %w", err)
+	}
+	return nil
+}
+
 // Install the update tracker
 func (c *Client) InstallUpdateTracker(verbose bool, version string) error {
 
@@ -551,9 +647,14 @@ func (c *Client) InstallUpdateTracker(verbose bool, version string) error {
 		"-v", fmt.Sprintf("%s", shellescape.Quote(version)),
 	}
 
+	// Route the download through the configured proxy
+	if err := c.ConfigureHttpClients(); err != nil {
+		return err
+	}
+
 	// Download the installer package
 	url := fmt.Sprintf(UpdateTrackerURL, version)
-	resp, err := http.Get(url)
+	resp, err := netutils.GetHttpClient(netutils.HttpTarget_Updates).Get(url)
 	if err != nil {
 		return fmt.Errorf("error downloading installer package: %w", err)
 	}
@@ -647,6 +748,48 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Pull the images for all of the Rocket Pool service's containers without restarting them
+func (c *Client) PullServiceImages(composeFiles []string) error {
+	cmd, err := c.compose(composeFiles, "pull --quiet")
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
+// Start or recreate the given containers of the Rocket Pool service without touching the ones they depend on
+func (c *Client) StartServiceContainers(composeFiles []string, serviceNames ...string) error {
+	sanitizedStrings := make([]string, len(serviceNames))
+	for i, serviceName := range serviceNames {
+		sanitizedStrings[i] = shellescape.Quote(serviceName)
+	}
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("up -d --no-deps --quiet-pull %s", strings.Join(sanitizedStrings, " ")))
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
+// Recreate a container of the Rocket Pool service with a specific image, such as the one it used before an upgrade.
+// The image only applies until the service is started normally again.
+func (c *Client) StartServiceContainerWithImage(composeFiles []string, serviceName string, image string) error {
+
+	// Write a compose override that replaces the service's image
+	overrideFile, err := os.CreateTemp("", fmt.Sprintf("rocketpool-%s-*%s", serviceName, composeFileSuffix))
+	if err != nil {
+		return fmt.Errorf("error creating image override for %s: %w", serviceName, err)
+	}
+	defer os.Remove(overrideFile.Name())
+	_, err = fmt.Fprintf(overrideFile, "services:\n  %s:\n    image: %s\n", serviceName, image)
+	overrideFile.Close()
+	if err != nil {
+		return fmt.Errorf("error writing image override for %s: %w", serviceName, err)
+	}
+
+	return c.StartServiceContainers(append(append([]string{}, composeFiles...), overrideFile.Name()), serviceName)
+
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -722,6 +865,19 @@ func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNam
 	return c.printOutput(cmd)
 }
 
+// Get the Rocket Pool service logs without following them
+func (c *Client) GetServiceLogs(composeFiles []string, tail string, serviceNames ...string) ([]byte, error) {
+	sanitizedStrings := make([]string, len(serviceNames))
+	for i, serviceName := range serviceNames {
+		sanitizedStrings[i] = shellescape.Quote(serviceName)
+	}
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs --no-color --timestamps --tail %s %s", shellescape.Quote(tail), strings.Join(sanitizedStrings, " ")))
+	if err != nil {
+		return nil, err
+	}
+	return c.readOutput(cmd)
+}
+
 // Print the Rocket Pool service stats
 func (c *Client) PrintServiceStats(composeFiles []string) error {
 
@@ -807,6 +963,32 @@ func (c *Client) GetDockerImage(container string) (string, error) {
 
 }
 
+// Get the ID of the image the given container is running, which stays the same when its tag is pulled again
+func (c *Client) GetDockerImageID(container string) (string, error) {
+
+	cmd := fmt.Sprintf("docker container inspect --format={{.Image}} %s", container)
+	id, err := c.readOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(id)), nil
+
+}
+
+// Get the ID of the given container, which changes whenever it's recreated
+func (c *Client) GetDockerContainerID(container string) (string, error) {
+
+	cmd := fmt.Sprintf("docker container inspect --format={{.Id}} %s", container)
+	id, err := c.readOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(id)), nil
+
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerStatus(container string) (string, error) {
 
@@ -1114,7 +1296,6 @@ func (c *Client) checkIfCommandExists(command string) (bool, error) {
 	// Run `type` to check for existence
 	cmd := fmt.Sprintf("type %s", command)
 	output, err := c.readOutput(cmd)
-
 	if err != nil {
 		exitErr, isExitErr := err.(*exec.ExitError)
 		if isExitErr && exitErr.ProcessState.ExitCode() == 127 {
@@ -1578,6 +1759,35 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.MevBoostContainerName+composeFileSuffix))
 	}
 
+	// Check the caching Beacon proxy
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local && cfg.ConsensusCommon.EnableBnProxy.Value == true {
+		// Installs from before the proxy was added don't have its template, so use the copy built into the CLI
+		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.BnProxyContainerName+templateSuffix))
+		if os.IsNotExist(err) {
+			var template string
+			template, err = envsubst.String(bnProxyTemplate)
+			contents = []byte(template)
+		}
+		if err != nil {
+			return []string{}, fmt.Errorf("error reading and substituting caching Beacon proxy container template: %w", err)
+		}
+		bnProxyComposePath := filepath.Join(runtimeFolder, config.BnProxyContainerName+composeFileSuffix)
+		err = os.WriteFile(bnProxyComposePath, contents, 0664)
+		if err != nil {
+			return []string{}, fmt.Errorf("could not write caching Beacon proxy container file to %s: %w", bnProxyComposePath, err)
+		}
+		bnProxyOverridePath := filepath.Join(overrideFolder, config.BnProxyContainerName+composeFileSuffix)
+		_, err = os.Stat(bnProxyOverridePath)
+		if os.IsNotExist(err) {
+			err = os.WriteFile(bnProxyOverridePath, []byte(bnProxyOverride), 0664)
+			if err != nil {
+				return []string{}, fmt.Errorf("could not write caching Beacon proxy override file to %s: %w", bnProxyOverridePath, err)
+			}
+		}
+		deployedContainers = append(deployedContainers, bnProxyComposePath)
+		deployedContainers = append(deployedContainers, bnProxyOverridePath)
+	}
+
 	// Create the custom keys dir
 	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
 	if err != nil {
@@ -1639,6 +1849,38 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	cmd, err := c.getApiCommand(false, args, otherArgs...)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Run the command
+	return c.runApiCall(cmd, nil, false)
+}
+
+// Call the Rocket Pool API with secrets such as a password or mnemonic in its arguments or response.
+// Each secret's argument must be apiutils.StdinSecretArg; the secrets are sent on stdin in the same order instead, so they
+// never show up in the process list. They're redacted from the debug output, and so is the response.
+func (c *Client) callSensitiveAPI(secrets []string, args string, otherArgs ...string) ([]byte, error) {
+	cmd, err := c.getApiCommand(len(secrets) > 0, args, otherArgs...)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Send one secret per line, and wipe them once the call is done
+	stdin := []byte{}
+	for _, value := range secrets {
+		stdin = append(stdin, value...)
+		stdin = append(stdin, '\n')
+	}
+	defer secret.Wipe(stdin)
+
+	// Run the command
+	return c.runApiCall(cmd, stdin, true, secrets...)
+}
+
+// Get the command that calls the Rocket Pool API, passing stdin through to it if requested
+func (c *Client) getApiCommand(withStdin bool, args string, otherArgs ...string) (string, error) {
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1647,22 +1889,25 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 	if c.daemonPath == "" {
 		containerName, err := c.getAPIContainerName()
 		if err != nil {
-			return []byte{}, err
+			return "", err
+		}
+		stdinFlag := ""
+		if withStdin {
+			stdinFlag = "-i "
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s%s %s %s %s %s %s %s api %s", stdinFlag, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getRouteOpts(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getRouteOpts(),
 			args)
 	}
-
-	// Run the command
-	return c.runApiCall(cmd)
+	return cmd, nil
 }
 
 // Call the Rocket Pool API with some custom environment variables
@@ -1682,13 +1927,13 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getRouteOpts(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
@@ -1696,11 +1941,12 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getRouteOpts(),
 			args)
 	}
 
 	// Run the command
-	return c.runApiCall(cmd)
+	return c.runApiCall(cmd, nil, false)
 }
 
 func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, string, string) {
@@ -1730,25 +1976,40 @@ func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, strin
 	return ignoreSyncCheckFlag, forceFallbacksFlag, args
 }
 
-func (c *Client) runApiCall(cmd string) ([]byte, error) {
+func (c *Client) runApiCall(cmd string, stdin []byte, sensitive bool, secrets ...string) ([]byte, error) {
 	if c.debugPrint {
 		fmt.Println("To API:")
-		fmt.Println(cmd)
+		fmt.Println(redactSecrets(cmd, secrets))
 	}
 
-	output, err := c.readOutput(cmd)
+	var output []byte
+	var err error
+	if len(stdin) > 0 {
+		output, err = c.readOutputWithStdin(cmd, stdin)
+	} else {
+		output, err = c.readOutput(cmd)
+	}
 
 	if c.debugPrint {
 		if output != nil {
 			fmt.Println("API Out:")
-			fmt.Println(string(output))
+			if sensitive {
+				fmt.Println(secret.Redacted)
+			} else {
+				fmt.Println(string(output))
+			}
 		}
 		if err != nil {
 			fmt.Println("API Err:")
-			fmt.Println(err.Error())
+			fmt.Println(redactSecrets(err.Error(), secrets))
 		}
 	}
 
+	// Show what the transactions in the call would have done
+	if err == nil && c.simulate {
+		c.printSimulations(output)
+	}
+
 	// Reset the gas settings after the call
 	c.maxFee = c.originalMaxFee
 	c.maxPrioFee = c.originalMaxPrioFee
@@ -1757,6 +2018,18 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	return output, err
 }
 
+// Replace any secrets in a string, along with their shell-quoted versions, so it can be printed
+func redactSecrets(text string, secrets []string) string {
+	for _, value := range secrets {
+		if value == "" {
+			continue
+		}
+		text = strings.ReplaceAll(text, shellescape.Quote(value), secret.Redacted)
+		text = strings.ReplaceAll(text, value, secret.Redacted)
+	}
+	return text
+}
+
 // Get the API container name
 func (c *Client) getAPIContainerName() (string, error) {
 	cfg, _, err := c.LoadConfig()
@@ -1787,6 +2060,18 @@ func (c *Client) getCustomNonce() string {
 	return nonce
 }
 
+// Get the flags for the route transactions should be submitted through
+func (c *Client) getRouteOpts() string {
+	var opts string
+	if c.useProtectedApi {
+		opts += "--use-protected-api "
+	}
+	if c.simulate {
+		opts += "--simulate "
+	}
+	return opts
+}
+
 // Get the first downloader available to the system
 func (c *Client) getDownloader() (string, error) {
 
@@ -1846,3 +2131,21 @@ func (c *Client) readOutput(cmdText string) ([]byte, error) {
 	return cmd.Output()
 
 }
+
+// Run a command with the provided stdin and return its output
+func (c *Client) readOutputWithStdin(cmdText string, stdin []byte) ([]byte, error) {
+
+	// Initialize command
+	cmd, err := c.newCommand(cmdText)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer func() {
+		_ = cmd.Close()
+	}()
+	cmd.SetStdin(bytes.NewReader(stdin))
+
+	// Run command and return output
+	return cmd.Output()
+
+}
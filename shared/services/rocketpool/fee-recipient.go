@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -43,6 +44,29 @@ func CheckFeeRecipientFile(feeRecipient common.Address, cfg *config.RocketPoolCo
 	return true, true, nil
 }
 
+// Gets the fee recipient address the VC is currently configured to use from the fee recipient file.
+// The second return value is false if the file doesn't exist or doesn't contain an address.
+func GetFeeRecipientFromFile(cfg *config.RocketPoolConfig) (common.Address, bool, error) {
+
+	// Read the file
+	path := cfg.Smartnode.GetFeeRecipientFilePath()
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return common.Address{}, false, nil
+	} else if err != nil {
+		return common.Address{}, false, fmt.Errorf("error reading fee recipient file: %w", err)
+	}
+
+	// Parse the address out of it
+	contents := strings.TrimSpace(string(bytes))
+	contents = strings.TrimPrefix(contents, config.FeeRecipientEnvVar+"=")
+	if !common.IsHexAddress(contents) {
+		return common.Address{}, false, nil
+	}
+	return common.HexToAddress(contents), true, nil
+
+}
+
 // Writes the given address to the fee recipient file. The VC should be restarted to pick up the new file.
 func UpdateFeeRecipientFile(feeRecipient common.Address, cfg *config.RocketPoolConfig) error {
 
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
 // Get minipool status
@@ -104,6 +105,22 @@ func (c *Client) CanStakeMinipool(address common.Address) (api.CanStakeMinipoolR
 	return response, nil
 }
 
+// Get the deposit data for a prelaunch minipool's stake deposit
+func (c *Client) GetMinipoolDepositData(address common.Address) (api.MinipoolDepositDataResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-deposit-data %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolDepositDataResponse{}, fmt.Errorf("Could not get minipool deposit data: %w", err)
+	}
+	var response api.MinipoolDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolDepositDataResponse{}, fmt.Errorf("Could not decode minipool deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolDepositDataResponse{}, fmt.Errorf("Could not get minipool deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Stake a minipool
 func (c *Client) StakeMinipool(address common.Address) (api.StakeMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool stake %s", address.Hex()))
@@ -424,6 +441,64 @@ func (c *Client) ReduceBondAmount(address common.Address) (api.ReduceBondAmountR
 	return response, nil
 }
 
+// Get the bond reduction eligibility and progress of the node's 16 ETH minipools
+func (c *Client) GetBondReductions() (api.GetBondReductionsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-bond-reductions")
+	if err != nil {
+		return api.GetBondReductionsResponse{}, fmt.Errorf("Could not get bond reductions: %w", err)
+	}
+	var response api.GetBondReductionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetBondReductionsResponse{}, fmt.Errorf("Could not decode bond reductions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetBondReductionsResponse{}, fmt.Errorf("Could not get bond reductions: %s", response.Error)
+	}
+	if response.RplStake == nil {
+		response.RplStake = big.NewInt(0)
+	}
+	if response.MinimumRplStake == nil {
+		response.MinimumRplStake = big.NewInt(0)
+	}
+	if response.RplPerReduction == nil {
+		response.RplPerReduction = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Plan the migration of the node's 16 ETH minipools to 8 ETH bonds
+func (c *Client) GetMigratePlan() (api.MinipoolMigratePlanResponse, error) {
+	responseBytes, err := c.callAPI("minipool migrate-plan")
+	if err != nil {
+		return api.MinipoolMigratePlanResponse{}, fmt.Errorf("Could not get migration plan: %w", err)
+	}
+	var response api.MinipoolMigratePlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolMigratePlanResponse{}, fmt.Errorf("Could not decode migration plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolMigratePlanResponse{}, fmt.Errorf("Could not get migration plan: %s", response.Error)
+	}
+	for _, value := range []**big.Int{
+		&response.BondReductions.RplStake,
+		&response.BondReductions.MinimumRplStake,
+		&response.BondReductions.RplPerReduction,
+		&response.RplBalance,
+		&response.CreditBalance,
+		&response.MaximumRplStake,
+		&response.RplToStake,
+		&response.RplShortfall,
+		&response.ProjectedCreditBalance,
+		&response.ProjectedMaximumRplStake,
+		&response.ProjectedWithdrawableRpl,
+	} {
+		if *value == nil {
+			*value = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
 // Get the balance distribution details for all of the node's minipools
 func (c *Client) GetDistributeBalanceDetails() (api.GetDistributeBalanceDetailsResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-distribute-balance-details")
@@ -458,7 +533,7 @@ func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanc
 
 // Import a validator private key for a vacant minipool
 func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("minipool import-key %s", address.Hex()), mnemonic)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, fmt.Sprintf("minipool import-key %s", address.Hex()), apiutils.StdinSecretArg)
 	if err != nil {
 		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %w", err)
 	}
@@ -474,7 +549,7 @@ func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeW
 
 // Check whether a solo validator's withdrawal creds can be migrated to a minipool address
 func (c *Client) CanChangeWithdrawalCredentials(address common.Address, mnemonic string) (api.CanChangeWithdrawalCredentialsResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-change-withdrawal-creds %s", address.Hex()), mnemonic)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, fmt.Sprintf("minipool can-change-withdrawal-creds %s", address.Hex()), apiutils.StdinSecretArg)
 	if err != nil {
 		return api.CanChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not get can-change-withdrawal-creds status: %w", err)
 	}
@@ -490,7 +565,7 @@ func (c *Client) CanChangeWithdrawalCredentials(address common.Address, mnemonic
 
 // Migrate a solo validator's withdrawal creds to a minipool address
 func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("minipool change-withdrawal-creds %s", address.Hex()), mnemonic)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, fmt.Sprintf("minipool change-withdrawal-creds %s", address.Hex()), apiutils.StdinSecretArg)
 	if err != nil {
 		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not change withdrawal creds: %w", err)
 	}
@@ -503,3 +578,142 @@ func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic st
 	}
 	return response, nil
 }
+
+// Score the attestations and proposals of the node's minipools over a number of epochs against the network average
+func (c *Client) GetMinipoolPerformance(epochs uint64) (api.MinipoolPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool performance %d", epochs))
+	if err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %w", err)
+	}
+	var response api.MinipoolPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not decode minipool performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the expected return of keeping each minipool versus exiting it and redeploying its ETH
+func (c *Client) GetMinipoolExitAdvice() (api.MinipoolExitAdviceResponse, error) {
+	responseBytes, err := c.callAPI("minipool exit-advice")
+	if err != nil {
+		return api.MinipoolExitAdviceResponse{}, fmt.Errorf("Could not get minipool exit advice: %w", err)
+	}
+	var response api.MinipoolExitAdviceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolExitAdviceResponse{}, fmt.Errorf("Could not decode minipool exit advice response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolExitAdviceResponse{}, fmt.Errorf("Could not get minipool exit advice: %s", response.Error)
+	}
+	if response.Report.RplStake == nil {
+		response.Report.RplStake = big.NewInt(0)
+	}
+	if response.Report.MinimumRplStake == nil {
+		response.Report.MinimumRplStake = big.NewInt(0)
+	}
+	for i := range response.Report.Minipools {
+		if response.Report.Minipools[i].AdditionalRpl == nil {
+			response.Report.Minipools[i].AdditionalRpl = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
+// Get the ETH each of the node's staking minipools is projected to earn over a year at the recent staking APR
+func (c *Client) GetMinipoolProjectedIncome() (api.MinipoolProjectedIncomeResponse, error) {
+	responseBytes, err := c.callAPI("minipool projected-income")
+	if err != nil {
+		return api.MinipoolProjectedIncomeResponse{}, fmt.Errorf("Could not get minipool projected income: %w", err)
+	}
+	var response api.MinipoolProjectedIncomeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolProjectedIncomeResponse{}, fmt.Errorf("Could not decode minipool projected income response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolProjectedIncomeResponse{}, fmt.Errorf("Could not get minipool projected income: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the labels given to the node's minipools
+func (c *Client) GetMinipoolLabels() (api.MinipoolLabelsResponse, error) {
+	responseBytes, err := c.callAPI("minipool labels")
+	if err != nil {
+		return api.MinipoolLabelsResponse{}, fmt.Errorf("Could not get minipool labels: %w", err)
+	}
+	var response api.MinipoolLabelsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolLabelsResponse{}, fmt.Errorf("Could not decode minipool labels response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolLabelsResponse{}, fmt.Errorf("Could not get minipool labels: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the label of one of the node's minipools; an empty label removes it
+func (c *Client) SetMinipoolLabel(address common.Address, label string) (api.SetMinipoolLabelResponse, error) {
+	responseBytes, err := c.callAPI("minipool set-label", address.Hex(), label)
+	if err != nil {
+		return api.SetMinipoolLabelResponse{}, fmt.Errorf("Could not set minipool label: %w", err)
+	}
+	var response api.SetMinipoolLabelResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetMinipoolLabelResponse{}, fmt.Errorf("Could not decode set minipool label response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetMinipoolLabelResponse{}, fmt.Errorf("Could not set minipool label: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the tags given to the node's minipools; a non-empty tag only includes the minipools with that tag
+func (c *Client) GetMinipoolTags(tag string) (api.MinipoolTagsResponse, error) {
+	responseBytes, err := c.callAPI("minipool tags", tag)
+	if err != nil {
+		return api.MinipoolTagsResponse{}, fmt.Errorf("Could not get minipool tags: %w", err)
+	}
+	var response api.MinipoolTagsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolTagsResponse{}, fmt.Errorf("Could not decode minipool tags response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolTagsResponse{}, fmt.Errorf("Could not get minipool tags: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Add a tag to one of the node's minipools
+func (c *Client) TagMinipool(address common.Address, tag string) (api.TagMinipoolResponse, error) {
+	responseBytes, err := c.callAPI("minipool tag", address.Hex(), tag)
+	if err != nil {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not tag minipool: %w", err)
+	}
+	var response api.TagMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not decode tag minipool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not tag minipool: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Remove a tag from one of the node's minipools
+func (c *Client) UntagMinipool(address common.Address, tag string) (api.TagMinipoolResponse, error) {
+	responseBytes, err := c.callAPI("minipool untag", address.Hex(), tag)
+	if err != nil {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not untag minipool: %w", err)
+	}
+	var response api.TagMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not decode untag minipool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TagMinipoolResponse{}, fmt.Errorf("Could not untag minipool: %s", response.Error)
+	}
+	return response, nil
+}
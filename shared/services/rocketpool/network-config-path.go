@@ -0,0 +1,152 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The networks that can be set up next to each other, each with its own config folder
+var SelectableNetworks = []cfgtypes.Network{
+	cfgtypes.Network_Mainnet,
+	cfgtypes.Network_Prater,
+	cfgtypes.Network_Devnet,
+}
+
+// Get the config path the CLI should use, which is the network's own folder if one was selected with --network
+func GetConfigPathFromCtx(c *cli.Context) (string, error) {
+	return GetNetworkConfigPath(c.GlobalString("config-path"), c.GlobalString("network"))
+}
+
+// Get the config folder for a network. The default folder is used if no network was selected or it's already set up for the selected one;
+// otherwise the network gets its own folder next to it (such as ~/.rocketpool-prater), so its wallet, data and containers are kept apart.
+func GetNetworkConfigPath(configPath string, network string) (string, error) {
+	if network == "" {
+		return configPath, nil
+	}
+	selectedNetwork, err := ParseSelectableNetwork(network)
+	if err != nil {
+		return "", err
+	}
+
+	// Use the default folder if it's already set up for the network
+	defaultNetwork, err := getConfiguredNetwork(configPath)
+	if err != nil {
+		return "", err
+	}
+	if defaultNetwork == selectedNetwork {
+		return configPath, nil
+	}
+	return getIsolatedNetworkConfigPath(configPath, selectedNetwork), nil
+}
+
+// Get the config folder of each network that has been set up, including the default folder
+func GetNetworkConfigPaths(configPath string) (map[cfgtypes.Network]string, error) {
+	paths := map[cfgtypes.Network]string{}
+	defaultNetwork, err := getConfiguredNetwork(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if defaultNetwork != cfgtypes.Network_Unknown {
+		paths[defaultNetwork] = configPath
+	}
+	for _, network := range SelectableNetworks {
+		if network == defaultNetwork {
+			continue
+		}
+		networkPath := getIsolatedNetworkConfigPath(configPath, network)
+		configuredNetwork, err := getConfiguredNetwork(networkPath)
+		if err != nil {
+			return nil, err
+		}
+		if configuredNetwork != cfgtypes.Network_Unknown {
+			paths[configuredNetwork] = networkPath
+		}
+	}
+	return paths, nil
+}
+
+// Parse the name of a network that can be selected with --network
+func ParseSelectableNetwork(network string) (cfgtypes.Network, error) {
+	names := []string{}
+	for _, selectableNetwork := range SelectableNetworks {
+		if strings.EqualFold(network, string(selectableNetwork)) {
+			return selectableNetwork, nil
+		}
+		names = append(names, string(selectableNetwork))
+	}
+	return cfgtypes.Network_Unknown, fmt.Errorf("unknown network '%s' (supported networks: %s)", network, strings.Join(names, ", "))
+}
+
+// Set up a new config for the network its folder belongs to.
+// A network in its own folder also gets its own Docker project and shifted host ports, so it can run next to the node in the default folder.
+func applyNetworkDefaults(cfg *config.RocketPoolConfig, network cfgtypes.Network, isIsolated bool) {
+	cfg.ChangeNetwork(network)
+	if !isIsolated {
+		return
+	}
+	cfg.Smartnode.ProjectName.Value = fmt.Sprintf("%s-%s", cfg.Smartnode.ProjectName.Value, network)
+
+	// Each network's ports are shifted by a different multiple of 1000. The default ports sit close together (the metrics
+	// ports are 9100-9111 and the Beacon Node's P2P port is 9001), so a smaller step would move one network's port onto
+	// another network's default.
+	offset := uint16(0)
+	for i, selectableNetwork := range SelectableNetworks {
+		if selectableNetwork == network {
+			offset = uint16(1000 * (i + 1))
+		}
+	}
+	for _, param := range []*cfgtypes.Parameter{
+		&cfg.ExecutionCommon.HttpPort,
+		&cfg.ExecutionCommon.WsPort,
+		&cfg.ExecutionCommon.EnginePort,
+		&cfg.ExecutionCommon.P2pPort,
+		&cfg.ConsensusCommon.P2pPort,
+		&cfg.ConsensusCommon.ApiPort,
+		&cfg.ConsensusCommon.BnProxyPort,
+		&cfg.Prysm.RpcPort,
+		&cfg.MevBoost.Port,
+		&cfg.Grafana.Port,
+		&cfg.Prometheus.Port,
+		&cfg.EcMetricsPort,
+		&cfg.BnMetricsPort,
+		&cfg.VcMetricsPort,
+		&cfg.NodeMetricsPort,
+		&cfg.ExporterMetricsPort,
+		&cfg.WatchtowerMetricsPort,
+		&cfg.Smartnode.NodeApiPort,
+		&cfg.Smartnode.MetricsStreamPort,
+	} {
+		param.Value = param.Value.(uint16) + offset
+	}
+}
+
+// Get the folder a network is isolated in when the default folder is used by another network
+func getIsolatedNetworkConfigPath(configPath string, network cfgtypes.Network) string {
+	return fmt.Sprintf("%s-%s", strings.TrimSuffix(configPath, string(filepath.Separator)), network)
+}
+
+// Get the network the config in a folder is set up for, or Network_Unknown if there isn't one
+func getConfiguredNetwork(configPath string) (cfgtypes.Network, error) {
+	settingsFilePath, err := homedir.Expand(filepath.Join(os.ExpandEnv(configPath), SettingsFile))
+	if err != nil {
+		return cfgtypes.Network_Unknown, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	cfg, err := rp.LoadConfigFromFile(settingsFilePath)
+	if err != nil {
+		return cfgtypes.Network_Unknown, err
+	}
+	if cfg == nil {
+		return cfgtypes.Network_Unknown, nil
+	}
+	network, _ := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	return network, nil
+}
@@ -24,6 +24,37 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get EIP-1559 fee suggestions from the Execution client's fee history
+func (c *Client) FeeSuggestion() (api.NetworkFeeSuggestionResponse, error) {
+	responseBytes, err := c.callAPI("network fee-suggestion")
+	if err != nil {
+		return api.NetworkFeeSuggestionResponse{}, fmt.Errorf("Could not get fee suggestions: %w", err)
+	}
+	var response api.NetworkFeeSuggestionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkFeeSuggestionResponse{}, fmt.Errorf("Could not decode fee suggestion response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkFeeSuggestionResponse{}, fmt.Errorf("Could not get fee suggestions: %s", response.Error)
+	}
+	for _, value := range []**big.Int{
+		&response.Suggestion.BaseFeeWei,
+		&response.Suggestion.RapidWei,
+		&response.Suggestion.RapidPriorityWei,
+		&response.Suggestion.FastWei,
+		&response.Suggestion.FastPriorityWei,
+		&response.Suggestion.StandardWei,
+		&response.Suggestion.StandardPriorityWei,
+		&response.Suggestion.SlowWei,
+		&response.Suggestion.SlowPriorityWei,
+	} {
+		if *value == nil {
+			*value = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -135,7 +166,7 @@ func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error
 	return response, nil
 }
 
-// Download a rewards info file from IPFS for the given interval
+// Download a rewards info file from IPFS or a mirror for the given interval
 func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("network download-rewards-file %d", interval))
 	if err != nil {
@@ -151,6 +182,22 @@ func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileRe
 	return response, nil
 }
 
+// Verify the local rewards tree file for an interval against the submitted Merkle root and the published file
+func (c *Client) VerifyRewardsFile(interval uint64) (api.VerifyRewardsFileResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-file %d", interval))
+	if err != nil {
+		return api.VerifyRewardsFileResponse{}, fmt.Errorf("could not verify rewards file: %w", err)
+	}
+	var response api.VerifyRewardsFileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyRewardsFileResponse{}, fmt.Errorf("could not decode verify-rewards-file response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyRewardsFileResponse{}, fmt.Errorf("could not verify rewards file: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if Atlas has been deployed yet
 func (c *Client) IsAtlasDeployed() (api.IsAtlasDeployedResponse, error) {
 	responseBytes, err := c.callAPI("network is-atlas-deployed")
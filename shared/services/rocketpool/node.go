@@ -158,6 +158,86 @@ func (c *Client) ConfirmNodeWithdrawalAddress() (api.SetNodeWithdrawalAddressRes
 	return response, nil
 }
 
+// Get the node's RPL withdrawal address
+func (c *Client) GetNodeRplWithdrawalAddress() (api.NodeRplWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node get-rpl-withdrawal-address")
+	if err != nil {
+		return api.NodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get node RPL withdrawal address: %w", err)
+	}
+	var response api.NodeRplWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not decode node RPL withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get node RPL withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks if the node's RPL withdrawal address can be set
+func (c *Client) CanSetNodeRplWithdrawalAddress(rplWithdrawalAddress common.Address, confirm bool) (api.CanSetNodeRplWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node can-set-rpl-withdrawal-address", rplWithdrawalAddress.Hex(), strconv.FormatBool(confirm))
+	if err != nil {
+		return api.CanSetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get can set node RPL withdrawal address: %w", err)
+	}
+	var response api.CanSetNodeRplWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not decode can set node RPL withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get can set node RPL withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the node's RPL withdrawal address
+func (c *Client) SetNodeRplWithdrawalAddress(rplWithdrawalAddress common.Address, confirm bool) (api.SetNodeRplWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node set-rpl-withdrawal-address", rplWithdrawalAddress.Hex(), strconv.FormatBool(confirm))
+	if err != nil {
+		return api.SetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not set node RPL withdrawal address: %w", err)
+	}
+	var response api.SetNodeRplWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not decode set node RPL withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not set node RPL withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks if the node's pending RPL withdrawal address can be confirmed
+func (c *Client) CanConfirmNodeRplWithdrawalAddress() (api.CanConfirmNodeRplWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node can-confirm-rpl-withdrawal-address")
+	if err != nil {
+		return api.CanConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get can confirm node RPL withdrawal address: %w", err)
+	}
+	var response api.CanConfirmNodeRplWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not decode can confirm node RPL withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not get can confirm node RPL withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Confirm the node's pending RPL withdrawal address
+func (c *Client) ConfirmNodeRplWithdrawalAddress() (api.ConfirmNodeRplWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node confirm-rpl-withdrawal-address")
+	if err != nil {
+		return api.ConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not confirm node RPL withdrawal address: %w", err)
+	}
+	var response api.ConfirmNodeRplWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not decode confirm node RPL withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmNodeRplWithdrawalAddressResponse{}, fmt.Errorf("Could not confirm node RPL withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Checks if the node's timezone location can be set
 func (c *Client) CanSetNodeTimezone(timezoneLocation string) (api.CanSetNodeTimezoneResponse, error) {
 	responseBytes, err := c.callAPI("node can-set-timezone", timezoneLocation)
@@ -398,6 +478,22 @@ func (c *Client) CanNodeWithdrawRpl(amountWei *big.Int) (api.CanNodeWithdrawRplR
 	return response, nil
 }
 
+// Get the most staked RPL the node can withdraw while staying above a target collateral ratio (as a fraction of borrowed ETH, in wei)
+func (c *Client) GetNodeRplWithdrawalPlan(targetCollateral *big.Int) (api.NodeRplWithdrawalPlanResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-rpl-withdrawal-plan %s", targetCollateral.String()))
+	if err != nil {
+		return api.NodeRplWithdrawalPlanResponse{}, fmt.Errorf("Could not get RPL withdrawal plan: %w", err)
+	}
+	var response api.NodeRplWithdrawalPlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRplWithdrawalPlanResponse{}, fmt.Errorf("Could not decode RPL withdrawal plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRplWithdrawalPlanResponse{}, fmt.Errorf("Could not get RPL withdrawal plan: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Withdraw RPL staked against the node
 func (c *Client) NodeWithdrawRpl(amountWei *big.Int) (api.NodeWithdrawRplResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node withdraw-rpl %s", amountWei.String()))
@@ -830,6 +926,26 @@ func (c *Client) NodeClaimAndStakeRewards(indices []uint64, stakeAmountWei *big.
 	return response, nil
 }
 
+// Compare the ways the rewards for the given intervals can be claimed
+func (c *Client) NodeOptimizeClaims(indices []uint64, stakeAmountWei *big.Int) (api.NodeOptimizeClaimsResponse, error) {
+	indexStrings := []string{}
+	for _, index := range indices {
+		indexStrings = append(indexStrings, fmt.Sprint(index))
+	}
+	responseBytes, err := c.callAPI("node optimize-claims", strings.Join(indexStrings, ","), stakeAmountWei.String())
+	if err != nil {
+		return api.NodeOptimizeClaimsResponse{}, fmt.Errorf("Could not optimize rewards claims: %w", err)
+	}
+	var response api.NodeOptimizeClaimsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeOptimizeClaimsResponse{}, fmt.Errorf("Could not decode optimize rewards claims response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeOptimizeClaimsResponse{}, fmt.Errorf("Could not optimize rewards claims: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether or not the node is opted into the Smoothing Pool
 func (c *Client) NodeGetSmoothingPoolRegistrationStatus() (api.GetSmoothingPoolRegistrationStatusResponse, error) {
 	responseBytes, err := c.callAPI("node get-smoothing-pool-registration-status")
@@ -926,6 +1042,25 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Use the node private key to sign EIP-712 typed data
+func (c *Client) SignTypedData(typedData string) (api.NodeSignTypedDataResponse, error) {
+	// Ignore sync status so we can sign typed data even without ready clients
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI("node sign-typed-data", typedData)
+	if err != nil {
+		return api.NodeSignTypedDataResponse{}, fmt.Errorf("Could not sign typed data: %w", err)
+	}
+
+	var response api.NodeSignTypedDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSignTypedDataResponse{}, fmt.Errorf("Could not decode node sign typed data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSignTypedDataResponse{}, fmt.Errorf("Could not sign typed data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a vacant minipool can be created for solo staker migration
 func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CanCreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
@@ -974,6 +1109,38 @@ func (c *Client) CheckCollateral() (api.CheckCollateralResponse, error) {
 	return response, nil
 }
 
+// Get how many minipools the node can create from its credit, its ETH balance and its RPL stake
+func (c *Client) GetNodeDepositPlan() (api.NodeDepositPlanResponse, error) {
+	responseBytes, err := c.callAPI("node get-deposit-plan")
+	if err != nil {
+		return api.NodeDepositPlanResponse{}, fmt.Errorf("Could not get node deposit plan: %w", err)
+	}
+	var response api.NodeDepositPlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDepositPlanResponse{}, fmt.Errorf("Could not decode node deposit plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDepositPlanResponse{}, fmt.Errorf("Could not get node deposit plan: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check the node's withdrawal addresses and its minipools' withdrawal credentials against the expected ones
+func (c *Client) GetWithdrawalSafety() (api.NodeWithdrawalSafetyResponse, error) {
+	responseBytes, err := c.callAPI("node get-withdrawal-safety")
+	if err != nil {
+		return api.NodeWithdrawalSafetyResponse{}, fmt.Errorf("Could not check withdrawal address safety: %w", err)
+	}
+	var response api.NodeWithdrawalSafetyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeWithdrawalSafetyResponse{}, fmt.Errorf("Could not decode withdrawal address safety response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeWithdrawalSafetyResponse{}, fmt.Errorf("Could not check withdrawal address safety: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the ETH balance of the node address
 func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	responseBytes, err := c.callAPI("node get-eth-balance")
@@ -989,3 +1156,180 @@ func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the history of the node's on-chain activity
+func (c *Client) GetNodeActivity() (api.NodeActivityResponse, error) {
+	responseBytes, err := c.callAPI("node get-activity")
+	if err != nil {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not get node activity: %w", err)
+	}
+	var response api.NodeActivityResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not decode node activity response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not get node activity: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's upcoming obligations as calendar events
+func (c *Client) NodeDutiesCalendar() (api.NodeDutiesCalendarResponse, error) {
+	responseBytes, err := c.callAPI("node duties-calendar")
+	if err != nil {
+		return api.NodeDutiesCalendarResponse{}, fmt.Errorf("Could not get node duties calendar: %w", err)
+	}
+	var response api.NodeDutiesCalendarResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDutiesCalendarResponse{}, fmt.Errorf("Could not decode node duties calendar response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDutiesCalendarResponse{}, fmt.Errorf("Could not get node duties calendar: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the events recorded in the node's event journal; types is "all" or a comma-separated list, and since and limit are ignored if they're 0
+func (c *Client) NodeHistory(types string, since uint64, limit uint64) (api.NodeHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node history %s %d %d", types, since, limit))
+	if err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %w", err)
+	}
+	var response api.NodeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not decode node history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's daily balance snapshots from the last number of days (0 for all of them)
+func (c *Client) NodeBalanceHistory(days uint64) (api.NodeBalanceHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node balance-history %d", days))
+	if err != nil {
+		return api.NodeBalanceHistoryResponse{}, fmt.Errorf("Could not get balance history: %w", err)
+	}
+	var response api.NodeBalanceHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeBalanceHistoryResponse{}, fmt.Errorf("Could not decode balance history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeBalanceHistoryResponse{}, fmt.Errorf("Could not get balance history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's rewards for every interval it took part in
+func (c *Client) GetRewardsHistory() (api.NodeRewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI("node get-rewards-history")
+	if err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get rewards history: %w", err)
+	}
+	var response api.NodeRewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not decode rewards history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get rewards history: %s", response.Error)
+	}
+	for i, interval := range response.Intervals {
+		if interval.CollateralRpl == nil {
+			response.Intervals[i].CollateralRpl = big.NewInt(0)
+		}
+		if interval.ODaoRpl == nil {
+			response.Intervals[i].ODaoRpl = big.NewInt(0)
+		}
+		if interval.SmoothingPoolEth == nil {
+			response.Intervals[i].SmoothingPoolEth = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
+// Get everything Rocket Pool has sent to the node's withdrawal address
+func (c *Client) GetWithdrawalLedger() (api.NodeWithdrawalLedgerResponse, error) {
+	responseBytes, err := c.callAPI("node get-withdrawal-ledger")
+	if err != nil {
+		return api.NodeWithdrawalLedgerResponse{}, fmt.Errorf("Could not get withdrawal ledger: %w", err)
+	}
+	var response api.NodeWithdrawalLedgerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeWithdrawalLedgerResponse{}, fmt.Errorf("Could not decode withdrawal ledger response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeWithdrawalLedgerResponse{}, fmt.Errorf("Could not get withdrawal ledger: %s", response.Error)
+	}
+	if response.TotalEth == nil {
+		response.TotalEth = big.NewInt(0)
+	}
+	if response.TotalRpl == nil {
+		response.TotalRpl = big.NewInt(0)
+	}
+	for i, entry := range response.Entries {
+		if entry.EthAmount == nil {
+			response.Entries[i].EthAmount = big.NewInt(0)
+		}
+		if entry.RplAmount == nil {
+			response.Entries[i].RplAmount = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
+// Get the actions that would raise the node's weighted average commission
+func (c *Client) GetCommissionUpgrades() (api.NodeCommissionUpgradesResponse, error) {
+	responseBytes, err := c.callAPI("node commission-upgrades")
+	if err != nil {
+		return api.NodeCommissionUpgradesResponse{}, fmt.Errorf("Could not get commission upgrades: %w", err)
+	}
+	var response api.NodeCommissionUpgradesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCommissionUpgradesResponse{}, fmt.Errorf("Could not decode commission upgrades response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCommissionUpgradesResponse{}, fmt.Errorf("Could not get commission upgrades: %s", response.Error)
+	}
+	if response.Report.DepositCreditBalance == nil {
+		response.Report.DepositCreditBalance = big.NewInt(0)
+	}
+	for i := range response.Report.Upgrades {
+		if response.Report.Upgrades[i].AdditionalRplRequired == nil {
+			response.Report.Upgrades[i].AdditionalRplRequired = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
+// Get the graffiti of each of the node's validators
+func (c *Client) NodeGraffiti() (api.NodeGraffitiResponse, error) {
+	responseBytes, err := c.callAPI("node graffiti")
+	if err != nil {
+		return api.NodeGraffitiResponse{}, fmt.Errorf("Could not get validator graffiti: %w", err)
+	}
+	var response api.NodeGraffitiResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeGraffitiResponse{}, fmt.Errorf("Could not decode validator graffiti response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeGraffitiResponse{}, fmt.Errorf("Could not get validator graffiti: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the graffiti template of one of the node's validators
+func (c *Client) NodeSetGraffiti(pubkey types.ValidatorPubkey, template string) (api.NodeSetGraffitiResponse, error) {
+	responseBytes, err := c.callAPI("node set-graffiti", pubkey.Hex(), template)
+	if err != nil {
+		return api.NodeSetGraffitiResponse{}, fmt.Errorf("Could not set validator graffiti: %w", err)
+	}
+	var response api.NodeSetGraffitiResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSetGraffitiResponse{}, fmt.Errorf("Could not decode set validator graffiti response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSetGraffitiResponse{}, fmt.Errorf("Could not set validator graffiti: %s", response.Error)
+	}
+	return response, nil
+}
@@ -66,7 +66,7 @@ func (c *Client) TNDAOProposals() (api.TNDAOProposalsResponse, error) {
 
 // Get a single oracle DAO proposal
 func (c *Client) TNDAOProposal(id uint64) (api.TNDAOProposalResponse, error) {
-	responseBytes, err := c.callAPI("odao proposal-details", string(id))
+	responseBytes, err := c.callAPI("odao proposal-details", fmt.Sprint(id))
 	if err != nil {
 		return api.TNDAOProposalResponse{}, fmt.Errorf("Could not get oracle DAO proposal: %w", err)
 	}
@@ -384,6 +384,86 @@ func (c *Client) LeaveTNDAO(bondRefundAddress common.Address) (api.LeaveTNDAORes
 	return response, nil
 }
 
+// Get the node's oracle DAO bond, challenge and replacement status
+func (c *Client) TNDAOMembership() (api.TNDAOMembershipResponse, error) {
+	responseBytes, err := c.callAPI("odao membership")
+	if err != nil {
+		return api.TNDAOMembershipResponse{}, fmt.Errorf("Could not get oracle DAO membership status: %w", err)
+	}
+	var response api.TNDAOMembershipResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOMembershipResponse{}, fmt.Errorf("Could not decode oracle DAO membership status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOMembershipResponse{}, fmt.Errorf("Could not get oracle DAO membership status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can challenge an oracle DAO member
+func (c *Client) CanChallengeTNDAOMember(memberAddress common.Address) (api.CanChallengeTNDAOMemberResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-challenge-member %s", memberAddress.Hex()))
+	if err != nil {
+		return api.CanChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not get can challenge oracle DAO member status: %w", err)
+	}
+	var response api.CanChallengeTNDAOMemberResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not decode can challenge oracle DAO member response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not get can challenge oracle DAO member status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Challenge an oracle DAO member
+func (c *Client) ChallengeTNDAOMember(memberAddress common.Address) (api.ChallengeTNDAOMemberResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao challenge-member %s", memberAddress.Hex()))
+	if err != nil {
+		return api.ChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not challenge oracle DAO member: %w", err)
+	}
+	var response api.ChallengeTNDAOMemberResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not decode challenge oracle DAO member response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ChallengeTNDAOMemberResponse{}, fmt.Errorf("Could not challenge oracle DAO member: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can decide a challenge against an oracle DAO member
+func (c *Client) CanDecideTNDAOChallenge(memberAddress common.Address) (api.CanDecideTNDAOChallengeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-decide-challenge %s", memberAddress.Hex()))
+	if err != nil {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not get can decide oracle DAO challenge status: %w", err)
+	}
+	var response api.CanDecideTNDAOChallengeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decode can decide oracle DAO challenge response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not get can decide oracle DAO challenge status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decide a challenge against an oracle DAO member
+func (c *Client) DecideTNDAOChallenge(memberAddress common.Address) (api.DecideTNDAOChallengeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao decide-challenge %s", memberAddress.Hex()))
+	if err != nil {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decide oracle DAO challenge: %w", err)
+	}
+	var response api.DecideTNDAOChallengeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decode decide oracle DAO challenge response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decide oracle DAO challenge: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can replace its position in the oracle DAO
 func (c *Client) CanReplaceTNDAOMember() (api.CanReplaceTNDAOPositionResponse, error) {
 	responseBytes, err := c.callAPI("odao can-replace")
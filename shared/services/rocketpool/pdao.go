@@ -0,0 +1,56 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's protocol DAO voting power
+func (c *Client) PdaoVotingPower() (api.PdaoVotingPowerResponse, error) {
+	responseBytes, err := c.callAPI("pdao voting-power")
+	if err != nil {
+		return api.PdaoVotingPowerResponse{}, fmt.Errorf("Could not get voting power: %w", err)
+	}
+	var response api.PdaoVotingPowerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PdaoVotingPowerResponse{}, fmt.Errorf("Could not decode voting power response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PdaoVotingPowerResponse{}, fmt.Errorf("Could not get voting power: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can vote on a protocol DAO proposal
+func (c *Client) PdaoCanVote(proposalId string, choice uint64) (api.PdaoCanVoteResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-vote %s %d", proposalId, choice))
+	if err != nil {
+		return api.PdaoCanVoteResponse{}, fmt.Errorf("Could not get can vote on proposal status: %w", err)
+	}
+	var response api.PdaoCanVoteResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PdaoCanVoteResponse{}, fmt.Errorf("Could not decode can vote on proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PdaoCanVoteResponse{}, fmt.Errorf("Could not get can vote on proposal status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Vote on a protocol DAO proposal
+func (c *Client) PdaoVote(proposalId string, choice uint64) (api.PdaoVoteResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao vote %s %d", proposalId, choice))
+	if err != nil {
+		return api.PdaoVoteResponse{}, fmt.Errorf("Could not vote on proposal: %w", err)
+	}
+	var response api.PdaoVoteResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PdaoVoteResponse{}, fmt.Errorf("Could not decode vote on proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PdaoVoteResponse{}, fmt.Errorf("Could not vote on proposal: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Check if transactions are being simulated instead of sent
+func (c *Client) IsSimulating() bool {
+	return c.simulate
+}
+
+// Print the transactions an API call simulated, if there were any
+func (c *Client) printSimulations(responseBytes []byte) {
+	var response api.SimulationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return
+	}
+
+	for _, simulation := range response.Simulations {
+		fmt.Printf("%s=== Simulated Transaction %s ===%s\n", colorYellow, simulation.Hash.Hex(), colorReset)
+		fmt.Printf("From:             %s\n", simulation.From.Hex())
+		if simulation.To != nil {
+			if simulation.Contract != "" {
+				fmt.Printf("To:               %s (%s)\n", simulation.To.Hex(), simulation.Contract)
+			} else {
+				fmt.Printf("To:               %s\n", simulation.To.Hex())
+			}
+		}
+		if simulation.Method != "" {
+			fmt.Printf("Call:             %s\n", simulation.Method)
+		}
+		for _, argument := range simulation.Arguments {
+			fmt.Printf("                  %s\n", argument)
+		}
+		if simulation.Value != nil && simulation.Value.Sign() > 0 {
+			fmt.Printf("Value:            %.6f ETH\n", eth.WeiToEth(simulation.Value))
+		}
+		fmt.Printf("Nonce:            %d\n", simulation.Nonce)
+		fmt.Printf("Gas limit:        %d\n", simulation.GasLimit)
+		fmt.Printf("Expected gas:     %d\n", simulation.EstimatedGas)
+		if simulation.MaxFee != nil && simulation.MaxPriorityFee != nil {
+			fmt.Printf("Max fee:          %.2f gwei (%.2f gwei priority)\n", eth.WeiToGwei(simulation.MaxFee), eth.WeiToGwei(simulation.MaxPriorityFee))
+			maxCost := eth.WeiToEth(simulation.MaxFee) * float64(simulation.EstimatedGas)
+			fmt.Printf("Max cost:         %.6f ETH\n", maxCost)
+		}
+		if simulation.Error != "" {
+			fmt.Printf("Result:           %sFAILED: %s%s\n\n", colorRed, simulation.Error, colorReset)
+		} else if len(simulation.Result) > 0 {
+			fmt.Printf("Result:           %ssuccess%s (returned %s)\n\n", colorGreen, colorReset, strings.Join(simulation.Result, ", "))
+		} else {
+			fmt.Printf("Result:           %ssuccess%s\n\n", colorGreen, colorReset)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Config
+const systemdUnitFolder string = "/etc/systemd/system"
+
+// Generate the Native mode systemd units, write them to the system folder, and reload systemd
+func (c *Client) InstallSystemdUnits(cfg *config.RocketPoolConfig) ([]config.SystemdUnit, error) {
+
+	// Generate the units
+	units, err := cfg.GenerateSystemdUnits(c.daemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error generating systemd units: %w", err)
+	}
+
+	// Get the command to run with root privileges
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return nil, fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	for _, unit := range units {
+		// Write the unit to a temporary file first, since the system folder needs root access
+		tempFile, err := os.CreateTemp("", unit.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error creating temporary file for %s: %w", unit.Name, err)
+		}
+		_, err = tempFile.WriteString(unit.Contents)
+		tempFile.Close()
+		if err != nil {
+			os.Remove(tempFile.Name())
+			return nil, fmt.Errorf("error writing %s: %w", unit.Name, err)
+		}
+
+		cmd := fmt.Sprintf("%s install -m 0644 %s %s", rootCmd, shellescape.Quote(tempFile.Name()), shellescape.Quote(filepath.Join(systemdUnitFolder, unit.Name)))
+		_, err = c.readOutput(cmd)
+		os.Remove(tempFile.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error installing %s: %w", unit.Name, err)
+		}
+	}
+
+	_, err = c.readOutput(fmt.Sprintf("%s systemctl daemon-reload", rootCmd))
+	if err != nil {
+		return nil, fmt.Errorf("error reloading systemd: %w", err)
+	}
+	return units, nil
+
+}
+
+// Enable and start the given systemd units
+func (c *Client) StartSystemdUnits(unitNames []string) error {
+	return c.runSystemctl("enable --now", unitNames)
+}
+
+// Stop the given systemd units without disabling them
+func (c *Client) StopSystemdUnits(unitNames []string) error {
+	return c.runSystemctl("stop", unitNames)
+}
+
+// Print the status of the given systemd units
+func (c *Client) PrintSystemdUnitStatus(unitNames []string) error {
+	return c.printOutput(fmt.Sprintf("systemctl list-units --all --no-pager %s", quoteUnitNames(unitNames)))
+}
+
+// Print the logs of the given systemd units
+func (c *Client) PrintSystemdUnitLogs(unitNames []string, tail string) error {
+	args := make([]string, len(unitNames))
+	for i, unitName := range unitNames {
+		args[i] = "-u " + shellescape.Quote(unitName)
+	}
+	return c.printOutput(fmt.Sprintf("journalctl -f -n %s %s", shellescape.Quote(tail), strings.Join(args, " ")))
+}
+
+// Run a systemctl command on the given units with root privileges
+func (c *Client) runSystemctl(action string, unitNames []string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	return c.printOutput(fmt.Sprintf("%s systemctl %s %s", rootCmd, action, quoteUnitNames(unitNames)))
+}
+
+// Quote unit names for use in a shell command
+func quoteUnitNames(unitNames []string) string {
+	quoted := make([]string, len(unitNames))
+	for i, unitName := range unitNames {
+		quoted[i] = shellescape.Quote(unitName)
+	}
+	return strings.Join(quoted, " ")
+}
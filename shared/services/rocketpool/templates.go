@@ -0,0 +1,15 @@
+package rocketpool
+
+import (
+	_ "embed"
+)
+
+// The compose template for the caching Beacon proxy, used when the installed templates predate it
+//
+//go:embed templates/bn-proxy.tmpl
+var bnProxyTemplate string
+
+// The empty override for the caching Beacon proxy, created when the installer didn't provide one
+//
+//go:embed templates/override/bn-proxy.yml
+var bnProxyOverride string
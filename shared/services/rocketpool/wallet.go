@@ -3,9 +3,11 @@ package rocketpool
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
 // Get wallet status
@@ -26,7 +28,7 @@ func (c *Client) WalletStatus() (api.WalletStatusResponse, error) {
 
 // Set wallet password
 func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
-	responseBytes, err := c.callAPI("wallet set-password", password)
+	responseBytes, err := c.callSensitiveAPI([]string{password}, "wallet set-password", apiutils.StdinSecretArg)
 	if err != nil {
 		return api.SetPasswordResponse{}, fmt.Errorf("Could not set wallet password: %w", err)
 	}
@@ -41,8 +43,14 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 }
 
 // Initialize wallet
-func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
+func (c *Client) InitWallet(derivationPath string, walletIndex uint) (api.InitWalletResponse, error) {
+	command := "wallet init "
+	if walletIndex != 0 {
+		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
+	}
+	command += "--derivation-path"
+
+	responseBytes, err := c.callSensitiveAPI(nil, command, derivationPath)
 	if err != nil {
 		return api.InitWalletResponse{}, fmt.Errorf("Could not initialize wallet: %w", err)
 	}
@@ -67,7 +75,7 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	}
 	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, command, derivationPath, apiutils.StdinSecretArg)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover wallet: %w", err)
 	}
@@ -81,6 +89,43 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	return response, nil
 }
 
+// Restore wallet from the wallet file in a backup
+func (c *Client) RestoreWalletBackup(walletJson string, skipValidatorKeyRecovery bool) (api.RecoverWalletResponse, error) {
+	command := "wallet restore-backup"
+	if skipValidatorKeyRecovery {
+		command += " --skip-validator-key-recovery"
+	}
+	responseBytes, err := c.callAPI(command, walletJson)
+	if err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not restore wallet backup: %w", err)
+	}
+	var response api.RecoverWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not decode restore wallet backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not restore wallet backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Search a mnemonic's derivation paths and indices for accounts with on-chain history
+func (c *Client) SearchWallet(mnemonic string, derivationPath string, maxIndex uint) (api.SearchWalletResponse, error) {
+	command := fmt.Sprintf("wallet search --max-index %d --derivation-path", maxIndex)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, command, derivationPath, apiutils.StdinSecretArg)
+	if err != nil {
+		return api.SearchWalletResponse{}, fmt.Errorf("Could not search wallet: %w", err)
+	}
+	var response api.SearchWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SearchWalletResponse{}, fmt.Errorf("Could not decode search wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SearchWalletResponse{}, fmt.Errorf("Could not search wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Search and recover wallet
 func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
@@ -88,7 +133,7 @@ func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address,
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, command, apiutils.StdinSecretArg, address.Hex())
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not search and recover wallet: %w", err)
 	}
@@ -113,7 +158,7 @@ func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery boo
 	}
 	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, command, derivationPath, apiutils.StdinSecretArg)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not test recover wallet: %w", err)
 	}
@@ -134,7 +179,7 @@ func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Addr
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callSensitiveAPI([]string{mnemonic}, command, apiutils.StdinSecretArg, address.Hex())
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not test search and recover wallet: %w", err)
 	}
@@ -164,6 +209,22 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Check if any of the node's validators are attesting from another machine
+func (c *Client) CheckDoppelgangers() (api.CheckDoppelgangersResponse, error) {
+	responseBytes, err := c.callAPI("wallet check-doppelgangers")
+	if err != nil {
+		return api.CheckDoppelgangersResponse{}, fmt.Errorf("Could not check for doppelgangers: %w", err)
+	}
+	var response api.CheckDoppelgangersResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckDoppelgangersResponse{}, fmt.Errorf("Could not decode check doppelgangers response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckDoppelgangersResponse{}, fmt.Errorf("Could not check for doppelgangers: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -198,7 +259,7 @@ func (c *Client) SetEnsName(name string) (api.SetEnsNameResponse, error) {
 
 // Export wallet
 func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet export")
+	responseBytes, err := c.callSensitiveAPI(nil, "wallet export")
 	if err != nil {
 		return api.ExportWalletResponse{}, fmt.Errorf("Could not export wallet: %w", err)
 	}
@@ -211,3 +272,108 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Sign a test transaction without broadcasting it
+func (c *Client) TestTransaction() (api.TestTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet test-transaction")
+	if err != nil {
+		return api.TestTransactionResponse{}, fmt.Errorf("Could not sign test transaction: %w", err)
+	}
+	var response api.TestTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TestTransactionResponse{}, fmt.Errorf("Could not decode test transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TestTransactionResponse{}, fmt.Errorf("Could not sign test transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Put the wallet into a read-only mode that reports another node's address as the node account
+func (c *Client) Masquerade(address common.Address) (api.MasqueradeResponse, error) {
+	responseBytes, err := c.callAPI("wallet masquerade", address.Hex())
+	if err != nil {
+		return api.MasqueradeResponse{}, fmt.Errorf("Could not masquerade: %w", err)
+	}
+	var response api.MasqueradeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MasqueradeResponse{}, fmt.Errorf("Could not decode masquerade response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MasqueradeResponse{}, fmt.Errorf("Could not masquerade: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Stop masquerading and go back to the node wallet's own account
+func (c *Client) EndMasquerade() (api.EndMasqueradeResponse, error) {
+	responseBytes, err := c.callAPI("wallet end-masquerade")
+	if err != nil {
+		return api.EndMasqueradeResponse{}, fmt.Errorf("Could not end masquerade: %w", err)
+	}
+	var response api.EndMasqueradeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.EndMasqueradeResponse{}, fmt.Errorf("Could not decode end masquerade response: %w", err)
+	}
+	if response.Error != "" {
+		return api.EndMasqueradeResponse{}, fmt.Errorf("Could not end masquerade: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the address and balance of the wallet's transactor key
+func (c *Client) TransactorStatus() (api.TransactorStatusResponse, error) {
+	responseBytes, err := c.callAPI("wallet transactor-status")
+	if err != nil {
+		return api.TransactorStatusResponse{}, fmt.Errorf("Could not get transactor status: %w", err)
+	}
+	var response api.TransactorStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TransactorStatusResponse{}, fmt.Errorf("Could not decode transactor status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TransactorStatusResponse{}, fmt.Errorf("Could not get transactor status: %s", response.Error)
+	}
+	if response.Balance == nil {
+		response.Balance = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Create a new transactor key, moving the balance of the current one to it
+func (c *Client) CreateTransactor() (api.CreateTransactorResponse, error) {
+	responseBytes, err := c.callAPI("wallet create-transactor")
+	if err != nil {
+		return api.CreateTransactorResponse{}, fmt.Errorf("Could not create transactor key: %w", err)
+	}
+	var response api.CreateTransactorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateTransactorResponse{}, fmt.Errorf("Could not decode create transactor response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateTransactorResponse{}, fmt.Errorf("Could not create transactor key: %s", response.Error)
+	}
+	if response.SweptAmount == nil {
+		response.SweptAmount = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Retire the transactor key and send its balance back to the node account
+func (c *Client) RemoveTransactor() (api.RemoveTransactorResponse, error) {
+	responseBytes, err := c.callAPI("wallet remove-transactor")
+	if err != nil {
+		return api.RemoveTransactorResponse{}, fmt.Errorf("Could not remove transactor key: %w", err)
+	}
+	var response api.RemoveTransactorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RemoveTransactorResponse{}, fmt.Errorf("Could not decode remove transactor response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RemoveTransactorResponse{}, fmt.Errorf("Could not remove transactor key: %s", response.Error)
+	}
+	if response.SweptAmount == nil {
+		response.SweptAmount = big.NewInt(0)
+	}
+	return response, nil
+}
@@ -4,25 +4,40 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -40,6 +55,7 @@ var (
 	passwordManager    *passwords.PasswordManager
 	nodeWallet         *wallet.Wallet
 	ecManager          *ExecutionClientManager
+	archiveEcManager   *ArchiveClientManager
 	bcManager          *BeaconClientManager
 	rocketPool         *rocketpool.RocketPool
 	oneInchOracle      *contracts.OneInchOracle
@@ -47,11 +63,16 @@ var (
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
 	docker             *client.Client
+	eventJournal       *journal.Journal
+	balanceHistory     *balancehistory.Store
+	dvtManager         *dvt.Manager
 
 	initCfg                sync.Once
+	cfgLock                sync.RWMutex
 	initPasswordManager    sync.Once
 	initNodeWallet         sync.Once
 	initECManager          sync.Once
+	initArchiveECManager   sync.Once
 	initBCManager          sync.Once
 	initRocketPool         sync.Once
 	initOneInchOracle      sync.Once
@@ -59,6 +80,9 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initEventJournal       sync.Once
+	initBalanceHistory     sync.Once
+	initDvtManager         sync.Once
 )
 
 //
@@ -86,6 +110,16 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+// Get the keystores that keep validator keys on disk, even if the wallet is using Web3Signer instead
+func GetLocalKeystores(c *cli.Context) (map[string]keystore.Keystore, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm := getPasswordManager(cfg)
+	return getLocalKeystores(cfg, pm), nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -98,23 +132,37 @@ func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	return ec, nil
 }
 
+// Get the load-balanced client for the archive ECs; returns nil if no archive EC is configured
+func GetArchiveEthClient(cfg *config.RocketPoolConfig) (*ArchiveClientManager, error) {
+	return getArchiveEthClient(cfg)
+}
+
 func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
 		return nil, err
 	}
 	var ec rocketpool.ExecutionClient
-	if c.GlobalBool("use-protected-api") {
+	var ecManager *ExecutionClientManager
+	if c.GlobalBool("use-protected-api") && !c.GlobalBool("simulate") {
 		url := cfg.Smartnode.GetFlashbotsProtectUrl()
-		ec, err = ethclient.Dial(url)
+		ec, err = dialEthClient(url)
 	} else {
-		ec, err = getEthClient(c, cfg)
+		ecManager, err = getEthClient(c, cfg)
+		ec = ecManager
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return getRocketPool(cfg, ec)
+	rp, err := getRocketPool(cfg, ec)
+	if err != nil {
+		return nil, err
+	}
+	if ecManager != nil && ecManager.simulator != nil {
+		ecManager.simulator.SetRocketPool(rp)
+	}
+	return rp, nil
 }
 
 func GetOneInchOracle(c *cli.Context) (*contracts.OneInchOracle, error) {
@@ -165,6 +213,40 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetEventJournal(c *cli.Context) (*journal.Journal, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getEventJournal(cfg), nil
+}
+
+// Record an event in the node's journal.
+// The journal is only an audit trail, so failing to write it shouldn't fail the action that caused the event.
+func RecordEvent(c *cli.Context, event journal.Event) {
+	j, err := GetEventJournal(c)
+	if err != nil {
+		return
+	}
+	_ = j.Record(event)
+}
+
+func GetBalanceHistory(c *cli.Context) (*balancehistory.Store, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getBalanceHistory(cfg), nil
+}
+
+func GetDvtManager(c *cli.Context) (*dvt.Manager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getDvtManager(cfg)
+}
+
 //
 // Service instance getters
 //
@@ -177,10 +259,55 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 		if cfg == nil && err == nil {
 			err = fmt.Errorf("Settings file [%s] not found.", settingsFile)
 		}
+		if err == nil {
+			err = configureHttpClients(cfg)
+		}
+		if err == nil {
+			configureValidatorStatusBatchers(cfg)
+		}
 	})
+	cfgLock.RLock()
+	defer cfgLock.RUnlock()
 	return cfg, err
 }
 
+// Replace the config the services hand out, such as after the node daemon reloads its settings.
+// Anything still holding the previous config keeps reading it unchanged, so a config is never modified while it's in use.
+func SetConfig(newCfg *config.RocketPoolConfig) {
+	cfgLock.Lock()
+	defer cfgLock.Unlock()
+	cfg = newCfg
+	configureValidatorStatusBatchers(newCfg)
+}
+
+// Route the outbound HTTP clients through the proxy and IP settings in the config
+func configureHttpClients(cfg *config.RocketPoolConfig) error {
+	settings, err := cfg.Smartnode.GetHttpClientSettings()
+	if err != nil {
+		return fmt.Errorf("error getting HTTP proxy settings: %w", err)
+	}
+	return net.ConfigureHttpClients(settings)
+}
+
+// Apply the validator status batch size and concurrency from the config to the shared batchers
+func configureValidatorStatusBatchers(cfg *config.RocketPoolConfig) {
+	batchSize := cfg.Smartnode.ValidatorStatusBatchSize.Value.(uint64)
+	concurrency := cfg.Smartnode.ValidatorStatusConcurrency.Value.(uint64)
+	eth2.ConfigureValidatorStatusBatchers(int(batchSize), int(concurrency))
+}
+
+// Connect to an Execution client, sending HTTP requests through the outbound HTTP client for ECs
+func dialEthClient(url string) (*ethclient.Client, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return ethclient.Dial(url)
+	}
+	client, err := rpc.DialHTTPWithClient(url, net.GetHttpClient(net.HttpTarget_ExecutionClient))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
+
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
 		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
@@ -188,6 +315,28 @@ func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager
 	return passwordManager
 }
 
+func getEventJournal(cfg *config.RocketPoolConfig) *journal.Journal {
+	initEventJournal.Do(func() {
+		eventJournal = journal.NewJournal(os.ExpandEnv(cfg.Smartnode.GetEventJournalPath()))
+	})
+	return eventJournal
+}
+
+func getBalanceHistory(cfg *config.RocketPoolConfig) *balancehistory.Store {
+	initBalanceHistory.Do(func() {
+		balanceHistory = balancehistory.NewStore(os.ExpandEnv(cfg.Smartnode.GetBalanceHistoryPath()))
+	})
+	return balanceHistory
+}
+
+func getDvtManager(cfg *config.RocketPoolConfig) (*dvt.Manager, error) {
+	var err error
+	initDvtManager.Do(func() {
+		dvtManager, err = dvt.NewManager(os.ExpandEnv(cfg.Smartnode.GetDvtPath()))
+	})
+	return dvtManager, err
+}
+
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
@@ -215,22 +364,64 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		if err != nil {
 			return
 		}
+		err = nodeWallet.LoadMasquerade(os.ExpandEnv(cfg.Smartnode.GetMasqueradeAddressPath()))
+		if err != nil {
+			return
+		}
+		err = nodeWallet.LoadTransactorKey(os.ExpandEnv(cfg.Smartnode.GetTransactorKeyPath()))
+		if err != nil {
+			return
+		}
+		if c.GlobalBool("simulate") {
+			nodeWallet.EnableSimulation()
+		}
 
-		// Keystores
-		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		lodestarKeystore := lokeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		tekuKeystore := tkkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nodeWallet.AddKeystore("lighthouse", lighthouseKeystore)
-		nodeWallet.AddKeystore("lodestar", lodestarKeystore)
-		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
-		nodeWallet.AddKeystore("prysm", prysmKeystore)
-		nodeWallet.AddKeystore("teku", tekuKeystore)
+		// Keys that have been split into a DVT cluster are run by the cluster, so they're never put back into the Validator Client
+		var manager *dvt.Manager
+		manager, err = getDvtManager(cfg)
+		if err != nil {
+			return
+		}
+		nodeWallet.SetExternalValidatorCheck(func(pubkey rptypes.ValidatorPubkey) bool {
+			_, exists := manager.GetCluster(pubkey)
+			return exists
+		})
+
+		// Keystores; with remote signing, the keys are only pushed to Web3Signer so the Validator client can't load them locally
+		if cfg.Smartnode.UseWeb3Signer.Value == true {
+			var vc *keymanager.Client
+			if consensusClient, _ := cfg.GetSelectedConsensusClient(); consensusClient == cfgtypes.ConsensusClient_Lighthouse {
+				// Lighthouse can't be pointed at the signer with flags, so each key is registered with it as a remote key
+				vc = keymanager.NewClient(cfg.Smartnode.KeymanagerApiUrl.Value.(string), cfg.Smartnode.GetKeymanagerApiTokenPath())
+			}
+			var signerUrl string
+			signerUrl, err = cfg.Smartnode.GetWeb3SignerUrl()
+			if err != nil {
+				return
+			}
+			signer := web3signer.NewClient(signerUrl)
+			nodeWallet.AddKeystore("web3signer", w3skeystore.NewKeystore(signer, vc, os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPath())))
+			return
+		}
+		for name, ks := range getLocalKeystores(cfg, pm) {
+			nodeWallet.AddKeystore(name, ks)
+		}
 	})
 	return nodeWallet, err
 }
 
+// Get the keystores that keep validator keys on disk for each Validator client, by client name
+func getLocalKeystores(cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) map[string]keystore.Keystore {
+	keychainPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	return map[string]keystore.Keystore{
+		"lighthouse": lhkeystore.NewKeystore(keychainPath, pm),
+		"lodestar":   lokeystore.NewKeystore(keychainPath, pm),
+		"nimbus":     nmkeystore.NewKeystore(keychainPath, pm),
+		"prysm":      prkeystore.NewKeystore(keychainPath, pm),
+		"teku":       tkkeystore.NewKeystore(keychainPath, pm),
+	}
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initECManager.Do(func() {
@@ -244,15 +435,39 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 			if c.GlobalBool("force-fallbacks") {
 				ecManager.primaryReady = false
 			}
+
+			// Simulate transactions as the node account instead of sending them if requested
+			if c.GlobalBool("simulate") {
+				ecManager.simulator = simulation.NewSimulator(ecManager, func() (common.Address, error) {
+					w, err := getWallet(c, cfg, getPasswordManager(cfg))
+					if err != nil {
+						return common.Address{}, err
+					}
+					account, err := w.GetNodeAccount()
+					return account.Address, err
+				})
+			}
 		}
 	})
 	return ecManager, err
 }
 
+func getArchiveEthClient(cfg *config.RocketPoolConfig) (*ArchiveClientManager, error) {
+	var err error
+	initArchiveECManager.Do(func() {
+		urls := cfg.Smartnode.GetArchiveEcUrls()
+		if len(urls) > 0 {
+			archiveEcManager, err = NewArchiveClientManager(urls)
+		}
+	})
+	return archiveEcManager, err
+}
+
 func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*rocketpool.RocketPool, error) {
 	var err error
 	initRocketPool.Do(func() {
-		rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		// The binding's getLogs queries go through logscan so the event scans in rocketpool-go adapt to the provider's limits too
+		rocketPool, err = rocketpool.NewRocketPool(logscan.NewClient(client), common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
 	})
 	return rocketPool, err
 }
@@ -0,0 +1,236 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The network contracts transactions are decoded against; minipools and fee distributors are matched by their
+// delegate's ABI since they don't have their own entry in RocketStorage
+var contractNames = []string{
+	"rocketAuctionManager",
+	"rocketClaimDAO",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAOProtocolProposals",
+	"rocketDAOProposal",
+	"rocketDepositPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketNetworkBalances",
+	"rocketNetworkPrices",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+	"rocketMinipoolDelegate",
+	"rocketNodeDistributorDelegate",
+}
+
+// Every transaction simulated by this process, in the order they were submitted
+var (
+	simulations     = []api.TransactionSimulation{}
+	simulationsLock sync.Mutex
+)
+
+// Runs transactions through eth_call and eth_estimateGas instead of broadcasting them, recording what would have happened
+type Simulator struct {
+	ec        rocketpool.ExecutionClient
+	rp        *rocketpool.RocketPool
+	getSender func() (common.Address, error)
+}
+
+// Create a simulator that reads from the provided client. Unsigned transactions are simulated as coming from
+// the address returned by getSender.
+func NewSimulator(ec rocketpool.ExecutionClient, getSender func() (common.Address, error)) *Simulator {
+	return &Simulator{
+		ec:        ec,
+		getSender: getSender,
+	}
+}
+
+// Set the Rocket Pool binding used to decode calls to the network contracts
+func (s *Simulator) SetRocketPool(rp *rocketpool.RocketPool) {
+	s.rp = rp
+}
+
+// Simulate a transaction in place of sending it. Returns an error if it would revert.
+func (s *Simulator) Simulate(ctx context.Context, tx *types.Transaction) error {
+
+	// Work out who the transaction is from; it's only signed if the caller didn't use the simulating transactor
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		from, err = s.getSender()
+		if err != nil {
+			return fmt.Errorf("error getting the sender of the simulated transaction: %w", err)
+		}
+	}
+
+	simulation := api.TransactionSimulation{
+		Hash:           tx.Hash(),
+		From:           from,
+		To:             tx.To(),
+		Arguments:      []string{},
+		Value:          tx.Value(),
+		Nonce:          tx.Nonce(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		Result:         []string{},
+	}
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+
+	// Decode the call if it's to a known contract
+	method := s.decodeCall(&simulation, tx.Data())
+
+	// Estimate the gas without the limit, then run the call with it so it fails the same way the real one would
+	simulation.EstimatedGas, err = s.ec.EstimateGas(ctx, msg)
+	if err != nil {
+		simulation.Error = err.Error()
+		recordSimulation(simulation)
+		return fmt.Errorf("simulated transaction would fail: %w", err)
+	}
+	msg.Gas = tx.Gas()
+	result, err := s.ec.CallContract(ctx, msg, nil)
+	if err != nil {
+		simulation.Error = err.Error()
+		recordSimulation(simulation)
+		return fmt.Errorf("simulated transaction would fail: %w", err)
+	}
+	simulation.Result = decodeResult(method, result)
+	recordSimulation(simulation)
+	return nil
+
+}
+
+// Get the transactions simulated so far
+func GetSimulations() []api.TransactionSimulation {
+	simulationsLock.Lock()
+	defer simulationsLock.Unlock()
+	return append([]api.TransactionSimulation{}, simulations...)
+}
+
+// Add a simulated transaction to the record
+func recordSimulation(simulation api.TransactionSimulation) {
+	simulationsLock.Lock()
+	defer simulationsLock.Unlock()
+	simulations = append(simulations, simulation)
+}
+
+// Fill in the contract, method and arguments of a simulation from its calldata, returning the method if it was found
+func (s *Simulator) decodeCall(simulation *api.TransactionSimulation, data []byte) *abi.Method {
+	if len(data) == 0 {
+		simulation.Method = "transfer"
+		return nil
+	}
+	if len(data) < 4 || s.rp == nil {
+		return nil
+	}
+
+	contractName, method := s.findMethod(simulation.To, data[:4])
+	simulation.Contract = contractName
+	if method == nil {
+		simulation.Method = hexutil.Encode(data[:4])
+		return nil
+	}
+	simulation.Method = method.Sig
+
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		simulation.Arguments = append(simulation.Arguments, fmt.Sprintf("<error decoding arguments: %s>", err.Error()))
+		return method
+	}
+	for i, arg := range args {
+		simulation.Arguments = append(simulation.Arguments, fmt.Sprintf("%s = %s", method.Inputs[i].Name, formatValue(arg)))
+	}
+	return method
+
+}
+
+// Find the contract and method a call is for. Contracts are matched by address first; if the address isn't a
+// network contract, the first contract with a method matching the selector is used.
+func (s *Simulator) findMethod(to *common.Address, selector []byte) (string, *abi.Method) {
+	var fallbackName string
+	var fallbackMethod *abi.Method
+	for _, name := range contractNames {
+		contractAbi, err := s.rp.GetABI(name, nil)
+		if err != nil {
+			continue
+		}
+		method, err := contractAbi.MethodById(selector)
+		if err != nil {
+			continue
+		}
+		if to != nil {
+			address, err := s.rp.GetAddress(name, nil)
+			if err == nil && *address == *to {
+				return name, method
+			}
+		}
+		if fallbackMethod == nil {
+			fallbackName = name
+			fallbackMethod = method
+		}
+	}
+	return fallbackName, fallbackMethod
+}
+
+// Decode the return data of a simulated call, falling back to the raw bytes if the method isn't known
+func decodeResult(method *abi.Method, result []byte) []string {
+	if len(result) == 0 {
+		return []string{}
+	}
+	if method == nil {
+		return []string{hexutil.Encode(result)}
+	}
+	values, err := method.Outputs.Unpack(result)
+	if err != nil {
+		return []string{hexutil.Encode(result)}
+	}
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatValue(value)
+	}
+	return formatted
+}
+
+// Format a decoded ABI value for display
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -184,6 +184,11 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 // Creates a snapshot of the Rocket Pool network, but only for a single node
 // Also gets the total effective RPL stake of the network for convenience since this is required by several node routines
 func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config, nodeAddress common.Address, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
+	return CreateNetworkStateForNodes(cfg, rp, ec, bc, log, slotNumber, beaconConfig, []common.Address{nodeAddress}, calculateTotalEffectiveStake)
+}
+
+// Creates a snapshot of the Rocket Pool network, but only for the provided nodes
+func CreateNetworkStateForNodes(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config, nodeAddresses []common.Address, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
 	steps := 5
 	if calculateTotalEffectiveStake {
 		steps++
@@ -246,17 +251,24 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	state.logLine("1/%d - Retrieved network details (%s so far)", steps, time.Since(start))
 
 	// Node details
-	nodeDetails, err := rpstate.GetNativeNodeDetails(rp, contracts, nodeAddress, isAtlasDeployed)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error getting node details: %w", err)
+	state.NodeDetails = make([]rpstate.NativeNodeDetails, 0, len(nodeAddresses))
+	for _, nodeAddress := range nodeAddresses {
+		nodeDetails, err := rpstate.GetNativeNodeDetails(rp, contracts, nodeAddress, isAtlasDeployed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting details for node %s: %w", nodeAddress.Hex(), err)
+		}
+		state.NodeDetails = append(state.NodeDetails, nodeDetails)
 	}
-	state.NodeDetails = []rpstate.NativeNodeDetails{nodeDetails}
 	state.logLine("2/%d - Retrieved node details (%s so far)", steps, time.Since(start))
 
 	// Minipool details
-	state.MinipoolDetails, err = rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAddress)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error getting all minipool details: %w", err)
+	state.MinipoolDetails = []rpstate.NativeMinipoolDetails{}
+	for _, nodeAddress := range nodeAddresses {
+		minipoolDetails, err := rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAddress)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting minipool details for node %s: %w", nodeAddress.Hex(), err)
+		}
+		state.MinipoolDetails = append(state.MinipoolDetails, minipoolDetails...)
 	}
 	state.logLine("3/%d - Retrieved minipool details (%s so far)", steps, time.Since(start))
 
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A serializable copy of a network state.
+// The lookup maps of the network state are rebuilt from these lists when it's loaded.
+type Snapshot struct {
+	ChainID                uint                            `json:"chainId"`
+	IsAtlasDeployed        bool                            `json:"isAtlasDeployed"`
+	ElBlockNumber          uint64                          `json:"elBlockNumber"`
+	BeaconSlotNumber       uint64                          `json:"beaconSlotNumber"`
+	BeaconConfig           beacon.Eth2Config               `json:"beaconConfig"`
+	NetworkDetails         *rpstate.NetworkDetails         `json:"networkDetails"`
+	NodeDetails            []rpstate.NativeNodeDetails     `json:"nodeDetails"`
+	MinipoolDetails        []rpstate.NativeMinipoolDetails `json:"minipoolDetails"`
+	ValidatorDetails       []beacon.ValidatorStatus        `json:"validatorDetails"`
+	TotalEffectiveRplStake *big.Int                        `json:"totalEffectiveRplStake"`
+}
+
+// Create a snapshot of a network state on the chain with the given ID
+func NewSnapshot(networkState *NetworkState, totalEffectiveRplStake *big.Int, chainID uint) *Snapshot {
+	snapshot := &Snapshot{
+		ChainID:                chainID,
+		IsAtlasDeployed:        networkState.IsAtlasDeployed,
+		ElBlockNumber:          networkState.ElBlockNumber,
+		BeaconSlotNumber:       networkState.BeaconSlotNumber,
+		BeaconConfig:           networkState.BeaconConfig,
+		NetworkDetails:         networkState.NetworkDetails,
+		NodeDetails:            networkState.NodeDetails,
+		MinipoolDetails:        networkState.MinipoolDetails,
+		ValidatorDetails:       make([]beacon.ValidatorStatus, 0, len(networkState.ValidatorDetails)),
+		TotalEffectiveRplStake: totalEffectiveRplStake,
+	}
+	for _, validator := range networkState.ValidatorDetails {
+		snapshot.ValidatorDetails = append(snapshot.ValidatorDetails, validator)
+	}
+	return snapshot
+}
+
+// Load a snapshot from a JSON file
+func LoadSnapshot(path string) (*Snapshot, error) {
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading network state snapshot [%s]: %w", path, err)
+	}
+
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(snapshotBytes, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing network state snapshot [%s]: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Save the snapshot to a JSON file.
+// It's written to a temporary file first and moved into place, so a crash mid-write never leaves a truncated snapshot behind.
+func (s *Snapshot) Save(path string) error {
+	snapshotBytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing network state snapshot: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating network state snapshot folder: %w", err)
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, snapshotBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing network state snapshot [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving network state snapshot into place [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Build a network state from the snapshot, including all of its lookup maps
+func (s *Snapshot) NetworkState() *NetworkState {
+	networkState := &NetworkState{
+		IsAtlasDeployed:          s.IsAtlasDeployed,
+		ElBlockNumber:            s.ElBlockNumber,
+		BeaconSlotNumber:         s.BeaconSlotNumber,
+		BeaconConfig:             s.BeaconConfig,
+		NetworkDetails:           s.NetworkDetails,
+		NodeDetails:              s.NodeDetails,
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetails:          s.MinipoolDetails,
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:         map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+	}
+
+	for i, details := range networkState.NodeDetails {
+		networkState.NodeDetailsByAddress[details.NodeAddress] = &networkState.NodeDetails[i]
+	}
+	for i, details := range networkState.MinipoolDetails {
+		networkState.MinipoolDetailsByAddress[details.MinipoolAddress] = &networkState.MinipoolDetails[i]
+		networkState.MinipoolDetailsByNode[details.NodeAddress] = append(networkState.MinipoolDetailsByNode[details.NodeAddress], &networkState.MinipoolDetails[i])
+	}
+	for _, validator := range s.ValidatorDetails {
+		networkState.ValidatorDetails[validator.Pubkey] = validator
+	}
+	return networkState
+}
@@ -0,0 +1,108 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+)
+
+// Web3Signer keystore.
+// Keys are pushed to the signer instead of being written to disk, so they can't be loaded back from it.
+type Keystore struct {
+	signer                 *web3signer.Client
+	vc                     *keymanager.Client
+	slashingProtectionPath string
+	encryptor              *eth2ks.Encryptor
+}
+
+// EIP-2335 validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  string                 `json:"pubkey"`
+}
+
+// Create new Web3Signer keystore.
+// If the Validator client can't be pointed at the signer with command line flags, provide its keymanager API client
+// so each key can be registered with it as a remote key.
+// The slashing protection history saved at the given path, if there is one, is imported along with each key.
+func NewKeystore(signer *web3signer.Client, vc *keymanager.Client, slashingProtectionPath string) *Keystore {
+	return &Keystore{
+		signer:                 signer,
+		vc:                     vc,
+		slashingProtectionPath: slashingProtectionPath,
+		encryptor:              eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}
+}
+
+// Get the keystore directory; there isn't one, since the keys live in the signer
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a one-time password for the import
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import it into the signer, with the history of anything it signed before it moved there
+	slashingProtection, err := web3signer.LoadSlashingProtection(ks.slashingProtectionPath)
+	if err != nil {
+		return err
+	}
+	if err := ks.signer.ImportKeystore(pubkey, keyStoreBytes, password, slashingProtection); err != nil {
+		return err
+	}
+
+	// Register it with the Validator client if required
+	if ks.vc != nil {
+		if err := ks.vc.ImportRemoteKey(pubkey, ks.signer.GetUrl()); err != nil {
+			return fmt.Errorf("Validator %s was imported into Web3Signer but couldn't be registered with the Validator client: %w", pubkey.Hex(), err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Load a private key; Web3Signer doesn't export keys, so this never finds one
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
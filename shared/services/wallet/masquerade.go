@@ -0,0 +1,72 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned by anything that needs the node key while the wallet is masquerading
+var ErrMasquerading = errors.New("The wallet is masquerading as another node and is read-only. Run 'rocketpool wallet end-masquerade' to use your own node wallet again.")
+
+// Load the address the wallet masquerades as from the provided file, if it exists.
+// While masquerading, the wallet reports that address as the node account and refuses to sign anything.
+func (w *Wallet) LoadMasquerade(path string) error {
+	w.masqueradePath = path
+	w.masqueradeAddress = nil
+
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Could not read masquerade address file: %w", err)
+	}
+
+	addressString := strings.TrimSpace(string(bytes))
+	if !common.IsHexAddress(addressString) {
+		return fmt.Errorf("Masquerade address file %s does not contain a valid address", path)
+	}
+	address := common.HexToAddress(addressString)
+	w.masqueradeAddress = &address
+	return nil
+}
+
+// Check if the wallet is masquerading as another node
+func (w *Wallet) IsMasquerading() bool {
+	return w.masqueradeAddress != nil
+}
+
+// Get the address the wallet is masquerading as, if there is one
+func (w *Wallet) GetMasqueradeAddress() (common.Address, bool) {
+	if w.masqueradeAddress == nil {
+		return common.Address{}, false
+	}
+	return *w.masqueradeAddress, true
+}
+
+// Start masquerading as the provided node address and save it so other processes pick it up
+func (w *Wallet) Masquerade(address common.Address) error {
+	if w.masqueradePath == "" {
+		return errors.New("Masquerading is not supported by this wallet")
+	}
+	if err := os.WriteFile(w.masqueradePath, []byte(address.Hex()), FileMode); err != nil {
+		return fmt.Errorf("Could not write masquerade address file: %w", err)
+	}
+	w.masqueradeAddress = &address
+	return nil
+}
+
+// Stop masquerading and go back to the node wallet's own account
+func (w *Wallet) EndMasquerade() error {
+	if w.masqueradePath != "" {
+		err := os.Remove(w.masqueradePath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Could not remove masquerade address file: %w", err)
+		}
+	}
+	w.masqueradeAddress = nil
+	return nil
+}
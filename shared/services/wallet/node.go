@@ -10,12 +10,21 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Report the masquerade address instead if there is one
+	if w.masqueradeAddress != nil {
+		return accounts.Account{
+			Address: *w.masqueradeAddress,
+		}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -48,6 +57,29 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+	// Transactions are only simulated, so they don't need to be signed; this works while masquerading too
+	if w.simulating {
+		account, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		return &bind.TransactOpts{
+			From: account.Address,
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			GasFeeCap: w.maxFee,
+			GasTipCap: w.maxPriorityFee,
+			GasLimit:  w.gasLimit,
+			Context:   context.Background(),
+		}, nil
+	}
+
+	// Check wallet can sign
+	if w.masqueradeAddress != nil {
+		return nil, ErrMasquerading
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -72,6 +104,11 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
+	// Check wallet can sign
+	if w.masqueradeAddress != nil {
+		return nil, ErrMasquerading
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -91,6 +128,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
+	// Never hand out the key while masquerading, since it doesn't belong to the reported node account
+	if w.masqueradeAddress != nil {
+		return nil, "", ErrMasquerading
+	}
+
 	// Check for cached node key
 	if w.nodeKey != nil {
 		return w.nodeKey, w.nodeKeyPath, nil
@@ -102,8 +144,9 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 		return nil, "", err
 	}
 
-	// Get private key
+	// Get private key, then wipe the derived key since only the private key is kept
 	privateKey, err := derivedKey.ECPrivKey()
+	derivedKey.Zero()
 	if err != nil {
 		return nil, "", fmt.Errorf("Could not get node private key: %w", err)
 	}
@@ -121,6 +164,19 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 // Get the derived key & derivation path for the node account at the index
 func (w *Wallet) getNodeDerivedKey(index uint) (*hdkeychain.ExtendedKey, string, error) {
 
+	// The master key can't be wiped by a reload while it's being used
+	w.keyLock.RLock()
+	defer w.keyLock.RUnlock()
+	if w.mk == nil {
+		return nil, "", errors.New("Wallet is not initialized")
+	}
+	return w.deriveNodeKey(index)
+
+}
+
+// Derive the key & derivation path for the node account at the index; the key lock must be held
+func (w *Wallet) deriveNodeKey(index uint) (*hdkeychain.ExtendedKey, string, error) {
+
 	// Get derivation path
 	if w.ws.DerivationPath == "" {
 		w.ws.DerivationPath = DefaultNodeKeyPath
@@ -138,13 +194,20 @@ func (w *Wallet) getNodeDerivedKey(index uint) (*hdkeychain.ExtendedKey, string,
 	for i, n := range path {
 		// Use the legacy implementation for Goerli
 		// TODO: remove this if Prater ever goes away!
+		var child *hdkeychain.ExtendedKey
 		if w.chainID.Cmp(big.NewInt(5)) == 0 {
-			key, err = key.DeriveNonStandard(n)
+			child, err = key.DeriveNonStandard(n)
 		} else {
-			key, err = key.Derive(n)
+			child, err = key.Derive(n)
+		}
+
+		// Wipe the intermediate keys along the path
+		if key != w.mk {
+			key.Zero()
 		}
+		key = child
 		if err == hdkeychain.ErrInvalidChild {
-			return w.getNodeDerivedKey(index + 1)
+			return w.deriveNodeKey(index + 1)
 		} else if err != nil {
 			return nil, "", fmt.Errorf("Invalid child key at depth %d: %w", i, err)
 		}
@@ -0,0 +1,187 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// The transactor key is a standalone hot key, unrelated to the node mnemonic, that the daemon uses for routine
+// transactions that don't need to come from the node account (such as distributing the fee distributor's balance).
+// It has no special permissions in the Rocket Pool contracts, so losing it only loses the ETH it holds for gas.
+
+// Returned when something asks for the transactor key but none has been created
+var ErrNoTransactorKey = errors.New("No transactor key has been created. Run 'rocketpool wallet transactor create' to create one.")
+
+// Load the transactor key from the provided keystore file, if it exists
+func (w *Wallet) LoadTransactorKey(path string) error {
+	w.transactorKeyPath = path
+	w.transactorKey = nil
+
+	keyJson, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Could not read transactor key file: %w", err)
+	}
+
+	password, err := w.pm.GetPasswordBuffer()
+	if err != nil {
+		return fmt.Errorf("Could not get the password for the transactor key: %w", err)
+	}
+	defer password.Destroy()
+	key, err := keystore.DecryptKey(keyJson, password.UnsafeString())
+	if err != nil {
+		return fmt.Errorf("Could not decrypt transactor key file %s: %w", path, err)
+	}
+	w.transactorKey = key.PrivateKey
+	return nil
+}
+
+// Check if the wallet has a transactor key
+func (w *Wallet) HasTransactorKey() bool {
+	return w.transactorKey != nil
+}
+
+// Get the transactor account
+func (w *Wallet) GetTransactorAccount() (accounts.Account, error) {
+	if w.transactorKey == nil {
+		return accounts.Account{}, ErrNoTransactorKey
+	}
+	return accounts.Account{
+		Address: crypto.PubkeyToAddress(w.transactorKey.PublicKey),
+		URL: accounts.URL{
+			Scheme: "",
+			Path:   w.transactorKeyPath,
+		},
+	}, nil
+}
+
+// Get a transactor for the transactor account
+func (w *Wallet) GetTransactorAccountTransactor() (*bind.TransactOpts, error) {
+	if w.transactorKey == nil {
+		return nil, ErrNoTransactorKey
+	}
+	return w.newTransactor(w.transactorKey)
+}
+
+// Get a transactor for routine transactions that don't need to come from the node account.
+// This uses the transactor key if there is one, and falls back to the node account otherwise.
+// The transactor key doesn't depend on the node wallet, so it works while masquerading, such as when the node
+// mnemonic is kept offline and the daemon only knows the node's address.
+func (w *Wallet) GetRoutineTransactor() (*bind.TransactOpts, error) {
+	if w.transactorKey == nil {
+		return w.GetNodeAccountTransactor()
+	}
+	if w.simulating {
+		account, err := w.GetTransactorAccount()
+		if err != nil {
+			return nil, err
+		}
+		return &bind.TransactOpts{
+			From: account.Address,
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			GasFeeCap: w.maxFee,
+			GasTipCap: w.maxPriorityFee,
+			GasLimit:  w.gasLimit,
+			Context:   context.Background(),
+		}, nil
+	}
+	return w.GetTransactorAccountTransactor()
+}
+
+// Create a new transactor key and save it, retiring the current one if there is one.
+// Returns a transactor for the retired key so its remaining balance can be moved to the new one.
+func (w *Wallet) CreateTransactorKey() (*bind.TransactOpts, error) {
+
+	// Check the key can be saved
+	if w.transactorKeyPath == "" {
+		return nil, errors.New("Transactor keys are not supported by this wallet")
+	}
+	password, err := w.pm.GetPasswordBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the password for the transactor key: %w", err)
+	}
+	defer password.Destroy()
+
+	// Keep the retired key on disk so it's never lost, even if moving its balance fails
+	var retiredOpts *bind.TransactOpts
+	if w.transactorKey != nil {
+		retiredAddress := crypto.PubkeyToAddress(w.transactorKey.PublicKey)
+		retiredPath := fmt.Sprintf("%s.%s.retired", w.transactorKeyPath, retiredAddress.Hex())
+		if err := os.Rename(w.transactorKeyPath, retiredPath); err != nil {
+			return nil, fmt.Errorf("Could not retire the current transactor key: %w", err)
+		}
+		retiredOpts, err = w.newTransactor(w.transactorKey)
+		if err != nil {
+			return nil, err
+		}
+		w.transactorKey = nil
+	}
+
+	// Generate the new key
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("Could not generate transactor key: %w", err)
+	}
+	keyJson, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, password.UnsafeString(), keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encrypt transactor key: %w", err)
+	}
+	if err := os.WriteFile(w.transactorKeyPath, keyJson, FileMode); err != nil {
+		return nil, fmt.Errorf("Could not write transactor key file: %w", err)
+	}
+	w.transactorKey = privateKey
+
+	// Return
+	return retiredOpts, nil
+
+}
+
+// Retire the transactor key so routine transactions go back to the node account.
+// Returns a transactor for the retired key so its remaining balance can be moved elsewhere.
+func (w *Wallet) RemoveTransactorKey() (*bind.TransactOpts, error) {
+	if w.transactorKey == nil {
+		return nil, ErrNoTransactorKey
+	}
+	retiredAddress := crypto.PubkeyToAddress(w.transactorKey.PublicKey)
+	retiredPath := fmt.Sprintf("%s.%s.retired", w.transactorKeyPath, retiredAddress.Hex())
+	if err := os.Rename(w.transactorKeyPath, retiredPath); err != nil {
+		return nil, fmt.Errorf("Could not retire the transactor key: %w", err)
+	}
+	retiredOpts, err := w.newTransactor(w.transactorKey)
+	if err != nil {
+		return nil, err
+	}
+	w.transactorKey = nil
+	return retiredOpts, nil
+}
+
+// Create a transactor for a private key with the wallet's gas settings
+func (w *Wallet) newTransactor(privateKey *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create transactor: %w", err)
+	}
+	transactor.GasFeeCap = w.maxFee
+	transactor.GasTipCap = w.maxPriorityFee
+	transactor.GasLimit = w.gasLimit
+	transactor.Context = context.Background()
+	return transactor, nil
+}
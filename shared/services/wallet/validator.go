@@ -63,7 +63,22 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 	}
 
 	// Load the key from the wallet's keystores
-	return w.LoadValidatorKey(pubkey)
+	key, err := w.LoadValidatorKey(pubkey)
+	if err == nil {
+		return key, nil
+	}
+
+	// Keys that only live in a remote signer have to be re-derived from the wallet's seed
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		derivedKey, _, derivationErr := w.getValidatorPrivateKey(index)
+		if derivationErr != nil {
+			return nil, derivationErr
+		}
+		if bytes.Equal(pubkey.Bytes(), derivedKey.PublicKey().Marshal()) {
+			return derivedKey, nil
+		}
+	}
+	return nil, err
 
 }
 
@@ -96,9 +111,26 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 }
 
+// Set the check for validator keys that are run outside of the Validator Client, such as keys that have been split into a
+// distributed validator cluster. Those keys are never stored in the wallet's keystores, so rebuilding the wallet can't
+// put them back into the Validator Client while something else is also signing with them.
+func (w *Wallet) SetExternalValidatorCheck(isExternal func(pubkey rptypes.ValidatorPubkey) bool) {
+	w.isExternalValidator = isExternal
+}
+
+// Check if a validator's key is run outside of the Validator Client
+func (w *Wallet) IsExternalValidator(pubkey rptypes.ValidatorPubkey) bool {
+	return w.isExternalValidator != nil && w.isExternalValidator(pubkey)
+}
+
 // Stores a validator key into all of the wallet's keystores
 func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) error {
 
+	// Keys that are run elsewhere must stay out of the Validator Client
+	if w.IsExternalValidator(rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())) {
+		return nil
+	}
+
 	for name := range w.keystores {
 		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
 		if err := w.keystores[name].StoreValidatorKey(key, path); err != nil {
@@ -141,6 +173,10 @@ func (w *Wallet) DeleteValidatorStores() error {
 
 	for name := range w.keystores {
 		keystorePath := w.keystores[name].GetKeystoreDir()
+		if keystorePath == "" {
+			// Remote keystores don't have a directory
+			continue
+		}
 		err := os.RemoveAll(keystorePath)
 		if err != nil {
 			return fmt.Errorf("error deleting validator directory for %s: %w", name, err)
@@ -208,6 +244,11 @@ func (w *Wallet) SaveValidatorKey(key ValidatorKey) error {
 		w.ws.NextAccount = key.WalletIndex + 1
 	}
 
+	// Keys that are run elsewhere must stay out of the Validator Client
+	if w.IsExternalValidator(key.PublicKey) {
+		return nil
+	}
+
 	// Update keystores
 	for name := range w.keystores {
 		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
@@ -254,6 +295,11 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey, startIndex
 		w.ws.NextAccount = nextIndex
 	}
 
+	// Keys that are run elsewhere must stay out of the Validator Client
+	if w.IsExternalValidator(pubkey) {
+		return index + startIndex, nil
+	}
+
 	// Update keystores
 	for name := range w.keystores {
 		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
@@ -313,8 +359,14 @@ func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, s
 		return nil, "", fmt.Errorf("Could not initialize BLS library: %w", err)
 	}
 
-	// Get private key
-	privateKey, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+	// Get private key; the seed can't be wiped by a reload while it's being used
+	w.keyLock.RLock()
+	if w.seed == nil {
+		w.keyLock.RUnlock()
+		return nil, "", errors.New("Wallet is not initialized")
+	}
+	privateKey, err := eth2util.PrivateKeyFromSeedAndPath(w.seed.Bytes(), derivationPath)
+	w.keyLock.RUnlock()
 	if err != nil {
 		return nil, "", fmt.Errorf("Could not get validator %d private key: %w", index, err)
 	}
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+const testMnemonic string = "test test test test test test test test test test test junk"
+
+// A keystore that records the keys stored in it
+type recordingKeystore struct {
+	stored map[rptypes.ValidatorPubkey]bool
+}
+
+func (ks *recordingKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	ks.stored[rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())] = true
+	return nil
+}
+
+func (ks *recordingKeystore) LoadValidatorKey(pubkey rptypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+func (ks *recordingKeystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Create a wallet recovered from the test mnemonic, with a keystore that records what's stored in it
+func newTestWallet(t *testing.T) (*Wallet, *recordingKeystore) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatalf("error initializing BLS: %s", err.Error())
+	}
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("test-password"); err != nil {
+		t.Fatalf("error setting password: %s", err.Error())
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatalf("error creating wallet: %s", err.Error())
+	}
+	if err := w.Recover(DefaultNodeKeyPath, 0, testMnemonic); err != nil {
+		t.Fatalf("error recovering wallet: %s", err.Error())
+	}
+	ks := &recordingKeystore{stored: map[rptypes.ValidatorPubkey]bool{}}
+	w.AddKeystore("test", ks)
+	return w, ks
+}
+
+func TestExternalValidatorKeysAreNotStored(t *testing.T) {
+	w, ks := newTestWallet(t)
+	keys, err := w.GetValidatorKeys(0, 3)
+	if err != nil {
+		t.Fatalf("error getting validator keys: %s", err.Error())
+	}
+
+	// The middle key is run by a cluster
+	external := keys[1].PublicKey
+	w.SetExternalValidatorCheck(func(pubkey rptypes.ValidatorPubkey) bool {
+		return pubkey == external
+	})
+
+	// Rebuilding saves every key it finds
+	for _, key := range keys {
+		if err := w.SaveValidatorKey(key); err != nil {
+			t.Fatalf("error saving validator key %d: %s", key.WalletIndex, err.Error())
+		}
+	}
+	if ks.stored[external] {
+		t.Errorf("external validator key %s was stored in the keystore", external.Hex())
+	}
+	for _, key := range []ValidatorKey{keys[0], keys[2]} {
+		if !ks.stored[key.PublicKey] {
+			t.Errorf("validator key %s wasn't stored in the keystore", key.PublicKey.Hex())
+		}
+	}
+
+	// The account index still has to move past it, or the next new minipool would reuse its key
+	count, err := w.GetValidatorKeyCount()
+	if err != nil {
+		t.Fatalf("error getting validator key count: %s", err.Error())
+	}
+	if count != 3 {
+		t.Errorf("expected the next account to be 3, got %d", count)
+	}
+
+	// Custom and imported keys go through StoreValidatorKey instead
+	delete(ks.stored, keys[0].PublicKey)
+	if err := w.StoreValidatorKey(keys[1].PrivateKey, keys[1].DerivationPath); err != nil {
+		t.Fatalf("error storing external validator key: %s", err.Error())
+	}
+	if err := w.StoreValidatorKey(keys[0].PrivateKey, keys[0].DerivationPath); err != nil {
+		t.Fatalf("error storing validator key: %s", err.Error())
+	}
+	if ks.stored[external] {
+		t.Errorf("external validator key %s was stored in the keystore", external.Hex())
+	}
+	if !ks.stored[keys[0].PublicKey] {
+		t.Errorf("validator key %s wasn't stored in the keystore", keys[0].PublicKey.Hex())
+	}
+
+	// Recovering it by its pubkey skips it too
+	if _, err := w.RecoverValidatorKey(external, 0); err != nil {
+		t.Fatalf("error recovering external validator key: %s", err.Error())
+	}
+	if ks.stored[external] {
+		t.Errorf("recovered external validator key %s was stored in the keystore", external.Hex())
+	}
+}
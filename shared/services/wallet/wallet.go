@@ -7,19 +7,24 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sync"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/secret"
 )
 
 // Config
@@ -43,9 +48,11 @@ type Wallet struct {
 	// Encrypted store
 	ws *walletStore
 
-	// Seed & master key
-	seed []byte
-	mk   *hdkeychain.ExtendedKey
+	// Seed & master key; the seed is kept in locked memory, and both are wiped when they're replaced.
+	// The lock keeps them from being replaced while a key is being derived from them.
+	seed    *secret.Buffer
+	mk      *hdkeychain.ExtendedKey
+	keyLock sync.RWMutex
 
 	// Node key cache
 	nodeKey     *ecdsa.PrivateKey
@@ -57,6 +64,20 @@ type Wallet struct {
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// Checks for validator keys that are run outside of the Validator Client, which must never be stored in its keystores
+	isExternalValidator func(pubkey rptypes.ValidatorPubkey) bool
+
+	// Read-only node address override
+	masqueradePath    string
+	masqueradeAddress *common.Address
+
+	// Standalone hot key for routine transactions
+	transactorKeyPath string
+	transactorKey     *ecdsa.PrivateKey
+
+	// Hand out unsigned transactors so transactions can be simulated without the node key
+	simulating bool
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -106,6 +127,16 @@ func (w *Wallet) GetChainID() *big.Int {
 	return copy
 }
 
+// Hand out transactors that leave transactions unsigned, for simulating them instead of sending them
+func (w *Wallet) EnableSimulation() {
+	w.simulating = true
+}
+
+// Check if the wallet is only being used to simulate transactions
+func (w *Wallet) IsSimulating() bool {
+	return w.simulating
+}
+
 // Add a keystore to the wallet
 func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
@@ -113,6 +144,8 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
+	w.keyLock.RLock()
+	defer w.keyLock.RUnlock()
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
 }
 
@@ -156,6 +189,7 @@ func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, er
 	if err != nil {
 		return "", fmt.Errorf("Could not generate wallet mnemonic entropy bytes: %w", err)
 	}
+	defer secret.Wipe(entropy)
 
 	// Generate mnemonic
 	mnemonic, err := bip39.NewMnemonic(entropy)
@@ -183,7 +217,7 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 
 	// Check mnemonic
 	if !bip39.IsMnemonicValid(mnemonic) {
-		return fmt.Errorf("Invalid mnemonic '%s'", mnemonic)
+		return errors.New("Invalid mnemonic")
 	}
 
 	// Initialize wallet store
@@ -196,23 +230,69 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 
 }
 
+// Restore a wallet from a serialized wallet store, such as one from a backup.
+// The store must be encrypted with the node password.
+func (w *Wallet) Restore(walletJson string) error {
+
+	// Check wallet is not initialized
+	if w.IsInitialized() {
+		return errors.New("Wallet is already initialized")
+	}
+
+	// Decode wallet store
+	ws := new(walletStore)
+	if err := json.Unmarshal([]byte(walletJson), ws); err != nil {
+		return fmt.Errorf("Could not decode wallet: %w", err)
+	}
+	if ws.DerivationPath == "" {
+		ws.DerivationPath = DefaultNodeKeyPath
+	}
+
+	// Get wallet password
+	password, err := w.pm.GetPasswordBuffer()
+	if err != nil {
+		return fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	defer password.Destroy()
+
+	// Decrypt seed
+	decryptedSeed, err := w.encryptor.Decrypt(ws.Crypto, password.UnsafeString())
+	if err != nil {
+		return fmt.Errorf("Could not decrypt wallet seed, the node password may not match the wallet's: %w", err)
+	}
+	seed := secret.FromBytes(decryptedSeed)
+
+	// Create master key
+	mk, err := hdkeychain.NewMaster(seed.Bytes(), &chaincfg.MainNetParams)
+	if err != nil {
+		seed.Destroy()
+		return fmt.Errorf("Could not create wallet master key: %w", err)
+	}
+
+	w.ws = ws
+	w.setKeys(seed, mk)
+	return nil
+
+}
+
 // Recover a wallet from a mnemonic - only used for testing mnemonics
 func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string) error {
 
 	// Check mnemonic
 	if !bip39.IsMnemonicValid(mnemonic) {
-		return fmt.Errorf("Invalid mnemonic '%s'", mnemonic)
+		return errors.New("Invalid mnemonic")
 	}
 
 	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	seed := secret.FromBytes(bip39.NewSeed(mnemonic, ""))
 
 	// Create master key
-	var err error
-	w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
+	mk, err := hdkeychain.NewMaster(seed.Bytes(), &chaincfg.MainNetParams)
 	if err != nil {
+		seed.Destroy()
 		return fmt.Errorf("Could not create wallet master key: %w", err)
 	}
+	w.setKeys(seed, mk)
 
 	// Create wallet store
 	w.ws = &walletStore{
@@ -317,6 +397,28 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs EIP-712 typed data with the node's private key, returning the signature and the hash that was signed
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, []byte, error) {
+	// Get the wallet's private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataHash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+	signedData, err := crypto.Sign(dataHash, privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+
+	// Use the same 'v' convention as personal_sign signatures
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, dataHash, nil
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -332,34 +434,41 @@ func (w *Wallet) loadStore() (bool, error) {
 		return false, nil
 	}
 
-	// Decode wallet store
-	w.ws = new(walletStore)
-	if err = json.Unmarshal(wsBytes, w.ws); err != nil {
+	// Decode wallet store; it's only swapped in once it's complete, since a reload can happen while it's being used
+	ws := new(walletStore)
+	if err = json.Unmarshal(wsBytes, ws); err != nil {
 		return false, fmt.Errorf("Could not decode wallet: %w", err)
 	}
 
 	// Upgrade legacy wallets to include derivation paths
-	if w.ws.DerivationPath == "" {
-		w.ws.DerivationPath = DefaultNodeKeyPath
+	if ws.DerivationPath == "" {
+		ws.DerivationPath = DefaultNodeKeyPath
 	}
+	w.ws = ws
 
 	// Get wallet password
-	password, err := w.pm.GetPassword()
+	password, err := w.pm.GetPasswordBuffer()
 	if err != nil {
 		return false, fmt.Errorf("Could not get wallet password: %w", err)
 	}
+	defer password.Destroy()
 
 	// Decrypt seed
-	w.seed, err = w.encryptor.Decrypt(w.ws.Crypto, password)
+	decryptedSeed, err := w.encryptor.Decrypt(ws.Crypto, password.UnsafeString())
 	if err != nil {
+		w.setKeys(nil, nil)
 		return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
 	}
+	seed := secret.FromBytes(decryptedSeed)
 
 	// Create master key
-	w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
+	mk, err := hdkeychain.NewMaster(seed.Bytes(), &chaincfg.MainNetParams)
 	if err != nil {
+		seed.Destroy()
+		w.setKeys(nil, nil)
 		return false, fmt.Errorf("Could not create wallet master key: %w", err)
 	}
+	w.setKeys(seed, mk)
 
 	// Return
 	return true, nil
@@ -370,26 +479,32 @@ func (w *Wallet) loadStore() (bool, error) {
 func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string) error {
 
 	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	seed := secret.FromBytes(bip39.NewSeed(mnemonic, ""))
 
 	// Create master key
-	var err error
-	w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
+	mk, err := hdkeychain.NewMaster(seed.Bytes(), &chaincfg.MainNetParams)
 	if err != nil {
+		seed.Destroy()
 		return fmt.Errorf("Could not create wallet master key: %w", err)
 	}
 
 	// Get wallet password
-	password, err := w.pm.GetPassword()
+	password, err := w.pm.GetPasswordBuffer()
 	if err != nil {
+		seed.Destroy()
+		mk.Zero()
 		return fmt.Errorf("Could not get wallet password: %w", err)
 	}
+	defer password.Destroy()
 
 	// Encrypt seed
-	encryptedSeed, err := w.encryptor.Encrypt(w.seed, password)
+	encryptedSeed, err := w.encryptor.Encrypt(seed.Bytes(), password.UnsafeString())
 	if err != nil {
+		seed.Destroy()
+		mk.Zero()
 		return fmt.Errorf("Could not encrypt wallet seed: %w", err)
 	}
+	w.setKeys(seed, mk)
 
 	// Create wallet store
 	w.ws = &walletStore{
@@ -406,3 +521,17 @@ func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemon
 	return nil
 
 }
+
+// Replace the wallet seed and master key, wiping the old ones once nothing is deriving keys from them
+func (w *Wallet) setKeys(seed *secret.Buffer, mk *hdkeychain.ExtendedKey) {
+	w.keyLock.Lock()
+	defer w.keyLock.Unlock()
+	if w.seed != nil {
+		w.seed.Destroy()
+	}
+	if w.mk != nil {
+		w.mk.Zero()
+	}
+	w.seed = seed
+	w.mk = mk
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReloadWhileDerivingKeys(t *testing.T) {
+	w, _ := newTestWallet(t)
+	if err := w.Save(); err != nil {
+		t.Fatalf("error saving wallet: %s", err.Error())
+	}
+	expectedKey, err := w.GetValidatorKeyAt(0)
+	if err != nil {
+		t.Fatalf("error getting validator key: %s", err.Error())
+	}
+
+	// Keep deriving new keys and the node key while the wallet is reloaded, which replaces and wipes the seed
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for index := uint(1); index < 20; index++ {
+			if _, err := w.GetValidatorKeyAt(index); err != nil {
+				errs <- err
+				return
+			}
+			w.nodeKey = nil
+			if _, err := w.GetNodeAccount(); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			if err := w.Reload(); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("error using the wallet during a reload: %s", err.Error())
+	}
+
+	// The reloaded seed still derives the same keys
+	delete(w.validatorKeys, 0)
+	key, err := w.GetValidatorKeyAt(0)
+	if err != nil {
+		t.Fatalf("error getting validator key after reloading: %s", err.Error())
+	}
+	if string(key.Marshal()) != string(expectedKey.Marshal()) {
+		t.Errorf("validator key changed after reloading the wallet")
+	}
+}
@@ -0,0 +1,147 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// How long to wait for Web3Signer to respond
+const requestTimeout time.Duration = 30 * time.Second
+
+// Web3Signer routes
+const (
+	upcheckPath    string = "/upcheck"
+	publicKeysPath string = "/api/v1/eth2/publicKeys"
+	keystoresPath  string = "/eth/v1/keystores"
+)
+
+// Keystore import statuses
+const (
+	importStatusImported  string = "imported"
+	importStatusDuplicate string = "duplicate"
+)
+
+// A client for a Web3Signer remote signer (https://docs.web3signer.consensys.net/).
+// Keys are imported through its keymanager API, so it must be started with --key-manager-api-enabled.
+type Client struct {
+	url    string
+	client http.Client
+}
+
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type importKeystoresResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// Create a new Web3Signer client
+func NewClient(url string) *Client {
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+		client: http.Client{
+			Timeout: requestTimeout,
+		},
+	}
+}
+
+// Get the URL of the signer
+func (c *Client) GetUrl() string {
+	return c.url
+}
+
+// Check that the signer is up
+func (c *Client) Upcheck() error {
+	if _, err := c.request(http.MethodGet, upcheckPath, nil); err != nil {
+		return fmt.Errorf("Web3Signer at %s is not available: %w", c.url, err)
+	}
+	return nil
+}
+
+// Get the public keys of the validators the signer has keys for
+func (c *Client) GetPublicKeys() (map[types.ValidatorPubkey]bool, error) {
+	responseBody, err := c.request(http.MethodGet, publicKeysPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting public keys from Web3Signer: %w", err)
+	}
+	var response []string
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error decoding public keys from Web3Signer: %w", err)
+	}
+
+	pubkeys := make(map[types.ValidatorPubkey]bool, len(response))
+	for _, pubkeyString := range response {
+		pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(pubkeyString, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("Web3Signer returned an invalid public key [%s]: %w", pubkeyString, err)
+		}
+		pubkeys[pubkey] = true
+	}
+	return pubkeys, nil
+}
+
+// Import an EIP-2335 keystore and its password into the signer, along with an optional EIP-3076 slashing protection
+// interchange that holds the key's signing history. Keys the signer already has are treated as imported.
+func (c *Client) ImportKeystore(pubkey types.ValidatorPubkey, keystore []byte, password string, slashingProtection string) error {
+	requestBody, err := json.Marshal(importKeystoresRequest{
+		Keystores:          []string{string(keystore)},
+		Passwords:          []string{password},
+		SlashingProtection: slashingProtection,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing keystore import: %w", err)
+	}
+	responseBody, err := c.request(http.MethodPost, keystoresPath, requestBody)
+	if err != nil {
+		return fmt.Errorf("error importing key for validator %s into Web3Signer: %w", pubkey.Hex(), err)
+	}
+	var response importKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return fmt.Errorf("error decoding keystore import response for validator %s: %w", pubkey.Hex(), err)
+	}
+	if len(response.Data) != 1 {
+		return fmt.Errorf("Web3Signer returned %d import results for validator %s instead of 1", len(response.Data), pubkey.Hex())
+	}
+	result := response.Data[0]
+	if result.Status != importStatusImported && result.Status != importStatusDuplicate {
+		return fmt.Errorf("Web3Signer could not import the key for validator %s: %s (%s)", pubkey.Hex(), result.Status, result.Message)
+	}
+	return nil
+}
+
+// Send a request to the signer
+func (c *Client) request(method string, path string, body []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("Web3Signer responded with %s: %s", response.Status, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+}
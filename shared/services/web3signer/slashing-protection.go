@@ -0,0 +1,80 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// An EIP-3076 slashing protection interchange.
+// Only the pubkeys are read; each validator's signing history is kept as-is.
+type slashingProtectionInterchange struct {
+	Metadata json.RawMessage            `json:"metadata"`
+	Data     []slashingProtectionRecord `json:"data"`
+}
+type slashingProtectionRecord struct {
+	Pubkey             string          `json:"pubkey"`
+	SignedBlocks       json.RawMessage `json:"signed_blocks"`
+	SignedAttestations json.RawMessage `json:"signed_attestations"`
+}
+
+// Load the slashing protection history saved at the path, or an empty string if none has been saved
+func LoadSlashingProtection(path string) (string, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading slashing protection history [%s]: %w", path, err)
+	}
+	return string(bytes), nil
+}
+
+// Merge an exported slashing protection history into the one saved at the path.
+// The export replaces the saved history of the validators it has, and the rest are kept, so exporting again after the
+// Validator client has already given up some of its keys doesn't lose their history.
+func SaveSlashingProtection(path string, export string) error {
+	if strings.TrimSpace(export) == "" {
+		return nil
+	}
+	var exported slashingProtectionInterchange
+	if err := json.Unmarshal([]byte(export), &exported); err != nil {
+		return fmt.Errorf("error decoding exported slashing protection history: %w", err)
+	}
+
+	saved, err := LoadSlashingProtection(path)
+	if err != nil {
+		return err
+	}
+	merged := exported
+	if saved != "" {
+		var existing slashingProtectionInterchange
+		if err := json.Unmarshal([]byte(saved), &existing); err != nil {
+			return fmt.Errorf("error decoding slashing protection history [%s]: %w", path, err)
+		}
+		exportedPubkeys := map[string]bool{}
+		for _, record := range exported.Data {
+			exportedPubkeys[strings.ToLower(record.Pubkey)] = true
+		}
+		for _, record := range existing.Data {
+			if !exportedPubkeys[strings.ToLower(record.Pubkey)] {
+				merged.Data = append(merged.Data, record)
+			}
+		}
+		if len(merged.Metadata) == 0 {
+			merged.Metadata = existing.Metadata
+		}
+	}
+
+	bytes, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("error serializing slashing protection history: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error saving slashing protection history [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveSlashingProtectionKeepsEarlierExports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slashing-protection.json")
+
+	// The first export has both validators
+	first := `{"metadata":{"interchange_format_version":"5"},"data":[` +
+		`{"pubkey":"0xaa","signed_blocks":[{"slot":"10"}],"signed_attestations":[]},` +
+		`{"pubkey":"0xbb","signed_blocks":[{"slot":"11"}],"signed_attestations":[]}]}`
+	if err := SaveSlashingProtection(path, first); err != nil {
+		t.Fatalf("error saving first export: %s", err.Error())
+	}
+
+	// The Validator client has already given up 0xbb, so the second export only has 0xaa
+	second := `{"metadata":{"interchange_format_version":"5"},"data":[` +
+		`{"pubkey":"0xAA","signed_blocks":[{"slot":"20"}],"signed_attestations":[]}]}`
+	if err := SaveSlashingProtection(path, second); err != nil {
+		t.Fatalf("error saving second export: %s", err.Error())
+	}
+	if err := SaveSlashingProtection(path, ""); err != nil {
+		t.Fatalf("error saving empty export: %s", err.Error())
+	}
+
+	saved, err := LoadSlashingProtection(path)
+	if err != nil {
+		t.Fatalf("error loading history: %s", err.Error())
+	}
+	var interchange slashingProtectionInterchange
+	if err := json.Unmarshal([]byte(saved), &interchange); err != nil {
+		t.Fatalf("error decoding history: %s", err.Error())
+	}
+	blocks := map[string]string{}
+	for _, record := range interchange.Data {
+		blocks[record.Pubkey] = string(record.SignedBlocks)
+	}
+	expected := map[string]string{
+		"0xAA": `[{"slot":"20"}]`,
+		"0xbb": `[{"slot":"11"}]`,
+	}
+	if len(blocks) != len(expected) {
+		t.Fatalf("expected %d validators in the history, got %d: %s", len(expected), len(blocks), saved)
+	}
+	for pubkey, expectedBlocks := range expected {
+		if blocks[pubkey] != expectedBlocks {
+			t.Errorf("expected blocks %s for %s, got %s", expectedBlocks, pubkey, blocks[pubkey])
+		}
+	}
+}
+
+func TestLoadSlashingProtectionWithoutHistory(t *testing.T) {
+	saved, err := LoadSlashingProtection(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("error loading missing history: %s", err.Error())
+	}
+	if saved != "" {
+		t.Errorf("expected no history, got %s", saved)
+	}
+}
@@ -1,6 +1,35 @@
 package api
 
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// The outcome of running a transaction through eth_call and eth_estimateGas instead of broadcasting it
+type TransactionSimulation struct {
+	Hash           common.Hash     `json:"hash"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	Contract       string          `json:"contract"`
+	Method         string          `json:"method"`
+	Arguments      []string        `json:"arguments"`
+	Value          *big.Int        `json:"value"`
+	Nonce          uint64          `json:"nonce"`
+	GasLimit       uint64          `json:"gasLimit"`
+	EstimatedGas   uint64          `json:"estimatedGas"`
+	MaxFee         *big.Int        `json:"maxFee"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFee"`
+	Result         []string        `json:"result"`
+	Error          string          `json:"error"`
+}
+
+// The transactions an API call simulated while running with --simulate
+type SimulationResponse struct {
+	Simulations []TransactionSimulation `json:"simulations"`
+}
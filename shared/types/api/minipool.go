@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 type MinipoolStatusResponse struct {
@@ -45,6 +46,8 @@ type MinipoolDetails struct {
 	Penalties             uint64                 `json:"penalties"`
 	ReduceBondTime        time.Time              `json:"reduceBondTime"`
 	ReduceBondCancelled   bool                   `json:"reduceBondCancelled"`
+	Label                 string                 `json:"label,omitempty"`
+	Tags                  []string               `json:"tags,omitempty"`
 }
 type ValidatorDetails struct {
 	Exists      bool     `json:"exists"`
@@ -154,6 +157,7 @@ type MinipoolCloseDetails struct {
 	Refund             *big.Int              `json:"refund"`
 	UserDepositBalance *big.Int              `json:"userDepositBalance"`
 	BeaconState        beacon.ValidatorState `json:"beaconState"`
+	ExitFinalized      bool                  `json:"exitFinalized"`
 	NodeShare          *big.Int              `json:"nodeShare"`
 	GasInfo            rocketpool.GasInfo    `json:"gasInfo"`
 }
@@ -331,3 +335,151 @@ type ReduceBondAmountResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type BondReductionPhase string
+
+const (
+	BondReductionPhase_Eligible   BondReductionPhase = "eligible"
+	BondReductionPhase_Ineligible BondReductionPhase = "ineligible"
+	BondReductionPhase_Waiting    BondReductionPhase = "waiting"
+	BondReductionPhase_Ready      BondReductionPhase = "ready"
+	BondReductionPhase_Scrubbed   BondReductionPhase = "scrubbed"
+)
+
+type MinipoolBondReductionDetails struct {
+	Address          common.Address        `json:"address"`
+	Phase            BondReductionPhase    `json:"phase"`
+	IneligibleReason string                `json:"ineligibleReason"`
+	NodeFee          float64               `json:"nodeFee"`
+	NewNodeFee       float64               `json:"newNodeFee"`
+	Balance          uint64                `json:"balance"`
+	BeaconState      beacon.ValidatorState `json:"beaconState"`
+	ReduceBondTime   time.Time             `json:"reduceBondTime"`
+	WindowStart      time.Time             `json:"windowStart"`
+	WindowEnd        time.Time             `json:"windowEnd"`
+}
+type GetBondReductionsResponse struct {
+	Status                      string                         `json:"status"`
+	Error                       string                         `json:"error"`
+	IsAtlasDeployed             bool                           `json:"isAtlasDeployed"`
+	BondReductionEnabled        bool                           `json:"bondReductionEnabled"`
+	IsFeeDistributorInitialized bool                           `json:"isFeeDistributorInitialized"`
+	AutoCompleteEnabled         bool                           `json:"autoCompleteEnabled"`
+	WindowStart                 time.Duration                  `json:"windowStart"`
+	WindowLength                time.Duration                  `json:"windowLength"`
+	RplStake                    *big.Int                       `json:"rplStake"`
+	MinimumRplStake             *big.Int                       `json:"minimumRplStake"`
+	RplPerReduction             *big.Int                       `json:"rplPerReduction"`
+	PendingReductions           uint64                         `json:"pendingReductions"`
+	Minipools                   []MinipoolBondReductionDetails `json:"minipools"`
+}
+
+type MigratePlanAction string
+
+const (
+	MigratePlanAction_CompleteReduction MigratePlanAction = "complete-reduction"
+	MigratePlanAction_WaitForReduction  MigratePlanAction = "wait-for-reduction"
+	MigratePlanAction_StakeRpl          MigratePlanAction = "stake-rpl"
+	MigratePlanAction_BeginReduction    MigratePlanAction = "begin-reduction"
+)
+
+// One step of a plan to migrate the node's 16 ETH minipools to 8 ETH bonds
+type MigratePlanStep struct {
+	Action     MigratePlanAction `json:"action"`
+	Minipool   common.Address    `json:"minipool"`
+	RplAmount  *big.Int          `json:"rplAmount"`
+	NodeFee    float64           `json:"nodeFee"`
+	NewNodeFee float64           `json:"newNodeFee"`
+	WindowEnd  time.Time         `json:"windowEnd"`
+}
+type MinipoolMigratePlanResponse struct {
+	Status                   string                         `json:"status"`
+	Error                    string                         `json:"error"`
+	BondReductions           GetBondReductionsResponse      `json:"bondReductions"`
+	RplBalance               *big.Int                       `json:"rplBalance"`
+	CreditBalance            *big.Int                       `json:"creditBalance"`
+	MaximumRplStake          *big.Int                       `json:"maximumRplStake"`
+	Steps                    []MigratePlanStep              `json:"steps"`
+	RplToStake               *big.Int                       `json:"rplToStake"`
+	RplShortfall             *big.Int                       `json:"rplShortfall"`
+	BlockedMinipools         []MinipoolBondReductionDetails `json:"blockedMinipools"`
+	ProjectedCreditBalance   *big.Int                       `json:"projectedCreditBalance"`
+	ProjectedMaximumRplStake *big.Int                       `json:"projectedMaximumRplStake"`
+	ProjectedWithdrawableRpl *big.Int                       `json:"projectedWithdrawableRpl"`
+}
+
+type MinipoolDepositDataResponse struct {
+	Status      string               `json:"status"`
+	Error       string               `json:"error"`
+	DepositData LaunchpadDepositData `json:"depositData"`
+}
+
+// Deposit data in the format produced by the staking deposit CLI and used by the launchpad
+type LaunchpadDepositData struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
+
+type MinipoolExitAdviceResponse struct {
+	Status string              `json:"status"`
+	Error  string              `json:"error"`
+	Report rp.ExitAdviceReport `json:"report"`
+}
+
+type MinipoolPerformanceResponse struct {
+	Status string                       `json:"status"`
+	Error  string                       `json:"error"`
+	Report rp.MinipoolPerformanceReport `json:"report"`
+}
+
+type MinipoolProjectedIncomeResponse struct {
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error"`
+	StakingApr float64                   `json:"stakingApr"`
+	Minipools  []MinipoolProjectedIncome `json:"minipools"`
+}
+type MinipoolProjectedIncome struct {
+	Address               common.Address `json:"address"`
+	NodeFee               float64        `json:"nodeFee"`
+	DepositSize           float64        `json:"depositSize"`
+	ProjectedAnnualIncome float64        `json:"projectedAnnualIncome"`
+}
+
+type MinipoolLabelsResponse struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error"`
+	Labels []MinipoolLabel `json:"labels"`
+}
+type MinipoolLabel struct {
+	Address common.Address `json:"address"`
+	Label   string         `json:"label"`
+}
+
+type SetMinipoolLabelResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type MinipoolTagsResponse struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error"`
+	Tags   []MinipoolTags `json:"tags"`
+}
+type MinipoolTags struct {
+	Address common.Address `json:"address"`
+	Label   string         `json:"label,omitempty"`
+	Tags    []string       `json:"tags"`
+}
+
+type TagMinipoolResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Changed bool   `json:"changed"`
+}
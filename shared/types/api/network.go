@@ -4,6 +4,8 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
 )
 
 type NodeFeeResponse struct {
@@ -32,6 +34,8 @@ type NetworkStatsResponse struct {
 	TotalValueLocked          float64        `json:"totalValueLocked"`
 	DepositPoolBalance        float64        `json:"depositPoolBalance"`
 	MinipoolCapacity          float64        `json:"minipoolCapacity"`
+	DepositPoolExcess         float64        `json:"depositPoolExcess"`
+	NodeDemand                float64        `json:"nodeDemand"`
 	StakerUtilization         float64        `json:"stakerUtilization"`
 	NodeFee                   float64        `json:"nodeFee"`
 	NodeCount                 uint64         `json:"nodeCount"`
@@ -45,6 +49,8 @@ type NetworkStatsResponse struct {
 	TotalRplStaked            float64        `json:"totalRplStaked"`
 	EffectiveRplStaked        float64        `json:"effectiveRplStaked"`
 	RethPrice                 float64        `json:"rethPrice"`
+	RethApr                   float64        `json:"rethApr"`
+	RethAprError              string         `json:"rethAprError"`
 	SmoothingPoolNodes        uint64         `json:"smoothingPoolNodes"`
 	SmoothingPoolAddress      common.Address `json:"SmoothingPoolAddress"`
 	SmoothingPoolBalance      float64        `json:"smoothingPoolBalance"`
@@ -84,6 +90,28 @@ type DownloadRewardsFileResponse struct {
 	Error  string `json:"error"`
 }
 
+type VerifyRewardsFileResponse struct {
+	Status                string           `json:"status"`
+	Error                 string           `json:"error"`
+	Interval              uint64           `json:"interval"`
+	CID                   string           `json:"cid"`
+	CanonicalMerkleRoot   common.Hash      `json:"canonicalMerkleRoot"`
+	TreeFilePath          string           `json:"treeFilePath"`
+	TreeFileExists        bool             `json:"treeFileExists"`
+	FileIndex             uint64           `json:"fileIndex"`
+	FileNetwork           string           `json:"fileNetwork"`
+	ExpectedNetwork       string           `json:"expectedNetwork"`
+	FileMerkleRoot        common.Hash      `json:"fileMerkleRoot"`
+	ComputedMerkleRoot    common.Hash      `json:"computedMerkleRoot"`
+	InvalidProofs         []common.Address `json:"invalidProofs"`
+	PublishedFileChecked  bool             `json:"publishedFileChecked"`
+	PublishedFileError    string           `json:"publishedFileError"`
+	PublishedMerkleRoot   common.Hash      `json:"publishedMerkleRoot"`
+	PublishedComputedRoot common.Hash      `json:"publishedComputedRoot"`
+	PublishedFileMismatch bool             `json:"publishedFileMismatch"`
+	Verified              bool             `json:"verified"`
+}
+
 type IsAtlasDeployedResponse struct {
 	Status          string `json:"status"`
 	Error           string `json:"error"`
@@ -95,3 +123,9 @@ type GetLatestDelegateResponse struct {
 	Error   string         `json:"error"`
 	Address common.Address `json:"address"`
 }
+
+type NetworkFeeSuggestionResponse struct {
+	Status     string                      `json:"status"`
+	Error      string                      `json:"error"`
+	Suggestion feehistory.GasFeeSuggestion `json:"suggestion"`
+}
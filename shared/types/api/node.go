@@ -9,7 +9,10 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/balancehistory"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/ical"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -78,6 +81,21 @@ type RegisterNodeResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type NodeWithdrawalSafetyResponse struct {
+	Status                       string         `json:"status"`
+	Error                        string         `json:"error"`
+	WithdrawalAddress            common.Address `json:"withdrawalAddress"`
+	PendingWithdrawalAddress     common.Address `json:"pendingWithdrawalAddress"`
+	RplWithdrawalAddress         common.Address `json:"rplWithdrawalAddress"`
+	HasRplWithdrawalAddress      bool           `json:"hasRplWithdrawalAddress"`
+	ExpectedWithdrawalAddress    string         `json:"expectedWithdrawalAddress"`
+	ExpectedRplWithdrawalAddress string         `json:"expectedRplWithdrawalAddress"`
+	ProofMessage                 string         `json:"proofMessage"`
+	HasProof                     bool           `json:"hasProof"`
+	ProofSigner                  common.Address `json:"proofSigner"`
+	Problems                     []string       `json:"problems"`
+}
+
 type CanSetNodeWithdrawalAddressResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -114,6 +132,50 @@ type GetNodePendingWithdrawalAddressResponse struct {
 	Address common.Address `json:"address"`
 }
 
+type NodeRplWithdrawalAddressResponse struct {
+	Status                      string         `json:"status"`
+	Error                       string         `json:"error"`
+	IsSupported                 bool           `json:"isSupported"`
+	WithdrawalAddress           common.Address `json:"withdrawalAddress"`
+	RplWithdrawalAddress        common.Address `json:"rplWithdrawalAddress"`
+	HasRplWithdrawalAddress     bool           `json:"hasRplWithdrawalAddress"`
+	PendingRplWithdrawalAddress common.Address `json:"pendingRplWithdrawalAddress"`
+	NodeManagerAddress          common.Address `json:"nodeManagerAddress"`
+	ConfirmCalldata             string         `json:"confirmCalldata"`
+}
+
+type CanSetNodeRplWithdrawalAddressResponse struct {
+	Status             string             `json:"status"`
+	Error              string             `json:"error"`
+	CanSet             bool               `json:"canSet"`
+	IsUnsupported      bool               `json:"isUnsupported"`
+	Authority          common.Address     `json:"authority"`
+	NodeManagerAddress common.Address     `json:"nodeManagerAddress"`
+	Calldata           string             `json:"calldata"`
+	GasInfo            rocketpool.GasInfo `json:"gasInfo"`
+}
+type SetNodeRplWithdrawalAddressResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanConfirmNodeRplWithdrawalAddressResponse struct {
+	Status                      string             `json:"status"`
+	Error                       string             `json:"error"`
+	CanConfirm                  bool               `json:"canConfirm"`
+	IsUnsupported               bool               `json:"isUnsupported"`
+	PendingRplWithdrawalAddress common.Address     `json:"pendingRplWithdrawalAddress"`
+	NodeManagerAddress          common.Address     `json:"nodeManagerAddress"`
+	Calldata                    string             `json:"calldata"`
+	GasInfo                     rocketpool.GasInfo `json:"gasInfo"`
+}
+type ConfirmNodeRplWithdrawalAddressResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type CanSetNodeTimezoneResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -195,6 +257,33 @@ type CanNodeWithdrawRplResponse struct {
 	IsAtlasDeployed              bool               `json:"isAtlasDeployed"`
 	GasInfo                      rocketpool.GasInfo `json:"gasInfo"`
 }
+type NodeRplWithdrawalPlanResponse struct {
+	Status                   string             `json:"status"`
+	Error                    string             `json:"error"`
+	IsAtlasDeployed          bool               `json:"isAtlasDeployed"`
+	RplStake                 *big.Int           `json:"rplStake"`
+	RplPrice                 *big.Int           `json:"rplPrice"`
+	EthMatched               *big.Int           `json:"ethMatched"`
+	PendingMatchAmount       *big.Int           `json:"pendingMatchAmount"`
+	MaximumRplStake          *big.Int           `json:"maximumRplStake"`
+	MinimumRplStake          *big.Int           `json:"minimumRplStake"`
+	TargetRplStake           *big.Int           `json:"targetRplStake"`
+	RequiredRplStake         *big.Int           `json:"requiredRplStake"`
+	LimitedBy                RplWithdrawalLimit `json:"limitedBy"`
+	MaxSafeWithdrawal        *big.Int           `json:"maxSafeWithdrawal"`
+	CurrentCollateralRatio   float64            `json:"currentCollateralRatio"`
+	ResultingCollateralRatio float64            `json:"resultingCollateralRatio"`
+	WithdrawalDelayActive    bool               `json:"withdrawalDelayActive"`
+	WithdrawalDelayEnd       time.Time          `json:"withdrawalDelayEnd"`
+}
+type RplWithdrawalLimit string
+
+const (
+	RplWithdrawalLimit_Protocol RplWithdrawalLimit = "protocol"
+	RplWithdrawalLimit_Minimum  RplWithdrawalLimit = "minimum"
+	RplWithdrawalLimit_Target   RplWithdrawalLimit = "target"
+)
+
 type NodeWithdrawRplResponse struct {
 	Status string      `json:"status"`
 	Error  string      `json:"error"`
@@ -220,6 +309,32 @@ type CanNodeDepositResponse struct {
 	MinipoolAddress                  common.Address     `json:"minipoolAddress"`
 	GasInfo                          rocketpool.GasInfo `json:"gasInfo"`
 }
+type NodeDepositPlanResponse struct {
+	Status             string                  `json:"status"`
+	Error              string                  `json:"error"`
+	IsAtlasDeployed    bool                    `json:"isAtlasDeployed"`
+	DepositDisabled    bool                    `json:"depositDisabled"`
+	CreditBalance      *big.Int                `json:"creditBalance"`
+	NodeBalance        *big.Int                `json:"nodeBalance"`
+	DepositPoolBalance *big.Int                `json:"depositPoolBalance"`
+	CanUseCredit       bool                    `json:"canUseCredit"`
+	AvailableEth       *big.Int                `json:"availableEth"`
+	EthMatched         *big.Int                `json:"ethMatched"`
+	EthMatchedLimit    *big.Int                `json:"ethMatchedLimit"`
+	PendingMatchAmount *big.Int                `json:"pendingMatchAmount"`
+	AvailableToMatch   *big.Int                `json:"availableToMatch"`
+	Options            []NodeDepositPlanOption `json:"options"`
+}
+
+// How many minipools with a given bond the node can create, and what limits it
+type NodeDepositPlanOption struct {
+	BondAmount  *big.Int `json:"bondAmount"`
+	MatchAmount *big.Int `json:"matchAmount"`
+	MaxByEth    uint64   `json:"maxByEth"`
+	MaxByRpl    uint64   `json:"maxByRpl"`
+	MaxCount    uint64   `json:"maxCount"`
+}
+
 type NodeDepositResponse struct {
 	Status          string                  `json:"status"`
 	Error           string                  `json:"error"`
@@ -314,6 +429,7 @@ type NodeRewardsResponse struct {
 	UnclaimedEthRewards         float64       `json:"unclaimedEthRewards"`
 	UnclaimedTrustedRplRewards  float64       `json:"unclaimedTrustedRplRewards"`
 	BeaconRewards               float64       `json:"beaconRewards"`
+	MissingRewardsIntervals     []uint64      `json:"missingRewardsIntervals"`
 	TxHash                      common.Hash   `json:"txHash"`
 }
 
@@ -328,9 +444,21 @@ type DepositContractInfoResponse struct {
 }
 
 type NodeSignResponse struct {
-	Status     string `json:"status"`
-	Error      string `json:"error"`
-	SignedData string `json:"signedData"`
+	Status      string         `json:"status"`
+	Error       string         `json:"error"`
+	SignedData  string         `json:"signedData"`
+	Address     common.Address `json:"address"`
+	MessageHash common.Hash    `json:"messageHash"`
+}
+
+type NodeSignTypedDataResponse struct {
+	Status          string         `json:"status"`
+	Error           string         `json:"error"`
+	SignedData      string         `json:"signedData"`
+	Address         common.Address `json:"address"`
+	PrimaryType     string         `json:"primaryType"`
+	DomainSeparator common.Hash    `json:"domainSeparator"`
+	MessageHash     common.Hash    `json:"messageHash"`
 }
 
 type EstimateSetSnapshotDelegateGasResponse struct {
@@ -429,6 +557,31 @@ type NodeClaimAndStakeRewardsResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+// A single rewards claim transaction within a claim plan
+type ClaimTransaction struct {
+	Indices       []uint64           `json:"indices"`
+	StakeAmount   *big.Int           `json:"stakeAmount"`
+	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
+	CalldataBytes uint64             `json:"calldataBytes"`
+	CalldataGas   uint64             `json:"calldataGas"`
+}
+
+// A set of transactions that claims a selection of rewards intervals
+type ClaimPlan struct {
+	Description        string             `json:"description"`
+	Restake            bool               `json:"restake"`
+	Transactions       []ClaimTransaction `json:"transactions"`
+	TotalGas           uint64             `json:"totalGas"`
+	TotalCalldataBytes uint64             `json:"totalCalldataBytes"`
+	TotalCalldataGas   uint64             `json:"totalCalldataGas"`
+}
+type NodeOptimizeClaimsResponse struct {
+	Status          string      `json:"status"`
+	Error           string      `json:"error"`
+	Plans           []ClaimPlan `json:"plans"`
+	RecommendedPlan int         `json:"recommendedPlan"`
+}
+
 type GetSmoothingPoolRegistrationStatusResponse struct {
 	Status                  string        `json:"status"`
 	Error                   string        `json:"error"`
@@ -457,6 +610,7 @@ type SnapshotProposal struct {
 	Start         int64     `json:"start"`
 	End           int64     `json:"end"`
 	State         string    `json:"state"`
+	Type          string    `json:"type"`
 	Snapshot      string    `json:"snapshot"`
 	Author        string    `json:"author"`
 	Choices       []string  `json:"choices"`
@@ -476,7 +630,8 @@ type SnapshotResponse struct {
 type SnapshotVotingPower struct {
 	Data struct {
 		Vp struct {
-			Vp float64 `json:"vp"`
+			Vp           float64   `json:"vp"`
+			VpByStrategy []float64 `json:"vp_by_strategy"`
 		} `json:"vp"`
 	} `json:"data"`
 }
@@ -515,3 +670,157 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeHealthResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	SafeMode       bool   `json:"safeMode"`
+	RecentRestarts int    `json:"recentRestarts"`
+	StateLoaded    bool   `json:"stateLoaded"`
+	StateStale     bool   `json:"stateStale"`
+	StateSlot      uint64 `json:"stateSlot"`
+}
+
+type NodeConfigReloadResponse struct {
+	Status          string             `json:"status"`
+	Error           string             `json:"error"`
+	Generation      uint64             `json:"generation"`
+	Applied         []NodeConfigChange `json:"applied"`
+	RestartRequired []string           `json:"restartRequired"`
+	Warnings        []string           `json:"warnings"`
+}
+
+// A setting that was changed in the running node daemon by a config reload
+type NodeConfigChange struct {
+	Setting  string `json:"setting"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+type NodeActivityResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	Activity []NodeActivity `json:"activity"`
+}
+type NodeActivity struct {
+	BlockNumber uint64         `json:"blockNumber"`
+	Time        time.Time      `json:"time"`
+	TxHash      common.Hash    `json:"txHash"`
+	Type        string         `json:"type"`
+	Interval    *uint64        `json:"interval,omitempty"`
+	Minipool    common.Address `json:"minipool"`
+	RplAmount   *big.Int       `json:"rplAmount"`
+	EthAmount   *big.Int       `json:"ethAmount"`
+}
+
+type NodeDutiesCalendarResponse struct {
+	Status string       `json:"status"`
+	Error  string       `json:"error"`
+	Events []ical.Event `json:"events"`
+}
+
+type NodeGraffitiResponse struct {
+	Status     string              `json:"status"`
+	Error      string              `json:"error"`
+	Enabled    bool                `json:"enabled"`
+	Template   string              `json:"template"`
+	Validators []ValidatorGraffiti `json:"validators"`
+}
+type ValidatorGraffiti struct {
+	Pubkey   rptypes.ValidatorPubkey `json:"pubkey"`
+	Index    string                  `json:"index"`
+	Template string                  `json:"template"`
+	Custom   bool                    `json:"custom"`
+	Graffiti string                  `json:"graffiti"`
+}
+
+type NodeSetGraffitiResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type NodeHistoryResponse struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error"`
+	Events []journal.Event `json:"events"`
+}
+
+type NodeBalanceHistoryResponse struct {
+	Status    string                    `json:"status"`
+	Error     string                    `json:"error"`
+	Node      common.Address            `json:"node"`
+	Snapshots []balancehistory.Snapshot `json:"snapshots"`
+	Deltas    []balancehistory.Delta    `json:"deltas"`
+}
+
+type NodeWithdrawalLedgerResponse struct {
+	Status            string                  `json:"status"`
+	Error             string                  `json:"error"`
+	NodeAddress       common.Address          `json:"nodeAddress"`
+	WithdrawalAddress common.Address          `json:"withdrawalAddress"`
+	Entries           []WithdrawalLedgerEntry `json:"entries"`
+	TotalEth          *big.Int                `json:"totalEth"`
+	TotalRpl          *big.Int                `json:"totalRpl"`
+}
+type WithdrawalLedgerEntry struct {
+	BlockNumber uint64         `json:"blockNumber"`
+	Time        time.Time      `json:"time"`
+	TxHash      common.Hash    `json:"txHash"`
+	Type        string         `json:"type"`
+	Source      common.Address `json:"source"`
+	Interval    *uint64        `json:"interval,omitempty"`
+	EthAmount   *big.Int       `json:"ethAmount"`
+	RplAmount   *big.Int       `json:"rplAmount"`
+}
+
+type NodeRewardsHistoryResponse struct {
+	Status    string                `json:"status"`
+	Error     string                `json:"error"`
+	Intervals []NodeRewardsInterval `json:"intervals"`
+}
+type NodeRewardsInterval struct {
+	Index            uint64       `json:"index"`
+	Claimed          bool         `json:"claimed"`
+	TreeFileExists   bool         `json:"treeFileExists"`
+	StartTime        time.Time    `json:"startTime"`
+	EndTime          time.Time    `json:"endTime"`
+	CollateralRpl    *big.Int     `json:"collateralRpl"`
+	ODaoRpl          *big.Int     `json:"oDaoRpl"`
+	SmoothingPoolEth *big.Int     `json:"smoothingPoolEth"`
+	ClaimTxHash      *common.Hash `json:"claimTxHash,omitempty"`
+	ClaimTime        *time.Time   `json:"claimTime,omitempty"`
+}
+
+type NodeRewardEventsResponse struct {
+	Status     string            `json:"status"`
+	Error      string            `json:"error"`
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Currency   string            `json:"currency"`
+	Events     []NodeRewardEvent `json:"events"`
+	TotalRpl   *big.Int          `json:"totalRpl"`
+	TotalEth   *big.Int          `json:"totalEth"`
+	TotalValue float64           `json:"totalValue"`
+}
+type NodeRewardEvent struct {
+	Interval       uint64       `json:"interval"`
+	Time           time.Time    `json:"time"`
+	Type           string       `json:"type"`
+	Asset          string       `json:"asset"`
+	Amount         *big.Int     `json:"amount"`
+	Price          float64      `json:"price"`
+	Value          float64      `json:"value"`
+	TreeFileExists bool         `json:"treeFileExists"`
+	Claimed        bool         `json:"claimed"`
+	ClaimTxHash    *common.Hash `json:"claimTxHash,omitempty"`
+	RunningRpl     *big.Int     `json:"runningRpl"`
+	RunningEth     *big.Int     `json:"runningEth"`
+	RunningValue   float64      `json:"runningValue"`
+}
+
+type NodeCommissionUpgradesResponse struct {
+	Status          string                     `json:"status"`
+	Error           string                     `json:"error"`
+	IsAtlasDeployed bool                       `json:"isAtlasDeployed"`
+	Report          rp.CommissionUpgradeReport `json:"report"`
+}
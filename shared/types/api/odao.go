@@ -186,6 +186,60 @@ type LeaveTNDAOResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type TNDAOMembershipResponse struct {
+	Status                      string         `json:"status"`
+	Error                       string         `json:"error"`
+	IsMember                    bool           `json:"isMember"`
+	RPLBondAmount               *big.Int       `json:"rplBondAmount"`
+	RequiredRPLBond             *big.Int       `json:"requiredRplBond"`
+	UnbondedValidatorCount      uint64         `json:"unbondedValidatorCount"`
+	IsChallenged                bool           `json:"isChallenged"`
+	ChallengedTime              uint64         `json:"challengedTime"`
+	ChallengedBy                common.Address `json:"challengedBy"`
+	ChallengeWindow             uint64         `json:"challengeWindow"`
+	LeaveProposalExecutedTime   uint64         `json:"leaveProposalExecutedTime"`
+	ReplaceProposalExecutedTime uint64         `json:"replaceProposalExecutedTime"`
+	ReplacementAddress          common.Address `json:"replacementAddress"`
+	ReplacingMember             common.Address `json:"replacingMember"`
+	ProposalActionTime          uint64         `json:"proposalActionTime"`
+}
+
+type CanChallengeTNDAOMemberResponse struct {
+	Status              string             `json:"status"`
+	Error               string             `json:"error"`
+	CanChallenge        bool               `json:"canChallenge"`
+	MemberDoesNotExist  bool               `json:"memberDoesNotExist"`
+	ChallengeSelf       bool               `json:"challengeSelf"`
+	AlreadyChallenged   bool               `json:"alreadyChallenged"`
+	ChallengeCooldown   bool               `json:"challengeCooldown"`
+	InsufficientBalance bool               `json:"insufficientBalance"`
+	IsMember            bool               `json:"isMember"`
+	ChallengeCost       *big.Int           `json:"challengeCost"`
+	ChallengeWindow     uint64             `json:"challengeWindow"`
+	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
+}
+type ChallengeTNDAOMemberResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanDecideTNDAOChallengeResponse struct {
+	Status              string             `json:"status"`
+	Error               string             `json:"error"`
+	CanDecide           bool               `json:"canDecide"`
+	NotChallenged       bool               `json:"notChallenged"`
+	ChallengeWindowOpen bool               `json:"challengeWindowOpen"`
+	IsOwnChallenge      bool               `json:"isOwnChallenge"`
+	ChallengeWindowEnd  uint64             `json:"challengeWindowEnd"`
+	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
+}
+type DecideTNDAOChallengeResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type CanReplaceTNDAOPositionResponse struct {
 	Status              string             `json:"status"`
 	Error               string             `json:"error"`
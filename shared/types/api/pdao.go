@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type PdaoVotingPowerResponse struct {
+	Status                string         `json:"status"`
+	Error                 string         `json:"error"`
+	AccountAddress        common.Address `json:"accountAddress"`
+	VotingDelegate        common.Address `json:"votingDelegate"`
+	VotingPower           float64        `json:"votingPower"`
+	VotingPowerByStrategy []float64      `json:"votingPowerByStrategy"`
+}
+
+type PdaoCanVoteResponse struct {
+	Status            string           `json:"status"`
+	Error             string           `json:"error"`
+	CanVote           bool             `json:"canVote"`
+	ProposalNotFound  bool             `json:"proposalNotFound"`
+	ProposalNotActive bool             `json:"proposalNotActive"`
+	UnsupportedType   bool             `json:"unsupportedType"`
+	InvalidChoice     bool             `json:"invalidChoice"`
+	NoVotingPower     bool             `json:"noVotingPower"`
+	Proposal          SnapshotProposal `json:"proposal"`
+	VotingPower       float64          `json:"votingPower"`
+}
+type PdaoVoteResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	VoteId string `json:"voteId"`
+}
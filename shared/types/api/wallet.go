@@ -1,6 +1,8 @@
 package api
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -23,6 +25,47 @@ type WalletStatusResponse struct {
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
 	AccountAddress    common.Address `json:"accountAddress"`
+	IsMasquerading    bool           `json:"isMasquerading"`
+	HasTransactor     bool           `json:"hasTransactor"`
+	TransactorAddress common.Address `json:"transactorAddress"`
+}
+
+type MasqueradeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type EndMasqueradeResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	WasMasquerading bool   `json:"wasMasquerading"`
+}
+
+type TransactorStatusResponse struct {
+	Status        string         `json:"status"`
+	Error         string         `json:"error"`
+	HasTransactor bool           `json:"hasTransactor"`
+	Address       common.Address `json:"address"`
+	Balance       *big.Int       `json:"balance"`
+	NodeAddress   common.Address `json:"nodeAddress"`
+}
+
+type CreateTransactorResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	Address        common.Address `json:"address"`
+	Rotated        bool           `json:"rotated"`
+	RetiredAddress common.Address `json:"retiredAddress"`
+	SweptAmount    *big.Int       `json:"sweptAmount"`
+	SweepTxHash    common.Hash    `json:"sweepTxHash"`
+}
+
+type RemoveTransactorResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	RetiredAddress common.Address `json:"retiredAddress"`
+	SweptAmount    *big.Int       `json:"sweptAmount"`
+	SweepTxHash    common.Hash    `json:"sweepTxHash"`
 }
 
 type SetPasswordResponse struct {
@@ -54,10 +97,32 @@ type SearchAndRecoverWalletResponse struct {
 	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
 }
 
+type SearchWalletResponse struct {
+	Status   string                `json:"status"`
+	Error    string                `json:"error"`
+	Accounts []WalletSearchAccount `json:"accounts"`
+}
+type WalletSearchAccount struct {
+	DerivationPath string         `json:"derivationPath"`
+	Index          uint           `json:"index"`
+	Address        common.Address `json:"address"`
+	Nonce          uint64         `json:"nonce"`
+	Balance        *big.Int       `json:"balance"`
+}
+
 type RebuildWalletResponse struct {
-	Status        string                  `json:"status"`
-	Error         string                  `json:"error"`
-	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+	Status                   string                  `json:"status"`
+	Error                    string                  `json:"error"`
+	ValidatorKeys            []types.ValidatorPubkey `json:"validatorKeys"`
+	DoppelgangerCheckSkipped bool                    `json:"doppelgangerCheckSkipped"`
+}
+
+type CheckDoppelgangersResponse struct {
+	Status         string                  `json:"status"`
+	Error          string                  `json:"error"`
+	Checked        bool                    `json:"checked"`
+	Epochs         []uint64                `json:"epochs"`
+	LiveValidators []types.ValidatorPubkey `json:"liveValidators"`
 }
 
 type ExportWalletResponse struct {
@@ -88,3 +153,14 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type TestTransactionResponse struct {
+	Status           string         `json:"status"`
+	Error            string         `json:"error"`
+	AccountAddress   common.Address `json:"accountAddress"`
+	DerivationPath   string         `json:"derivationPath"`
+	RecoveredAddress common.Address `json:"recoveredAddress"`
+	SignatureValid   bool           `json:"signatureValid"`
+	SignedTx         string         `json:"signedTx"`
+	TxHash           common.Hash    `json:"txHash"`
+}
@@ -10,6 +10,11 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type BcRoutingMode string
+type GasOracle string
+type IpMode string
+type LogFormat string
+type LogLevel string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -25,6 +30,7 @@ const (
 	ContainerID_Prometheus ContainerID = "prometheus"
 	ContainerID_Exporter   ContainerID = "exporter"
 	ContainerID_MevBoost   ContainerID = "mev-boost"
+	ContainerID_BnProxy    ContainerID = "bn-proxy"
 )
 
 // Enum to describe which network the system is on
@@ -83,6 +89,42 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe where the Smartnode gets its gas price suggestions from
+const (
+	GasOracle_Unknown         GasOracle = ""
+	GasOracle_ExecutionClient GasOracle = "executionClient"
+	GasOracle_External        GasOracle = "external"
+)
+
+// Enum to describe which IP version the Smartnode's outbound HTTP connections use
+const (
+	IpMode_Auto IpMode = "auto"
+	IpMode_IPv4 IpMode = "ipv4"
+	IpMode_IPv6 IpMode = "ipv6"
+)
+
+// Enum to describe the format of the daemons' logs
+const (
+	LogFormat_Console LogFormat = "console"
+	LogFormat_Json    LogFormat = "json"
+)
+
+// Enum to describe the lowest severity of the log messages the daemons write
+const (
+	LogLevel_Debug LogLevel = "debug"
+	LogLevel_Info  LogLevel = "info"
+	LogLevel_Warn  LogLevel = "warn"
+	LogLevel_Error LogLevel = "error"
+)
+
+// Enum to describe how a class of Beacon requests is routed when both the primary and fallback clients are healthy
+const (
+	BcRoutingMode_Unknown BcRoutingMode = ""
+	BcRoutingMode_Primary BcRoutingMode = "primary"
+	BcRoutingMode_Fastest BcRoutingMode = "fastest"
+	BcRoutingMode_Slowest BcRoutingMode = "slowest"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -4,19 +4,33 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
+	WriteResponse(os.Stdout, response, responseError)
+}
+
+// Print an API error response
+func PrintErrorResponse(err error) {
+	PrintResponse(&api.APIResponse{}, err)
+}
+
+// Write an API response to the provided writer
+// response must be a pointer to a struct type with Error and Status string fields
+func WriteResponse(w io.Writer, response interface{}, responseError error) {
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		WriteErrorResponse(w, errors.New("Invalid API response"))
 		return
 	}
 
@@ -30,7 +44,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		WriteErrorResponse(w, errors.New("Invalid API response"))
 		return
 	}
 
@@ -49,16 +63,39 @@ func PrintResponse(response interface{}, responseError error) {
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+		WriteErrorResponse(w, fmt.Errorf("Could not encode API response: %w", err))
 		return
 	}
 
-	// Print
-	fmt.Println(string(responseBytes))
+	// Attach any transactions that were simulated instead of sent
+	if simulations := simulation.GetSimulations(); len(simulations) > 0 {
+		responseBytes, err = addSimulations(responseBytes, simulations)
+		if err != nil {
+			WriteErrorResponse(w, fmt.Errorf("Could not encode API response: %w", err))
+			return
+		}
+	}
+
+	// Write
+	fmt.Fprintln(w, string(responseBytes))
 
 }
 
-// Print an API error response
-func PrintErrorResponse(err error) {
-	PrintResponse(&api.APIResponse{}, err)
+// Write an API error response to the provided writer
+func WriteErrorResponse(w io.Writer, err error) {
+	WriteResponse(w, &api.APIResponse{}, err)
+}
+
+// Add the simulated transactions to an encoded API response
+func addSimulations(responseBytes []byte, simulations []api.TransactionSimulation) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(responseBytes, &fields); err != nil {
+		return nil, err
+	}
+	simulationBytes, err := json.Marshal(simulations)
+	if err != nil {
+		return nil, err
+	}
+	fields["simulations"] = simulationBytes
+	return json.Marshal(fields)
 }
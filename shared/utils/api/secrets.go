@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/utils/secret"
+)
+
+// The argument the client passes in place of a secret, such as a password or mnemonic, that it sends on stdin instead.
+// Secrets on stdin don't show up in the process list the way command arguments do.
+const StdinSecretArg string = "-"
+
+// Secrets are sent one per line, in the order of their arguments
+var stdinLock sync.Mutex
+
+// Get an argument that holds a secret, reading it from stdin if the client sent it there
+func GetSecretArg(c *cli.Context, index int) (string, error) {
+	arg := c.Args().Get(index)
+	if arg != StdinSecretArg {
+		return arg, nil
+	}
+
+	stdinLock.Lock()
+	defer stdinLock.Unlock()
+	value, err := readSecretLine(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret argument %d from stdin: %w", index, err)
+	}
+	return value, nil
+}
+
+// Read a line into locked memory one byte at a time, so nothing past it is buffered and the only copy left is the string
+func readSecretLine(reader io.Reader) (string, error) {
+	line := secret.NewBuffer(4096)
+	defer line.Destroy()
+	buffer := line.Bytes()
+	length := 0
+	next := make([]byte, 1)
+	defer secret.Wipe(next)
+	for {
+		count, err := reader.Read(next)
+		if count == 1 {
+			if next[0] == '\n' {
+				break
+			}
+			if length == len(buffer) {
+				return "", fmt.Errorf("the secret is longer than %d bytes", len(buffer))
+			}
+			buffer[length] = next[0]
+			length++
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if length == 0 {
+		return "", errors.New("the secret wasn't provided")
+	}
+	return string(buffer[:length]), nil
+}
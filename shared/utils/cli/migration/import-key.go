@@ -52,7 +52,7 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 			fmt.Printf("failed!\n%sWARNING: error restarting validator client: %s\n\nPlease restart it manually so it picks up the new validator key for your minipool.%s", colorYellow, err.Error(), colorReset)
 			return false
 		}
-		fmt.Println("done!\n")
+		fmt.Print("done!\n\n")
 	}
 	return true
 
@@ -45,6 +45,11 @@ func PrintMultiTransactionNonceWarning() {
 // Implementation of PrintTransactionHash and PrintTransactionHashNoCancel
 func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) {
 
+	if rp.IsSimulating() {
+		fmt.Printf("%sTransaction %s was only simulated; it was not signed or broadcast, so nothing has changed on-chain.%s\n\n", colorYellow, hash.Hex(), colorReset)
+		return
+	}
+
 	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		fmt.Printf("Warning: couldn't read config file so the transaction URL will be unavailable (%s).\n", err)
@@ -9,7 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -80,22 +79,21 @@ func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConf
 			strings.Contains(errMessage, "Internal error") { // Besu
 
 			// The state was missing so fall back to the archive node
-			archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
-			if archiveEcUrl != "" {
-				printMessage(fmt.Sprintf("Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", blockNumber.Uint64(), archiveEcUrl))
-				ec, err := ethclient.Dial(archiveEcUrl)
-				if err != nil {
-					return nil, fmt.Errorf("Error connecting to archive EC: %w", err)
-				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+			archiveEc, err := services.GetArchiveEthClient(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("Error connecting to archive EC: %w", err)
+			}
+			if archiveEc != nil {
+				printMessage(fmt.Sprintf("Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", blockNumber.Uint64(), strings.Join(archiveEc.GetUrls(), ", ")))
+				client, err = rocketpool.NewRocketPool(archiveEc, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
 				if err != nil {
-					return nil, fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", err)
+					return nil, fmt.Errorf("Error creating Rocket Pool client connected to archive EC: %w", err)
 				}
 
 				// Get the rETH address from the archive EC
 				address, err = client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
 				if err != nil {
-					return nil, fmt.Errorf("%s Error verifying rETH address with Archive EC: %w", err)
+					return nil, fmt.Errorf("Error verifying rETH address with Archive EC: %w", err)
 				}
 			} else {
 				// No archive node specified
@@ -0,0 +1,241 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings for splitting up validator status requests
+type ValidatorStatusBatchSettings struct {
+	// The number of validators to request at once
+	BatchSize int
+
+	// The number of requests to have in flight at once
+	MaxConcurrentRequests int
+
+	// How long statuses are kept for a state before they have to be requested again
+	CacheLifetime time.Duration
+}
+
+// The batch settings used when none are provided
+var DefaultValidatorStatusBatchSettings = ValidatorStatusBatchSettings{
+	BatchSize:             100,
+	MaxConcurrentRequests: 4,
+	CacheLifetime:         2 * time.Minute,
+}
+
+// The statuses retrieved for one Beacon state
+type validatorStatusCacheEntry struct {
+	statuses map[types.ValidatorPubkey]beacon.ValidatorStatus
+	expires  time.Time
+}
+
+// A Beacon client that splits large validator status requests into small batches so they don't time out on nodes with
+// many validators, and caches the results by state root so repeated lookups against the same state don't hit the
+// Beacon node again.
+// Requests for the head state are batched but not cached, since the head moves every slot.
+type ValidatorStatusBatcher struct {
+	beacon.Client
+	settings ValidatorStatusBatchSettings
+
+	slotsPerEpoch uint64
+	cache         map[common.Hash]*validatorStatusCacheEntry
+	lock          sync.Mutex
+}
+
+// The batchers shared by everything using the same Beacon client, and the settings they use
+var batchers = map[beacon.Client]*ValidatorStatusBatcher{}
+var batcherSettings = DefaultValidatorStatusBatchSettings
+var batchersLock sync.Mutex
+
+// Create a validator status batcher that wraps a Beacon client
+func NewValidatorStatusBatcher(bc beacon.Client, settings ValidatorStatusBatchSettings) *ValidatorStatusBatcher {
+	if settings.BatchSize <= 0 {
+		settings.BatchSize = DefaultValidatorStatusBatchSettings.BatchSize
+	}
+	if settings.MaxConcurrentRequests <= 0 {
+		settings.MaxConcurrentRequests = DefaultValidatorStatusBatchSettings.MaxConcurrentRequests
+	}
+	return &ValidatorStatusBatcher{
+		Client:   bc,
+		settings: settings,
+		cache:    map[common.Hash]*validatorStatusCacheEntry{},
+	}
+}
+
+// Set the batch size and concurrency of the shared batchers, such as from the config; values of 0 use the defaults.
+// Batchers that were already created switch to the new settings for their next request.
+func ConfigureValidatorStatusBatchers(batchSize int, maxConcurrentRequests int) {
+	if batchSize <= 0 {
+		batchSize = DefaultValidatorStatusBatchSettings.BatchSize
+	}
+	if maxConcurrentRequests <= 0 {
+		maxConcurrentRequests = DefaultValidatorStatusBatchSettings.MaxConcurrentRequests
+	}
+
+	batchersLock.Lock()
+	defer batchersLock.Unlock()
+	batcherSettings.BatchSize = batchSize
+	batcherSettings.MaxConcurrentRequests = maxConcurrentRequests
+	for _, batcher := range batchers {
+		batcher.lock.Lock()
+		batcher.settings.BatchSize = batchSize
+		batcher.settings.MaxConcurrentRequests = maxConcurrentRequests
+		batcher.lock.Unlock()
+	}
+}
+
+// Get the batcher for a Beacon client with the configured settings, creating it if this is the first time the client
+// has been used so its cache is shared by every caller
+func GetValidatorStatusBatcher(bc beacon.Client) *ValidatorStatusBatcher {
+	if batcher, ok := bc.(*ValidatorStatusBatcher); ok {
+		return batcher
+	}
+	batchersLock.Lock()
+	defer batchersLock.Unlock()
+	batcher, exists := batchers[bc]
+	if !exists {
+		batcher = NewValidatorStatusBatcher(bc, batcherSettings)
+		batchers[bc] = batcher
+	}
+	return batcher
+}
+
+// Get the statuses of multiple validators by their pubkeys, in batches
+func (b *ValidatorStatusBatcher) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+
+	// Get the state root to cache the statuses under
+	stateRoot, cacheable, err := b.getStateRoot(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the validators that haven't been cached for this state
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	missing := make([]types.ValidatorPubkey, 0, len(pubkeys))
+	requested := make(map[types.ValidatorPubkey]bool, len(pubkeys))
+	b.lock.Lock()
+	settings := b.settings
+	b.pruneCache()
+	entry := b.cache[stateRoot]
+	for _, pubkey := range pubkeys {
+		if requested[pubkey] {
+			continue
+		}
+		requested[pubkey] = true
+		if cacheable && entry != nil {
+			if status, exists := entry.statuses[pubkey]; exists {
+				// Match the client, which leaves validators that don't exist out of the results
+				if status.Exists || pubkey == (types.ValidatorPubkey{}) {
+					statuses[pubkey] = status
+				}
+				continue
+			}
+		}
+		missing = append(missing, pubkey)
+	}
+	b.lock.Unlock()
+
+	// Request the rest in batches
+	var lock sync.Mutex
+	var wg errgroup.Group
+	wg.SetLimit(settings.MaxConcurrentRequests)
+	for bsi := 0; bsi < len(missing); bsi += settings.BatchSize {
+		bei := bsi + settings.BatchSize
+		if bei > len(missing) {
+			bei = len(missing)
+		}
+		batch := missing[bsi:bei]
+		wg.Go(func() error {
+			batchStatuses, err := b.Client.GetValidatorStatuses(batch, opts)
+			if err != nil {
+				return err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			for pubkey, status := range batchStatuses {
+				statuses[pubkey] = status
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+
+	// Cache the new statuses, including the validators that don't exist yet so they aren't requested again for this state
+	if cacheable && len(missing) > 0 {
+		b.lock.Lock()
+		entry = b.cache[stateRoot]
+		if entry == nil {
+			entry = &validatorStatusCacheEntry{
+				statuses: map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+				expires:  time.Now().Add(settings.CacheLifetime),
+			}
+			b.cache[stateRoot] = entry
+		}
+		for _, pubkey := range missing {
+			entry.statuses[pubkey] = statuses[pubkey]
+		}
+		b.lock.Unlock()
+	}
+
+	return statuses, nil
+
+}
+
+// Get the root of the state a request is for, and whether its statuses can be cached
+func (b *ValidatorStatusBatcher) getStateRoot(opts *beacon.ValidatorStatusOptions) (common.Hash, bool, error) {
+	if opts == nil || b.settings.CacheLifetime <= 0 {
+		return common.Hash{}, false, nil
+	}
+
+	// Named states like "finalized" move, so they aren't cached
+	if opts.StateId != "" {
+		return common.Hash{}, false, nil
+	}
+
+	var slot uint64
+	if opts.Slot != nil {
+		slot = *opts.Slot
+	} else if opts.Epoch != nil {
+		b.lock.Lock()
+		if b.slotsPerEpoch == 0 {
+			eth2Config, err := b.Client.GetEth2Config()
+			if err != nil {
+				b.lock.Unlock()
+				return common.Hash{}, false, fmt.Errorf("error getting Beacon config: %w", err)
+			}
+			b.slotsPerEpoch = eth2Config.SlotsPerEpoch
+		}
+		slot = *opts.Epoch * b.slotsPerEpoch
+		b.lock.Unlock()
+	} else {
+		return common.Hash{}, false, nil
+	}
+
+	// States that don't exist yet can't be cached
+	stateRoot, exists, err := b.Client.GetBeaconStateRoot(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("error getting state root for slot %d: %w", slot, err)
+	}
+	return stateRoot, exists, nil
+}
+
+// Remove the cached states that have expired; the lock must be held
+func (b *ValidatorStatusBatcher) pruneCache() {
+	now := time.Now()
+	for stateRoot, entry := range b.cache {
+		if now.After(entry.expires) {
+			delete(b.cache, stateRoot)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	productID     string = "-//Rocket Pool//Smartnode//EN"
+	timeFormat    string = "20060102T150405Z"
+	maxLineLength int    = 75
+)
+
+// A single event in a calendar
+type Event struct {
+	UID         string    `json:"uid"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Category    string    `json:"category"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+}
+
+// Render events as an iCalendar (RFC 5545) document
+func Write(name string, events []Event) string {
+	var builder strings.Builder
+	writeLine(&builder, "BEGIN:VCALENDAR")
+	writeLine(&builder, "VERSION:2.0")
+	writeLine(&builder, "PRODID:"+productID)
+	writeLine(&builder, "CALSCALE:GREGORIAN")
+	writeLine(&builder, "METHOD:PUBLISH")
+	writeLine(&builder, "X-WR-CALNAME:"+escapeText(name))
+
+	stamp := time.Now().UTC().Format(timeFormat)
+	for _, event := range events {
+		end := event.End
+		if end.Before(event.Start) {
+			end = event.Start
+		}
+		writeLine(&builder, "BEGIN:VEVENT")
+		writeLine(&builder, "UID:"+escapeText(event.UID))
+		writeLine(&builder, "DTSTAMP:"+stamp)
+		writeLine(&builder, "DTSTART:"+event.Start.UTC().Format(timeFormat))
+		writeLine(&builder, "DTEND:"+end.UTC().Format(timeFormat))
+		writeLine(&builder, "SUMMARY:"+escapeText(event.Summary))
+		if event.Category != "" {
+			writeLine(&builder, "CATEGORIES:"+escapeText(event.Category))
+		}
+		if event.Description != "" {
+			writeLine(&builder, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		writeLine(&builder, "END:VEVENT")
+	}
+
+	writeLine(&builder, "END:VCALENDAR")
+	return builder.String()
+}
+
+// Escape the characters that have a special meaning in text values
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(text)
+}
+
+// Write a content line, folding it onto continuation lines if it's longer than the spec allows.
+// Lines are only split between UTF-8 characters so multi-byte characters stay intact.
+func writeLine(builder *strings.Builder, line string) {
+	length := 0
+	for _, char := range line {
+		size := len(string(char))
+		if length+size > maxLineLength {
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+		builder.WriteRune(char)
+		length += size
+	}
+	builder.WriteString("\r\n")
+}
@@ -1,43 +1,144 @@
 package log
 
 import (
-	"log"
+	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 )
 
-// Logger with ANSI color output
+// Logger with ANSI color output, whose messages are written as records of the module it belongs to
 type ColorLogger struct {
-	Color       color.Attribute
-	sprintFunc  func(a ...interface{}) string
-	sprintfFunc func(format string, a ...interface{}) string
+	Color      color.Attribute
+	Module     string
+	level      Level
+	attrs      []Attr
+	sprintFunc func(a ...interface{}) string
 }
 
 // Create new color logger
 func NewColorLogger(colorAttr color.Attribute) ColorLogger {
+	return NewModuleLogger(DefaultModule, colorAttr)
+}
+
+// Create a color logger for a module, such as a node task; the module's records can be given their own level in the config
+func NewModuleLogger(module string, colorAttr color.Attribute) ColorLogger {
+	return newLogger(module, LevelInfo, colorAttr)
+}
+
+// Create a color logger for a module whose Print methods write warnings
+func NewWarningLogger(module string, colorAttr color.Attribute) ColorLogger {
+	return newLogger(module, LevelWarn, colorAttr)
+}
+
+// Create a color logger for a module whose Print methods write errors
+func NewErrorLogger(module string, colorAttr color.Attribute) ColorLogger {
+	return newLogger(module, LevelError, colorAttr)
+}
+
+// Create a color logger
+func newLogger(module string, level Level, colorAttr color.Attribute) ColorLogger {
 	return ColorLogger{
-		Color:       colorAttr,
-		sprintFunc:  color.New(colorAttr).SprintFunc(),
-		sprintfFunc: color.New(colorAttr).SprintfFunc(),
+		Color:      colorAttr,
+		Module:     module,
+		level:      level,
+		sprintFunc: color.New(colorAttr).SprintFunc(),
 	}
 }
 
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+	l.write(l.level, fmt.Sprint(v...))
 }
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+	l.write(l.level, sprintln(v...))
 }
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+	l.write(l.level, fmt.Sprintf(format, v...))
 }
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+	l.write(l.level, fmt.Sprintf(format, v...))
+}
+
+// Write values as a debug record
+func (l *ColorLogger) Debug(v ...interface{}) {
+	l.write(LevelDebug, sprintln(v...))
+}
+
+// Write a formatted string as a debug record
+func (l *ColorLogger) Debugf(format string, v ...interface{}) {
+	l.write(LevelDebug, fmt.Sprintf(format, v...))
+}
+
+// Write values as a warning record
+func (l *ColorLogger) Warn(v ...interface{}) {
+	l.write(LevelWarn, sprintln(v...))
+}
+
+// Write a formatted string as a warning record
+func (l *ColorLogger) Warnf(format string, v ...interface{}) {
+	l.write(LevelWarn, fmt.Sprintf(format, v...))
+}
+
+// Write values as an error record
+func (l *ColorLogger) Error(v ...interface{}) {
+	l.write(LevelError, sprintln(v...))
+}
+
+// Write a formatted string as an error record
+func (l *ColorLogger) Errorf(format string, v ...interface{}) {
+	l.write(LevelError, fmt.Sprintf(format, v...))
+}
+
+// Write a debug record with attributes
+func (l *ColorLogger) Debugw(message string, attrs ...Attr) {
+	l.write(LevelDebug, message, attrs...)
+}
+
+// Write an info record with attributes
+func (l *ColorLogger) Infow(message string, attrs ...Attr) {
+	l.write(LevelInfo, message, attrs...)
+}
+
+// Write a warning record with attributes
+func (l *ColorLogger) Warnw(message string, attrs ...Attr) {
+	l.write(LevelWarn, message, attrs...)
+}
+
+// Write an error record with attributes
+func (l *ColorLogger) Errorw(message string, attrs ...Attr) {
+	l.write(LevelError, message, attrs...)
+}
+
+// Get a copy of the logger that adds the attributes to every record it writes, such as the minipool a loop is working on
+func (l *ColorLogger) With(attrs ...Attr) ColorLogger {
+	logger := *l
+	logger.attrs = make([]Attr, 0, len(l.attrs)+len(attrs))
+	logger.attrs = append(logger.attrs, l.attrs...)
+	logger.attrs = append(logger.attrs, attrs...)
+	return logger
+}
+
+// Check if the logger's debug records are written, so expensive debug messages can be skipped
+func (l *ColorLogger) IsDebugEnabled() bool {
+	return IsEnabled(l.Module, LevelDebug)
+}
+
+// Write a record for the logger's module
+func (l *ColorLogger) write(level Level, message string, attrs ...Attr) {
+	if len(l.attrs) > 0 {
+		attrs = append(append([]Attr{}, l.attrs...), attrs...)
+	}
+	writeRecord(l.Module, level, message, attrs, l.sprintFunc)
+}
+
+// Format values the way Println does, without the trailing newline
+func sprintln(v ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(v...), "\n")
 }
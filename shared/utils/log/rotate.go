@@ -0,0 +1,98 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// A log file that's rotated once it reaches its maximum size. The old files are kept as path.1, path.2 and so on,
+// with path.1 being the most recent.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// Open a log file for appending, creating it and its folder if they don't exist.
+// A maximum size of 0 means it's never rotated.
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating log folder for %s: %w", path, err)
+	}
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write to the file, rotating it first if the write would take it past its maximum size
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close the file
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
+
+// Open the file and get its current size
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening log file %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error getting the size of log file %s: %w", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Move the file to path.1, shifting the older files up and dropping the oldest, then start a new one
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("error closing log file %s: %w", f.path, err)
+	}
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing log file %s: %w", f.path, err)
+		}
+		return f.open()
+	}
+
+	oldest := fmt.Sprintf("%s.%d", f.path, f.maxBackups)
+	if err := os.Remove(oldest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing old log file %s: %w", oldest, err)
+	}
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", f.path, i)
+		to := fmt.Sprintf("%s.%d", f.path, i+1)
+		if err := os.Rename(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error moving old log file %s: %w", from, err)
+		}
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error moving log file %s: %w", f.path, err)
+	}
+	return f.open()
+}
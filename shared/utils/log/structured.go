@@ -0,0 +1,304 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The module records are attributed to when they don't come from a module logger, such as the standard library's logger
+const DefaultModule string = "daemon"
+
+// The timestamp format of console records, which matches the standard library's logger
+const consoleTimeFormat string = "2006/01/02 15:04:05"
+
+// The severity of a log record
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Settings for the daemons' logs
+type Settings struct {
+	// The format records are written in
+	Format config.LogFormat
+
+	// The lowest severity that's written, unless the module has its own level
+	Level config.LogLevel
+
+	// The lowest severity that's written for specific modules
+	ModuleLevels map[string]config.LogLevel
+
+	// The file to write the logs to in addition to the console; blank to only write to the console
+	FilePath string
+
+	// The size in bytes the log file is rotated at
+	MaxFileSize int64
+
+	// How many rotated log files are kept
+	MaxFileBackups int
+}
+
+// A record in the JSON format
+type jsonRecord struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Module  string `json:"module"`
+	Message string `json:"msg"`
+}
+
+// A key/value pair attached to a record, such as the minipool or transaction it's about.
+// JSON records carry them as fields of their own and console records append them as key=value.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// Sends the standard library logger's output through the structured logger
+type stdLogWriter struct{}
+
+var logFormat = config.LogFormat_Console
+var logLevel = LevelInfo
+var logModuleLevels = map[string]Level{}
+var logConsole io.Writer = os.Stderr
+var logFile *rotatingFile
+var logLock sync.Mutex
+
+// Set the format, levels and file the logs are written with.
+// This also redirects the standard library's logger, so messages that don't come from a module logger are formatted the same way.
+func Configure(settings Settings) error {
+	level, err := ParseLevel(settings.Level)
+	if err != nil {
+		return err
+	}
+	moduleLevels := map[string]Level{}
+	for module, moduleLevel := range settings.ModuleLevels {
+		moduleLevels[module], err = ParseLevel(moduleLevel)
+		if err != nil {
+			return fmt.Errorf("invalid level for module %s: %w", module, err)
+		}
+	}
+	format := settings.Format
+	switch format {
+	case "":
+		format = config.LogFormat_Console
+	case config.LogFormat_Console, config.LogFormat_Json:
+	default:
+		return fmt.Errorf("unknown log format '%s'", format)
+	}
+
+	var file *rotatingFile
+	if settings.FilePath != "" {
+		file, err = openRotatingFile(settings.FilePath, settings.MaxFileSize, settings.MaxFileBackups)
+		if err != nil {
+			return err
+		}
+	}
+
+	logLock.Lock()
+	if logFile != nil {
+		logFile.Close()
+	}
+	logFormat = format
+	logLevel = level
+	logModuleLevels = moduleLevels
+	logFile = file
+	logLock.Unlock()
+
+	log.SetFlags(0)
+	log.SetOutput(stdLogWriter{})
+	return nil
+}
+
+// Parse a log level
+func ParseLevel(level config.LogLevel) (Level, error) {
+	switch level {
+	case config.LogLevel_Debug:
+		return LevelDebug, nil
+	case config.LogLevel_Info, "":
+		return LevelInfo, nil
+	case config.LogLevel_Warn:
+		return LevelWarn, nil
+	case config.LogLevel_Error:
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level '%s' (supported levels: debug, info, warn, error)", level)
+	}
+}
+
+// Parse a list of module levels in the format 'module=level', separated by commas
+func ParseModuleLevels(value string) (map[string]config.LogLevel, error) {
+	moduleLevels := map[string]config.LogLevel{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		elements := strings.SplitN(entry, "=", 2)
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid module level [%s]: expected the format 'module=level'", entry)
+		}
+		module := strings.TrimSpace(elements[0])
+		if module == "" {
+			return nil, fmt.Errorf("invalid module level [%s]: the module is blank", entry)
+		}
+		level := config.LogLevel(strings.ToLower(strings.TrimSpace(elements[1])))
+		if _, err := ParseLevel(level); err != nil {
+			return nil, fmt.Errorf("invalid module level [%s]: %w", entry, err)
+		}
+		moduleLevels[module] = level
+	}
+	return moduleLevels, nil
+}
+
+// Check if records of a severity are written for a module
+func IsEnabled(module string, level Level) bool {
+	logLock.Lock()
+	defer logLock.Unlock()
+	return isEnabled(module, level)
+}
+
+// Create an attribute
+func Any(key string, value interface{}) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Create an attribute for an error, recorded under the "error" key
+func Err(err error) Attr {
+	return Attr{Key: "error", Value: err}
+}
+
+// Get the name of a level as it appears in JSON records
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Write a record. The colorize function is only applied to console records written to the console, not to the log file.
+func writeRecord(module string, level Level, message string, attrs []Attr, colorize func(a ...interface{}) string) {
+	logLock.Lock()
+	defer logLock.Unlock()
+	if !isEnabled(module, level) {
+		return
+	}
+
+	// Warnings are marked by their level, so the prefix the older messages carry isn't repeated
+	if level == LevelWarn {
+		message = strings.TrimPrefix(message, "WARNING: ")
+	}
+	now := time.Now()
+
+	if logFormat == config.LogFormat_Json {
+		bytes, err := json.Marshal(jsonRecord{
+			Time:    now.Format(time.RFC3339Nano),
+			Level:   level.String(),
+			Module:  module,
+			Message: message,
+		})
+		if err != nil {
+			return
+		}
+		bytes = appendJsonAttrs(bytes, attrs)
+		bytes = append(bytes, '\n')
+		logConsole.Write(bytes)
+		if logFile != nil {
+			logFile.Write(bytes)
+		}
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		message = "DEBUG: " + message
+	case LevelWarn:
+		message = "WARNING: " + message
+	}
+	message += formatConsoleAttrs(attrs)
+	timestamp := now.Format(consoleTimeFormat)
+	consoleMessage := message
+	if colorize != nil {
+		consoleMessage = colorize(message)
+	}
+	fmt.Fprintf(logConsole, "%s %s\n", timestamp, consoleMessage)
+	if logFile != nil {
+		fmt.Fprintf(logFile, "%s %s\n", timestamp, message)
+	}
+}
+
+// Check if records of a severity are written for a module; the lock must be held
+func isEnabled(module string, level Level) bool {
+	if moduleLevel, exists := logModuleLevels[module]; exists {
+		return level >= moduleLevel
+	}
+	return level >= logLevel
+}
+
+// Add attributes to a serialized JSON record as fields of their own
+func appendJsonAttrs(record []byte, attrs []Attr) []byte {
+	if len(attrs) == 0 {
+		return record
+	}
+	record = record[:len(record)-1]
+	for _, attr := range attrs {
+		key, err := json.Marshal(attr.Key)
+		if err != nil {
+			continue
+		}
+		value, err := json.Marshal(attrValue(attr.Value))
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(attr.Value))
+		}
+		record = append(record, ',')
+		record = append(record, key...)
+		record = append(record, ':')
+		record = append(record, value...)
+	}
+	return append(record, '}')
+}
+
+// Format attributes as key=value pairs for a console record, quoting values with spaces
+func formatConsoleAttrs(attrs []Attr) string {
+	builder := strings.Builder{}
+	for _, attr := range attrs {
+		value := fmt.Sprint(attrValue(attr.Value))
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(&builder, " %s=%s", attr.Key, value)
+	}
+	return builder.String()
+}
+
+// Get the value an attribute is written as; errors are written as their message since they don't serialize on their own
+func attrValue(value interface{}) interface{} {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return value
+}
+
+// Write a standard library log message as an info record
+func (w stdLogWriter) Write(p []byte) (int, error) {
+	writeRecord(DefaultModule, LevelInfo, strings.TrimSuffix(string(p), "\n"), nil, nil)
+	return len(p), nil
+}
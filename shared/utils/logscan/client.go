@@ -0,0 +1,34 @@
+package logscan
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An Execution client whose getLogs queries are split up by a Scanner when the provider rejects them as too large.
+// This gives the fixed-interval scans in rocketpool-go, which go through the RocketPool binding's client, the same
+// adaptive chunking as the scans in the Smartnode.
+type Client struct {
+	rocketpool.ExecutionClient
+}
+
+// Wrap an Execution client so its getLogs queries are chunked adaptively
+func NewClient(client rocketpool.ExecutionClient) *Client {
+	if adaptiveClient, ok := client.(*Client); ok {
+		return adaptiveClient
+	}
+	return &Client{
+		ExecutionClient: client,
+	}
+}
+
+// Get the logs matching a query. Queries for a block hash or an open-ended or tagged range can't be split, so they're sent as-is.
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if query.BlockHash != nil || query.FromBlock == nil || query.ToBlock == nil || query.FromBlock.Sign() < 0 || query.ToBlock.Sign() < 0 {
+		return c.ExecutionClient.FilterLogs(ctx, query)
+	}
+	return NewScanner(c.ExecutionClient, 0).FilterLogs(query.Addresses, query.Topics, query.FromBlock.Uint64(), query.ToBlock.Uint64())
+}
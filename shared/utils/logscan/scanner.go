@@ -0,0 +1,250 @@
+package logscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	// How many disjoint block ranges are queried at the same time
+	maxConcurrentQueries int = 4
+
+	// The chunk size used when the configured interval is unlimited but the provider rejects the full range
+	defaultChunkSize uint64 = 10000
+
+	// The key used for clients that can't report which endpoint they're using
+	unknownEndpoint string = "default"
+
+	// The number of queries that have to succeed in a row before a learned chunk size is raised again
+	growthStreak int = 8
+)
+
+// Fragments of the errors providers return when a getLogs query covers too many blocks or results.
+// Every client and hosted provider words this differently, so they're matched loosely.
+var chunkTooLargeErrors = []string{
+	"query returned more than",
+	"too many",
+	"limit exceeded",
+	"block range",
+	"range too large",
+	"range is too large",
+	"response size",
+	"query timeout exceeded",
+	"exceed",
+}
+
+// The chunk size each endpoint had to be shrunk to, so later scans don't have to rediscover it, and the number of
+// queries that have succeeded in a row since, so the size can be raised again if the limit was only temporary
+var learnedChunkSizes = map[string]uint64{}
+var successStreaks = map[string]int{}
+var learnedChunkSizesLock sync.Mutex
+
+// An execution client that can report which endpoint its requests are currently routed to
+type providerReporter interface {
+	GetActiveProvider() string
+}
+
+// Scans the Execution layer for logs in chunks, shrinking them whenever the provider rejects a query as too large
+type Scanner struct {
+	client    rocketpool.ExecutionClient
+	chunkSize uint64
+}
+
+// Create a scanner that starts with the given chunk size, typically the configured event log interval.
+// A chunk size of 0 scans the whole range in one query unless the provider rejects it.
+func NewScanner(client rocketpool.ExecutionClient, chunkSize uint64) *Scanner {
+	// Query the wrapped client directly so the chunks aren't split up twice
+	if adaptiveClient, ok := client.(*Client); ok {
+		client = adaptiveClient.ExecutionClient
+	}
+	return &Scanner{
+		client:    client,
+		chunkSize: chunkSize,
+	}
+}
+
+// Get the logs matching the filter between two blocks (inclusive), in the order they were emitted.
+// If fromBlock is nil, the scan starts at the block Rocket Pool was deployed on; if toBlock is nil, it ends at the latest block.
+func GetLogs(rp *rocketpool.RocketPool, addresses []common.Address, topics [][]common.Hash, chunkSize uint64, fromBlock *big.Int, toBlock *big.Int) ([]types.Log, error) {
+	if fromBlock == nil {
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		}
+		fromBlock = deployBlock
+	}
+	if toBlock == nil {
+		latestBlock, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block: %w", err)
+		}
+		toBlock = big.NewInt(0).SetUint64(latestBlock)
+	}
+	return NewScanner(rp.Client, chunkSize).FilterLogs(addresses, topics, fromBlock.Uint64(), toBlock.Uint64())
+}
+
+// Get the logs matching the filter between two blocks (inclusive), in the order they were emitted
+func (s *Scanner) FilterLogs(addresses []common.Address, topics [][]common.Hash, fromBlock uint64, toBlock uint64) ([]types.Log, error) {
+	if fromBlock > toBlock {
+		return []types.Log{}, nil
+	}
+
+	// Split the range into chunks of the best known size
+	chunkSize := s.GetChunkSize()
+	ranges := [][2]uint64{}
+	for start := fromBlock; start <= toBlock; {
+		end := toBlock
+		if chunkSize > 0 && toBlock-start >= chunkSize {
+			end = start + chunkSize - 1
+		}
+		ranges = append(ranges, [2]uint64{start, end})
+		if end == toBlock {
+			break
+		}
+		start = end + 1
+	}
+
+	// Query the chunks in parallel; each one keeps its own results so they can be put back in order
+	results := make([][]types.Log, len(ranges))
+	var wg errgroup.Group
+	wg.SetLimit(maxConcurrentQueries)
+	for i, blockRange := range ranges {
+		i := i
+		blockRange := blockRange
+		wg.Go(func() error {
+			logs, err := s.filterRange(addresses, topics, blockRange[0], blockRange[1])
+			if err != nil {
+				return err
+			}
+			results[i] = logs
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	logs := []types.Log{}
+	for _, chunk := range results {
+		logs = append(logs, chunk...)
+	}
+	return logs, nil
+}
+
+// Query a single range, splitting it in half and remembering the smaller size if the provider says it's too large
+func (s *Scanner) filterRange(addresses []common.Address, topics [][]common.Hash, fromBlock uint64, toBlock uint64) ([]types.Log, error) {
+	logs, err := s.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: addresses,
+		Topics:    topics,
+		FromBlock: big.NewInt(0).SetUint64(fromBlock),
+		ToBlock:   big.NewInt(0).SetUint64(toBlock),
+	})
+	if err == nil {
+		s.recordSuccess()
+		return logs, nil
+	}
+	if !isChunkTooLarge(err) || fromBlock == toBlock {
+		return nil, fmt.Errorf("error getting logs for blocks %d to %d: %w", fromBlock, toBlock, err)
+	}
+
+	// Try again with two halves
+	size := toBlock - fromBlock + 1
+	if s.chunkSize == 0 && size > defaultChunkSize {
+		size = defaultChunkSize * 2
+	}
+	half := size / 2
+	s.learnChunkSize(half)
+	middle := fromBlock + half - 1
+	if middle >= toBlock {
+		middle = toBlock - 1
+	}
+	firstLogs, err := s.filterRange(addresses, topics, fromBlock, middle)
+	if err != nil {
+		return nil, err
+	}
+	secondLogs, err := s.filterRange(addresses, topics, middle+1, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	return append(firstLogs, secondLogs...), nil
+}
+
+// Get the chunk size scans start with, which is the smaller of the configured size and the one learned for the endpoint
+func (s *Scanner) GetChunkSize() uint64 {
+	learnedChunkSizesLock.Lock()
+	defer learnedChunkSizesLock.Unlock()
+	learned, exists := learnedChunkSizes[s.getEndpoint()]
+	if exists && (s.chunkSize == 0 || learned < s.chunkSize) {
+		return learned
+	}
+	return s.chunkSize
+}
+
+// Remember a smaller chunk size for the endpoint
+func (s *Scanner) learnChunkSize(size uint64) {
+	if size == 0 {
+		size = 1
+	}
+	learnedChunkSizesLock.Lock()
+	defer learnedChunkSizesLock.Unlock()
+	endpoint := s.getEndpoint()
+	if learned, exists := learnedChunkSizes[endpoint]; !exists || size < learned {
+		learnedChunkSizes[endpoint] = size
+	}
+	successStreaks[endpoint] = 0
+}
+
+// Double the endpoint's learned chunk size once enough queries have succeeded in a row, dropping it once it's back
+// up to the configured size
+func (s *Scanner) recordSuccess() {
+	learnedChunkSizesLock.Lock()
+	defer learnedChunkSizesLock.Unlock()
+	endpoint := s.getEndpoint()
+	learned, exists := learnedChunkSizes[endpoint]
+	if !exists {
+		return
+	}
+	successStreaks[endpoint]++
+	if successStreaks[endpoint] < growthStreak {
+		return
+	}
+	successStreaks[endpoint] = 0
+	learned *= 2
+	if s.chunkSize > 0 && learned >= s.chunkSize {
+		delete(learnedChunkSizes, endpoint)
+		return
+	}
+	learnedChunkSizes[endpoint] = learned
+}
+
+// Get the endpoint the client is using
+func (s *Scanner) getEndpoint() string {
+	if reporter, ok := s.client.(providerReporter); ok {
+		if provider := reporter.GetActiveProvider(); provider != "" {
+			return provider
+		}
+	}
+	return unknownEndpoint
+}
+
+// Check if an error means the query covered too many blocks or results
+func isChunkTooLarge(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range chunkTooLargeErrors {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
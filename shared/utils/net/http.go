@@ -0,0 +1,206 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	gonet "net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The outbound connections that can be routed through their own proxy
+const (
+	HttpTarget_ExecutionClient string = "ec"
+	HttpTarget_BeaconNode      string = "bn"
+	HttpTarget_Prices          string = "prices"
+	HttpTarget_GasOracles      string = "gas"
+	HttpTarget_Rewards         string = "rewards"
+	HttpTarget_Updates         string = "updates"
+	HttpTarget_Relays          string = "relays"
+	HttpTarget_Snapshot        string = "snapshot"
+	HttpTarget_Backup          string = "backup"
+	HttpTarget_Lookups         string = "lookups"
+)
+
+// All of the outbound connection targets
+var HttpTargets = []string{
+	HttpTarget_ExecutionClient,
+	HttpTarget_BeaconNode,
+	HttpTarget_Prices,
+	HttpTarget_GasOracles,
+	HttpTarget_Rewards,
+	HttpTarget_Updates,
+	HttpTarget_Relays,
+	HttpTarget_Snapshot,
+	HttpTarget_Backup,
+	HttpTarget_Lookups,
+}
+
+// An override that connects a target directly, bypassing the default proxy
+const HttpProxy_Direct string = "direct"
+
+// Settings for the outbound HTTP clients
+type HttpClientSettings struct {
+	// The proxy every target uses unless it has an override; blank to connect directly
+	Proxy string
+
+	// The IP version to connect with
+	IpMode config.IpMode
+
+	// Proxies for specific targets, or HttpProxy_Direct to bypass the default proxy
+	Overrides map[string]string
+}
+
+var httpSettings = HttpClientSettings{IpMode: config.IpMode_Auto}
+var httpClients = map[string]*http.Client{}
+var httpLock sync.Mutex
+
+// Set the proxy and IP settings the outbound HTTP clients use.
+// Clients that were already handed out keep their old settings.
+func ConfigureHttpClients(settings HttpClientSettings) error {
+	if settings.IpMode == "" {
+		settings.IpMode = config.IpMode_Auto
+	}
+	if err := ValidateProxy(settings.Proxy); err != nil {
+		return err
+	}
+	for target, proxy := range settings.Overrides {
+		if proxy == HttpProxy_Direct {
+			continue
+		}
+		if err := ValidateProxy(proxy); err != nil {
+			return fmt.Errorf("invalid proxy for %s: %w", target, err)
+		}
+	}
+
+	httpLock.Lock()
+	defer httpLock.Unlock()
+	httpSettings = settings
+	httpClients = map[string]*http.Client{}
+	return nil
+}
+
+// Get the HTTP client for an outbound connection target, honoring the configured proxy and IP settings
+func GetHttpClient(target string) *http.Client {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+
+	if client, exists := httpClients[target]; exists {
+		return client
+	}
+
+	// Get the proxy for the target
+	proxy := httpSettings.Proxy
+	if override, exists := httpSettings.Overrides[target]; exists {
+		proxy = override
+	}
+	if proxy == HttpProxy_Direct {
+		proxy = ""
+	}
+
+	// Targets without any special settings use the default client, which honors the proxy environment variables
+	client := http.DefaultClient
+	if proxy != "" || httpSettings.IpMode != config.IpMode_Auto {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if proxy != "" {
+			proxyUrl, _ := parseProxy(proxy)
+			transport.Proxy = http.ProxyURL(proxyUrl)
+		}
+		if network := getDialNetwork(httpSettings.IpMode); network != "tcp" {
+			dialer := &gonet.Dialer{}
+			transport.DialContext = func(ctx context.Context, _ string, address string) (gonet.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			}
+		}
+		client = &http.Client{Transport: transport}
+	}
+
+	httpClients[target] = client
+	return client
+}
+
+// Parse a list of proxy overrides in the format 'target=proxy', separated by commas
+func ParseProxyOverrides(value string) (map[string]string, error) {
+	overrides := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		elements := strings.SplitN(entry, "=", 2)
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid proxy override [%s]: expected the format 'target=proxy'", entry)
+		}
+		target := strings.TrimSpace(elements[0])
+		if !isHttpTarget(target) {
+			return nil, fmt.Errorf("invalid proxy override [%s]: unknown target '%s' (supported targets: %s)", entry, target, strings.Join(HttpTargets, ", "))
+		}
+		if _, exists := overrides[target]; exists {
+			return nil, fmt.Errorf("invalid proxy override [%s]: target '%s' has more than one override", entry, target)
+		}
+		proxy := strings.TrimSpace(elements[1])
+		if proxy != HttpProxy_Direct {
+			if err := ValidateProxy(proxy); err != nil {
+				return nil, fmt.Errorf("invalid proxy override [%s]: %w", entry, err)
+			}
+		}
+		overrides[target] = proxy
+	}
+	return overrides, nil
+}
+
+// Check that a proxy URL is supported; a blank one means no proxy
+func ValidateProxy(proxy string) error {
+	_, err := parseProxy(proxy)
+	return err
+}
+
+// Parse a proxy URL; returns nil if it's blank.
+// SOCKS5 proxies resolve hostnames themselves, so socks5h URLs are accepted as well.
+func parseProxy(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	proxyUrl, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL [%s]: %w", proxy, err)
+	}
+	switch proxyUrl.Scheme {
+	case "http", "https", "socks5":
+	case "socks5h":
+		proxyUrl.Scheme = "socks5"
+	default:
+		return nil, fmt.Errorf("invalid proxy URL [%s]: the scheme must be http, https, socks5, or socks5h", proxy)
+	}
+	if proxyUrl.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL [%s]: it doesn't have a host", proxy)
+	}
+	return proxyUrl, nil
+}
+
+// Get the network to dial for an IP mode
+func getDialNetwork(mode config.IpMode) string {
+	switch mode {
+	case config.IpMode_IPv4:
+		return "tcp4"
+	case config.IpMode_IPv6:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
+// Check if a target supports its own proxy
+func isHttpTarget(target string) bool {
+	for _, name := range HttpTargets {
+		if name == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package rp
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+const (
+	// The bond sizes involved in commission upgrades, in ETH
+	legacyBondSize float64 = 16
+	leb8BondSize   float64 = 8
+	fullDepositEth float64 = 32
+)
+
+type CommissionUpgradeType string
+
+const (
+	CommissionUpgradeType_ReduceBond        CommissionUpgradeType = "reduce-bond"
+	CommissionUpgradeType_DepositWithCredit CommissionUpgradeType = "deposit-with-credit"
+	CommissionUpgradeType_Deposit           CommissionUpgradeType = "deposit"
+)
+
+// An action the node can take that would increase the commission it earns from borrowed ETH
+type CommissionUpgrade struct {
+	Type                  CommissionUpgradeType `json:"type"`
+	MinipoolAddress       common.Address        `json:"minipoolAddress"`
+	Count                 uint64                `json:"count"`
+	CurrentCommission     float64               `json:"currentCommission"`
+	NewCommission         float64               `json:"newCommission"`
+	AdditionalBorrowedEth float64               `json:"additionalBorrowedEth"`
+	EthRequired           float64               `json:"ethRequired"`
+	AdditionalRplRequired *big.Int              `json:"additionalRplRequired"`
+	NewAverageCommission  float64               `json:"newAverageCommission"`
+
+	// The increase in borrowed ETH weighted by the commission earned on it; multiplying this by the staking APR gives the extra ETH earned per year
+	CommissionWeightedEthGain float64 `json:"commissionWeightedEthGain"`
+}
+
+// The node's current commission and the actions that would improve it
+type CommissionUpgradeReport struct {
+	AverageCommission    float64             `json:"averageCommission"`
+	NetworkCommission    float64             `json:"networkCommission"`
+	BorrowedEth          float64             `json:"borrowedEth"`
+	DepositCreditBalance *big.Int            `json:"depositCreditBalance"`
+	Upgrades             []CommissionUpgrade `json:"upgrades"`
+}
+
+// Find the bond reductions and new deposits that would increase the commission-weighted ETH a node earns on.
+// Each upgrade is quantified on its own against the node's current minipools.
+func GetCommissionUpgrades(state *state.NetworkState, nodeAddress common.Address) CommissionUpgradeReport {
+
+	report := CommissionUpgradeReport{
+		NetworkCommission:    state.NetworkDetails.NodeFee,
+		DepositCreditBalance: big.NewInt(0),
+		Upgrades:             []CommissionUpgrade{},
+	}
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists || !state.IsAtlasDeployed {
+		return report
+	}
+	if nodeDetails.DepositCreditBalance != nil {
+		report.DepositCreditBalance = nodeDetails.DepositCreditBalance
+	}
+
+	// Get the commission-weighted borrowed ETH across the node's active minipools
+	var weightedEth float64
+	minipools := state.MinipoolDetailsByNode[nodeAddress]
+	for _, mpd := range minipools {
+		if !isCommissionBearing(mpd.Status, mpd.Finalised) {
+			continue
+		}
+		borrowed := fullDepositEth - eth.WeiToEth(mpd.NodeDepositBalance)
+		report.BorrowedEth += borrowed
+		weightedEth += eth.WeiToEth(mpd.NodeFee) * borrowed
+	}
+	if report.BorrowedEth > 0 {
+		report.AverageCommission = weightedEth / report.BorrowedEth
+	}
+
+	// Quantify an upgrade that adds borrowed ETH at a given commission, replacing the commission on the ETH it already borrows
+	networkFee := state.NetworkDetails.NodeFee
+	newUpgrade := func(upgradeType CommissionUpgradeType, currentCommission float64, currentBorrowed float64, newCommission float64, newBorrowed float64, ethRequired float64) CommissionUpgrade {
+		gain := newCommission*newBorrowed - currentCommission*currentBorrowed
+		additionalBorrowed := newBorrowed - currentBorrowed
+		return CommissionUpgrade{
+			Type:                      upgradeType,
+			Count:                     1,
+			CurrentCommission:         currentCommission,
+			NewCommission:             newCommission,
+			AdditionalBorrowedEth:     additionalBorrowed,
+			EthRequired:               ethRequired,
+			AdditionalRplRequired:     getAdditionalRplRequired(state, nodeDetails, additionalBorrowed),
+			NewAverageCommission:      (weightedEth + gain) / (report.BorrowedEth + additionalBorrowed),
+			CommissionWeightedEthGain: gain,
+		}
+	}
+
+	// Reducing a 16 ETH bond to 8 borrows another 8 ETH, and raises the commission to the network's current rate if it's higher
+	for _, mpd := range minipools {
+		if mpd.Status != types.Staking || mpd.Finalised || mpd.IsVacant || eth.WeiToEth(mpd.NodeDepositBalance) != legacyBondSize {
+			continue
+		}
+		if isBondReductionUnderway(mpd.ReduceBondTime, mpd.ReduceBondCancelled) {
+			// Already underway
+			continue
+		}
+		currentFee := eth.WeiToEth(mpd.NodeFee)
+		newFee := currentFee
+		if networkFee > newFee {
+			newFee = networkFee
+		}
+		upgrade := newUpgrade(CommissionUpgradeType_ReduceBond, currentFee, fullDepositEth-legacyBondSize, newFee, fullDepositEth-leb8BondSize, 0)
+		upgrade.MinipoolAddress = mpd.MinipoolAddress
+		report.Upgrades = append(report.Upgrades, upgrade)
+	}
+
+	// New 8 ETH minipools only improve the average if the network's current rate is above it
+	if networkFee <= report.AverageCommission && report.BorrowedEth > 0 {
+		return report
+	}
+	creditMinipools := new(big.Int).Div(report.DepositCreditBalance, eth.EthToWei(leb8BondSize)).Uint64()
+	if creditMinipools > 0 {
+		upgrade := newUpgrade(CommissionUpgradeType_DepositWithCredit, 0, 0, networkFee, fullDepositEth-leb8BondSize, 0)
+		upgrade.Count = creditMinipools
+		report.Upgrades = append(report.Upgrades, upgrade)
+	}
+	report.Upgrades = append(report.Upgrades, newUpgrade(CommissionUpgradeType_Deposit, 0, 0, networkFee, fullDepositEth-leb8BondSize, leb8BondSize))
+
+	return report
+
+}
+
+// Check if a minipool is earning (or about to earn) commission on its borrowed ETH
+func isCommissionBearing(status types.MinipoolStatus, finalised bool) bool {
+	if finalised {
+		return false
+	}
+	switch status {
+	case types.Initialized, types.Prelaunch, types.Staking:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check if a minipool has a bond reduction in progress
+func isBondReductionUnderway(reduceBondTime *big.Int, cancelled bool) bool {
+	return reduceBondTime != nil && reduceBondTime.Sign() > 0 && !cancelled
+}
+
+// Get how much more RPL the node would need to stake to stay above the minimum collateral after borrowing more ETH
+func getAdditionalRplRequired(state *state.NetworkState, nodeDetails *rpstate.NativeNodeDetails, additionalBorrowedEth float64) *big.Int {
+	rplPrice := state.NetworkDetails.RplPrice
+	if rplPrice == nil || rplPrice.Sign() == 0 || nodeDetails.EthMatched == nil || nodeDetails.RplStake == nil {
+		return big.NewInt(0)
+	}
+
+	// minimum stake = matched ETH * minimum collateral fraction / RPL price
+	matched := new(big.Int).Add(nodeDetails.EthMatched, eth.EthToWei(additionalBorrowedEth))
+	minimumStake := new(big.Int).Mul(matched, state.NetworkDetails.MinCollateralFraction)
+	minimumStake.Div(minimumStake, rplPrice)
+
+	shortfall := minimumStake.Sub(minimumStake, nodeDetails.RplStake)
+	if shortfall.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return shortfall
+}
@@ -6,12 +6,17 @@ import (
 	"path/filepath"
 
 	"github.com/alessio/shellescape"
+	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
 	"gopkg.in/yaml.v2"
 )
 
 const (
 	upgradeFlagFile string = ".firstrun"
+
+	// The copy of the settings from before the last upgrade, which rollbacks restore
+	BackupSettingsFile string = "user-settings-backup.yml"
 )
 
 // Loads a config without updating it if it exists
@@ -38,6 +43,11 @@ func SaveConfig(cfg *config.RocketPoolConfig, path string) error {
 		return fmt.Errorf("could not serialize settings file: %w", err)
 	}
 
+	// Keep the settings written by the previous release so the upgrade can be rolled back
+	if err := backupConfigBeforeUpgrade(path); err != nil {
+		return err
+	}
+
 	if err := os.WriteFile(path, configBytes, 0664); err != nil {
 		return fmt.Errorf("could not write Rocket Pool config to %s: %w", shellescape.Quote(path), err)
 	}
@@ -46,6 +56,68 @@ func SaveConfig(cfg *config.RocketPoolConfig, path string) error {
 
 }
 
+// Copy the settings file to the backup file if it was written by a different release or schema than the current one
+func backupConfigBeforeUpgrade(path string) error {
+	configBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
+	}
+
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		// Don't keep a broken file as the rollback target
+		return nil
+	}
+	root := settings["root"]
+	if root["version"] == fmt.Sprintf("v%s", shared.RocketPoolVersion) && root[migration.SchemaVersionKey] == fmt.Sprint(migration.GetCurrentSchemaVersion()) {
+		return nil
+	}
+
+	backupPath := filepath.Join(filepath.Dir(path), BackupSettingsFile)
+	if err := os.WriteFile(backupPath, configBytes, 0664); err != nil {
+		return fmt.Errorf("could not back up Rocket Pool config to %s: %w", shellescape.Quote(backupPath), err)
+	}
+	return nil
+}
+
+// Restore the settings file from before the last upgrade.
+// The current settings are swapped into the backup file, so the rollback can itself be undone.
+// Returns the Smartnode version that wrote the restored settings.
+func RollbackConfig(path string) (string, error) {
+	backupPath := filepath.Join(filepath.Dir(path), BackupSettingsFile)
+	backupBytes, err := os.ReadFile(backupPath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("there is no backup of your previous settings at %s to roll back to", shellescape.Quote(backupPath))
+	} else if err != nil {
+		return "", fmt.Errorf("could not read backup settings file at %s: %w", shellescape.Quote(backupPath), err)
+	}
+	currentBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
+	}
+
+	// Make sure the backup is usable before replacing anything
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(backupBytes, &settings); err != nil {
+		return "", fmt.Errorf("the backup settings file is not valid: %w", err)
+	}
+	backupCfg := config.NewRocketPoolConfig(filepath.Dir(path), false)
+	if err := backupCfg.Deserialize(settings); err != nil {
+		return "", fmt.Errorf("the backup settings file could not be loaded: %w", err)
+	}
+
+	// Swap the files
+	if err := os.WriteFile(path, backupBytes, 0664); err != nil {
+		return "", fmt.Errorf("could not write Rocket Pool config to %s: %w", shellescape.Quote(path), err)
+	}
+	if err := os.WriteFile(backupPath, currentBytes, 0664); err != nil {
+		return "", fmt.Errorf("the previous settings were restored, but the current ones could not be saved to %s: %w", shellescape.Quote(backupPath), err)
+	}
+	return backupCfg.Version, nil
+}
+
 // Checks if this is the first run of the configurator after an install
 func IsFirstRun(configDir string) bool {
 	upgradeFilePath := filepath.Join(configDir, upgradeFlagFile)
@@ -0,0 +1,72 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+)
+
+// How far back to look for deposits when estimating the Deposit Pool's inflow rate
+const DepositInflowWindow time.Duration = 7 * 24 * time.Hour
+
+// Estimate how much ETH is deposited into the Deposit Pool per day, from the deposits it received over the window.
+// The deposits are read from the contract's event logs, so this works without an archive node.
+func GetRecentDepositInflowRate(rp *rocketpool.RocketPool, currentBlock uint64) (float64, error) {
+
+	// Get the deposit pool's DepositReceived event
+	contract, err := rp.GetContract("rocketDepositPool", nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting deposit pool contract: %w", err)
+	}
+	event, exists := contract.ABI.Events["DepositReceived"]
+	if !exists {
+		return 0, fmt.Errorf("the deposit pool contract doesn't have a DepositReceived event")
+	}
+
+	// Get the deposits in the window
+	windowBlocks := uint64(DepositInflowWindow / elBlockTime)
+	fromBlock := uint64(0)
+	if currentBlock > windowBlocks {
+		fromBlock = currentBlock - windowBlocks
+	}
+	logs, err := logscan.NewScanner(rp.Client, 0).FilterLogs([]common.Address{*contract.Address}, [][]common.Hash{{event.ID}}, fromBlock, currentBlock)
+	if err != nil {
+		return 0, fmt.Errorf("error getting deposits: %w", err)
+	}
+	total := big.NewInt(0)
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		if err := contract.ABI.UnpackIntoMap(values, event.Name, log.Data); err != nil {
+			return 0, fmt.Errorf("error decoding deposit in block %d: %w", log.BlockNumber, err)
+		}
+		amount, ok := values["amount"].(*big.Int)
+		if !ok {
+			return 0, fmt.Errorf("deposit in block %d is missing its amount", log.BlockNumber)
+		}
+		total.Add(total, amount)
+	}
+
+	// Get the time the window actually covers
+	fromHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(fromBlock))
+	if err != nil {
+		return 0, fmt.Errorf("error getting header for block %d: %w", fromBlock, err)
+	}
+	toHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(currentBlock))
+	if err != nil {
+		return 0, fmt.Errorf("error getting header for block %d: %w", currentBlock, err)
+	}
+	elapsed := time.Duration(toHeader.Time-fromHeader.Time) * time.Second
+	if elapsed <= 0 {
+		return 0, fmt.Errorf("the deposit window doesn't cover any time")
+	}
+
+	return eth.WeiToEth(total) / (elapsed.Hours() / 24), nil
+
+}
@@ -0,0 +1,140 @@
+package rp
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+type ExitRecommendation string
+
+const (
+	ExitRecommendation_Keep       ExitRecommendation = "keep"
+	ExitRecommendation_ReduceBond ExitRecommendation = "reduce-bond"
+	ExitRecommendation_Redeploy   ExitRecommendation = "exit-and-redeploy"
+)
+
+// The expected return of keeping a minipool running, compared to exiting it and redeploying its ETH into new 8 ETH minipools.
+// Returns are expressed in ETH earned per year for every 1% of staking APR, so they can be scaled by any APR estimate.
+type MinipoolExitAdvice struct {
+	MinipoolAddress    common.Address     `json:"minipoolAddress"`
+	ValidatorIndex     uint64             `json:"validatorIndex"`
+	BondEth            float64            `json:"bondEth"`
+	Commission         float64            `json:"commission"`
+	ValidatorBalance   float64            `json:"validatorBalance"`
+	RecoverableEth     float64            `json:"recoverableEth"`
+	RedeployMinipools  uint64             `json:"redeployMinipools"`
+	RedeployCommission float64            `json:"redeployCommission"`
+	KeepReturn         float64            `json:"keepReturn"`
+	RedeployReturn     float64            `json:"redeployReturn"`
+	MarginalReturn     float64            `json:"marginalReturn"`
+	UnusedEth          float64            `json:"unusedEth"`
+	AdditionalBorrowed float64            `json:"additionalBorrowed"`
+	AdditionalRpl      *big.Int           `json:"additionalRpl"`
+	Recommendation     ExitRecommendation `json:"recommendation"`
+}
+
+// The node's minipools ranked by how much more they would earn if their ETH was redeployed
+type ExitAdviceReport struct {
+	NetworkCommission float64              `json:"networkCommission"`
+	RplStake          *big.Int             `json:"rplStake"`
+	MinimumRplStake   *big.Int             `json:"minimumRplStake"`
+	Minipools         []MinipoolExitAdvice `json:"minipools"`
+}
+
+// Estimate the marginal return of exiting each of the node's staking minipools and redeploying the ETH it returns.
+// Each minipool is compared on its own against the node's current minipools, and the list is sorted with the most
+// profitable exits first.
+func GetExitAdvice(state *state.NetworkState, nodeAddress common.Address) ExitAdviceReport {
+
+	report := ExitAdviceReport{
+		NetworkCommission: state.NetworkDetails.NodeFee,
+		RplStake:          big.NewInt(0),
+		MinimumRplStake:   big.NewInt(0),
+		Minipools:         []MinipoolExitAdvice{},
+	}
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return report
+	}
+	if nodeDetails.RplStake != nil {
+		report.RplStake = nodeDetails.RplStake
+	}
+	if nodeDetails.MinimumRPLStake != nil {
+		report.MinimumRplStake = nodeDetails.MinimumRPLStake
+	}
+
+	networkFee := state.NetworkDetails.NodeFee
+	redeployBorrowed := fullDepositEth - leb8BondSize
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Status != types.Staking || mpd.Finalised || mpd.IsVacant {
+			continue
+		}
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || validator.Status != beacon.ValidatorState_ActiveOngoing {
+			continue
+		}
+
+		bond := eth.WeiToEth(mpd.NodeDepositBalance)
+		borrowed := fullDepositEth - bond
+		commission := eth.WeiToEth(mpd.NodeFee)
+		balance := eth.WeiToEth(eth.GweiToWei(float64(validator.Balance)))
+
+		// The pool is repaid first, so any penalties come out of the node's bond
+		recoverable := math.Max(balance-eth.WeiToEth(mpd.UserDepositBalance), 0)
+		if mpd.NodeRefundBalance != nil {
+			recoverable += eth.WeiToEth(mpd.NodeRefundBalance)
+		}
+		redeployCount := uint64(recoverable / leb8BondSize)
+
+		// The node earns the full reward on its bond, plus its commission on the borrowed ETH
+		advice := MinipoolExitAdvice{
+			MinipoolAddress:    mpd.MinipoolAddress,
+			ValidatorIndex:     validator.Index,
+			BondEth:            bond,
+			Commission:         commission,
+			ValidatorBalance:   balance,
+			RecoverableEth:     recoverable,
+			RedeployMinipools:  redeployCount,
+			RedeployCommission: networkFee,
+			KeepReturn:         (bond + commission*borrowed) * 0.01,
+			RedeployReturn:     float64(redeployCount) * (leb8BondSize + networkFee*redeployBorrowed) * 0.01,
+			UnusedEth:          recoverable - float64(redeployCount)*leb8BondSize,
+			AdditionalRpl:      big.NewInt(0),
+		}
+		advice.MarginalReturn = advice.RedeployReturn - advice.KeepReturn
+
+		// Borrowing more ETH raises the node's minimum RPL collateral
+		advice.AdditionalBorrowed = float64(redeployCount)*redeployBorrowed - borrowed
+		if advice.AdditionalBorrowed > 0 {
+			advice.AdditionalRpl = getAdditionalRplRequired(state, nodeDetails, advice.AdditionalBorrowed)
+		}
+
+		// A 16 ETH minipool can borrow the extra ETH by reducing its bond instead, without leaving the validator set
+		switch {
+		case advice.MarginalReturn <= 0:
+			advice.Recommendation = ExitRecommendation_Keep
+		case bond == legacyBondSize && state.IsAtlasDeployed:
+			if isBondReductionUnderway(mpd.ReduceBondTime, mpd.ReduceBondCancelled) {
+				advice.Recommendation = ExitRecommendation_Keep
+			} else {
+				advice.Recommendation = ExitRecommendation_ReduceBond
+			}
+		default:
+			advice.Recommendation = ExitRecommendation_Redeploy
+		}
+		report.Minipools = append(report.Minipools, advice)
+	}
+
+	sort.SliceStable(report.Minipools, func(i, j int) bool {
+		return report.Minipools[i].MarginalReturn > report.Minipools[j].MarginalReturn
+	})
+	return report
+
+}
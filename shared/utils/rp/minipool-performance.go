@@ -0,0 +1,215 @@
+package rp
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The default number of epochs to score the minipools over, about 8 hours
+const DefaultPerformanceEpochs uint64 = 75
+
+// How well a minipool's validator performed its duties over a window of epochs.
+// The score is its attestation rate relative to the network's, so 100 is the network average.
+type MinipoolPerformance struct {
+	MinipoolAddress   common.Address `json:"minipoolAddress"`
+	ValidatorIndex    uint64         `json:"validatorIndex"`
+	AttestationDuties uint64         `json:"attestationDuties"`
+	Attestations      uint64         `json:"attestations"`
+	AttestationRate   float64        `json:"attestationRate"`
+	ProposalDuties    uint64         `json:"proposalDuties"`
+	Proposals         uint64         `json:"proposals"`
+	Score             float64        `json:"score"`
+}
+
+// The performance of the node's minipools compared to the whole Beacon Chain
+type MinipoolPerformanceReport struct {
+	StartEpoch               uint64                `json:"startEpoch"`
+	EndEpoch                 uint64                `json:"endEpoch"`
+	NetworkAttestationDuties uint64                `json:"networkAttestationDuties"`
+	NetworkAttestations      uint64                `json:"networkAttestations"`
+	NetworkAttestationRate   float64               `json:"networkAttestationRate"`
+	NetworkSlots             uint64                `json:"networkSlots"`
+	NetworkProposals         uint64                `json:"networkProposals"`
+	NetworkProposalRate      float64               `json:"networkProposalRate"`
+	Minipools                []MinipoolPerformance `json:"minipools"`
+}
+
+// A committee's members and which of them have had their attestation included
+type committeeAttestations struct {
+	validators []uint64
+	attested   []bool
+}
+
+// Score the attestations and proposals of the node's validators over the given number of epochs against the rest of the network.
+// The window ends two epochs before the state's slot, so every attestation in it has had the chance to be included.
+// Every block and committee in the window is read from the Beacon node, so this can take a few minutes for long windows.
+func GetMinipoolPerformance(bc beacon.Client, state *state.NetworkState, nodeAddress common.Address, epochs uint64) (MinipoolPerformanceReport, error) {
+
+	report := MinipoolPerformanceReport{
+		Minipools: []MinipoolPerformance{},
+	}
+	slotsPerEpoch := state.BeaconConfig.SlotsPerEpoch
+	currentEpoch := state.BeaconSlotNumber / slotsPerEpoch
+	if epochs == 0 || currentEpoch < epochs+1 {
+		return report, fmt.Errorf("the chain doesn't have %d complete epochs to score yet", epochs)
+	}
+	report.EndEpoch = currentEpoch - 2
+	report.StartEpoch = report.EndEpoch + 1 - epochs
+
+	// Map the node's validators to their minipools
+	performance := map[uint64]*MinipoolPerformance{}
+	indices := []uint64{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		performance[validator.Index] = &MinipoolPerformance{
+			MinipoolAddress: mpd.MinipoolAddress,
+			ValidatorIndex:  validator.Index,
+		}
+		indices = append(indices, validator.Index)
+	}
+
+	// Attestations can be included until the end of the epoch after their slot, so the epoch after the window is read too
+	duties := map[uint64]map[uint64]*committeeAttestations{}
+	for epoch := report.StartEpoch; epoch <= report.EndEpoch+1; epoch++ {
+		inWindow := epoch <= report.EndEpoch
+
+		// Get the committees and blocks for the epoch
+		var committees []beacon.Committee
+		blocks := make([]beacon.BeaconBlock, slotsPerEpoch)
+		found := make([]bool, slotsPerEpoch)
+		var wg errgroup.Group
+		if inWindow {
+			wg.Go(func() error {
+				var err error
+				committees, err = bc.GetCommitteesForEpoch(&epoch)
+				return err
+			})
+		}
+		for i := uint64(0); i < slotsPerEpoch; i++ {
+			i := i
+			slot := epoch*slotsPerEpoch + i
+			wg.Go(func() error {
+				var err error
+				blocks[i], found[i], err = bc.GetBeaconBlock(fmt.Sprint(slot))
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return report, fmt.Errorf("error getting the committees and blocks for epoch %d: %w", epoch, err)
+		}
+
+		// Record the attestation duties in the epoch
+		for _, committee := range committees {
+			slotDuties, exists := duties[committee.Slot]
+			if !exists {
+				slotDuties = map[uint64]*committeeAttestations{}
+				duties[committee.Slot] = slotDuties
+			}
+			slotDuties[committee.Index] = &committeeAttestations{
+				validators: committee.Validators,
+				attested:   make([]bool, len(committee.Validators)),
+			}
+			report.NetworkAttestationDuties += uint64(len(committee.Validators))
+			for _, validatorIndex := range committee.Validators {
+				if minipool, exists := performance[validatorIndex]; exists {
+					minipool.AttestationDuties++
+				}
+			}
+		}
+
+		// Check the blocks for proposals and the attestations they include
+		proposalsInEpoch := map[uint64]uint64{}
+		missedSlots := false
+		for i := uint64(0); i < slotsPerEpoch; i++ {
+			if inWindow {
+				report.NetworkSlots++
+			}
+			if !found[i] {
+				missedSlots = missedSlots || inWindow
+				continue
+			}
+			block := blocks[i]
+			if inWindow {
+				report.NetworkProposals++
+				if minipool, exists := performance[block.ProposerIndex]; exists {
+					minipool.Proposals++
+					minipool.ProposalDuties++
+					proposalsInEpoch[block.ProposerIndex]++
+				}
+			}
+			for _, attestation := range block.Attestations {
+				committee, exists := duties[attestation.SlotIndex][attestation.CommitteeIndex]
+				if !exists {
+					continue
+				}
+				for position, validatorIndex := range committee.validators {
+					if committee.attested[position] || !attestation.AggregationBits.BitAt(uint64(position)) {
+						continue
+					}
+					committee.attested[position] = true
+					report.NetworkAttestations++
+					if minipool, exists := performance[validatorIndex]; exists {
+						minipool.Attestations++
+					}
+				}
+			}
+		}
+
+		// Any proposals the node's validators were assigned beyond the ones they made were missed
+		if missedSlots && len(indices) > 0 {
+			proposerDuties, err := bc.GetValidatorProposerDuties(indices, epoch)
+			if err != nil {
+				return report, fmt.Errorf("error getting proposer duties for epoch %d: %w", epoch, err)
+			}
+			for validatorIndex, count := range proposerDuties {
+				if count > proposalsInEpoch[validatorIndex] {
+					performance[validatorIndex].ProposalDuties += count - proposalsInEpoch[validatorIndex]
+				}
+			}
+		}
+
+		// The previous epoch's attestations can't be included any later than this one
+		for slot := range duties {
+			if slot < epoch*slotsPerEpoch {
+				delete(duties, slot)
+			}
+		}
+	}
+
+	// Score the minipools that had duties against the network
+	if report.NetworkAttestationDuties > 0 {
+		report.NetworkAttestationRate = float64(report.NetworkAttestations) / float64(report.NetworkAttestationDuties)
+	}
+	if report.NetworkSlots > 0 {
+		report.NetworkProposalRate = float64(report.NetworkProposals) / float64(report.NetworkSlots)
+	}
+	for _, minipool := range performance {
+		if minipool.AttestationDuties == 0 {
+			continue
+		}
+		minipool.AttestationRate = float64(minipool.Attestations) / float64(minipool.AttestationDuties)
+		if report.NetworkAttestationRate > 0 {
+			minipool.Score = minipool.AttestationRate / report.NetworkAttestationRate * 100
+		}
+		report.Minipools = append(report.Minipools, *minipool)
+	}
+
+	// Show the weakest minipools first
+	sort.SliceStable(report.Minipools, func(i, j int) bool {
+		if report.Minipools[i].Score != report.Minipools[j].Score {
+			return report.Minipools[i].Score < report.Minipools[j].Score
+		}
+		return report.Minipools[i].ValidatorIndex < report.Minipools[j].ValidatorIndex
+	})
+	return report, nil
+
+}
@@ -0,0 +1,122 @@
+package rp
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+)
+
+// Check if the deployed contracts support a separate RPL withdrawal address
+func IsRplWithdrawalAddressSupported(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", opts)
+	if err != nil {
+		return false, err
+	}
+	_, exists := rocketNodeManager.ABI.Methods["setRPLWithdrawalAddress"]
+	return exists, nil
+}
+
+// Get the RPL withdrawal address a node is waiting to have confirmed; returns the zero address if there isn't one
+func GetNodePendingRplWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if _, exists := rocketNodeManager.ABI.Methods["getNodePendingRPLWithdrawalAddress"]; !exists {
+		return common.Address{}, nil
+	}
+	address := new(common.Address)
+	if err := rocketNodeManager.Call(opts, address, "getNodePendingRPLWithdrawalAddress", nodeAddress); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get the pending RPL withdrawal address of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *address, nil
+}
+
+// Get the address that's allowed to change a node's RPL withdrawal address.
+// That's the RPL withdrawal address itself once it's set, and the node's primary withdrawal address before then.
+func GetRplWithdrawalAddressAuthority(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	rplWithdrawalAddress, isSet, err := GetNodeRplWithdrawalAddress(rp, nodeAddress, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if isSet {
+		return rplWithdrawalAddress, nil
+	}
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAddress, opts)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Could not get the withdrawal address of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return withdrawalAddress, nil
+}
+
+// Estimate the gas of setting a node's RPL withdrawal address
+func EstimateSetRplWithdrawalAddressGas(rp *rocketpool.RocketPool, nodeAddress common.Address, rplWithdrawalAddress common.Address, confirm bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeManager.GetTransactionGasInfo(opts, "setRPLWithdrawalAddress", nodeAddress, rplWithdrawalAddress, confirm)
+}
+
+// Set a node's RPL withdrawal address.
+// If confirm is false, the new address is pending until it confirms the change itself.
+func SetRplWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, rplWithdrawalAddress common.Address, confirm bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeManager.Transact(opts, "setRPLWithdrawalAddress", nodeAddress, rplWithdrawalAddress, confirm)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not set the RPL withdrawal address of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of confirming a node's pending RPL withdrawal address
+func EstimateConfirmRplWithdrawalAddressGas(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeManager.GetTransactionGasInfo(opts, "confirmRPLWithdrawalAddress", nodeAddress)
+}
+
+// Confirm a node's pending RPL withdrawal address; this must be sent from the pending address
+func ConfirmRplWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeManager.Transact(opts, "confirmRPLWithdrawalAddress", nodeAddress)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not confirm the RPL withdrawal address of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the contract address and calldata of a transaction that sets a node's RPL withdrawal address, so it can be sent
+// from a wallet other than the node's
+func GetSetRplWithdrawalAddressCalldata(rp *rocketpool.RocketPool, nodeAddress common.Address, rplWithdrawalAddress common.Address, confirm bool) (common.Address, []byte, error) {
+	return packNodeManagerCall(rp, "setRPLWithdrawalAddress", nodeAddress, rplWithdrawalAddress, confirm)
+}
+
+// Get the contract address and calldata of the transaction a pending RPL withdrawal address sends to confirm itself
+func GetConfirmRplWithdrawalAddressCalldata(rp *rocketpool.RocketPool, nodeAddress common.Address) (common.Address, []byte, error) {
+	return packNodeManagerCall(rp, "confirmRPLWithdrawalAddress", nodeAddress)
+}
+
+// Get the address of the node manager and the calldata for calling one of its methods
+func packNodeManagerCall(rp *rocketpool.RocketPool, method string, params ...interface{}) (common.Address, []byte, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	data, err := rocketNodeManager.ABI.Pack(method, params...)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("Could not encode the %s call: %w", method, err)
+	}
+	return *rocketNodeManager.Address, data, nil
+}
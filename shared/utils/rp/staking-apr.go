@@ -0,0 +1,150 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/utils/logscan"
+)
+
+// Settings
+const (
+	// How far back to look for rETH balance updates when estimating the staking APR
+	StakingAprWindow time.Duration = 7 * 24 * time.Hour
+
+	// The average time between EL blocks, used to find the block at the start of the window
+	elBlockTime time.Duration = 12 * time.Second
+)
+
+// A point on the rETH exchange rate history, taken from a balances update by the Oracle DAO
+type rethBalancesUpdate struct {
+	blockNumber uint64
+	totalEth    *big.Int
+	stakingEth  *big.Int
+	rethSupply  *big.Int
+}
+
+// Estimate the recent Beacon chain staking APR, as a fraction, from the growth of the rETH exchange rate.
+// rETH only earns rewards on the ETH that's staking, minus the node commission, so the rate's growth is scaled back up by both.
+// The balances updates are read from the contract's event logs, so this works without an archive node.
+func GetRecentStakingApr(rp *rocketpool.RocketPool, currentBlock uint64, nodeFee float64) (float64, error) {
+	rethApr, stakingShare, err := getRecentRethGrowth(rp, currentBlock)
+	if err != nil {
+		return 0, err
+	}
+
+	// Scale it back up to what the validators themselves earned
+	if nodeFee >= 1 {
+		return 0, fmt.Errorf("invalid node fee %f", nodeFee)
+	}
+	return rethApr / (stakingShare * (1 - nodeFee)), nil
+}
+
+// Estimate the recent APR of holding rETH, as a fraction, from the growth of its exchange rate
+func GetRecentRethApr(rp *rocketpool.RocketPool, currentBlock uint64) (float64, error) {
+	rethApr, _, err := getRecentRethGrowth(rp, currentBlock)
+	return rethApr, err
+}
+
+// Get the annualized growth of the rETH exchange rate over the window, and the share of the ETH backing rETH that's staking
+func getRecentRethGrowth(rp *rocketpool.RocketPool, currentBlock uint64) (float64, float64, error) {
+
+	// Get the balances updates in the window
+	windowBlocks := uint64(StakingAprWindow / elBlockTime)
+	fromBlock := uint64(0)
+	if currentBlock > windowBlocks {
+		fromBlock = currentBlock - windowBlocks
+	}
+	updates, err := getRethBalancesUpdates(rp, fromBlock, currentBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(updates) < 2 {
+		return 0, 0, fmt.Errorf("there weren't enough rETH balance updates in the last %s to estimate the staking APR", StakingAprWindow)
+	}
+	first := updates[0]
+	last := updates[len(updates)-1]
+	if first.rethSupply.Sign() == 0 || last.rethSupply.Sign() == 0 || last.totalEth.Sign() == 0 || last.stakingEth.Sign() == 0 {
+		return 0, 0, fmt.Errorf("the rETH balance updates are empty")
+	}
+
+	// Get the time between the first and last update
+	firstHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(first.blockNumber))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting header for block %d: %w", first.blockNumber, err)
+	}
+	lastHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(last.blockNumber))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting header for block %d: %w", last.blockNumber, err)
+	}
+	elapsed := time.Duration(lastHeader.Time-firstHeader.Time) * time.Second
+	if elapsed <= 0 {
+		return 0, 0, fmt.Errorf("the rETH balance updates were in the same block")
+	}
+
+	// Annualize the growth of the exchange rate
+	firstRate := getRatio(first.totalEth, first.rethSupply)
+	lastRate := getRatio(last.totalEth, last.rethSupply)
+	yearFraction := elapsed.Hours() / (24 * 365)
+	rethApr := math.Pow(lastRate/firstRate, 1/yearFraction) - 1
+	stakingShare := getRatio(last.stakingEth, last.totalEth)
+	return rethApr, stakingShare, nil
+
+}
+
+// Get the projected ETH a minipool will earn for the node in a year at the given staking APR.
+// The node earns the full reward on its bond, plus its commission on the ETH borrowed from the staking pool.
+func GetProjectedAnnualIncome(bondEth float64, commission float64, stakingApr float64) float64 {
+	borrowed := fullDepositEth - bondEth
+	return (bondEth + commission*borrowed) * stakingApr
+}
+
+// Get the balances updates submitted by the Oracle DAO between the given blocks
+func getRethBalancesUpdates(rp *rocketpool.RocketPool, fromBlock uint64, toBlock uint64) ([]rethBalancesUpdate, error) {
+	contract, err := rp.GetContract("rocketNetworkBalances", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network balances contract: %w", err)
+	}
+	event, exists := contract.ABI.Events["BalancesUpdated"]
+	if !exists {
+		return nil, fmt.Errorf("the network balances contract doesn't have a BalancesUpdated event")
+	}
+
+	logs, err := logscan.NewScanner(rp.Client, 0).FilterLogs([]common.Address{*contract.Address}, [][]common.Hash{{event.ID}}, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rETH balance updates: %w", err)
+	}
+
+	updates := make([]rethBalancesUpdate, 0, len(logs))
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		if err := contract.ABI.UnpackIntoMap(values, event.Name, log.Data); err != nil {
+			return nil, fmt.Errorf("error decoding rETH balance update in block %d: %w", log.BlockNumber, err)
+		}
+		totalEth, ok1 := values["totalEth"].(*big.Int)
+		stakingEth, ok2 := values["stakingEth"].(*big.Int)
+		rethSupply, ok3 := values["rethSupply"].(*big.Int)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf("rETH balance update in block %d is missing some of its values", log.BlockNumber)
+		}
+		updates = append(updates, rethBalancesUpdate{
+			blockNumber: log.BlockNumber,
+			totalEth:    totalEth,
+			stakingEth:  stakingEth,
+			rethSupply:  rethSupply,
+		})
+	}
+	return updates, nil
+}
+
+// Get the ratio of two big integers as a float
+func getRatio(numerator *big.Int, denominator *big.Int) float64 {
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(numerator), new(big.Float).SetInt(denominator)).Float64()
+	return ratio
+}
@@ -0,0 +1,191 @@
+package rp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The result of checking a node's withdrawal addresses and its minipools' withdrawal credentials
+type WithdrawalSafety struct {
+	WithdrawalAddress        common.Address
+	PendingWithdrawalAddress common.Address
+	RplWithdrawalAddress     common.Address
+	HasRplWithdrawalAddress  bool
+	ProofMessage             string
+	ProofSigner              common.Address
+	HasProof                 bool
+	Problems                 []string
+}
+
+// Get an error describing every problem that was found, or nil if the checks passed
+func (s *WithdrawalSafety) Error() error {
+	if len(s.Problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("refusing to continue because the node's withdrawal addresses aren't what they should be:\n- %s\nIf these changes were intentional, update the expected withdrawal addresses in the Smartnode settings.", strings.Join(s.Problems, "\n- "))
+}
+
+// Get the message a node's withdrawal address signs to prove the node operator controls it
+func GetWithdrawalAddressProofMessage(nodeAddress common.Address) string {
+	return fmt.Sprintf("I control the withdrawal address of Rocket Pool node %s", nodeAddress.Hex())
+}
+
+// Get a node's RPL withdrawal address. Returns false if the node doesn't have one, either because it hasn't been set
+// or because the deployed contracts don't support it yet; its RPL goes to the primary withdrawal address in that case.
+func GetNodeRplWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (common.Address, bool, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", opts)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	if _, exists := rocketNodeManager.ABI.Methods["getNodeRPLWithdrawalAddressIsSet"]; !exists {
+		return common.Address{}, false, nil
+	}
+
+	isSet := new(bool)
+	if err := rocketNodeManager.Call(opts, isSet, "getNodeRPLWithdrawalAddressIsSet", nodeAddress); err != nil {
+		return common.Address{}, false, fmt.Errorf("Could not check if node %s has an RPL withdrawal address: %w", nodeAddress.Hex(), err)
+	}
+	if !*isSet {
+		return common.Address{}, false, nil
+	}
+	address := new(common.Address)
+	if err := rocketNodeManager.Call(opts, address, "getNodeRPLWithdrawalAddress", nodeAddress); err != nil {
+		return common.Address{}, false, fmt.Errorf("Could not get the RPL withdrawal address of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *address, true, nil
+}
+
+// Check that a node's withdrawal addresses are the ones in the config and, if a proof was provided, that the
+// withdrawal address signed it. If any minipools are provided, their validators' withdrawal credentials are also
+// checked against the credentials Rocket Pool assigned them.
+// Errors are only returned if the checks couldn't be run; the problems that were found are in the result.
+func CheckWithdrawalSafety(rp *rocketpool.RocketPool, bc beacon.Client, cfg *config.RocketPoolConfig, nodeAddress common.Address, minipoolAddresses []common.Address) (*WithdrawalSafety, error) {
+	safety := &WithdrawalSafety{
+		ProofMessage: GetWithdrawalAddressProofMessage(nodeAddress),
+		Problems:     []string{},
+	}
+
+	// Get the node's addresses
+	var err error
+	safety.WithdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's withdrawal address: %w", err)
+	}
+	safety.PendingWithdrawalAddress, err = storage.GetNodePendingWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's pending withdrawal address: %w", err)
+	}
+	safety.RplWithdrawalAddress, safety.HasRplWithdrawalAddress, err = GetNodeRplWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check them against the expected ones
+	zeroAddress := common.Address{}
+	expectedAddress := cfg.Smartnode.ExpectedWithdrawalAddress.Value.(string)
+	if expectedAddress != "" {
+		expected := common.HexToAddress(expectedAddress)
+		if safety.WithdrawalAddress != expected {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The node's withdrawal address is %s, but it should be %s.", safety.WithdrawalAddress.Hex(), expected.Hex()))
+		}
+		if safety.PendingWithdrawalAddress != zeroAddress && safety.PendingWithdrawalAddress != expected {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The node has a pending change of its withdrawal address to %s, but it should be %s.", safety.PendingWithdrawalAddress.Hex(), expected.Hex()))
+		}
+	}
+	expectedRplAddress := cfg.Smartnode.ExpectedRplWithdrawalAddress.Value.(string)
+	if expectedRplAddress != "" {
+		expected := common.HexToAddress(expectedRplAddress)
+		actual := safety.WithdrawalAddress
+		if safety.HasRplWithdrawalAddress {
+			actual = safety.RplWithdrawalAddress
+		}
+		if actual != expected {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The node's RPL is withdrawn to %s, but it should be %s.", actual.Hex(), expected.Hex()))
+		}
+	}
+
+	// Check that the withdrawal address signed the proof
+	proof := cfg.Smartnode.WithdrawalAddressProof.Value.(string)
+	if proof != "" {
+		safety.HasProof = true
+		safety.ProofSigner, err = recoverProofSigner(safety.ProofMessage, proof)
+		if err != nil {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The withdrawal address proof is invalid: %s.", err.Error()))
+		} else if safety.ProofSigner != safety.WithdrawalAddress {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The withdrawal address proof was signed by %s, not by the node's withdrawal address %s.", safety.ProofSigner.Hex(), safety.WithdrawalAddress.Hex()))
+		}
+	}
+
+	// Check the withdrawal credentials of the minipools' validators
+	if len(minipoolAddresses) == 0 {
+		return safety, nil
+	}
+	pubkeys := []types.ValidatorPubkey{}
+	expectedCredentials := map[types.ValidatorPubkey]common.Hash{}
+	minipoolsByPubkey := map[types.ValidatorPubkey]common.Address{}
+	for _, minipoolAddress := range minipoolAddresses {
+		pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the pubkey of minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+		if pubkey == (types.ValidatorPubkey{}) {
+			continue
+		}
+		credentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the withdrawal credentials of minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+		expectedCredentials[pubkey] = credentials
+		minipoolsByPubkey[pubkey] = minipoolAddress
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	for _, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		if status.WithdrawalCredentials != expectedCredentials[pubkey] {
+			safety.Problems = append(safety.Problems, fmt.Sprintf("The validator of minipool %s has withdrawal credentials %s instead of %s.", minipoolsByPubkey[pubkey].Hex(), status.WithdrawalCredentials.Hex(), expectedCredentials[pubkey].Hex()))
+		}
+	}
+
+	return safety, nil
+}
+
+// Recover the address that signed a proof message
+func recoverProofSigner(message string, proof string) (common.Address, error) {
+	signature, err := hexutil.Decode(proof)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("it isn't a hex string")
+	}
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("it is %d bytes long instead of %d", len(signature), crypto.SignatureLength)
+	}
+
+	// Wallets add 27 to the recovery ID, but recovery expects it to be 0 or 1
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
@@ -0,0 +1,88 @@
+package secret
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// What a secret is printed as, so it can't end up in a log or error message by accident
+const Redacted string = "[redacted]"
+
+// A buffer for a secret such as a password or wallet seed.
+// Where the OS allows it, the buffer lives outside of the Go heap in memory that's locked so it's never swapped to disk,
+// and it's wiped as soon as it's destroyed instead of whenever the garbage collector gets to it.
+type Buffer struct {
+	data   []byte
+	mapped bool
+	locked bool
+}
+
+// Create a new zeroed buffer of the given size
+func NewBuffer(size int) *Buffer {
+	data, mapped, locked := allocate(size)
+	return &Buffer{
+		data:   data,
+		mapped: mapped,
+		locked: locked,
+	}
+}
+
+// Move a secret into a new buffer, wiping the original
+func FromBytes(source []byte) *Buffer {
+	buffer := NewBuffer(len(source))
+	copy(buffer.data, source)
+	Wipe(source)
+	return buffer
+}
+
+// Get the secret. The slice must not be used after the buffer is destroyed.
+func (b *Buffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	return b.data
+}
+
+// Get the secret as a string without copying it out of the buffer, for APIs that only take strings.
+// The string must not be kept or used after the buffer is destroyed.
+func (b *Buffer) UnsafeString() string {
+	if len(b.data) == 0 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&b.data))
+}
+
+// Get the length of the secret
+func (b *Buffer) Len() int {
+	return len(b.data)
+}
+
+// Check if the buffer's memory is locked; if it isn't, the secret could be swapped to disk
+func (b *Buffer) IsLocked() bool {
+	return b.locked
+}
+
+// Keep the secret out of anything that prints the buffer
+func (b *Buffer) String() string {
+	return Redacted
+}
+
+// Wipe the secret and release the buffer's memory
+func (b *Buffer) Destroy() {
+	if b == nil || b.data == nil {
+		return
+	}
+	Wipe(b.data)
+	release(b.data, b.mapped, b.locked)
+	b.data = nil
+	b.mapped = false
+	b.locked = false
+}
+
+// Overwrite a secret with zeros
+func Wipe(data []byte) {
+	for i := range data {
+		data[i] = 0
+	}
+	runtime.KeepAlive(data)
+}
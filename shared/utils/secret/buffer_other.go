@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd)
+
+package secret
+
+// Memory can't be locked on this platform, so the buffer is kept on the heap and only wiped when it's destroyed
+func allocate(size int) ([]byte, bool, bool) {
+	return make([]byte, size), false, false
+}
+
+// Heap buffers are released by the garbage collector
+func release(data []byte, mapped bool, locked bool) {
+}
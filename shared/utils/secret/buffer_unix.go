@@ -0,0 +1,31 @@
+//go:build linux || darwin || freebsd
+
+package secret
+
+import (
+	"syscall"
+)
+
+// Map anonymous memory for the buffer and lock it, falling back to the heap if the memory can't be mapped.
+// Locking can fail if the process has hit its RLIMIT_MEMLOCK; the buffer still works then, it just isn't locked.
+func allocate(size int) ([]byte, bool, bool) {
+	if size == 0 {
+		return []byte{}, false, false
+	}
+	data, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return make([]byte, size), false, false
+	}
+	locked := syscall.Mlock(data) == nil
+	return data, true, locked
+}
+
+// Unlock and unmap the buffer's memory
+func release(data []byte, mapped bool, locked bool) {
+	if locked {
+		_ = syscall.Munlock(data)
+	}
+	if mapped {
+		_ = syscall.Munmap(data)
+	}
+}
@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The number of epochs a validator client with doppelganger detection enabled waits before it starts attesting
+const DoppelgangerDetectionEpochs uint64 = 2
+
+// The result of checking the Beacon Chain for validators that are attesting from somewhere other than the local validator client
+type DoppelgangerCheck struct {
+	// False if the local validator client may have been attesting during every recent epoch, so liveness can't be attributed to another machine
+	Checked bool
+
+	// The epochs whose liveness data was checked
+	Epochs []uint64
+
+	// The validators that were live during one of the checked epochs
+	LiveValidators []types.ValidatorPubkey
+}
+
+// Check if any of the given validators were seen attesting or proposing during a recent epoch in which the local
+// validator client couldn't have been doing so, because it was stopped or still waiting out its doppelganger detection.
+// Only the current and previous epochs can be checked, since Beacon Nodes don't keep liveness data for older ones.
+func CheckForDoppelgangers(cfg *config.RocketPoolConfig, bc beacon.Client, d *client.Client, pubkeys []types.ValidatorPubkey) (DoppelgangerCheck, error) {
+
+	result := DoppelgangerCheck{
+		Epochs:         []uint64{},
+		LiveValidators: []types.ValidatorPubkey{},
+	}
+
+	// Get the window in which the local validator client wasn't attesting
+	quietStart, quietEnd, isQuiet, err := getValidatorQuietWindow(cfg, bc, d)
+	if err != nil {
+		return result, err
+	}
+	if !isQuiet {
+		return result, nil
+	}
+
+	// Get the recent epochs that fall entirely within the window
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return result, fmt.Errorf("Error getting Beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return result, fmt.Errorf("Error getting Beacon head: %w", err)
+	}
+	candidates := []uint64{head.Epoch}
+	if head.Epoch > 0 {
+		candidates = []uint64{head.Epoch - 1, head.Epoch}
+	}
+	for _, epoch := range candidates {
+		epochStart := time.Unix(int64(eth2Config.GenesisTime+epoch*eth2Config.SecondsPerEpoch), 0)
+		epochEnd := epochStart.Add(time.Duration(eth2Config.SecondsPerEpoch) * time.Second)
+		if epochStart.Before(quietStart) {
+			continue
+		}
+		if !quietEnd.IsZero() && epochEnd.After(quietEnd) {
+			continue
+		}
+		result.Epochs = append(result.Epochs, epoch)
+	}
+	if len(result.Epochs) == 0 {
+		return result, nil
+	}
+	result.Checked = true
+	if len(pubkeys) == 0 {
+		return result, nil
+	}
+
+	// Get the indices of the validators on the Beacon Chain
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return result, fmt.Errorf("Error getting validator statuses: %w", err)
+	}
+	indices := []uint64{}
+	pubkeysByIndex := map[uint64]types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		indices = append(indices, status.Index)
+		pubkeysByIndex[status.Index] = pubkey
+	}
+	if len(indices) == 0 {
+		return result, nil
+	}
+
+	// Check their liveness
+	live := map[uint64]bool{}
+	for _, epoch := range result.Epochs {
+		liveness, err := bc.GetValidatorLiveness(indices, epoch)
+		if err != nil {
+			return result, fmt.Errorf("Error getting validator liveness for epoch %d: %w", epoch, err)
+		}
+		for index, isLive := range liveness {
+			if isLive {
+				live[index] = true
+			}
+		}
+	}
+	for _, index := range indices {
+		if live[index] {
+			result.LiveValidators = append(result.LiveValidators, pubkeysByIndex[index])
+		}
+	}
+
+	return result, nil
+
+}
+
+// Get the window in which the local validator client hasn't been attesting.
+// If it's stopped, the window starts when it stopped and has no end; if it was just started with doppelganger
+// detection enabled, the window covers its detection period. Otherwise, there is no such window.
+func getValidatorQuietWindow(cfg *config.RocketPoolConfig, bc beacon.Client, d *client.Client) (time.Time, time.Time, bool, error) {
+
+	if cfg.IsNativeMode {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	// Get validator container name
+	if cfg.Smartnode.ProjectName.Value == "" {
+		return time.Time{}, time.Time{}, false, errors.New("Rocket Pool docker project name not set")
+	}
+	containerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+
+	// Get the container's state; if it doesn't exist, it can't have been attesting
+	details, err := d.ContainerInspect(context.Background(), containerName)
+	if client.IsErrNotFound(err) {
+		return time.Time{}, time.Time{}, true, nil
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("Could not get validator container details: %w", err)
+	}
+	if details.State == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	// Stopped containers have been quiet since they finished
+	if !details.State.Running {
+		finishedAt, err := time.Parse(time.RFC3339Nano, details.State.FinishedAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("Could not parse validator container stop time [%s]: %w", details.State.FinishedAt, err)
+		}
+		return finishedAt, time.Time{}, true, nil
+	}
+
+	// Running containers are only quiet while doppelganger detection is active
+	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	if !doppelgangerEnabled {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, details.State.StartedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("Could not parse validator container start time [%s]: %w", details.State.StartedAt, err)
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("Error getting Beacon config: %w", err)
+	}
+	detectionEnd := startedAt.Add(time.Duration(DoppelgangerDetectionEpochs*eth2Config.SecondsPerEpoch) * time.Second)
+	if time.Now().After(detectionEnd) {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	return startedAt, detectionEnd, true, nil
+
+}
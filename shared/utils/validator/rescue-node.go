@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The environment variables that hold the Beacon Node endpoints the validator client connects to
+var validatorEndpointEnvVars = []string{
+	"CC_API_ENDPOINT",
+	"CC_RPC_ENDPOINT",
+}
+
+// Recreate the validator container so it connects to the Beacon Node endpoints in the given environment.
+// The endpoints are only read when the container is created, so restarting it isn't enough to switch Beacon Nodes.
+// The rest of the container's settings are carried over as-is.
+func ReconnectValidator(cfg *config.RocketPoolConfig, log *log.ColorLogger, d *client.Client, envVars map[string]string) error {
+
+	if cfg.IsNativeMode {
+		return errors.New("The validator can't be reconnected in Native mode; please update its Beacon Node endpoint and restart it manually")
+	}
+
+	// Get validator container name
+	if cfg.Smartnode.ProjectName.Value == "" {
+		return errors.New("Rocket Pool docker project name not set")
+	}
+	containerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+
+	// Log
+	if log != nil {
+		log.Printlnf("Recreating validator container (%s)...", containerName)
+	}
+
+	// Get the validator container's current settings
+	details, err := d.ContainerInspect(context.Background(), containerName)
+	if err != nil {
+		return fmt.Errorf("Could not get validator container details: %w", err)
+	}
+	containerConfig := details.Config
+	containerConfig.Env = replaceEnvVars(containerConfig.Env, envVars)
+	endpoints := map[string]*network.EndpointSettings{}
+	if details.NetworkSettings != nil {
+		for name, settings := range details.NetworkSettings.Networks {
+			endpoints[name] = &network.EndpointSettings{
+				Aliases: settings.Aliases,
+				Links:   settings.Links,
+			}
+		}
+	}
+
+	// Replace the container
+	timeout := int(validatorRestartTimeout.Seconds())
+	if err := d.ContainerStop(context.Background(), details.ID, container.StopOptions{Timeout: &timeout}); err != nil {
+		return fmt.Errorf("Could not stop validator container: %w", err)
+	}
+	if err := d.ContainerRemove(context.Background(), details.ID, types.ContainerRemoveOptions{}); err != nil {
+		return fmt.Errorf("Could not remove validator container: %w", err)
+	}
+	created, err := d.ContainerCreate(context.Background(), containerConfig, details.HostConfig, &network.NetworkingConfig{EndpointsConfig: endpoints}, nil, strings.TrimPrefix(details.Name, "/"))
+	if err != nil {
+		return fmt.Errorf("Could not create validator container: %w", err)
+	}
+	if err := d.ContainerStart(context.Background(), created.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("Could not start validator container: %w", err)
+	}
+
+	// Log & return
+	if log != nil {
+		log.Println("Successfully recreated validator")
+	}
+	return nil
+
+}
+
+// Replace the values of the Beacon Node endpoint variables in a container's environment
+func replaceEnvVars(env []string, envVars map[string]string) []string {
+	updated := make([]string, 0, len(env))
+	for _, entry := range env {
+		name, _, _ := strings.Cut(entry, "=")
+		replaced := false
+		for _, endpointVar := range validatorEndpointEnvVars {
+			if name != endpointVar {
+				continue
+			}
+			if value, exists := envVars[endpointVar]; exists {
+				updated = append(updated, fmt.Sprintf("%s=%s", name, value))
+				replaced = true
+			}
+			break
+		}
+		if !replaced {
+			updated = append(updated, entry)
+		}
+	}
+	return updated
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+)
+
+// Take validators' keys out of the local keystores before they're moved to Web3Signer, so the Validator client can't
+// sign with them alongside the signer. The Validator client removes the keys it has loaded and exports their slashing
+// protection history, which is saved so it's imported into Web3Signer with the keys. The keystores the other clients
+// would load are deleted outright; the selected client's folder also holds its own data, so only its keys are removed.
+func DisableLocalKeystores(cfg *config.RocketPoolConfig, localKeystores map[string]keystore.Keystore, pubkeys []types.ValidatorPubkey) error {
+
+	// Remove the keys from the Validator client and save their history
+	vc := keymanager.NewClient(cfg.Smartnode.KeymanagerApiUrl.Value.(string), cfg.Smartnode.GetKeymanagerApiTokenPath())
+	slashingProtection, err := vc.DeleteKeystores(pubkeys)
+	if err != nil {
+		return fmt.Errorf("error exporting the slashing protection history from the Validator client (it must be running with its keymanager API enabled): %w", err)
+	}
+	if err := web3signer.SaveSlashingProtection(os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPath()), slashingProtection); err != nil {
+		return err
+	}
+
+	// Delete the keystores of the clients that aren't running
+	selectedClient, _ := cfg.GetSelectedConsensusClient()
+	for name, ks := range localKeystores {
+		if name == string(selectedClient) {
+			continue
+		}
+		if err := os.RemoveAll(ks.GetKeystoreDir()); err != nil {
+			return fmt.Errorf("error deleting the %s validator keystores: %w", name, err)
+		}
+	}
+	return nil
+
+}
@@ -80,7 +80,8 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		for _, validatorKey := range keys {
 			_, exists := pubkeyMap[validatorKey.PublicKey]
 			if exists {
-				// Found one!
+				// Found one! Keys that have been split into a DVT cluster only update the wallet's account index;
+				// the wallet keeps them out of the Validator Client so the cluster is the only thing signing with them.
 				delete(pubkeyMap, validatorKey.PublicKey)
 				if !testOnly {
 					err := w.SaveValidatorKey(validatorKey)
@@ -195,8 +196,8 @@ func CheckForAndRecoverCustomMinipoolKeys(cfg *config.RocketPoolConfig, pubkeyMa
 					return nil, fmt.Errorf("private keystore file %s claims to be for validator %s but it's for validator %s", file.Name(), keystore.Pubkey.Hex(), reconstructedPubkey.Hex())
 				}
 
-				// Store the key
-				if !testOnly {
+				// Store the key, unless it's been split into a DVT cluster that runs it instead of the Validator Client
+				if !testOnly && !w.IsExternalValidator(reconstructedPubkey) {
 					err = w.StoreValidatorKey(privateKey, keystore.Path)
 					if err != nil {
 						return nil, fmt.Errorf("error storing private keystore for %s: %w", reconstructedPubkey.Hex(), err)
@@ -0,0 +1,237 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	maxSheetNameLength int    = 31
+	timeFormat         string = "2006-01-02 15:04:05"
+)
+
+// A minimal Office Open XML workbook, with one plain table per sheet and a bold header row
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// A single sheet in a workbook
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]interface{}
+}
+
+// Create a new, empty workbook
+func NewWorkbook() *Workbook {
+	return &Workbook{
+		sheets: []*Sheet{},
+	}
+}
+
+// Add a sheet to the workbook
+func (wb *Workbook) AddSheet(name string, header []string, rows [][]interface{}) error {
+	if name == "" || len(name) > maxSheetNameLength || strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("invalid sheet name [%s]", name)
+	}
+	for _, sheet := range wb.sheets {
+		if strings.EqualFold(sheet.Name, name) {
+			return fmt.Errorf("duplicate sheet name [%s]", name)
+		}
+	}
+	wb.sheets = append(wb.sheets, &Sheet{
+		Name:   name,
+		Header: header,
+		Rows:   rows,
+	})
+	return nil
+}
+
+// Get the sheets in the workbook
+func (wb *Workbook) Sheets() []*Sheet {
+	return wb.sheets
+}
+
+// Write the workbook in .xlsx format
+func (wb *Workbook) Write(writer io.Writer) error {
+
+	if len(wb.sheets) == 0 {
+		return fmt.Errorf("the workbook doesn't have any sheets")
+	}
+
+	files := map[string]string{
+		"[Content_Types].xml":        wb.getContentTypes(),
+		"_rels/.rels":                packageRels,
+		"xl/workbook.xml":            wb.getWorkbook(),
+		"xl/_rels/workbook.xml.rels": wb.getWorkbookRels(),
+		"xl/styles.xml":              styles,
+	}
+	for i, sheet := range wb.sheets {
+		files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = sheet.getXml()
+	}
+
+	// Write the parts in a stable order, with the content types first
+	order := []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"}
+	for i := range wb.sheets {
+		order = append(order, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+	}
+
+	zipWriter := zip.NewWriter(writer)
+	for _, name := range order {
+		fileWriter, err := zipWriter.Create(name)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", name, err)
+		}
+		_, err = io.WriteString(fileWriter, files[name])
+		if err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+	return zipWriter.Close()
+
+}
+
+// Convert a cell value to the string used for CSV output and for text cells
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(timeFormat)
+	case *big.Int:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (wb *Workbook) getContentTypes() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range wb.sheets {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+func (wb *Workbook) getWorkbook() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range wb.sheets {
+		fmt.Fprintf(&sb, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+	return sb.String()
+}
+
+func (wb *Workbook) getWorkbookRels() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range wb.sheets {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(wb.sheets)+1)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+func (sheet *Sheet) getXml() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	// Header row, using the bold style
+	sb.WriteString(`<row r="1">`)
+	for col, title := range sheet.Header {
+		fmt.Fprintf(&sb, `<c r="%s1" t="inlineStr" s="1"><is><t>%s</t></is></c>`, getColumnName(col), escape(title))
+	}
+	sb.WriteString(`</row>`)
+
+	// Data rows
+	for i, row := range sheet.Rows {
+		rowNumber := i + 2
+		fmt.Fprintf(&sb, `<row r="%d">`, rowNumber)
+		for col, value := range row {
+			ref := fmt.Sprintf("%s%d", getColumnName(col), rowNumber)
+			switch v := value.(type) {
+			case int, int64, uint64:
+				fmt.Fprintf(&sb, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case float64:
+				fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+			case bool:
+				boolValue := 0
+				if v {
+					boolValue = 1
+				}
+				fmt.Fprintf(&sb, `<c r="%s" t="b"><v>%d</v></c>`, ref, boolValue)
+			default:
+				text := FormatValue(value)
+				if text == "" {
+					continue
+				}
+				fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escape(text))
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// Get the spreadsheet column name for a zero-based column index (A, B, ..., Z, AA, AB, ...)
+func getColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// Escape a string for use in XML text or attributes
+func escape(text string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
+
+const packageRels string = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const styles string = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`